@@ -10,16 +10,34 @@ import (
 	"time"
 
 	"lalan-be/internal/config"
+	adminblocklist "lalan-be/internal/features/admin/blocklist"
 	admincategory "lalan-be/internal/features/admin/category"
 	adminidentity "lalan-be/internal/features/admin/identity"
+	adminsettlement "lalan-be/internal/features/admin/settlement"
+	adminstorage "lalan-be/internal/features/admin/storage"
 	auth "lalan-be/internal/features/auth"
 	booking "lalan-be/internal/features/customer/booking"
 	custidentity "lalan-be/internal/features/customer/identity"
+	hosteramendment "lalan-be/internal/features/hoster/amendment"
+	hosteranalytics "lalan-be/internal/features/hoster/analytics"
+	hosterblocklist "lalan-be/internal/features/hoster/blocklist"
 	hosterbooking "lalan-be/internal/features/hoster/booking"
+	hosterbundle "lalan-be/internal/features/hoster/bundle"
+	hostercalendar "lalan-be/internal/features/hoster/calendar"
+	hosterhandover "lalan-be/internal/features/hoster/handover"
+	hosteridentity "lalan-be/internal/features/hoster/identity"
 	hosteritem "lalan-be/internal/features/hoster/item"
+	hosterlatefee "lalan-be/internal/features/hoster/latefee"
+	hosterledger "lalan-be/internal/features/hoster/ledger"
 	hosterprofile "lalan-be/internal/features/hoster/profile"
+	hosterschedule "lalan-be/internal/features/hoster/schedule"
+	hosterstore "lalan-be/internal/features/hoster/store"
+	hosterteam "lalan-be/internal/features/hoster/team"
 	hostertnc "lalan-be/internal/features/hoster/tnc"
+	hosterunit "lalan-be/internal/features/hoster/unit"
+	hostervariant "lalan-be/internal/features/hoster/variant"
 	public "lalan-be/internal/features/public"
+	upload "lalan-be/internal/features/upload"
 	"lalan-be/internal/middleware"
 	"lalan-be/internal/utils"
 
@@ -56,18 +74,48 @@ func main() {
 	// Public & Auth
 	pubHandler := public.NewPublicHandler(public.NewPublicService(public.NewPublicRepository(dbCfg.DB)))
 	authHandler := auth.NewAuthHandler(auth.NewAuthService(auth.NewAuthRepository(dbCfg.DB)))
+	uploadHandler := upload.NewUploadHandler(upload.NewUploadService(upload.NewUploadRepository(dbCfg.DB), storage, cfg))
 
 	// Customer
-	bookingHandler := booking.NewBookingHandler(booking.NewBookingService(booking.NewBookingRepository(dbCfg.DB)))
+	hosterHandoverRepo := hosterhandover.NewHandoverRepository(dbCfg.DB)    // Handover dipakai bersama hoster (isi) & customer (lihat + konfirmasi)
+	hosterAmendmentRepo := hosteramendment.NewAmendmentRepository(dbCfg.DB) // Perubahan booking dipakai bersama customer (ajukan) & hoster (putuskan)
+	bookingHandler := booking.NewBookingHandler(booking.NewBookingService(booking.NewBookingRepository(dbCfg.DB), hosterHandoverRepo, hosterAmendmentRepo))
 	customerIdentityHandler := custidentity.NewIdentityHandler(
 		custidentity.NewIdentityService(custidentity.NewIdentityRepository(dbCfg.DB), storage, cfg),
 	)
 
 	// Hoster
-	hosterHandler := hosterbooking.NewHosterBookingHandler(hosterbooking.NewBookingService(hosterbooking.NewHosterBookingRepository(dbCfg.DB)))
+	hosterLedgerRepo := hosterledger.NewHosterLedgerRepository(dbCfg.DB) // Posting ledger dipakai bersama update status booking & sync admin
+	hosterLedgerHandler := hosterledger.NewHosterLedgerHandler(hosterledger.NewHosterLedgerService(hosterLedgerRepo))
+	hosterLateFeeRepo := hosterlatefee.NewLateFeeRepository(dbCfg.DB) // Akrual denda dipakai bersama detektor & update status booking (completed)
+	hosterLateFeeService := hosterlatefee.NewLateFeeService(hosterLateFeeRepo)
+	hosterLateFeeHandler := hosterlatefee.NewHosterLateFeeHandler(hosterLateFeeService)
+	hosterBlocklistHandler := hosterblocklist.NewHosterBlocklistHandler(hosterblocklist.NewBlocklistService(hosterblocklist.NewBlocklistRepository(dbCfg.DB)))
+	hosterAmendmentHandler := hosteramendment.NewHosterAmendmentHandler(hosteramendment.NewAmendmentService(hosterAmendmentRepo, hosterLedgerRepo))
+	hosterHandler := hosterbooking.NewHosterBookingHandler(
+		hosterbooking.NewBookingService(hosterbooking.NewHosterBookingRepository(dbCfg.DB), hosterLedgerRepo, hosterLateFeeRepo),
+	)
 	hosterItemHandler := hosteritem.NewHosterItemHandler(hosteritem.NewItemService(hosteritem.NewHosterItemRepository(dbCfg.DB), storage, cfg))
 	hosterTnCHandler := hostertnc.NewHosterTnCHandler(hostertnc.NewTnCService(hostertnc.NewTnCRepository(dbCfg.DB)))
-	hosterProfileHandler := hosterprofile.NewHosterProfileHandler(hosterprofile.NewHosterProfileService(hosterprofile.NewHosterProfileRepository(dbCfg.DB)))
+	hosterUnitHandler := hosterunit.NewHosterUnitHandler(hosterunit.NewUnitService(hosterunit.NewUnitRepository(dbCfg.DB)))
+	hosterVariantHandler := hostervariant.NewHosterVariantHandler(hostervariant.NewVariantService(hostervariant.NewVariantRepository(dbCfg.DB)))
+	hosterBundleHandler := hosterbundle.NewHosterBundleHandler(hosterbundle.NewBundleService(hosterbundle.NewBundleRepository(dbCfg.DB)))
+	hosterHandoverHandler := hosterhandover.NewHosterHandoverHandler(hosterhandover.NewHandoverService(hosterHandoverRepo, storage, cfg))
+	hosterProfileHandler := hosterprofile.NewHosterProfileHandler(hosterprofile.NewHosterProfileService(hosterprofile.NewHosterProfileRepository(dbCfg.DB), storage, cfg))
+	hosterAnalyticsHandler := hosteranalytics.NewHosterAnalyticsHandler(
+		hosteranalytics.NewHosterAnalyticsService(hosteranalytics.NewHosterAnalyticsRepository(dbCfg.DB)),
+	)
+	hosterCalendarHandler := hostercalendar.NewHosterCalendarHandler(
+		hostercalendar.NewHosterCalendarService(hostercalendar.NewHosterCalendarRepository(dbCfg.DB)),
+	)
+	hosterStoreHandler := hosterstore.NewHosterStoreHandler(hosterstore.NewHosterStoreService(hosterstore.NewHosterStoreRepository(dbCfg.DB)))
+	hosterScheduleHandler := hosterschedule.NewHosterScheduleHandler(hosterschedule.NewHosterScheduleService(hosterschedule.NewHosterScheduleRepository(dbCfg.DB)))
+	hosterTeamRepo := hosterteam.NewHosterTeamRepository(dbCfg.DB)
+	hosterTeamHandler := hosterteam.NewHosterTeamHandler(hosterteam.NewHosterTeamService(hosterTeamRepo))
+	middleware.SetHosterMemberResolver(hosterTeamRepo.ResolveMember) // Staff toko dicek ulang (aktif & role terbaru) di setiap request
+	hosterIdentityHandler := hosteridentity.NewHosterIdentityHandler(
+		hosteridentity.NewHosterIdentityService(hosteridentity.NewHosterIdentityRepository(dbCfg.DB), storage, cfg),
+	)
 
 	// Admin
 	adminIdentityHandler := adminidentity.NewAdminIdentityHandler(
@@ -76,6 +124,15 @@ func main() {
 	adminCategoryHandler := admincategory.NewCategoryHandler(
 		admincategory.NewCategoryService(admincategory.NewCategoryRepository(dbCfg.DB)),
 	)
+	adminStorageHandler := adminstorage.NewStorageGCHandler(
+		adminstorage.NewStorageGCService(adminstorage.NewStorageGCRepository(dbCfg.DB), storage, cfg),
+	)
+	adminBlocklistHandler := adminblocklist.NewBlocklistHandler(
+		adminblocklist.NewBlocklistService(adminblocklist.NewBlocklistRepository(dbCfg.DB)),
+	)
+	adminSettlementHandler := adminsettlement.NewSettlementHandler(
+		adminsettlement.NewSettlementService(adminsettlement.NewSettlementRepository(dbCfg.DB), hosterLedgerRepo),
+	)
 
 	// 6. Setup router & routes
 	router := mux.NewRouter()
@@ -85,6 +142,7 @@ func main() {
 	// Public & Auth
 	public.SetupPublicRoutes(router, pubHandler)
 	auth.SetupAuthRoutes(router, authHandler)
+	upload.SetupUploadRoutes(router, uploadHandler)
 
 	// Customer
 	booking.SetupBookingRoutes(router, bookingHandler)
@@ -94,11 +152,28 @@ func main() {
 	hosterbooking.SetupBookingRoutes(router, hosterHandler)
 	hosteritem.SetupItemRoutes(router, hosterItemHandler)
 	hostertnc.SetupTnCRoutes(router, hosterTnCHandler)
+	hosterunit.SetupUnitRoutes(router, hosterUnitHandler)
+	hostervariant.SetupVariantRoutes(router, hosterVariantHandler)
+	hosterbundle.SetupBundleRoutes(router, hosterBundleHandler)
+	hosterhandover.SetupHandoverRoutes(router, hosterHandoverHandler)
 	hosterprofile.SetupProfileRoutes(router, hosterProfileHandler)
+	hosteridentity.SetupIdentityRoutes(router, hosterIdentityHandler)
+	hosteranalytics.SetupAnalyticsRoutes(router, hosterAnalyticsHandler)
+	hostercalendar.SetupCalendarRoutes(router, hosterCalendarHandler)
+	hosterteam.SetupTeamRoutes(router, hosterTeamHandler)
+	hosterstore.SetupStoreRoutes(router, hosterStoreHandler)
+	hosterledger.SetupLedgerRoutes(router, hosterLedgerHandler)
+	hosterlatefee.SetupLateFeeRoutes(router, hosterLateFeeHandler)
+	hosteramendment.SetupAmendmentRoutes(router, hosterAmendmentHandler)
+	hosterblocklist.SetupBlocklistRoutes(router, hosterBlocklistHandler)
+	hosterschedule.SetupScheduleRoutes(router, hosterScheduleHandler)
 
 	// Admin
 	adminidentity.SetupAdminIdentityRoutes(router, adminIdentityHandler)
 	admincategory.SetupCategoryRoutes(router, adminCategoryHandler)
+	adminstorage.SetupStorageRoutes(router, adminStorageHandler)
+	adminsettlement.SetupSettlementRoutes(router, adminSettlementHandler)
+	adminblocklist.SetupBlocklistRoutes(router, adminBlocklistHandler)
 
 	// 7. Konfigurasi HTTP server dengan timeout aman
 	srv := &http.Server{
@@ -117,11 +192,17 @@ func main() {
 		}
 	}()
 
+	// Detektor booking terlambat (akrual denda ke outstanding) berjalan sampai server shutdown
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go hosterLateFeeService.RunOverdueDetector(jobCtx, config.GetLateFeeScanInterval())
+
 	// 9. Tunggu sinyal shutdown (Ctrl+C / SIGTERM)
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopJobs()
 
 	// 10. Graceful shutdown dengan timeout 10 detik
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
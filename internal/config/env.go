@@ -1,8 +1,12 @@
 package config
 
 import (
+	"crypto/sha256"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -75,6 +79,126 @@ func GetJWTSecret() []byte {
 	return []byte(secret)
 }
 
+/*
+GetNIKEncryptionKey mengembalikan key AES-256 (32 byte) untuk enkripsi NIK / nomor dokumen identitas.
+
+Alur kerja:
+1. Baca NIK_ENCRYPTION_KEY dari env (wajib di production, minimal 32 karakter)
+2. Derive ke 32 byte dengan SHA-256 agar panjang key selalu valid untuk AES-256
+
+Output sukses:
+- []byte key 32 byte
+Output error:
+- log.Fatal → aplikasi berhenti (hanya di production jika tidak valid)
+*/
+func GetNIKEncryptionKey() []byte {
+	return getDerivedKey("NIK_ENCRYPTION_KEY", "dev-nik-encryption-key-1234567890")
+}
+
+/*
+GetNIKBlindIndexKey mengembalikan key HMAC untuk blind index NIK / nomor dokumen identitas.
+Key HARUS berbeda dari key enkripsi agar index tidak bisa dipakai untuk membuka data.
+
+Output sukses:
+- []byte key 32 byte
+Output error:
+- log.Fatal → aplikasi berhenti (hanya di production jika tidak valid)
+*/
+func GetNIKBlindIndexKey() []byte {
+	return getDerivedKey("NIK_BLIND_INDEX_KEY", "dev-nik-blind-index-key-123456789")
+}
+
+/*
+GetPickupCodeKey mengembalikan key HMAC untuk tanda tangan kode pickup (QR) booking.
+Mengganti key membuat semua kode pickup yang sudah dibagikan tidak berlaku.
+
+Output sukses:
+- []byte key 32 byte
+Output error:
+- log.Fatal → aplikasi berhenti (hanya di production jika tidak valid)
+*/
+func GetPickupCodeKey() []byte {
+	return getDerivedKey("PICKUP_CODE_SECRET", "dev-pickup-code-secret-1234567890")
+}
+
+/*
+GetUnverifiedHosterMaxActiveItems mengembalikan batas listing aktif (item + paket dengan is_hidden = false) untuk hoster yang belum terverifikasi.
+Dibaca dari UNVERIFIED_HOSTER_MAX_ACTIVE_ITEMS, nilai kosong/tidak valid → default 3.
+
+Output:
+- int batas item aktif (>= 0)
+*/
+func GetUnverifiedHosterMaxActiveItems() int {
+	limit, err := strconv.Atoi(GetEnv("UNVERIFIED_HOSTER_MAX_ACTIVE_ITEMS", "3"))
+	if err != nil || limit < 0 {
+		log.Printf("WARNING: invalid UNVERIFIED_HOSTER_MAX_ACTIVE_ITEMS, using default 3")
+		return 3
+	}
+	return limit
+}
+
+/*
+GetPlatformCommissionBps mengembalikan tarif komisi platform dalam basis point (1000 = 10%).
+Dibaca dari PLATFORM_COMMISSION_BPS, nilai kosong/tidak valid (di luar 0-10000) → default 1000.
+Tarif disimpan di setiap transaksi ledger, sehingga perubahan tarif tidak mengubah transaksi lama.
+
+Output:
+- int tarif komisi (0-10000)
+*/
+func GetPlatformCommissionBps() int {
+	bps, err := strconv.Atoi(GetEnv("PLATFORM_COMMISSION_BPS", "1000"))
+	if err != nil || bps < 0 || bps > 10000 {
+		log.Printf("WARNING: invalid PLATFORM_COMMISSION_BPS, using default 1000")
+		return 1000
+	}
+	return bps
+}
+
+/*
+GetLateFeeScanInterval mengembalikan jarak antar putaran detektor booking terlambat.
+Dibaca dari LATE_FEE_SCAN_INTERVAL_MINUTES, nilai kosong/tidak valid (< 1) → default 60 menit.
+
+Output:
+- time.Duration interval detektor (>= 1 menit)
+*/
+func GetLateFeeScanInterval() time.Duration {
+	minutes, err := strconv.Atoi(GetEnv("LATE_FEE_SCAN_INTERVAL_MINUTES", "60"))
+	if err != nil || minutes < 1 {
+		log.Printf("WARNING: invalid LATE_FEE_SCAN_INTERVAL_MINUTES, using default 60")
+		return 60 * time.Minute
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+/*
+GetPublicAPIURL mengembalikan base URL publik API (tanpa trailing slash), contoh https://api.lalan.id.
+Dipakai untuk membuat link yang dibuka di luar aplikasi (contoh: feed iCal hoster).
+Kosong → handler memakai host dari request.
+*/
+func GetPublicAPIURL() string {
+	return strings.TrimRight(GetEnv("PUBLIC_API_URL", ""), "/")
+}
+
+/*
+getDerivedKey membaca secret dari env dengan aturan yang sama seperti GetJWTSecret,
+lalu menurunkannya menjadi 32 byte via SHA-256.
+*/
+func getDerivedKey(name, devDefault string) []byte {
+	secret := os.Getenv(name)
+
+	if os.Getenv("APP_ENV") == "production" {
+		if len(secret) < 32 {
+			log.Fatalf("FATAL: %s must be set with at least 32 characters in production", name)
+		}
+	} else if secret == "" {
+		log.Printf("WARNING: %s not set, using development default (NOT FOR PRODUCTION!)", name)
+		secret = devDefault
+	}
+
+	key := sha256.Sum256([]byte(secret))
+	return key[:]
+}
+
 /*
 LoadEnv memuat file .env.dev secara otomatis jika bukan mode production.
 
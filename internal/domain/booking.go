@@ -30,24 +30,31 @@ import "time"
 // - Booking has one BookingCustomer (snapshot data customer saat booking)
 // - Booking may have one Identity (KTP yang dipakai untuk verifikasi)
 type Booking struct {
-	ID                   string    `json:"id" db:"id"`
-	HosterID             string    `json:"hoster_id" db:"hoster_id"`         // Hoster pemilik item (diambil dari item pertama)
-	LockedUntil          time.Time `json:"locked_until" db:"locked_until"`   // Waktu kadaluarsa pembayaran (30 menit dari create)
-	TimeRemainingMinutes int       `json:"time_remaining_minutes" db:"-"`    // Sisa waktu dalam menit (dihitung runtime, tidak disimpan)
-	StartDate            time.Time `json:"start_date" db:"start_date"`       // Tanggal mulai sewa
-	EndDate              time.Time `json:"end_date" db:"end_date"`           // Tanggal selesai sewa
-	TotalDays            int       `json:"total_days" db:"total_days"`       // Durasi sewa dalam hari
-	DeliveryType         string    `json:"delivery_type" db:"delivery_type"` // "self_pickup" (ambil sendiri) atau "delivery" (antar ke alamat)
-	Rental               int       `json:"rental" db:"rental"`               // Total biaya sewa (sum dari semua item)
-	Deposit              int       `json:"deposit" db:"deposit"`             // Total deposit (sum dari semua item)
-	Discount             int       `json:"discount" db:"discount"`           // Diskon (jika ada)
-	Total                int       `json:"total" db:"total"`                 // rental + deposit - discount
-	Outstanding          int       `json:"outstanding" db:"outstanding"`     // Sisa yang harus dibayar (awalnya sama dengan total)
-	UserID               string    `json:"user_id" db:"user_id"`             // ID customer yang booking
-	IdentityID           *string   `json:"identity_id" db:"identity_id"`     // ID KTP yang dipakai (nullable, diisi jika sudah verified)
-	Status               string    `json:"status" db:"status"`               // Status booking (lihat keterangan di atas)
-	CreatedAt            time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`
+	ID                   string     `json:"id" db:"id"`
+	HosterID             string     `json:"hoster_id" db:"hoster_id"`                     // Hoster pemilik item (diambil dari item pertama)
+	TenantID             *string    `json:"store_id" db:"tenant_id"`                      // Store tempat semua item booking berada (NULL jika store sudah dihapus)
+	LockedUntil          time.Time  `json:"locked_until" db:"locked_until"`               // Waktu kadaluarsa pembayaran (30 menit dari create)
+	TimeRemainingMinutes int        `json:"time_remaining_minutes" db:"-"`                // Sisa waktu dalam menit (dihitung runtime, tidak disimpan)
+	StartDate            time.Time  `json:"start_date" db:"start_date"`                   // Tanggal mulai sewa
+	EndDate              time.Time  `json:"end_date" db:"end_date"`                       // Tanggal selesai sewa
+	TotalDays            int        `json:"total_days" db:"total_days"`                   // Durasi sewa dalam hari
+	DeliveryType         string     `json:"delivery_type" db:"delivery_type"`             // "self_pickup" (ambil sendiri) atau "delivery" (antar ke alamat)
+	Rental               int        `json:"rental" db:"rental"`                           // Total biaya sewa (sum dari semua item)
+	Deposit              int        `json:"deposit" db:"deposit"`                         // Total deposit (sum dari semua item)
+	Discount             int        `json:"discount" db:"discount"`                       // Diskon (jika ada)
+	Total                int        `json:"total" db:"total"`                             // rental + deposit - discount
+	Outstanding          int        `json:"outstanding" db:"outstanding"`                 // Sisa yang harus dibayar (awalnya sama dengan total)
+	UserID               string     `json:"user_id" db:"user_id"`                         // ID customer yang booking
+	IdentityID           *string    `json:"identity_id" db:"identity_id"`                 // ID KTP yang dipakai (nullable, diisi jika sudah verified)
+	Status               string     `json:"status" db:"status"`                           // Status booking (lihat keterangan di atas)
+	PickupCodeNonce      string     `json:"-" db:"pickup_code_nonce"`                     // Nonce kode pickup (QR), lihat utils.SignPickupCode
+	PickupCodeUsedAt     *time.Time `json:"pickup_code_used_at" db:"pickup_code_used_at"` // Waktu kode pickup dipindai hoster (sekali pakai)
+	LateFee              int        `json:"late_fee" db:"late_fee"`                       // Total denda keterlambatan yang sudah masuk ke outstanding
+	OverdueAt            *time.Time `json:"overdue_at" db:"overdue_at"`                   // Waktu booking ditandai terlambat (on_rent melewati end_date)
+	PickupTime           *string    `json:"pickup_time" db:"pickup_time"`                 // Slot pickup (HH:MM, jam lokal store) di start_date
+	ReturnTime           *string    `json:"return_time" db:"return_time"`                 // Slot pengembalian (HH:MM, jam lokal store) di end_date
+	CreatedAt            time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // ===================================================================
@@ -66,15 +73,20 @@ type Booking struct {
 type BookingItem struct {
 	ID              string   `json:"id" db:"id"`
 	BookingID       string   `json:"booking_id" db:"booking_id"`
-	ItemID          string   `json:"item_id" db:"item_id"`                   // ID item asli (untuk tracking)
-	Name            string   `json:"name" db:"name"`                         // Snapshot nama item saat booking dibuat
-	Description     string   `json:"description" db:"description"`           // Enriched dari item table (bukan snapshot)
-	Photos          []string `json:"photos" db:"photos"`                     // Enriched dari item table (bukan snapshot)
-	Quantity        int      `json:"quantity" db:"quantity"`                 // Jumlah unit yang disewa
-	PricePerDay     int      `json:"price_per_day" db:"price_per_day"`       // Snapshot harga per hari
-	DepositPerUnit  int      `json:"deposit_per_unit" db:"deposit_per_unit"` // Snapshot deposit per unit
-	SubtotalRental  int      `json:"subtotal_rental" db:"subtotal_rental"`   // quantity × price_per_day × total_days
-	SubtotalDeposit int      `json:"subtotal_deposit" db:"subtotal_deposit"` // quantity × deposit_per_unit
+	ItemID          string   `json:"item_id" db:"item_id"`                           // ID item asli (untuk tracking)
+	Name            string   `json:"name" db:"name"`                                 // Snapshot nama item saat booking dibuat
+	Description     string   `json:"description" db:"description"`                   // Enriched dari item table (bukan snapshot)
+	Photos          []string `json:"photos" db:"photos"`                             // Enriched dari item table (bukan snapshot)
+	Quantity        int      `json:"quantity" db:"quantity"`                         // Jumlah unit yang disewa
+	PricePerDay     int      `json:"price_per_day" db:"price_per_day"`               // Snapshot harga per hari
+	DepositPerUnit  int      `json:"deposit_per_unit" db:"deposit_per_unit"`         // Snapshot deposit per unit
+	SubtotalRental  int      `json:"subtotal_rental" db:"subtotal_rental"`           // quantity × price_per_day × total_days
+	SubtotalDeposit int      `json:"subtotal_deposit" db:"subtotal_deposit"`         // quantity × deposit_per_unit
+	VariantID       *string  `json:"variant_id,omitempty" db:"variant_id"`           // Varian yang disewa (item bervarian)
+	VariantName     *string  `json:"variant_name,omitempty" db:"variant_name"`       // Snapshot nama varian saat booking dibuat
+	BundleID        *string  `json:"bundle_id,omitempty" db:"bundle_id"`             // Paket asal baris komponen ini
+	BundleName      *string  `json:"bundle_name,omitempty" db:"bundle_name"`         // Snapshot nama paket saat booking dibuat
+	BundleQuantity  *int     `json:"bundle_quantity,omitempty" db:"bundle_quantity"` // Jumlah paket yang disewa
 }
 
 // ===================================================================
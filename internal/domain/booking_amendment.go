@@ -0,0 +1,75 @@
+// ===================================================================
+// File: booking_amendment.go
+// Deskripsi: Entity BookingAmendment - permintaan perubahan booking (perpanjangan & quantity)
+// Catatan: SEMUA model perubahan booking HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// Status permintaan perubahan booking.
+const (
+	AmendmentStatusPending   = "pending"   // Menunggu keputusan hoster
+	AmendmentStatusApproved  = "approved"  // Disetujui, booking sudah diubah
+	AmendmentStatusRejected  = "rejected"  // Ditolak hoster
+	AmendmentStatusCancelled = "cancelled" // Dibatalkan customer sebelum diputuskan
+)
+
+// Kebijakan harga tambahan hari / unit (diatur per store, tenant.amendment_pricing).
+const (
+	AmendmentPricingSnapshot = "snapshot" // Harga saat booking dibuat (booking_item)
+	AmendmentPricingCurrent  = "current"  // Harga item saat ini
+)
+
+// BookingAmendment adalah permintaan perubahan booking dari customer beserta jejak auditnya.
+// Nilai Old* adalah kondisi booking saat permintaan dibuat, New* adalah hasil setelah disetujui.
+//
+// Relasi:
+// - BookingAmendment belongs to Booking (booking_id)
+// - BookingAmendment has many BookingAmendmentItem (satu per booking_item)
+type BookingAmendment struct {
+	ID                string                 `json:"id" db:"id"`
+	BookingID         string                 `json:"booking_id" db:"booking_id"`
+	Status            string                 `json:"status" db:"status"`   // Lihat AmendmentStatus*
+	Pricing           string                 `json:"pricing" db:"pricing"` // Lihat AmendmentPricing*
+	OldEndDate        time.Time              `json:"old_end_date" db:"old_end_date"`
+	NewEndDate        time.Time              `json:"new_end_date" db:"new_end_date"`
+	OldTotalDays      int                    `json:"old_total_days" db:"old_total_days"`
+	NewTotalDays      int                    `json:"new_total_days" db:"new_total_days"`
+	OldRental         int                    `json:"old_rental" db:"old_rental"`
+	NewRental         int                    `json:"new_rental" db:"new_rental"`
+	OldDeposit        int                    `json:"old_deposit" db:"old_deposit"`
+	NewDeposit        int                    `json:"new_deposit" db:"new_deposit"`
+	OldTotal          int                    `json:"old_total" db:"old_total"`
+	NewTotal          int                    `json:"new_total" db:"new_total"`
+	Discount          int                    `json:"-" db:"discount"` // Diskon booking (tidak berubah), dipakai menghitung sewa bersih ledger
+	CustomerNote      *string                `json:"customer_note" db:"customer_note"`
+	HosterNote        *string                `json:"hoster_note" db:"hoster_note"`
+	DecidedByMemberID *string                `json:"decided_by_member_id" db:"decided_by_member_id"` // NULL = diputuskan owner toko
+	DecidedAt         *time.Time             `json:"decided_at" db:"decided_at"`
+	CreatedAt         time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at" db:"updated_at"`
+	Items             []BookingAmendmentItem `json:"items" db:"-"`
+}
+
+// BookingAmendmentItem adalah perubahan satu baris booking_item.
+// PricePerDay & DepositPerUnit adalah harga yang dipakai untuk tambahan (sesuai kebijakan).
+type BookingAmendmentItem struct {
+	ID                 string  `json:"id" db:"id"`
+	AmendmentID        string  `json:"amendment_id" db:"amendment_id"`
+	BookingItemID      string  `json:"booking_item_id" db:"booking_item_id"`
+	ItemID             string  `json:"item_id" db:"item_id"`
+	VariantID          *string `json:"variant_id,omitempty" db:"variant_id"`
+	BundleID           *string `json:"bundle_id,omitempty" db:"bundle_id"` // Baris komponen paket: quantity tidak bisa diubah, harga selalu snapshot
+	OldQuantity        int     `json:"old_quantity" db:"old_quantity"`
+	NewQuantity        int     `json:"new_quantity" db:"new_quantity"`
+	SnapshotPrice      int     `json:"-" db:"snapshot_price"`   // booking_item.price_per_day (untuk pengurangan)
+	SnapshotDeposit    int     `json:"-" db:"snapshot_deposit"` // booking_item.deposit_per_unit (untuk pengurangan)
+	PricePerDay        int     `json:"price_per_day" db:"price_per_day"`
+	DepositPerUnit     int     `json:"deposit_per_unit" db:"deposit_per_unit"`
+	OldSubtotalRental  int     `json:"old_subtotal_rental" db:"old_subtotal_rental"`
+	NewSubtotalRental  int     `json:"new_subtotal_rental" db:"new_subtotal_rental"`
+	OldSubtotalDeposit int     `json:"old_subtotal_deposit" db:"old_subtotal_deposit"`
+	NewSubtotalDeposit int     `json:"new_subtotal_deposit" db:"new_subtotal_deposit"`
+}
@@ -0,0 +1,32 @@
+// ===================================================================
+// File: booking_review.go
+// Deskripsi: Entity BookingReview - ulasan customer atas booking yang selesai
+// Catatan: SEMUA model ulasan booking HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// Batas nilai rating ulasan (bintang).
+const (
+	ReviewRatingMin = 1
+	ReviewRatingMax = 5
+)
+
+// BookingReview adalah ulasan customer untuk satu booking yang sudah selesai.
+// HosterID disalin dari booking untuk ringkasan rating storefront.
+//
+// Relasi:
+// - BookingReview belongs to Booking (booking_id, satu ulasan per booking)
+// - BookingReview belongs to Customer (user_id)
+// - BookingReview belongs to Hoster (hoster_id)
+type BookingReview struct {
+	ID        string    `json:"id" db:"id"`
+	BookingID string    `json:"booking_id" db:"booking_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	HosterID  string    `json:"hoster_id" db:"hoster_id"`
+	Rating    int       `json:"rating" db:"rating"`
+	Comment   *string   `json:"comment" db:"comment"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,43 @@
+// ===================================================================
+// File: bundle.go
+// Deskripsi: Entity Bundle - paket sewa berisi beberapa item dengan harga paket
+// Catatan: SEMUA model paket sewa HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// Bundle adalah paket sewa (misal: Paket Camping 2 Orang) dengan harga & deposit sendiri.
+// Booking paket me-reservasi stock setiap komponen (BundleItem).
+//
+// Relasi:
+// - Bundle belongs to Hoster (hoster_id) dan Tenant / store (tenant_id)
+// - Bundle has many BundleItem
+// - BookingItem mereferensikan Bundle (bundle_id) untuk baris komponen paket
+type Bundle struct {
+	ID          string       `json:"id" db:"id"`
+	HosterID    string       `json:"hoster_id" db:"hoster_id"`
+	TenantID    string       `json:"tenant_id" db:"tenant_id"`
+	Name        string       `json:"name" db:"name"`
+	Description string       `json:"description" db:"description"`
+	PricePerDay int          `json:"price_per_day" db:"price_per_day"` // Harga paket per hari
+	Deposit     int          `json:"deposit" db:"deposit"`             // Deposit per paket
+	IsHidden    bool         `json:"is_hidden" db:"is_hidden"`
+	Items       []BundleItem `json:"items" db:"-"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// BundleItem adalah satu komponen paket beserta data item yang dibutuhkan saat booking.
+type BundleItem struct {
+	BundleID    string `json:"bundle_id" db:"bundle_id"`
+	ItemID      string `json:"item_id" db:"item_id"`
+	Quantity    int    `json:"quantity" db:"quantity"`           // Unit per satu paket
+	Name        string `json:"name" db:"name"`                   // Nama item saat ini
+	PricePerDay int    `json:"price_per_day" db:"price_per_day"` // Harga satuan item (bobot pembagian harga paket)
+	Deposit     int    `json:"deposit" db:"deposit"`             // Deposit satuan item (bobot pembagian deposit paket)
+	TenantID    string `json:"tenant_id" db:"tenant_id"`
+	IsHidden    bool   `json:"is_hidden" db:"is_hidden"`
+	HasVariants bool   `json:"has_variants" db:"has_variants"`
+}
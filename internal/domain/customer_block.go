@@ -0,0 +1,28 @@
+// ===================================================================
+// File: customer_block.go
+// Deskripsi: Entity CustomerBlock - daftar blokir customer per hoster
+// Catatan: SEMUA model blokir customer HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// CustomerBlock adalah blokir customer oleh hoster.
+// DocumentType & DocumentNumberHash terisi jika blokir juga berlaku untuk dokumen identitas
+// terverifikasi customer (akun lain dengan NIK / nomor dokumen yang sama ikut tertolak).
+//
+// Relasi:
+// - CustomerBlock belongs to Hoster (hoster_id)
+// - CustomerBlock belongs to Customer (customer_id)
+type CustomerBlock struct {
+	ID                 string    `json:"id" db:"id"`
+	HosterID           string    `json:"hoster_id" db:"hoster_id"`
+	CustomerID         string    `json:"customer_id" db:"customer_id"`
+	DocumentType       *string   `json:"document_type" db:"document_type"`
+	DocumentNumberHash *string   `json:"-" db:"document_number_hash"`                    // Blind index, tidak pernah dikirim ke client
+	Note               *string   `json:"note" db:"note"`                                 // Catatan privat hoster
+	BlockedByMemberID  *string   `json:"blocked_by_member_id" db:"blocked_by_member_id"` // NULL = diblokir owner toko
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,54 @@
+// ===================================================================
+// File: handover.go
+// Deskripsi: Entity Handover - berita acara serah terima barang booking (pickup & return)
+// Catatan: SEMUA model handover HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// Jenis handover.
+const (
+	HandoverKindPickup = "pickup" // Barang diserahkan ke customer (on_progress → on_rent)
+	HandoverKindReturn = "return" // Barang dikembalikan customer (on_rent → completed)
+)
+
+// Kondisi barang di handover memakai nilai yang sama dengan kondisi unit (good, fair, damaged).
+
+// BookingHandover adalah satu berita acara serah terima untuk sebuah booking.
+// Satu booking maksimal punya satu handover per jenis.
+//
+// Relasi:
+// - BookingHandover belongs to Booking (booking_id)
+// - BookingHandover has many BookingHandoverItem
+type BookingHandover struct {
+	ID                  string                `json:"id" db:"id"`
+	BookingID           string                `json:"booking_id" db:"booking_id"`
+	Kind                string                `json:"kind" db:"kind"` // Lihat HandoverKind*
+	Notes               *string               `json:"notes" db:"notes"`
+	RecordedByMemberID  *string               `json:"recorded_by_member_id" db:"recorded_by_member_id"` // NULL = diisi owner toko
+	HosterConfirmedAt   time.Time             `json:"hoster_confirmed_at" db:"hoster_confirmed_at"`
+	CustomerConfirmedAt *time.Time            `json:"customer_confirmed_at" db:"customer_confirmed_at"`
+	CustomerNote        *string               `json:"customer_note" db:"customer_note"`
+	CreatedAt           time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at" db:"updated_at"`
+	Items               []BookingHandoverItem `json:"items" db:"-"`
+}
+
+// BookingHandoverItem adalah kondisi satu baris booking_item saat serah terima.
+type BookingHandoverItem struct {
+	ID            string                  `json:"id" db:"id"`
+	HandoverID    string                  `json:"handover_id" db:"handover_id"`
+	BookingItemID string                  `json:"booking_item_id" db:"booking_item_id"`
+	Condition     string                  `json:"condition" db:"condition"` // good, fair, damaged
+	Notes         *string                 `json:"notes" db:"notes"`
+	Checklist     []HandoverChecklistItem `json:"checklist" db:"-"` // JSONB
+	Photos        []string                `json:"photos" db:"-"`    // JSONB, URL bucket hoster
+}
+
+// HandoverChecklistItem adalah satu poin pemeriksaan (misal: "Lensa bersih").
+type HandoverChecklistItem struct {
+	Label   string `json:"label"`
+	Checked bool   `json:"checked"`
+}
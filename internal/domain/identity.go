@@ -1,22 +1,107 @@
 // ===================================================================
 // File: identity.go
-// Deskripsi: Entity Identity (KTP/Verifikasi Identitas)
+// Deskripsi: Entity Identity (Dokumen Identitas: KTP, SIM, Paspor, KITAS)
 // Catatan: INI SATU-SATUNYA tempat untuk model Identity. JANGAN duplikasi!
 // ===================================================================
 
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // ===================================================================
-// IDENTITY (KTP)
+// DOCUMENT TYPE (Enum/Constant)
 // ===================================================================
 
-// Identity adalah entity untuk data KTP user (customer/hoster).
+// DocumentType adalah enum untuk jenis dokumen identitas
+type DocumentType string
+
+const (
+	// DocumentTypeKTP: Kartu Tanda Penduduk (WNI), nomor = NIK 16 digit
+	DocumentTypeKTP DocumentType = "ktp"
+
+	// DocumentTypeSIM: Surat Izin Mengemudi (Indonesia), nomor 12-14 digit, wajib masa berlaku
+	DocumentTypeSIM DocumentType = "sim"
+
+	// DocumentTypePassport: Paspor (WNI/WNA), wajib negara penerbit + masa berlaku
+	DocumentTypePassport DocumentType = "passport"
+
+	// DocumentTypeKITAS: Kartu Izin Tinggal Terbatas (WNA), wajib negara asal + masa berlaku
+	DocumentTypeKITAS DocumentType = "kitas"
+)
+
+// Label mengembalikan nama dokumen untuk pesan ke user (contoh: "KTP", "Passport")
+func (t DocumentType) Label() string {
+	switch t {
+	case DocumentTypeSIM:
+		return "SIM"
+	case DocumentTypePassport:
+		return "Passport"
+	case DocumentTypeKITAS:
+		return "KITAS"
+	default:
+		return "KTP"
+	}
+}
+
+// IdentityPhotoKind adalah jenis foto tambahan pada dokumen identitas
+type IdentityPhotoKind string
+
+const (
+	// IdentityPhotoSelfie: Selfie sambil memegang dokumen
+	IdentityPhotoSelfie IdentityPhotoKind = "selfie"
+
+	// IdentityPhotoBack: Sisi belakang dokumen (SIM/KITAS)
+	IdentityPhotoBack IdentityPhotoKind = "back"
+)
+
+// IdentityPhoto adalah satu foto tambahan (disimpan di kolom JSONB identity.extra_photos)
+type IdentityPhoto struct {
+	Kind IdentityPhotoKind `json:"kind"`
+	URL  string            `json:"url"`
+}
+
+// IdentityUserRole adalah enum pemilik dokumen identitas (tabel identity dipakai bersama)
+type IdentityUserRole string
+
+const (
+	// IdentityRoleCustomer: Dokumen milik customer (syarat booking)
+	IdentityRoleCustomer IdentityUserRole = "customer"
+
+	// IdentityRoleHoster: Dokumen milik hoster (syarat badge "toko terverifikasi")
+	IdentityRoleHoster IdentityUserRole = "hoster"
+)
+
+// BusinessDocumentType adalah enum untuk jenis dokumen usaha hoster
+type BusinessDocumentType string
+
+const (
+	// BusinessDocumentNIB: Nomor Induk Berusaha (OSS), 13 digit
+	BusinessDocumentNIB BusinessDocumentType = "nib"
+
+	// BusinessDocumentNPWP: Nomor Pokok Wajib Pajak, 15 digit (format lama) atau 16 digit (format baru)
+	BusinessDocumentNPWP BusinessDocumentType = "npwp"
+)
+
+// BusinessDocument adalah satu dokumen usaha hoster (disimpan di kolom JSONB identity.business_documents)
+type BusinessDocument struct {
+	Type            BusinessDocumentType `json:"type"`
+	NumberEncrypted string               `json:"number_encrypted,omitempty"` // AES-256-GCM, tidak pernah plaintext di DB (dikosongkan sebelum ditampilkan)
+	Number          string               `json:"number,omitempty"`           // Plaintext/masked, diisi service saat ditampilkan (tidak disimpan)
+	URL             string               `json:"url"`                        // URL foto/scan dokumen (bucket hoster)
+}
+
+// ===================================================================
+// IDENTITY (Dokumen Identitas)
+// ===================================================================
+
+// Identity adalah entity untuk dokumen identitas user (customer/hoster).
 // Digunakan untuk proses verifikasi identitas sebelum user bisa melakukan booking.
 //
 // Flow:
-// 1. User upload foto KTP → status = "pending", verified = false
+// 1. User upload foto dokumen → status = "pending", verified = false
 // 2. Admin verifikasi:
 //   - Approve → status = "approved", verified = true, verified_at diisi
 //   - Reject → status = "rejected", verified = false, reason diisi alasan penolakan
@@ -24,16 +109,86 @@ import "time"
 // 3. User bisa re-upload jika ditolak (data lama di-override atau buat baru)
 //
 // Relasi:
+// - Pemilik dibedakan lewat UserRole (customer/hoster), user_id tidak lagi FK ke customer saja
+// - Hoster boleh melampirkan dokumen usaha (NIB/NPWP), approve → hoster.is_verified = true
 // - Satu user bisa punya banyak record Identity (history upload)
 // - Hanya yang verified=true yang dipakai untuk booking
+//
+// Nomor dokumen:
+// - Disimpan terenkripsi (DocumentNumberEncrypted) + blind index (DocumentNumberHash), tidak pernah plaintext di DB
+// - KTP: blind index dari NIK saja, tipe lain: dari "tipe:negara:nomor"
+// - DuplicateFlag = true jika saat upload nomor dokumen sudah terverifikasi di akun lain
+// - Approve ditolak jika nomor dokumen sudah terverifikasi di akun lain
 type Identity struct {
-	ID         string     `json:"id" db:"id"`
-	UserID     string     `json:"user_id" db:"user_id"`         // ID customer/hoster
-	KTPURL     string     `json:"ktp_url" db:"ktp_url"`         // URL foto KTP (dari cloud storage)
-	Verified   bool       `json:"verified" db:"verified"`       // true jika approved, false jika pending/rejected
-	Status     string     `json:"status" db:"status"`           // "pending", "approved", "rejected"
-	Reason     string     `json:"reason" db:"reason"`           // Alasan reject (kosong jika pending/approved)
-	VerifiedAt *time.Time `json:"verified_at" db:"verified_at"` // Waktu admin approve/reject (nullable)
-	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	ID                      string             `json:"id" db:"id"`
+	UserID                  string             `json:"user_id" db:"user_id"`                           // ID customer/hoster
+	UserRole                IdentityUserRole   `json:"user_role" db:"user_role"`                       // "customer" atau "hoster"
+	DocumentType            DocumentType       `json:"document_type" db:"document_type"`               // "ktp", "sim", "passport", "kitas"
+	DocumentURL             string             `json:"document_url" db:"document_url"`                 // URL foto dokumen (sisi depan, dari cloud storage)
+	DocumentCountry         string             `json:"document_country" db:"document_country"`         // Negara penerbit (ISO 3166-1 alpha-3), "IDN" untuk KTP/SIM
+	DocumentExpiry          *time.Time         `json:"document_expiry,omitempty" db:"document_expiry"` // Masa berlaku (nullable, KTP seumur hidup)
+	ExtraPhotos             json.RawMessage    `json:"extra_photos,omitempty" db:"extra_photos"`       // []IdentityPhoto (selfie, sisi belakang)
+	Verified                bool               `json:"verified" db:"verified"`                         // true jika approved, false jika pending/rejected
+	Status                  string             `json:"status" db:"status"`                             // "pending", "approved", "rejected"
+	Reason                  string             `json:"reason" db:"reason"`                             // Alasan reject (kosong jika pending/approved)
+	VerifiedAt              *time.Time         `json:"verified_at" db:"verified_at"`                   // Waktu admin approve/reject (nullable)
+	CreatedAt               time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt               time.Time          `json:"updated_at" db:"updated_at"`
+	DocumentNumber          string             `json:"document_number,omitempty" db:"-"`                   // Plaintext/masked, diisi service (tidak disimpan)
+	DocumentNumberEncrypted *string            `json:"-" db:"document_number_encrypted"`                   // AES-256-GCM (nullable untuk data lama)
+	DocumentNumberHash      *string            `json:"-" db:"document_number_hash"`                        // Blind index HMAC-SHA256
+	HolderBirthDate         *time.Time         `json:"holder_birth_date,omitempty" db:"holder_birth_date"` // Tanggal lahir (hasil decode NIK untuk KTP)
+	HolderSex               *string            `json:"holder_sex,omitempty" db:"holder_sex"`               // "male" / "female" (hasil decode NIK untuk KTP)
+	DuplicateFlag           bool               `json:"duplicate_flag" db:"duplicate_flag"`                 // Nomor dokumen sudah terverifikasi di akun lain saat upload
+	ClaimedBy               *string            `json:"claimed_by,omitempty" db:"claimed_by"`               // Admin yang sedang meninjau (lease)
+	ClaimedUntil            *time.Time         `json:"claimed_until,omitempty" db:"claimed_until"`         // Lease habis setelah waktu ini
+	ReviewedBy              *string            `json:"reviewed_by,omitempty" db:"reviewed_by"`             // Admin yang approve/reject
+	RejectionTemplateID     *string            `json:"rejection_template_id,omitempty" db:"rejection_template_id"`
+	BusinessDocuments       json.RawMessage    `json:"-" db:"business_documents"`           // []BusinessDocument (hanya hoster)
+	BusinessDocumentList    []BusinessDocument `json:"business_documents,omitempty" db:"-"` // Diisi service saat ditampilkan ke admin
+}
+
+// ===================================================================
+// REVIEW (Antrian verifikasi admin)
+// ===================================================================
+
+// IdentityReviewAction adalah enum untuk aksi reviewer yang dicatat di audit log
+type IdentityReviewAction string
+
+const (
+	// IdentityReviewClaim: Admin mengambil dokumen dari antrian (lease)
+	IdentityReviewClaim IdentityReviewAction = "claim"
+
+	// IdentityReviewRelease: Admin melepas dokumen kembali ke antrian
+	IdentityReviewRelease IdentityReviewAction = "release"
+
+	// IdentityReviewApproved: Admin menyetujui dokumen
+	IdentityReviewApproved IdentityReviewAction = "approved"
+
+	// IdentityReviewRejected: Admin menolak dokumen
+	IdentityReviewRejected IdentityReviewAction = "rejected"
+)
+
+// IdentityReviewLog adalah audit trail satu aksi reviewer pada dokumen identitas.
+type IdentityReviewLog struct {
+	ID                  string               `json:"id" db:"id"`
+	IdentityID          string               `json:"identity_id" db:"identity_id"`
+	AdminID             *string              `json:"admin_id" db:"admin_id"`     // nullable jika admin dihapus
+	AdminName           string               `json:"admin_name" db:"admin_name"` // Hasil join ke tabel admin
+	Action              IdentityReviewAction `json:"action" db:"action"`
+	Reason              *string              `json:"reason,omitempty" db:"reason"`
+	RejectionTemplateID *string              `json:"rejection_template_id,omitempty" db:"rejection_template_id"`
+	CreatedAt           time.Time            `json:"created_at" db:"created_at"`
+}
+
+// IdentityRejectionTemplate adalah template alasan penolakan yang dikelola admin.
+// Reviewer wajib memilih salah satu template aktif saat menolak dokumen.
+type IdentityRejectionTemplate struct {
+	ID        string    `json:"id" db:"id"`
+	Code      string    `json:"code" db:"code"`     // Kode unik, contoh: "blurry_photo"
+	Title     string    `json:"title" db:"title"`   // Judul singkat untuk UI reviewer
+	Reason    string    `json:"reason" db:"reason"` // Teks alasan yang dikirim ke customer
+	IsActive  bool      `json:"is_active" db:"is_active"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -45,17 +45,20 @@ const (
 // - Item belongs to Category (category_id)
 // - Item has many TermsAndConditions
 type Item struct {
-	ID          string       `json:"id" db:"id"`
-	Name        string       `json:"name" db:"name"`
-	Description string       `json:"description" db:"description"`
-	Photos      []string     `json:"photos" db:"photos"`
-	Stock       int          `json:"stock" db:"stock"`
-	PickupType  PickupMethod `json:"pickup_type" db:"pickup_type"`
-	PricePerDay int          `json:"price_per_day" db:"price_per_day"`
-	Deposit     int          `json:"deposit" db:"deposit"`
-	Discount    int          `json:"discount,omitempty" db:"discount"`
-	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
-	CategoryID  string       `json:"category_id" db:"category_id"` // FK ke Category
-	HosterID    string       `json:"hoster_id" db:"hoster_id"`     // FK ke Hoster
+	ID             string       `json:"id" db:"id"`
+	Name           string       `json:"name" db:"name"`
+	Description    string       `json:"description" db:"description"`
+	Photos         []string     `json:"photos" db:"photos"`
+	Stock          int          `json:"stock" db:"stock"`
+	PickupType     PickupMethod `json:"pickup_type" db:"pickup_type"`
+	PricePerDay    int          `json:"price_per_day" db:"price_per_day"`
+	Deposit        int          `json:"deposit" db:"deposit"`
+	Discount       int          `json:"discount,omitempty" db:"discount"`
+	CreatedAt      time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at" db:"updated_at"`
+	CategoryID     string       `json:"category_id" db:"category_id"`       // FK ke Category
+	HosterID       string       `json:"hoster_id" db:"hoster_id"`           // FK ke Hoster
+	TenantID       string       `json:"store_id" db:"tenant_id"`            // FK ke Tenant (store tempat item disewakan)
+	IsHidden       bool         `json:"is_hidden,omitempty" db:"is_hidden"` // true = disembunyikan dari customer
+	HosterVerified bool         `json:"hoster_verified,omitempty" db:"-"`   // Badge toko terverifikasi (hasil JOIN, hanya listing publik)
 }
@@ -0,0 +1,30 @@
+// ===================================================================
+// File: item_variant.go
+// Deskripsi: Entity ItemVariant - varian item (ukuran, warna, dll.) dengan stock sendiri
+// Catatan: SEMUA model varian item HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// ItemVariant adalah satu kombinasi varian dari sebuah item (misal: Sepatu Gunung ukuran 40).
+// Dipakai jika item punya variant_dimensions; stock item = jumlah stock varian aktif.
+//
+// Relasi:
+// - ItemVariant belongs to Item (item_id)
+// - BookingItem mereferensikan ItemVariant (variant_id) untuk item bervarian
+type ItemVariant struct {
+	ID           string    `json:"id" db:"id"`
+	ItemID       string    `json:"item_id" db:"item_id"`
+	HosterID     string    `json:"hoster_id" db:"hoster_id"`
+	Name         string    `json:"name" db:"name"`                   // Gabungan option_values, misal "40 / Merah"
+	OptionValues []string  `json:"option_values" db:"option_values"` // Urut sesuai Item.VariantDimensions
+	Stock        int       `json:"stock" db:"stock"`
+	PricePerDay  *int      `json:"price_per_day" db:"price_per_day"` // nil = ikut harga item
+	Deposit      *int      `json:"deposit" db:"deposit"`             // nil = ikut deposit item
+	IsActive     bool      `json:"is_active" db:"is_active"`         // false = dihapus hoster tetapi masih dipakai riwayat booking
+	SortOrder    int       `json:"sort_order" db:"sort_order"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,32 @@
+// ===================================================================
+// File: late_fee.go
+// Deskripsi: Entity LateFeeRule - aturan denda keterlambatan pengembalian barang
+// Catatan: SEMUA model denda keterlambatan HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// Jenis perhitungan denda keterlambatan.
+const (
+	LateFeeTypeFixed   = "fixed"   // Amount = rupiah per unit per hari terlambat
+	LateFeeTypePercent = "percent" // Amount = basis point harga sewa per hari per hari terlambat (1000 = 10%)
+)
+
+// LateFeeRule adalah aturan denda keterlambatan milik hoster.
+// ItemID NULL = aturan default hoster, terisi = aturan khusus item (menimpa default).
+//
+// Relasi:
+// - LateFeeRule belongs to Hoster (hoster_id)
+// - LateFeeRule may belong to Item (item_id)
+type LateFeeRule struct {
+	ID         string    `json:"id" db:"id"`
+	HosterID   string    `json:"hoster_id" db:"hoster_id"`
+	ItemID     *string   `json:"item_id" db:"item_id"`
+	FeeType    string    `json:"fee_type" db:"fee_type"` // Lihat LateFeeType*
+	Amount     int       `json:"amount" db:"amount"`
+	GraceHours int       `json:"grace_hours" db:"grace_hours"` // Toleransi setelah batas pengembalian
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
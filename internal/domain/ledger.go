@@ -0,0 +1,108 @@
+// ===================================================================
+// File: ledger.go
+// Deskripsi: Entity Ledger (double-entry) dan Settlement payout hoster
+// Catatan: SEMUA model ledger & settlement HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// ===================================================================
+// LEDGER ACCOUNT & KIND
+// ===================================================================
+
+// Akun ledger. Semua akun dicatat per hoster (ledger_entry.hoster_id).
+const (
+	LedgerAccountPlatformCash       = "platform_cash"       // Kas platform (uang customer yang sudah diterima)
+	LedgerAccountUnearnedRental     = "unearned_rental"     // Sewa sudah dibayar, booking belum selesai
+	LedgerAccountCustomerDeposit    = "customer_deposit"    // Deposit customer yang sedang ditahan
+	LedgerAccountHosterPayable      = "hoster_payable"      // Hutang platform ke hoster
+	LedgerAccountPlatformCommission = "platform_commission" // Pendapatan komisi platform
+)
+
+// Jenis transaksi ledger.
+const (
+	LedgerKindBookingPayment   = "booking_payment"   // Booking dibayar (pending → on_progress)
+	LedgerKindBookingAmendment = "booking_amendment" // Perubahan booking disetujui setelah dibayar: selisih sewa & deposit
+	LedgerKindRentalIncome     = "rental_income"     // Booking selesai: sewa diakui, dipotong komisi
+	LedgerKindDepositRefund    = "deposit_refund"    // Booking selesai: deposit dikembalikan ke customer
+	LedgerKindLateFee          = "late_fee"          // Booking selesai terlambat: denda diakui, dipotong komisi
+	LedgerKindPayout           = "payout"            // Settlement dibayar ke hoster
+)
+
+// Status settlement batch dan settlement per hoster.
+const (
+	SettlementStatusPending = "pending"
+	SettlementStatusPaid    = "paid"
+)
+
+// ===================================================================
+// LEDGER TRANSACTION & ENTRY
+// ===================================================================
+
+// LedgerTransaction adalah satu kejadian keuangan milik hoster.
+// Total debit seluruh Entries selalu sama dengan total kredit.
+//
+// Relasi:
+// - LedgerTransaction belongs to Hoster (hoster_id)
+// - LedgerTransaction belongs to Booking (booking_id, nullable untuk payout)
+// - LedgerTransaction belongs to Settlement (settlement_id, nullable)
+// - LedgerTransaction belongs to BookingAmendment (amendment_id, nullable)
+// - LedgerTransaction has many LedgerEntry
+type LedgerTransaction struct {
+	ID                string        `json:"id" db:"id"`
+	HosterID          string        `json:"hoster_id" db:"hoster_id"`
+	BookingID         *string       `json:"booking_id,omitempty" db:"booking_id"`
+	SettlementID      *string       `json:"settlement_id,omitempty" db:"settlement_id"`
+	AmendmentID       *string       `json:"amendment_id,omitempty" db:"amendment_id"`     // Terisi untuk booking_amendment
+	Kind              string        `json:"kind" db:"kind"`                               // Lihat LedgerKind*
+	CommissionRateBps int           `json:"commission_rate_bps" db:"commission_rate_bps"` // Tarif komisi saat posting (basis point, 1000 = 10%)
+	Description       string        `json:"description" db:"description"`
+	OccurredAt        time.Time     `json:"occurred_at" db:"occurred_at"` // Waktu kejadian (diturunkan dari data booking, bukan waktu posting)
+	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
+	Entries           []LedgerEntry `json:"entries" db:"-"`
+}
+
+// LedgerEntry adalah satu baris debit atau kredit pada akun ledger.
+type LedgerEntry struct {
+	ID            string `json:"id" db:"id"`
+	TransactionID string `json:"transaction_id" db:"transaction_id"`
+	HosterID      string `json:"hoster_id" db:"hoster_id"`
+	Account       string `json:"account" db:"account"` // Lihat LedgerAccount*
+	Debit         int64  `json:"debit" db:"debit"`
+	Credit        int64  `json:"credit" db:"credit"`
+}
+
+// ===================================================================
+// SETTLEMENT
+// ===================================================================
+
+// SettlementBatch adalah payout periodik yang dibuat admin.
+// Mencakup semua rental_income & late_fee yang belum di-settle sampai PeriodEnd.
+//
+// Relasi:
+// - SettlementBatch has many Settlement (satu per hoster)
+type SettlementBatch struct {
+	ID        string     `json:"id" db:"id"`
+	PeriodEnd time.Time  `json:"period_end" db:"period_end"`
+	Status    string     `json:"status" db:"status"`
+	CreatedBy string     `json:"created_by" db:"created_by"`
+	PaidBy    *string    `json:"paid_by,omitempty" db:"paid_by"`
+	PaidAt    *time.Time `json:"paid_at,omitempty" db:"paid_at"`
+	Reference *string    `json:"reference,omitempty" db:"reference"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Settlement adalah jumlah yang dibayar ke satu hoster dalam satu batch.
+type Settlement struct {
+	ID               string     `json:"id" db:"id"`
+	BatchID          string     `json:"batch_id" db:"batch_id"`
+	HosterID         string     `json:"hoster_id" db:"hoster_id"`
+	Amount           int64      `json:"amount" db:"amount"`
+	TransactionCount int        `json:"transaction_count" db:"transaction_count"`
+	Status           string     `json:"status" db:"status"`
+	PaidAt           *time.Time `json:"paid_at,omitempty" db:"paid_at"`
+	Reference        *string    `json:"reference,omitempty" db:"reference"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+}
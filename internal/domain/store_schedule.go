@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// ===================================================================
+// STORE SCHEDULE (Jam Buka & Slot Serah Terima)
+// ===================================================================
+
+// StoreOperatingHours adalah jam buka mingguan satu store.
+// Weekday mengikuti time.Weekday (0 = Minggu), hari tanpa jam buka dianggap tutup.
+type StoreOperatingHours struct {
+	TenantID  string `json:"store_id" db:"tenant_id"`
+	Weekday   int    `json:"weekday" db:"weekday"`
+	OpenTime  string `json:"open_time" db:"open_time"`   // Format HH:MM (jam lokal store)
+	CloseTime string `json:"close_time" db:"close_time"` // Format HH:MM, slot terakhir harus selesai sebelum jam ini
+}
+
+// StoreClosure adalah hari libur / tutup khusus store (menimpa jam buka mingguan).
+type StoreClosure struct {
+	ID          string    `json:"id" db:"id"`
+	TenantID    string    `json:"store_id" db:"tenant_id"`
+	ClosureDate time.Time `json:"closure_date" db:"closure_date"`
+	Reason      *string   `json:"reason,omitempty" db:"reason"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// StoreDaySchedule adalah jadwal satu store pada satu tanggal (hasil gabungan jam buka, hari libur, dan pengaturan slot).
+type StoreDaySchedule struct {
+	Configured   bool    `db:"configured"`    // false = store belum mengatur jam buka sama sekali
+	Closed       bool    `db:"closed"`        // true = hari libur / tidak ada jam buka di hari tersebut
+	ClosedReason *string `db:"closed_reason"` // Alasan hari libur (jika ada)
+	OpenTime     string  `db:"open_time"`     // Format HH:MM
+	CloseTime    string  `db:"close_time"`    // Format HH:MM
+	SlotMinutes  int     `db:"slot_minutes"`  // Panjang satu slot
+	SlotCapacity int     `db:"slot_capacity"` // Serah terima maksimal per slot (pickup + pengembalian)
+}
@@ -12,20 +12,31 @@ import "time"
 // TENANT
 // ===================================================================
 
-// Tent adalah entity untuk tenant/penyewa toko.
-// Relasi one-to-one dengan Hoster (satu hoster punya satu tenant).
-//
-// Catatan: Model ini kemungkinan belum fully digunakan di sistem saat ini.
-// TODO: Verifikasi apakah model Tenant masih diperlukan atau bisa dihapus
+// Tenant adalah cabang toko (store) milik hoster.
+// Satu hoster bisa punya banyak store di kota berbeda, masing-masing dengan alamat,
+// T&C, pengaturan delivery, dan item sendiri. Booking selalu terikat ke satu store.
 //
 // Relasi:
-// - Tenant belongs to Hoster (one-to-one via hoster_id)
+// - Tenant belongs to Hoster (one-to-many via hoster_id)
+// - Tenant has many Item (item.tenant_id)
+// - Tenant has many Booking (booking.tenant_id)
+// - Tenant has one TermsAndConditions (opsional, fallback ke T&C umum hoster)
 type Tenant struct {
-	ID        string    `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	HosterID  string    `json:"hoster_id" db:"hoster_id"` // FK ke Hoster (one-to-one)
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID               string    `json:"id" db:"id"`
+	Name             string    `json:"name" db:"name"`
+	HosterID         string    `json:"hoster_id" db:"hoster_id"` // FK ke Hoster
+	Address          string    `json:"address" db:"address"`
+	City             string    `json:"city" db:"city"`
+	PhoneNumber      *string   `json:"phone_number,omitempty" db:"phone_number"`
+	DeliveryEnabled  bool      `json:"delivery_enabled" db:"delivery_enabled"`               // false = customer hanya bisa ambil sendiri
+	DeliveryFee      int       `json:"delivery_fee" db:"delivery_fee"`                       // Ongkos antar flat (rupiah)
+	DeliveryRadiusKm *int      `json:"delivery_radius_km,omitempty" db:"delivery_radius_km"` // Jangkauan antar, nil = tanpa batas
+	IsDefault        bool      `json:"is_default" db:"is_default"`                           // Store tujuan item baru jika store tidak dipilih
+	AmendmentPricing string    `json:"amendment_pricing" db:"amendment_pricing"`             // Harga tambahan perubahan booking, lihat AmendmentPricing*
+	SlotMinutes      int       `json:"slot_minutes" db:"slot_minutes"`                       // Panjang slot pickup / pengembalian (menit)
+	SlotCapacity     int       `json:"slot_capacity" db:"slot_capacity"`                     // Serah terima maksimal per slot
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 }
 
 // ===================================================================
@@ -33,7 +44,8 @@ type Tenant struct {
 // ===================================================================
 
 // TermsAndConditions adalah entity untuk syarat dan ketentuan penyewaan.
-// Setiap hoster atau item bisa punya T&C sendiri.
+// Setiap hoster, store, atau item bisa punya T&C sendiri. Description berisi salinan versi terbaru,
+// setiap perubahan disimpan sebagai TermsAndConditionsVersion baru (versi lama tidak pernah diubah).
 //
 // Contoh T&C:
 // - "Barang harus dikembalikan dalam kondisi bersih"
@@ -42,12 +54,33 @@ type Tenant struct {
 //
 // Relasi:
 // - TermsAndConditions belongs to Hoster (user_id) → T&C umum hoster
+// - TermsAndConditions belongs to Tenant (tenant_id, nullable) → T&C khusus store
 // - TermsAndConditions belongs to Item (item_id, nullable) → T&C spesifik item
+// - TermsAndConditions has many TermsAndConditionsVersion
 type TermsAndConditions struct {
-	ID          string    `json:"id" db:"id"`
-	Description []string  `json:"description" db:"description"` // Array string (poin-poin T&C)
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
-	UserID      string    `json:"user_id" db:"user_id"`           // FK ke Hoster
-	ItemID      string    `json:"item_id,omitempty" db:"item_id"` // FK ke Item (nullable, kosong jika T&C umum hoster)
+	ID               string    `json:"id" db:"id"`
+	Description      []string  `json:"description" db:"description"` // Array string (poin-poin T&C)
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+	UserID           string    `json:"user_id" db:"user_id"`                                 // FK ke Hoster
+	TenantID         *string   `json:"tenant_id,omitempty" db:"tenant_id"`                   // FK ke Tenant (nullable, kosong jika T&C umum hoster)
+	ItemID           *string   `json:"item_id,omitempty" db:"item_id"`                       // FK ke Item (nullable, kosong jika T&C umum hoster / store)
+	CurrentVersionID *string   `json:"current_version_id,omitempty" db:"current_version_id"` // FK ke TermsAndConditionsVersion terbaru
+}
+
+// TermsAndConditionsVersion adalah satu versi T&C yang tidak bisa diubah.
+// Booking menyimpan ID versi yang disetujui customer (booking_tnc_acceptance).
+//
+// Relasi:
+// - TermsAndConditionsVersion belongs to TermsAndConditions (tnc_id, nullable jika scope sudah dihapus)
+type TermsAndConditionsVersion struct {
+	ID                string    `json:"id" db:"id"`
+	TnCID             *string   `json:"tnc_id" db:"tnc_id"`
+	HosterID          string    `json:"hoster_id" db:"hoster_id"`
+	TenantID          *string   `json:"tenant_id,omitempty" db:"tenant_id"` // Snapshot scope
+	ItemID            *string   `json:"item_id,omitempty" db:"item_id"`     // Snapshot scope
+	Version           int       `json:"version" db:"version"`
+	Description       []string  `json:"description" db:"description"`
+	CreatedByMemberID *string   `json:"created_by_member_id,omitempty" db:"created_by_member_id"` // NULL = dibuat owner toko
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
 }
@@ -0,0 +1,65 @@
+// ===================================================================
+// File: unit.go
+// Deskripsi: Entity ItemUnit - unit fisik (aset) di dalam satu item
+// Catatan: SEMUA model unit & riwayatnya HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// Status unit.
+const (
+	UnitStatusAvailable   = "available"   // Siap disewakan
+	UnitStatusRented      = "rented"      // Sedang dibawa customer
+	UnitStatusMaintenance = "maintenance" // Diperbaiki / dibersihkan, tidak dihitung stock
+	UnitStatusRetired     = "retired"     // Tidak dipakai lagi
+)
+
+// Kondisi unit.
+const (
+	UnitConditionGood    = "good"
+	UnitConditionFair    = "fair"
+	UnitConditionDamaged = "damaged"
+)
+
+// Jenis kejadian di riwayat unit.
+const (
+	UnitEventCreated  = "created"  // Unit didaftarkan
+	UnitEventUpdated  = "updated"  // Label / kondisi / status diubah hoster
+	UnitEventRented   = "rented"   // Dialokasikan ke booking saat pickup
+	UnitEventReturned = "returned" // Kembali saat booking selesai
+	UnitEventDamage   = "damage"   // Catatan kerusakan
+)
+
+// ItemUnit adalah satu barang fisik dari sebuah item (misal: kamera dengan serial tertentu).
+// Hanya dipakai jika Item.UnitTracking aktif; stock item = jumlah unit available + rented.
+//
+// Relasi:
+// - ItemUnit belongs to Item (item_id)
+// - ItemUnit has many ItemUnitEvent
+// - ItemUnit dialokasikan ke BookingItem lewat tabel booking_item_unit
+type ItemUnit struct {
+	ID           string    `json:"id" db:"id"`
+	ItemID       string    `json:"item_id" db:"item_id"`
+	HosterID     string    `json:"hoster_id" db:"hoster_id"`
+	Label        string    `json:"label" db:"label"`                 // Nama unit, unik per item
+	SerialNumber *string   `json:"serial_number" db:"serial_number"` // Nomor seri pabrik (opsional)
+	Condition    string    `json:"condition" db:"condition"`         // Lihat UnitCondition*
+	Status       string    `json:"status" db:"status"`               // Lihat UnitStatus*
+	Notes        *string   `json:"notes" db:"notes"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ItemUnitEvent adalah satu baris riwayat unit.
+type ItemUnitEvent struct {
+	ID        string    `json:"id" db:"id"`
+	UnitID    string    `json:"unit_id" db:"unit_id"`
+	BookingID *string   `json:"booking_id" db:"booking_id"` // Terisi untuk kejadian rented / returned / damage saat sewa
+	Kind      string    `json:"kind" db:"kind"`             // Lihat UnitEvent*
+	Condition *string   `json:"condition" db:"condition"`
+	Status    *string   `json:"status" db:"status"`
+	Note      *string   `json:"note" db:"note"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
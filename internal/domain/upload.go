@@ -0,0 +1,63 @@
+// ===================================================================
+// File: upload.go
+// Deskripsi: Entity UploadSession (upload langsung ke bucket via presigned PUT)
+// Catatan: SEMUA model upload session HANYA di file ini!
+// ===================================================================
+
+package domain
+
+import "time"
+
+// ===================================================================
+// UPLOAD TARGET (Enum/Constant)
+// ===================================================================
+
+// UploadTarget adalah enum untuk tujuan file yang di-upload
+type UploadTarget string
+
+const (
+	// UploadTargetItemPhoto: Foto item milik hoster (bucket hoster, item.photos)
+	UploadTargetItemPhoto UploadTarget = "item_photo"
+
+	// UploadTargetIdentityDocument: Foto dokumen identitas customer (bucket customer, identity.document_url)
+	UploadTargetIdentityDocument UploadTarget = "identity_document"
+
+	// UploadTargetIdentityExtra: Foto tambahan dokumen (selfie/sisi belakang, identity.extra_photos)
+	UploadTargetIdentityExtra UploadTarget = "identity_extra"
+
+	// UploadTargetIdentityKTP: Alias lama dari identity_document (client lama)
+	UploadTargetIdentityKTP UploadTarget = "identity_ktp"
+)
+
+// ===================================================================
+// UPLOAD SESSION
+// ===================================================================
+
+// UploadSession adalah entity untuk satu sesi upload langsung ke bucket.
+// Server hanya menerbitkan presigned PUT URL; file tidak lewat proses API.
+//
+// Flow status:
+// - "pending": Presigned URL sudah diterbitkan, menunggu client upload + finalize
+// - "finalized": Objek sudah diverifikasi dan ditempel ke item/identity
+// - "failed": Objek tidak valid (ukuran/tipe), objek dihapus dari bucket
+//
+// Relasi:
+//   - UploadSession belongs to User (user_id, bisa hoster atau customer)
+//   - TargetID menunjuk ke item (item_photo), identity pending (identity_extra),
+//     atau kosong (identity_document, record identity dibuat saat finalize)
+type UploadSession struct {
+	ID          string       `json:"id" db:"id"`
+	UserID      string       `json:"user_id" db:"user_id"`
+	Target      UploadTarget `json:"target" db:"target"`
+	TargetID    *string      `json:"target_id" db:"target_id"`   // item_id / identity_id (nullable)
+	PhotoKind   *string      `json:"photo_kind" db:"photo_kind"` // "selfie" / "back" untuk identity_extra (nullable)
+	Bucket      string       `json:"bucket" db:"bucket"`
+	Path        string       `json:"path" db:"path"`                 // Path objek di bucket, ditentukan server
+	ContentType string       `json:"content_type" db:"content_type"` // Content-Type yang wajib dipakai saat PUT
+	Status      string       `json:"status" db:"status"`             // "pending", "finalized", "failed"
+	Reason      *string      `json:"reason" db:"reason"`             // Alasan gagal (nullable)
+	ExpiresAt   time.Time    `json:"expires_at" db:"expires_at"`     // Batas waktu finalize
+	FinalizedAt *time.Time   `json:"finalized_at" db:"finalized_at"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+}
@@ -30,20 +30,120 @@ type Admin struct {
 // Hoster adalah entity untuk user yang menyewakan item/property.
 // Hoster dapat membuat item, menerima booking, dan mengelola inventori.
 type Hoster struct {
-	ID           string    `json:"id" db:"id"`
-	FullName     string    `json:"full_name" db:"full_name"`
-	ProfilePhoto string    `json:"profile_photo" db:"profile_photo"`
-	StoreName    string    `json:"store_name" db:"store_name"`
-	Description  string    `json:"description" db:"description"`
-	Website      string    `json:"website,omitempty" db:"website"`
-	Instagram    string    `json:"instagram,omitempty" db:"instagram"`
-	Tiktok       string    `json:"tiktok,omitempty" db:"tiktok"`
-	PhoneNumber  string    `json:"phone_number" db:"phone_number"`
-	Email        string    `json:"email" db:"email"`
-	Address      string    `json:"address" db:"address"`
-	PasswordHash string    `json:"-" db:"password_hash"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           string     `json:"id" db:"id"`
+	FullName     string     `json:"full_name" db:"full_name"`
+	ProfilePhoto string     `json:"profile_photo" db:"profile_photo"`
+	BannerPhoto  string     `json:"banner_photo,omitempty" db:"banner_photo"` // Banner halaman toko (storefront)
+	StoreName    string     `json:"store_name" db:"store_name"`
+	Slug         string     `json:"slug" db:"slug"` // Slug storefront publik dari store_name, tidak berubah setelah dibuat
+	Description  string     `json:"description" db:"description"`
+	Website      string     `json:"website,omitempty" db:"website"`
+	Instagram    string     `json:"instagram,omitempty" db:"instagram"`
+	Tiktok       string     `json:"tiktok,omitempty" db:"tiktok"`
+	PhoneNumber  string     `json:"phone_number" db:"phone_number"`
+	Email        string     `json:"email" db:"email"`
+	Address      string     `json:"address" db:"address"`
+	PasswordHash string     `json:"-" db:"password_hash"`
+	IsVerified   bool       `json:"is_verified" db:"is_verified"` // true jika dokumen identitas terakhir di-approve admin
+	VerifiedAt   *time.Time `json:"verified_at,omitempty" db:"verified_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+
+	CalendarTokenHash      *string    `json:"-" db:"calendar_token_hash"`       // SHA-256 token feed iCal, nil = feed tidak aktif
+	CalendarTokenCreatedAt *time.Time `json:"-" db:"calendar_token_created_at"` // Waktu token feed iCal dibuat
+}
+
+// ===================================================================
+// HOSTER MEMBER (STAFF TOKO)
+// ===================================================================
+
+// HosterRole adalah peran user di dalam satu toko (hoster)
+type HosterRole string
+
+const (
+	// HosterRoleOwner: pemilik toko (akun hoster itu sendiri), akses penuh
+	HosterRoleOwner HosterRole = "owner"
+
+	// HosterRoleManager: kelola item, booking, revenue, dan pengaturan toko
+	HosterRoleManager HosterRole = "manager"
+
+	// HosterRoleStaff: staff counter, lihat item & booking, update status booking (serah terima barang)
+	HosterRoleStaff HosterRole = "staff"
+)
+
+// HosterPermission adalah hak akses granular di dalam toko
+type HosterPermission string
+
+const (
+	HosterPermItemsView      HosterPermission = "items:view"             // Lihat item & kategori
+	HosterPermItemsManage    HosterPermission = "items:manage"           // Buat, ubah, hapus, hide item
+	HosterPermBookingsView   HosterPermission = "bookings:view"          // Lihat booking & customer
+	HosterPermBookingsUpdate HosterPermission = "bookings:update_status" // Update status booking
+	HosterPermRevenueView    HosterPermission = "revenue:view"           // Analytics & export booking
+	HosterPermStoreManage    HosterPermission = "store:manage"           // Profil toko, T&C, feed kalender
+	HosterPermAccountVerify  HosterPermission = "account:verify"         // Verifikasi identitas toko (KYC)
+	HosterPermTeamManage     HosterPermission = "team:manage"            // Undang & kelola staff
+)
+
+// hosterRolePermissions adalah matriks role → permission
+var hosterRolePermissions = map[HosterRole][]HosterPermission{
+	HosterRoleOwner: {
+		HosterPermItemsView, HosterPermItemsManage, HosterPermBookingsView, HosterPermBookingsUpdate,
+		HosterPermRevenueView, HosterPermStoreManage, HosterPermAccountVerify, HosterPermTeamManage,
+	},
+	HosterRoleManager: {
+		HosterPermItemsView, HosterPermItemsManage, HosterPermBookingsView, HosterPermBookingsUpdate,
+		HosterPermRevenueView, HosterPermStoreManage,
+	},
+	HosterRoleStaff: {
+		HosterPermItemsView, HosterPermBookingsView, HosterPermBookingsUpdate,
+	},
+}
+
+// Permissions mengembalikan daftar permission milik role (kosong jika role tidak dikenal)
+func (r HosterRole) Permissions() []HosterPermission {
+	return hosterRolePermissions[r]
+}
+
+// Can mengecek apakah role memiliki permission tertentu
+func (r HosterRole) Can(p HosterPermission) bool {
+	for _, perm := range hosterRolePermissions[r] {
+		if perm == p {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAssignable mengecek apakah role boleh diberikan ke staff (owner tidak bisa diberikan)
+func (r HosterRole) IsAssignable() bool {
+	return r == HosterRoleManager || r == HosterRoleStaff
+}
+
+// HosterMemberStatus adalah status akun staff toko
+type HosterMemberStatus string
+
+const (
+	HosterMemberInvited  HosterMemberStatus = "invited"  // Undangan terkirim, password belum dibuat
+	HosterMemberActive   HosterMemberStatus = "active"   // Bisa login
+	HosterMemberDisabled HosterMemberStatus = "disabled" // Dinonaktifkan owner, token langsung ditolak
+)
+
+// HosterMember adalah akun staff yang login terpisah dan bertindak atas nama toko (hoster).
+// Setelah login, middleware.GetUserID mengembalikan ID toko (HosterID), bukan ID staff.
+type HosterMember struct {
+	ID              string             `json:"id" db:"id"`
+	HosterID        string             `json:"hoster_id" db:"hoster_id"`
+	FullName        string             `json:"full_name" db:"full_name"`
+	Email           string             `json:"email" db:"email"`
+	PasswordHash    *string            `json:"-" db:"password_hash"` // nil selama undangan belum diterima
+	Role            HosterRole         `json:"role" db:"role"`
+	Status          HosterMemberStatus `json:"status" db:"status"`
+	InviteTokenHash *string            `json:"-" db:"invite_token_hash"`
+	InviteExpiresAt *time.Time         `json:"invite_expires_at,omitempty" db:"invite_expires_at"`
+	LastLoginAt     *time.Time         `json:"last_login_at,omitempty" db:"last_login_at"`
+	CreatedAt       time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at" db:"updated_at"`
 }
 
 // ===================================================================
@@ -0,0 +1,150 @@
+// ===================================================================
+// File: analytics_dto.go
+// Deskripsi: DTO untuk Analytics Dashboard Hoster (revenue, utilisasi, top item)
+// Catatan: SEMUA DTO analytics HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// AnalyticsFilterByHosterRequest adalah filter periode analytics (dari query string)
+// Endpoint: GET /api/v1/hoster/analytics/*?from=2025-01-01&to=2025-06-30&store_id=uuid&granularity=month
+//
+// Catatan: from & to inklusif (YYYY-MM-DD), default 30 hari terakhir, maksimal 366 hari
+type AnalyticsFilterByHosterRequest struct {
+	From        string // YYYY-MM-DD (inklusif)
+	To          string // YYYY-MM-DD (inklusif)
+	StoreID     string // Opsional: analytics satu store saja, kosong = semua store
+	Granularity string // "day", "week", "month" (default), hanya untuk /revenue
+	Sort        string // "revenue" (default) atau "utilisation", hanya untuk /items
+	Limit       int    // Default 10, maksimal 100, hanya untuk /items
+}
+
+// ===================================================================
+// RESPONSE DTO
+// ===================================================================
+
+// AnalyticsPeriodResponse adalah periode yang dipakai untuk agregasi
+type AnalyticsPeriodResponse struct {
+	From time.Time `json:"from"` // Awal periode (inklusif)
+	To   time.Time `json:"to"`   // Akhir periode (eksklusif, hari setelah "to" di request)
+	Days int       `json:"days"`
+}
+
+// AnalyticsSummaryByHosterResponse adalah ringkasan kinerja toko dalam satu periode
+// Endpoint: GET /api/v1/hoster/analytics/summary
+//
+// Contoh JSON:
+//
+//	{
+//	  "period": {"from": "2025-11-01T00:00:00Z", "to": "2025-12-01T00:00:00Z", "days": 30},
+//	  "gross_rental": 4500000,
+//	  "discounts": 150000,
+//	  "net_rental": 4350000,
+//	  "deposits_collected": 3000000,
+//	  "deposits_held": 800000,
+//	  "bookings": 32,
+//	  "cancellations": 3,
+//	  "expired": 4,
+//	  "cancellation_rate": 0.0857,
+//	  "unique_customers": 25,
+//	  "repeat_customers": 6,
+//	  "repeat_customer_rate": 0.24,
+//	  "booked_unit_days": 180,
+//	  "stock_days": 900,
+//	  "utilisation": 0.2
+//	}
+//
+// Catatan:
+//   - Booking dihitung masuk periode berdasarkan start_date (tanggal mulai sewa)
+//   - bookings = booking efektif (on_progress, on_rent, completed)
+//   - cancellations = cancelled/rejected, expired = pending yang lewat batas pembayaran
+//   - cancellation_rate = (cancellations + expired) / (bookings + cancellations + expired)
+//   - deposits_held = deposit booking yang belum selesai (on_progress, on_rent)
+//   - repeat_customer = customer periode ini yang punya ≥ 2 booking efektif di toko ini (sampai akhir periode)
+//   - utilisation = booked_unit_days / stock_days (semua item toko, irisan tanggal sewa dengan periode)
+type AnalyticsSummaryByHosterResponse struct {
+	Period             AnalyticsPeriodResponse `json:"period"`
+	GrossRental        int64                   `json:"gross_rental" db:"gross_rental"`
+	Discounts          int64                   `json:"discounts" db:"discounts"`
+	NetRental          int64                   `json:"net_rental" db:"-"`
+	DepositsCollected  int64                   `json:"deposits_collected" db:"deposits_collected"`
+	DepositsHeld       int64                   `json:"deposits_held" db:"deposits_held"`
+	Bookings           int                     `json:"bookings" db:"bookings"`
+	Cancellations      int                     `json:"cancellations" db:"cancellations"`
+	Expired            int                     `json:"expired" db:"expired"`
+	CancellationRate   float64                 `json:"cancellation_rate" db:"-"`
+	UniqueCustomers    int                     `json:"unique_customers" db:"unique_customers"`
+	RepeatCustomers    int                     `json:"repeat_customers" db:"repeat_customers"`
+	RepeatCustomerRate float64                 `json:"repeat_customer_rate" db:"-"`
+	BookedUnitDays     int64                   `json:"booked_unit_days" db:"-"`
+	StockDays          int64                   `json:"stock_days" db:"-"`
+	Utilisation        float64                 `json:"utilisation" db:"-"`
+}
+
+// AnalyticsRevenuePointByHosterResponse adalah satu bucket time series revenue
+type AnalyticsRevenuePointByHosterResponse struct {
+	PeriodStart       time.Time `json:"period_start" db:"period_start"`
+	GrossRental       int64     `json:"gross_rental" db:"gross_rental"`
+	Discounts         int64     `json:"discounts" db:"discounts"`
+	DepositsCollected int64     `json:"deposits_collected" db:"deposits_collected"`
+	Bookings          int       `json:"bookings" db:"bookings"`
+	Cancellations     int       `json:"cancellations" db:"cancellations"`
+}
+
+// AnalyticsRevenueByHosterResponse adalah time series revenue per hari/minggu/bulan
+// Endpoint: GET /api/v1/hoster/analytics/revenue
+//
+// Contoh JSON:
+//
+//	{
+//	  "period": {"from": "2025-01-01T00:00:00Z", "to": "2025-07-01T00:00:00Z", "days": 181},
+//	  "granularity": "month",
+//	  "points": [
+//	    {"period_start": "2025-01-01T00:00:00Z", "gross_rental": 1200000, "discounts": 0, "deposits_collected": 500000, "bookings": 8, "cancellations": 1}
+//	  ]
+//	}
+//
+// Catatan: bucket tanpa booking tetap dikirim dengan nilai 0
+type AnalyticsRevenueByHosterResponse struct {
+	Period      AnalyticsPeriodResponse                 `json:"period"`
+	Granularity string                                  `json:"granularity"`
+	Points      []AnalyticsRevenuePointByHosterResponse `json:"points"`
+}
+
+// AnalyticsItemByHosterResponse adalah kinerja satu item dalam periode
+type AnalyticsItemByHosterResponse struct {
+	ItemID         string  `json:"item_id" db:"item_id"`
+	Name           string  `json:"name" db:"name"`
+	Stock          int     `json:"stock" db:"stock"`
+	Bookings       int     `json:"bookings" db:"bookings"`                 // Jumlah booking efektif yang memuat item ini
+	UnitsRented    int     `json:"units_rented" db:"units_rented"`         // Total quantity yang disewa
+	GrossRental    int64   `json:"gross_rental" db:"gross_rental"`         // Total subtotal_rental
+	BookedUnitDays int64   `json:"booked_unit_days" db:"booked_unit_days"` // quantity × hari sewa yang beririsan dengan periode
+	StockDays      int64   `json:"stock_days" db:"stock_days"`             // stock × jumlah hari periode
+	Utilisation    float64 `json:"utilisation" db:"utilisation"`           // booked_unit_days / stock_days
+}
+
+// AnalyticsItemsByHosterResponse adalah daftar item dengan revenue / utilisasi tertinggi
+// Endpoint: GET /api/v1/hoster/analytics/items
+//
+// Contoh JSON:
+//
+//	{
+//	  "period": {"from": "2025-11-01T00:00:00Z", "to": "2025-12-01T00:00:00Z", "days": 30},
+//	  "sort": "revenue",
+//	  "items": [
+//	    {"item_id": "uuid-item-1", "name": "Tenda Dome 4P", "stock": 3, "bookings": 9, "units_rented": 11,
+//	     "gross_rental": 1650000, "booked_unit_days": 30, "stock_days": 90, "utilisation": 0.3333}
+//	  ]
+//	}
+type AnalyticsItemsByHosterResponse struct {
+	Period AnalyticsPeriodResponse         `json:"period"`
+	Sort   string                          `json:"sort"`
+	Items  []AnalyticsItemByHosterResponse `json:"items"`
+}
@@ -148,6 +148,8 @@ type AuthResponse struct {
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int    `json:"expires_in"`
 	Role         string `json:"role"`
+	StoreID      string `json:"store_id,omitempty"`    // Khusus staff toko: ID hoster
+	MemberRole   string `json:"member_role,omitempty"` // Khusus staff toko: "manager" atau "staff"
 }
 
 // CreateCustomerResponse adalah response sukses setelah register customer
@@ -0,0 +1,115 @@
+// ===================================================================
+// File: booking_amendment_dto.go
+// Deskripsi: DTO untuk permintaan perubahan booking - perpanjangan & quantity (Customer & Hoster)
+// Catatan: SEMUA DTO perubahan booking HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - CUSTOMER
+// ===================================================================
+
+// BookingAmendmentItemRequest adalah quantity baru untuk satu booking_item
+type BookingAmendmentItemRequest struct {
+	BookingItemID string `json:"booking_item_id"`
+	Quantity      int    `json:"quantity"`
+}
+
+// CreateBookingAmendmentByCustomerRequest adalah payload permintaan perubahan booking
+// Endpoint: POST /api/v1/customer/booking/{id}/amendment
+//
+// Contoh JSON ("tambah satu hari dan satu tenda"):
+//
+//	{
+//	  "end_date": "2026-01-13",
+//	  "items": [{"booking_item_id": "uuid-booking-item", "quantity": 3}],
+//	  "note": "Mau lanjut camping satu malam lagi"
+//	}
+//
+// Catatan:
+// - end_date opsional (YYYY-MM-DD), kosong = tidak diperpanjang, jika diisi harus setelah end_date sekarang
+// - items opsional, booking_item yang tidak dikirim quantity-nya tetap
+// - quantity hanya bisa diubah sebelum barang diambil (on_progress)
+type CreateBookingAmendmentByCustomerRequest struct {
+	EndDate string                        `json:"end_date"`
+	Items   []BookingAmendmentItemRequest `json:"items"`
+	Note    string                        `json:"note"`
+}
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// DecideBookingAmendmentByHosterRequest adalah payload setuju / tolak perubahan booking
+// Endpoint: POST /api/v1/hoster/amendment/{id}/approve, POST /api/v1/hoster/amendment/{id}/reject
+//
+// Catatan: note opsional, ditampilkan ke customer (misal alasan penolakan)
+type DecideBookingAmendmentByHosterRequest struct {
+	Note string `json:"note"`
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER & CUSTOMER
+// ===================================================================
+
+// BookingAmendmentItemResponse adalah perubahan satu booking_item
+type BookingAmendmentItemResponse struct {
+	BookingItemID      string `json:"booking_item_id" db:"booking_item_id"`
+	ItemName           string `json:"item_name" db:"item_name"` // Snapshot nama dari booking_item
+	OldQuantity        int    `json:"old_quantity" db:"old_quantity"`
+	NewQuantity        int    `json:"new_quantity" db:"new_quantity"`
+	PricePerDay        int    `json:"price_per_day" db:"price_per_day"`       // Harga tambahan per unit per hari (sesuai kebijakan)
+	DepositPerUnit     int    `json:"deposit_per_unit" db:"deposit_per_unit"` // Deposit tambahan per unit (sesuai kebijakan)
+	OldSubtotalRental  int    `json:"old_subtotal_rental" db:"old_subtotal_rental"`
+	NewSubtotalRental  int    `json:"new_subtotal_rental" db:"new_subtotal_rental"`
+	OldSubtotalDeposit int    `json:"old_subtotal_deposit" db:"old_subtotal_deposit"`
+	NewSubtotalDeposit int    `json:"new_subtotal_deposit" db:"new_subtotal_deposit"`
+}
+
+// BookingAmendmentResponse adalah satu permintaan perubahan booking beserta jejak auditnya
+// Endpoint: GET /api/v1/customer/booking/{id}/amendment, GET /api/v1/hoster/booking/{id}/amendment,
+// GET /api/v1/hoster/amendment?status=pending
+//
+// Contoh JSON:
+//
+//	{
+//	  "id": "uuid-amendment",
+//	  "booking_id": "uuid-booking",
+//	  "status": "pending",
+//	  "pricing": "snapshot",
+//	  "old_end_date": "2026-01-12T00:00:00Z",
+//	  "new_end_date": "2026-01-13T00:00:00Z",
+//	  "old_total_days": 2,
+//	  "new_total_days": 3,
+//	  "old_total": 350000,
+//	  "new_total": 500000,
+//	  "total_difference": 150000,
+//	  "items": [...]
+//	}
+type BookingAmendmentResponse struct {
+	ID                string                         `json:"id" db:"id"`
+	BookingID         string                         `json:"booking_id" db:"booking_id"`
+	CustomerName      string                         `json:"customer_name,omitempty" db:"customer_name"` // Hanya di list hoster
+	Status            string                         `json:"status" db:"status"`
+	Pricing           string                         `json:"pricing" db:"pricing"` // snapshot / current
+	OldEndDate        time.Time                      `json:"old_end_date" db:"old_end_date"`
+	NewEndDate        time.Time                      `json:"new_end_date" db:"new_end_date"`
+	OldTotalDays      int                            `json:"old_total_days" db:"old_total_days"`
+	NewTotalDays      int                            `json:"new_total_days" db:"new_total_days"`
+	OldRental         int                            `json:"old_rental" db:"old_rental"`
+	NewRental         int                            `json:"new_rental" db:"new_rental"`
+	OldDeposit        int                            `json:"old_deposit" db:"old_deposit"`
+	NewDeposit        int                            `json:"new_deposit" db:"new_deposit"`
+	OldTotal          int                            `json:"old_total" db:"old_total"`
+	NewTotal          int                            `json:"new_total" db:"new_total"`
+	TotalDifference   int                            `json:"total_difference" db:"-"` // new_total - old_total (negatif = berkurang)
+	CustomerNote      *string                        `json:"customer_note" db:"customer_note"`
+	HosterNote        *string                        `json:"hoster_note" db:"hoster_note"`
+	DecidedByMemberID *string                        `json:"decided_by_member_id,omitempty" db:"decided_by_member_id"`
+	DecidedAt         *time.Time                     `json:"decided_at" db:"decided_at"`
+	CreatedAt         time.Time                      `json:"created_at" db:"created_at"`
+	Items             []BookingAmendmentItemResponse `json:"items" db:"-"`
+}
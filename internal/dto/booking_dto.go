@@ -21,10 +21,13 @@ import "time"
 //	{
 //	  "start_date": "2025-12-20",
 //	  "end_date": "2025-12-25",
+//	  "pickup_time": "09:00",
+//	  "return_time": "16:00",
 //	  "delivery_type": "self_pickup",
 //	  "items": [
 //	    {
 //	      "item_id": "uuid-item-123",
+//	      "variant_id": "uuid-variant-789",
 //	      "name": "Kamera DSLR Canon",
 //	      "quantity": 2,
 //	      "price_per_day": 100000,
@@ -33,6 +36,16 @@ import "time"
 //	      "subtotal_deposit": 1000000
 //	    }
 //	  ],
+//	  "bundles": [
+//	    {
+//	      "bundle_id": "uuid-bundle-321",
+//	      "quantity": 1,
+//	      "price_per_day": 150000,
+//	      "deposit_per_unit": 300000,
+//	      "subtotal_rental": 750000,
+//	      "subtotal_deposit": 300000
+//	    }
+//	  ],
 //	  "customer": {
 //	    "name": "Budi Santoso",
 //	    "phone": "081234567890",
@@ -40,6 +53,7 @@ import "time"
 //	    "delivery_address": "Jakarta Selatan",
 //	    "notes": "Tolong kirim pagi hari"
 //	  },
+//	  "accepted_tnc_version_ids": ["uuid-tnc-version-456"],
 //	  "delivery": 50000,
 //	  "discount": 0
 //	}
@@ -51,11 +65,25 @@ type CreateBookingByCustomerRequest struct {
 	Customer     CreateBookingCustomerByCustomerRequest `json:"customer"`
 	Delivery     int                                    `json:"delivery"`
 	Discount     int                                    `json:"discount"`
+
+	// AcceptedTnCVersionIDs adalah versi T&C yang disetujui customer (tnc_versions di detail item).
+	// Wajib sama persis dengan versi terbaru saat booking dibuat.
+	AcceptedTnCVersionIDs []string `json:"accepted_tnc_version_ids"`
+
+	// PickupTime & ReturnTime adalah slot serah terima (HH:MM) dari GET /customer/store/{id}/slots.
+	// Wajib jika store sudah mengatur jam buka, diabaikan jika belum.
+	PickupTime string `json:"pickup_time,omitempty"`
+	ReturnTime string `json:"return_time,omitempty"`
+
+	// Bundles adalah paket sewa (GET /public/bundle); setiap paket disimpan sebagai
+	// baris items per komponen. items boleh kosong jika bundles terisi.
+	Bundles []CreateBookingBundleByCustomerRequest `json:"bundles,omitempty"`
 }
 
 // CreateBookingItemByCustomerRequest adalah detail item dalam booking request
 type CreateBookingItemByCustomerRequest struct {
 	ItemID          string `json:"item_id"`
+	VariantID       string `json:"variant_id,omitempty"` // Wajib jika item punya varian (variants di detail item)
 	Name            string `json:"name"`
 	Quantity        int    `json:"quantity"`
 	PricePerDay     int    `json:"price_per_day"`
@@ -85,12 +113,24 @@ type CreateBookingCustomerByCustomerRequest struct {
 //	{
 //	  "booking": { ... },
 //	  "items": [ ... ],
-//	  "customer": { ... }
+//	  "customer": { ... },
+//	  "pickup": { "code": "LP1....", "qr_url": "/api/v1/customer/booking/{id}/pickup-qr" }
 //	}
+//
+// Catatan: pickup hanya ada saat booking sudah dibayar (on_progress) dan kode belum dipakai
 type BookingDetailByCustomerResponse struct {
-	Booking  BookingInfoResponse   `json:"booking"`
-	Items    []BookingItemResponse `json:"items"`
-	Customer CustomerInfoResponse  `json:"customer"`
+	Booking       BookingInfoResponse           `json:"booking"`
+	Items         []BookingItemResponse         `json:"items"`
+	Customer      CustomerInfoResponse          `json:"customer"`
+	Pickup        *PickupCodeByCustomerResponse `json:"pickup,omitempty"`
+	AcceptedTerms []TnCVersionResponse          `json:"accepted_terms"` // Versi T&C yang disetujui saat booking
+}
+
+// PickupCodeByCustomerResponse adalah kode pickup sekali pakai yang ditunjukkan customer ke hoster
+// QR: GET /customer/booking/{id}/pickup-qr?format=png|svg
+type PickupCodeByCustomerResponse struct {
+	Code  string `json:"code"`   // Kode bertanda tangan (isi QR), bisa diketik manual jika kamera bermasalah
+	QRURL string `json:"qr_url"` // Path gambar QR (PNG default, ?format=svg untuk SVG)
 }
 
 // BookingListByCustomerResponse adalah response untuk list booking customer
@@ -114,27 +154,75 @@ type BookingListByCustomerResponse struct {
 //
 // Sama seperti customer, tapi hoster juga bisa lihat data KTP customer
 type BookingDetailByHosterResponse struct {
-	Booking  BookingInfoResponse   `json:"booking"`
-	Items    []BookingItemResponse `json:"items"`
-	Customer CustomerInfoResponse  `json:"customer"` // Termasuk KTP jika sudah verified
+	Booking       BookingInfoResponse   `json:"booking"`
+	Items         []BookingItemResponse `json:"items"`
+	Customer      CustomerInfoResponse  `json:"customer"`       // Termasuk KTP jika sudah verified
+	AcceptedTerms []TnCVersionResponse  `json:"accepted_terms"` // Versi T&C yang disetujui customer saat booking
 }
 
 // BookingListByHosterResponse adalah response untuk list booking hoster
 // Endpoint: GET /hoster/booking
 type BookingListByHosterResponse struct {
-	BookingID    string    `json:"booking_id" db:"booking_id"`
-	CustomerName string    `json:"customer_name" db:"customer_name"`
-	ItemName     string    `json:"item_name" db:"item_name"`
-	Quantity     int       `json:"quantity" db:"quantity"`
-	StartDate    time.Time `json:"start_date" db:"start_date"`
-	EndDate      time.Time `json:"end_date" db:"end_date"`
-	Total        float64   `json:"total" db:"total"`
-	Status       string    `json:"status" db:"status"`
+	BookingID     string    `json:"booking_id" db:"booking_id"`
+	CustomerName  string    `json:"customer_name" db:"customer_name"`
+	CustomerPhone string    `json:"customer_phone" db:"customer_phone"`
+	ItemName      string    `json:"item_name" db:"item_name"`
+	Quantity      int       `json:"quantity" db:"quantity"`
+	StartDate     time.Time `json:"start_date" db:"start_date"`
+	EndDate       time.Time `json:"end_date" db:"end_date"`
+	Total         float64   `json:"total" db:"total"`
+	Status        string    `json:"status" db:"status"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// BookingListPageByHosterResponse adalah response list booking hoster (cursor pagination)
+// Endpoint: GET /hoster/booking
+//
+// Contoh JSON:
+//
+//	{
+//	  "items": [{"booking_id": "uuid-booking-1", "customer_name": "Budi Santoso", "status": "on_rent", ...}],
+//	  "limit": 20,
+//	  "next_cursor": "eyJ2IjoiMjAyNS0xMi0wMVQxMDowMDowMCIsImlkIjoidXVpZCJ9",
+//	  "has_more": true
+//	}
+//
+// Catatan: kirim next_cursor sebagai query ?cursor= (dengan filter & sort yang sama) untuk halaman berikutnya
+type BookingListPageByHosterResponse struct {
+	Items      []BookingListByHosterResponse `json:"items"`
+	Limit      int                           `json:"limit"`
+	NextCursor string                        `json:"next_cursor,omitempty"`
+	HasMore    bool                          `json:"has_more"`
+}
+
+// BookingExportRowByHosterResponse adalah satu baris file export booking hoster (CSV / XLSX)
+// Endpoint: GET /hoster/booking/export
+type BookingExportRowByHosterResponse struct {
+	BookingID     string    `db:"booking_id"`
+	CreatedAt     time.Time `db:"created_at"`
+	Status        string    `db:"status"`
+	CustomerName  string    `db:"customer_name"`
+	CustomerPhone string    `db:"customer_phone"`
+	CustomerEmail string    `db:"customer_email"`
+	ItemName      string    `db:"item_name"`
+	Quantity      int       `db:"quantity"`
+	StartDate     time.Time `db:"start_date"`
+	EndDate       time.Time `db:"end_date"`
+	TotalDays     int       `db:"total_days"`
+	DeliveryType  string    `db:"delivery_type"`
+	Rental        int       `db:"rental"`
+	Deposit       int       `db:"deposit"`
+	Discount      int       `db:"discount"`
+	Total         int       `db:"total"`
+	Outstanding   int       `db:"outstanding"`
 }
 
 // CustomerListByHosterResponse adalah response untuk list customer yang pernah booking
 // Endpoint: GET /hoster/booking/customers
-type CustomerListByHosterResponse = CustomerInfoResponse
+type CustomerListByHosterResponse struct {
+	CustomerInfoResponse
+	Blocked bool `json:"blocked" db:"blocked"` // true jika customer diblokir hoster
+}
 
 // ===================================================================
 // SHARED RESPONSE DTO (dipakai customer & hoster)
@@ -156,6 +244,10 @@ type BookingInfoResponse struct {
 	Discount             int        `json:"discount"`
 	Total                int        `json:"total"`
 	Outstanding          int        `json:"outstanding"`
+	LateFee              int        `json:"late_fee"`
+	OverdueAt            *time.Time `json:"overdue_at,omitempty"`
+	PickupTime           *string    `json:"pickup_time,omitempty"` // Slot pickup (HH:MM) di start_date
+	ReturnTime           *string    `json:"return_time,omitempty"` // Slot pengembalian (HH:MM) di end_date
 	Status               string     `json:"status"`
 	LockedUntil          *time.Time `json:"locked_until,omitempty"`
 	TimeRemainingMinutes int        `json:"time_remaining_minutes,omitempty"`
@@ -169,6 +261,11 @@ type BookingItemResponse struct {
 	ID              string   `json:"id" db:"id"`
 	BookingID       string   `json:"booking_id" db:"booking_id"`
 	ItemID          string   `json:"item_id" db:"item_id"`
+	VariantID       *string  `json:"variant_id,omitempty" db:"variant_id"`           // Varian yang dipesan (item bervarian)
+	VariantName     *string  `json:"variant_name,omitempty" db:"variant_name"`       // Nama varian saat booking, misal "40 / Hitam"
+	BundleID        *string  `json:"bundle_id,omitempty" db:"bundle_id"`             // Paket asal baris komponen ini
+	BundleName      *string  `json:"bundle_name,omitempty" db:"bundle_name"`         // Nama paket saat booking
+	BundleQuantity  *int     `json:"bundle_quantity,omitempty" db:"bundle_quantity"` // Jumlah paket yang disewa
 	Name            string   `json:"name" db:"name"`
 	Description     string   `json:"description,omitempty" db:"description"`
 	Photos          []string `json:"photos,omitempty" db:"photos"`
@@ -177,21 +274,24 @@ type BookingItemResponse struct {
 	DepositPerUnit  int      `json:"deposit_per_unit" db:"deposit_per_unit"`
 	SubtotalRental  int      `json:"subtotal_rental" db:"subtotal_rental"`
 	SubtotalDeposit int      `json:"subtotal_deposit" db:"subtotal_deposit"`
+
+	Units []BookingItemUnitResponse `json:"units,omitempty" db:"-"` // Unit fisik yang diserahkan (item dengan unit tracking)
 }
 
 // CustomerInfoResponse berisi informasi dasar customer yang aman untuk ditampilkan
 // Digunakan di berbagai tempat: booking detail, customer list, dll
 type CustomerInfoResponse struct {
-	ID          string     `json:"id" db:"id"`
-	FullName    string     `json:"full_name" db:"full_name"`
-	Email       string     `json:"email" db:"email"`
-	PhoneNumber string     `json:"phone_number" db:"phone_number"`
-	KTPID       string     `json:"ktp_id,omitempty" db:"ktp_id"`
-	KTPPhoto    string     `json:"ktp_photo,omitempty" db:"ktp_photo"`
-	Status      string     `json:"status,omitempty" db:"status"` // Status KTP: pending, approved, rejected
-	Reason      string     `json:"reason,omitempty" db:"reason"` // Alasan approve/reject KTP
-	UploadedAt  *time.Time `json:"uploaded_at,omitempty" db:"uploaded_at"`
-	VerifiedAt  *time.Time `json:"verified_at,omitempty" db:"verified_at"` // Waktu admin verify KTP
+	ID           string     `json:"id" db:"id"`
+	FullName     string     `json:"full_name" db:"full_name"`
+	Email        string     `json:"email" db:"email"`
+	PhoneNumber  string     `json:"phone_number" db:"phone_number"`
+	KTPID        string     `json:"ktp_id,omitempty" db:"ktp_id"`               // ID identity (nama lama dipertahankan)
+	KTPPhoto     string     `json:"ktp_photo,omitempty" db:"ktp_photo"`         // URL foto dokumen (nama lama dipertahankan)
+	DocumentType string     `json:"document_type,omitempty" db:"document_type"` // Jenis dokumen: ktp, sim, passport, kitas
+	Status       string     `json:"status,omitempty" db:"status"`               // Status dokumen: pending, approved, rejected
+	Reason       string     `json:"reason,omitempty" db:"reason"`               // Alasan approve/reject dokumen
+	UploadedAt   *time.Time `json:"uploaded_at,omitempty" db:"uploaded_at"`
+	VerifiedAt   *time.Time `json:"verified_at,omitempty" db:"verified_at"` // Waktu admin verify KTP
 }
 
 // ===================================================================
@@ -209,8 +309,50 @@ type CustomerInfoResponse struct {
 //
 // Valid status transitions:
 // - pending → on_progress (hoster siapkan barang)
-// - on_progress → on_rent (barang sudah diserahkan ke customer)
 // - on_rent → completed (barang dikembalikan, kondisi OK)
+//
+// on_progress → on_rent (serah terima) hanya lewat POST /hoster/booking/pickup/verify.
+// Item dengan unit tracking: returns mencatat kondisi unit saat kembali (kosong → kondisi tidak berubah)
 type UpdateBookingStatusByHosterRequest struct {
-	Status string `json:"status"` // "on_progress", "on_rent", "completed"
+	Status  string                     `json:"status"` // "on_progress", "completed"
+	Returns []BookingUnitReturnRequest `json:"returns,omitempty"`
+}
+
+// VerifyPickupCodeByHosterRequest adalah payload verifikasi kode pickup hasil scan QR
+// Endpoint: POST /hoster/booking/pickup/verify
+//
+// Contoh JSON:
+//
+//	{
+//	  "code": "LP1.xxxx.yyyy",
+//	  "units": [{"booking_item_id": "uuid-booking-item", "unit_ids": ["uuid-unit-1"]}]
+//	}
+//
+// Kode valid → booking on_progress langsung menjadi on_rent.
+// Item dengan unit tracking: units memilih unit per booking_item (kosong → unit available dipilih otomatis)
+type VerifyPickupCodeByHosterRequest struct {
+	Code  string                         `json:"code"`
+	Units []BookingUnitAllocationRequest `json:"units,omitempty"`
+}
+
+// BookingListFilterByHosterRequest adalah filter list & export booking hoster (dari query string)
+// Endpoint: GET /hoster/booking?status=on_progress,on_rent&from=2025-12-01&to=2025-12-31&item_id=uuid&store_id=uuid&q=budi&sort=start_date&order=asc&limit=20&cursor=...
+// Endpoint: GET /hoster/booking/export?format=xlsx&<filter yang sama>
+//
+// Catatan:
+//   - from & to inklusif (YYYY-MM-DD), booking masuk jika periode sewanya beririsan dengan rentang tersebut
+//   - q mencari nama / nomor HP customer (snapshot booking_customer maupun akun customer)
+//   - cursor hanya berlaku untuk sort & order yang sama dengan halaman sebelumnya
+type BookingListFilterByHosterRequest struct {
+	Status     []string // "pending", "on_progress", "on_rent", "completed", ... (kosong = semua)
+	From       string   // YYYY-MM-DD (inklusif)
+	To         string   // YYYY-MM-DD (inklusif)
+	ItemID     string   // Booking yang memuat item ini
+	CustomerID string   // Booking milik customer ini
+	StoreID    string   // Booking di store (cabang) ini
+	Search     string   // Query "q"
+	Sort       string   // "created_at" (default), "start_date", "total"
+	Order      string   // "desc" (default) atau "asc"
+	Limit      int      // Default 20, maksimal 100 (tidak berlaku untuk export)
+	Cursor     string   // next_cursor dari halaman sebelumnya
 }
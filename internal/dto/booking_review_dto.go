@@ -0,0 +1,48 @@
+// ===================================================================
+// File: booking_review_dto.go
+// Deskripsi: DTO untuk ulasan booking (Customer) dan ringkasan rating toko
+// Catatan: SEMUA DTO ulasan booking HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - CUSTOMER
+// ===================================================================
+
+// CreateBookingReviewByCustomerRequest adalah payload ulasan customer untuk booking yang selesai
+// Endpoint: POST /api/v1/customer/booking/{id}/review
+//
+// Contoh JSON:
+//
+//	{
+//	  "rating": 5,
+//	  "comment": "Tenda bersih dan lengkap, pengembalian cepat"
+//	}
+//
+// Catatan: rating 1 - 5, comment opsional (maksimal 1000 karakter), satu ulasan per booking
+type CreateBookingReviewByCustomerRequest struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+// ===================================================================
+// RESPONSE DTO
+// ===================================================================
+
+// BookingReviewResponse adalah ulasan yang tersimpan
+type BookingReviewResponse struct {
+	ID        string    `json:"id" db:"id"`
+	BookingID string    `json:"booking_id" db:"booking_id"`
+	Rating    int       `json:"rating" db:"rating"`
+	Comment   *string   `json:"comment" db:"comment"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// StorefrontRatingResponse adalah ringkasan rating toko dari ulasan booking selesai
+type StorefrontRatingResponse struct {
+	Average *float64 `json:"average" db:"average"` // Rata-rata bintang 1-5 (1 desimal), null jika belum ada ulasan
+	Count   int      `json:"count" db:"count"`
+}
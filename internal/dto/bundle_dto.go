@@ -0,0 +1,151 @@
+// ===================================================================
+// File: bundle_dto.go
+// Deskripsi: DTO untuk paket sewa (bundle) berisi beberapa item (Hoster, Public & Customer)
+// Catatan: SEMUA DTO paket sewa HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// BundleByHosterRequest adalah payload membuat / mengubah paket sewa
+// Endpoint: POST /api/v1/hoster/bundle, PUT /api/v1/hoster/bundle/{id}
+//
+// Contoh JSON:
+//
+//	{
+//	  "store_id": "uuid-store-1",
+//	  "name": "Paket Camping 2 Orang",
+//	  "description": "Tenda dome, 2 sleeping bag, dan kompor portable",
+//	  "price_per_day": 150000,
+//	  "deposit": 300000,
+//	  "is_hidden": false,
+//	  "items": [
+//	    {"item_id": "uuid-tenda", "quantity": 1},
+//	    {"item_id": "uuid-sleeping-bag", "quantity": 2},
+//	    {"item_id": "uuid-kompor", "quantity": 1}
+//	  ]
+//	}
+//
+// Catatan:
+//   - store_id kosong = store default (hanya saat membuat paket, diabaikan saat update)
+//   - items menggantikan seluruh komposisi lama, semua item wajib dari store paket dan tidak bervarian
+//   - paket minimal berisi 2 unit (misal 2 item berbeda, atau 1 item × 2)
+type BundleByHosterRequest struct {
+	StoreID     string                      `json:"store_id,omitempty"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	PricePerDay int                         `json:"price_per_day"`
+	Deposit     int                         `json:"deposit"`
+	IsHidden    bool                        `json:"is_hidden"`
+	Items       []BundleItemByHosterRequest `json:"items"`
+}
+
+// BundleItemByHosterRequest adalah satu komponen di BundleByHosterRequest
+type BundleItemByHosterRequest struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"` // Unit per satu paket
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER & PUBLIC
+// ===================================================================
+
+// BundleItemResponse adalah satu komponen paket beserta data item saat ini
+type BundleItemResponse struct {
+	ItemID      string   `json:"item_id" db:"item_id"`
+	Name        string   `json:"name" db:"name"`
+	Photos      []string `json:"photos" db:"-"`
+	Quantity    int      `json:"quantity" db:"quantity"`           // Unit per satu paket
+	Stock       int      `json:"stock" db:"stock"`                 // Stock item
+	PricePerDay int      `json:"price_per_day" db:"price_per_day"` // Harga satuan item jika disewa terpisah
+	Deposit     int      `json:"deposit" db:"deposit"`             // Deposit satuan item jika disewa terpisah
+}
+
+// BundleByHosterResponse adalah detail paket sewa untuk hoster
+// Endpoint: GET /api/v1/hoster/bundle, GET /api/v1/hoster/bundle/{id}
+type BundleByHosterResponse struct {
+	ID          string               `json:"id" db:"id"`
+	StoreID     string               `json:"store_id" db:"tenant_id"`
+	Name        string               `json:"name" db:"name"`
+	Description string               `json:"description" db:"description"`
+	PricePerDay int                  `json:"price_per_day" db:"price_per_day"`
+	Deposit     int                  `json:"deposit" db:"deposit"`
+	IsHidden    bool                 `json:"is_hidden" db:"is_hidden"`
+	ItemsValue  int                  `json:"items_value" db:"-"` // Total harga per hari jika semua komponen disewa terpisah
+	Items       []BundleItemResponse `json:"items" db:"-"`
+	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// BundlePublicResponse adalah paket sewa di katalog publik
+// Endpoint: GET /api/v1/public/bundle?store_id=uuid
+//
+// Contoh JSON:
+//
+//	{
+//	  "id": "uuid-bundle-1",
+//	  "name": "Paket Camping 2 Orang",
+//	  "description": "Tenda dome, 2 sleeping bag, dan kompor portable",
+//	  "photos": ["https://storage.com/tenda.jpg", "https://storage.com/sleeping-bag.jpg"],
+//	  "price_per_day": 150000,
+//	  "deposit": 300000,
+//	  "items_value": 185000,
+//	  "available": 3,
+//	  "store_id": "uuid-store-1",
+//	  "hoster_id": "uuid-hoster-1",
+//	  "hoster_verified": true,
+//	  "items": [{"item_id": "uuid-tenda", "name": "Tenda Dome", "quantity": 1, ...}]
+//	}
+type BundlePublicResponse struct {
+	ID             string               `json:"id" db:"id"`
+	Name           string               `json:"name" db:"name"`
+	Description    string               `json:"description" db:"description"`
+	Photos         []string             `json:"photos" db:"-"` // Foto pertama setiap komponen
+	PricePerDay    int                  `json:"price_per_day" db:"price_per_day"`
+	Deposit        int                  `json:"deposit" db:"deposit"`
+	ItemsValue     int                  `json:"items_value" db:"-"` // Total harga per hari jika komponen disewa terpisah
+	Available      int                  `json:"available" db:"-"`   // Jumlah paket maksimal menurut stock komponen
+	StoreID        string               `json:"store_id" db:"store_id"`
+	HosterID       string               `json:"hoster_id" db:"hoster_id"`
+	HosterVerified bool                 `json:"hoster_verified" db:"hoster_verified"`
+	Items          []BundleItemResponse `json:"items" db:"-"`
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// BundleDetailPublicResponse adalah detail paket sewa publik
+// Endpoint: GET /api/v1/public/bundle/{id}
+//
+// Catatan:
+//   - booked_dates = tanggal (mulai hari ini) di mana minimal satu komponen tidak cukup untuk satu paket
+//   - tnc_versions wajib disetujui saat booking (T&C store / umum hoster + T&C khusus komponen)
+type BundleDetailPublicResponse struct {
+	Bundle      BundlePublicResponse `json:"bundle"`
+	Store       StorePublicResponse  `json:"store"`
+	TnCVersions []TnCVersionResponse `json:"tnc_versions"`
+	BookedDates []string             `json:"booked_dates"`
+}
+
+// ===================================================================
+// REQUEST DTO - CUSTOMER
+// ===================================================================
+
+// CreateBookingBundleByCustomerRequest adalah paket sewa dalam booking request
+// (lihat CreateBookingByCustomerRequest.Bundles)
+//
+// Catatan:
+//   - price_per_day & deposit_per_unit harus sama dengan harga paket saat ini
+//   - subtotal_rental = quantity × price_per_day × total hari, subtotal_deposit = quantity × deposit_per_unit
+type CreateBookingBundleByCustomerRequest struct {
+	BundleID        string `json:"bundle_id"`
+	Quantity        int    `json:"quantity"` // Jumlah paket
+	PricePerDay     int    `json:"price_per_day"`
+	DepositPerUnit  int    `json:"deposit_per_unit"`
+	SubtotalRental  int    `json:"subtotal_rental"`
+	SubtotalDeposit int    `json:"subtotal_deposit"`
+}
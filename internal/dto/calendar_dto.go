@@ -0,0 +1,32 @@
+// ===================================================================
+// File: calendar_dto.go
+// Deskripsi: DTO untuk Feed iCalendar (.ics) booking hoster
+// Catatan: SEMUA DTO calendar feed HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// RESPONSE DTO - HOSTER
+// ===================================================================
+
+// CalendarFeedByHosterResponse adalah status link feed iCal milik hoster
+// Endpoint: GET /hoster/profile/calendar, POST /hoster/profile/calendar
+//
+// Contoh JSON (setelah POST):
+//
+//	{
+//	  "active": true,
+//	  "created_at": "2025-12-01T10:00:00Z",
+//	  "feed_url": "https://api.lalan.id/api/v1/calendar/Zx8...Q.ics"
+//	}
+//
+// Catatan: feed_url hanya dikirim sekali saat link dibuat (token tidak disimpan dalam bentuk asli).
+// Jika link hilang, buat ulang via POST (link lama otomatis tidak berlaku).
+type CalendarFeedByHosterResponse struct {
+	Active    bool       `json:"active"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	FeedURL   string     `json:"feed_url,omitempty"`
+}
@@ -0,0 +1,85 @@
+// ===================================================================
+// File: customer_block_dto.go
+// Deskripsi: DTO untuk daftar blokir customer (Hoster & Admin)
+// Catatan: SEMUA DTO blokir customer HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// BlockCustomerByHosterRequest adalah payload blokir customer
+// Endpoint: PUT /api/v1/hoster/customer/{id}/block
+//
+// Contoh JSON:
+//
+//	{
+//	  "note": "Tenda dikembalikan sobek, tidak mau ganti rugi",
+//	  "include_document": true
+//	}
+//
+// Catatan:
+// - note opsional, hanya terlihat oleh hoster
+// - include_document = true → akun lain dengan dokumen identitas terverifikasi yang sama ikut diblokir
+type BlockCustomerByHosterRequest struct {
+	Note            string `json:"note"`
+	IncludeDocument bool   `json:"include_document"`
+}
+
+// ===================================================================
+// REQUEST DTO - ADMIN
+// ===================================================================
+
+// CustomerBlockFilterByAdminRequest adalah filter daftar risiko customer (dari query string)
+type CustomerBlockFilterByAdminRequest struct {
+	MinCount int // Minimal jumlah hoster yang memblokir, default 1
+	Page     int // Default 1
+	Limit    int // Default 20, maksimal 100
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER
+// ===================================================================
+
+// CustomerBlockByHosterResponse adalah satu customer di daftar blokir hoster
+// Endpoint: GET /api/v1/hoster/customer/blocked, PUT /api/v1/hoster/customer/{id}/block
+type CustomerBlockByHosterResponse struct {
+	CustomerID        string    `json:"customer_id" db:"customer_id"`
+	FullName          string    `json:"full_name" db:"full_name"`
+	Email             string    `json:"email" db:"email"`
+	PhoneNumber       string    `json:"phone_number" db:"phone_number"`
+	DocumentType      *string   `json:"document_type" db:"document_type"` // Terisi jika dokumen identitas ikut diblokir
+	Note              *string   `json:"note" db:"note"`
+	BlockedByMemberID *string   `json:"blocked_by_member_id,omitempty" db:"blocked_by_member_id"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ===================================================================
+// RESPONSE DTO - ADMIN
+// ===================================================================
+
+// CustomerBlockSummaryByAdminResponse adalah jumlah blokir satu customer dari seluruh hoster
+// Catatan hoster tidak ditampilkan ke admin
+type CustomerBlockSummaryByAdminResponse struct {
+	CustomerID    string    `json:"customer_id" db:"customer_id"`
+	FullName      string    `json:"full_name" db:"full_name"`
+	Email         string    `json:"email" db:"email"`
+	BlockCount    int       `json:"block_count" db:"block_count"`       // Jumlah hoster yang memblokir
+	DocumentCount int       `json:"document_count" db:"document_count"` // Blokir yang juga berlaku untuk dokumen identitas
+	LastBlockedAt time.Time `json:"last_blocked_at" db:"last_blocked_at"`
+}
+
+// CustomerBlockListByAdminResponse adalah daftar risiko customer berdasarkan jumlah blokir (paginated)
+// Endpoint: GET /api/v1/admin/customer-block?min_count=2&page=1&limit=20
+type CustomerBlockListByAdminResponse struct {
+	Items      []CustomerBlockSummaryByAdminResponse `json:"items"`
+	Page       int                                   `json:"page"`
+	Limit      int                                   `json:"limit"`
+	Total      int                                   `json:"total"`
+	TotalPages int                                   `json:"total_pages"`
+}
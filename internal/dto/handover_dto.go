@@ -0,0 +1,113 @@
+// ===================================================================
+// File: handover_dto.go
+// Deskripsi: DTO untuk berita acara serah terima barang booking (Hoster & Customer)
+// Catatan: SEMUA DTO handover HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// HandoverChecklistItem adalah satu poin pemeriksaan barang
+type HandoverChecklistItem struct {
+	Label   string `json:"label"`
+	Checked bool   `json:"checked"`
+}
+
+// SubmitHandoverItemByHosterRequest adalah kondisi satu baris booking_item saat serah terima
+type SubmitHandoverItemByHosterRequest struct {
+	BookingItemID string                  `json:"booking_item_id"`
+	Condition     string                  `json:"condition"` // good, fair, damaged
+	Notes         string                  `json:"notes"`
+	Checklist     []HandoverChecklistItem `json:"checklist"`
+	Photos        []string                `json:"photos"` // URL foto lama yang dipertahankan (saat mengisi ulang)
+}
+
+// SubmitHandoverByHosterRequest adalah payload handover pickup / return
+// Endpoint: POST /api/v1/hoster/booking/{id}/handover/{kind} (multipart)
+//
+// Field multipart:
+//   - data: JSON seperti contoh di bawah
+//   - photos_{booking_item_id}: foto baru untuk booking_item tersebut (boleh lebih dari satu)
+//
+// Contoh JSON (field data):
+//
+//	{
+//	  "notes": "Diambil sendiri oleh customer",
+//	  "items": [
+//	    {
+//	      "booking_item_id": "uuid-booking-item",
+//	      "condition": "good",
+//	      "notes": "Ada goresan kecil di sisi kiri",
+//	      "checklist": [{"label": "Frame lengkap", "checked": true}, {"label": "Pasak 12 pcs", "checked": true}]
+//	    }
+//	  ]
+//	}
+type SubmitHandoverByHosterRequest struct {
+	Notes string                              `json:"notes"`
+	Items []SubmitHandoverItemByHosterRequest `json:"items"`
+}
+
+// ===================================================================
+// REQUEST DTO - CUSTOMER
+// ===================================================================
+
+// ConfirmHandoverByCustomerRequest adalah payload konfirmasi handover oleh customer
+// Endpoint: POST /api/v1/customer/booking/{id}/handover/{kind}/confirm
+//
+// Catatan: note opsional, dipakai untuk mencatat keberatan atas kondisi yang dicatat hoster
+type ConfirmHandoverByCustomerRequest struct {
+	Note string `json:"note"`
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER & CUSTOMER
+// ===================================================================
+
+// BookingHandoverItemResponse adalah kondisi satu booking_item di handover
+type BookingHandoverItemResponse struct {
+	BookingItemID string                  `json:"booking_item_id" db:"booking_item_id"`
+	ItemName      string                  `json:"item_name" db:"item_name"` // Snapshot nama dari booking_item
+	Condition     string                  `json:"condition" db:"condition"`
+	Notes         *string                 `json:"notes" db:"notes"`
+	Checklist     []HandoverChecklistItem `json:"checklist" db:"-"`
+	Photos        []string                `json:"photos" db:"-"`
+}
+
+// BookingHandoverResponse adalah satu berita acara serah terima
+type BookingHandoverResponse struct {
+	ID                  string                        `json:"id" db:"id"`
+	Kind                string                        `json:"kind" db:"kind"` // pickup / return
+	Notes               *string                       `json:"notes" db:"notes"`
+	RecordedByMemberID  *string                       `json:"recorded_by_member_id" db:"recorded_by_member_id"`
+	HosterConfirmedAt   time.Time                     `json:"hoster_confirmed_at" db:"hoster_confirmed_at"`
+	CustomerConfirmedAt *time.Time                    `json:"customer_confirmed_at" db:"customer_confirmed_at"`
+	CustomerNote        *string                       `json:"customer_note" db:"customer_note"`
+	UpdatedAt           time.Time                     `json:"updated_at" db:"updated_at"`
+	Items               []BookingHandoverItemResponse `json:"items" db:"-"`
+}
+
+// HandoverComparisonResponse membandingkan kondisi satu booking_item saat pickup dan return
+type HandoverComparisonResponse struct {
+	BookingItemID        string   `json:"booking_item_id" db:"booking_item_id"`
+	ItemName             string   `json:"item_name" db:"item_name"`
+	PickupCondition      *string  `json:"pickup_condition" db:"pickup_condition"`
+	ReturnCondition      *string  `json:"return_condition" db:"return_condition"`
+	ConditionChanged     bool     `json:"condition_changed" db:"condition_changed"`
+	ChecklistRegressions []string `json:"checklist_regressions" db:"-"` // Poin yang dicentang saat pickup tapi tidak saat return
+}
+
+// BookingHandoversResponse adalah handover pickup & return sebuah booking beserta perbandingannya
+// Endpoint: GET /api/v1/hoster/booking/{id}/handover, GET /api/v1/customer/booking/{id}/handover
+//
+// Catatan: comparison hanya terisi jika handover return sudah ada
+type BookingHandoversResponse struct {
+	BookingID  string                       `json:"booking_id"`
+	Pickup     *BookingHandoverResponse     `json:"pickup"`
+	Return     *BookingHandoverResponse     `json:"return"`
+	Comparison []HandoverComparisonResponse `json:"comparison"`
+}
@@ -1,6 +1,6 @@
 // ===================================================================
 // File: identity_dto.go
-// Deskripsi: DTO untuk Identity/KTP (Customer & Admin)
+// Deskripsi: DTO untuk Identity - KTP, SIM, Paspor, KITAS (Customer, Hoster & Admin)
 // Catatan: SEMUA DTO identity HANYA di file ini!
 // ===================================================================
 
@@ -12,27 +12,77 @@ import "time"
 // REQUEST DTO - CUSTOMER
 // ===================================================================
 
-// UploadIdentityByCustomerRequest adalah payload saat customer upload KTP pertama kali
-// Endpoint: POST /customer/identity/upload
+// UploadIdentityByCustomerRequest adalah payload saat customer upload dokumen identitas
+// Endpoint: POST /customer/identity (multipart/form-data)
+//
+// Form field:
+//   - document_type    : "ktp" (default), "sim", "passport", "kitas"
+//   - document_number  : NIK / nomor SIM / nomor paspor / nomor KITAS (field lama "nik" tetap diterima)
+//   - document_country : ISO 3166-1 alpha-3, wajib untuk passport & kitas (contoh: "AUS")
+//   - document_expiry  : YYYY-MM-DD, wajib untuk sim, passport, kitas
+//   - document         : foto dokumen sisi depan (field lama "ktp" tetap diterima)
+//   - selfie, back     : foto tambahan opsional (selfie memegang dokumen, sisi belakang)
 //
 // Catatan: File upload dilakukan via multipart/form-data, service akan dapat URL setelah upload
 type UploadIdentityByCustomerRequest struct {
-	UserID string `json:"user_id"` // ID customer yang upload KTP
-	KTPURL string `json:"ktp_url"` // URL foto KTP yang sudah di-upload ke storage
+	UserID                  string                 `json:"user_id"`          // ID customer yang upload dokumen
+	DocumentType            string                 `json:"document_type"`    // "ktp", "sim", "passport", "kitas"
+	DocumentNumber          string                 `json:"document_number"`  // Tidak disimpan plaintext
+	DocumentCountry         string                 `json:"document_country"` // ISO 3166-1 alpha-3
+	DocumentExpiry          string                 `json:"document_expiry"`  // YYYY-MM-DD
+	DocumentURL             string                 `json:"-"`                // Diisi service setelah upload
+	ExtraPhotos             []IdentityPhotoRequest `json:"-"`                // Diisi service setelah upload
+	DocumentNumberEncrypted string                 `json:"-"`                // Diisi service (AES-256-GCM)
+	DocumentNumberHash      string                 `json:"-"`                // Diisi service (blind index)
+	DocumentExpiryDate      *time.Time             `json:"-"`                // Diisi service dari DocumentExpiry
+	HolderBirthDate         *time.Time             `json:"-"`                // Diisi service (decode NIK, hanya KTP)
+	HolderSex               string                 `json:"-"`                // Diisi service (decode NIK, hanya KTP)
+}
+
+// IdentityPhotoRequest adalah satu foto tambahan yang sudah di-upload ke storage
+type IdentityPhotoRequest struct {
+	Kind string `json:"kind"` // "selfie" atau "back"
+	URL  string `json:"url"`
 }
 
-// ReuploadIdentityByCustomerRequest adalah payload saat customer re-upload KTP
-// Endpoint: PUT /customer/identity/upload
+// ReuploadIdentityByCustomerRequest adalah payload saat customer re-upload dokumen
+// Endpoint: PUT /customer/identity
 type ReuploadIdentityByCustomerRequest struct {
-	KTPURL string `json:"ktp_url"` // URL KTP baru
+	DocumentURL string `json:"document_url"` // URL dokumen baru
+}
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// UploadIdentityByHosterRequest adalah payload saat hoster upload dokumen identitas (KYC toko)
+// Endpoint: POST /hoster/identity (multipart/form-data)
+//
+// Form field:
+//   - document_type, document_number, document_country, document_expiry : sama dengan customer
+//   - document, selfie, back : foto dokumen identitas pemilik toko (sama dengan customer)
+//   - nib_number + nib       : opsional, Nomor Induk Berusaha (13 digit) + foto/scan NIB
+//   - npwp_number + npwp     : opsional, NPWP (15/16 digit) + foto/scan NPWP
+//
+// Catatan: nomor dan file dokumen usaha wajib dikirim berpasangan
+type UploadIdentityByHosterRequest struct {
+	UploadIdentityByCustomerRequest                           // Field dokumen identitas (UserID = ID hoster)
+	BusinessDocuments               []BusinessDocumentRequest `json:"-"` // Diisi service setelah upload
+}
+
+// BusinessDocumentRequest adalah satu dokumen usaha hoster yang sudah di-upload ke storage
+type BusinessDocumentRequest struct {
+	Type            string `json:"type"`             // "nib" atau "npwp"
+	NumberEncrypted string `json:"number_encrypted"` // AES-256-GCM
+	URL             string `json:"url"`
 }
 
 // ===================================================================
 // REQUEST DTO - ADMIN
 // ===================================================================
 
-// VerifyIdentityByAdminRequest adalah payload saat admin verifikasi KTP
-// Endpoint: POST /admin/identity/{id}/verify
+// VerifyIdentityByAdminRequest adalah payload saat admin verifikasi dokumen identitas
+// Endpoint: POST /admin/identity/validate/{id}
 //
 // Contoh JSON (Approve):
 //
@@ -45,51 +95,195 @@ type ReuploadIdentityByCustomerRequest struct {
 //
 //	{
 //	  "status": "rejected",
-//	  "reason": "Foto KTP buram, silakan upload ulang dengan foto yang lebih jelas"
+//	  "template_id": "uuid-template-blurry-photo",
+//	  "reason": "Bagian tanggal lahir tertutup jari"
 //	}
+//
+// Catatan: saat reject, alasan ke customer = teks template + reason (catatan tambahan opsional)
 type VerifyIdentityByAdminRequest struct {
-	Status string `json:"status"` // "approved" atau "rejected"
-	Reason string `json:"reason"` // Wajib diisi jika status = "rejected"
+	Status     string `json:"status"`                // "approved" atau "rejected"
+	TemplateID string `json:"template_id,omitempty"` // Wajib jika status = "rejected"
+	Reason     string `json:"reason,omitempty"`      // Catatan tambahan opsional
+}
+
+// IdentityQueueFilterByAdminRequest adalah filter antrian review dokumen (dari query string)
+// Endpoint: GET /admin/identity/queue?status=pending&role=hoster&document_type=passport&submitted_from=2025-12-01&resubmission=true&claim=available&page=1&limit=20
+type IdentityQueueFilterByAdminRequest struct {
+	Status        string // "pending" (default), "approved", "rejected", "all"
+	DocumentType  string // "ktp", "sim", "passport", "kitas" (kosong = semua)
+	Role          string // "customer", "hoster" (kosong = semua)
+	SubmittedFrom string // YYYY-MM-DD (inklusif)
+	SubmittedTo   string // YYYY-MM-DD (inklusif)
+	Resubmission  string // "true" = hanya upload ulang, "false" = hanya upload pertama
+	Claim         string // "available" (belum di-claim / milik saya), "mine" (kosong = semua)
+	Sort          string // "oldest" (default, FIFO) atau "newest"
+	Page          int    // Default 1
+	Limit         int    // Default 20, maksimal 100
+}
+
+// ClaimIdentityByAdminRequest adalah payload saat admin mengambil dokumen dari antrian
+// Endpoint: POST /admin/identity/{id}/claim
+//
+// Contoh JSON:
+//
+//	{
+//	  "lease_minutes": 15
+//	}
+type ClaimIdentityByAdminRequest struct {
+	LeaseMinutes int `json:"lease_minutes,omitempty"` // Default 15, maksimal 60
+}
+
+// CreateRejectionTemplateByAdminRequest adalah payload untuk menambah template alasan penolakan
+// Endpoint: POST /admin/identity/rejection-templates
+//
+// Contoh JSON:
+//
+//	{
+//	  "code": "glare",
+//	  "title": "Pantulan cahaya",
+//	  "reason": "Ada pantulan cahaya yang menutupi data dokumen, silakan foto ulang tanpa flash"
+//	}
+type CreateRejectionTemplateByAdminRequest struct {
+	Code   string `json:"code"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// UpdateRejectionTemplateByAdminRequest adalah payload untuk mengubah template alasan penolakan
+// Endpoint: PUT /admin/identity/rejection-templates/{id}
+//
+// Catatan: field kosong tidak diubah, is_active=true untuk mengaktifkan kembali template
+type UpdateRejectionTemplateByAdminRequest struct {
+	Title    string `json:"title,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
 }
 
 // ===================================================================
 // RESPONSE DTO
 // ===================================================================
 
-// IdentityStatusByCustomerResponse adalah response status KTP customer
-// Endpoint: GET /customer/identity/status
+// IdentityStatusByCustomerResponse adalah response status dokumen identitas customer
+// Endpoint: GET /customer/identity
 //
 // Contoh JSON:
 //
 //	{
-//	  "ktp_id": "uuid-ktp-123",
+//	  "identity_id": "uuid-identity-123",
 //	  "user_id": "uuid-customer-123",
-//	  "ktp_url": "https://storage.com/ktp/customer-123.jpg",
+//	  "document_type": "passport",
+//	  "document_url": "https://storage.com/identity/customer-123/passport_01122025.jpg",
+//	  "document_country": "AUS",
+//	  "document_expiry": "2030-05-01T00:00:00Z",
+//	  "document_number_masked": "PA****123",
+//	  "extra_photos": [{"kind": "selfie", "url": "https://storage.com/..."}],
 //	  "created_at": "2025-11-28T10:00:00Z",
 //	  "status": "pending",
 //	  "verified": false,
 //	  "reason": "",
 //	  "verified_at": null
 //	}
+//
+// Catatan: ktp_id & ktp_url tetap dikirim (sama dengan identity_id & document_url) untuk client lama
 type IdentityStatusByCustomerResponse struct {
-	KTPID      string     `json:"ktp_id,omitempty"`
-	UserID     string     `json:"user_id"`
-	KTPURL     string     `json:"ktp_url,omitempty"`
-	CreatedAt  time.Time  `json:"created_at"`
-	Status     string     `json:"status"`                // "pending", "approved", "rejected"
-	Verified   bool       `json:"verified"`              // true jika approved, false jika pending/rejected
-	Reason     string     `json:"reason"`                // Alasan approve/reject dari admin
-	VerifiedAt *time.Time `json:"verified_at,omitempty"` // Waktu verifikasi oleh admin
-}
-
-// IdentityListByAdminResponse adalah response untuk list semua KTP yang perlu diverifikasi
-// Endpoint: GET /admin/identity/pending
+	IdentityID           string                 `json:"identity_id,omitempty"`
+	KTPID                string                 `json:"ktp_id,omitempty"` // Deprecated: pakai identity_id
+	UserID               string                 `json:"user_id"`
+	DocumentType         string                 `json:"document_type"`
+	DocumentURL          string                 `json:"document_url,omitempty"`
+	KTPURL               string                 `json:"ktp_url,omitempty"` // Deprecated: pakai document_url
+	DocumentCountry      string                 `json:"document_country"`
+	DocumentExpiry       *time.Time             `json:"document_expiry,omitempty"`
+	DocumentNumberMasked string                 `json:"document_number_masked,omitempty"` // Nomor dokumen tersamar
+	ExtraPhotos          []IdentityPhotoRequest `json:"extra_photos,omitempty"`
+	CreatedAt            time.Time              `json:"created_at"`
+	Status               string                 `json:"status"`                // "pending", "approved", "rejected"
+	Verified             bool                   `json:"verified"`              // true jika approved, false jika pending/rejected
+	Reason               string                 `json:"reason"`                // Alasan approve/reject dari admin
+	VerifiedAt           *time.Time             `json:"verified_at,omitempty"` // Waktu verifikasi oleh admin
+}
+
+// IdentityStatusByHosterResponse adalah response status verifikasi toko (KYC hoster)
+// Endpoint: GET /hoster/identity
+//
+// Contoh JSON:
+//
+//	{
+//	  "identity_id": "uuid-identity-123",
+//	  "user_id": "uuid-hoster-123",
+//	  "document_type": "ktp",
+//	  "document_number_masked": "317401******0001",
+//	  "business_documents": [{"type": "nib", "number_masked": "91******123", "url": "https://storage.com/..."}],
+//	  "status": "approved",
+//	  "verified": true,
+//	  "store_verified": true
+//	}
+type IdentityStatusByHosterResponse struct {
+	IdentityStatusByCustomerResponse
+	BusinessDocuments []BusinessDocumentResponse `json:"business_documents,omitempty"`
+	StoreVerified     bool                       `json:"store_verified"` // Badge "toko terverifikasi" (hoster.is_verified)
+}
+
+// BusinessDocumentResponse adalah dokumen usaha hoster dengan nomor tersamar
+type BusinessDocumentResponse struct {
+	Type         string `json:"type"` // "nib" atau "npwp"
+	NumberMasked string `json:"number_masked,omitempty"`
+	URL          string `json:"url"`
+}
+
+// IdentityListByAdminResponse adalah satu baris antrian review dokumen
+// Endpoint: GET /admin/identity/queue
 type IdentityListByAdminResponse struct {
-	KTPID     string    `json:"ktp_id"`
-	UserID    string    `json:"user_id"`
-	UserName  string    `json:"user_name"`
-	UserEmail string    `json:"user_email"`
-	KTPURL    string    `json:"ktp_url"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
+	IdentityID              string     `json:"identity_id" db:"id"`
+	UserID                  string     `json:"user_id" db:"user_id"`
+	UserRole                string     `json:"user_role" db:"user_role"` // "customer" atau "hoster"
+	UserName                string     `json:"user_name" db:"user_name"`
+	UserEmail               string     `json:"user_email" db:"user_email"`
+	DocumentType            string     `json:"document_type" db:"document_type"`
+	DocumentURL             string     `json:"document_url" db:"document_url"`
+	DocumentNumberMasked    string     `json:"document_number_masked,omitempty" db:"-"`
+	DocumentNumberEncrypted *string    `json:"-" db:"document_number_encrypted"`
+	DuplicateFlag           bool       `json:"duplicate_flag" db:"duplicate_flag"`
+	PreviousSubmissions     int        `json:"previous_submissions" db:"previous_submissions"` // > 0 berarti upload ulang
+	BlockCount              int        `json:"block_count" db:"block_count"`                   // Jumlah hoster yang memblokir customer (sinyal risiko)
+	Status                  string     `json:"status" db:"status"`
+	ClaimedBy               *string    `json:"claimed_by,omitempty" db:"claimed_by"`
+	ClaimedByName           *string    `json:"claimed_by_name,omitempty" db:"claimed_by_name"`
+	ClaimedUntil            *time.Time `json:"claimed_until,omitempty" db:"claimed_until"` // Hanya diisi jika lease masih aktif
+	ReviewedBy              *string    `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	CreatedAt               time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IdentityQueueByAdminResponse adalah response antrian review dokumen (paginated)
+//
+// Contoh JSON:
+//
+//	{
+//	  "items": [{"identity_id": "uuid-identity-123", "document_type": "ktp", "previous_submissions": 1, ...}],
+//	  "page": 1,
+//	  "limit": 20,
+//	  "total": 42,
+//	  "total_pages": 3
+//	}
+type IdentityQueueByAdminResponse struct {
+	Items      []IdentityListByAdminResponse `json:"items"`
+	Page       int                           `json:"page"`
+	Limit      int                           `json:"limit"`
+	Total      int                           `json:"total"`
+	TotalPages int                           `json:"total_pages"`
+}
+
+// IdentityClaimByAdminResponse adalah response setelah admin claim dokumen
+//
+// Contoh JSON:
+//
+//	{
+//	  "identity_id": "uuid-identity-123",
+//	  "claimed_by": "uuid-admin-1",
+//	  "claimed_until": "2025-12-01T10:15:00Z"
+//	}
+type IdentityClaimByAdminResponse struct {
+	IdentityID   string    `json:"identity_id"`
+	ClaimedBy    string    `json:"claimed_by"`
+	ClaimedUntil time.Time `json:"claimed_until"`
 }
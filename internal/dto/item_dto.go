@@ -17,29 +17,35 @@ const (
 )
 
 type ItemListByHosterResponse struct {
-	ID          string       `json:"id" db:"id"`
-	Name        string       `json:"name" db:"name"`
-	Stock       int          `json:"stock" db:"stock"`
-	PricePerDay int          `json:"price_per_day" db:"price_per_day"`
-	PickupType  PickupMethod `json:"pickup_type" db:"pickup_type"`
-	IsHidden    bool         `json:"is_hidden" db:"is_hidden"`
+	ID           string       `json:"id" db:"id"`
+	Name         string       `json:"name" db:"name"`
+	Stock        int          `json:"stock" db:"stock"`
+	PricePerDay  int          `json:"price_per_day" db:"price_per_day"`
+	PickupType   PickupMethod `json:"pickup_type" db:"pickup_type"`
+	IsHidden     bool         `json:"is_hidden" db:"is_hidden"`
+	StoreID      string       `json:"store_id" db:"tenant_id"`
+	UnitTracking bool         `json:"unit_tracking" db:"unit_tracking"` // Stock diturunkan dari unit fisik
+	HasVariants  bool         `json:"has_variants" db:"has_variants"`   // Stock diturunkan dari varian
 }
 
 type ItemDetailByHosterResponse struct {
-	ID          string       `json:"id" db:"id"`
-	Name        string       `json:"name" db:"name"`
-	Description string       `json:"description" db:"description"`
-	Photos      []string     `json:"photos" db:"photos"`               // Array URL foto item
-	Stock       int          `json:"stock" db:"stock"`                 // Jumlah unit tersedia
-	PickupType  PickupMethod `json:"pickup_type" db:"pickup_type"`     // "pickup" atau "delivery"
-	PricePerDay int          `json:"price_per_day" db:"price_per_day"` // Harga sewa per hari (dalam satuan terkecil, misal: rupiah)
-	Deposit     int          `json:"deposit" db:"deposit"`             // Deposit per unit
-	Discount    int          `json:"discount,omitempty" db:"discount"` // Diskon (opsional)
-	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
-	CategoryID  string       `json:"category_id" db:"category_id"` // FK ke Category
-	HosterID    string       `json:"hoster_id" db:"hoster_id"`     // FK ke Hoster
-	IsHidden    bool         `json:"is_hidden" db:"is_hidden"`     // Item hidden dari customer
+	ID           string       `json:"id" db:"id"`
+	Name         string       `json:"name" db:"name"`
+	Description  string       `json:"description" db:"description"`
+	Photos       []string     `json:"photos" db:"photos"`               // Array URL foto item
+	Stock        int          `json:"stock" db:"stock"`                 // Jumlah unit tersedia
+	PickupType   PickupMethod `json:"pickup_type" db:"pickup_type"`     // "pickup" atau "delivery"
+	PricePerDay  int          `json:"price_per_day" db:"price_per_day"` // Harga sewa per hari (dalam satuan terkecil, misal: rupiah)
+	Deposit      int          `json:"deposit" db:"deposit"`             // Deposit per unit
+	Discount     int          `json:"discount,omitempty" db:"discount"` // Diskon (opsional)
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
+	CategoryID   string       `json:"category_id" db:"category_id"`     // FK ke Category
+	HosterID     string       `json:"hoster_id" db:"hoster_id"`         // FK ke Hoster
+	IsHidden     bool         `json:"is_hidden" db:"is_hidden"`         // Item hidden dari customer
+	StoreID      string       `json:"store_id" db:"tenant_id"`          // FK ke Tenant (store)
+	UnitTracking bool         `json:"unit_tracking" db:"unit_tracking"` // true = stock diturunkan dari unit fisik
+	HasVariants  bool         `json:"has_variants" db:"has_variants"`   // true = stock diturunkan dari varian (GET /item/{id}/variants)
 }
 
 type CreateItemByCustomerRequest struct {
@@ -62,8 +68,70 @@ type UpdateItemRequestRequest struct {
 	CategoryID  *string       `json:"category_id,omitempty"`   // FK ke Category
 	PricePerDay *int          `json:"price_per_day,omitempty"` // Harga sewa per hari (optional)
 	Description *string       `json:"description,omitempty"`   // Deskripsi item (optional)
+	StoreID     *string       `json:"store_id,omitempty"`      // Pindahkan item ke store lain milik hoster (optional)
 }
 
 type UpdateVisibilityRequest struct {
 	IsHidden bool `json:"is_hidden"` // true = sembunyikan, false = tampilkan
 }
+
+// ===================================================================
+// IMPORT / EXPORT DTO - HOSTER
+// ===================================================================
+
+// ItemExportRowByHosterResponse adalah satu baris katalog untuk export / template import
+// Endpoint: GET /api/v1/hoster/item/export?format=csv|xlsx&store_id=uuid
+//
+// Kolom file: ID, Name, Description, Category, Pickup Type, Price Per Day, Deposit, Discount, Stock, Store ID, Hidden
+type ItemExportRowByHosterResponse struct {
+	ID           string       `db:"id"`
+	Name         string       `db:"name"`
+	Description  string       `db:"description"`
+	CategoryName string       `db:"category_name"`
+	PickupType   PickupMethod `db:"pickup_type"`
+	PricePerDay  int          `db:"price_per_day"`
+	Deposit      int          `db:"deposit"`
+	Discount     int          `db:"discount"`
+	Stock        int          `db:"stock"`
+	StoreID      string       `db:"tenant_id"`
+	IsHidden     bool         `db:"is_hidden"`
+}
+
+// ItemImportRowErrorResponse adalah kesalahan validasi satu baris file import
+type ItemImportRowErrorResponse struct {
+	Row     int    `json:"row"`              // Nomor baris di file (header = baris 1)
+	Column  string `json:"column,omitempty"` // Kolom yang bermasalah (kosong = seluruh baris)
+	Message string `json:"message"`
+}
+
+// ImportItemsByHosterResponse adalah laporan hasil import item
+// Endpoint: POST /api/v1/hoster/item/import (multipart: file, mode)
+//
+// Contoh JSON:
+//
+//	{
+//	  "mode": "partial",
+//	  "committed": true,
+//	  "total_rows": 150,
+//	  "created": 140,
+//	  "updated": 8,
+//	  "failed": 2,
+//	  "errors": [
+//	    {"row": 17, "column": "Category", "message": "category not found"},
+//	    {"row": 42, "column": "Price Per Day", "message": "must be a whole number greater than 0"}
+//	  ]
+//	}
+//
+// Catatan:
+//   - mode all_or_nothing (default): satu baris gagal → tidak ada yang disimpan (committed = false)
+//   - mode partial: baris valid tetap disimpan, baris gagal dilewati
+//   - baris dengan ID item milik toko → update, ID kosong → item baru (tanpa foto)
+type ImportItemsByHosterResponse struct {
+	Mode      string                       `json:"mode"`
+	Committed bool                         `json:"committed"`
+	TotalRows int                          `json:"total_rows"`
+	Created   int                          `json:"created"`
+	Updated   int                          `json:"updated"`
+	Failed    int                          `json:"failed"`
+	Errors    []ItemImportRowErrorResponse `json:"errors"`
+}
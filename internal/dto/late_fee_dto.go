@@ -0,0 +1,77 @@
+// ===================================================================
+// File: late_fee_dto.go
+// Deskripsi: DTO untuk aturan denda keterlambatan & daftar booking terlambat (Hoster & Customer)
+// Catatan: SEMUA DTO denda keterlambatan HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// SetLateFeeRuleByHosterRequest adalah payload aturan denda keterlambatan
+// Endpoint: PUT /api/v1/hoster/late-fee (default toko), PUT /api/v1/hoster/item/{id}/late-fee (khusus item)
+//
+// Contoh JSON ("denda 10% per hari, toleransi 3 jam"):
+//
+//	{
+//	  "fee_type": "percent",
+//	  "amount": 1000,
+//	  "grace_hours": 3
+//	}
+//
+// fee_type:
+// - fixed   → amount = rupiah per unit per hari terlambat
+// - percent → amount = basis point dari harga sewa per hari (1000 = 10%)
+type SetLateFeeRuleByHosterRequest struct {
+	FeeType    string `json:"fee_type"`
+	Amount     int    `json:"amount"`
+	GraceHours int    `json:"grace_hours"`
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER
+// ===================================================================
+
+// LateFeeRuleResponse adalah satu aturan denda keterlambatan
+type LateFeeRuleResponse struct {
+	ItemID     *string   `json:"item_id,omitempty" db:"item_id"`     // Kosong = aturan default toko
+	ItemName   *string   `json:"item_name,omitempty" db:"item_name"` // Nama item untuk aturan khusus item
+	FeeType    string    `json:"fee_type" db:"fee_type"`
+	Amount     int       `json:"amount" db:"amount"`
+	GraceHours int       `json:"grace_hours" db:"grace_hours"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// LateFeeRulesByHosterResponse adalah aturan default toko beserta aturan khusus item
+// Endpoint: GET /api/v1/hoster/late-fee
+//
+// Catatan: item tanpa aturan khusus memakai default, default kosong → item tersebut tidak didenda
+type LateFeeRulesByHosterResponse struct {
+	Default *LateFeeRuleResponse  `json:"default"`
+	Items   []LateFeeRuleResponse `json:"items"`
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER & CUSTOMER
+// ===================================================================
+
+// OverdueBookingResponse adalah satu booking on_rent yang melewati batas pengembalian
+// Endpoint: GET /api/v1/hoster/booking/overdue, GET /api/v1/customer/booking/overdue
+//
+// Catatan: late_fee adalah denda yang sudah masuk ke outstanding saat detektor terakhir berjalan
+type OverdueBookingResponse struct {
+	BookingID    string     `json:"booking_id" db:"booking_id"`
+	CustomerName string     `json:"customer_name,omitempty" db:"customer_name"` // Hanya untuk hoster
+	StoreName    string     `json:"store_name,omitempty" db:"store_name"`       // Hanya untuk customer
+	ItemNames    string     `json:"item_names" db:"item_names"`
+	StartDate    time.Time  `json:"start_date" db:"start_date"`
+	EndDate      time.Time  `json:"end_date" db:"end_date"`
+	DaysLate     int        `json:"days_late" db:"days_late"` // Hari kalender sejak batas pengembalian (dibulatkan ke atas)
+	OverdueAt    *time.Time `json:"overdue_at" db:"overdue_at"`
+	LateFee      int        `json:"late_fee" db:"late_fee"`
+	Outstanding  int        `json:"outstanding" db:"outstanding"`
+}
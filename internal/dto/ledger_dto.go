@@ -0,0 +1,238 @@
+// ===================================================================
+// File: ledger_dto.go
+// Deskripsi: DTO untuk Ledger payout hoster dan Settlement batch (Hoster & Admin)
+// Catatan: SEMUA DTO ledger & settlement HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// LedgerStatementFilterByHosterRequest adalah filter periode statement (dari query string)
+// Endpoint: GET /api/v1/hoster/ledger/statement?from=2025-11-01&to=2025-11-30
+//
+// Catatan: from & to inklusif (YYYY-MM-DD), default 30 hari terakhir, maksimal 366 hari
+type LedgerStatementFilterByHosterRequest struct {
+	From string // YYYY-MM-DD (inklusif)
+	To   string // YYYY-MM-DD (inklusif)
+}
+
+// ===================================================================
+// REQUEST DTO - ADMIN
+// ===================================================================
+
+// CreateSettlementBatchByAdminRequest adalah payload saat admin membuat batch settlement
+// Endpoint: POST /api/v1/admin/settlement/batches
+//
+// Contoh JSON:
+//
+//	{
+//	  "period_end": "2025-11-30"
+//	}
+//
+// Catatan: semua rental_income & late_fee yang belum di-settle sampai akhir period_end (inklusif) ikut masuk batch
+type CreateSettlementBatchByAdminRequest struct {
+	PeriodEnd string `json:"period_end"` // YYYY-MM-DD, harus sebelum hari ini
+}
+
+// MarkSettlementBatchPaidByAdminRequest adalah payload saat admin menandai batch sudah ditransfer
+// Endpoint: POST /api/v1/admin/settlement/batches/{id}/paid
+//
+// Contoh JSON:
+//
+//	{
+//	  "reference": "TRF-20251201-001"
+//	}
+type MarkSettlementBatchPaidByAdminRequest struct {
+	Reference string `json:"reference"` // Opsional: nomor referensi transfer bank
+}
+
+// SettlementBatchFilterByAdminRequest adalah filter daftar batch settlement (dari query string)
+type SettlementBatchFilterByAdminRequest struct {
+	Status string // pending, paid (kosong = semua)
+	Page   int    // Default 1
+	Limit  int    // Default 20, maksimal 100
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER
+// ===================================================================
+
+// LedgerBalanceByHosterResponse adalah ringkasan saldo hoster di ledger
+// Endpoint: GET /api/v1/hoster/ledger/balance
+//
+// Contoh JSON:
+//
+//	{
+//	  "payable": 3915000,
+//	  "in_settlement": 2700000,
+//	  "unsettled": 1215000,
+//	  "pending_rental": 900000,
+//	  "deposits_held": 800000,
+//	  "commission_total": 435000,
+//	  "paid_out_total": 12000000,
+//	  "commission_rate_bps": 1000
+//	}
+//
+// Catatan:
+//   - payable = hutang platform ke hoster yang belum di-payout
+//   - in_settlement = bagian payable yang sudah masuk batch settlement tapi belum dibayar
+//   - unsettled = payable - in_settlement (akan masuk batch berikutnya)
+//   - pending_rental = sewa booking yang sudah dibayar tapi belum selesai (sebelum komisi)
+//   - commission_rate_bps = tarif komisi saat ini untuk booking yang akan selesai
+type LedgerBalanceByHosterResponse struct {
+	Payable           int64 `json:"payable" db:"payable"`
+	InSettlement      int64 `json:"in_settlement" db:"in_settlement"`
+	Unsettled         int64 `json:"unsettled" db:"-"`
+	PendingRental     int64 `json:"pending_rental" db:"pending_rental"`
+	DepositsHeld      int64 `json:"deposits_held" db:"deposits_held"`
+	CommissionTotal   int64 `json:"commission_total" db:"commission_total"`
+	PaidOutTotal      int64 `json:"paid_out_total" db:"paid_out_total"`
+	CommissionRateBps int   `json:"commission_rate_bps" db:"-"`
+}
+
+// LedgerEntryResponse adalah satu baris debit / kredit dalam transaksi ledger
+type LedgerEntryResponse struct {
+	Account string `json:"account" db:"account"`
+	Debit   int64  `json:"debit" db:"debit"`
+	Credit  int64  `json:"credit" db:"credit"`
+}
+
+// LedgerStatementLineByHosterResponse adalah satu transaksi di statement hoster
+type LedgerStatementLineByHosterResponse struct {
+	TransactionID     string                `json:"transaction_id" db:"id"`
+	Kind              string                `json:"kind" db:"kind"`
+	BookingID         *string               `json:"booking_id,omitempty" db:"booking_id"`
+	SettlementID      *string               `json:"settlement_id,omitempty" db:"settlement_id"`
+	Description       string                `json:"description" db:"description"`
+	CommissionRateBps int                   `json:"commission_rate_bps" db:"commission_rate_bps"`
+	OccurredAt        time.Time             `json:"occurred_at" db:"occurred_at"`
+	Entries           []LedgerEntryResponse `json:"entries" db:"-"`
+	PayableChange     int64                 `json:"payable_change" db:"-"` // Kredit - debit akun hoster_payable
+	Balance           int64                 `json:"balance" db:"-"`        // Saldo payable setelah transaksi ini
+}
+
+// LedgerStatementByHosterResponse adalah statement ledger hoster dalam satu periode
+// Endpoint: GET /api/v1/hoster/ledger/statement
+//
+// Contoh JSON:
+//
+//	{
+//	  "from": "2025-11-01T00:00:00Z",
+//	  "to": "2025-12-01T00:00:00Z",
+//	  "opening_balance": 500000,
+//	  "closing_balance": 1400000,
+//	  "lines": [
+//	    {
+//	      "transaction_id": "uuid-tx",
+//	      "kind": "rental_income",
+//	      "booking_id": "uuid-booking",
+//	      "description": "Pendapatan sewa booking uuid-booking (komisi 1000 bps)",
+//	      "commission_rate_bps": 1000,
+//	      "occurred_at": "2025-11-10T09:00:00Z",
+//	      "entries": [
+//	        {"account": "unearned_rental", "debit": 1000000, "credit": 0},
+//	        {"account": "platform_commission", "debit": 0, "credit": 100000},
+//	        {"account": "hoster_payable", "debit": 0, "credit": 900000}
+//	      ],
+//	      "payable_change": 900000,
+//	      "balance": 1400000
+//	    }
+//	  ]
+//	}
+//
+// Catatan: balance = saldo hoster_payable berjalan, to = eksklusif (hari setelah "to" di request)
+type LedgerStatementByHosterResponse struct {
+	From           time.Time                             `json:"from"`
+	To             time.Time                             `json:"to"`
+	OpeningBalance int64                                 `json:"opening_balance"`
+	ClosingBalance int64                                 `json:"closing_balance"`
+	Lines          []LedgerStatementLineByHosterResponse `json:"lines"`
+}
+
+// SettlementByHosterResponse adalah satu payout settlement untuk hoster
+// Endpoint: GET /api/v1/hoster/ledger/settlements
+type SettlementByHosterResponse struct {
+	ID               string     `json:"id" db:"id"`
+	BatchID          string     `json:"batch_id" db:"batch_id"`
+	PeriodEnd        time.Time  `json:"period_end" db:"period_end"`
+	Amount           int64      `json:"amount" db:"amount"`
+	TransactionCount int        `json:"transaction_count" db:"transaction_count"`
+	Status           string     `json:"status" db:"status"`
+	PaidAt           *time.Time `json:"paid_at,omitempty" db:"paid_at"`
+	Reference        *string    `json:"reference,omitempty" db:"reference"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ===================================================================
+// RESPONSE DTO - ADMIN
+// ===================================================================
+
+// SettlementBatchByAdminResponse adalah ringkasan satu batch settlement
+type SettlementBatchByAdminResponse struct {
+	ID          string     `json:"id" db:"id"`
+	PeriodEnd   time.Time  `json:"period_end" db:"period_end"`
+	Status      string     `json:"status" db:"status"`
+	HosterCount int        `json:"hoster_count" db:"hoster_count"`
+	TotalAmount int64      `json:"total_amount" db:"total_amount"`
+	CreatedBy   string     `json:"created_by" db:"created_by"`
+	PaidBy      *string    `json:"paid_by,omitempty" db:"paid_by"`
+	PaidAt      *time.Time `json:"paid_at,omitempty" db:"paid_at"`
+	Reference   *string    `json:"reference,omitempty" db:"reference"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// SettlementBatchListByAdminResponse adalah daftar batch settlement (paginated)
+// Endpoint: GET /api/v1/admin/settlement/batches
+type SettlementBatchListByAdminResponse struct {
+	Items      []SettlementBatchByAdminResponse `json:"items"`
+	Page       int                              `json:"page"`
+	Limit      int                              `json:"limit"`
+	Total      int                              `json:"total"`
+	TotalPages int                              `json:"total_pages"`
+}
+
+// SettlementByAdminResponse adalah jumlah payout satu hoster di dalam batch
+type SettlementByAdminResponse struct {
+	ID               string `json:"id" db:"id"`
+	HosterID         string `json:"hoster_id" db:"hoster_id"`
+	StoreName        string `json:"store_name" db:"store_name"`
+	Email            string `json:"email" db:"email"`
+	Amount           int64  `json:"amount" db:"amount"`
+	TransactionCount int    `json:"transaction_count" db:"transaction_count"`
+	Status           string `json:"status" db:"status"`
+}
+
+// SettlementBatchDetailByAdminResponse adalah detail batch beserta payout per hoster
+// Endpoint: GET /api/v1/admin/settlement/batches/{id}
+//
+// Contoh JSON:
+//
+//	{
+//	  "id": "uuid-batch",
+//	  "period_end": "2025-11-30T00:00:00Z",
+//	  "status": "pending",
+//	  "hoster_count": 2,
+//	  "total_amount": 5400000,
+//	  "created_by": "uuid-admin",
+//	  "created_at": "2025-12-01T08:00:00Z",
+//	  "settlements": [
+//	    {"id": "uuid-settlement", "hoster_id": "uuid-hoster", "store_name": "Kamera Jogja", "email": "toko@example.com", "amount": 2700000, "transaction_count": 5, "status": "pending"}
+//	  ]
+//	}
+type SettlementBatchDetailByAdminResponse struct {
+	SettlementBatchByAdminResponse
+	Settlements []SettlementByAdminResponse `json:"settlements"`
+}
+
+// LedgerSyncByAdminResponse adalah hasil sinkronisasi ledger dengan data booking
+// Endpoint: POST /api/v1/admin/settlement/sync
+type LedgerSyncByAdminResponse struct {
+	Bookings     int `json:"bookings"`     // Booking yang transaksinya belum lengkap
+	Transactions int `json:"transactions"` // Transaksi ledger yang baru diposting
+	Failed       int `json:"failed"`       // Booking yang gagal diposting (lihat log)
+}
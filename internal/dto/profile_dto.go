@@ -13,13 +13,15 @@ type HosterProfileResponse struct {
 	PhoneNumber   string    `json:"phone_number"`
 	Address       string    `json:"address"`
 	StoreName     string    `json:"store_name"`
+	Slug          string    `json:"slug"` // Slug storefront publik (GET /public/hoster/{slug})
 	Description   string    `json:"description"`
 	Website       string    `json:"website,omitempty"`
 	Instagram     string    `json:"instagram,omitempty"`
 	Tiktok        string    `json:"tiktok,omitempty"`
 	ProfilePhoto  string    `json:"profile_photo,omitempty"`
-	JoinedAt      time.Time `json:"joined_at"`       // Tanggal bergabung
-	DaysSinceJoin int       `json:"days_since_join"` // Jumlah hari bergabung
+	BannerPhoto   string    `json:"banner_photo,omitempty"` // Banner halaman toko (storefront)
+	JoinedAt      time.Time `json:"joined_at"`              // Tanggal bergabung
+	DaysSinceJoin int       `json:"days_since_join"`        // Jumlah hari bergabung
 }
 
 /*
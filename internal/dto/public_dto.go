@@ -53,19 +53,21 @@ type CategoryPublicResponse struct {
 //	  "user_id": "uuid-hoster-123"
 //	}
 type ItemPublicResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Photos      []string  `json:"photos"`
-	Stock       int       `json:"stock"`
-	PickupType  string    `json:"pickup_type"`
-	PricePerDay int       `json:"price_per_day"`
-	Deposit     int       `json:"deposit"`
-	Discount    int       `json:"discount,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	CategoryID  string    `json:"category_id"`
-	HosterID    string    `json:"hoster_id"`
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Photos         []string  `json:"photos"`
+	Stock          int       `json:"stock"`
+	PickupType     string    `json:"pickup_type"`
+	PricePerDay    int       `json:"price_per_day"`
+	Deposit        int       `json:"deposit"`
+	Discount       int       `json:"discount,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	CategoryID     string    `json:"category_id"`
+	HosterID       string    `json:"hoster_id"`
+	StoreID        string    `json:"store_id"`        // Store (cabang) tempat item disewakan
+	HosterVerified bool      `json:"hoster_verified"` // Badge "toko terverifikasi"
 }
 
 // TermsAndConditionsPublicResponse adalah response untuk syarat dan ketentuan
@@ -109,11 +111,15 @@ type TermsAndConditionsPublicResponse struct {
 //	  "booked_dates": ["2025-12-05", "2025-12-06", "2025-12-07"]
 //	}
 type ItemDetailResponse struct {
-	Item               ItemDetail     `json:"item"`
-	Category           CategoryDetail `json:"category"`
-	Hoster             HosterDetail   `json:"hoster"`
-	TermsAndConditions []string       `json:"terms_and_conditions"`
-	BookedDates        []string       `json:"booked_dates"`
+	Item               ItemDetail                  `json:"item"`
+	Category           CategoryDetail              `json:"category"`
+	Hoster             HosterDetail                `json:"hoster"`
+	Store              StorePublicResponse         `json:"store"`                // Store (cabang) tempat item diambil / dikirim
+	TermsAndConditions []string                    `json:"terms_and_conditions"` // T&C khusus store, fallback ke T&C umum hoster
+	TnCVersions        []TnCVersionResponse        `json:"tnc_versions"`         // Versi T&C (store/umum + khusus item) yang wajib disetujui saat booking
+	BookedDates        []string                    `json:"booked_dates"`
+	VariantDimensions  []string                    `json:"variant_dimensions"` // Dimensi varian (mis. ["Ukuran", "Warna"]), kosong = item tanpa varian
+	Variants           []ItemVariantPublicResponse `json:"variants"`           // Varian aktif beserta ketersediaannya; booking wajib memilih salah satu
 }
 
 // ItemDetail adalah detail item untuk response detail
@@ -143,11 +149,69 @@ type HosterDetail struct {
 	ID           string `json:"id"`
 	FullName     string `json:"full_name"`
 	StoreName    string `json:"store_name"`
+	Slug         string `json:"slug"` // Slug storefront publik (GET /public/hoster/{slug})
 	Description  string `json:"description"`
 	PhoneNumber  string `json:"phone_number"`
 	Address      string `json:"address"`
 	ProfilePhoto string `json:"profile_photo,omitempty"`
+	BannerPhoto  string `json:"banner_photo,omitempty"` // Banner halaman toko (storefront)
 	Website      string `json:"website,omitempty"`
 	Instagram    string `json:"instagram,omitempty"`
 	Tiktok       string `json:"tiktok,omitempty"`
+	Verified     bool   `json:"verified"` // Badge "toko terverifikasi" (identitas hoster sudah di-approve admin)
+}
+
+// ===================================================================
+// STOREFRONT HOSTER - PUBLIC
+// ===================================================================
+
+// StorefrontFilterPublicRequest adalah filter item di halaman storefront (dari query string)
+// Endpoint: GET /public/hoster/{id_or_slug}?store_id=uuid&category_id=uuid&page=1&limit=20
+type StorefrontFilterPublicRequest struct {
+	StoreID    string // Item di store (cabang) ini saja
+	CategoryID string // Item di kategori ini saja
+	Page       int    // Default 1
+	Limit      int    // Default 20, maksimal 100
+}
+
+// StorefrontPublicResponse adalah halaman toko publik yang bisa dibagikan lewat slug
+// Endpoint: GET /public/hoster/{id_or_slug}
+//
+// Contoh JSON:
+//
+//	{
+//	  "hoster": {"id": "uuid-hoster-123", "slug": "kamera-jogja", "store_name": "Kamera Jogja", "verified": true, ...},
+//	  "joined_at": "2025-01-10T08:00:00Z",
+//	  "stores": [{"id": "uuid-store-1", "name": "Kamera Jogja - Sleman", "city": "Sleman", ...}],
+//	  "terms_and_conditions": ["Penyewa wajib mengembalikan barang dalam kondisi baik"],
+//	  "categories": [{"id": "uuid-category-123", "name": "Kamera", "item_count": 12}],
+//	  "rating": {"average": 4.7, "count": 32},
+//	  "completed_rentals": 87,
+//	  "items": {"items": [...], "page": 1, "limit": 20, "total": 35, "total_pages": 2}
+//	}
+type StorefrontPublicResponse struct {
+	Hoster             HosterDetail                     `json:"hoster"`
+	JoinedAt           time.Time                        `json:"joined_at"`
+	Stores             []StorePublicResponse            `json:"stores"`
+	TermsAndConditions []string                         `json:"terms_and_conditions"` // T&C umum hoster (T&C khusus store ada di detail item)
+	Categories         []StorefrontCategoryResponse     `json:"categories"`           // Kategori yang punya item tampil di toko ini
+	Rating             StorefrontRatingResponse         `json:"rating"`               // Ringkasan ulasan customer (lihat booking_review_dto.go)
+	CompletedRentals   int                              `json:"completed_rentals"`    // Jumlah sewa selesai, sinyal kepercayaan toko
+	Items              StorefrontItemPagePublicResponse `json:"items"`
+}
+
+// StorefrontCategoryResponse adalah kategori yang dibawa toko beserta jumlah item tampilnya
+type StorefrontCategoryResponse struct {
+	ID        string `json:"id" db:"id"`
+	Name      string `json:"name" db:"name"`
+	ItemCount int    `json:"item_count" db:"item_count"`
+}
+
+// StorefrontItemPagePublicResponse adalah satu halaman item tampil di storefront
+type StorefrontItemPagePublicResponse struct {
+	Items      []ItemPublicResponse `json:"items"`
+	Page       int                  `json:"page"`
+	Limit      int                  `json:"limit"`
+	Total      int                  `json:"total"`
+	TotalPages int                  `json:"total_pages"`
 }
@@ -0,0 +1,142 @@
+// ===================================================================
+// File: schedule_dto.go
+// Deskripsi: DTO untuk Jam Buka Store, Hari Libur, Slot Pickup / Pengembalian, dan Jadwal Harian Hoster
+// Catatan: SEMUA DTO jadwal store HANYA di file ini!
+// ===================================================================
+
+package dto
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// UpdateStoreHoursByHosterRequest adalah payload saat hoster mengatur jam buka mingguan store
+// Endpoint: PUT /api/v1/hoster/store/{id}/hours
+//
+// Contoh JSON:
+//
+//	{
+//	  "slot_minutes": 60,
+//	  "slot_capacity": 5,
+//	  "days": [
+//	    {"weekday": 1, "open_time": "09:00", "close_time": "17:00"},
+//	    {"weekday": 6, "open_time": "10:00", "close_time": "14:00"}
+//	  ]
+//	}
+//
+// Catatan: days menggantikan seluruh jam buka lama, hari yang tidak dikirim dianggap tutup.
+// weekday: 0 = Minggu, 1 = Senin, ..., 6 = Sabtu.
+type UpdateStoreHoursByHosterRequest struct {
+	SlotMinutes  int                            `json:"slot_minutes"`  // 15, 30, 60, atau 120
+	SlotCapacity int                            `json:"slot_capacity"` // Serah terima maksimal per slot (pickup + pengembalian)
+	Days         []StoreHoursDayByHosterRequest `json:"days"`
+}
+
+// StoreHoursDayByHosterRequest adalah jam buka satu hari dalam seminggu
+type StoreHoursDayByHosterRequest struct {
+	Weekday   int    `json:"weekday"`
+	OpenTime  string `json:"open_time"`  // HH:MM
+	CloseTime string `json:"close_time"` // HH:MM
+}
+
+// CreateStoreClosureByHosterRequest adalah payload saat hoster menandai hari libur store
+// Endpoint: POST /api/v1/hoster/store/{id}/closure
+//
+// Contoh JSON:
+//
+//	{
+//	  "date": "2026-03-31",
+//	  "reason": "Libur Idul Fitri"
+//	}
+type CreateStoreClosureByHosterRequest struct {
+	Date   string `json:"date"`             // YYYY-MM-DD
+	Reason string `json:"reason,omitempty"` // Opsional, ditampilkan ke customer
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER
+// ===================================================================
+
+// StoreHoursByHosterResponse adalah jam buka, pengaturan slot, dan hari libur mendatang satu store
+// Endpoint: GET /api/v1/hoster/store/{id}/hours
+type StoreHoursByHosterResponse struct {
+	StoreID      string                  `json:"store_id"`
+	SlotMinutes  int                     `json:"slot_minutes"`
+	SlotCapacity int                     `json:"slot_capacity"`
+	Days         []StoreHoursDayResponse `json:"days"`     // Urut weekday, hari yang tidak ada = tutup
+	Closures     []StoreClosureResponse  `json:"closures"` // Hari libur mulai hari ini
+}
+
+// ScheduleByHosterResponse adalah jadwal serah terima harian hoster
+// Endpoint: GET /api/v1/hoster/schedule?date=YYYY-MM-DD&store_id=
+//
+// Contoh JSON:
+//
+//	{
+//	  "date": "2026-01-05",
+//	  "pickups": [{"booking_id": "uuid-booking-1", "time": "09:00", "customer_name": "Budi Santoso", ...}],
+//	  "returns": [{"booking_id": "uuid-booking-2", "time": null, "customer_name": "Siti", ...}]
+//	}
+type ScheduleByHosterResponse struct {
+	Date    string                            `json:"date"`
+	Pickups []ScheduleBookingByHosterResponse `json:"pickups"` // Booking lunas (on_progress) yang mulai sewa di tanggal ini
+	Returns []ScheduleBookingByHosterResponse `json:"returns"` // Booking on_rent yang selesai sewa di tanggal ini
+}
+
+// ScheduleBookingByHosterResponse adalah satu booking di jadwal serah terima harian
+type ScheduleBookingByHosterResponse struct {
+	BookingID     string  `json:"booking_id" db:"booking_id"`
+	Time          *string `json:"time" db:"slot_time"` // HH:MM, null jika booking tanpa slot
+	StoreID       *string `json:"store_id" db:"store_id"`
+	StoreName     string  `json:"store_name" db:"store_name"`
+	CustomerName  string  `json:"customer_name" db:"customer_name"`
+	CustomerPhone string  `json:"customer_phone" db:"customer_phone"`
+	DeliveryType  string  `json:"delivery_type" db:"delivery_type"`
+	ItemNames     string  `json:"item_names" db:"item_names"`
+	TotalItems    int     `json:"total_items" db:"total_items"`
+	Status        string  `json:"status" db:"status"`
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER & CUSTOMER
+// ===================================================================
+
+// StoreHoursDayResponse adalah jam buka satu hari dalam seminggu
+type StoreHoursDayResponse struct {
+	Weekday   int    `json:"weekday" db:"weekday"`
+	OpenTime  string `json:"open_time" db:"open_time"`
+	CloseTime string `json:"close_time" db:"close_time"`
+}
+
+// StoreClosureResponse adalah satu hari libur store
+type StoreClosureResponse struct {
+	Date   string  `json:"date" db:"closure_date"` // YYYY-MM-DD
+	Reason *string `json:"reason,omitempty" db:"reason"`
+}
+
+// StoreSlotsResponse adalah slot pickup / pengembalian yang bisa dipilih customer di satu tanggal
+// Endpoint: GET /api/v1/customer/store/{id}/slots?date=YYYY-MM-DD
+//
+// Contoh JSON:
+//
+//	{
+//	  "store_id": "uuid-store-1",
+//	  "date": "2026-01-05",
+//	  "open": true,
+//	  "slots": [{"time": "09:00", "remaining": 5}, {"time": "10:00", "remaining": 0}]
+//	}
+//
+// Catatan: store yang belum mengatur jam buka mengembalikan open = true dan slots kosong (jam bebas).
+type StoreSlotsResponse struct {
+	StoreID      string              `json:"store_id"`
+	Date         string              `json:"date"`
+	Open         bool                `json:"open"`
+	ClosedReason *string             `json:"closed_reason,omitempty"`
+	Slots        []StoreSlotResponse `json:"slots"`
+}
+
+// StoreSlotResponse adalah satu slot serah terima beserta sisa kapasitasnya
+type StoreSlotResponse struct {
+	Time      string `json:"time"` // HH:MM
+	Remaining int    `json:"remaining"`
+}
@@ -0,0 +1,93 @@
+// ===================================================================
+// File: storage_dto.go
+// Deskripsi: DTO untuk Storage Garbage Collector (Admin)
+// Catatan: SEMUA DTO storage GC HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - ADMIN
+// ===================================================================
+
+// RunStorageGCByAdminRequest adalah payload saat admin menjalankan reconciliation bucket
+// Endpoint: POST /api/v1/admin/storage/gc
+//
+// Contoh JSON:
+//
+//	{
+//	  "dry_run": true,
+//	  "grace_period_hours": 24
+//	}
+//
+// Catatan: dry_run default true (hanya laporan), grace_period_hours default 24
+type RunStorageGCByAdminRequest struct {
+	DryRun           *bool `json:"dry_run,omitempty"`            // false = benar-benar hapus objek orphan
+	GracePeriodHours int   `json:"grace_period_hours,omitempty"` // Objek lebih muda dari ini tidak disentuh
+}
+
+// ===================================================================
+// RESPONSE DTO
+// ===================================================================
+
+// StorageGCReportByAdminResponse adalah laporan hasil reconciliation bucket vs database
+//
+// Contoh JSON:
+//
+//	{
+//	  "dry_run": true,
+//	  "grace_period_hours": 24,
+//	  "started_at": "2025-12-01T10:00:00Z",
+//	  "finished_at": "2025-12-01T10:00:05Z",
+//	  "buckets": [
+//	    {
+//	      "bucket": "hoster",
+//	      "scanned": 120,
+//	      "referenced": 110,
+//	      "in_grace_period": 2,
+//	      "orphaned": 8,
+//	      "deleted": 0,
+//	      "failed": 0,
+//	      "freed_bytes": 0,
+//	      "orphans": [
+//	        {
+//	          "path": "uuid-hoster/item/uuid-item/item1_01122025.jpg",
+//	          "size": 204800,
+//	          "last_modified": "2025-11-20T08:00:00Z",
+//	          "deleted": false
+//	        }
+//	      ]
+//	    }
+//	  ]
+//	}
+type StorageGCReportByAdminResponse struct {
+	DryRun           bool                             `json:"dry_run"`
+	GracePeriodHours int                              `json:"grace_period_hours"`
+	StartedAt        time.Time                        `json:"started_at"`
+	FinishedAt       time.Time                        `json:"finished_at"`
+	Buckets          []StorageGCBucketByAdminResponse `json:"buckets"`
+}
+
+// StorageGCBucketByAdminResponse adalah ringkasan reconciliation untuk satu bucket
+type StorageGCBucketByAdminResponse struct {
+	Bucket        string                           `json:"bucket"`
+	Scanned       int                              `json:"scanned"`         // Total objek di bucket
+	Referenced    int                              `json:"referenced"`      // Objek yang masih dirujuk database
+	InGracePeriod int                              `json:"in_grace_period"` // Orphan tapi masih terlalu baru untuk dihapus
+	Orphaned      int                              `json:"orphaned"`        // Orphan yang melewati grace period
+	Deleted       int                              `json:"deleted"`         // Orphan yang berhasil dihapus (0 saat dry-run)
+	Failed        int                              `json:"failed"`          // Orphan yang gagal dihapus
+	FreedBytes    int64                            `json:"freed_bytes"`     // Total ukuran objek yang dihapus
+	Orphans       []StorageGCObjectByAdminResponse `json:"orphans"`
+}
+
+// StorageGCObjectByAdminResponse adalah detail satu objek orphan
+type StorageGCObjectByAdminResponse struct {
+	Path         string    `json:"path"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+	Deleted      bool      `json:"deleted"`
+	Error        string    `json:"error,omitempty"` // Diisi jika delete gagal
+}
@@ -0,0 +1,114 @@
+// ===================================================================
+// File: store_dto.go
+// Deskripsi: DTO untuk Store (cabang toko / tenant) milik hoster
+// Catatan: SEMUA DTO store HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// CreateStoreByHosterRequest adalah payload saat hoster membuka cabang baru
+// Endpoint: POST /api/v1/hoster/store
+//
+// Contoh JSON:
+//
+//	{
+//	  "name": "Lalan Outdoor Bandung",
+//	  "address": "Jl. Dago No. 10",
+//	  "city": "Bandung",
+//	  "phone_number": "081234567890",
+//	  "delivery_enabled": true,
+//	  "delivery_fee": 25000,
+//	  "delivery_radius_km": 15,
+//	  "is_default": false,
+//	  "amendment_pricing": "snapshot"
+//	}
+type CreateStoreByHosterRequest struct {
+	Name             string  `json:"name"`
+	Address          string  `json:"address"`
+	City             string  `json:"city"`
+	PhoneNumber      *string `json:"phone_number,omitempty"`
+	DeliveryEnabled  *bool   `json:"delivery_enabled,omitempty"`   // Default true
+	DeliveryFee      int     `json:"delivery_fee"`                 // Ongkos antar flat (rupiah)
+	DeliveryRadiusKm *int    `json:"delivery_radius_km,omitempty"` // nil = tanpa batas
+	IsDefault        bool    `json:"is_default"`                   // true = jadikan store default
+	AmendmentPricing string  `json:"amendment_pricing,omitempty"`  // snapshot (default) / current: harga tambahan hari / unit saat booking diubah
+}
+
+// UpdateStoreByHosterRequest adalah payload saat hoster mengubah store
+// Endpoint: PUT /api/v1/hoster/store/{id}
+//
+// Catatan: field yang tidak dikirim tidak diubah. is_default hanya bisa diset true
+// (store default lama otomatis dilepas); untuk melepas, jadikan store lain default.
+type UpdateStoreByHosterRequest struct {
+	Name             *string `json:"name,omitempty"`
+	Address          *string `json:"address,omitempty"`
+	City             *string `json:"city,omitempty"`
+	PhoneNumber      *string `json:"phone_number,omitempty"`
+	DeliveryEnabled  *bool   `json:"delivery_enabled,omitempty"`
+	DeliveryFee      *int    `json:"delivery_fee,omitempty"`
+	DeliveryRadiusKm *int    `json:"delivery_radius_km,omitempty"` // 0 = hapus batas jangkauan
+	IsDefault        *bool   `json:"is_default,omitempty"`
+	AmendmentPricing *string `json:"amendment_pricing,omitempty"`
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER
+// ===================================================================
+
+// StoreByHosterResponse adalah satu store milik hoster beserta ringkasan isinya
+// Endpoint: GET /api/v1/hoster/store
+//
+// Contoh JSON:
+//
+//	{
+//	  "id": "uuid-store-1",
+//	  "name": "Lalan Outdoor Bandung",
+//	  "address": "Jl. Dago No. 10",
+//	  "city": "Bandung",
+//	  "delivery_enabled": true,
+//	  "delivery_fee": 25000,
+//	  "is_default": true,
+//	  "amendment_pricing": "snapshot",
+//	  "item_count": 12,
+//	  "active_bookings": 3,
+//	  "created_at": "2025-12-01T10:00:00Z",
+//	  "updated_at": "2025-12-01T10:00:00Z"
+//	}
+type StoreByHosterResponse struct {
+	ID               string    `json:"id" db:"id"`
+	Name             string    `json:"name" db:"name"`
+	Address          string    `json:"address" db:"address"`
+	City             string    `json:"city" db:"city"`
+	PhoneNumber      *string   `json:"phone_number,omitempty" db:"phone_number"`
+	DeliveryEnabled  bool      `json:"delivery_enabled" db:"delivery_enabled"`
+	DeliveryFee      int       `json:"delivery_fee" db:"delivery_fee"`
+	DeliveryRadiusKm *int      `json:"delivery_radius_km,omitempty" db:"delivery_radius_km"`
+	IsDefault        bool      `json:"is_default" db:"is_default"`
+	AmendmentPricing string    `json:"amendment_pricing" db:"amendment_pricing"`
+	ItemCount        int       `json:"item_count" db:"item_count"`
+	ActiveBookings   int       `json:"active_bookings" db:"active_bookings"` // pending (masih di-lock), on_progress, on_rent
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ===================================================================
+// RESPONSE DTO - PUBLIC
+// ===================================================================
+
+// StorePublicResponse adalah info store yang ditampilkan ke customer (detail item, katalog)
+type StorePublicResponse struct {
+	ID               string  `json:"id" db:"id"`
+	Name             string  `json:"name" db:"name"`
+	Address          string  `json:"address" db:"address"`
+	City             string  `json:"city" db:"city"`
+	PhoneNumber      *string `json:"phone_number,omitempty" db:"phone_number"`
+	DeliveryEnabled  bool    `json:"delivery_enabled" db:"delivery_enabled"`
+	DeliveryFee      int     `json:"delivery_fee" db:"delivery_fee"`
+	DeliveryRadiusKm *int    `json:"delivery_radius_km,omitempty" db:"delivery_radius_km"`
+}
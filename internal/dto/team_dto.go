@@ -0,0 +1,116 @@
+// ===================================================================
+// File: team_dto.go
+// Deskripsi: DTO untuk Tim Toko (staff hoster: owner, manager, staff counter)
+// Catatan: SEMUA DTO team HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER (OWNER)
+// ===================================================================
+
+// InviteMemberByHosterRequest adalah payload saat owner mengundang staff
+// Endpoint: POST /hoster/team
+//
+// Contoh JSON:
+//
+//	{
+//	  "full_name": "Siti Aminah",
+//	  "email": "siti@example.com",
+//	  "role": "staff"
+//	}
+type InviteMemberByHosterRequest struct {
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	Role     string `json:"role"` // "manager" atau "staff"
+}
+
+// UpdateMemberByHosterRequest adalah payload saat owner mengubah role / status staff
+// Endpoint: PUT /hoster/team/{id}
+//
+// Contoh JSON:
+//
+//	{
+//	  "role": "manager",
+//	  "status": "disabled"
+//	}
+//
+// Catatan: field kosong = tidak diubah, staff yang dinonaktifkan langsung tidak bisa memakai token lamanya
+type UpdateMemberByHosterRequest struct {
+	Role   string `json:"role,omitempty"`   // "manager" atau "staff"
+	Status string `json:"status,omitempty"` // "active" atau "disabled"
+}
+
+// ===================================================================
+// REQUEST DTO - STAFF (PUBLIC)
+// ===================================================================
+
+// AcceptInviteByMemberRequest adalah payload saat staff menerima undangan dan membuat password
+// Endpoint: POST /hoster/team/accept (tanpa login)
+//
+// Contoh JSON:
+//
+//	{
+//	  "email": "siti@example.com",
+//	  "token": "q9Zx...",
+//	  "password": "rahasia123"
+//	}
+type AcceptInviteByMemberRequest struct {
+	Email    string `json:"email"`
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ===================================================================
+// RESPONSE DTO
+// ===================================================================
+
+// MemberByHosterResponse adalah satu staff toko
+type MemberByHosterResponse struct {
+	ID              string     `json:"id" db:"id"`
+	FullName        string     `json:"full_name" db:"full_name"`
+	Email           string     `json:"email" db:"email"`
+	Role            string     `json:"role" db:"role"`
+	Status          string     `json:"status" db:"status"`
+	InviteExpiresAt *time.Time `json:"invite_expires_at,omitempty" db:"invite_expires_at"`
+	LastLoginAt     *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// MemberInviteByHosterResponse adalah response setelah owner mengundang / mengirim ulang undangan
+//
+// Contoh JSON:
+//
+//	{
+//	  "member": {"id": "uuid-member-1", "email": "siti@example.com", "role": "staff", "status": "invited", ...},
+//	  "invite_token": "q9Zx...",
+//	  "invite_expires_at": "2025-12-08T10:00:00Z"
+//	}
+//
+// Catatan: invite_token untuk development/testing (seperti OTP), production dikirim via email
+type MemberInviteByHosterResponse struct {
+	Member          MemberByHosterResponse `json:"member"`
+	InviteToken     string                 `json:"invite_token"`
+	InviteExpiresAt time.Time              `json:"invite_expires_at"`
+}
+
+// TeamAccessByHosterResponse adalah identitas & hak akses user yang sedang login di toko
+// Endpoint: GET /hoster/team/me (owner & staff)
+//
+// Contoh JSON:
+//
+//	{
+//	  "store_id": "uuid-hoster-1",
+//	  "member_id": "uuid-member-1",
+//	  "role": "staff",
+//	  "permissions": ["items:view", "bookings:view", "bookings:update_status"]
+//	}
+type TeamAccessByHosterResponse struct {
+	StoreID     string   `json:"store_id"`
+	MemberID    string   `json:"member_id,omitempty"` // Kosong untuk owner
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
@@ -23,12 +23,17 @@ import "time"
 //	    "Keterlambatan pengembalian dikenakan denda"
 //	  ]
 //	}
+//
+// Catatan: store_id dan item_id tidak boleh diisi bersamaan
 type CreateTnCRequest struct {
-	Description []string `json:"description"` // Array poin-poin T&C
+	Description []string `json:"description"`        // Array poin-poin T&C
+	StoreID     string   `json:"store_id,omitempty"` // Opsional: T&C khusus store, kosong = T&C umum hoster
+	ItemID      string   `json:"item_id,omitempty"`  // Opsional: T&C khusus item (berlaku bersama T&C store / umum)
 }
 
 // UpdateTnCRequest adalah payload untuk update T&C oleh hoster
 // Endpoint: PUT /api/v1/hoster/tnc/{id}
+// Update tidak mengubah versi lama, melainkan membuat versi baru (version + 1)
 //
 // Contoh JSON:
 //
@@ -54,6 +59,10 @@ type UpdateTnCRequest struct {
 //	{
 //	  "id": "uuid-tnc-123",
 //	  "hoster_id": "uuid-hoster-123",
+//	  "store_id": "uuid-store-123",
+//	  "item_id": null,
+//	  "version_id": "uuid-tnc-version-456",
+//	  "version": 3,
 //	  "description": [
 //	    "Penyewa wajib mengembalikan barang dalam kondisi baik",
 //	    "Keterlambatan pengembalian dikenakan denda"
@@ -64,7 +73,41 @@ type UpdateTnCRequest struct {
 type TnCResponse struct {
 	ID          string    `json:"id" db:"id"`
 	HosterID    string    `json:"hoster_id" db:"hoster_id"`
+	StoreID     *string   `json:"store_id" db:"tenant_id"` // null = T&C umum hoster
+	ItemID      *string   `json:"item_id" db:"item_id"`    // null = bukan T&C khusus item
+	VersionID   string    `json:"version_id" db:"version_id"`
+	Version     int       `json:"version" db:"version"`
 	Description []string  `json:"description" db:"description"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
+
+// ===================================================================
+// RESPONSE DTO - HOSTER, CUSTOMER & PUBLIC
+// ===================================================================
+
+// TnCVersionResponse adalah satu versi T&C yang tidak bisa diubah
+// Endpoint: GET /api/v1/hoster/tnc/{id}/versions, detail item publik (tnc_versions),
+// detail booking (accepted_terms)
+//
+// Contoh JSON:
+//
+//	{
+//	  "id": "uuid-tnc-version-456",
+//	  "version": 3,
+//	  "store_id": "uuid-store-123",
+//	  "item_id": null,
+//	  "description": ["Penyewa wajib mengembalikan barang dalam kondisi baik"],
+//	  "created_at": "2026-01-02T10:00:00Z",
+//	  "accepted_at": "2026-01-05T08:00:00Z"
+//	}
+type TnCVersionResponse struct {
+	ID                string     `json:"id" db:"id"`
+	Version           int        `json:"version" db:"version"`
+	StoreID           *string    `json:"store_id" db:"tenant_id"` // null = T&C umum hoster / khusus item
+	ItemID            *string    `json:"item_id" db:"item_id"`    // null = T&C umum hoster / khusus store
+	Description       []string   `json:"description" db:"-"`
+	CreatedByMemberID *string    `json:"created_by_member_id,omitempty" db:"created_by_member_id"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	AcceptedAt        *time.Time `json:"accepted_at,omitempty" db:"accepted_at"` // Hanya di detail booking
+}
@@ -0,0 +1,143 @@
+// ===================================================================
+// File: unit_dto.go
+// Deskripsi: DTO untuk unit fisik (aset) per item dan alokasinya ke booking (Hoster)
+// Catatan: SEMUA DTO unit HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// SetUnitTrackingByHosterRequest adalah payload untuk mengaktifkan / mematikan pelacakan unit item
+// Endpoint: PUT /api/v1/hoster/item/{id}/unit-tracking
+//
+// Catatan: saat aktif, stock item = jumlah unit available + rented (tidak bisa diubah manual)
+type SetUnitTrackingByHosterRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// CreateItemUnitByHosterRequest adalah payload untuk mendaftarkan unit baru di sebuah item
+// Endpoint: POST /api/v1/hoster/item/{id}/units
+//
+// Contoh JSON:
+//
+//	{
+//	  "label": "Tenda #3",
+//	  "serial_number": "TD-2025-0003",
+//	  "condition": "good",
+//	  "notes": "Frame baru diganti Nov 2025"
+//	}
+type CreateItemUnitByHosterRequest struct {
+	Label        string `json:"label"`
+	SerialNumber string `json:"serial_number"`
+	Condition    string `json:"condition"` // good (default), fair, damaged
+	Notes        string `json:"notes"`
+}
+
+// UpdateItemUnitByHosterRequest adalah payload untuk mengubah unit (semua field opsional)
+// Endpoint: PUT /api/v1/hoster/unit/{id}
+//
+// Catatan:
+//   - status hanya available / maintenance / retired (rented diatur otomatis oleh booking)
+//   - note dicatat di riwayat unit sebagai alasan perubahan
+type UpdateItemUnitByHosterRequest struct {
+	Label        *string `json:"label,omitempty"`
+	SerialNumber *string `json:"serial_number,omitempty"`
+	Condition    *string `json:"condition,omitempty"`
+	Status       *string `json:"status,omitempty"`
+	Notes        *string `json:"notes,omitempty"`
+	Note         string  `json:"note"`
+}
+
+// CreateUnitDamageNoteByHosterRequest adalah payload catatan kerusakan unit
+// Endpoint: POST /api/v1/hoster/unit/{id}/damage
+//
+// Contoh JSON:
+//
+//	{
+//	  "note": "Resleting pintu sobek",
+//	  "condition": "damaged",
+//	  "booking_id": "uuid-booking"
+//	}
+type CreateUnitDamageNoteByHosterRequest struct {
+	Note      string `json:"note"`
+	Condition string `json:"condition"`  // Opsional, kondisi unit setelah dicatat
+	BookingID string `json:"booking_id"` // Opsional, booking yang menyebabkan kerusakan
+}
+
+// BookingUnitAllocationRequest adalah pilihan unit untuk satu baris booking_item saat pickup
+// Dipakai di VerifyPickupCodeByHosterRequest.Units (serah terima lewat kode pickup)
+type BookingUnitAllocationRequest struct {
+	BookingItemID string   `json:"booking_item_id"`
+	UnitIDs       []string `json:"unit_ids"` // Jumlah harus sama dengan quantity booking_item
+}
+
+// BookingUnitReturnRequest adalah kondisi unit saat dikembalikan
+// Dipakai di UpdateBookingStatusByHosterRequest.Returns (status completed)
+//
+// Catatan: condition damaged → unit otomatis masuk maintenance
+type BookingUnitReturnRequest struct {
+	UnitID    string `json:"unit_id"`
+	Condition string `json:"condition"`
+	Note      string `json:"note"`
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER
+// ===================================================================
+
+// ItemUnitByHosterResponse adalah satu unit beserta booking yang sedang memakainya
+type ItemUnitByHosterResponse struct {
+	ID               string    `json:"id" db:"id"`
+	ItemID           string    `json:"item_id" db:"item_id"`
+	Label            string    `json:"label" db:"label"`
+	SerialNumber     *string   `json:"serial_number" db:"serial_number"`
+	Condition        string    `json:"condition" db:"condition"`
+	Status           string    `json:"status" db:"status"`
+	Notes            *string   `json:"notes" db:"notes"`
+	CurrentBookingID *string   `json:"current_booking_id" db:"current_booking_id"` // Terisi jika status rented
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ItemUnitListByHosterResponse adalah daftar unit sebuah item
+// Endpoint: GET /api/v1/hoster/item/{id}/units?status=available
+type ItemUnitListByHosterResponse struct {
+	ItemID       string                     `json:"item_id"`
+	UnitTracking bool                       `json:"unit_tracking"`
+	Stock        int                        `json:"stock"`
+	Units        []ItemUnitByHosterResponse `json:"units"`
+}
+
+// ItemUnitEventByHosterResponse adalah satu baris riwayat unit
+type ItemUnitEventByHosterResponse struct {
+	ID           string    `json:"id" db:"id"`
+	Kind         string    `json:"kind" db:"kind"` // created, updated, rented, returned, damage
+	BookingID    *string   `json:"booking_id" db:"booking_id"`
+	CustomerName *string   `json:"customer_name" db:"customer_name"` // Snapshot penerima booking (jika ada)
+	Condition    *string   `json:"condition" db:"condition"`
+	Status       *string   `json:"status" db:"status"`
+	Note         *string   `json:"note" db:"note"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// ItemUnitHistoryByHosterResponse adalah riwayat sewa & kerusakan satu unit
+// Endpoint: GET /api/v1/hoster/unit/{id}/history
+type ItemUnitHistoryByHosterResponse struct {
+	Unit   ItemUnitByHosterResponse        `json:"unit"`
+	Events []ItemUnitEventByHosterResponse `json:"events"`
+}
+
+// BookingItemUnitResponse adalah unit yang dialokasikan ke satu baris booking_item
+type BookingItemUnitResponse struct {
+	BookingItemID string     `json:"-" db:"booking_item_id"`
+	UnitID        string     `json:"unit_id" db:"unit_id"`
+	Label         string     `json:"label" db:"label"`
+	SerialNumber  *string    `json:"serial_number" db:"serial_number"`
+	AllocatedAt   time.Time  `json:"allocated_at" db:"allocated_at"`
+	ReturnedAt    *time.Time `json:"returned_at" db:"returned_at"`
+}
@@ -0,0 +1,116 @@
+// ===================================================================
+// File: upload_dto.go
+// Deskripsi: DTO untuk Upload Session (presigned PUT langsung ke bucket)
+// Catatan: SEMUA DTO upload session HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO
+// ===================================================================
+
+// CreateUploadSessionRequest adalah payload untuk meminta presigned PUT URL
+// Endpoint: POST /api/v1/upload/session
+//
+// Contoh JSON (Hoster - foto item):
+//
+//	{
+//	  "target": "item_photo",
+//	  "target_id": "uuid-item-123",
+//	  "content_type": "image/jpeg",
+//	  "file_size": 204800
+//	}
+//
+// Contoh JSON (Customer - dokumen identitas):
+//
+//	{
+//	  "target": "identity_document",
+//	  "content_type": "image/png"
+//	}
+//
+// Contoh JSON (Customer - selfie untuk identity yang masih pending):
+//
+//	{
+//	  "target": "identity_extra",
+//	  "target_id": "uuid-identity-123",
+//	  "photo_kind": "selfie",
+//	  "content_type": "image/jpeg"
+//	}
+//
+// Catatan: "identity_ktp" tetap diterima sebagai alias "identity_document"
+type CreateUploadSessionRequest struct {
+	Target      string `json:"target"`               // "item_photo" (hoster), "identity_document" / "identity_extra" (customer)
+	TargetID    string `json:"target_id,omitempty"`  // Wajib untuk item_photo (ID item) dan identity_extra (ID identity)
+	PhotoKind   string `json:"photo_kind,omitempty"` // Wajib untuk identity_extra: "selfie" atau "back"
+	ContentType string `json:"content_type"`         // image/jpeg, image/png, image/webp
+	FileSize    int64  `json:"file_size,omitempty"`  // Opsional, dicek lebih awal sebelum presign
+}
+
+// FinalizeUploadSessionRequest adalah payload saat client selesai upload ke presigned URL
+// Endpoint: POST /api/v1/upload/session/{id}/finalize
+//
+// Contoh JSON (Customer - paspor):
+//
+//	{
+//	  "document_type": "passport",
+//	  "document_number": "PA1234567",
+//	  "document_country": "AUS",
+//	  "document_expiry": "2030-05-01"
+//	}
+//
+// Catatan: body boleh kosong untuk item_photo & identity_extra, data dokumen wajib untuk identity_document.
+// Field lama "nik" tetap diterima (document_type kosong = ktp)
+type FinalizeUploadSessionRequest struct {
+	DocumentType    string `json:"document_type,omitempty"`
+	DocumentNumber  string `json:"document_number,omitempty"`
+	DocumentCountry string `json:"document_country,omitempty"`
+	DocumentExpiry  string `json:"document_expiry,omitempty"`
+	NIK             string `json:"nik,omitempty"` // Deprecated: pakai document_number
+}
+
+// ===================================================================
+// RESPONSE DTO
+// ===================================================================
+
+// UploadSessionResponse adalah response berisi presigned PUT URL
+//
+// Contoh JSON:
+//
+//	{
+//	  "session_id": "uuid-session-123",
+//	  "upload_url": "https://storage.com/hoster/...?X-Amz-Signature=...",
+//	  "method": "PUT",
+//	  "headers": {"Content-Type": "image/jpeg"},
+//	  "path": "uuid-hoster/item/uuid-item-123/uuid-file.jpg",
+//	  "max_size": 5242880,
+//	  "expires_at": "2025-12-01T10:15:00Z"
+//	}
+type UploadSessionResponse struct {
+	SessionID string            `json:"session_id"`
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`  // Selalu "PUT"
+	Headers   map[string]string `json:"headers"` // Header yang wajib dikirim client saat PUT
+	Path      string            `json:"path"`
+	MaxSize   int64             `json:"max_size"`   // Ukuran maksimal file (byte)
+	ExpiresAt time.Time         `json:"expires_at"` // Batas waktu finalize
+}
+
+// FinalizeUploadSessionResponse adalah response setelah objek diverifikasi dan ditempel ke record
+//
+// Contoh JSON:
+//
+//	{
+//	  "session_id": "uuid-session-123",
+//	  "target": "item_photo",
+//	  "target_id": "uuid-item-123",
+//	  "url": "https://storage.com/hoster/uuid-hoster/item/uuid-item-123/uuid-file.jpg"
+//	}
+type FinalizeUploadSessionResponse struct {
+	SessionID string `json:"session_id"`
+	Target    string `json:"target"`
+	TargetID  string `json:"target_id"` // item_id (item_photo) atau identity_id (identity_document / identity_extra)
+	URL       string `json:"url"`
+}
@@ -0,0 +1,100 @@
+// ===================================================================
+// File: variant_dto.go
+// Deskripsi: DTO untuk varian item (ukuran, warna, dll.) dengan stock & harga sendiri (Hoster & Public)
+// Catatan: SEMUA DTO varian item HANYA di file ini!
+// ===================================================================
+
+package dto
+
+import "time"
+
+// ===================================================================
+// REQUEST DTO - HOSTER
+// ===================================================================
+
+// ReplaceItemVariantsByHosterRequest adalah payload untuk mengatur seluruh varian sebuah item
+// Endpoint: PUT /api/v1/hoster/item/{id}/variants
+//
+// Contoh JSON:
+//
+//	{
+//	  "dimensions": ["Ukuran", "Warna"],
+//	  "variants": [
+//	    {"id": "uuid-variant-1", "options": ["40", "Hitam"], "stock": 3},
+//	    {"options": ["41", "Hitam"], "stock": 2, "price_per_day": 60000, "deposit": 150000}
+//	  ]
+//	}
+//
+// Catatan:
+//   - variants menggantikan seluruh varian lama: id diisi = ubah, tanpa id = varian baru,
+//     varian lama yang tidak dikirim dihapus (dinonaktifkan jika pernah dipakai booking)
+//   - options urut sesuai dimensions, kombinasi harus unik
+//   - dimensions & variants kosong = item kembali tanpa varian (stock terakhir dipertahankan)
+//   - stock item otomatis = jumlah stock varian
+type ReplaceItemVariantsByHosterRequest struct {
+	Dimensions []string                     `json:"dimensions"`
+	Variants   []ItemVariantByHosterRequest `json:"variants"`
+}
+
+// ItemVariantByHosterRequest adalah satu varian di ReplaceItemVariantsByHosterRequest
+type ItemVariantByHosterRequest struct {
+	ID          string   `json:"id,omitempty"` // Kosong = varian baru
+	Options     []string `json:"options"`
+	Stock       int      `json:"stock"`
+	PricePerDay *int     `json:"price_per_day,omitempty"` // Kosong = ikut harga item
+	Deposit     *int     `json:"deposit,omitempty"`       // Kosong = ikut deposit item
+}
+
+// ===================================================================
+// RESPONSE DTO - HOSTER
+// ===================================================================
+
+// ItemVariantByHosterResponse adalah satu varian item untuk hoster
+type ItemVariantByHosterResponse struct {
+	ID          string    `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Options     []string  `json:"options" db:"-"`
+	Stock       int       `json:"stock" db:"stock"`
+	PricePerDay *int      `json:"price_per_day" db:"price_per_day"` // null = ikut harga item
+	Deposit     *int      `json:"deposit" db:"deposit"`             // null = ikut deposit item
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ItemVariantListByHosterResponse adalah daftar varian aktif sebuah item
+// Endpoint: GET /api/v1/hoster/item/{id}/variants
+type ItemVariantListByHosterResponse struct {
+	ItemID     string                        `json:"item_id"`
+	Dimensions []string                      `json:"dimensions"`
+	Stock      int                           `json:"stock"` // Jumlah stock varian (stock manual jika tanpa varian)
+	Variants   []ItemVariantByHosterResponse `json:"variants"`
+}
+
+// ===================================================================
+// RESPONSE DTO - PUBLIC
+// ===================================================================
+
+// ItemVariantPublicResponse adalah varian item beserta ketersediaannya di detail item publik
+//
+// Contoh JSON:
+//
+//	{
+//	  "id": "uuid-variant-1",
+//	  "name": "40 / Hitam",
+//	  "options": ["40", "Hitam"],
+//	  "stock": 3,
+//	  "price_per_day": 50000,
+//	  "deposit": 100000,
+//	  "available": true,
+//	  "fully_booked_dates": ["2026-01-10", "2026-01-11"]
+//	}
+type ItemVariantPublicResponse struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Options          []string `json:"options"`
+	Stock            int      `json:"stock"`
+	PricePerDay      int      `json:"price_per_day"` // Harga efektif (override varian atau harga item)
+	Deposit          int      `json:"deposit"`       // Deposit efektif (override varian atau deposit item)
+	Available        bool     `json:"available"`     // false jika stock varian 0
+	FullyBookedDates []string `json:"fully_booked_dates"`
+}
@@ -0,0 +1,55 @@
+package blocklist
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/response"
+)
+
+/*
+BlocklistHandler menangani endpoint admin untuk sinyal risiko customer dari blokir hoster.
+*/
+type BlocklistHandler struct {
+	service BlocklistService
+}
+
+/*
+NewBlocklistHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *BlocklistHandler siap digunakan
+*/
+func NewBlocklistHandler(service BlocklistService) *BlocklistHandler {
+	return &BlocklistHandler{service: service}
+}
+
+/*
+ListBlockedCustomers menangani GET /api/v1/admin/customer-block?min_count=2&page=1&limit=20
+
+Output sukses:
+- 200 OK + { items, page, limit, total, total_pages }
+Output error:
+- 500 Internal Server Error
+*/
+func (h *BlocklistHandler) ListBlockedCustomers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	minCount, _ := strconv.Atoi(query.Get("min_count"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	result, err := h.service.ListBlockedCustomers(dto.CustomerBlockFilterByAdminRequest{
+		MinCount: minCount,
+		Page:     page,
+		Limit:    limit,
+	})
+	if err != nil {
+		log.Printf("ListBlockedCustomers handler: service error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+		return
+	}
+	response.OK(w, result, message.CustomerBlockRetrieved)
+}
@@ -0,0 +1,78 @@
+package blocklist
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/dto"
+)
+
+/*
+BlocklistRepository adalah kontrak akses data agregat blokir customer untuk admin.
+*/
+type BlocklistRepository interface {
+	ListBlockedCustomers(minCount, limit, offset int) ([]dto.CustomerBlockSummaryByAdminResponse, int, error)
+}
+
+/*
+blocklistRepository adalah implementasi repository agregat blokir customer.
+*/
+type blocklistRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewBlocklistRepository membuat instance repository dengan koneksi database.
+
+Output:
+- BlocklistRepository siap digunakan
+*/
+func NewBlocklistRepository(db *sqlx.DB) BlocklistRepository {
+	return &blocklistRepository{db: db}
+}
+
+/*
+blockSummary adalah agregat blokir per customer (jumlah hoster, blokir dokumen, blokir terakhir).
+*/
+const blockSummary = `
+	SELECT customer_id,
+	       COUNT(*) AS block_count,
+	       COUNT(document_number_hash) AS document_count,
+	       MAX(created_at) AS last_blocked_at
+	FROM hoster_customer_block
+	GROUP BY customer_id
+	HAVING COUNT(*) >= $1
+`
+
+/*
+ListBlockedCustomers mengambil customer yang diblokir minimal minCount hoster,
+diurutkan dari jumlah blokir terbanyak. Catatan hoster tidak ikut diambil.
+
+Output sukses:
+- ([]dto.CustomerBlockSummaryByAdminResponse, total, nil)
+Output error:
+- (nil, 0, error) → query gagal
+*/
+func (r *blocklistRepository) ListBlockedCustomers(minCount, limit, offset int) ([]dto.CustomerBlockSummaryByAdminResponse, int, error) {
+	var total int
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM (`+blockSummary+`) s`, minCount); err != nil {
+		log.Printf("ListBlockedCustomers: count error: %v", err)
+		return nil, 0, err
+	}
+
+	items := []dto.CustomerBlockSummaryByAdminResponse{}
+	err := r.db.Select(&items, `
+		SELECT s.customer_id, COALESCE(c.full_name, '') AS full_name, COALESCE(c.email, '') AS email,
+		       s.block_count, s.document_count, s.last_blocked_at
+		FROM (`+blockSummary+`) s
+		LEFT JOIN customer c ON c.id = s.customer_id
+		ORDER BY s.block_count DESC, s.last_blocked_at DESC, s.customer_id
+		LIMIT $2 OFFSET $3
+	`, minCount, limit, offset)
+	if err != nil {
+		log.Printf("ListBlockedCustomers: query error: %v", err)
+		return nil, 0, err
+	}
+	return items, total, nil
+}
@@ -0,0 +1,34 @@
+package blocklist
+
+import (
+	"lalan-be/internal/middleware"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+/*
+SetupBlocklistRoutes mendaftarkan endpoint admin untuk sinyal risiko customer (jumlah blokir hoster).
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/admin/customer-block
+2. Terapkan middleware JWT + role Admin (protected route)
+3. Daftarkan endpoint:
+  - GET / → customer dengan jumlah blokir terbanyak (filter min_count + pagination)
+
+Output:
+- Router terkonfigurasi dengan endpoint blokir customer admin
+*/
+func SetupBlocklistRoutes(router *mux.Router, h *BlocklistHandler) {
+	protected := router.PathPrefix("/api/v1/admin/customer-block").Subrouter()
+
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Admin)
+
+	protected.HandleFunc("", h.ListBlockedCustomers).Methods("GET")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,77 @@
+package blocklist
+
+import (
+	"errors"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+Konstanta pagination daftar risiko customer.
+*/
+const (
+	DefaultBlockLimit = 20
+	MaxBlockLimit     = 100
+)
+
+/*
+BlocklistService adalah kontrak logika bisnis sinyal risiko customer (jumlah blokir hoster) untuk admin.
+*/
+type BlocklistService interface {
+	ListBlockedCustomers(filter dto.CustomerBlockFilterByAdminRequest) (*dto.CustomerBlockListByAdminResponse, error)
+}
+
+/*
+blocklistService adalah implementasi service agregat blokir customer.
+*/
+type blocklistService struct {
+	repo BlocklistRepository
+}
+
+/*
+NewBlocklistService membuat instance service dengan dependency injection.
+
+Output:
+- BlocklistService siap digunakan
+*/
+func NewBlocklistService(repo BlocklistRepository) BlocklistService {
+	return &blocklistService{repo: repo}
+}
+
+/*
+ListBlockedCustomers mengambil customer dengan jumlah blokir terbanyak (paginated).
+
+Output sukses:
+- (*dto.CustomerBlockListByAdminResponse, nil)
+Output error:
+- (nil, error) → internal error
+*/
+func (s *blocklistService) ListBlockedCustomers(filter dto.CustomerBlockFilterByAdminRequest) (*dto.CustomerBlockListByAdminResponse, error) {
+	minCount, page, limit := filter.MinCount, filter.Page, filter.Limit
+	if minCount <= 0 {
+		minCount = 1
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultBlockLimit
+	}
+	if limit > MaxBlockLimit {
+		limit = MaxBlockLimit
+	}
+
+	items, total, err := s.repo.ListBlockedCustomers(minCount, limit, (page-1)*limit)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.CustomerBlockListByAdminResponse{
+		Items:      items,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
@@ -3,15 +3,18 @@ package identity
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"lalan-be/internal/dto"
 	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
 	"lalan-be/internal/response"
 
 	"github.com/gorilla/mux"
 )
 
 /*
-AdminIdentityHandler menangani endpoint admin untuk verifikasi identitas (KTP).
+AdminIdentityHandler menangani endpoint admin untuk verifikasi identitas (KTP, SIM, paspor, KITAS).
 Berfungsi sebagai adapter antara HTTP request dan service layer.
 */
 type AdminIdentityHandler struct {
@@ -29,74 +32,226 @@ func NewAdminIdentityHandler(service *AdminIdentityService) *AdminIdentityHandle
 }
 
 /*
-GetPendingIdentities menangani GET /api/v1/admin/identities/pending.
+GetReviewQueue menangani GET /api/v1/admin/identity/queue (alias lama: /pending).
 
-Alur kerja:
-1. Panggil service untuk ambil semua identitas berstatus pending
-2. Return data atau error
+Query string:
+- status         : pending (default), approved, rejected, all
+- role           : customer, hoster (kosong = semua)
+- document_type  : ktp, sim, passport, kitas
+- submitted_from : YYYY-MM-DD, submitted_to : YYYY-MM-DD
+- resubmission   : true (hanya upload ulang) / false (hanya upload pertama)
+- claim          : available (belum di-claim / milik saya) / mine
+- sort           : oldest (default) / newest
+- page, limit    : default 1 dan 20 (maks 100)
 
 Output sukses:
-- 200 OK + list identitas pending
+- 200 OK + { items, page, limit, total, total_pages }
 Output error:
+- 400 Bad Request → filter tidak valid
 - 500 Internal Server Error
 */
-func (h *AdminIdentityHandler) GetPendingIdentities(w http.ResponseWriter, r *http.Request) {
-	identities, err := h.service.GetPendingIdentities()
+func (h *AdminIdentityHandler) GetReviewQueue(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	filter := dto.IdentityQueueFilterByAdminRequest{
+		Status:        query.Get("status"),
+		DocumentType:  query.Get("document_type"),
+		Role:          query.Get("role"),
+		SubmittedFrom: query.Get("submitted_from"),
+		SubmittedTo:   query.Get("submitted_to"),
+		Resubmission:  query.Get("resubmission"),
+		Claim:         query.Get("claim"),
+		Sort:          query.Get("sort"),
+		Page:          page,
+		Limit:         limit,
+	}
+
+	queue, err := h.service.GetReviewQueue(middleware.GetUserID(r), filter)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, message.InternalError)
+		writeReviewError(w, err)
 		return
 	}
-	response.OK(w, identities, message.KTPListRetrieved)
+	response.OK(w, queue, message.IdentityQueueRetrieved)
 }
 
 /*
-ValidateIdentity menangani POST /api/v1/admin/identities/{userID}/validate.
+ClaimIdentity menangani POST /api/v1/admin/identity/{id}/claim.
 
 Alur kerja:
-1. Ambil userID dari path parameter
-2. Decode request body (status + reason optional)
-3. Panggil service untuk approve/reject KTP
+1. Ambil adminID dari context dan id dari path
+2. Decode body opsional (lease_minutes)
+3. Panggil service untuk claim dokumen
+
+Output sukses:
+- 200 OK + { identity_id, claimed_by, claimed_until }
+Output error:
+- 400 Bad Request → lease tidak valid
+- 404 Not Found   → dokumen tidak ditemukan
+- 409 Conflict    → sudah direview / sedang di-claim admin lain
+*/
+func (h *AdminIdentityHandler) ClaimIdentity(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var req dto.ClaimIdentityByAdminRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.BadRequest(w, message.BadRequest)
+			return
+		}
+	}
+
+	claim, err := h.service.ClaimIdentity(middleware.GetUserID(r), id, req)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	response.OK(w, claim, message.IdentityClaimed)
+}
+
+/*
+ReleaseIdentity menangani POST /api/v1/admin/identity/{id}/release.
+
+Output sukses:
+- 200 OK
+Output error:
+- 409 Conflict → dokumen tidak sedang di-claim oleh admin ini
+*/
+func (h *AdminIdentityHandler) ReleaseIdentity(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.service.ReleaseIdentity(middleware.GetUserID(r), id); err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	response.OK(w, nil, message.IdentityReleased)
+}
+
+/*
+ValidateIdentity menangani POST /api/v1/admin/identity/validate/{id}.
+
+Alur kerja:
+1. Ambil id dari path parameter dan adminID dari context
+2. Decode request body (status + template_id wajib saat reject + reason opsional)
+3. Panggil service untuk approve/reject dokumen identitas (reviewer dicatat)
 4. Return hasil validasi
 
 Output sukses:
 - 200 OK + pesan sukses
 Output error:
-- 400 Bad Request → body tidak valid / status tidak diperbolehkan
+- 400 Bad Request → body tidak valid / status tidak diperbolehkan / template tidak valid
+- 404 Not Found   → dokumen / template tidak ditemukan
+- 409 Conflict    → nomor dokumen sudah terverifikasi di akun lain / sudah direview / di-claim admin lain
 */
 func (h *AdminIdentityHandler) ValidateIdentity(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	// Path parameter now is the identity record id (not user id)
 	id := vars["id"]
 
-	var req struct {
-		Status string `json:"status"`           // "approved" atau "rejected"
-		Reason string `json:"reason,omitempty"` // wajib jika rejected
-	}
-
+	var req dto.VerifyIdentityByAdminRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.BadRequest(w, message.BadRequest)
 		return
 	}
 
-	err := h.service.ValidateIdentity(id, req.Status, req.Reason)
-	if err != nil {
-		response.BadRequest(w, err.Error())
+	if err := h.service.ValidateIdentity(middleware.GetUserID(r), id, req); err != nil {
+		writeReviewError(w, err)
 		return
 	}
 
 	// Response message sesuai status
-	msg := message.KTPApproved
+	msg := message.IdentityApproved
 	if req.Status == "rejected" {
-		msg = message.KTPRejected
+		msg = message.IdentityRejected
 	}
 	response.OK(w, nil, msg)
 }
 
+/*
+GetReviewHistory menangani GET /api/v1/admin/identity/{id}/history.
+
+Output sukses:
+- 200 OK + audit log (claim, release, approved, rejected) beserta nama admin
+Output error:
+- 404 Not Found → dokumen tidak ditemukan
+*/
+func (h *AdminIdentityHandler) GetReviewHistory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	logs, err := h.service.GetReviewHistory(id)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	response.OK(w, logs, message.IdentityHistoryRetrieved)
+}
+
+/*
+GetRejectionTemplates menangani GET /api/v1/admin/identity/rejection-templates?include_inactive=true.
+*/
+func (h *AdminIdentityHandler) GetRejectionTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.service.GetRejectionTemplates(r.URL.Query().Get("include_inactive") == "true")
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	response.OK(w, templates, message.RejectionTemplateListRetrieved)
+}
+
+/*
+CreateRejectionTemplate menangani POST /api/v1/admin/identity/rejection-templates.
+*/
+func (h *AdminIdentityHandler) CreateRejectionTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateRejectionTemplateByAdminRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	template, err := h.service.CreateRejectionTemplate(req)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	response.Success(w, http.StatusCreated, template, message.RejectionTemplateCreated)
+}
+
+/*
+UpdateRejectionTemplate menangani PUT /api/v1/admin/identity/rejection-templates/{templateID}.
+*/
+func (h *AdminIdentityHandler) UpdateRejectionTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdateRejectionTemplateByAdminRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	template, err := h.service.UpdateRejectionTemplate(mux.Vars(r)["templateID"], req)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	response.OK(w, template, message.RejectionTemplateUpdated)
+}
+
+/*
+DeleteRejectionTemplate menangani DELETE /api/v1/admin/identity/rejection-templates/{templateID}.
+Template hanya dinonaktifkan (soft delete).
+*/
+func (h *AdminIdentityHandler) DeleteRejectionTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeactivateRejectionTemplate(mux.Vars(r)["templateID"]); err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	response.OK(w, nil, message.RejectionTemplateDeactivated)
+}
+
 /*
 GetIdentity menangani GET /api/v1/admin/identities/{id}.
 
 Alur kerja:
-1. Ambil id dari path parameter (KTP ID)
+1. Ambil id dari path parameter (identity ID)
 2. Panggil service untuk detail identitas tersebut
 
 Output sukses:
@@ -114,5 +269,24 @@ func (h *AdminIdentityHandler) GetIdentity(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	response.OK(w, identity, message.KTPStatusRetrieved)
+	response.OK(w, identity, message.IdentityStatusRetrieved)
+}
+
+/*
+writeReviewError memetakan error service antrian review ke HTTP response.
+*/
+func writeReviewError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.NotFound, message.RejectionTemplateNotFound:
+		response.NotFound(w, err.Error())
+	case message.DocumentAlreadyVerified, message.IdentityAlreadyReviewed,
+		message.IdentityClaimedByOther, message.IdentityNotClaimed, message.RejectionTemplateCodeExists:
+		response.Error(w, http.StatusConflict, err.Error())
+	case message.InternalError:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	default:
+		response.BadRequest(w, err.Error())
+	}
 }
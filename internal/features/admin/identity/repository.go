@@ -1,16 +1,21 @@
 package identity
 
 import (
+	"database/sql"
+	"errors"
+	"log"
 	"time"
 
 	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 /*
 AdminIdentityRepository mengatur akses database untuk fitur verifikasi identitas oleh admin.
-Berisi query-query khusus admin (pending list, update status, detail per user).
+Berisi query-query khusus admin (antrian review customer & hoster, update status, detail per user).
 */
 type AdminIdentityRepository struct {
 	db *sqlx.DB
@@ -27,73 +32,108 @@ func NewAdminIdentityRepository(db *sqlx.DB) *AdminIdentityRepository {
 }
 
 /*
-GetPendingIdentities mengambil identitas terbaru per user yang berstatus 'pending'.
-
-Alur kerja:
-1. Query SELECT dengan DISTINCT ON untuk ambil KTP terbaru per user
-2. Filter status = 'pending'
-3. Urutkan berdasarkan user_id dan created_at DESC (terbaru)
-
-Output sukses:
-- ([]*model.IdentityModel, nil) - hanya KTP terbaru per user
-Output error:
-- (nil, error) → query gagal / koneksi DB bermasalah
+IdentityQueueQuery adalah filter antrian review yang sudah divalidasi service.
 */
-func (r *AdminIdentityRepository) GetPendingIdentities() ([]*domain.Identity, error) {
-	var identities []*domain.Identity
-	query := `
-		SELECT DISTINCT ON (user_id)
-			id, user_id, ktp_url, verified, status, reason, 
-			verified_at, created_at, updated_at 
-		FROM identity 
-		WHERE status = 'pending' 
-		ORDER BY user_id, created_at DESC
-	`
-
-	err := r.db.Select(&identities, query)
-	return identities, err
+type IdentityQueueQuery struct {
+	AdminID       string
+	Status        string // "pending", "approved", "rejected", "all"
+	DocumentType  string
+	Role          string // "", "customer", "hoster"
+	SubmittedFrom *time.Time
+	SubmittedTo   *time.Time
+	Resubmission  *bool
+	Claim         string // "", "available", "mine"
+	NewestFirst   bool
+	Limit         int
+	Offset        int
 }
 
 /*
-UpdateIdentityStatus memperbarui status verifikasi identitas (digunakan oleh service).
+identityQueueWhere adalah kondisi bersama untuk query list dan count antrian.
+Hanya dokumen terbaru per user (per role) yang masuk antrian (upload lama sudah digantikan).
+*/
+const identityQueueWhere = `
+	FROM identity i
+	LEFT JOIN customer c ON c.id = i.user_id AND i.user_role = 'customer'
+	LEFT JOIN hoster h ON h.id = i.user_id AND i.user_role = 'hoster'
+	LEFT JOIN admin a ON a.id = i.claimed_by AND i.claimed_until > NOW()
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) AS cnt FROM identity p
+		WHERE p.user_id = i.user_id AND p.user_role = i.user_role AND p.created_at < i.created_at
+	) prev
+	WHERE ($1 = 'all' OR i.status = $1)
+	  AND ($2 = '' OR i.document_type = $2)
+	  AND ($3::timestamptz IS NULL OR i.created_at >= $3::timestamptz)
+	  AND ($4::timestamptz IS NULL OR i.created_at < $4::timestamptz)
+	  AND ($5::boolean IS NULL OR (prev.cnt > 0) = $5::boolean)
+	  AND (
+		$6 = ''
+		OR ($6 = 'mine' AND i.claimed_by = $7::uuid AND i.claimed_until > NOW())
+		OR ($6 = 'available' AND (i.claimed_by IS NULL OR i.claimed_until <= NOW() OR i.claimed_by = $7::uuid))
+	  )
+	  AND ($8 = '' OR i.user_role = $8)
+	  AND NOT EXISTS (
+		SELECT 1 FROM identity n
+		WHERE n.user_id = i.user_id AND n.user_role = i.user_role AND n.created_at > i.created_at
+	  )
+`
+
+/*
+GetReviewQueue mengambil antrian review dokumen dengan filter dan pagination.
 
 Alur kerja:
-1. Jika status = approved → verified = true, verified_at = NOW()
-2. Jika rejected → reason bisa diisi
-3. Update kolom status, reason, verified, verified_at, updated_at
+1. Hitung total baris sesuai filter
+2. Ambil satu halaman baris (FIFO default: created_at ASC)
+3. Claim yang lease-nya sudah habis tidak ditampilkan sebagai claimed
 
 Output sukses:
-- nil
+- ([]dto.IdentityListByAdminResponse, total, nil)
 Output error:
-- error → query gagal / user_id tidak ditemukan
+- (nil, 0, error) → query gagal / koneksi DB bermasalah
 */
-func (r *AdminIdentityRepository) UpdateIdentityStatus(userID, status, reason string) error {
-	var reasonArg interface{}
-	if reason == "" {
-		reasonArg = nil
-	} else {
-		reasonArg = reason
+func (r *AdminIdentityRepository) GetReviewQueue(q IdentityQueueQuery) ([]dto.IdentityListByAdminResponse, int, error) {
+	args := []interface{}{q.Status, q.DocumentType, q.SubmittedFrom, q.SubmittedTo, q.Resubmission, q.Claim, nullableUUID(q.AdminID), q.Role}
+
+	var total int
+	if err := r.db.Get(&total, `SELECT COUNT(*) `+identityQueueWhere, args...); err != nil {
+		log.Printf("GetReviewQueue: count error: %v", err)
+		return nil, 0, err
 	}
 
-	verified := status == "approved"
+	order := "ASC"
+	if q.NewestFirst {
+		order = "DESC"
+	}
 
 	query := `
-		UPDATE identity
-		SET 
-			status = $1,
-			reason = $2,
-			verified = $3,
-			verified_at = CASE WHEN $1 = 'approved' THEN NOW() ELSE NULL END,
-			updated_at = NOW()
-		WHERE user_id = $4::uuid
+		SELECT
+			i.id, i.user_id, i.user_role,
+			COALESCE(c.full_name, h.store_name, '') AS user_name,
+			COALESCE(c.email, h.email, '') AS user_email,
+			i.document_type, i.document_url, i.document_number_encrypted,
+			i.duplicate_flag, prev.cnt AS previous_submissions, i.status,
+			CASE WHEN i.user_role = 'customer' THEN (
+				SELECT COUNT(*) FROM hoster_customer_block hb WHERE hb.customer_id = i.user_id
+			) ELSE 0 END AS block_count,
+			CASE WHEN i.claimed_until > NOW() THEN i.claimed_by END AS claimed_by,
+			a.full_name AS claimed_by_name,
+			CASE WHEN i.claimed_until > NOW() THEN i.claimed_until END AS claimed_until,
+			i.reviewed_by, i.created_at
+	` + identityQueueWhere + `
+		ORDER BY i.created_at ` + order + `, i.id
+		LIMIT $9 OFFSET $10
 	`
 
-	_, err := r.db.Exec(query, status, reasonArg, verified, userID)
-	return err
+	items := []dto.IdentityListByAdminResponse{}
+	if err := r.db.Select(&items, query, append(args, q.Limit, q.Offset)...); err != nil {
+		log.Printf("GetReviewQueue: select error: %v", err)
+		return nil, 0, err
+	}
+	return items, total, nil
 }
 
 /*
-GetIdentityByID mengambil satu record identitas berdasarkan ID KTP.
+GetIdentityByID mengambil satu record identitas berdasarkan ID identity.
 
 Output sukses:
 - (*domain.Identity, nil)
@@ -104,8 +144,11 @@ func (r *AdminIdentityRepository) GetIdentityByID(id string) (*domain.Identity,
 	var identity domain.Identity
 	query := `
 		SELECT 
-			id, user_id, ktp_url, verified, status, reason, 
-			verified_at, created_at, updated_at 
+			id, user_id, user_role, document_type, document_url, document_country,
+			document_expiry, extra_photos, business_documents, verified, status, reason,
+			verified_at, created_at, updated_at,
+			document_number_encrypted, holder_birth_date, holder_sex, duplicate_flag,
+			claimed_by, claimed_until, reviewed_by, rejection_template_id
 		FROM identity 
 		WHERE id = $1
 	`
@@ -129,8 +172,9 @@ func (r *AdminIdentityRepository) GetIdentityByUserID(userID string) (*domain.Id
 	var identity domain.Identity
 	query := `
 		SELECT 
-			id, user_id, ktp_url, verified, status, reason, 
-			verified_at, created_at, updated_at 
+			id, user_id, document_type, document_url, document_country,
+			document_expiry, verified, status, reason,
+			verified_at, created_at, updated_at
 		FROM identity 
 		WHERE user_id = $1 
 		LIMIT 1
@@ -144,20 +188,174 @@ func (r *AdminIdentityRepository) GetIdentityByUserID(userID string) (*domain.Id
 }
 
 /*
-ValidateIdentity memperbarui status verifikasi identitas (versi lengkap di repository).
+HasVerifiedDuplicateDocument mengecek apakah nomor dokumen pada identitas ini sudah terverifikasi di akun lain.
 
 Alur kerja:
-1. Validasi status hanya boleh "approved" atau "rejected"
-2. Set verified = true hanya jika approved
-3. Kosongkan reason jika approved
-4. Update semua field terkait + timestamp
+ 1. Ambil document_type, document_number_hash dan user_id dari identitas yang akan di-approve
+ 2. Cari identitas lain dengan jenis + hash + role sama, verified = true, user_id berbeda
+    (orang yang sama boleh punya akun customer dan akun hoster)
+
+Output sukses:
+- (true, nil)  → dokumen sudah dipakai akun lain yang terverifikasi
+- (false, nil) → aman / identitas lama tanpa nomor dokumen
+Output error:
+- (false, error) → query gagal
+*/
+func (r *AdminIdentityRepository) HasVerifiedDuplicateDocument(identityID string) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM identity cur
+			JOIN identity other
+			  ON other.document_type = cur.document_type
+			 AND other.document_number_hash = cur.document_number_hash
+			 AND other.user_role = cur.user_role
+			WHERE cur.id = $1
+			  AND cur.document_number_hash IS NOT NULL
+			  AND other.verified = true
+			  AND other.user_id <> cur.user_id
+		)
+	`
+
+	err := r.db.Get(&exists, query, identityID)
+	return exists, err
+}
+
+/*
+ClaimIdentity memberi lease dokumen ke admin selama leaseMinutes dan mencatatnya di audit log.
+
+Alur kerja:
+1. UPDATE kondisional: hanya dokumen pending yang belum di-claim, lease-nya habis, atau milik admin yang sama
+2. Insert audit log "claim"
+3. Commit
+
+Output sukses:
+- (claimed_until, nil)
+Output error:
+- (zero, sql.ErrNoRows) → dokumen tidak bisa di-claim (tidak ada / sudah direview / di-claim admin lain)
+- (zero, error) → query gagal
+*/
+func (r *AdminIdentityRepository) ClaimIdentity(identityID, adminID string, leaseMinutes int) (time.Time, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("ClaimIdentity: begin tx error: %v", err)
+		return time.Time{}, err
+	}
+	defer tx.Rollback()
+
+	var claimedUntil time.Time
+	err = tx.QueryRow(`
+		UPDATE identity
+		SET claimed_by = $2::uuid,
+			claimed_until = NOW() + make_interval(mins => $3),
+			updated_at = NOW()
+		WHERE id = $1::uuid
+		  AND status = 'pending'
+		  AND (claimed_by IS NULL OR claimed_until <= NOW() OR claimed_by = $2::uuid)
+		RETURNING claimed_until
+	`, identityID, adminID, leaseMinutes).Scan(&claimedUntil)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("ClaimIdentity: update error identity=%s: %v", identityID, err)
+		}
+		return time.Time{}, err
+	}
+
+	if err := insertReviewLog(tx, identityID, adminID, domain.IdentityReviewClaim, nil, nil); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return time.Time{}, err
+	}
+	return claimedUntil, nil
+}
+
+/*
+ReleaseIdentity melepas lease dokumen milik admin dan mengembalikannya ke antrian.
 
 Output sukses:
 - nil
 Output error:
-- error → status tidak valid / query gagal
+- sql.ErrNoRows → dokumen tidak sedang di-claim oleh admin ini
+- error → query gagal
+*/
+func (r *AdminIdentityRepository) ReleaseIdentity(identityID, adminID string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("ReleaseIdentity: begin tx error: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE identity
+		SET claimed_by = NULL, claimed_until = NULL, updated_at = NOW()
+		WHERE id = $1::uuid AND claimed_by = $2::uuid AND claimed_until > NOW()
+	`, identityID, adminID)
+	if err != nil {
+		log.Printf("ReleaseIdentity: update error identity=%s: %v", identityID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertReviewLog(tx, identityID, adminID, domain.IdentityReviewRelease, nil, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+/*
+GetIdentityReviewState mengambil status dan claim dokumen untuk menjelaskan kenapa claim/review gagal.
+
+Output sukses:
+- (*domain.Identity, nil) → hanya id, status, claimed_by, claimed_until yang terisi
+Output error:
+- (nil, sql.ErrNoRows) → dokumen tidak ditemukan
+- (nil, error) → query gagal
 */
-func (r *AdminIdentityRepository) ValidateIdentity(identityID, status, reason string) error {
+func (r *AdminIdentityRepository) GetIdentityReviewState(identityID string) (*domain.Identity, error) {
+	var identity domain.Identity
+	err := r.db.Get(&identity, `
+		SELECT id, status, claimed_by, claimed_until
+		FROM identity
+		WHERE id = $1::uuid
+	`, identityID)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+/*
+ReviewIdentity menyimpan keputusan approve/reject beserta reviewer dan audit log dalam satu transaksi.
+
+Alur kerja:
+1. UPDATE kondisional: hanya dokumen pending yang tidak di-claim admin lain (lease aktif)
+2. Set reviewed_by, template penolakan, kosongkan claim
+3. Jika dokumen milik hoster → sinkronkan badge hoster.is_verified
+(approve = true, reject = false hanya jika tidak ada dokumen hoster lain yang masih terverifikasi)
+4. Insert audit log "approved"/"rejected"
+5. Commit
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → dokumen tidak ada / sudah direview / di-claim admin lain
+- errors.New("duplicate_document") → nomor dokumen sudah terverifikasi di akun lain (excl_identity_verified_document)
+- error → query gagal
+*/
+func (r *AdminIdentityRepository) ReviewIdentity(identityID, adminID, status, reason string, templateID *string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("ReviewIdentity: begin tx error: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
 	now := time.Now()
 	verified := status == "approved"
 	var verifiedAt *time.Time
@@ -167,17 +365,198 @@ func (r *AdminIdentityRepository) ValidateIdentity(identityID, status, reason st
 
 	// Explicit types for parameters reduce Postgres ambiguity when some values
 	// are NULL and prevent errors like "inconsistent types deduced for parameter $N".
-	query := `
+	var userID, userRole string
+	err = tx.QueryRow(`
 		UPDATE identity
 		SET
 			status = $1::text,
 			reason = $2::text,
 			verified = $3::boolean,
-			verified_at = CASE WHEN $1::text = 'approved' THEN $4::timestamptz ELSE NULL END,
-			updated_at = $5::timestamptz
-		WHERE id = $6::uuid
-	`
+			verified_at = $4::timestamptz,
+			reviewed_by = $5::uuid,
+			rejection_template_id = $6::uuid,
+			claimed_by = NULL,
+			claimed_until = NULL,
+			updated_at = $7::timestamptz
+		WHERE id = $8::uuid
+		  AND status = 'pending'
+		  AND (claimed_by IS NULL OR claimed_until <= NOW() OR claimed_by = $5::uuid)
+		RETURNING user_id, user_role
+	`, status, reason, verified, verifiedAt, adminID, templateID, now, identityID).Scan(&userID, &userRole)
+	if err != nil {
+		// Approve bersamaan dengan dokumen yang sama → ditolak exclusion constraint
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == "excl_identity_verified_document" {
+			log.Printf("ReviewIdentity: duplicate verified document identity=%s", identityID)
+			return errors.New("duplicate_document")
+		}
+		if err != sql.ErrNoRows {
+			log.Printf("ReviewIdentity: update error identity=%s: %v", identityID, err)
+		}
+		return err
+	}
+
+	if userRole == string(domain.IdentityRoleHoster) {
+		// Menolak pengajuan ulang tidak boleh mencabut badge dari dokumen lama yang sudah terverifikasi
+		if _, err := tx.Exec(`
+			UPDATE hoster SET is_verified = $1, verified_at = $2, updated_at = NOW()
+			WHERE id = $3::uuid
+			  AND (
+			      $1
+			      OR NOT EXISTS (
+			          SELECT 1 FROM identity
+			          WHERE user_id = $3::uuid AND user_role = 'hoster' AND verified = true
+			      )
+			  )
+		`, verified, verifiedAt, userID); err != nil {
+			log.Printf("ReviewIdentity: update hoster badge error hoster=%s: %v", userID, err)
+			return err
+		}
+	}
+
+	var reasonArg *string
+	if reason != "" {
+		reasonArg = &reason
+	}
+	if err := insertReviewLog(tx, identityID, adminID, domain.IdentityReviewAction(status), reasonArg, templateID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+/*
+GetReviewHistory mengambil audit log review satu dokumen (terlama → terbaru).
+
+Output sukses:
+- ([]domain.IdentityReviewLog, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *AdminIdentityRepository) GetReviewHistory(identityID string) ([]domain.IdentityReviewLog, error) {
+	logs := []domain.IdentityReviewLog{}
+	err := r.db.Select(&logs, `
+		SELECT
+			l.id, l.identity_id, l.admin_id, COALESCE(a.full_name, '') AS admin_name,
+			l.action, l.reason, l.rejection_template_id, l.created_at
+		FROM identity_review_log l
+		LEFT JOIN admin a ON a.id = l.admin_id
+		WHERE l.identity_id = $1::uuid
+		ORDER BY l.created_at ASC
+	`, identityID)
+	if err != nil {
+		log.Printf("GetReviewHistory: query error identity=%s: %v", identityID, err)
+		return nil, err
+	}
+	return logs, nil
+}
+
+/*
+GetRejectionTemplates mengambil daftar template alasan penolakan.
+
+Output sukses:
+- ([]domain.IdentityRejectionTemplate, nil) → includeInactive=false hanya template aktif
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *AdminIdentityRepository) GetRejectionTemplates(includeInactive bool) ([]domain.IdentityRejectionTemplate, error) {
+	templates := []domain.IdentityRejectionTemplate{}
+	err := r.db.Select(&templates, `
+		SELECT id, code, title, reason, is_active, created_at, updated_at
+		FROM identity_rejection_template
+		WHERE $1 OR is_active = true
+		ORDER BY title ASC
+	`, includeInactive)
+	if err != nil {
+		log.Printf("GetRejectionTemplates: query error: %v", err)
+		return nil, err
+	}
+	return templates, nil
+}
+
+/*
+GetRejectionTemplateByID mengambil satu template alasan penolakan.
+
+Output sukses:
+- (*domain.IdentityRejectionTemplate, nil)
+Output error:
+- (nil, sql.ErrNoRows) → template tidak ditemukan
+- (nil, error) → query gagal
+*/
+func (r *AdminIdentityRepository) GetRejectionTemplateByID(id string) (*domain.IdentityRejectionTemplate, error) {
+	var template domain.IdentityRejectionTemplate
+	err := r.db.Get(&template, `
+		SELECT id, code, title, reason, is_active, created_at, updated_at
+		FROM identity_rejection_template
+		WHERE id = $1::uuid
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+/*
+CreateRejectionTemplate menyimpan template alasan penolakan baru.
+
+Output sukses:
+- nil → template.ID, CreatedAt, UpdatedAt terisi
+Output error:
+- error → code duplikat (unique violation) / query gagal
+*/
+func (r *AdminIdentityRepository) CreateRejectionTemplate(template *domain.IdentityRejectionTemplate) error {
+	err := r.db.QueryRowx(`
+		INSERT INTO identity_rejection_template (code, title, reason, is_active)
+		VALUES ($1, $2, $3, true)
+		RETURNING id, is_active, created_at, updated_at
+	`, template.Code, template.Title, template.Reason).Scan(&template.ID, &template.IsActive, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		log.Printf("CreateRejectionTemplate: insert error code=%s: %v", template.Code, err)
+	}
+	return err
+}
 
-	_, err := r.db.Exec(query, status, reason, verified, verifiedAt, now, identityID)
+/*
+UpdateRejectionTemplate menyimpan perubahan judul, alasan, dan status aktif template.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → template tidak ditemukan
+- error → query gagal
+*/
+func (r *AdminIdentityRepository) UpdateRejectionTemplate(template *domain.IdentityRejectionTemplate) error {
+	err := r.db.QueryRowx(`
+		UPDATE identity_rejection_template
+		SET title = $1, reason = $2, is_active = $3, updated_at = NOW()
+		WHERE id = $4::uuid
+		RETURNING updated_at
+	`, template.Title, template.Reason, template.IsActive, template.ID).Scan(&template.UpdatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("UpdateRejectionTemplate: update error id=%s: %v", template.ID, err)
+	}
 	return err
 }
+
+/*
+insertReviewLog mencatat satu aksi reviewer di dalam transaksi yang sedang berjalan.
+*/
+func insertReviewLog(tx *sqlx.Tx, identityID, adminID string, action domain.IdentityReviewAction, reason, templateID *string) error {
+	_, err := tx.Exec(`
+		INSERT INTO identity_review_log (identity_id, admin_id, action, reason, rejection_template_id)
+		VALUES ($1::uuid, $2::uuid, $3, $4, $5::uuid)
+	`, identityID, adminID, action, reason, templateID)
+	if err != nil {
+		log.Printf("insertReviewLog: insert error identity=%s action=%s: %v", identityID, action, err)
+	}
+	return err
+}
+
+/*
+nullableUUID mengubah string kosong menjadi NULL agar cast ::uuid tidak gagal.
+*/
+func nullableUUID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
@@ -8,15 +8,23 @@ import (
 )
 
 /*
-SetupAdminIdentityRoutes mendaftarkan semua endpoint admin untuk verifikasi identitas (KTP).
+SetupAdminIdentityRoutes mendaftarkan semua endpoint admin untuk verifikasi identitas (KTP, SIM, paspor, KITAS).
 
 Alur kerja:
 1. Buat subrouter dengan prefix /api/v1/admin/identity
 2. Terapkan middleware JWT + role Admin (protected route)
-3. Daftarkan endpoint:
-  - GET    /pending           → daftar identitas yang menunggu verifikasi
-  - GET    /{id}              → detail identitas berdasarkan KTP ID
-  - POST   /validate/{id}     → approve / reject KTP berdasarkan ID
+3. Daftarkan endpoint (route statis sebelum /{id}):
+  - GET    /queue                               → antrian review (filter + pagination)
+  - GET    /pending                             → alias lama /queue
+  - GET    /rejection-templates                 → daftar template alasan penolakan
+  - POST   /rejection-templates                 → tambah template
+  - PUT    /rejection-templates/{templateID}    → ubah / aktifkan kembali template
+  - DELETE /rejection-templates/{templateID}    → nonaktifkan template
+  - GET    /{id}                                → detail identitas berdasarkan identity ID
+  - GET    /{id}/history                        → audit log review
+  - POST   /{id}/claim                          → claim dokumen (lease N menit)
+  - POST   /{id}/release                        → lepas claim
+  - POST   /validate/{id}                       → approve / reject dokumen berdasarkan ID
 
 Output:
 - Router terkonfigurasi dengan endpoint admin yang aman dan konsisten
@@ -28,8 +36,16 @@ func SetupAdminIdentityRoutes(router *mux.Router, handler *AdminIdentityHandler)
 	protected.Use(middleware.JWTMiddleware)
 	protected.Use(middleware.Admin)
 
-	protected.HandleFunc("/pending", handler.GetPendingIdentities).Methods("GET")
+	protected.HandleFunc("/queue", handler.GetReviewQueue).Methods("GET")
+	protected.HandleFunc("/pending", handler.GetReviewQueue).Methods("GET")
+	protected.HandleFunc("/rejection-templates", handler.GetRejectionTemplates).Methods("GET")
+	protected.HandleFunc("/rejection-templates", handler.CreateRejectionTemplate).Methods("POST")
+	protected.HandleFunc("/rejection-templates/{templateID}", handler.UpdateRejectionTemplate).Methods("PUT")
+	protected.HandleFunc("/rejection-templates/{templateID}", handler.DeleteRejectionTemplate).Methods("DELETE")
 	protected.HandleFunc("/{id}", handler.GetIdentity).Methods("GET")
+	protected.HandleFunc("/{id}/history", handler.GetReviewHistory).Methods("GET")
+	protected.HandleFunc("/{id}/claim", handler.ClaimIdentity).Methods("POST")
+	protected.HandleFunc("/{id}/release", handler.ReleaseIdentity).Methods("POST")
 	protected.HandleFunc("/validate/{id}", handler.ValidateIdentity).Methods("POST")
 
 	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
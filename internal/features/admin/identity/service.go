@@ -1,10 +1,17 @@
 package identity
 
 import (
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"log"
+	"strings"
+	"time"
 
 	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
 	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
 )
 
 /*
@@ -26,52 +33,406 @@ func NewAdminIdentityService(repo *AdminIdentityRepository) *AdminIdentityServic
 }
 
 /*
-GetPendingIdentities mengambil semua identitas yang berstatus 'pending' untuk ditinjau admin.
+Konstanta antrian review dan lease claim.
+*/
+const (
+	DefaultQueueLimit   = 20
+	MaxQueueLimit       = 100
+	DefaultLeaseMinutes = 15
+	MaxLeaseMinutes     = 60
+)
+
+/*
+GetReviewQueue mengambil antrian review dokumen dengan filter dan pagination.
 
 Alur kerja:
-1. Delegasikan ke repository
+1. Normalisasi filter (status default pending, page default 1, limit default 20 maks 100)
+2. Validasi role (customer/hoster), jenis dokumen, tanggal (YYYY-MM-DD), resubmission (true/false), claim, sort
+3. Ambil halaman + total dari repository
+4. Isi nomor dokumen tersamar untuk list
+
+Output sukses:
+- (*dto.IdentityQueueByAdminResponse, nil)
+Output error:
+- (nil, error) → filter tidak valid (message.IdentityInvalidFilter / IdentityInvalidType) / query gagal
+*/
+func (s *AdminIdentityService) GetReviewQueue(adminID string, filter dto.IdentityQueueFilterByAdminRequest) (*dto.IdentityQueueByAdminResponse, error) {
+	q := IdentityQueueQuery{AdminID: adminID, Status: filter.Status, Claim: filter.Claim}
+
+	switch q.Status {
+	case "":
+		q.Status = "pending"
+	case "pending", "approved", "rejected", "all":
+	default:
+		return nil, errors.New(message.IdentityInvalidFilter)
+	}
+
+	switch domain.IdentityUserRole(filter.Role) {
+	case "", domain.IdentityRoleCustomer, domain.IdentityRoleHoster:
+		q.Role = filter.Role
+	default:
+		return nil, errors.New(message.IdentityInvalidRole)
+	}
+
+	if filter.DocumentType != "" {
+		docType, err := utils.ParseDocumentType(filter.DocumentType)
+		if err != nil {
+			return nil, err
+		}
+		q.DocumentType = string(docType)
+	}
+
+	if filter.SubmittedFrom != "" {
+		from, err := time.Parse("2006-01-02", filter.SubmittedFrom)
+		if err != nil {
+			return nil, errors.New(message.IdentityInvalidFilter)
+		}
+		q.SubmittedFrom = &from
+	}
+	if filter.SubmittedTo != "" {
+		to, err := time.Parse("2006-01-02", filter.SubmittedTo)
+		if err != nil {
+			return nil, errors.New(message.IdentityInvalidFilter)
+		}
+		// Inklusif: sampai akhir hari submitted_to
+		to = to.AddDate(0, 0, 1)
+		q.SubmittedTo = &to
+	}
+
+	switch filter.Resubmission {
+	case "":
+	case "true", "false":
+		resubmission := filter.Resubmission == "true"
+		q.Resubmission = &resubmission
+	default:
+		return nil, errors.New(message.IdentityInvalidFilter)
+	}
+
+	switch q.Claim {
+	case "", "available", "mine":
+	default:
+		return nil, errors.New(message.IdentityInvalidFilter)
+	}
+
+	switch filter.Sort {
+	case "", "oldest":
+	case "newest":
+		q.NewestFirst = true
+	default:
+		return nil, errors.New(message.IdentityInvalidFilter)
+	}
+
+	page, limit := filter.Page, filter.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultQueueLimit
+	}
+	if limit > MaxQueueLimit {
+		limit = MaxQueueLimit
+	}
+	q.Limit = limit
+	q.Offset = (page - 1) * limit
+
+	items, total, err := s.repo.GetReviewQueue(q)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	for i := range items {
+		if items[i].DocumentNumberEncrypted == nil {
+			continue
+		}
+		number, err := utils.RevealDocumentNumber(*items[i].DocumentNumberEncrypted)
+		if err != nil {
+			log.Printf("GetReviewQueue(admin service): failed to decrypt document number identity=%s: %v", items[i].IdentityID, err)
+			continue
+		}
+		items[i].DocumentNumberMasked = utils.MaskDocumentNumber(domain.DocumentType(items[i].DocumentType), number)
+	}
+
+	return &dto.IdentityQueueByAdminResponse{
+		Items:      items,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+/*
+ClaimIdentity mengambil dokumen dari antrian agar hanya admin ini yang meninjau selama lease berlaku.
+
+Alur kerja:
+1. Validasi lease_minutes (default 15, 1-60)
+2. Claim atomik di repository (claim ulang oleh admin yang sama = perpanjang lease)
+3. Jika gagal → cari tahu penyebab (tidak ada / sudah direview / di-claim admin lain)
+
+Output sukses:
+- (*dto.IdentityClaimByAdminResponse, nil)
+Output error:
+- (nil, error) → lease tidak valid / NotFound / IdentityAlreadyReviewed / IdentityClaimedByOther / internal error
+*/
+func (s *AdminIdentityService) ClaimIdentity(adminID, identityID string, req dto.ClaimIdentityByAdminRequest) (*dto.IdentityClaimByAdminResponse, error) {
+	if adminID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	lease := req.LeaseMinutes
+	if lease == 0 {
+		lease = DefaultLeaseMinutes
+	}
+	if lease < 1 || lease > MaxLeaseMinutes {
+		return nil, errors.New(message.IdentityInvalidLease)
+	}
+
+	claimedUntil, err := s.repo.ClaimIdentity(identityID, adminID, lease)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, s.reviewConflict(identityID)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("ClaimIdentity(admin service): identity=%s claimed by admin=%s until %s", identityID, adminID, claimedUntil.Format(time.RFC3339))
+	return &dto.IdentityClaimByAdminResponse{
+		IdentityID:   identityID,
+		ClaimedBy:    adminID,
+		ClaimedUntil: claimedUntil,
+	}, nil
+}
+
+/*
+ReleaseIdentity melepas claim admin sehingga dokumen kembali tersedia di antrian.
 
 Output sukses:
-- ([]*model.IdentityModel, nil)
+- nil
 Output error:
-- (nil, error) → query gagal / DB error
+- error → IdentityNotClaimed (bukan pemilik lease / lease habis) / internal error
 */
-func (s *AdminIdentityService) GetPendingIdentities() ([]*domain.Identity, error) {
-	return s.repo.GetPendingIdentities()
+func (s *AdminIdentityService) ReleaseIdentity(adminID, identityID string) error {
+	if adminID == "" {
+		return errors.New(message.Unauthorized)
+	}
+
+	if err := s.repo.ReleaseIdentity(identityID, adminID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.IdentityNotClaimed)
+		}
+		return errors.New(message.InternalError)
+	}
+	return nil
 }
 
 /*
-ValidateIdentity memproses persetujuan atau penolakan KTP oleh admin.
+ValidateIdentity memproses persetujuan atau penolakan dokumen identitas oleh admin.
 
 Alur kerja:
 1. Validasi status hanya boleh "approved" atau "rejected"
-2. Jika rejected → reason wajib diisi
-3. Panggil repository untuk update status
+2. Jika rejected → template_id wajib dan harus aktif, alasan = teks template (+ catatan tambahan)
+3. Jika approved → tolak bila nomor dokumen (jenis sama) sudah terverifikasi di akun lain
+4. Simpan keputusan + reviewer + audit log (gagal jika sudah direview atau di-claim admin lain),
+approve bersamaan dengan nomor dokumen yang sama ditolak constraint database → dokumen duplikat
 
 Output sukses:
 - nil → status berhasil diperbarui
 Output error:
-- error → status tidak valid / reason kosong saat rejected
+- error → status tidak valid / template tidak valid / dokumen duplikat / sudah direview / di-claim admin lain
 */
-func (s *AdminIdentityService) ValidateIdentity(identityID, status, reason string) error {
-	if status != "approved" && status != "rejected" {
+func (s *AdminIdentityService) ValidateIdentity(adminID, identityID string, req dto.VerifyIdentityByAdminRequest) error {
+	if adminID == "" {
+		return errors.New(message.Unauthorized)
+	}
+	if req.Status != "approved" && req.Status != "rejected" {
 		return errors.New(message.InvalidStatus)
 	}
 
-	if status == "rejected" && reason == "" {
-		return errors.New("reason required when rejecting KTP")
+	reason := strings.TrimSpace(req.Reason)
+	var templateID *string
+
+	if req.Status == "rejected" {
+		if req.TemplateID == "" {
+			return errors.New(message.RejectionTemplateRequired)
+		}
+		template, err := s.repo.GetRejectionTemplateByID(req.TemplateID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New(message.RejectionTemplateNotFound)
+			}
+			return errors.New(message.InternalError)
+		}
+		if !template.IsActive {
+			return errors.New(message.RejectionTemplateInactive)
+		}
+		if reason != "" {
+			reason = template.Reason + " - " + reason
+		} else {
+			reason = template.Reason
+		}
+		templateID = &template.ID
+	}
+
+	if req.Status == "approved" {
+		duplicate, err := s.repo.HasVerifiedDuplicateDocument(identityID)
+		if err != nil {
+			log.Printf("ValidateIdentity(admin service): duplicate check failed identity=%s: %v", identityID, err)
+			return errors.New(message.InternalError)
+		}
+		if duplicate {
+			return errors.New(message.DocumentAlreadyVerified)
+		}
+	}
+
+	if err := s.repo.ReviewIdentity(identityID, adminID, req.Status, reason, templateID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return s.reviewConflict(identityID)
+		}
+		if err.Error() == "duplicate_document" {
+			return errors.New(message.DocumentAlreadyVerified)
+		}
+		return errors.New(message.InternalError)
+	}
+
+	log.Printf("ValidateIdentity(admin service): identity=%s %s by admin=%s", identityID, req.Status, adminID)
+	return nil
+}
+
+/*
+GetReviewHistory mengambil audit log review satu dokumen.
+
+Output sukses:
+- ([]domain.IdentityReviewLog, nil)
+Output error:
+- (nil, error) → dokumen tidak ditemukan / internal error
+*/
+func (s *AdminIdentityService) GetReviewHistory(identityID string) ([]domain.IdentityReviewLog, error) {
+	if _, err := s.repo.GetIdentityReviewState(identityID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.NotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	logs, err := s.repo.GetReviewHistory(identityID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return logs, nil
+}
+
+/*
+GetRejectionTemplates mengambil daftar template alasan penolakan (includeInactive untuk halaman pengelolaan).
+*/
+func (s *AdminIdentityService) GetRejectionTemplates(includeInactive bool) ([]domain.IdentityRejectionTemplate, error) {
+	templates, err := s.repo.GetRejectionTemplates(includeInactive)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return templates, nil
+}
+
+/*
+CreateRejectionTemplate menambah template alasan penolakan baru.
+
+Alur kerja:
+1. Validasi code, title, reason wajib (code dinormalisasi ke huruf kecil)
+2. Simpan ke repository, code duplikat → RejectionTemplateCodeExists
+
+Output sukses:
+- (*domain.IdentityRejectionTemplate, nil)
+Output error:
+- (nil, error) → field kosong / code duplikat / internal error
+*/
+func (s *AdminIdentityService) CreateRejectionTemplate(req dto.CreateRejectionTemplateByAdminRequest) (*domain.IdentityRejectionTemplate, error) {
+	template := &domain.IdentityRejectionTemplate{
+		Code:   strings.ToLower(strings.TrimSpace(req.Code)),
+		Title:  strings.TrimSpace(req.Title),
+		Reason: strings.TrimSpace(req.Reason),
+	}
+	if template.Code == "" || template.Title == "" || template.Reason == "" {
+		return nil, errors.New(message.RejectionTemplateFieldRequired)
+	}
+
+	if err := s.repo.CreateRejectionTemplate(template); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, errors.New(message.RejectionTemplateCodeExists)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	return template, nil
+}
+
+/*
+UpdateRejectionTemplate mengubah judul/alasan template atau mengaktifkan/menonaktifkan template.
+
+Output sukses:
+- (*domain.IdentityRejectionTemplate, nil)
+Output error:
+- (nil, error) → template tidak ditemukan / internal error
+*/
+func (s *AdminIdentityService) UpdateRejectionTemplate(id string, req dto.UpdateRejectionTemplateByAdminRequest) (*domain.IdentityRejectionTemplate, error) {
+	template, err := s.repo.GetRejectionTemplateByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.RejectionTemplateNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	if title := strings.TrimSpace(req.Title); title != "" {
+		template.Title = title
+	}
+	if reason := strings.TrimSpace(req.Reason); reason != "" {
+		template.Reason = reason
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.UpdateRejectionTemplate(template); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.RejectionTemplateNotFound)
+		}
+		return nil, errors.New(message.InternalError)
 	}
+	return template, nil
+}
+
+/*
+DeactivateRejectionTemplate menonaktifkan template (soft delete).
+Template tetap dirujuk oleh keputusan lama sehingga tidak dihapus permanen.
+*/
+func (s *AdminIdentityService) DeactivateRejectionTemplate(id string) error {
+	inactive := false
+	_, err := s.UpdateRejectionTemplate(id, dto.UpdateRejectionTemplateByAdminRequest{IsActive: &inactive})
+	return err
+}
 
-	// Use the repository ValidateIdentity which targets a specific identity record id
-	// to avoid ambiguity and allow validating historical uploads separately.
-	return s.repo.ValidateIdentity(identityID, status, reason)
+/*
+reviewConflict menjelaskan kenapa claim/keputusan ditolak oleh UPDATE kondisional.
+*/
+func (s *AdminIdentityService) reviewConflict(identityID string) error {
+	state, err := s.repo.GetIdentityReviewState(identityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.NotFound)
+		}
+		return errors.New(message.InternalError)
+	}
+	if state.Status != "pending" {
+		return errors.New(message.IdentityAlreadyReviewed)
+	}
+	return errors.New(message.IdentityClaimedByOther)
 }
 
 /*
-GetIdentity mengambil detail identitas berdasarkan ID KTP.
+GetIdentity mengambil detail identitas berdasarkan ID identity.
 
 Alur kerja:
 1. Delegasikan ke repository
+2. Dekripsi nomor dokumen lengkap agar admin bisa mencocokkan dengan foto dokumen
+3. Untuk hoster: dekripsi juga nomor dokumen usaha (NIB/NPWP)
 
 Output sukses:
 - (*domain.Identity, nil)
@@ -79,5 +440,51 @@ Output error:
 - (nil, error) → identitas tidak ditemukan / DB error
 */
 func (s *AdminIdentityService) GetIdentity(id string) (*domain.Identity, error) {
-	return s.repo.GetIdentityByID(id)
+	identity, err := s.repo.GetIdentityByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	identity.DocumentNumber = revealDocumentNumber(identity)
+	identity.BusinessDocumentList = revealBusinessDocuments(identity)
+	return identity, nil
+}
+
+/*
+revealDocumentNumber mendekripsi nomor dokumen (kosong untuk data lama tanpa nomor atau jika gagal dekripsi).
+*/
+func revealDocumentNumber(identity *domain.Identity) string {
+	if identity.DocumentNumberEncrypted == nil {
+		return ""
+	}
+	number, err := utils.RevealDocumentNumber(*identity.DocumentNumberEncrypted)
+	if err != nil {
+		log.Printf("revealDocumentNumber: failed to decrypt document number identity=%s: %v", identity.ID, err)
+		return ""
+	}
+	return number
+}
+
+/*
+revealBusinessDocuments mendekripsi nomor dokumen usaha hoster (nil untuk customer / tanpa dokumen usaha).
+*/
+func revealBusinessDocuments(identity *domain.Identity) []domain.BusinessDocument {
+	if len(identity.BusinessDocuments) == 0 {
+		return nil
+	}
+	var docs []domain.BusinessDocument
+	if err := json.Unmarshal(identity.BusinessDocuments, &docs); err != nil {
+		log.Printf("revealBusinessDocuments: invalid business_documents identity=%s: %v", identity.ID, err)
+		return nil
+	}
+	for i := range docs {
+		number, err := utils.RevealDocumentNumber(docs[i].NumberEncrypted)
+		docs[i].NumberEncrypted = ""
+		if err != nil {
+			log.Printf("revealBusinessDocuments: failed to decrypt %s number identity=%s: %v", docs[i].Type, identity.ID, err)
+			continue
+		}
+		docs[i].Number = number
+	}
+	return docs
 }
@@ -0,0 +1,167 @@
+package settlement
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+SettlementHandler menangani endpoint admin untuk settlement payout hoster.
+*/
+type SettlementHandler struct {
+	service SettlementService
+}
+
+/*
+NewSettlementHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *SettlementHandler siap digunakan
+*/
+func NewSettlementHandler(service SettlementService) *SettlementHandler {
+	return &SettlementHandler{service: service}
+}
+
+/*
+ListBatches menangani GET /api/v1/admin/settlement/batches?status=pending&page=1&limit=20
+
+Output sukses:
+- 200 OK + { items, page, limit, total, total_pages }
+Output error:
+- 400 Bad Request / 500 Internal Server Error
+*/
+func (h *SettlementHandler) ListBatches(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	batches, err := h.service.ListBatches(dto.SettlementBatchFilterByAdminRequest{
+		Status: query.Get("status"),
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		log.Printf("ListBatches handler: service error: %v", err)
+		writeSettlementError(w, err)
+		return
+	}
+	response.OK(w, batches, message.SettlementRetrieved)
+}
+
+/*
+CreateBatch menangani POST /api/v1/admin/settlement/batches
+
+Output sukses:
+- 200 OK + detail batch beserta payout per hoster
+Output error:
+- 400 Bad Request (period_end tidak valid / tidak ada saldo jatuh tempo) / 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *SettlementHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSettlementBatchByAdminRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("CreateBatch: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	batch, err := h.service.CreateBatch(middleware.GetUserID(r), req)
+	if err != nil {
+		log.Printf("CreateBatch handler: service error: %v", err)
+		writeSettlementError(w, err)
+		return
+	}
+	response.OK(w, batch, message.SettlementBatchCreated)
+}
+
+/*
+GetBatch menangani GET /api/v1/admin/settlement/batches/{id}
+
+Output sukses:
+- 200 OK + detail batch beserta payout per hoster
+Output error:
+- 404 Not Found / 500 Internal Server Error
+*/
+func (h *SettlementHandler) GetBatch(w http.ResponseWriter, r *http.Request) {
+	batch, err := h.service.GetBatch(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetBatch handler: service error: %v", err)
+		writeSettlementError(w, err)
+		return
+	}
+	response.OK(w, batch, message.SettlementRetrieved)
+}
+
+/*
+MarkBatchPaid menangani POST /api/v1/admin/settlement/batches/{id}/paid
+
+Output sukses:
+- 200 OK + detail batch (status paid)
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict (sudah dibayar) / 500 Internal Server Error
+*/
+func (h *SettlementHandler) MarkBatchPaid(w http.ResponseWriter, r *http.Request) {
+	var req dto.MarkSettlementBatchPaidByAdminRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Printf("MarkBatchPaid: failed to decode JSON: %v", err)
+			response.BadRequest(w, message.BadRequest)
+			return
+		}
+	}
+
+	batch, err := h.service.MarkBatchPaid(middleware.GetUserID(r), mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("MarkBatchPaid handler: service error: %v", err)
+		writeSettlementError(w, err)
+		return
+	}
+	response.OK(w, batch, message.SettlementBatchPaid)
+}
+
+/*
+SyncLedger menangani POST /api/v1/admin/settlement/sync
+
+Output sukses:
+- 200 OK + jumlah booking diperiksa, transaksi baru, dan booking gagal
+Output error:
+- 500 Internal Server Error
+*/
+func (h *SettlementHandler) SyncLedger(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.SyncLedger()
+	if err != nil {
+		log.Printf("SyncLedger handler: service error: %v", err)
+		writeSettlementError(w, err)
+		return
+	}
+	response.OK(w, result, message.LedgerSynced)
+}
+
+/*
+writeSettlementError memetakan error service settlement ke HTTP response.
+*/
+func writeSettlementError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.SettlementBatchNotFound:
+		response.NotFound(w, err.Error())
+	case message.SettlementBatchAlreadyPaid:
+		response.Error(w, http.StatusConflict, err.Error())
+	case message.BadRequest,
+		message.SettlementInvalidPeriod,
+		message.SettlementInvalidStatus,
+		message.SettlementNothingDue:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
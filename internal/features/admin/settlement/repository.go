@@ -0,0 +1,366 @@
+package settlement
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/dto"
+)
+
+/*
+SettlementRepository adalah kontrak akses data batch settlement (payout hoster) untuk admin.
+*/
+type SettlementRepository interface {
+	CreateBatch(adminID string, periodEnd time.Time) (string, error)
+	ListBatches(status string, limit, offset int) ([]dto.SettlementBatchByAdminResponse, int, error)
+	GetBatch(batchID string) (*dto.SettlementBatchByAdminResponse, error)
+	GetBatchSettlements(batchID string) ([]dto.SettlementByAdminResponse, error)
+	MarkBatchPaid(batchID, adminID, reference string) error
+	GetUnpostedBookingIDs() ([]string, error)
+}
+
+/*
+settlementRepository adalah implementasi repository settlement admin.
+*/
+type settlementRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewSettlementRepository membuat instance repository dengan koneksi database.
+
+Output:
+- SettlementRepository siap digunakan
+*/
+func NewSettlementRepository(db *sqlx.DB) SettlementRepository {
+	return &settlementRepository{db: db}
+}
+
+/*
+batchColumns adalah kolom ringkasan batch (jumlah hoster dan total payout dihitung dari settlement).
+*/
+const batchColumns = `
+	b.id, b.period_end, b.status, b.created_by, b.paid_by, b.paid_at, b.reference, b.created_at,
+	COALESCE(s.hoster_count, 0) AS hoster_count,
+	COALESCE(s.total_amount, 0) AS total_amount
+`
+
+/*
+batchSummaryJoin menggabungkan agregat settlement per batch.
+*/
+const batchSummaryJoin = `
+	LEFT JOIN (
+		SELECT batch_id, COUNT(*) AS hoster_count, SUM(amount) AS total_amount
+		FROM settlement
+		GROUP BY batch_id
+	) s ON s.batch_id = b.id
+`
+
+/*
+CreateBatch membuat batch settlement untuk semua rental_income & late_fee yang belum di-settle sebelum periodEnd.
+
+Alur kerja:
+1. Advisory lock transaksi → pembuatan batch tidak berjalan paralel (transaksi tidak masuk dua batch)
+2. Insert header batch
+3. Dalam satu statement: jumlahkan kredit hoster_payable per hoster (hanya yang > 0), insert settlement per hoster,
+lalu tandai transaksi dengan settlement_id-nya
+4. Batch tanpa settlement dibatalkan (rollback)
+
+Parameter:
+- periodEnd: tanggal akhir periode (inklusif, occurred_at < period_end + 1 hari)
+
+Output sukses:
+- (batchID, nil)
+Output error:
+- ("", sql.ErrNoRows) → tidak ada saldo hoster yang jatuh tempo
+- ("", error) → query gagal
+*/
+func (r *settlementRepository) CreateBatch(adminID string, periodEnd time.Time) (string, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("CreateBatch: error starting transaction: %v", err)
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('settlement_batch'))`); err != nil {
+		log.Printf("CreateBatch: lock error: %v", err)
+		return "", err
+	}
+
+	var batchID string
+	err = tx.QueryRow(`
+		INSERT INTO settlement_batch (period_end, created_by)
+		VALUES ($1, $2)
+		RETURNING id
+	`, periodEnd, adminID).Scan(&batchID)
+	if err != nil {
+		log.Printf("CreateBatch: insert batch error admin=%s err=%v", adminID, err)
+		return "", err
+	}
+
+	query := `
+		WITH due AS (
+			SELECT t.id, t.hoster_id, SUM(e.credit - e.debit) AS amount
+			FROM ledger_transaction t
+			JOIN ledger_entry e ON e.transaction_id = t.id AND e.account = 'hoster_payable'
+			WHERE t.kind IN ('rental_income', 'late_fee')
+			  AND t.settlement_id IS NULL
+			  AND t.occurred_at < $2::date + 1
+			GROUP BY t.id, t.hoster_id
+		),
+		totals AS (
+			SELECT hoster_id, SUM(amount) AS amount, COUNT(*) AS transaction_count
+			FROM due
+			GROUP BY hoster_id
+			HAVING SUM(amount) > 0
+		),
+		created AS (
+			INSERT INTO settlement (batch_id, hoster_id, amount, transaction_count)
+			SELECT $1, hoster_id, amount, transaction_count
+			FROM totals
+			RETURNING id, hoster_id
+		)
+		UPDATE ledger_transaction t
+		SET settlement_id = created.id
+		FROM due
+		JOIN created ON created.hoster_id = due.hoster_id
+		WHERE t.id = due.id
+	`
+	result, err := tx.Exec(query, batchID, periodEnd)
+	if err != nil {
+		log.Printf("CreateBatch: settle error batch=%s err=%v", batchID, err)
+		return "", err
+	}
+	settled, err := result.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if settled == 0 {
+		return "", sql.ErrNoRows
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("CreateBatch: error committing transaction: %v", err)
+		return "", err
+	}
+	return batchID, nil
+}
+
+/*
+ListBatches mengambil daftar batch settlement terbaru dulu.
+
+Output sukses:
+- ([]dto.SettlementBatchByAdminResponse, total, nil)
+Output error:
+- (nil, 0, error) → query gagal
+*/
+func (r *settlementRepository) ListBatches(status string, limit, offset int) ([]dto.SettlementBatchByAdminResponse, int, error) {
+	var total int
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM settlement_batch WHERE ($1 = '' OR status = $1)`, status); err != nil {
+		log.Printf("ListBatches: count error: %v", err)
+		return nil, 0, err
+	}
+
+	batches := []dto.SettlementBatchByAdminResponse{}
+	query := `
+		SELECT ` + batchColumns + `
+		FROM settlement_batch b
+		` + batchSummaryJoin + `
+		WHERE ($1 = '' OR b.status = $1)
+		ORDER BY b.created_at DESC, b.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	if err := r.db.Select(&batches, query, status, limit, offset); err != nil {
+		log.Printf("ListBatches: query error: %v", err)
+		return nil, 0, err
+	}
+	return batches, total, nil
+}
+
+/*
+GetBatch mengambil ringkasan satu batch settlement.
+
+Output sukses:
+- (*dto.SettlementBatchByAdminResponse, nil)
+Output error:
+- (nil, sql.ErrNoRows) → batch tidak ditemukan
+- (nil, error) → query gagal
+*/
+func (r *settlementRepository) GetBatch(batchID string) (*dto.SettlementBatchByAdminResponse, error) {
+	var batch dto.SettlementBatchByAdminResponse
+	query := `
+		SELECT ` + batchColumns + `
+		FROM settlement_batch b
+		` + batchSummaryJoin + `
+		WHERE b.id = $1
+	`
+	if err := r.db.Get(&batch, query, batchID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetBatch: query error batch=%s err=%v", batchID, err)
+		}
+		return nil, err
+	}
+	return &batch, nil
+}
+
+/*
+GetBatchSettlements mengambil payout per hoster di dalam batch, nominal terbesar dulu.
+
+Output sukses:
+- ([]dto.SettlementByAdminResponse, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *settlementRepository) GetBatchSettlements(batchID string) ([]dto.SettlementByAdminResponse, error) {
+	settlements := []dto.SettlementByAdminResponse{}
+	query := `
+		SELECT s.id, s.hoster_id, h.store_name, h.email, s.amount, s.transaction_count, s.status
+		FROM settlement s
+		JOIN hoster h ON h.id = s.hoster_id
+		WHERE s.batch_id = $1
+		ORDER BY s.amount DESC, h.store_name ASC
+	`
+	if err := r.db.Select(&settlements, query, batchID); err != nil {
+		log.Printf("GetBatchSettlements: query error batch=%s err=%v", batchID, err)
+		return nil, err
+	}
+	return settlements, nil
+}
+
+/*
+MarkBatchPaid menandai batch dan seluruh settlement-nya sudah ditransfer, sekaligus memposting payout ke ledger.
+
+Alur kerja:
+1. Kunci baris batch (FOR UPDATE), batch yang sudah paid ditolak
+2. Dalam satu statement: update settlement pending → paid, insert transaksi payout per settlement,
+lalu baris debit hoster_payable / kredit platform_cash sebesar amount
+3. Update status batch → paid
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → batch tidak ditemukan
+- errors.New("already paid") → batch sudah dibayar
+- error → query gagal
+*/
+func (r *settlementRepository) MarkBatchPaid(batchID, adminID, reference string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("MarkBatchPaid: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	if err := tx.Get(&status, `SELECT status FROM settlement_batch WHERE id = $1 FOR UPDATE`, batchID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("MarkBatchPaid: get batch error batch=%s err=%v", batchID, err)
+		}
+		return err
+	}
+	if status == "paid" {
+		return errors.New("already paid")
+	}
+
+	payoutQuery := `
+		WITH paid AS (
+			UPDATE settlement
+			SET status = 'paid', paid_at = NOW(), reference = NULLIF($2, '')
+			WHERE batch_id = $1 AND status = 'pending'
+			RETURNING id, hoster_id, amount
+		),
+		payout AS (
+			INSERT INTO ledger_transaction (hoster_id, settlement_id, kind, description, occurred_at)
+			SELECT hoster_id, id, 'payout', 'Payout settlement ' || id::text, NOW()
+			FROM paid
+			RETURNING id, hoster_id, settlement_id
+		)
+		INSERT INTO ledger_entry (transaction_id, hoster_id, account, debit, credit)
+		SELECT payout.id, payout.hoster_id, v.account, v.debit, v.credit
+		FROM payout
+		JOIN paid ON paid.id = payout.settlement_id
+		CROSS JOIN LATERAL (
+			VALUES ('hoster_payable', paid.amount, 0::bigint),
+			       ('platform_cash', 0::bigint, paid.amount)
+		) AS v(account, debit, credit)
+	`
+	if _, err := tx.Exec(payoutQuery, batchID, reference); err != nil {
+		log.Printf("MarkBatchPaid: payout error batch=%s err=%v", batchID, err)
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE settlement_batch
+		SET status = 'paid', paid_by = $2, paid_at = NOW(), reference = NULLIF($3, '')
+		WHERE id = $1
+	`, batchID, adminID, reference)
+	if err != nil {
+		log.Printf("MarkBatchPaid: update batch error batch=%s err=%v", batchID, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("MarkBatchPaid: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+GetUnpostedBookingIDs mengambil booking yang transaksi ledger-nya belum lengkap.
+
+Alur kerja:
+1. Booking sudah dibayar (on_progress, on_rent, completed) bernilai > 0 tanpa booking_payment
+2. Booking completed dengan sewa bersih > 0 tanpa rental_income
+3. Booking completed dengan denda > 0 tanpa late_fee
+4. Perubahan booking yang disetujui tanpa booking_amendment
+
+Output sukses:
+- ([]string booking ID, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *settlementRepository) GetUnpostedBookingIDs() ([]string, error) {
+	var ids []string
+	query := `
+		SELECT b.id
+		FROM booking b
+		WHERE (
+			b.status IN ('on_progress', 'on_rent', 'completed')
+			AND GREATEST(b.rental - b.discount, 0) + b.deposit > 0
+			AND NOT EXISTS (
+				SELECT 1 FROM ledger_transaction t
+				WHERE t.booking_id = b.id AND t.kind = 'booking_payment'
+			)
+		) OR (
+			b.status = 'completed'
+			AND b.rental - b.discount > 0
+			AND NOT EXISTS (
+				SELECT 1 FROM ledger_transaction t
+				WHERE t.booking_id = b.id AND t.kind = 'rental_income'
+			)
+		) OR (
+			b.status = 'completed'
+			AND b.late_fee > 0
+			AND NOT EXISTS (
+				SELECT 1 FROM ledger_transaction t
+				WHERE t.booking_id = b.id AND t.kind = 'late_fee'
+			)
+		) OR EXISTS (
+			SELECT 1 FROM booking_amendment a
+			WHERE a.booking_id = b.id AND a.status = 'approved'
+			  AND NOT EXISTS (SELECT 1 FROM ledger_transaction t WHERE t.amendment_id = a.id)
+		)
+		ORDER BY b.created_at ASC
+	`
+	if err := r.db.Select(&ids, query); err != nil {
+		log.Printf("GetUnpostedBookingIDs: query error: %v", err)
+		return nil, err
+	}
+	return ids, nil
+}
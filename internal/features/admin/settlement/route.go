@@ -0,0 +1,42 @@
+package settlement
+
+import (
+	"lalan-be/internal/middleware"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+/*
+SetupSettlementRoutes mendaftarkan endpoint admin untuk settlement payout hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/admin/settlement
+2. Terapkan middleware JWT + role Admin (protected route)
+3. Daftarkan endpoint:
+  - GET  /batches           → daftar batch settlement (filter status + pagination)
+  - POST /batches           → buat batch dari saldo hoster yang belum di-settle s.d. period_end
+  - GET  /batches/{id}      → detail batch beserta payout per hoster
+  - POST /batches/{id}/paid → tandai batch sudah ditransfer (posting payout ke ledger)
+  - POST /sync              → posting ulang ledger booking yang belum lengkap (idempotent)
+
+Output:
+- Router terkonfigurasi dengan endpoint settlement admin
+*/
+func SetupSettlementRoutes(router *mux.Router, h *SettlementHandler) {
+	protected := router.PathPrefix("/api/v1/admin/settlement").Subrouter()
+
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Admin)
+
+	protected.HandleFunc("/batches", h.ListBatches).Methods("GET")
+	protected.HandleFunc("/batches", h.CreateBatch).Methods("POST")
+	protected.HandleFunc("/batches/{id}", h.GetBatch).Methods("GET")
+	protected.HandleFunc("/batches/{id}/paid", h.MarkBatchPaid).Methods("POST")
+	protected.HandleFunc("/sync", h.SyncLedger).Methods("POST")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
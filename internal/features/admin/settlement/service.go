@@ -0,0 +1,243 @@
+package settlement
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+Konstanta pagination dan validasi settlement.
+*/
+const (
+	DefaultBatchLimit  = 20
+	MaxBatchLimit      = 100
+	MaxReferenceLength = 255
+)
+
+/*
+BookingLedgerPoster adalah kontrak posting ledger booking (diimplementasikan repository ledger hoster).
+*/
+type BookingLedgerPoster interface {
+	PostBookingLedger(bookingID string, commissionRateBps int) (int, error)
+}
+
+/*
+SettlementService adalah kontrak logika bisnis settlement payout hoster untuk admin.
+*/
+type SettlementService interface {
+	CreateBatch(adminID string, req dto.CreateSettlementBatchByAdminRequest) (*dto.SettlementBatchDetailByAdminResponse, error)
+	ListBatches(filter dto.SettlementBatchFilterByAdminRequest) (*dto.SettlementBatchListByAdminResponse, error)
+	GetBatch(batchID string) (*dto.SettlementBatchDetailByAdminResponse, error)
+	MarkBatchPaid(adminID, batchID string, req dto.MarkSettlementBatchPaidByAdminRequest) (*dto.SettlementBatchDetailByAdminResponse, error)
+	SyncLedger() (*dto.LedgerSyncByAdminResponse, error)
+}
+
+/*
+settlementService adalah implementasi service settlement admin.
+*/
+type settlementService struct {
+	repo   SettlementRepository
+	ledger BookingLedgerPoster
+}
+
+/*
+NewSettlementService membuat instance service dengan dependency injection.
+
+Output:
+- SettlementService siap digunakan
+*/
+func NewSettlementService(repo SettlementRepository, ledger BookingLedgerPoster) SettlementService {
+	return &settlementService{repo: repo, ledger: ledger}
+}
+
+/*
+CreateBatch membuat batch settlement sampai period_end.
+
+Alur kerja:
+1. Validasi period_end (YYYY-MM-DD, sebelum hari ini agar transaksi periode sudah final)
+2. Repository mengelompokkan rental_income & late_fee yang belum di-settle per hoster
+3. Kembalikan detail batch
+
+Output sukses:
+- (*dto.SettlementBatchDetailByAdminResponse, nil)
+Output error:
+- (nil, error) → unauthorized / SettlementInvalidPeriod / SettlementNothingDue / internal error
+*/
+func (s *settlementService) CreateBatch(adminID string, req dto.CreateSettlementBatchByAdminRequest) (*dto.SettlementBatchDetailByAdminResponse, error) {
+	if adminID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	periodEnd, err := time.Parse("2006-01-02", strings.TrimSpace(req.PeriodEnd))
+	if err != nil || !periodEnd.Before(time.Now().UTC().Truncate(24*time.Hour)) {
+		return nil, errors.New(message.SettlementInvalidPeriod)
+	}
+
+	batchID, err := s.repo.CreateBatch(adminID, periodEnd)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.SettlementNothingDue)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("CreateBatch(settlement service): admin %s created batch %s until %s", adminID, batchID, periodEnd.Format("2006-01-02"))
+	return s.GetBatch(batchID)
+}
+
+/*
+ListBatches mengambil daftar batch settlement (paginated).
+
+Output sukses:
+- (*dto.SettlementBatchListByAdminResponse, nil)
+Output error:
+- (nil, error) → SettlementInvalidStatus / internal error
+*/
+func (s *settlementService) ListBatches(filter dto.SettlementBatchFilterByAdminRequest) (*dto.SettlementBatchListByAdminResponse, error) {
+	switch filter.Status {
+	case "", "pending", "paid":
+	default:
+		return nil, errors.New(message.SettlementInvalidStatus)
+	}
+
+	page, limit := filter.Page, filter.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultBatchLimit
+	}
+	if limit > MaxBatchLimit {
+		limit = MaxBatchLimit
+	}
+
+	items, total, err := s.repo.ListBatches(filter.Status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.SettlementBatchListByAdminResponse{
+		Items:      items,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+/*
+GetBatch mengambil detail batch beserta payout per hoster.
+
+Output sukses:
+- (*dto.SettlementBatchDetailByAdminResponse, nil)
+Output error:
+- (nil, error) → SettlementBatchNotFound / internal error
+*/
+func (s *settlementService) GetBatch(batchID string) (*dto.SettlementBatchDetailByAdminResponse, error) {
+	if _, err := uuid.Parse(batchID); err != nil {
+		return nil, errors.New(message.SettlementBatchNotFound)
+	}
+
+	batch, err := s.repo.GetBatch(batchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.SettlementBatchNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	settlements, err := s.repo.GetBatchSettlements(batchID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.SettlementBatchDetailByAdminResponse{
+		SettlementBatchByAdminResponse: *batch,
+		Settlements:                    settlements,
+	}, nil
+}
+
+/*
+MarkBatchPaid menandai batch sudah ditransfer ke semua hoster di dalamnya.
+
+Alur kerja:
+1. Validasi reference (opsional, maksimal 255 karakter)
+2. Repository mengubah status settlement + batch dan memposting payout ke ledger dalam satu transaksi
+3. Kembalikan detail batch terbaru
+
+Output sukses:
+- (*dto.SettlementBatchDetailByAdminResponse, nil)
+Output error:
+- (nil, error) → unauthorized / BadRequest / SettlementBatchNotFound / SettlementBatchAlreadyPaid / internal error
+*/
+func (s *settlementService) MarkBatchPaid(adminID, batchID string, req dto.MarkSettlementBatchPaidByAdminRequest) (*dto.SettlementBatchDetailByAdminResponse, error) {
+	if adminID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(batchID); err != nil {
+		return nil, errors.New(message.SettlementBatchNotFound)
+	}
+
+	reference := strings.TrimSpace(req.Reference)
+	if len(reference) > MaxReferenceLength {
+		return nil, errors.New(message.BadRequest)
+	}
+
+	if err := s.repo.MarkBatchPaid(batchID, adminID, reference); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.SettlementBatchNotFound)
+		}
+		if err.Error() == "already paid" {
+			return nil, errors.New(message.SettlementBatchAlreadyPaid)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("MarkBatchPaid(settlement service): admin %s marked batch %s as paid", adminID, batchID)
+	return s.GetBatch(batchID)
+}
+
+/*
+SyncLedger memposting ulang transaksi ledger booking yang belum lengkap.
+Dipakai untuk booking lama (sebelum ledger ada) atau posting yang gagal saat update status.
+Posting idempotent, sehingga aman dijalankan berkali-kali.
+
+Alur kerja:
+1. Ambil booking yang transaksi ledger-nya belum lengkap
+2. Posting per booking dengan tarif komisi saat ini (gagal dicatat, tidak menghentikan proses)
+
+Output sukses:
+- (*dto.LedgerSyncByAdminResponse, nil)
+Output error:
+- (nil, error) → internal error
+*/
+func (s *settlementService) SyncLedger() (*dto.LedgerSyncByAdminResponse, error) {
+	ids, err := s.repo.GetUnpostedBookingIDs()
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	rate := config.GetPlatformCommissionBps()
+	result := &dto.LedgerSyncByAdminResponse{Bookings: len(ids)}
+	for _, id := range ids {
+		posted, err := s.ledger.PostBookingLedger(id, rate)
+		if err != nil {
+			log.Printf("SyncLedger(settlement service): failed to post booking %s: %v", id, err)
+			result.Failed++
+			continue
+		}
+		result.Transactions += posted
+	}
+
+	log.Printf("SyncLedger(settlement service): bookings=%d transactions=%d failed=%d", result.Bookings, result.Transactions, result.Failed)
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/response"
+)
+
+/*
+StorageGCHandler menangani endpoint admin untuk reconciliation storage.
+*/
+type StorageGCHandler struct {
+	service StorageGCService
+}
+
+/*
+NewStorageGCHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *StorageGCHandler siap digunakan
+*/
+func NewStorageGCHandler(s StorageGCService) *StorageGCHandler {
+	return &StorageGCHandler{service: s}
+}
+
+/*
+RunGC menangani POST /api/v1/admin/storage/gc
+
+Alur kerja:
+1. Validasi method POST
+2. Decode body (opsional, body kosong = dry-run dengan grace period default)
+3. Panggil service untuk reconciliation
+4. Return laporan
+
+Output sukses:
+- 200 OK + laporan per bucket
+Output error:
+- 400 Bad Request (body / grace period tidak valid)
+- 500 Internal Server Error
+*/
+func (h *StorageGCHandler) RunGC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MethodNotAllowed)
+		return
+	}
+
+	var req dto.RunStorageGCByAdminRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	report, err := h.service.RunGC(r.Context(), req)
+	if err != nil {
+		log.Printf("RunGC handler: service error err=%v", err)
+		switch err.Error() {
+		case message.StorageGCInvalidPeriod:
+			response.BadRequest(w, message.StorageGCInvalidPeriod)
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+
+	msg := message.StorageGCCompleted
+	if report.DryRun {
+		msg = message.StorageGCDryRun
+	}
+	response.OK(w, report, msg)
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+/*
+StorageGCRepository adalah kontrak untuk mengambil semua URL file yang masih dirujuk database.
+Digunakan oleh garbage collector untuk menentukan objek mana yang orphan.
+*/
+type StorageGCRepository interface {
+	GetItemPhotoURLs() ([]string, error)
+	GetHandoverPhotoURLs() ([]string, error)
+	GetHosterMediaURLs() ([]string, error)
+	GetIdentityURLs(role string) ([]string, error)
+}
+
+/*
+storageGCRepository adalah implementasi konkret dari StorageGCRepository.
+*/
+type storageGCRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewStorageGCRepository membuat instance repository dengan koneksi database.
+
+Output:
+- StorageGCRepository siap digunakan
+*/
+func NewStorageGCRepository(db *sqlx.DB) StorageGCRepository {
+	return &storageGCRepository{db: db}
+}
+
+/*
+GetItemPhotoURLs mengambil semua URL foto item (bucket hoster).
+
+Alur kerja:
+1. Expand JSONB array item.photos menjadi baris dengan jsonb_array_elements_text
+2. Ambil nilai unik saja
+
+Output sukses:
+- ([]string, nil) → daftar URL foto yang masih dipakai
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *storageGCRepository) GetItemPhotoURLs() ([]string, error) {
+	var urls []string
+	query := `
+		SELECT DISTINCT photo
+		FROM item, jsonb_array_elements_text(item.photos) AS photo
+		WHERE item.photos IS NOT NULL
+		  AND jsonb_typeof(item.photos) = 'array'
+	`
+
+	if err := r.db.Select(&urls, query); err != nil {
+		log.Printf("GetItemPhotoURLs: query error: %v", err)
+		return nil, err
+	}
+	return urls, nil
+}
+
+/*
+GetHandoverPhotoURLs mengambil semua URL foto berita acara serah terima booking (bucket hoster).
+
+Output sukses:
+- ([]string, nil) → daftar URL foto yang masih dipakai
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *storageGCRepository) GetHandoverPhotoURLs() ([]string, error) {
+	var urls []string
+	query := `
+		SELECT DISTINCT photo
+		FROM booking_handover_item, jsonb_array_elements_text(booking_handover_item.photos) AS photo
+		WHERE jsonb_typeof(booking_handover_item.photos) = 'array'
+	`
+
+	if err := r.db.Select(&urls, query); err != nil {
+		log.Printf("GetHandoverPhotoURLs: query error: %v", err)
+		return nil, err
+	}
+	return urls, nil
+}
+
+/*
+GetHosterMediaURLs mengambil semua URL foto profil dan banner toko hoster (bucket hoster).
+
+Output sukses:
+- ([]string, nil) → daftar URL foto yang masih dipakai
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *storageGCRepository) GetHosterMediaURLs() ([]string, error) {
+	var urls []string
+	query := `
+		SELECT profile_photo FROM hoster WHERE COALESCE(profile_photo, '') <> ''
+		UNION
+		SELECT banner_photo FROM hoster WHERE COALESCE(banner_photo, '') <> ''
+	`
+
+	if err := r.db.Select(&urls, query); err != nil {
+		log.Printf("GetHosterMediaURLs: query error: %v", err)
+		return nil, err
+	}
+	return urls, nil
+}
+
+/*
+GetIdentityURLs mengambil semua URL foto dokumen identitas milik satu role (customer → bucket customer, hoster → bucket hoster).
+
+Alur kerja:
+1. Ambil document_url dari seluruh record identity role tersebut (termasuk history upload)
+2. Gabungkan dengan URL foto tambahan (selfie, sisi belakang) dari JSONB extra_photos
+3. Gabungkan dengan URL dokumen usaha (NIB/NPWP) dari JSONB business_documents
+
+Output sukses:
+- ([]string, nil) → daftar URL dokumen yang masih dipakai
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *storageGCRepository) GetIdentityURLs(role string) ([]string, error) {
+	var urls []string
+	query := `
+		SELECT document_url
+		FROM identity
+		WHERE user_role = $1 AND document_url IS NOT NULL AND document_url <> ''
+		UNION
+		SELECT photo->>'url'
+		FROM identity, jsonb_array_elements(identity.extra_photos) AS photo
+		WHERE identity.user_role = $1
+		  AND identity.extra_photos IS NOT NULL
+		  AND jsonb_typeof(identity.extra_photos) = 'array'
+		  AND photo->>'url' IS NOT NULL
+		UNION
+		SELECT doc->>'url'
+		FROM identity, jsonb_array_elements(identity.business_documents) AS doc
+		WHERE identity.user_role = $1
+		  AND jsonb_typeof(identity.business_documents) = 'array'
+		  AND doc->>'url' IS NOT NULL
+	`
+
+	if err := r.db.Select(&urls, query, role); err != nil {
+		log.Printf("GetIdentityURLs: query error role=%s: %v", role, err)
+		return nil, err
+	}
+	return urls, nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"lalan-be/internal/middleware"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+/*
+SetupStorageRoutes mendaftarkan endpoint admin untuk maintenance storage.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/admin/storage
+2. Terapkan middleware JWT + role Admin (protected route)
+3. Daftarkan endpoint:
+  - POST /gc → reconciliation bucket vs database (dry-run default)
+
+Output:
+- Router terkonfigurasi dengan endpoint storage admin
+*/
+func SetupStorageRoutes(router *mux.Router, h *StorageGCHandler) {
+	protected := router.PathPrefix("/api/v1/admin/storage").Subrouter()
+
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Admin)
+
+	protected.HandleFunc("/gc", h.RunGC).Methods("POST", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
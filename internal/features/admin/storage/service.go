@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
+)
+
+/*
+Konstanta default garbage collector.
+*/
+const (
+	DefaultGracePeriodHours = 24
+	MinGracePeriodHours     = 1
+)
+
+/*
+StorageGCService adalah kontrak untuk reconciliation bucket storage dengan database.
+*/
+type StorageGCService interface {
+	RunGC(ctx context.Context, req dto.RunStorageGCByAdminRequest) (*dto.StorageGCReportByAdminResponse, error)
+}
+
+/*
+storageGCService adalah implementasi konkret dari StorageGCService.
+Mengandung dependency ke repository (referensi DB) dan storage (list/delete objek).
+*/
+type storageGCService struct {
+	repo    StorageGCRepository
+	storage utils.Storage
+	config  config.StorageConfig
+}
+
+/*
+NewStorageGCService membuat instance service dengan dependency injection.
+
+Output:
+- StorageGCService siap digunakan
+*/
+func NewStorageGCService(repo StorageGCRepository, storage utils.Storage, config config.StorageConfig) StorageGCService {
+	return &storageGCService{repo: repo, storage: storage, config: config}
+}
+
+/*
+RunGC mencari dan (opsional) menghapus objek di bucket yang tidak lagi dirujuk database.
+
+Alur kerja:
+1. Normalisasi request (dry_run default true, grace period default 24 jam, minimal 1 jam)
+2. Kumpulkan semua path yang dirujuk item.photos (bucket hoster) dan identity.document_url + extra_photos (bucket customer)
+3. List seluruh objek per bucket
+4. Objek yang tidak dirujuk dan lebih tua dari grace period dianggap orphan
+5. Jika bukan dry-run → hapus orphan satu per satu (gagal dicatat, tidak menghentikan proses)
+6. Bangun laporan per bucket
+
+Output sukses:
+- (*dto.StorageGCReportByAdminResponse, nil)
+Output error:
+- (nil, error) → grace period tidak valid / gagal ambil referensi DB / gagal list bucket
+*/
+func (s *storageGCService) RunGC(ctx context.Context, req dto.RunStorageGCByAdminRequest) (*dto.StorageGCReportByAdminResponse, error) {
+	dryRun := true
+	if req.DryRun != nil {
+		dryRun = *req.DryRun
+	}
+
+	graceHours := req.GracePeriodHours
+	if graceHours == 0 {
+		graceHours = DefaultGracePeriodHours
+	}
+	if graceHours < MinGracePeriodHours {
+		return nil, errors.New(message.StorageGCInvalidPeriod)
+	}
+
+	report := &dto.StorageGCReportByAdminResponse{
+		DryRun:           dryRun,
+		GracePeriodHours: graceHours,
+		StartedAt:        time.Now(),
+		Buckets:          []dto.StorageGCBucketByAdminResponse{},
+	}
+	cutoff := report.StartedAt.Add(-time.Duration(graceHours) * time.Hour)
+
+	// Referensi DB wajib lengkap sebelum menyentuh bucket, kalau tidak semua objek terlihat orphan
+	references, buckets, err := s.collectReferences()
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	for _, bucket := range buckets {
+		bucketReport, err := s.reconcileBucket(ctx, bucket, references[bucket], cutoff, dryRun)
+		if err != nil {
+			log.Printf("RunGC(admin service): failed to reconcile bucket %s: %v", bucket, err)
+			return nil, errors.New(message.InternalError)
+		}
+		report.Buckets = append(report.Buckets, *bucketReport)
+	}
+
+	report.FinishedAt = time.Now()
+	log.Printf("RunGC(admin service): finished dry_run=%v grace=%dh buckets=%d", dryRun, graceHours, len(report.Buckets))
+	return report, nil
+}
+
+/*
+collectReferences mengumpulkan path objek yang masih dirujuk database, dikelompokkan per bucket.
+
+Output sukses:
+- (map bucket → set path, daftar bucket berurutan, nil)
+Output error:
+- (nil, nil, error) → query repository gagal
+*/
+func (s *storageGCService) collectReferences() (map[string]map[string]bool, []string, error) {
+	references := map[string]map[string]bool{}
+	var buckets []string
+
+	add := func(bucket string, urls []string) {
+		if _, ok := references[bucket]; !ok {
+			references[bucket] = map[string]bool{}
+			buckets = append(buckets, bucket)
+		}
+		for _, url := range urls {
+			if path := s.referencedPath(url, bucket); path != "" {
+				references[bucket][path] = true
+			}
+		}
+	}
+
+	photoURLs, err := s.repo.GetItemPhotoURLs()
+	if err != nil {
+		log.Printf("collectReferences: failed to get item photos: %v", err)
+		return nil, nil, err
+	}
+	add(s.config.HosterBucket, photoURLs)
+
+	handoverURLs, err := s.repo.GetHandoverPhotoURLs()
+	if err != nil {
+		log.Printf("collectReferences: failed to get handover photos: %v", err)
+		return nil, nil, err
+	}
+	add(s.config.HosterBucket, handoverURLs)
+
+	hosterMediaURLs, err := s.repo.GetHosterMediaURLs()
+	if err != nil {
+		log.Printf("collectReferences: failed to get hoster profile media: %v", err)
+		return nil, nil, err
+	}
+	add(s.config.HosterBucket, hosterMediaURLs)
+
+	hosterIdentityURLs, err := s.repo.GetIdentityURLs(string(domain.IdentityRoleHoster))
+	if err != nil {
+		log.Printf("collectReferences: failed to get hoster identity urls: %v", err)
+		return nil, nil, err
+	}
+	add(s.config.HosterBucket, hosterIdentityURLs)
+
+	identityURLs, err := s.repo.GetIdentityURLs(string(domain.IdentityRoleCustomer))
+	if err != nil {
+		log.Printf("collectReferences: failed to get identity urls: %v", err)
+		return nil, nil, err
+	}
+	add(s.config.CustomerBucket, identityURLs)
+
+	return references, buckets, nil
+}
+
+/*
+reconcileBucket membandingkan isi satu bucket dengan set path yang dirujuk.
+
+Output sukses:
+- (*dto.StorageGCBucketByAdminResponse, nil)
+Output error:
+- (nil, error) → gagal list bucket
+*/
+func (s *storageGCService) reconcileBucket(ctx context.Context, bucket string, referenced map[string]bool, cutoff time.Time, dryRun bool) (*dto.StorageGCBucketByAdminResponse, error) {
+	objects, err := s.storage.List(ctx, "", bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.StorageGCBucketByAdminResponse{
+		Bucket:  bucket,
+		Scanned: len(objects),
+		Orphans: []dto.StorageGCObjectByAdminResponse{},
+	}
+
+	for _, obj := range objects {
+		if referenced[strings.Trim(obj.Path, "/")] {
+			result.Referenced++
+			continue
+		}
+		if obj.LastModified.After(cutoff) {
+			result.InGracePeriod++
+			continue
+		}
+
+		result.Orphaned++
+		orphan := dto.StorageGCObjectByAdminResponse{
+			Path:         obj.Path,
+			Size:         obj.Size,
+			LastModified: obj.LastModified,
+		}
+
+		if !dryRun {
+			if err := s.storage.Delete(ctx, obj.Path, bucket); err != nil {
+				log.Printf("reconcileBucket: failed to delete %s from bucket %s: %v", obj.Path, bucket, err)
+				orphan.Error = err.Error()
+				result.Failed++
+			} else {
+				orphan.Deleted = true
+				result.Deleted++
+				result.FreedBytes += obj.Size
+			}
+		}
+
+		result.Orphans = append(result.Orphans, orphan)
+	}
+
+	return result, nil
+}
+
+/*
+referencedPath mengubah URL publik yang tersimpan di DB menjadi path objek di bucket.
+Fallback ke segmen "/{bucket}/" jika domain storage sudah berubah sejak file di-upload.
+*/
+func (s *storageGCService) referencedPath(url, bucket string) string {
+	path := utils.ExtractPathFromURL(url, s.config.Domain, bucket)
+	if path == url {
+		marker := "/" + bucket + "/"
+		if idx := strings.Index(url, marker); idx >= 0 {
+			path = url[idx+len(marker):]
+		}
+	}
+	return strings.Trim(path, "/")
+}
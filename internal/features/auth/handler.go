@@ -113,6 +113,10 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		"expires_in":    resp.ExpiresIn,
 		"role":          resp.Role,
 	}
+	if resp.StoreID != "" {
+		userData["store_id"] = resp.StoreID
+		userData["member_role"] = resp.MemberRole
+	}
 
 	response.OK(w, userData, message.Success)
 }
@@ -11,6 +11,7 @@ import (
 
 	"lalan-be/internal/domain"
 	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
 )
 
 /*
@@ -24,6 +25,8 @@ type AuthUser struct {
 	PasswordHash  string
 	Role          string
 	EmailVerified bool
+	StoreID       string // Khusus staff toko: ID hoster tempat staff bekerja
+	MemberRole    string // Khusus staff toko: manager / staff
 }
 
 /*
@@ -50,7 +53,8 @@ FindByEmail mencari user berdasarkan email di semua tabel role.
 Fungsi ini akan mencari secara berurutan di tabel:
 1. Admin
 2. Hoster
-3. Customer
+3. Hoster member (staff toko, hanya yang status active)
+4. Customer
 
 Jika ditemukan, akan mengembalikan data user beserta role-nya.
 Jika tidak ditemukan di semua tabel, mengembalikan nil.
@@ -97,7 +101,33 @@ func (r *authRepository) FindByEmail(email string) (*AuthUser, error) {
 		return nil, err
 	}
 
-	// 3. Cek tabel Customer
+	// 3. Cek tabel Hoster member (staff toko)
+	var mid struct {
+		ID           string `db:"id"`
+		HosterID     string `db:"hoster_id"`
+		Email        string `db:"email"`
+		PasswordHash string `db:"password_hash"`
+		Role         string `db:"role"`
+	}
+	queryMember := `
+		SELECT id, hoster_id, email, password_hash, role
+		FROM hoster_member
+		WHERE LOWER(email) = LOWER($1) AND status = 'active' AND password_hash IS NOT NULL
+		LIMIT 1
+	`
+	err = r.db.Get(&mid, queryMember, email)
+	if err == nil {
+		log.Printf("authRepository: found hoster member %s (hoster %s)", mid.ID, mid.HosterID)
+		return &AuthUser{
+			ID: mid.ID, Email: mid.Email, PasswordHash: mid.PasswordHash, Role: "hoster",
+			StoreID: mid.HosterID, MemberRole: mid.Role,
+		}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// 4. Cek tabel Customer
 	var cid struct {
 		ID            string `db:"id"`
 		Email         string `db:"email"`
@@ -119,6 +149,35 @@ func (r *authRepository) FindByEmail(email string) (*AuthUser, error) {
 	return nil, nil
 }
 
+/*
+IsMemberEmail mengecek apakah email sudah dipakai staff toko (hoster_member).
+Dipakai saat registrasi agar satu email tidak punya dua akun login.
+
+Output:
+- (true, nil) jika email sudah dipakai staff
+- (false, error) jika query gagal
+*/
+func (r *authRepository) IsMemberEmail(email string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM hoster_member WHERE LOWER(email) = LOWER($1))`
+	if err := r.db.Get(&exists, query, email); err != nil {
+		log.Printf("IsMemberEmail (auth): %v", err)
+		return false, err
+	}
+	return exists, nil
+}
+
+/*
+TouchMemberLogin mencatat waktu login terakhir staff toko.
+*/
+func (r *authRepository) TouchMemberLogin(memberID string) error {
+	_, err := r.db.Exec(`UPDATE hoster_member SET last_login_at = NOW() WHERE id = $1`, memberID)
+	if err != nil {
+		log.Printf("TouchMemberLogin (auth): %v", err)
+	}
+	return err
+}
+
 /*
 CreateCustomer menyimpan data customer baru ke database.
 
@@ -171,16 +230,35 @@ CreateHoster menyimpan data hoster baru ke database.
 
 Fungsi ini melakukan insert data hoster lengkap. Hoster biasanya tidak
 memerlukan verifikasi email di tahap awal (tergantung business logic).
+Store default (tenant) dibuat dalam transaksi yang sama dari store_name,
+address, dan phone_number hoster. h.Slug berisi slug dasar; jika sudah dipakai
+toko lain, diberi sufiks -2, -3, ... sebelum disimpan.
 
 Output:
 - error jika insert gagal.
 - nil jika berhasil.
 */
 func (r *authRepository) CreateHoster(h *domain.Hoster) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("CreateHoster (auth): error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var taken []string
+	err = tx.Select(&taken, `SELECT slug FROM hoster WHERE slug = $1 OR slug ~ ('^' || $1 || '-[0-9]+$')`, h.Slug)
+	if err != nil {
+		log.Printf("CreateHoster (auth): failed to check slug %s: %v", h.Slug, err)
+		return err
+	}
+	h.Slug = utils.NextSlug(h.Slug, taken)
+
 	query := `
 		INSERT INTO hoster (
 			full_name,
 			store_name,
+			slug,
 			address,
 			phone_number,
 			email,
@@ -192,23 +270,42 @@ func (r *authRepository) CreateHoster(h *domain.Hoster) error {
 			website,
 			created_at,
 			updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id, created_at, updated_at
 	`
-	err := r.db.QueryRow(query,
-		h.FullName, h.StoreName, h.Address, h.PhoneNumber, h.Email,
+	err = tx.QueryRow(query,
+		h.FullName, h.StoreName, h.Slug, h.Address, h.PhoneNumber, h.Email,
 		h.PasswordHash, h.ProfilePhoto, h.Description, h.Tiktok,
 		h.Instagram, h.Website, h.CreatedAt, h.UpdatedAt,
 	).Scan(&h.ID, &h.CreatedAt, &h.UpdatedAt)
 
 	if err != nil {
 		log.Printf("CreateHoster (auth): %v", err)
+		// Slug bentrok dengan registrasi lain di saat yang sama → bukan masalah email
+		if strings.Contains(err.Error(), "idx_hoster_slug") {
+			return err
+		}
 		// Check duplicate email constraint
 		if strings.Contains(err.Error(), "duplicate") {
 			return errors.New("email already exists")
 		}
 		return err
 	}
+
+	// Store default: setiap hoster minimal punya satu store untuk menampung item
+	_, err = tx.Exec(`
+		INSERT INTO tenant (name, hoster_id, address, phone_number, is_default)
+		VALUES ($1, $2, $3, NULLIF($4, ''), true)
+	`, h.StoreName, h.ID, h.Address, h.PhoneNumber)
+	if err != nil {
+		log.Printf("CreateHoster (auth): failed to create default store: %v", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("CreateHoster (auth): error committing transaction: %v", err)
+		return err
+	}
 	return nil
 }
 
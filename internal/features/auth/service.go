@@ -15,6 +15,7 @@ import (
 	"lalan-be/internal/dto"
 	"lalan-be/internal/message"
 	"lalan-be/internal/middleware"
+	"lalan-be/internal/utils"
 )
 
 // AuthResponse sekarang menggunakan DTO dari package dto
@@ -76,6 +77,51 @@ func (s *authService) generateToken(userID, role string) (*dto.AuthResponse, err
 	}, nil
 }
 
+/*
+generateMemberToken membuat JWT untuk staff toko.
+Subject = ID staff, store_id = ID hoster sehingga middleware.GetUserID mengembalikan ID toko.
+
+Output:
+- Pointer ke AuthResponse berisi token, store_id, dan member_role.
+- error jika signing token gagal.
+*/
+func (s *authService) generateMemberToken(user *AuthUser) (*dto.AuthResponse, error) {
+	accessToken, err := middleware.GenerateMemberToken(user.ID, user.StoreID, user.MemberRole)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.AuthResponse{
+		ID:           user.ID,
+		AccessToken:  accessToken,
+		RefreshToken: uuid.New().String(),
+		TokenType:    "Bearer",
+		ExpiresIn:    3600,
+		Role:         user.Role,
+		StoreID:      user.StoreID,
+		MemberRole:   user.MemberRole,
+	}, nil
+}
+
+/*
+ensureEmailAvailable menolak email yang sudah dipakai staff toko.
+
+Output:
+- error EmailAlreadyExists jika dipakai staff
+- error InternalError jika query gagal
+- nil jika email bebas
+*/
+func (s *authService) ensureEmailAvailable(email string) error {
+	used, err := s.repo.IsMemberEmail(email)
+	if err != nil {
+		return errors.New(message.InternalError)
+	}
+	if used {
+		return errors.New(message.EmailAlreadyExists)
+	}
+	return nil
+}
+
 // CreateCustomerResponse sekarang menggunakan DTO dari package dto
 // Lihat: internal/dto/auth_dto.go
 
@@ -100,18 +146,24 @@ RegisterHoster mendaftarkan hoster baru.
 
 Langkah-langkah:
 1. Hash password.
-2. Simpan data hoster ke database.
+2. Bentuk slug dasar storefront dari store_name (sufiks unik ditentukan repository).
+3. Simpan data hoster ke database.
 
 Output:
 - error jika terjadi kesalahan sistem.
 - nil jika berhasil.
 */
 func (s *authService) RegisterHoster(h *domain.Hoster) error {
+	if err := s.ensureEmailAvailable(h.Email); err != nil {
+		return err
+	}
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(h.PasswordHash), bcrypt.DefaultCost)
 	if err != nil {
 		return errors.New(message.InternalError)
 	}
 	h.PasswordHash = string(hash)
+	h.Slug = utils.Slugify(h.StoreName)
 	h.CreatedAt = time.Now()
 	h.UpdatedAt = time.Now()
 
@@ -136,6 +188,10 @@ Output:
 - nil jika berhasil.
 */
 func (s *authService) RegisterAdmin(a *domain.Admin) error {
+	if err := s.ensureEmailAvailable(a.Email); err != nil {
+		return err
+	}
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(a.PasswordHash), bcrypt.DefaultCost)
 	if err != nil {
 		return errors.New(message.InternalError)
@@ -166,6 +222,10 @@ Output:
 - nil jika berhasil.
 */
 func (s *authService) RegisterCustomer(c *domain.Customer) error {
+	if err := s.ensureEmailAvailable(c.Email); err != nil {
+		return err
+	}
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(c.PasswordHash), bcrypt.DefaultCost)
 	if err != nil {
 		return errors.New(message.InternalError)
@@ -272,7 +332,11 @@ func (s *authService) Login(email, password string) (*dto.AuthResponse, error) {
 		return nil, errors.New(message.LoginFailed)
 	}
 
-	// 4. Generate token
+	// 4. Generate token (staff toko → token berisi ID toko & role staff)
+	if user.StoreID != "" {
+		_ = s.repo.TouchMemberLogin(user.ID)
+		return s.generateMemberToken(user)
+	}
 	return s.generateToken(user.ID, user.Role)
 }
 
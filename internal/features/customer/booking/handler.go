@@ -44,12 +44,12 @@ Langkah-langkah:
 1. Validasi method & decode JSON.
 2. Validasi input (misal item tidak kosong, tanggal valid).
 3. Panggil service CreateBooking.
-4. Jika error spesifik "silakan upload ktp terlebih dahulu", return 400 Bad Request.
+4. Jika error dokumen identitas (belum upload / rejected / kadaluarsa sebelum sewa selesai), return 400 Bad Request.
 5. Jika error lain, return 500 Internal Server Error.
 
 Output:
 - 200 OK: Booking berhasil dibuat, return detail booking.
-- 400 Bad Request: Validasi gagal (misal KTP belum upload).
+- 400 Bad Request: Validasi gagal (misal dokumen identitas belum upload).
 - 500 Internal Server Error: Kesalahan sistem.
 */
 func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
@@ -83,12 +83,12 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		errMsg := err.Error()
 
 		// Cek exact match untuk error constants
-		if errMsg == message.KTPRequired {
-			response.BadRequest(w, message.KTPRequired)
+		if errMsg == message.IdentityRequired {
+			response.BadRequest(w, message.IdentityRequired)
 			return
 		}
-		if errMsg == message.KTPRejectedUploadNew {
-			response.BadRequest(w, message.KTPRejectedUploadNew)
+		if errMsg == message.IdentityRejectedUploadNew || errMsg == message.DocumentExpiresBeforeRental {
+			response.BadRequest(w, errMsg)
 			return
 		}
 
@@ -145,6 +145,36 @@ func (h *BookingHandler) GetListBookings(w http.ResponseWriter, r *http.Request)
 	response.OK(w, bookings, message.Success)
 }
 
+/*
+GetOverdueBookings menangani endpoint GET /api/v1/customer/booking/overdue
+Mengembalikan booking yang belum dikembalikan melewati batas pengembalian beserta denda yang sudah berjalan.
+
+Output sukses:
+- Status: 200 OK
+- Body:   array booking terlambat (paling lama terlambat lebih dulu)
+Output error:
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *BookingHandler) GetOverdueBookings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	bookings, err := h.service.GetOverdueBookings(userID)
+	if err != nil {
+		log.Printf("GetOverdueBookings: service error: %v", err)
+		if err.Error() == message.UserIDRequired {
+			response.Unauthorized(w, message.Unauthorized)
+		} else {
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+	response.OK(w, bookings, message.OverdueBookingsRetrieved)
+}
+
 /*
 GetDetailBooking menangani endpoint GET /bookings/{id}
 Mengembalikan detail lengkap satu booking berdasarkan ID.
@@ -203,3 +233,310 @@ func (h *BookingHandler) GetDetailBooking(w http.ResponseWriter, r *http.Request
 
 	response.OK(w, bookingDetail, message.Success)
 }
+
+/*
+GetHandovers menangani GET /api/v1/customer/booking/{id}/handover
+Menampilkan berita acara serah terima (pickup & return) beserta perbandingan kondisi barang.
+
+Output sukses:
+- 200 OK + { booking_id, pickup, return, comparison }
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *BookingHandler) GetHandovers(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	result, err := h.service.GetHandovers(userID, strings.TrimSpace(mux.Vars(r)["id"]))
+	if err != nil {
+		log.Printf("GetHandovers: service error: %v", err)
+		writeHandoverError(w, err)
+		return
+	}
+	response.OK(w, result, message.HandoverRetrieved)
+}
+
+/*
+ConfirmHandover menangani POST /api/v1/customer/booking/{id}/handover/{kind}/confirm
+Body opsional: { "note": "..." } untuk mencatat keberatan atas kondisi yang dicatat hoster.
+
+Output sukses:
+- 200 OK + data handover terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict (sudah dikonfirmasi) / 500 Internal Server Error
+*/
+func (h *BookingHandler) ConfirmHandover(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.ConfirmHandoverByCustomerRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Printf("ConfirmHandover: failed to decode JSON: %v", err)
+			response.BadRequest(w, message.BadRequest)
+			return
+		}
+	}
+
+	vars := mux.Vars(r)
+	result, err := h.service.ConfirmHandover(userID, strings.TrimSpace(vars["id"]), vars["kind"], req)
+	if err != nil {
+		log.Printf("ConfirmHandover: service error: %v", err)
+		writeHandoverError(w, err)
+		return
+	}
+	response.OK(w, result, message.HandoverConfirmed)
+}
+
+/*
+GetPickupQR menangani GET /api/v1/customer/booking/{id}/pickup-qr?format=png|svg (default png)
+Gambar QR kode pickup sekali pakai yang dipindai hoster saat barang diambil.
+
+Output sukses:
+- 200 OK + image/png atau image/svg+xml
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict (belum dibayar / sudah dipakai) / 500 Internal Server Error
+*/
+func (h *BookingHandler) GetPickupQR(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = "png"
+	}
+
+	body, err := h.service.RenderPickupQR(userID, strings.TrimSpace(mux.Vars(r)["id"]), format)
+	if err != nil {
+		log.Printf("GetPickupQR: service error: %v", err)
+		switch err.Error() {
+		case message.Unauthorized:
+			response.Error(w, http.StatusUnauthorized, message.Unauthorized)
+		case fmt.Sprintf(message.NotFound, "booking"):
+			response.NotFound(w, err.Error())
+		case message.PickupQRInvalidFormat:
+			response.BadRequest(w, err.Error())
+		case message.PickupCodeUnavailable:
+			response.Error(w, http.StatusConflict, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+
+	contentType := "image/png"
+	if format == "svg" {
+		contentType = "image/svg+xml"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store") // Kode sekali pakai, jangan disimpan proxy / browser
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("GetPickupQR: failed to write body: %v", err)
+	}
+}
+
+/*
+writeHandoverError memetakan error service handover ke HTTP response.
+*/
+func writeHandoverError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Error(w, http.StatusUnauthorized, message.Unauthorized)
+	case fmt.Sprintf(message.NotFound, "booking"), message.HandoverNotFound:
+		response.NotFound(w, err.Error())
+	case message.HandoverAlreadyConfirmed:
+		response.Error(w, http.StatusConflict, err.Error())
+	case message.HandoverInvalidKind, fmt.Sprintf(message.TooLong, "note"):
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
+
+/*
+RequestAmendment menangani POST /api/v1/customer/booking/{id}/amendment
+Mengajukan perpanjangan tanggal dan/atau perubahan quantity, menunggu persetujuan hoster.
+
+Output sukses:
+- 200 OK + permintaan perubahan (status pending, old/new total)
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict (status, stock, masih ada pending) / 500 Internal Server Error
+*/
+func (h *BookingHandler) RequestAmendment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.CreateBookingAmendmentByCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("RequestAmendment: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	result, err := h.service.RequestAmendment(userID, strings.TrimSpace(mux.Vars(r)["id"]), req)
+	if err != nil {
+		log.Printf("RequestAmendment: service error: %v", err)
+		writeAmendmentError(w, err)
+		return
+	}
+	response.OK(w, result, message.AmendmentRequested)
+}
+
+/*
+GetAmendments menangani GET /api/v1/customer/booking/{id}/amendment
+
+Output sukses:
+- 200 OK + riwayat permintaan perubahan (terbaru dulu)
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *BookingHandler) GetAmendments(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	result, err := h.service.GetAmendments(userID, strings.TrimSpace(mux.Vars(r)["id"]))
+	if err != nil {
+		log.Printf("GetAmendments: service error: %v", err)
+		writeAmendmentError(w, err)
+		return
+	}
+	response.OK(w, result, message.AmendmentRetrieved)
+}
+
+/*
+CancelAmendment menangani POST /api/v1/customer/booking/{id}/amendment/{amendmentId}/cancel
+
+Output sukses:
+- 200 OK + permintaan perubahan (status cancelled)
+Output error:
+- 401 Unauthorized / 404 Not Found / 409 Conflict (sudah diputuskan) / 500 Internal Server Error
+*/
+func (h *BookingHandler) CancelAmendment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	result, err := h.service.CancelAmendment(userID, strings.TrimSpace(vars["id"]), strings.TrimSpace(vars["amendmentId"]))
+	if err != nil {
+		log.Printf("CancelAmendment: service error: %v", err)
+		writeAmendmentError(w, err)
+		return
+	}
+	response.OK(w, result, message.AmendmentCancelled)
+}
+
+/*
+GetStoreSlots menangani GET /api/v1/customer/store/{id}/slots?date=YYYY-MM-DD
+
+Dipakai sebelum membuat booking untuk memilih pickup_time (di start_date) dan return_time (di end_date).
+
+Output sukses:
+- 200 OK + slot beserta sisa kapasitas (open = false jika store libur)
+Output error:
+- 400 Bad Request (tanggal tidak valid) / 404 Not Found / 500 Internal Server Error
+*/
+func (h *BookingHandler) GetStoreSlots(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetStoreSlots(strings.TrimSpace(mux.Vars(r)["id"]), r.URL.Query().Get("date"))
+	if err != nil {
+		log.Printf("GetStoreSlots: service error: %v", err)
+		switch err.Error() {
+		case message.StoreNotFound:
+			response.NotFound(w, message.StoreNotFound)
+		case message.ScheduleInvalidDate:
+			response.BadRequest(w, message.ScheduleInvalidDate)
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+	response.OK(w, result, message.StoreSlotsRetrieved)
+}
+
+/*
+CreateReview menangani POST /api/v1/customer/booking/{id}/review
+Menyimpan ulasan customer untuk booking yang sudah selesai.
+
+Output sukses:
+- 201 Created + ulasan tersimpan
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict (belum selesai, sudah diulas) / 500 Internal Server Error
+*/
+func (h *BookingHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.CreateBookingReviewByCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("CreateReview: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	result, err := h.service.CreateReview(userID, strings.TrimSpace(mux.Vars(r)["id"]), req)
+	if err != nil {
+		log.Printf("CreateReview: service error: %v", err)
+		switch err.Error() {
+		case message.Unauthorized:
+			response.Error(w, http.StatusUnauthorized, message.Unauthorized)
+		case fmt.Sprintf(message.NotFound, "booking"):
+			response.NotFound(w, err.Error())
+		case message.ReviewNotAllowed, message.ReviewAlreadyExists:
+			response.Error(w, http.StatusConflict, err.Error())
+		case message.ReviewInvalidRating, fmt.Sprintf(message.TooLong, "comment"):
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+	response.Success(w, http.StatusCreated, result, message.ReviewCreated)
+}
+
+/*
+writeAmendmentError memetakan error service perubahan booking ke HTTP response.
+*/
+func writeAmendmentError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Error(w, http.StatusUnauthorized, message.Unauthorized)
+	case fmt.Sprintf(message.NotFound, "booking"), message.AmendmentNotFound:
+		response.NotFound(w, err.Error())
+	case message.AmendmentInvalidStatus,
+		message.AmendmentQuantityLocked,
+		message.AmendmentPending,
+		message.AmendmentUnavailable,
+		message.AmendmentStale,
+		message.AmendmentAlreadyDecided:
+		response.Error(w, http.StatusConflict, err.Error())
+	case message.AmendmentInvalidEndDate,
+		message.AmendmentInvalidItems,
+		message.AmendmentBundleQuantity,
+		message.AmendmentNoChanges,
+		fmt.Sprintf(message.TooLong, "note"):
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -2,7 +2,10 @@ package booking
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"lalan-be/internal/domain"
@@ -18,11 +21,21 @@ terkait fitur booking. Implementasinya hanya boleh berisi query SQL dan
 transaksi — tidak boleh ada logika bisnis atau validasi domain.
 */
 type BookingRepository interface {
-	CreateBooking(booking *domain.Booking, items []domain.BookingItem, customer domain.BookingCustomer) (*dto.BookingDetailByCustomerResponse, error)
+	CreateBooking(booking *domain.Booking, items []domain.BookingItem, customer domain.BookingCustomer, tncVersionIDs []string) (*dto.BookingDetailByCustomerResponse, error)
 	GetListBookings(userID string) ([]dto.BookingListByCustomerResponse, error)
 	GetBookingDetail(bookingID string) (*dto.BookingDetailByCustomerResponse, error)
 	GetIdentityByUserID(userID string) (*domain.Identity, error)
-	GetHosterIDByItemID(itemID string) (string, error)
+	GetStoreByItemID(itemID string) (*domain.Tenant, error)
+	GetPickupCodeNonce(bookingID string) (string, error)
+	GetOverdueBookings(userID string) ([]dto.OverdueBookingResponse, error)
+	IsCustomerBlocked(hosterID, userID string) (bool, error)
+	GetCurrentTnCVersions(hosterID, tenantID string, itemIDs []string) ([]dto.TnCVersionResponse, error)
+	GetStoreDaySchedule(tenantID string, date time.Time) (*domain.StoreDaySchedule, error)
+	GetSlotUsage(tenantID string, date time.Time) (map[string]int, error)
+	GetItemVariantDimensions(itemID string) ([]string, error)
+	GetBookingVariant(itemID, variantID string) (*domain.ItemVariant, error)
+	GetBookingBundle(bundleID string) (*domain.Bundle, error)
+	CreateReview(review *domain.BookingReview) error
 }
 
 /*
@@ -51,17 +64,23 @@ Menggunakan database transaction untuk menjamin atomicity (all-or-nothing).
 Alur kerja:
 1. Validasi KTP user (hanya cek keberadaan, bukan business rule)
 2. Mulai transaction
-3. Insert header booking → booking_item → booking_customer
-4. Commit transaction
-5. Query ulang detail booking untuk dikembalikan ke service
+3. Jika booking memakai slot pickup / pengembalian, lock store lalu cek ulang kapasitas slot
+3a. Jika ada item bervarian, lock varian lalu cek stock varian di rentang tanggal sewa
+3b. Jika ada paket sewa, lock item komponen lalu cek stock komponen di rentang tanggal sewa
+4. Insert header booking → booking_item → booking_customer → booking_tnc_acceptance
+5. Commit transaction
+6. Query ulang detail booking untuk dikembalikan ke service
 
 Output sukses:
 - *dto.BookingDetailByCustomerResponse (data lengkap booking yang baru dibuat)
 Output error:
 - error validasi KTP → "silakan upload ktp terlebih dahulu"
+- errors.New("slot_full") → slot terisi penuh oleh booking lain sejak dicek service
+- errors.New("variant_unavailable") → stock varian tidak cukup di rentang tanggal sewa
+- errors.New("bundle_unavailable") → stock komponen paket tidak cukup di rentang tanggal sewa
 - error DB → langsung diteruskan ke service (akan jadi 500 atau 400 sesuai konteks)
 */
-func (r *bookingRepository) CreateBooking(booking *domain.Booking, items []domain.BookingItem, customer domain.BookingCustomer) (*dto.BookingDetailByCustomerResponse, error) {
+func (r *bookingRepository) CreateBooking(booking *domain.Booking, items []domain.BookingItem, customer domain.BookingCustomer, tncVersionIDs []string) (*dto.BookingDetailByCustomerResponse, error) {
 	// Repository tidak perlu validasi business logic KTP
 	// Validasi sudah dilakukan di service layer
 
@@ -73,37 +92,57 @@ func (r *bookingRepository) CreateBooking(booking *domain.Booking, items []domai
 	}
 	defer tx.Rollback()
 
-	// 3. Insert Booking Header
+	// 3. Cek ulang kapasitas slot di bawah lock store (mencegah dua booking mengisi sisa slot terakhir)
+	if booking.TenantID != nil && (booking.PickupTime != nil || booking.ReturnTime != nil) {
+		if err := checkSlotCapacity(tx, booking); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3a. Cek stock varian di bawah lock varian (mencegah overbooking varian yang sama)
+	if err := checkVariantStock(tx, booking, items); err != nil {
+		return nil, err
+	}
+
+	// 3b. Cek stock item (sewa satuan & komponen paket) di bawah lock item (semua ter-reservasi atau tidak sama sekali)
+	if err := checkItemStock(tx, booking, items); err != nil {
+		return nil, err
+	}
+
+	// 4. Insert Booking Header
 	queryBooking := `
 		INSERT INTO booking (
-			id, hoster_id, locked_until, start_date, end_date, total_days,
+			id, hoster_id, tenant_id, locked_until, start_date, end_date, total_days,
 			delivery_type, rental, deposit, discount, total, outstanding,
-			user_id, identity_id, status
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			user_id, identity_id, status, pickup_time, return_time
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`
 	_, err = tx.Exec(queryBooking,
-		booking.ID, booking.HosterID, booking.LockedUntil,
+		booking.ID, booking.HosterID, booking.TenantID, booking.LockedUntil,
 		booking.StartDate, booking.EndDate, booking.TotalDays,
 		booking.DeliveryType, booking.Rental, booking.Deposit,
 		booking.Discount, booking.Total, booking.Outstanding,
 		booking.UserID, booking.IdentityID, booking.Status,
+		booking.PickupTime, booking.ReturnTime,
 	)
 	if err != nil {
 		log.Printf("CreateBooking: error inserting booking header: %v", err)
 		return nil, err
 	}
 
-	// 4. Insert Booking Items
+	// 5. Insert Booking Items
 	queryItem := `
 		INSERT INTO booking_item (
 			id, booking_id, item_id, name, quantity,
-			price_per_day, deposit_per_unit, subtotal_rental, subtotal_deposit
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			price_per_day, deposit_per_unit, subtotal_rental, subtotal_deposit,
+			variant_id, variant_name, bundle_id, bundle_name, bundle_quantity
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 	for i, item := range items {
 		_, err = tx.Exec(queryItem,
 			item.ID, item.BookingID, item.ItemID, item.Name, item.Quantity,
 			item.PricePerDay, item.DepositPerUnit, item.SubtotalRental, item.SubtotalDeposit,
+			item.VariantID, item.VariantName, item.BundleID, item.BundleName, item.BundleQuantity,
 		)
 		if err != nil {
 			log.Printf("CreateBooking: error inserting booking_item index %d: %v", i, err)
@@ -111,7 +150,7 @@ func (r *bookingRepository) CreateBooking(booking *domain.Booking, items []domai
 		}
 	}
 
-	// 5. Insert Booking Customer
+	// 6. Insert Booking Customer
 	queryCustomer := `
 		INSERT INTO booking_customer (
 			id, booking_id, name, phone, email, address, notes
@@ -126,14 +165,26 @@ func (r *bookingRepository) CreateBooking(booking *domain.Booking, items []domai
 		return nil, err
 	}
 
-	// 6. Commit Transaction
+	// 6a. Catat versi T&C yang disetujui customer
+	if len(tncVersionIDs) > 0 {
+		_, err = tx.Exec(`
+			INSERT INTO booking_tnc_acceptance (booking_id, tnc_version_id)
+			SELECT $1, UNNEST($2::uuid[])
+		`, booking.ID, pq.Array(tncVersionIDs))
+		if err != nil {
+			log.Printf("CreateBooking: error inserting booking_tnc_acceptance: %v", err)
+			return nil, err
+		}
+	}
+
+	// 7. Commit Transaction
 	if err = tx.Commit(); err != nil {
 		log.Printf("CreateBooking: error committing transaction: %v", err)
 		return nil, err
 	}
 	log.Printf("CreateBooking: booking %s created successfully", booking.ID)
 
-	// 7. Return Detail Booking
+	// 8. Return Detail Booking
 	detail, err := r.GetBookingDetail(booking.ID)
 	if err != nil {
 		log.Printf("CreateBooking: error retrieving created booking detail: %v", err)
@@ -143,10 +194,10 @@ func (r *bookingRepository) CreateBooking(booking *domain.Booking, items []domai
 }
 
 /*
-GetIdentityByUserID mengambil data KTP user untuk validasi booking.
+GetIdentityByUserID mengambil data dokumen identitas user untuk validasi booking.
 
 Best Practice Logic:
-1. Ambil KTP terbaru (ORDER BY created_at DESC)
+1. Ambil dokumen terbaru (ORDER BY created_at DESC)
 2. Jika terbaru = approved → ✅ pakai ini
 3. Jika terbaru = pending → ✅ pakai ini
 4. Jika terbaru = rejected → ❌ return nil (user harus upload baru)
@@ -164,16 +215,16 @@ Output error:
 */
 func (r *bookingRepository) GetIdentityByUserID(userID string) (*domain.Identity, error) {
 	var identity domain.Identity
-	// Ambil KTP TERBARU saja (by created_at DESC)
+	// Ambil dokumen TERBARU saja (by created_at DESC)
 	// Jika terbaru = rejected → return nil (user harus upload baru)
 	// Jika terbaru = pending/approved → return identity
 	query := `
 		SELECT
-			id, user_id, ktp_url, verified, status,
-			COALESCE(reason, '') AS reason,
+			id, user_id, document_type, document_url, document_expiry,
+			verified, status, COALESCE(reason, '') AS reason,
 			verified_at, created_at, updated_at
 		FROM identity
-		WHERE user_id = $1
+		WHERE user_id = $1 AND user_role = 'customer'
 		ORDER BY created_at DESC
 		LIMIT 1
 	`
@@ -226,23 +277,524 @@ func (r *bookingRepository) GetListBookings(userID string) ([]dto.BookingListByC
 }
 
 /*
-GetHosterIDByItemID mencari user_id (hoster) yang memiliki item tertentu.
-Digunakan saat pembuatan booking untuk mengisi field hoster_id.
+GetOverdueBookings mengambil booking on_rent milik user yang melewati batas pengembalian
+(end_date + return_time, atau akhir hari end_date jika tanpa slot pengembalian).
+Diurutkan dari yang paling lama terlambat.
+
+Output sukses:
+- []dto.OverdueBookingResponse (bisa kosong)
+Output error:
+- error hanya jika query gagal
+*/
+func (r *bookingRepository) GetOverdueBookings(userID string) ([]dto.OverdueBookingResponse, error) {
+	query := `
+		SELECT
+			b.id AS booking_id,
+			COALESCE(t.name, h.store_name, '') AS store_name,
+			COALESCE((
+				SELECT string_agg(bi.name, ', ' ORDER BY bi.name)
+				FROM booking_item bi WHERE bi.booking_id = b.id
+			), '') AS item_names,
+			b.start_date, b.end_date,
+			CEIL(EXTRACT(EPOCH FROM (NOW() - (b.end_date + COALESCE(b.return_time, TIME '24:00')))) / 86400)::int AS days_late,
+			b.overdue_at, b.late_fee, b.outstanding
+		FROM booking b
+		LEFT JOIN tenant t ON t.id = b.tenant_id
+		LEFT JOIN hoster h ON h.id = b.hoster_id
+		WHERE b.user_id = $1
+		  AND b.status = 'on_rent'
+		  AND (b.end_date + COALESCE(b.return_time, TIME '24:00')) < NOW()
+		ORDER BY b.end_date ASC
+	`
+	var bookings []dto.OverdueBookingResponse
+	if err := r.db.Select(&bookings, query, userID); err != nil {
+		log.Printf("GetOverdueBookings: database error for user %s: %v", userID, err)
+		return nil, err
+	}
+	return bookings, nil
+}
+
+/*
+GetStoreByItemID mencari store (tenant) dan hoster yang memiliki item tertentu.
+Digunakan saat pembuatan booking untuk mengisi field hoster_id dan tenant_id,
+serta mengecek apakah store melayani delivery.
 
 Output sukses:
-- string hoster_id
+- *domain.Tenant (id, hoster_id, name, delivery_enabled, delivery_fee, delivery_radius_km)
 Output error:
 - error jika item tidak ditemukan atau query gagal
 */
-func (r *bookingRepository) GetHosterIDByItemID(itemID string) (string, error) {
-	var hosterID string
-	query := `SELECT hoster_id FROM item WHERE id = $1`
-	err := r.db.Get(&hosterID, query, itemID)
+func (r *bookingRepository) GetStoreByItemID(itemID string) (*domain.Tenant, error) {
+	var store domain.Tenant
+	query := `
+		SELECT t.id, t.hoster_id, t.name, t.delivery_enabled, t.delivery_fee, t.delivery_radius_km
+		FROM item i
+		JOIN tenant t ON t.id = i.tenant_id
+		WHERE i.id = $1
+	`
+	err := r.db.Get(&store, query, itemID)
+	if err != nil {
+		log.Printf("GetStoreByItemID: error querying item %s: %v", itemID, err)
+		return nil, err
+	}
+	return &store, nil
+}
+
+/*
+IsCustomerBlocked mengecek customer diblokir hoster.
+Cocok jika diblokir berdasarkan customer_id, atau blokir dokumen hoster sama dengan
+nomor dokumen identitas yang pernah di-upload customer (akun lain dengan NIK yang sama).
+
+Output:
+- (true, nil) jika diblokir
+- (false, error) jika query gagal
+*/
+func (r *bookingRepository) IsCustomerBlocked(hosterID, userID string) (bool, error) {
+	var blocked bool
+	err := r.db.Get(&blocked, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM hoster_customer_block hb
+			WHERE hb.hoster_id = $1
+			  AND (
+			      hb.customer_id = $2
+			      OR (hb.document_number_hash IS NOT NULL AND EXISTS (
+			          SELECT 1 FROM identity i
+			          WHERE i.user_id = $2 AND i.user_role = 'customer'
+			            AND i.document_type = hb.document_type
+			            AND i.document_number_hash = hb.document_number_hash
+			      ))
+			  )
+		)
+	`, hosterID, userID)
+	if err != nil {
+		log.Printf("IsCustomerBlocked: query error hoster=%s user=%s err=%v", hosterID, userID, err)
+	}
+	return blocked, err
+}
+
+/*
+GetCurrentTnCVersions mengambil versi T&C terbaru yang berlaku untuk booking:
+T&C khusus store (fallback ke T&C umum hoster) ditambah T&C khusus tiap item.
+
+Output sukses:
+- ([]dto.TnCVersionResponse, nil) → slice kosong jika hoster belum membuat T&C
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *bookingRepository) GetCurrentTnCVersions(hosterID, tenantID string, itemIDs []string) ([]dto.TnCVersionResponse, error) {
+	var rows []struct {
+		dto.TnCVersionResponse
+		Description pq.StringArray `db:"description"`
+	}
+	err := r.db.Select(&rows, `
+		SELECT v.id, v.version, v.tenant_id, v.item_id, v.created_at,
+		       ARRAY(SELECT jsonb_array_elements_text(v.description)) AS description
+		FROM tnc t
+		INNER JOIN tnc_version v ON v.id = t.current_version_id
+		WHERE t.hoster_id = $1
+		  AND (
+		      t.item_id = ANY($3::uuid[])
+		      OR (t.item_id IS NULL AND t.id = (
+		          SELECT s.id FROM tnc s
+		          WHERE s.hoster_id = $1 AND s.item_id IS NULL
+		            AND (s.tenant_id = $2 OR s.tenant_id IS NULL)
+		          ORDER BY s.tenant_id NULLS LAST
+		          LIMIT 1
+		      ))
+		  )
+		ORDER BY t.item_id NULLS FIRST, v.created_at
+	`, hosterID, tenantID, pq.Array(itemIDs))
+	if err != nil {
+		log.Printf("GetCurrentTnCVersions: query error hoster=%s store=%s err=%v", hosterID, tenantID, err)
+		return nil, err
+	}
+
+	versions := make([]dto.TnCVersionResponse, len(rows))
+	for i, row := range rows {
+		versions[i] = row.TnCVersionResponse
+		versions[i].Description = row.Description
+	}
+	return versions, nil
+}
+
+/*
+GetStoreDaySchedule mengambil jadwal store pada satu tanggal: jam buka hari tersebut (weekday Postgres DOW = Go time.Weekday),
+hari libur, dan pengaturan slot.
+
+Output sukses:
+- *domain.StoreDaySchedule (configured = false jika store belum mengatur jam buka sama sekali)
+Output error:
+- sql.ErrNoRows → store tidak ditemukan
+- error → query gagal
+*/
+func (r *bookingRepository) GetStoreDaySchedule(tenantID string, date time.Time) (*domain.StoreDaySchedule, error) {
+	var schedule domain.StoreDaySchedule
+	query := `
+		SELECT
+			EXISTS (SELECT 1 FROM store_operating_hours oh WHERE oh.tenant_id = t.id) AS configured,
+			(sc.id IS NOT NULL OR (h.weekday IS NULL AND EXISTS (
+				SELECT 1 FROM store_operating_hours oh WHERE oh.tenant_id = t.id
+			))) AS closed,
+			sc.reason AS closed_reason,
+			COALESCE(to_char(h.open_time, 'HH24:MI'), '') AS open_time,
+			COALESCE(to_char(h.close_time, 'HH24:MI'), '') AS close_time,
+			t.slot_minutes,
+			t.slot_capacity
+		FROM tenant t
+		LEFT JOIN store_operating_hours h
+			ON h.tenant_id = t.id AND h.weekday = EXTRACT(DOW FROM $2::date)
+		LEFT JOIN store_closure sc
+			ON sc.tenant_id = t.id AND sc.closure_date = $2::date
+		WHERE t.id = $1
+	`
+	if err := r.db.Get(&schedule, query, tenantID, date); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("GetStoreDaySchedule: db error store=%s err=%v", tenantID, err)
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+/*
+GetSlotUsage menghitung serah terima (pickup + pengembalian) per slot store pada satu tanggal.
+
+Output sukses:
+- (map[HH:MM]jumlah, nil) → slot tanpa booking tidak ada di map
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *bookingRepository) GetSlotUsage(tenantID string, date time.Time) (map[string]int, error) {
+	usage, err := slotUsage(r.db, tenantID, date)
+	if err != nil {
+		log.Printf("GetSlotUsage: db error store=%s err=%v", tenantID, err)
+		return nil, err
+	}
+	return usage, nil
+}
+
+/*
+slotUsage menghitung pemakaian slot store pada satu tanggal (dipakai di luar dan di dalam transaksi).
+Booking dihitung jika masih berjalan: pending yang masih di-lock, on_progress, on_rent, atau completed.
+*/
+func slotUsage(q sqlx.Queryer, tenantID string, date time.Time) (map[string]int, error) {
+	var rows []struct {
+		Slot  string `db:"slot"`
+		Count int    `db:"count"`
+	}
+	query := `
+		SELECT slot, COUNT(*) AS count
+		FROM (
+			SELECT to_char(b.pickup_time, 'HH24:MI') AS slot
+			FROM booking b
+			WHERE b.tenant_id = $1 AND b.start_date = $2::date AND b.pickup_time IS NOT NULL
+			  AND (b.status IN ('on_progress', 'on_rent', 'completed') OR (b.status = 'pending' AND b.locked_until > NOW()))
+			UNION ALL
+			SELECT to_char(b.return_time, 'HH24:MI') AS slot
+			FROM booking b
+			WHERE b.tenant_id = $1 AND b.end_date = $2::date AND b.return_time IS NOT NULL
+			  AND (b.status IN ('on_progress', 'on_rent', 'completed') OR (b.status = 'pending' AND b.locked_until > NOW()))
+		) s
+		GROUP BY slot
+	`
+	if err := sqlx.Select(q, &rows, query, tenantID, date); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]int, len(rows))
+	for _, row := range rows {
+		usage[row.Slot] = row.Count
+	}
+	return usage, nil
+}
+
+/*
+checkSlotCapacity mengunci baris store lalu memastikan slot pickup & pengembalian booking masih muat.
+Lock pada tenant membuat pembuatan booking ber-slot di store yang sama berjalan bergantian.
+
+Output:
+- nil jika semua slot masih muat
+- errors.New("slot_full") jika salah satu slot sudah penuh
+- error jika query gagal
+*/
+func checkSlotCapacity(tx *sqlx.Tx, booking *domain.Booking) error {
+	var capacity int
+	if err := tx.Get(&capacity, `SELECT slot_capacity FROM tenant WHERE id = $1 FOR UPDATE`, *booking.TenantID); err != nil {
+		log.Printf("CreateBooking: error locking store %s: %v", *booking.TenantID, err)
+		return err
+	}
+
+	// Kebutuhan slot per tanggal; pickup & pengembalian di slot yang sama dihitung dua kali
+	needed := map[time.Time]map[string]int{}
+	add := func(date time.Time, slot *string) {
+		if slot == nil {
+			return
+		}
+		if needed[date] == nil {
+			needed[date] = map[string]int{}
+		}
+		needed[date][*slot]++
+	}
+	add(booking.StartDate, booking.PickupTime)
+	add(booking.EndDate, booking.ReturnTime)
+
+	for date, slots := range needed {
+		usage, err := slotUsage(tx, *booking.TenantID, date)
+		if err != nil {
+			log.Printf("CreateBooking: error counting slot usage store=%s: %v", *booking.TenantID, err)
+			return err
+		}
+		for slot, count := range slots {
+			if usage[slot]+count > capacity {
+				log.Printf("CreateBooking: slot %s %s full on store %s", date.Format("2006-01-02"), slot, *booking.TenantID)
+				return errors.New("slot_full")
+			}
+		}
+	}
+	return nil
+}
+
+/*
+checkVariantStock mengunci varian yang dipesan lalu memastikan stock-nya cukup di rentang tanggal sewa.
+Beberapa baris booking dengan varian yang sama dijumlahkan.
+
+Output:
+- nil jika semua varian masih cukup (atau booking tanpa varian)
+- errors.New("variant_unavailable") jika salah satu varian tidak cukup
+- error jika query gagal
+*/
+func checkVariantStock(tx *sqlx.Tx, booking *domain.Booking, items []domain.BookingItem) error {
+	needed := map[string]int{}
+	for _, item := range items {
+		if item.VariantID != nil {
+			needed[*item.VariantID] += item.Quantity
+		}
+	}
+
+	ok, err := checkDailyStock(tx, "variant_id", needed, booking.StartDate, booking.EndDate)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("variant_unavailable")
+	}
+	return nil
+}
+
+/*
+checkItemStock mengunci item yang dipesan tanpa varian (sewa satuan & komponen paket)
+lalu memastikan stock-nya cukup di rentang tanggal sewa.
+Kebutuhan per item = semua baris booking ini untuk item tersebut (komponen paket + sewa satuan).
+Baris bervarian dicek terhadap stock varian (lihat checkVariantStock).
+
+Output:
+- nil jika semua item masih cukup
+- errors.New("bundle_unavailable") jika tidak cukup dan booking berisi paket
+- errors.New("item_unavailable") jika tidak cukup pada booking tanpa paket
+- error jika query gagal
+*/
+func checkItemStock(tx *sqlx.Tx, booking *domain.Booking, items []domain.BookingItem) error {
+	needed := map[string]int{}
+	hasBundle := false
+	for _, item := range items {
+		if item.BundleID != nil {
+			hasBundle = true
+		}
+		if item.VariantID == nil {
+			needed[item.ItemID] += item.Quantity
+		}
+	}
+
+	ok, err := checkDailyStock(tx, "item_id", needed, booking.StartDate, booking.EndDate)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if hasBundle {
+			return errors.New("bundle_unavailable")
+		}
+		return errors.New("item_unavailable")
+	}
+	return nil
+}
+
+/*
+dailyStockLockQueries adalah query penguncian stock per kolom booking_item yang didukung checkDailyStock.
+*/
+var dailyStockLockQueries = map[string]string{
+	"variant_id": `SELECT id, stock FROM item_variant WHERE id = ANY($1::uuid[]) AND is_active ORDER BY id FOR UPDATE`,
+	"item_id":    `SELECT id, stock FROM item WHERE id = ANY($1::uuid[]) ORDER BY id FOR UPDATE`,
+}
+
+/*
+checkDailyStock mengunci baris stock (item / varian) lalu memastikan kebutuhan booking masih muat
+di pemakaian harian tertinggi booking aktif lain dalam rentang tanggal sewa.
+
+Parameter:
+- column: kolom booking_item yang menunjuk baris stock ("item_id" atau "variant_id")
+- needed: kebutuhan booking ini per ID
+
+Alur kerja:
+1. Kunci baris stock (FOR UPDATE) urut ID agar dua booking yang berebut stock yang sama tidak deadlock
+2. Per ID: hitung pemakaian tertinggi per hari dari booking aktif lain
+(booking on_rent yang terlambat kembali tetap memakai unit sampai dikembalikan)
+3. Pemakaian + kebutuhan melebihi stock → tidak cukup
+
+Output:
+- (true, nil) jika semua cukup (needed kosong juga dianggap cukup)
+- (false, nil) jika salah satu ID tidak ada / tidak aktif / stock-nya tidak cukup
+- (false, error) jika query gagal
+*/
+func checkDailyStock(tx *sqlx.Tx, column string, needed map[string]int, start, end time.Time) (bool, error) {
+	if len(needed) == 0 {
+		return true, nil
+	}
+	lockQuery, ok := dailyStockLockQueries[column]
+	if !ok {
+		return false, fmt.Errorf("checkDailyStock: unsupported column %q", column)
+	}
+
+	ids := make([]string, 0, len(needed))
+	for id := range needed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var rows []struct {
+		ID    string `db:"id"`
+		Stock int    `db:"stock"`
+	}
+	if err := tx.Select(&rows, lockQuery, pq.Array(ids)); err != nil {
+		log.Printf("CreateBooking: error locking stock %s: %v", column, err)
+		return false, err
+	}
+	if len(rows) != len(ids) {
+		return false, nil
+	}
+
+	usageQuery := `
+		SELECT COALESCE(MAX(daily), 0) FROM (
+			SELECT SUM(bi.quantity) AS daily
+			FROM booking_item bi
+			INNER JOIN booking b ON b.id = bi.booking_id
+			CROSS JOIN LATERAL generate_series(
+			    GREATEST(b.start_date, $2::date),
+			    CASE WHEN b.status = 'on_rent' THEN $3::date ELSE LEAST(b.end_date, $3::date) END,
+			    INTERVAL '1 day'
+			) AS d
+			WHERE bi.` + column + ` = $1
+			  AND b.start_date <= $3
+			  AND (b.end_date >= $2 OR b.status = 'on_rent')
+			  AND (
+			      b.status IN ('on_progress', 'on_rent')
+			      OR (b.status = 'pending' AND b.locked_until > NOW())
+			  )
+			GROUP BY d
+		) usage
+	`
+	for _, row := range rows {
+		var used int
+		if err := tx.Get(&used, usageQuery, row.ID, start, end); err != nil {
+			log.Printf("CreateBooking: error counting usage %s=%s: %v", column, row.ID, err)
+			return false, err
+		}
+		if used+needed[row.ID] > row.Stock {
+			log.Printf("CreateBooking: %s %s unavailable (stock=%d used=%d needed=%d)", column, row.ID, row.Stock, used, needed[row.ID])
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+/*
+GetBookingBundle mengambil paket sewa beserta komponen dan data item saat ini untuk booking.
+
+Output sukses:
+- (*domain.Bundle, nil) → Items urut nama item
+Output error:
+- (nil, sql.ErrNoRows) → paket tidak ditemukan
+- (nil, error) → query gagal
+*/
+func (r *bookingRepository) GetBookingBundle(bundleID string) (*domain.Bundle, error) {
+	var bundle domain.Bundle
+	err := r.db.Get(&bundle, `
+		SELECT id, hoster_id, tenant_id, name, description, price_per_day, deposit, is_hidden, created_at, updated_at
+		FROM bundle
+		WHERE id = $1
+	`, bundleID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetBookingBundle: query error bundle=%s err=%v", bundleID, err)
+		}
+		return nil, err
+	}
+
+	err = r.db.Select(&bundle.Items, `
+		SELECT bi.bundle_id, bi.item_id, bi.quantity, i.name, i.price_per_day, i.deposit,
+		       i.tenant_id, i.is_hidden, cardinality(i.variant_dimensions) > 0 AS has_variants
+		FROM bundle_item bi
+		INNER JOIN item i ON i.id = bi.item_id
+		WHERE bi.bundle_id = $1
+		ORDER BY i.name, bi.item_id
+	`, bundleID)
+	if err != nil {
+		log.Printf("GetBookingBundle: items query error bundle=%s err=%v", bundleID, err)
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+/*
+GetItemVariantDimensions mengambil dimensi varian item (kosong = item tanpa varian).
+
+Output sukses:
+- ([]string, nil)
+Output error:
+- (nil, error) → item tidak ditemukan / query gagal
+*/
+func (r *bookingRepository) GetItemVariantDimensions(itemID string) ([]string, error) {
+	var dimensions pq.StringArray
+	if err := r.db.Get(&dimensions, `SELECT variant_dimensions FROM item WHERE id = $1`, itemID); err != nil {
+		log.Printf("GetItemVariantDimensions: query error item=%s err=%v", itemID, err)
+		return nil, err
+	}
+	return dimensions, nil
+}
+
+/*
+GetBookingVariant mengambil varian aktif item dengan harga & deposit efektif
+(override varian, fallback ke harga / deposit item).
+
+Output sukses:
+- (*domain.ItemVariant, nil) → PricePerDay & Deposit selalu terisi
+Output error:
+- (nil, sql.ErrNoRows) → varian tidak ditemukan / bukan milik item / nonaktif
+- (nil, error) → query gagal
+*/
+func (r *bookingRepository) GetBookingVariant(itemID, variantID string) (*domain.ItemVariant, error) {
+	var row struct {
+		domain.ItemVariant
+		OptionValues pq.StringArray `db:"option_values"`
+	}
+	err := r.db.Get(&row, `
+		SELECT v.id, v.item_id, v.hoster_id, v.name, v.option_values, v.stock,
+		       COALESCE(v.price_per_day, i.price_per_day) AS price_per_day,
+		       COALESCE(v.deposit, i.deposit) AS deposit,
+		       v.is_active, v.sort_order, v.created_at, v.updated_at
+		FROM item_variant v
+		INNER JOIN item i ON i.id = v.item_id
+		WHERE v.id = $1 AND v.item_id = $2 AND v.is_active
+	`, variantID, itemID)
 	if err != nil {
-		log.Printf("GetHosterIDByItemID: error querying item %s: %v", itemID, err)
-		return "", err
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetBookingVariant: query error variant=%s err=%v", variantID, err)
+		}
+		return nil, err
 	}
-	return hosterID, nil
+	variant := row.ItemVariant
+	variant.OptionValues = row.OptionValues
+	return &variant, nil
 }
 
 /*
@@ -251,6 +803,7 @@ GetBookingDetail mengambil data lengkap satu booking termasuk:
 - Semua booking_item
 - Data customer
 - Status KTP terakhir user
+- Versi T&C yang disetujui saat booking
 
 Alur kerja:
 1. Query header booking
@@ -258,7 +811,8 @@ Alur kerja:
 3. Query items
 4. Query data customer
 5. Query data KTP (opsional)
-6. Bangun DTO lengkap
+6. Query versi T&C yang disetujui
+7. Bangun DTO lengkap
 
 Output sukses:
 - *dto.BookingDetailByCustomerResponse (semua field terisi)
@@ -270,7 +824,8 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 	var booking domain.Booking
 	queryBooking := `
 		SELECT id, hoster_id, locked_until, start_date, end_date, total_days, delivery_type,
-		       rental, deposit, discount, total, outstanding, user_id, identity_id, status,
+		       rental, deposit, discount, total, outstanding, late_fee, overdue_at, user_id, identity_id, status,
+		       to_char(pickup_time, 'HH24:MI') AS pickup_time, to_char(return_time, 'HH24:MI') AS return_time,
 		       created_at, updated_at
 		FROM booking WHERE id = $1
 	`
@@ -297,6 +852,7 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 		SELECT 
 			bi.id, bi.booking_id, bi.item_id, bi.name, bi.quantity, 
 			bi.price_per_day, bi.deposit_per_unit, bi.subtotal_rental, bi.subtotal_deposit,
+			bi.variant_id, bi.variant_name, bi.bundle_id, bi.bundle_name, bi.bundle_quantity,
 			COALESCE(i.description, '') AS description,
 			CASE 
 				WHEN i.photos IS NOT NULL THEN 
@@ -320,6 +876,7 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 		err := rows.Scan(
 			&item.ID, &item.BookingID, &item.ItemID, &item.Name, &item.Quantity,
 			&item.PricePerDay, &item.DepositPerUnit, &item.SubtotalRental, &item.SubtotalDeposit,
+			&item.VariantID, &item.VariantName, &item.BundleID, &item.BundleName, &item.BundleQuantity,
 			&item.Description, pq.Array(&item.Photos),
 		)
 		if err != nil {
@@ -350,8 +907,8 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 	if booking.IdentityID != nil {
 		queryIdentity := `
 			SELECT
-				id, user_id, ktp_url, verified, status,
-				COALESCE(reason, '') AS reason,
+				id, user_id, document_type, document_url, document_expiry,
+				verified, status, COALESCE(reason, '') AS reason,
 				verified_at, created_at, updated_at
 			FROM identity
 			WHERE id = $1
@@ -363,6 +920,12 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 		}
 	}
 
+	// 4a. Get versi T&C yang disetujui saat booking
+	acceptedTerms, err := getAcceptedTerms(r.db, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
 	// 5. Build Response DTO - MAPPING MANUAL
 
 	// Mapping Customer
@@ -375,7 +938,8 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 
 	if err != sql.ErrNoRows && identity.ID != "" {
 		customerResponse.KTPID = identity.ID
-		customerResponse.KTPPhoto = identity.KTPURL
+		customerResponse.KTPPhoto = identity.DocumentURL
+		customerResponse.DocumentType = string(identity.DocumentType)
 		customerResponse.Status = identity.Status
 		customerResponse.Reason = identity.Reason
 		customerResponse.UploadedAt = &identity.CreatedAt
@@ -404,6 +968,10 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 		Discount:             booking.Discount,
 		Total:                booking.Total,
 		Outstanding:          booking.Outstanding,
+		LateFee:              booking.LateFee,
+		OverdueAt:            booking.OverdueAt,
+		PickupTime:           booking.PickupTime,
+		ReturnTime:           booking.ReturnTime,
 		Status:               booking.Status,
 		LockedUntil:          lockedUntilPtr,
 		TimeRemainingMinutes: booking.TimeRemainingMinutes,
@@ -418,6 +986,11 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 			ID:              item.ID,
 			BookingID:       item.BookingID,
 			ItemID:          item.ItemID,
+			VariantID:       item.VariantID,
+			VariantName:     item.VariantName,
+			BundleID:        item.BundleID,
+			BundleName:      item.BundleName,
+			BundleQuantity:  item.BundleQuantity,
 			Name:            item.Name,
 			Description:     item.Description,
 			Photos:          item.Photos,
@@ -430,11 +1003,107 @@ func (r *bookingRepository) GetBookingDetail(bookingID string) (*dto.BookingDeta
 	}
 
 	detail := &dto.BookingDetailByCustomerResponse{
-		Booking:  bookingResponse,
-		Items:    itemsResponse,
-		Customer: customerResponse,
+		Booking:       bookingResponse,
+		Items:         itemsResponse,
+		Customer:      customerResponse,
+		AcceptedTerms: acceptedTerms,
 	}
 
 	log.Printf("GetBookingDetail: successfully retrieved detail for booking %s", bookingID)
 	return detail, nil
 }
+
+/*
+GetPickupCodeNonce mengambil nonce kode pickup booking yang sudah dibayar (on_progress) dan belum dipindai.
+
+Output sukses:
+- (nonce, nil)
+Output error:
+- ("", sql.ErrNoRows) → booking bukan on_progress / kode sudah dipakai
+- ("", error)         → query gagal
+*/
+func (r *bookingRepository) GetPickupCodeNonce(bookingID string) (string, error) {
+	var nonce string
+	err := r.db.Get(&nonce, `
+		SELECT pickup_code_nonce FROM booking
+		WHERE id = $1 AND status = 'on_progress' AND pickup_code_used_at IS NULL
+	`, bookingID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("GetPickupCodeNonce: query error booking=%s err=%v", bookingID, err)
+	}
+	return nonce, err
+}
+
+/*
+getAcceptedTerms mengambil versi T&C yang disetujui customer untuk satu booking.
+
+Output sukses:
+- ([]dto.TnCVersionResponse, nil) → slice kosong untuk booking sebelum T&C berversi
+Output error:
+- (nil, error) → query gagal
+*/
+func getAcceptedTerms(db *sqlx.DB, bookingID string) ([]dto.TnCVersionResponse, error) {
+	var rows []struct {
+		dto.TnCVersionResponse
+		Description pq.StringArray `db:"description"`
+	}
+	err := db.Select(&rows, `
+		SELECT v.id, v.version, v.tenant_id, v.item_id, v.created_at, a.accepted_at,
+		       ARRAY(SELECT jsonb_array_elements_text(v.description)) AS description
+		FROM booking_tnc_acceptance a
+		INNER JOIN tnc_version v ON v.id = a.tnc_version_id
+		WHERE a.booking_id = $1
+		ORDER BY v.item_id NULLS FIRST, v.created_at
+	`, bookingID)
+	if err != nil {
+		log.Printf("getAcceptedTerms: query error booking=%s err=%v", bookingID, err)
+		return nil, err
+	}
+
+	terms := make([]dto.TnCVersionResponse, len(rows))
+	for i, row := range rows {
+		terms[i] = row.TnCVersionResponse
+		terms[i].Description = row.Description
+	}
+	return terms, nil
+}
+
+/*
+CreateReview menyimpan ulasan customer untuk booking yang sudah selesai.
+hoster_id & user_id disalin dari booking; status completed dicek di query yang sama.
+
+Output sukses:
+- nil → review.ID, HosterID, CreatedAt terisi
+Output error:
+- errors.New("reviewed") → booking sudah punya ulasan
+- sql.ErrNoRows → booking tidak ada / belum completed
+- error → query gagal
+*/
+func (r *bookingRepository) CreateReview(review *domain.BookingReview) error {
+	err := r.db.QueryRowx(`
+		INSERT INTO booking_review (booking_id, user_id, hoster_id, rating, comment)
+		SELECT b.id, b.user_id, b.hoster_id, $3, $4
+		FROM booking b
+		WHERE b.id = $1 AND b.user_id = $2 AND b.status = 'completed'
+		ON CONFLICT (booking_id) DO NOTHING
+		RETURNING id, hoster_id, created_at
+	`, review.BookingID, review.UserID, review.Rating, review.Comment).Scan(&review.ID, &review.HosterID, &review.CreatedAt)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("CreateReview: insert error booking=%s: %v", review.BookingID, err)
+		return err
+	}
+
+	// Tidak ada baris: bisa karena booking belum selesai atau ulasan sudah ada
+	var exists bool
+	if err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM booking_review WHERE booking_id = $1)`, review.BookingID); err != nil {
+		log.Printf("CreateReview: check existing review booking=%s: %v", review.BookingID, err)
+		return err
+	}
+	if exists {
+		return errors.New("reviewed")
+	}
+	return sql.ErrNoRows
+}
@@ -17,9 +17,18 @@ Alur kerja:
   - Customer        → pastikan role user adalah "customer"
 
 3. Register route dengan urutan spesifik-ke-umum agar tidak tertimpa:
-  - GET  /booking/me          → daftar booking user login
-  - GET  /booking/{id}        → detail satu booking
-  - POST /booking             → buat booking baru
+  - GET  /booking/me                          → daftar booking user login
+  - GET  /booking/overdue                     → booking terlambat dikembalikan + denda (sebelum /booking/{id})
+  - GET  /booking/{id}                        → detail satu booking
+  - GET  /booking/{id}/pickup-qr              → QR kode pickup (PNG / SVG), hanya booking on_progress
+  - GET  /booking/{id}/handover               → berita acara serah terima + perbandingan kondisi
+  - POST /booking/{id}/handover/{kind}/confirm → konfirmasi handover pickup / return
+  - POST /booking/{id}/amendment              → ajukan perpanjangan / perubahan quantity
+  - GET  /booking/{id}/amendment              → riwayat permintaan perubahan
+  - POST /booking/{id}/amendment/{amendmentId}/cancel → batalkan permintaan yang masih pending
+  - POST /booking/{id}/review                 → ulasan (rating 1 - 5) untuk booking yang sudah selesai
+  - POST /booking                             → buat booking baru
+  - GET  /store/{id}/slots                    → slot pickup / pengembalian store di satu tanggal
 
 Output:
 - Subrouter yang sudah terproteksi dan siap menerima request booking.
@@ -35,8 +44,17 @@ func SetupBookingRoutes(router *mux.Router, h *BookingHandler) {
 
 	// Urutan penting: route dengan path parameter harus didefinisikan sebelum route umum
 	protected.HandleFunc("/booking", h.GetListBookings).Methods("GET")
+	protected.HandleFunc("/booking/overdue", h.GetOverdueBookings).Methods("GET")
 	protected.HandleFunc("/booking/{id}", h.GetDetailBooking).Methods("GET")
+	protected.HandleFunc("/booking/{id}/pickup-qr", h.GetPickupQR).Methods("GET")
+	protected.HandleFunc("/booking/{id}/handover", h.GetHandovers).Methods("GET")
+	protected.HandleFunc("/booking/{id}/handover/{kind}/confirm", h.ConfirmHandover).Methods("POST")
+	protected.HandleFunc("/booking/{id}/amendment", h.RequestAmendment).Methods("POST")
+	protected.HandleFunc("/booking/{id}/amendment", h.GetAmendments).Methods("GET")
+	protected.HandleFunc("/booking/{id}/amendment/{amendmentId}/cancel", h.CancelAmendment).Methods("POST")
+	protected.HandleFunc("/booking/{id}/review", h.CreateReview).Methods("POST")
 	protected.HandleFunc("/booking", h.CreateBooking).Methods("POST")
+	protected.HandleFunc("/store/{id}/slots", h.GetStoreSlots).Methods("GET")
 
 	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
 	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1,14 +1,20 @@
 package booking
 
 import (
+	"bytes"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"lalan-be/internal/config"
 	"lalan-be/internal/domain"
 	"lalan-be/internal/dto"
 	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
 
 	"github.com/google/uuid"
 )
@@ -26,7 +32,44 @@ Tidak boleh ada detail HTTP atau database di sini.
 type BookingService interface {
 	CreateBooking(userID string, req dto.CreateBookingByCustomerRequest) (*dto.BookingDetailByCustomerResponse, error)
 	GetListBookings(userID string) ([]dto.BookingListByCustomerResponse, error)
+	GetOverdueBookings(userID string) ([]dto.OverdueBookingResponse, error)
 	GetDetailBooking(userID string, bookingID string) (*dto.BookingDetailByCustomerResponse, error)
+	GetHandovers(userID, bookingID string) (*dto.BookingHandoversResponse, error)
+	ConfirmHandover(userID, bookingID, kind string, req dto.ConfirmHandoverByCustomerRequest) (*dto.BookingHandoversResponse, error)
+	RenderPickupQR(userID, bookingID, format string) ([]byte, error)
+	RequestAmendment(userID, bookingID string, req dto.CreateBookingAmendmentByCustomerRequest) (*dto.BookingAmendmentResponse, error)
+	GetAmendments(userID, bookingID string) ([]dto.BookingAmendmentResponse, error)
+	CancelAmendment(userID, bookingID, amendmentID string) (*dto.BookingAmendmentResponse, error)
+	GetStoreSlots(storeID, date string) (*dto.StoreSlotsResponse, error)
+	CreateReview(userID, bookingID string, req dto.CreateBookingReviewByCustomerRequest) (*dto.BookingReviewResponse, error)
+}
+
+// MaxHandoverNoteLength adalah panjang maksimal catatan customer saat konfirmasi handover.
+const MaxHandoverNoteLength = 1000
+
+// MaxAmendmentNoteLength adalah panjang maksimal catatan customer saat mengajukan perubahan booking.
+const MaxAmendmentNoteLength = 1000
+
+// MaxReviewCommentLength adalah panjang maksimal komentar ulasan customer.
+const MaxReviewCommentLength = 1000
+
+/*
+BookingHandoverStore adalah kontrak akses berita acara serah terima (diimplementasikan repository handover hoster).
+*/
+type BookingHandoverStore interface {
+	GetHandovers(bookingID string) (*dto.BookingHandoversResponse, error)
+	ConfirmHandover(bookingID, kind, note string) error
+}
+
+/*
+BookingAmendmentStore adalah kontrak akses permintaan perubahan booking (diimplementasikan repository amendment hoster).
+*/
+type BookingAmendmentStore interface {
+	GetAmendmentBase(bookingID string) (*domain.BookingAmendment, error)
+	CreateAmendment(a *domain.BookingAmendment) error
+	GetAmendment(amendmentID string) (*dto.BookingAmendmentResponse, error)
+	GetAmendments(bookingID string) ([]dto.BookingAmendmentResponse, error)
+	CancelAmendment(bookingID, amendmentID string) error
 }
 
 /*
@@ -34,7 +77,9 @@ bookingService adalah implementasi konkret dari BookingService.
 Menyimpan dependency ke repository untuk persistensi data.
 */
 type bookingService struct {
-	repo BookingRepository
+	repo       BookingRepository
+	handovers  BookingHandoverStore
+	amendments BookingAmendmentStore
 }
 
 /*
@@ -44,8 +89,8 @@ Dependency injection dilakukan di sini untuk memudahkan testing.
 Output:
 - Implementasi BookingService yang terkoneksi ke repository.
 */
-func NewBookingService(repo BookingRepository) BookingService {
-	return &bookingService{repo: repo}
+func NewBookingService(repo BookingRepository, handovers BookingHandoverStore, amendments BookingAmendmentStore) BookingService {
+	return &bookingService{repo: repo, handovers: handovers, amendments: amendments}
 }
 
 /*
@@ -53,20 +98,32 @@ CreateBooking menangani seluruh proses bisnis pembuatan booking baru.
 
 Alur kerja:
 1. Ekstrak user ID dari context (via middleware auth)
-2. Validasi KTP user sudah ter-upload (via repository)
+2. Validasi dokumen identitas user sudah ter-upload, tidak rejected, dan masih berlaku sampai akhir sewa
 3. Parse dan hitung durasi sewa (totalDays)
 4. Hitung total rental + deposit - discount
 5. Generate booking ID dan locked_until (30 menit)
-6. Tentukan hoster_id dari item pertama
-7. Bangun entity BookingModel, BookingItem[], dan BookingCustomer
-8. Persist semua data via repository dalam satu transaksi
+6. Validasi paket sewa (harga & subtotal sesuai paket saat ini),
+lalu tentukan store & hoster dari item dan komponen paket (semua wajib dari store yang sama, delivery hanya jika store melayani),
+lalu tolak customer yang diblokir hoster dan pastikan versi T&C terbaru sudah disetujui,
+lalu validasi slot pickup & pengembalian terhadap jam buka, hari libur, dan kapasitas store,
+lalu validasi varian untuk item bervarian
+7. Bangun entity BookingModel, BookingItem[] (paket dipecah menjadi baris per komponen), dan BookingCustomer
+8. Persist semua data (termasuk versi T&C yang disetujui) via repository dalam satu transaksi,
+kapasitas slot, stock varian, dan stock item (sewa satuan & komponen paket) dicek ulang di dalam transaksi
 
 Output sukses:
 - *dto.BookingDetailByCustomerResponse (detail lengkap booking yang baru dibuat)
 Output error:
 - message.Unauthorized → 401 (token invalid/missing)
-- "silakan upload ktp terlebih dahulu" → 400 (dari repository)
+- message.IdentityRequired / IdentityRejectedUploadNew / DocumentExpiresBeforeRental → 400
 - "hoster tidak dapat ditentukan..." → 400
+- message.BookingMixedStores / StoreDeliveryDisabled → 400
+- message.BookingNotAvailable → 400 (customer diblokir hoster, pesan sengaja netral)
+- message.TnCAcceptanceRequired → 400 (T&C belum disetujui atau sudah diperbarui)
+- message.StoreClosedOnDate / BookingSlotRequired / BookingSlotInvalid / BookingSlotFull → 400
+- message.BookingVariantRequired / BookingVariantInvalid / BookingVariantPriceChanged / BookingVariantUnavailable → 400
+- message.BookingBundleInvalid / BookingBundlePriceChanged / BookingBundleUnavailable → 400
+- message.BookingItemUnavailable → 400
 - Semua error lain → 500 (internal)
 */
 func (s *bookingService) CreateBooking(userID string, req dto.CreateBookingByCustomerRequest) (*dto.BookingDetailByCustomerResponse, error) {
@@ -75,7 +132,7 @@ func (s *bookingService) CreateBooking(userID string, req dto.CreateBookingByCus
 		return nil, errors.New(message.UserIDRequired)
 	}
 
-	// 2. Validasi KTP (hanya cek keberadaan, detail validasi di repo)
+	// 2. Validasi dokumen identitas (hanya cek keberadaan, detail validasi di repo)
 	identity, err := s.repo.GetIdentityByUserID(userID)
 	if err != nil {
 		log.Printf("CreateBooking service: repo error checking identity user %s: %v", userID, err)
@@ -83,18 +140,18 @@ func (s *bookingService) CreateBooking(userID string, req dto.CreateBookingByCus
 	}
 	if identity == nil {
 		// Jika tidak ada identity verifikasi yang valid, beri tahu user untuk
-		// mengunggah dan menyelesaikan proses verifikasi KTP terlebih dahulu.
-		return nil, errors.New(message.KTPRequired)
+		// mengunggah dan menyelesaikan proses verifikasi dokumen terlebih dahulu.
+		return nil, errors.New(message.IdentityRequired)
 	}
 
-	// 2a. Validasi status KTP - jika rejected, tampilkan reason dari admin
+	// 2a. Validasi status dokumen - jika rejected, tampilkan reason dari admin
 	if identity.Status == "rejected" {
-		log.Printf("CreateBooking service: user %s has rejected KTP, cannot create booking", userID)
-		// Format: "KTP Rejected - {reason dari admin}"
+		log.Printf("CreateBooking service: user %s has rejected %s, cannot create booking", userID, identity.DocumentType)
+		// Format: "{KTP|SIM|Passport|KITAS} Rejected - {reason dari admin}"
 		if identity.Reason != "" {
-			return nil, fmt.Errorf("KTP Rejected - %s", identity.Reason)
+			return nil, fmt.Errorf("%s Rejected - %s", identity.DocumentType.Label(), identity.Reason)
 		}
-		return nil, errors.New(message.KTPRejectedUploadNew)
+		return nil, errors.New(message.IdentityRejectedUploadNew)
 	}
 
 	// 3. Parse tanggal & hitung durasi
@@ -102,12 +159,22 @@ func (s *bookingService) CreateBooking(userID string, req dto.CreateBookingByCus
 	endDate, _ := time.Parse("2006-01-02", req.EndDate)
 	totalDays := int(endDate.Sub(startDate).Hours() / 24)
 
-	// 4. Hitung total biaya
+	// 3a. Dokumen bermasa berlaku (SIM/paspor/KITAS) harus masih berlaku sampai sewa selesai
+	if identity.DocumentExpiry != nil && identity.DocumentExpiry.Before(endDate) {
+		log.Printf("CreateBooking service: user %s %s expires %s before rental end %s", userID, identity.DocumentType, identity.DocumentExpiry.Format("2006-01-02"), req.EndDate)
+		return nil, errors.New(message.DocumentExpiresBeforeRental)
+	}
+
+	// 4. Hitung total biaya (item satuan + paket)
 	var rentalTotal, depositTotal int
 	for _, item := range req.Items {
 		rentalTotal += item.SubtotalRental
 		depositTotal += item.SubtotalDeposit
 	}
+	for _, b := range req.Bundles {
+		rentalTotal += b.SubtotalRental
+		depositTotal += b.SubtotalDeposit
+	}
 	total := rentalTotal + depositTotal - req.Discount
 	outstanding := total
 
@@ -137,19 +204,81 @@ func (s *bookingService) CreateBooking(userID string, req dto.CreateBookingByCus
 		UpdatedAt:            time.Now(),
 	}
 
-	// 7. Tentukan hoster dari item pertama
-	if len(req.Items) > 0 {
-		hosterID, err := s.repo.GetHosterIDByItemID(req.Items[0].ItemID)
+	// 7. Tentukan store & hoster dari item (termasuk komponen paket), satu booking hanya untuk satu store
+	if len(req.Items) == 0 && len(req.Bundles) == 0 {
+		return nil, errors.New("at least one item required")
+	}
+	bundles, err := s.resolveBundles(req.Bundles, totalDays)
+	if err != nil {
+		return nil, err
+	}
+	itemIDs := make([]string, 0, len(req.Items))
+	for _, it := range req.Items {
+		itemIDs = append(itemIDs, it.ItemID)
+	}
+	for _, b := range bundles {
+		for _, c := range b.Items {
+			itemIDs = append(itemIDs, c.ItemID)
+		}
+	}
+	for _, itemID := range itemIDs {
+		store, err := s.repo.GetStoreByItemID(itemID)
 		if err != nil {
-			log.Printf("CreateBooking service: failed resolve hoster for item %s: %v", req.Items[0].ItemID, err)
+			log.Printf("CreateBooking service: failed resolve store for item %s: %v", itemID, err)
 			return nil, errors.New(message.InternalError)
 		}
-		if hosterID == "" {
-			return nil, errors.New(message.HosterIDRequired)
+		if booking.TenantID == nil {
+			booking.TenantID = &store.ID
+			booking.HosterID = store.HosterID
+		} else if store.ID != *booking.TenantID {
+			return nil, errors.New(message.BookingMixedStores)
+		}
+		if req.DeliveryType == "delivery" && !store.DeliveryEnabled {
+			return nil, errors.New(message.StoreDeliveryDisabled)
+		}
+	}
+	if booking.HosterID == "" {
+		return nil, errors.New(message.HosterIDRequired)
+	}
+
+	// 7a. Customer yang diblokir hoster ditolak dengan pesan netral
+	blocked, err := s.repo.IsCustomerBlocked(booking.HosterID, userID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if blocked {
+		log.Printf("CreateBooking service: user %s is blocked by hoster %s", userID, booking.HosterID)
+		return nil, errors.New(message.BookingNotAvailable)
+	}
+
+	// 7b. Versi T&C terbaru (store / umum hoster + khusus item / komponen paket) wajib disetujui persis
+	currentTerms, err := s.repo.GetCurrentTnCVersions(booking.HosterID, *booking.TenantID, itemIDs)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	tncVersionIDs, ok := matchAcceptedTerms(currentTerms, req.AcceptedTnCVersionIDs)
+	if !ok {
+		log.Printf("CreateBooking service: user %s did not accept current tnc versions", userID)
+		return nil, errors.New(message.TnCAcceptanceRequired)
+	}
+
+	// 7c. Slot pickup (start_date) & pengembalian (end_date) harus di jam buka store dan belum penuh
+	if booking.PickupTime, err = s.validateSlot(*booking.TenantID, startDate, req.PickupTime); err != nil {
+		return nil, err
+	}
+	if booking.ReturnTime, err = s.validateSlot(*booking.TenantID, endDate, req.ReturnTime); err != nil {
+		return nil, err
+	}
+	if booking.PickupTime != nil && booking.ReturnTime != nil && startDate.Equal(endDate) && *booking.ReturnTime <= *booking.PickupTime {
+		return nil, errors.New(message.BookingSlotInvalid)
+	}
+
+	// 7d. Item bervarian wajib memilih varian aktif dengan harga & deposit yang masih sama
+	variants := make([]*domain.ItemVariant, len(req.Items))
+	for i, it := range req.Items {
+		if variants[i], err = s.resolveVariant(it); err != nil {
+			return nil, err
 		}
-		booking.HosterID = hosterID
-	} else {
-		return nil, errors.New("at least one item required")
 	}
 
 	// 8. Bangun booking items
@@ -166,6 +295,13 @@ func (s *bookingService) CreateBooking(userID string, req dto.CreateBookingByCus
 			SubtotalRental:  it.SubtotalRental,
 			SubtotalDeposit: it.SubtotalDeposit,
 		}
+		if v := variants[i]; v != nil {
+			items[i].VariantID = &v.ID
+			items[i].VariantName = &v.Name
+		}
+	}
+	for i, b := range bundles {
+		items = append(items, expandBundle(bookingID, b, req.Bundles[i].Quantity, totalDays)...)
 	}
 
 	// 9. Bangun customer data
@@ -183,14 +319,319 @@ func (s *bookingService) CreateBooking(userID string, req dto.CreateBookingByCus
 	}
 
 	// 10. Persist via repository
-	detail, err := s.repo.CreateBooking(booking, items, customer)
+	detail, err := s.repo.CreateBooking(booking, items, customer, tncVersionIDs)
 	if err != nil {
+		if err.Error() == "slot_full" {
+			return nil, errors.New(message.BookingSlotFull)
+		}
+		if err.Error() == "variant_unavailable" {
+			return nil, errors.New(message.BookingVariantUnavailable)
+		}
+		if err.Error() == "bundle_unavailable" {
+			return nil, errors.New(message.BookingBundleUnavailable)
+		}
+		if err.Error() == "item_unavailable" {
+			return nil, errors.New(message.BookingItemUnavailable)
+		}
 		return nil, err // error sudah sesuai konteks (KTP, DB, dll)
 	}
 
 	return detail, nil
 }
 
+/*
+resolveVariant memvalidasi pilihan varian satu item booking.
+
+Alur kerja:
+1. Item tanpa varian → variant_id harus kosong
+2. Item bervarian → variant_id wajib, harus varian aktif milik item
+3. Harga & deposit per unit harus sama dengan harga efektif varian saat ini
+
+Output:
+- (nil, nil) → item tanpa varian
+- (*domain.ItemVariant, nil) → varian valid
+- (nil, error) → BookingVariantRequired / BookingVariantInvalid / BookingVariantPriceChanged / internal error
+*/
+func (s *bookingService) resolveVariant(it dto.CreateBookingItemByCustomerRequest) (*domain.ItemVariant, error) {
+	dimensions, err := s.repo.GetItemVariantDimensions(it.ItemID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	variantID := strings.TrimSpace(it.VariantID)
+	if len(dimensions) == 0 {
+		if variantID != "" {
+			return nil, errors.New(message.BookingVariantInvalid)
+		}
+		return nil, nil
+	}
+	if variantID == "" {
+		return nil, errors.New(message.BookingVariantRequired)
+	}
+	if _, err := uuid.Parse(variantID); err != nil {
+		return nil, errors.New(message.BookingVariantInvalid)
+	}
+
+	variant, err := s.repo.GetBookingVariant(it.ItemID, variantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.BookingVariantInvalid)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	if *variant.PricePerDay != it.PricePerDay || *variant.Deposit != it.DepositPerUnit {
+		log.Printf("CreateBooking service: variant %s price changed (price=%d deposit=%d)", variant.ID, *variant.PricePerDay, *variant.Deposit)
+		return nil, errors.New(message.BookingVariantPriceChanged)
+	}
+	return variant, nil
+}
+
+/*
+resolveBundles memvalidasi paket sewa dalam booking request.
+
+Alur kerja:
+1. bundle_id valid dan unik, quantity > 0
+2. Paket ada, tidak disembunyikan, dan semua komponen masih tampil, di store paket, dan tidak bervarian
+3. Harga, deposit, dan subtotal harus sama dengan harga paket saat ini
+
+Output:
+- ([]*domain.Bundle, nil) → urut sesuai request
+- (nil, error) → BookingBundleInvalid / BookingBundlePriceChanged / internal error
+*/
+func (s *bookingService) resolveBundles(reqs []dto.CreateBookingBundleByCustomerRequest, totalDays int) ([]*domain.Bundle, error) {
+	bundles := make([]*domain.Bundle, len(reqs))
+	seen := make(map[string]bool, len(reqs))
+	for i, b := range reqs {
+		bundleID := strings.TrimSpace(b.BundleID)
+		if _, err := uuid.Parse(bundleID); err != nil || seen[bundleID] || b.Quantity <= 0 {
+			return nil, errors.New(message.BookingBundleInvalid)
+		}
+		seen[bundleID] = true
+
+		bundle, err := s.repo.GetBookingBundle(bundleID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.New(message.BookingBundleInvalid)
+			}
+			return nil, errors.New(message.InternalError)
+		}
+		if bundle.IsHidden || len(bundle.Items) == 0 {
+			return nil, errors.New(message.BookingBundleInvalid)
+		}
+		for _, c := range bundle.Items {
+			if c.IsHidden || c.HasVariants || c.TenantID != bundle.TenantID {
+				return nil, errors.New(message.BookingBundleInvalid)
+			}
+		}
+
+		if b.PricePerDay != bundle.PricePerDay || b.DepositPerUnit != bundle.Deposit ||
+			b.SubtotalRental != bundle.PricePerDay*b.Quantity*totalDays || b.SubtotalDeposit != bundle.Deposit*b.Quantity {
+			log.Printf("CreateBooking service: bundle %s price changed (price=%d deposit=%d)", bundle.ID, bundle.PricePerDay, bundle.Deposit)
+			return nil, errors.New(message.BookingBundlePriceChanged)
+		}
+		bundles[i] = bundle
+	}
+	return bundles, nil
+}
+
+/*
+expandBundle memecah paket menjadi baris booking_item per komponen.
+
+Alur kerja:
+1. Quantity baris = jumlah paket × quantity komponen
+2. Harga & deposit paket dibagi ke komponen sesuai bobot harga / deposit item (lihat splitBundleAmount)
+3. Sisa pembulatan dimasukkan ke subtotal baris pertama sehingga jumlah subtotal = harga paket
+4. Setiap baris menyimpan snapshot paket (bundle_id, bundle_name, bundle_quantity)
+*/
+func expandBundle(bookingID string, bundle *domain.Bundle, quantity, totalDays int) []domain.BookingItem {
+	prices, priceRest := splitBundleAmount(bundle.PricePerDay, bundle.Items, func(c domain.BundleItem) int { return c.PricePerDay })
+	deposits, depositRest := splitBundleAmount(bundle.Deposit, bundle.Items, func(c domain.BundleItem) int { return c.Deposit })
+
+	items := make([]domain.BookingItem, len(bundle.Items))
+	for i, c := range bundle.Items {
+		qty := quantity * c.Quantity
+		items[i] = domain.BookingItem{
+			ID:              uuid.New().String(),
+			BookingID:       bookingID,
+			ItemID:          c.ItemID,
+			Name:            c.Name,
+			Quantity:        qty,
+			PricePerDay:     prices[i],
+			DepositPerUnit:  deposits[i],
+			SubtotalRental:  prices[i] * qty * totalDays,
+			SubtotalDeposit: deposits[i] * qty,
+			BundleID:        &bundle.ID,
+			BundleName:      &bundle.Name,
+			BundleQuantity:  &quantity,
+		}
+	}
+	items[0].SubtotalRental += priceRest * quantity * totalDays
+	items[0].SubtotalDeposit += depositRest * quantity
+	return items
+}
+
+/*
+splitBundleAmount membagi nominal paket (per satu paket) menjadi nominal per unit setiap komponen.
+
+Alur kerja:
+1. Bobot komponen = weight(item) × quantity, jika semua bobot 0 → quantity saja
+2. Nominal per unit = bagian proporsional / quantity (dibulatkan ke bawah)
+3. Sisa dibagikan ke komponen dengan quantity terkecil lebih dulu (habis jika ada komponen quantity 1)
+
+Output:
+- ([]int nominal per unit, sisa yang tidak habis dibagi)
+*/
+func splitBundleAmount(amount int, components []domain.BundleItem, weight func(domain.BundleItem) int) ([]int, int) {
+	weights := make([]int, len(components))
+	totalWeight := 0
+	for i, c := range components {
+		weights[i] = weight(c) * c.Quantity
+		totalWeight += weights[i]
+	}
+	if totalWeight == 0 {
+		for i, c := range components {
+			weights[i] = c.Quantity
+			totalWeight += weights[i]
+		}
+	}
+
+	perUnit := make([]int, len(components))
+	rest := amount
+	for i, c := range components {
+		perUnit[i] = amount * weights[i] / totalWeight / c.Quantity
+		rest -= perUnit[i] * c.Quantity
+	}
+
+	order := make([]int, len(components))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return components[order[a]].Quantity < components[order[b]].Quantity })
+	for _, i := range order {
+		add := rest / components[i].Quantity
+		perUnit[i] += add
+		rest -= add * components[i].Quantity
+	}
+	return perUnit, rest
+}
+
+/*
+validateSlot memvalidasi slot serah terima customer pada satu tanggal.
+
+Alur kerja:
+1. Store libur / tidak buka di tanggal tersebut → ditolak
+2. Store yang belum mengatur jam buka tidak memakai slot (jam diabaikan)
+3. Slot wajib diisi, harus salah satu slot jam buka, dan belum penuh
+
+Output:
+- (*string HH:MM, nil) → slot valid
+- (nil, nil) → store tanpa jam buka
+- (nil, error) → StoreClosedOnDate / BookingSlotRequired / BookingSlotInvalid / BookingSlotFull / internal error
+*/
+func (s *bookingService) validateSlot(storeID string, date time.Time, slot string) (*string, error) {
+	schedule, err := s.repo.GetStoreDaySchedule(storeID, date)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if schedule.Closed {
+		return nil, errors.New(message.StoreClosedOnDate)
+	}
+	if !schedule.Configured {
+		return nil, nil
+	}
+
+	slot = strings.TrimSpace(slot)
+	if slot == "" {
+		return nil, errors.New(message.BookingSlotRequired)
+	}
+	minutes, err := utils.ParseClock(slot)
+	if err != nil {
+		return nil, errors.New(message.BookingSlotInvalid)
+	}
+	slot = utils.FormatClock(minutes)
+
+	valid := false
+	for _, available := range utils.BuildTimeSlots(schedule.OpenTime, schedule.CloseTime, schedule.SlotMinutes) {
+		if available == slot {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, errors.New(message.BookingSlotInvalid)
+	}
+
+	usage, err := s.repo.GetSlotUsage(storeID, date)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if usage[slot] >= schedule.SlotCapacity {
+		return nil, errors.New(message.BookingSlotFull)
+	}
+	return &slot, nil
+}
+
+/*
+GetStoreSlots mengembalikan slot pickup / pengembalian store pada satu tanggal beserta sisa kapasitasnya.
+
+Alur kerja:
+1. Validasi store ID dan tanggal (YYYY-MM-DD, tidak boleh sebelum hari ini)
+2. Ambil jadwal store di tanggal tersebut
+3. Store libur → open = false; store tanpa jam buka → open = true dengan slots kosong (jam bebas)
+4. Hitung sisa kapasitas setiap slot dari booking yang masih berjalan
+
+Output sukses:
+- *dto.StoreSlotsResponse
+Output error:
+- message.StoreNotFound → 404
+- message.ScheduleInvalidDate → 400
+- Semua error lain → 500
+*/
+func (s *bookingService) GetStoreSlots(storeID, date string) (*dto.StoreSlotsResponse, error) {
+	if _, err := uuid.Parse(storeID); err != nil {
+		return nil, errors.New(message.StoreNotFound)
+	}
+	day, err := time.Parse("2006-01-02", strings.TrimSpace(date))
+	if err != nil || day.Before(time.Now().UTC().Truncate(24*time.Hour)) {
+		return nil, errors.New(message.ScheduleInvalidDate)
+	}
+
+	schedule, err := s.repo.GetStoreDaySchedule(storeID, day)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.StoreNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	resp := &dto.StoreSlotsResponse{
+		StoreID: storeID,
+		Date:    day.Format("2006-01-02"),
+		Open:    !schedule.Closed,
+		Slots:   []dto.StoreSlotResponse{},
+	}
+	if schedule.Closed {
+		resp.ClosedReason = schedule.ClosedReason
+		return resp, nil
+	}
+	if !schedule.Configured {
+		return resp, nil
+	}
+
+	usage, err := s.repo.GetSlotUsage(storeID, day)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	for _, slot := range utils.BuildTimeSlots(schedule.OpenTime, schedule.CloseTime, schedule.SlotMinutes) {
+		remaining := schedule.SlotCapacity - usage[slot]
+		if remaining < 0 {
+			remaining = 0
+		}
+		resp.Slots = append(resp.Slots, dto.StoreSlotResponse{Time: slot, Remaining: remaining})
+	}
+	return resp, nil
+}
+
 /*
 GetBookingsByUserID mengembalikan daftar ringkas semua booking milik user yang login.
 
@@ -218,6 +659,31 @@ func (s *bookingService) GetListBookings(userID string) ([]dto.BookingListByCust
 	return bookings, nil
 }
 
+/*
+GetOverdueBookings mengembalikan booking on_rent milik user yang melewati batas pengembalian beserta dendanya.
+
+Output sukses:
+- []dto.OverdueBookingResponse (bisa kosong)
+Output error:
+- message.UserIDRequired → 401
+- Semua error repo → 500
+*/
+func (s *bookingService) GetOverdueBookings(userID string) ([]dto.OverdueBookingResponse, error) {
+	if userID == "" {
+		return nil, errors.New(message.UserIDRequired)
+	}
+
+	bookings, err := s.repo.GetOverdueBookings(userID)
+	if err != nil {
+		log.Printf("GetOverdueBookings service: repo error for user %s: %v", userID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	if bookings == nil {
+		bookings = []dto.OverdueBookingResponse{}
+	}
+	return bookings, nil
+}
+
 /*
 GetDetailBooking mengembalikan detail lengkap satu booking dengan pengecekan kepemilikan.
 
@@ -253,10 +719,466 @@ func (s *bookingService) GetDetailBooking(userID string, bookingID string) (*dto
 		return nil, errors.New(message.Unauthorized)
 	}
 
+	// Kode pickup hanya dibagikan setelah dibayar dan sebelum barang diambil
+	if detail.Booking.Status == "on_progress" {
+		if code, err := s.pickupCode(bookingID); err == nil {
+			detail.Pickup = &dto.PickupCodeByCustomerResponse{
+				Code:  code,
+				QRURL: fmt.Sprintf("/api/v1/customer/booking/%s/pickup-qr", bookingID),
+			}
+		}
+	}
+
 	return detail, nil
 }
 
+/*
+RenderPickupQR membuat gambar QR kode pickup booking milik customer.
+
+Alur kerja:
+1. Validasi format (png / svg) dan kepemilikan booking
+2. Ambil nonce kode pickup (hanya booking on_progress yang belum dipindai)
+3. Tanda tangani kode lalu render QR
+
+Output sukses:
+- []byte gambar PNG / SVG
+Output error:
+- message.PickupQRInvalidFormat → 400
+- message.Unauthorized → 401
+- message.NotFound + "booking" → 404
+- message.PickupCodeUnavailable → 409
+- Error lain → 500
+*/
+func (s *bookingService) RenderPickupQR(userID, bookingID, format string) ([]byte, error) {
+	if format != "png" && format != "svg" {
+		return nil, errors.New(message.PickupQRInvalidFormat)
+	}
+	if _, err := s.GetDetailBooking(userID, bookingID); err != nil {
+		return nil, err
+	}
+
+	code, err := s.pickupCode(bookingID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.PickupCodeUnavailable)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	qr, err := utils.EncodeQRCode(code)
+	if err != nil {
+		log.Printf("RenderPickupQR: failed to encode qr booking=%s err=%v", bookingID, err)
+		return nil, errors.New(message.InternalError)
+	}
+
+	var buf bytes.Buffer
+	if format == "svg" {
+		err = qr.WriteSVG(&buf, 8)
+	} else {
+		err = qr.WritePNG(&buf, 8)
+	}
+	if err != nil {
+		log.Printf("RenderPickupQR: failed to render qr booking=%s err=%v", bookingID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	return buf.Bytes(), nil
+}
+
+/*
+pickupCode menandatangani kode pickup booking dari nonce yang tersimpan.
+
+Output:
+- (kode, nil)
+- ("", sql.ErrNoRows) → booking bukan on_progress / kode sudah dipakai
+- ("", error)         → query / tanda tangan gagal
+*/
+func (s *bookingService) pickupCode(bookingID string) (string, error) {
+	nonce, err := s.repo.GetPickupCodeNonce(bookingID)
+	if err != nil {
+		return "", err
+	}
+	code, err := utils.SignPickupCode(bookingID, nonce, config.GetPickupCodeKey())
+	if err != nil {
+		log.Printf("pickupCode: failed to sign booking=%s err=%v", bookingID, err)
+	}
+	return code, err
+}
+
+/*
+GetHandovers mengembalikan handover pickup & return booking milik customer beserta perbandingan kondisinya.
+
+Output sukses:
+- *dto.BookingHandoversResponse (pickup / return nil jika belum diisi hoster)
+Output error:
+- message.Unauthorized → 401 (bukan pemilik)
+- message.NotFound + "booking" → 404
+- Error repository → 500
+*/
+func (s *bookingService) GetHandovers(userID, bookingID string) (*dto.BookingHandoversResponse, error) {
+	if _, err := s.GetDetailBooking(userID, bookingID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.handovers.GetHandovers(bookingID)
+	if err != nil {
+		log.Printf("GetHandovers service: repo error for booking %s: %v", bookingID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	return result, nil
+}
+
+/*
+ConfirmHandover mencatat konfirmasi customer atas handover pickup / return yang diisi hoster.
+Setelah dikonfirmasi, hoster tidak bisa lagi mengubah record tersebut.
+
+Alur kerja:
+1. Validasi jenis handover dan catatan (opsional, untuk keberatan atas kondisi)
+2. Validasi kepemilikan booking
+3. Simpan konfirmasi via repository
+
+Output sukses:
+- *dto.BookingHandoversResponse terbaru
+Output error:
+- message.HandoverInvalidKind / TooLong → 400
+- message.Unauthorized → 401
+- message.NotFound + "booking" / HandoverNotFound → 404
+- message.HandoverAlreadyConfirmed → 409
+- Error repository → 500
+*/
+func (s *bookingService) ConfirmHandover(userID, bookingID, kind string, req dto.ConfirmHandoverByCustomerRequest) (*dto.BookingHandoversResponse, error) {
+	if kind != domain.HandoverKindPickup && kind != domain.HandoverKindReturn {
+		return nil, errors.New(message.HandoverInvalidKind)
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(note) > MaxHandoverNoteLength {
+		return nil, fmt.Errorf(message.TooLong, "note")
+	}
+	if _, err := s.GetDetailBooking(userID, bookingID); err != nil {
+		return nil, err
+	}
+
+	if err := s.handovers.ConfirmHandover(bookingID, kind, note); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors.New(message.HandoverNotFound)
+		case err.Error() == "confirmed":
+			return nil, errors.New(message.HandoverAlreadyConfirmed)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	log.Printf("ConfirmHandover: customer %s confirmed %s handover for booking %s", userID, kind, bookingID)
+
+	return s.GetHandovers(userID, bookingID)
+}
+
+/*
+CreateReview menyimpan ulasan customer (rating 1 - 5 + komentar opsional) untuk booking miliknya.
+Ulasan menjadi sumber ringkasan rating di storefront publik.
+
+Alur kerja:
+1. Validasi rating dan panjang komentar
+2. Validasi kepemilikan booking dan status completed
+3. Simpan ulasan (satu ulasan per booking)
+
+Output sukses:
+- *dto.BookingReviewResponse
+Output error:
+- message.ReviewInvalidRating / TooLong → 400
+- message.Unauthorized → 401
+- message.NotFound + "booking" → 404
+- message.ReviewNotAllowed / ReviewAlreadyExists → 409
+- Error lain → 500
+*/
+func (s *bookingService) CreateReview(userID, bookingID string, req dto.CreateBookingReviewByCustomerRequest) (*dto.BookingReviewResponse, error) {
+	if req.Rating < domain.ReviewRatingMin || req.Rating > domain.ReviewRatingMax {
+		return nil, errors.New(message.ReviewInvalidRating)
+	}
+	comment := strings.TrimSpace(req.Comment)
+	if len(comment) > MaxReviewCommentLength {
+		return nil, fmt.Errorf(message.TooLong, "comment")
+	}
+	detail, err := s.GetDetailBooking(userID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Booking.Status != "completed" {
+		return nil, errors.New(message.ReviewNotAllowed)
+	}
+
+	review := &domain.BookingReview{
+		BookingID: bookingID,
+		UserID:    userID,
+		Rating:    req.Rating,
+	}
+	if comment != "" {
+		review.Comment = &comment
+	}
+	if err := s.repo.CreateReview(review); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors.New(message.ReviewNotAllowed)
+		case err.Error() == "reviewed":
+			return nil, errors.New(message.ReviewAlreadyExists)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	log.Printf("CreateReview: customer %s reviewed booking %s (rating=%d)", userID, bookingID, review.Rating)
+
+	return &dto.BookingReviewResponse{
+		ID:        review.ID,
+		BookingID: review.BookingID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		CreatedAt: review.CreatedAt,
+	}, nil
+}
+
+/*
+RequestAmendment mengajukan perpanjangan tanggal dan/atau perubahan quantity booking milik customer.
+Perubahan baru berlaku setelah disetujui hoster.
+
+Alur kerja:
+1. Validasi kepemilikan booking, status (on_progress / on_rent), dan catatan
+2. Ambil kondisi booking saat ini + harga sesuai kebijakan store (snapshot / current)
+3. Terapkan end_date & quantity baru lalu hitung ulang harga (lihat priceAmendment)
+4. Simpan permintaan (cek ketersediaan stock untuk tambahan hari / unit dilakukan di repository)
+
+Output sukses:
+- *dto.BookingAmendmentResponse (status pending)
+Output error:
+- message.AmendmentInvalidEndDate / AmendmentInvalidItems / AmendmentBundleQuantity / AmendmentNoChanges / TooLong → 400
+- message.Unauthorized → 401
+- message.NotFound + "booking" → 404
+- message.AmendmentInvalidStatus / AmendmentQuantityLocked / AmendmentPending / AmendmentUnavailable / AmendmentStale → 409
+- Error lain → 500
+*/
+func (s *bookingService) RequestAmendment(userID, bookingID string, req dto.CreateBookingAmendmentByCustomerRequest) (*dto.BookingAmendmentResponse, error) {
+	note := strings.TrimSpace(req.Note)
+	if len(note) > MaxAmendmentNoteLength {
+		return nil, fmt.Errorf(message.TooLong, "note")
+	}
+	detail, err := s.GetDetailBooking(userID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	status := detail.Booking.Status
+	if status != "on_progress" && status != "on_rent" {
+		return nil, errors.New(message.AmendmentInvalidStatus)
+	}
+
+	base, err := s.amendments.GetAmendmentBase(bookingID)
+	if err != nil {
+		log.Printf("RequestAmendment service: repo error for booking %s: %v", bookingID, err)
+		return nil, errors.New(message.InternalError)
+	}
+
+	// 1. Tanggal kembali baru (kosong = tetap)
+	newEnd := base.OldEndDate
+	if req.EndDate != "" {
+		newEnd, err = time.Parse("2006-01-02", req.EndDate)
+		if err != nil || !newEnd.After(base.OldEndDate) {
+			return nil, errors.New(message.AmendmentInvalidEndDate)
+		}
+	}
+
+	// 2. Quantity baru per booking_item (yang tidak dikirim tetap)
+	quantities := make(map[string]int, len(req.Items))
+	for _, item := range req.Items {
+		if _, dup := quantities[item.BookingItemID]; dup || item.Quantity <= 0 {
+			return nil, errors.New(message.AmendmentInvalidItems)
+		}
+		quantities[item.BookingItemID] = item.Quantity
+	}
+	quantityChanged := false
+	for i := range base.Items {
+		item := &base.Items[i]
+		item.NewQuantity = item.OldQuantity
+		if qty, ok := quantities[item.BookingItemID]; ok {
+			if item.BundleID != nil && qty != item.OldQuantity {
+				return nil, errors.New(message.AmendmentBundleQuantity)
+			}
+			item.NewQuantity = qty
+			delete(quantities, item.BookingItemID)
+		}
+		if item.NewQuantity != item.OldQuantity {
+			quantityChanged = true
+		}
+	}
+	if len(quantities) > 0 {
+		return nil, errors.New(message.AmendmentInvalidItems)
+	}
+	if !quantityChanged && newEnd.Equal(base.OldEndDate) {
+		return nil, errors.New(message.AmendmentNoChanges)
+	}
+	if quantityChanged && status != "on_progress" {
+		return nil, errors.New(message.AmendmentQuantityLocked)
+	}
+
+	// 3. Hitung ulang harga
+	priceAmendment(base, newEnd)
+	if note != "" {
+		base.CustomerNote = &note
+	}
+
+	// 4. Simpan
+	if err := s.amendments.CreateAmendment(base); err != nil {
+		return nil, amendmentError(err)
+	}
+	log.Printf("RequestAmendment: customer %s requested amendment %s for booking %s (total %d → %d)", userID, base.ID, bookingID, base.OldTotal, base.NewTotal)
+
+	result, err := s.amendments.GetAmendment(base.ID)
+	if err != nil {
+		log.Printf("RequestAmendment service: repo error reading amendment %s: %v", base.ID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	return result, nil
+}
+
+/*
+GetAmendments mengembalikan riwayat permintaan perubahan booking milik customer (terbaru dulu).
+
+Output sukses:
+- []dto.BookingAmendmentResponse (bisa kosong)
+Output error:
+- message.Unauthorized → 401
+- message.NotFound + "booking" → 404
+- Error repository → 500
+*/
+func (s *bookingService) GetAmendments(userID, bookingID string) ([]dto.BookingAmendmentResponse, error) {
+	if _, err := s.GetDetailBooking(userID, bookingID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.amendments.GetAmendments(bookingID)
+	if err != nil {
+		log.Printf("GetAmendments service: repo error for booking %s: %v", bookingID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	return result, nil
+}
+
+/*
+CancelAmendment membatalkan permintaan perubahan milik customer yang belum diputuskan hoster.
+
+Output sukses:
+- *dto.BookingAmendmentResponse (status cancelled)
+Output error:
+- message.Unauthorized → 401
+- message.NotFound + "booking" / AmendmentNotFound → 404
+- message.AmendmentAlreadyDecided → 409
+- Error repository → 500
+*/
+func (s *bookingService) CancelAmendment(userID, bookingID, amendmentID string) (*dto.BookingAmendmentResponse, error) {
+	if _, err := s.GetDetailBooking(userID, bookingID); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(amendmentID); err != nil {
+		return nil, errors.New(message.AmendmentNotFound)
+	}
+
+	if err := s.amendments.CancelAmendment(bookingID, amendmentID); err != nil {
+		return nil, amendmentError(err)
+	}
+	log.Printf("CancelAmendment: customer %s cancelled amendment %s for booking %s", userID, amendmentID, bookingID)
+
+	result, err := s.amendments.GetAmendment(amendmentID)
+	if err != nil {
+		log.Printf("CancelAmendment service: repo error reading amendment %s: %v", amendmentID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	return result, nil
+}
+
+/*
+priceAmendment menghitung ulang hari, subtotal, dan total booking untuk permintaan perubahan.
+
+Alur kerja:
+1. Total hari baru = total hari lama + selisih end_date
+2. Per item, selisih unit-hari = (qty baru × hari baru) - (qty lama × hari lama)
+  - bertambah → dikali harga sesuai kebijakan store (snapshot / current)
+  - berkurang → dikali harga snapshot booking (yang dulu dibayar)
+
+3. Selisih deposit = selisih qty × deposit kebijakan (bertambah) / deposit snapshot (berkurang)
+4. Subtotal baru = subtotal lama + selisih (minimal 0), diskon booking tidak berubah
+*/
+func priceAmendment(a *domain.BookingAmendment, newEnd time.Time) {
+	a.NewEndDate = newEnd
+	a.NewTotalDays = a.OldTotalDays + int(newEnd.Sub(a.OldEndDate).Hours()/24)
+	a.NewRental = a.OldRental
+	a.NewDeposit = a.OldDeposit
+
+	for i := range a.Items {
+		item := &a.Items[i]
+
+		unitDays := item.NewQuantity*a.NewTotalDays - item.OldQuantity*a.OldTotalDays
+		price := item.PricePerDay
+		if unitDays < 0 {
+			price = item.SnapshotPrice
+		}
+		item.NewSubtotalRental = max(item.OldSubtotalRental+unitDays*price, 0)
+
+		units := item.NewQuantity - item.OldQuantity
+		deposit := item.DepositPerUnit
+		if units < 0 {
+			deposit = item.SnapshotDeposit
+		}
+		item.NewSubtotalDeposit = max(item.OldSubtotalDeposit+units*deposit, 0)
+
+		a.NewRental += item.NewSubtotalRental - item.OldSubtotalRental
+		a.NewDeposit += item.NewSubtotalDeposit - item.OldSubtotalDeposit
+	}
+	a.NewTotal = a.NewRental + a.NewDeposit - a.Discount
+}
+
+/*
+amendmentError memetakan error repository amendment ke pesan untuk customer.
+*/
+func amendmentError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return errors.New(message.AmendmentNotFound)
+	case err.Error() == "invalid status":
+		return errors.New(message.AmendmentInvalidStatus)
+	case err.Error() == "stale":
+		return errors.New(message.AmendmentStale)
+	case err.Error() == "unavailable":
+		return errors.New(message.AmendmentUnavailable)
+	case err.Error() == "pending":
+		return errors.New(message.AmendmentPending)
+	case err.Error() == "decided":
+		return errors.New(message.AmendmentAlreadyDecided)
+	}
+	return errors.New(message.InternalError)
+}
+
 // DTO Request untuk booking sudah dipindah ke: internal/dto/booking_dto.go
 // - dto.CreateBookingByCustomerRequest
 // - dto.CreateBookingItemByCustomerRequest
 // - dto.CreateBookingCustomerByCustomerRequest
+
+/*
+matchAcceptedTerms memastikan versi T&C yang disetujui customer sama persis dengan versi terbaru.
+Versi lama (T&C sudah diperbarui) atau versi yang terlewat membuat booking ditolak.
+
+Output:
+- (versionIDs, true) jika cocok
+- (nil, false) jika ada yang berbeda
+*/
+func matchAcceptedTerms(current []dto.TnCVersionResponse, accepted []string) ([]string, bool) {
+	acceptedSet := make(map[string]bool, len(accepted))
+	for _, id := range accepted {
+		acceptedSet[id] = true
+	}
+	if len(acceptedSet) != len(current) {
+		return nil, false
+	}
+
+	versionIDs := make([]string, len(current))
+	for i, v := range current {
+		if !acceptedSet[v.ID] {
+			return nil, false
+		}
+		versionIDs[i] = v.ID
+	}
+	return versionIDs, true
+}
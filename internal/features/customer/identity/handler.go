@@ -1,6 +1,7 @@
 package identity
 
 import (
+	"io"
 	"log"
 	"net/http"
 	"strings"
@@ -8,10 +9,11 @@ import (
 	"lalan-be/internal/message"
 	"lalan-be/internal/middleware"
 	"lalan-be/internal/response"
+	"lalan-be/internal/utils"
 )
 
 /*
-IdentityHandler adalah HTTP transport layer untuk fitur verifikasi identitas (KTP).
+IdentityHandler adalah HTTP transport layer untuk fitur verifikasi identitas (KTP, SIM, paspor, KITAS).
 Tanggung jawab handler TERBATAS pada:
 • Validasi method, header, dan multipart form
 • Ekstrak userID dari context (middleware)
@@ -33,7 +35,7 @@ func NewIdentityHandler(service *IdentityService) *IdentityHandler {
 }
 
 /*
-UploadKTP menangani endpoint POST /identity/ktp (upload KTP).
+UploadIdentity menangani endpoint POST /customer/identity (upload dokumen identitas).
 
 Catatan: setiap upload diperlakukan sebagai entri baru — kita tidak akan
 menghapus atau memperbarui record lama. Ini menjaga referensi historis
@@ -43,21 +45,22 @@ Alur kerja:
 1. Validasi method POST
 2. Ambil userID dari context (middleware JWT)
 3. Parse multipart form (max 10 MB)
-4. Validasi field "ktp" ada dan bertipe image/*
-5. Panggil service.UploadKTP() untuk proses upload + simpan ke storage + DB
+4. Ambil field dokumen (document_type, document_number, document_country, document_expiry)
+5. Ambil file "document" (wajib) + "selfie"/"back" (opsional), semua harus image/*
+6. Panggil service.UploadIdentity() untuk validasi dokumen + upload ke storage + simpan DB
 
 Output sukses:
 - Status: 200 OK
 - Body:   null
-- Message: "KTP berhasil diupload"
+- Message: "identity document uploaded successfully"
 
 Output error:
-- 400 Bad Request  → method salah / form tidak valid / bukan gambar
+- 400 Bad Request  → method salah / form tidak valid / bukan gambar / dokumen tidak valid
 - 401 Unauthorized → token tidak valid / userID kosong
 - 500 Internal      → kegagalan storage / database
 */
-func (h *IdentityHandler) UploadKTP(w http.ResponseWriter, r *http.Request) {
-	log.Printf("Identity.UploadKTP: received request")
+func (h *IdentityHandler) UploadIdentity(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Identity.UploadIdentity: received request")
 
 	if r.Method != http.MethodPost {
 		response.MethodNotAllowed(w, message.MethodNotAllowed)
@@ -73,61 +76,54 @@ func (h *IdentityHandler) UploadKTP(w http.ResponseWriter, r *http.Request) {
 
 	// Parse multipart form (max 10 MB)
 	if err := r.ParseMultipartForm(10 << 20); err != nil {
-		log.Printf("Identity.UploadKTP: failed to parse multipart form: %v", err)
+		log.Printf("Identity.UploadIdentity: failed to parse multipart form: %v", err)
 		response.BadRequest(w, message.BadRequest)
 		return
 	}
 
-	file, header, err := r.FormFile("ktp")
-	if err != nil {
-		log.Printf("Identity.UploadKTP: missing ktp file: %v", err)
-		response.BadRequest(w, message.KTPRequired)
-		return
-	}
-	defer file.Close()
-
-	// Validasi tipe file harus gambar
-	if !strings.HasPrefix(header.Header.Get("Content-Type"), "image/") {
-		response.BadRequest(w, "file must be an image")
+	input, files, closeFiles, errMsg := parseIdentityForm(r)
+	defer closeFiles()
+	if errMsg != "" {
+		response.BadRequest(w, errMsg)
 		return
 	}
 
 	// Delegasi ke service (service hanya boleh simpan untuk userID yang sama)
-	if err := h.service.UploadKTP(r.Context(), userID, file); err != nil {
-		log.Printf("Identity.UploadKTP: service error: %v", err)
-		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	if err := h.service.UploadIdentity(r.Context(), userID, input, files); err != nil {
+		log.Printf("Identity.UploadIdentity: service error: %v", err)
+		writeIdentityError(w, err)
 		return
 	}
 
-	response.OK(w, nil, message.KTPUploaded)
+	response.OK(w, nil, message.IdentityUploaded)
 }
 
 /*
-UpdateKTP menangani endpoint PUT /identity/ktp (re-upload KTP oleh customer).
+UpdateIdentity menangani endpoint PUT /customer/identity (re-upload dokumen oleh customer).
 
 Catatan: re-upload = entri baru. Handler ini mengunggah file baru dan service
 akan menyimpan record baru di tabel `identity`. Record lama tidak diubah
-atau dihapus.
+atau dihapus. Jenis dokumen boleh berbeda dari upload sebelumnya.
 
 Alur kerja:
 1. Validasi method PUT
 2. Ambil userID dari context
 3. Pastikan Content-Type multipart/form-data
-4. Parse form dan ambil file "ktp"
-5. Panggil service.UpdateKTP() → file baru disimpan, status identity di-reset ke "pending"
+4. Parse form dan ambil field dokumen + file "document" (dan opsional "selfie"/"back")
+5. Panggil service.UpdateIdentity() → file baru disimpan, status identity di-reset ke "pending"
 
 Output sukses:
 - Status: 200 OK
 - Body:   null
-- Message: "KTP berhasil diupdate dan status disetel menjadi 'pending'"
+- Message: "identity document updated successfully"
 
 Output error:
-- 400 Bad Request  → method salah / header salah / file tidak ada
+- 400 Bad Request  → method salah / header salah / file tidak ada / dokumen tidak valid
 - 401 Unauthorized → token tidak valid
 - 500 Internal      → error storage / database
 */
-func (h *IdentityHandler) UpdateKTP(w http.ResponseWriter, r *http.Request) {
-	log.Printf("Identity.UpdateKTP: received request")
+func (h *IdentityHandler) UpdateIdentity(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Identity.UpdateIdentity: received request")
 
 	if r.Method != http.MethodPut {
 		response.MethodNotAllowed(w, message.MethodNotAllowed)
@@ -147,49 +143,48 @@ func (h *IdentityHandler) UpdateKTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := r.ParseMultipartForm(10 << 20); err != nil {
-		log.Printf("Identity.UpdateKTP: failed to parse multipart form: %v", err)
+		log.Printf("Identity.UpdateIdentity: failed to parse multipart form: %v", err)
 		response.BadRequest(w, message.BadRequest)
 		return
 	}
 
-	file, _, err := r.FormFile("ktp")
-	if err != nil {
-		log.Printf("Identity.UpdateKTP: missing ktp file: %v", err)
-		response.BadRequest(w, message.KTPRequired)
+	input, files, closeFiles, errMsg := parseIdentityForm(r)
+	defer closeFiles()
+	if errMsg != "" {
+		response.BadRequest(w, errMsg)
 		return
 	}
-	defer file.Close()
 
-	if err := h.service.UpdateKTP(r.Context(), userID, file); err != nil {
-		log.Printf("Identity.UpdateKTP: service error: %v", err)
-		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	if err := h.service.UpdateIdentity(r.Context(), userID, input, files); err != nil {
+		log.Printf("Identity.UpdateIdentity: service error: %v", err)
+		writeIdentityError(w, err)
 		return
 	}
 
-	response.OK(w, nil, message.KTPUpdated)
+	response.OK(w, nil, message.IdentityUpdated)
 }
 
 /*
-GetStatusKTP menangani endpoint GET /identity/ktp/status
-Mengembalikan status verifikasi KTP milik user yang sedang login.
+GetIdentityStatus menangani endpoint GET /customer/identity
+Mengembalikan status verifikasi dokumen identitas milik user yang sedang login.
 
 Alur kerja:
 1. Validasi method GET
 2. Ambil userID dari context
-3. Panggil service.GetStatusKTP() untuk mengambil record identity terakhir
+3. Panggil service.GetIdentityStatus() untuk mengambil record identity terakhir
 
 Output sukses:
 - Status: 200 OK
-- Body:   object status KTP (id, ktp_url, status, reason, dll)
-- Message: "Status KTP berhasil diambil"
+- Body:   object status dokumen (identity_id, document_type, document_url, status, reason, dll)
+- Message: "identity status retrieved"
 
 Output error:
 - 401 Unauthorized → token tidak valid
-- 404 Not Found     → user belum pernah upload KTP
+- 404 Not Found     → user belum pernah upload dokumen
 - 500 Internal      → error service / repository
 */
-func (h *IdentityHandler) GetStatusKTP(w http.ResponseWriter, r *http.Request) {
-	log.Printf("Identity.GetStatusKTP: received request")
+func (h *IdentityHandler) GetIdentityStatus(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Identity.GetIdentityStatus: received request")
 
 	if r.Method != http.MethodGet {
 		response.MethodNotAllowed(w, message.MethodNotAllowed)
@@ -203,9 +198,9 @@ func (h *IdentityHandler) GetStatusKTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	status, err := h.service.GetStatusKTP(r.Context(), userID)
+	status, err := h.service.GetIdentityStatus(r.Context(), userID)
 	if err != nil {
-		log.Printf("Identity.GetStatusKTP: service error: %v", err)
+		log.Printf("Identity.GetIdentityStatus: service error: %v", err)
 		response.Error(w, http.StatusInternalServerError, message.InternalError)
 		return
 	}
@@ -215,5 +210,74 @@ func (h *IdentityHandler) GetStatusKTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.OK(w, status, message.KTPStatusRetrieved)
+	response.OK(w, status, message.IdentityStatusRetrieved)
+}
+
+/*
+parseIdentityForm mengambil field dokumen dan file foto dari multipart form.
+Field lama "nik" dan file "ktp" tetap diterima agar client lama tidak rusak.
+
+Output sukses:
+- (input, files, closeFiles, "") → files[0] selalu foto dokumen utama
+Output error:
+- (_, _, closeFiles, pesan error) → file dokumen tidak ada / file bukan gambar
+*/
+func parseIdentityForm(r *http.Request) (utils.DocumentInput, []IdentityFile, func(), string) {
+	input := utils.DocumentInput{
+		Type:    r.FormValue("document_type"),
+		Number:  r.FormValue("document_number"),
+		Country: r.FormValue("document_country"),
+		Expiry:  r.FormValue("document_expiry"),
+	}
+	if input.Number == "" {
+		input.Number = r.FormValue("nik")
+	}
+
+	var files []IdentityFile
+	var opened []io.Closer
+	closeFiles := func() {
+		for _, c := range opened {
+			c.Close()
+		}
+	}
+
+	for _, field := range []string{"document", "selfie", "back"} {
+		file, header, err := r.FormFile(field)
+		if err != nil && field == "document" {
+			file, header, err = r.FormFile("ktp")
+		}
+		if err != nil {
+			if field == "document" {
+				return input, nil, closeFiles, message.IdentityDocumentRequired
+			}
+			continue
+		}
+		opened = append(opened, file)
+
+		// Validasi tipe file harus gambar
+		contentType := header.Header.Get("Content-Type")
+		if !strings.HasPrefix(contentType, "image/") {
+			return input, nil, closeFiles, "file must be an image"
+		}
+		files = append(files, IdentityFile{Kind: field, Reader: file, ContentType: contentType})
+	}
+
+	return input, files, closeFiles, ""
+}
+
+/*
+writeIdentityError memetakan error service ke HTTP response.
+Error validasi dokumen → 400, selain itu → 500.
+*/
+func writeIdentityError(w http.ResponseWriter, err error) {
+	if utils.IsDocumentValidationError(err) {
+		response.BadRequest(w, err.Error())
+		return
+	}
+	switch err.Error() {
+	case message.IdentityDocumentRequired, message.UploadInvalidContentType, message.UserIDRequired:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
 }
@@ -2,6 +2,7 @@ package identity
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -12,7 +13,7 @@ import (
 )
 
 /*
-IdentityRepository adalah layer data access untuk fitur verifikasi identitas (KTP).
+IdentityRepository adalah layer data access untuk fitur verifikasi identitas (KTP, SIM, paspor, KITAS).
 Hanya berisi operasi CRUD langsung ke tabel `identity` — tidak ada business rule.
 */
 type IdentityRepository struct {
@@ -31,46 +32,81 @@ func NewIdentityRepository(db *sqlx.DB) *IdentityRepository {
 }
 
 /*
-UploadKTP menyimpan record KTP baru yang di-upload oleh customer (upload pertama kali).
+CreateIdentity menyimpan record dokumen identitas baru yang di-upload oleh customer.
 
 Alur kerja:
-1. Bangun model IdentityModel dengan status awal "pending"
-2. Insert ke tabel identity
+1. Bangun model Identity dengan status awal "pending"
+2. Insert ke tabel identity beserta nomor dokumen terenkripsi + blind index + foto tambahan
+3. duplicate_flag dihitung langsung di query: nomor dokumen (jenis sama) sudah terverifikasi di akun lain
 
 Output sukses:
 - error = nil → insert berhasil
 Output error:
 - error → query gagal / constraint violation / DB error
 */
-func (r *IdentityRepository) UploadKTP(req *dto.UploadIdentityByCustomerRequest) error {
+func (r *IdentityRepository) CreateIdentity(req *dto.UploadIdentityByCustomerRequest) error {
 	now := time.Now()
 	identity := &domain.Identity{
-		UserID:     req.UserID,
-		KTPURL:     req.KTPURL,
-		Verified:   false,
-		Status:     "pending",
-		Reason:     "",
-		VerifiedAt: nil,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		UserID:          req.UserID,
+		DocumentType:    domain.DocumentType(req.DocumentType),
+		DocumentURL:     req.DocumentURL,
+		DocumentCountry: req.DocumentCountry,
+		DocumentExpiry:  req.DocumentExpiryDate,
+		Verified:        false,
+		Status:          "pending",
+		Reason:          "",
+		VerifiedAt:      nil,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	extraPhotos := req.ExtraPhotos
+	if extraPhotos == nil {
+		extraPhotos = []dto.IdentityPhotoRequest{}
+	}
+	extraJSON, err := json.Marshal(extraPhotos)
+	if err != nil {
+		return err
+	}
+
+	var holderSex *string
+	if req.HolderSex != "" {
+		holderSex = &req.HolderSex
 	}
 
 	query := `
 		INSERT INTO identity (
-			user_id, ktp_url, verified, status,
-			reason, verified_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			user_id, document_type, document_url, document_country, document_expiry,
+			extra_photos, verified, status, reason, verified_at, created_at, updated_at,
+			document_number_encrypted, document_number_hash, holder_birth_date, holder_sex,
+			duplicate_flag
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
+			EXISTS (
+				SELECT 1 FROM identity
+				WHERE document_type = $2 AND document_number_hash = $14
+				  AND verified = true AND user_id <> $1 AND user_role = 'customer'
+			)
+		)
 	`
 
-	_, err := r.db.Exec(query,
+	_, err = r.db.Exec(query,
 		identity.UserID,
-		identity.KTPURL,
+		identity.DocumentType,
+		identity.DocumentURL,
+		identity.DocumentCountry,
+		identity.DocumentExpiry,
+		extraJSON,
 		identity.Verified,
 		identity.Status,
 		identity.Reason,
 		identity.VerifiedAt,
 		identity.CreatedAt,
 		identity.UpdatedAt,
+		req.DocumentNumberEncrypted,
+		req.DocumentNumberHash,
+		req.HolderBirthDate,
+		holderSex,
 	)
 	return err
 }
@@ -81,25 +117,27 @@ func (r *IdentityRepository) UploadKTP(req *dto.UploadIdentityByCustomerRequest)
 // remain valid.
 
 /*
-GetStatusKTP mengambil record identity terakhir (most recent) milik user tertentu.
+GetLatestIdentity mengambil record identity terakhir (most recent) milik user tertentu.
 Jumlah record per user sekarang bisa lebih dari satu karena setiap upload
 jadi entri baru. Oleh karena itu gunakan ORDER BY created_at DESC LIMIT 1
 supaya selalu mendapatkan record terbaru.
 
 Output sukses:
 - (*model.IdentityModel, nil) → record ditemukan
-- (nil, nil)                  → user belum pernah upload KTP
+- (nil, nil)                  → user belum pernah upload dokumen
 Output error:
 - (nil, error)               → kesalahan database
 */
-func (r *IdentityRepository) GetStatusKTP(userID string) (*domain.Identity, error) {
+func (r *IdentityRepository) GetLatestIdentity(userID string) (*domain.Identity, error) {
 	var m domain.Identity
 	query := `
 		SELECT 
-			id, user_id, ktp_url, verified, status, 
-			reason, verified_at, created_at, updated_at
+			id, user_id, document_type, document_url, document_country,
+			document_expiry, extra_photos, verified, status,
+			reason, verified_at, created_at, updated_at,
+			document_number_encrypted, holder_birth_date, holder_sex, duplicate_flag
 		FROM identity
-		WHERE user_id = $1
+		WHERE user_id = $1 AND user_role = 'customer'
 		-- Logika baru: prioritaskan KTP yang paling baru di-upload oleh user
 		-- (created_at DESC). Jika ada lebih dari satu entri dengan created_at
 		-- yang sama, gunakan verified_at DESC sebagai tie-breaker sehingga jika
@@ -8,7 +8,7 @@ import (
 )
 
 /*
-SetupIdentityRoutes mendaftarkan semua endpoint fitur verifikasi identitas (KTP) khusus customer.
+SetupIdentityRoutes mendaftarkan semua endpoint fitur verifikasi identitas (KTP, SIM, paspor, KITAS) khusus customer.
 
 Alur kerja:
 1. Membuat subrouter dengan prefix "/api/v1/customer/identity"
@@ -17,9 +17,9 @@ Alur kerja:
   - Customer     → pastikan role user adalah "customer"
 
 3. Register endpoint:
-  - POST  /upload → upload KTP pertama kali
-  - PUT   /update → re-upload KTP (reset status jadi pending)
-  - GET   /status → ambil status verifikasi KTP user login
+  - POST  /identity → upload dokumen identitas pertama kali
+  - PUT   /identity → re-upload dokumen (reset status jadi pending)
+  - GET   /identity → ambil status verifikasi dokumen user login
 
 Output:
   - Subrouter terproteksi penuh — semua route otomatis ter-autentikasi dan ter-authorize.
@@ -34,9 +34,9 @@ func SetupIdentityRoutes(router *mux.Router, h *IdentityHandler) {
 	identity.Use(middleware.Customer)
 
 	// Route registration
-	identity.HandleFunc("/identity", h.UploadKTP).Methods("POST")
-	identity.HandleFunc("/identity", h.UpdateKTP).Methods("PUT")
-	identity.HandleFunc("/identity", h.GetStatusKTP).Methods("GET")
+	identity.HandleFunc("/identity", h.UploadIdentity).Methods("POST")
+	identity.HandleFunc("/identity", h.UpdateIdentity).Methods("PUT")
+	identity.HandleFunc("/identity", h.GetIdentityStatus).Methods("GET")
 
 	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
 	identity.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
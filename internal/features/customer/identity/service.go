@@ -1,17 +1,22 @@
-// This file contains the IdentityService implementation for handling KTP verification.
+// This file contains the IdentityService implementation for handling identity document verification.
 package identity
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"time"
 
 	"lalan-be/internal/config"
 	"lalan-be/internal/domain"
 	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
 	"lalan-be/internal/utils"
+
+	"github.com/google/uuid"
 )
 
 /*
@@ -19,12 +24,12 @@ IdentityRepo adalah kontrak (interface) untuk operasi database pada tabel identi
 Implementasi nyata berada di IdentityRepository (struct dengan sqlx).
 */
 type IdentityRepo interface {
-	UploadKTP(req *dto.UploadIdentityByCustomerRequest) error
-	GetStatusKTP(userID string) (*domain.Identity, error)
+	CreateIdentity(req *dto.UploadIdentityByCustomerRequest) error
+	GetLatestIdentity(userID string) (*domain.Identity, error)
 }
 
 /*
-IdentityService adalah layer business logic untuk fitur verifikasi KTP.
+IdentityService adalah layer business logic untuk fitur verifikasi dokumen identitas (KTP, SIM, paspor, KITAS).
 Bertanggung jawab atas:
 • Orkestrasi antara storage (upload/delete file) dan repository (DB)
 • Menjaga konsistensi data (file terhapus saat record dihapus)
@@ -53,112 +58,128 @@ func NewIdentityService(repo IdentityRepo, storage utils.Storage, cfg config.Sto
 }
 
 /*
-UploadKTP menangani upload KTP pertama kali oleh customer.
+IdentityFile adalah satu file foto dokumen yang dikirim customer via multipart form.
+*/
+type IdentityFile struct {
+	Kind        string // "document", "selfie", "back"
+	Reader      io.Reader
+	ContentType string // image/jpeg, image/png, image/webp
+}
+
+/*
+UploadIdentity menangani upload dokumen identitas pertama kali oleh customer.
 
 Alur kerja:
-1. Validasi userID tidak kosong
-2. Hapus semua record lama + file di storage (cleanup)
-3. Upload file baru ke storage dengan path deterministik: ktp/{userID}.jpg
+1. Validasi userID dan foto dokumen tidak kosong
+2. Validasi dokumen sesuai jenisnya (KTP/SIM/paspor/KITAS) lalu enkripsi nomor + blind index
+3. Upload foto dokumen + foto tambahan (selfie/sisi belakang) ke storage: identity/{userID}/{tipe}_{kind}_DDMMYYYY_{uuid}.ext
 4. Simpan record baru ke DB dengan status "pending"
 
 Output sukses:
 - error = nil → upload berhasil, record tersimpan
 Output error:
-- error → userID kosong / gagal hapus lama / gagal upload file / gagal insert DB
+- error → userID kosong / dokumen tidak valid (message.Document* / message.NIK*) / gagal upload file / gagal insert DB
 */
-func (s *IdentityService) UploadKTP(ctx context.Context, userID string, file io.Reader) error {
-	if userID == "" {
-		return errors.New("user ID required")
-	}
-
-	// Generate filename: ktp1_DDMMYYYY.jpg
-	now := time.Now()
-	dateStr := now.Format("02122005") // DDMMYYYY
-	filename := fmt.Sprintf("ktp_%s.jpg", dateStr)
-	path := fmt.Sprintf("ktp/%s/%s", userID, filename) // ktp/{userID}/ktp1_02122005.jpg
-
-	newURL, err := s.storage.Upload(ctx, file, path, "image/jpeg", s.config.CustomerBucket)
-	if err != nil {
-		return fmt.Errorf("failed to upload new ktp: %w", err)
-	}
-
-	// Simpan record baru
-	req := &dto.UploadIdentityByCustomerRequest{
-		UserID: userID,
-		KTPURL: newURL,
-	}
-	if err := s.repo.UploadKTP(req); err != nil {
-		_ = s.storage.Delete(ctx, newURL, s.config.CustomerBucket)
-		return fmt.Errorf("failed to save identity record: %w", err)
-	}
-
-	return nil
+func (s *IdentityService) UploadIdentity(ctx context.Context, userID string, input utils.DocumentInput, files []IdentityFile) error {
+	return s.saveIdentity(ctx, userID, input, files)
 }
 
 /*
-UpdateKTP menangani re-upload KTP oleh customer yang sudah memiliki record.
+UpdateIdentity menangani re-upload dokumen identitas oleh customer yang sudah memiliki record.
+Jenis dokumen boleh berbeda dari upload sebelumnya (contoh: KTP ditolak → ganti paspor).
 
 Alur kerja:
 1. Validasi userID dan file tidak kosong
-2. Upload file baru ke storage (overwrite path yang sama)
-3. Update record di DB → reset status ke "pending", verified = false
+2. Validasi dokumen + upload file baru ke storage
+3. Simpan record baru → status "pending", verified = false
 
 Output sukses:
 - error = nil → file terganti, status di-reset ke pending
 Output error:
-- error → userID/file kosong / gagal upload / gagal update DB (file tetap dihapus jika DB gagal)
+- error → userID/file kosong / dokumen tidak valid / gagal upload / gagal insert DB (file tetap dihapus jika DB gagal)
+*/
+func (s *IdentityService) UpdateIdentity(ctx context.Context, userID string, input utils.DocumentInput, files []IdentityFile) error {
+	return s.saveIdentity(ctx, userID, input, files)
+}
+
+/*
+saveIdentity berisi alur bersama upload dan re-upload dokumen.
+Semua file yang sudah ter-upload dihapus lagi jika ada langkah yang gagal.
 */
-func (s *IdentityService) UpdateKTP(ctx context.Context, userID string, file io.Reader) error {
+func (s *IdentityService) saveIdentity(ctx context.Context, userID string, input utils.DocumentInput, files []IdentityFile) error {
 	if userID == "" {
-		return errors.New("user ID required")
+		return errors.New(message.UserIDRequired)
 	}
-	if file == nil {
-		return errors.New("file required")
+	if len(files) == 0 || files[0].Kind != "document" || files[0].Reader == nil {
+		return errors.New(message.IdentityDocumentRequired)
 	}
 
-	// Generate filename dan path sama
-	now := time.Now()
-	dateStr := now.Format("02122005")
-	filename := fmt.Sprintf("ktp1_%s.jpg", dateStr)
-	path := fmt.Sprintf("ktp/%s/%s", userID, filename)
-
-	newURL, err := s.storage.Upload(ctx, file, path, "image/jpeg", s.config.CustomerBucket)
+	doc, err := utils.ProtectDocument(input)
 	if err != nil {
-		return fmt.Errorf("failed to upload ktp: %w", err)
+		return err
+	}
+
+	req := newIdentityRequest(userID, doc)
+	var uploaded []string
+	cleanup := func() {
+		for _, url := range uploaded {
+			_ = s.storage.Delete(ctx, url, s.config.CustomerBucket)
+		}
 	}
 
-	req := &dto.UploadIdentityByCustomerRequest{
-		UserID: userID,
-		KTPURL: newURL,
+	// Generate filename: {tipe}_{kind}_DDMMYYYY_{uuid}.ext
+	dateStr := time.Now().Format("02012006")
+	for _, f := range files {
+		ext, ok := identityImageExtensions[f.ContentType]
+		if !ok {
+			cleanup()
+			return errors.New(message.UploadInvalidContentType)
+		}
+		filename := fmt.Sprintf("%s_%s_%s_%s%s", doc.Type, f.Kind, dateStr, uuid.New().String()[:8], ext)
+		path := fmt.Sprintf("identity/%s/%s", userID, filename)
+
+		url, err := s.storage.Upload(ctx, f.Reader, path, f.ContentType, s.config.CustomerBucket)
+		if err != nil {
+			cleanup()
+			return fmt.Errorf("failed to upload identity %s: %w", f.Kind, err)
+		}
+		uploaded = append(uploaded, url)
+
+		if f.Kind == "document" {
+			req.DocumentURL = url
+		} else {
+			req.ExtraPhotos = append(req.ExtraPhotos, dto.IdentityPhotoRequest{Kind: f.Kind, URL: url})
+		}
 	}
-	if err := s.repo.UploadKTP(req); err != nil {
-		_ = s.storage.Delete(ctx, newURL, s.config.CustomerBucket)
-		return fmt.Errorf("failed to update identity record: %w", err)
+
+	if err := s.repo.CreateIdentity(req); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to save identity record: %w", err)
 	}
 
 	return nil
 }
 
 /*
-GetStatusKTP mengembalikan status verifikasi KTP milik user yang login.
+GetIdentityStatus mengembalikan status verifikasi dokumen identitas milik user yang login.
 
 Alur kerja:
 1. Validasi userID tidak kosong
-2. Ambil record dari repository
-3. Mapping ke DTO response
+2. Ambil record terakhir dari repository
+3. Mapping ke DTO response (nomor dokumen hanya ditampilkan tersamar)
 
 Output sukses:
-- (*dto.IdentityStatusDTO, nil) → record ditemukan
-- (nil, nil)                → user belum pernah upload KTP
+- (*dto.IdentityStatusByCustomerResponse, nil) → record ditemukan
+- (nil, nil)                                   → user belum pernah upload dokumen
 Output error:
-- (nil, error)             → userID kosong / error repository
+- (nil, error)                                 → userID kosong / error repository
 */
-func (s *IdentityService) GetStatusKTP(ctx context.Context, userID string) (*dto.IdentityStatusByCustomerResponse, error) {
+func (s *IdentityService) GetIdentityStatus(ctx context.Context, userID string) (*dto.IdentityStatusByCustomerResponse, error) {
 	if userID == "" {
-		return nil, errors.New("user ID required")
+		return nil, errors.New(message.UserIDRequired)
 	}
 
-	model, err := s.repo.GetStatusKTP(userID)
+	model, err := s.repo.GetLatestIdentity(userID)
 	if err != nil {
 		return nil, fmt.Errorf("repository error: %w", err)
 	}
@@ -166,14 +187,60 @@ func (s *IdentityService) GetStatusKTP(ctx context.Context, userID string) (*dto
 		return nil, nil
 	}
 
-	return &dto.IdentityStatusByCustomerResponse{
-		KTPID:      model.ID,
-		UserID:     model.UserID,
-		KTPURL:     model.KTPURL,
-		CreatedAt:  model.CreatedAt,
-		Status:     model.Status,
-		Verified:   model.Verified,
-		Reason:     model.Reason,
-		VerifiedAt: model.VerifiedAt,
-	}, nil
+	resp := &dto.IdentityStatusByCustomerResponse{
+		IdentityID:      model.ID,
+		KTPID:           model.ID,
+		UserID:          model.UserID,
+		DocumentType:    string(model.DocumentType),
+		DocumentURL:     model.DocumentURL,
+		KTPURL:          model.DocumentURL,
+		DocumentCountry: model.DocumentCountry,
+		DocumentExpiry:  model.DocumentExpiry,
+		CreatedAt:       model.CreatedAt,
+		Status:          model.Status,
+		Verified:        model.Verified,
+		Reason:          model.Reason,
+		VerifiedAt:      model.VerifiedAt,
+	}
+
+	if len(model.ExtraPhotos) > 0 {
+		if err := json.Unmarshal(model.ExtraPhotos, &resp.ExtraPhotos); err != nil {
+			log.Printf("GetIdentityStatus(customer service): invalid extra_photos for %s: %v", model.ID, err)
+		}
+	}
+
+	// Nomor dokumen hanya ditampilkan tersamar ke customer
+	if model.DocumentNumberEncrypted != nil {
+		if number, err := utils.RevealDocumentNumber(*model.DocumentNumberEncrypted); err == nil {
+			resp.DocumentNumberMasked = utils.MaskDocumentNumber(model.DocumentType, number)
+		}
+	}
+
+	return resp, nil
+}
+
+/*
+identityImageExtensions memetakan content type foto dokumen ke ekstensi file.
+*/
+var identityImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+/*
+newIdentityRequest membangun payload repository dari dokumen yang sudah tervalidasi dan diproteksi.
+URL foto diisi setelah upload ke storage.
+*/
+func newIdentityRequest(userID string, doc *utils.ProtectedDocument) *dto.UploadIdentityByCustomerRequest {
+	return &dto.UploadIdentityByCustomerRequest{
+		UserID:                  userID,
+		DocumentType:            string(doc.Type),
+		DocumentCountry:         doc.Country,
+		DocumentExpiryDate:      doc.Expiry,
+		DocumentNumberEncrypted: doc.Encrypted,
+		DocumentNumberHash:      doc.Hash,
+		HolderBirthDate:         doc.BirthDate,
+		HolderSex:               doc.Sex,
+	}
 }
@@ -0,0 +1,155 @@
+package amendment
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterAmendmentHandler menangani endpoint HTTP persetujuan perubahan booking dari perspektif hoster.
+*/
+type HosterAmendmentHandler struct {
+	service AmendmentService
+}
+
+/*
+NewHosterAmendmentHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterAmendmentHandler siap digunakan
+*/
+func NewHosterAmendmentHandler(s AmendmentService) *HosterAmendmentHandler {
+	return &HosterAmendmentHandler{service: s}
+}
+
+/*
+ListAmendments menangani GET /api/v1/hoster/amendment?status=pending
+
+Output sukses:
+- 200 OK + daftar permintaan perubahan (terbaru dulu)
+Output error:
+- 400 Bad Request / 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterAmendmentHandler) ListAmendments(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	status := strings.TrimSpace(r.URL.Query().Get("status"))
+	list, err := h.service.ListAmendments(hosterID, status)
+	if err != nil {
+		log.Printf("ListAmendments handler: service error hoster=%s err=%v", hosterID, err)
+		writeAmendmentError(w, err)
+		return
+	}
+	response.OK(w, list, message.AmendmentRetrieved)
+}
+
+/*
+GetBookingAmendments menangani GET /api/v1/hoster/booking/{id}/amendment
+
+Output sukses:
+- 200 OK + riwayat permintaan perubahan booking
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterAmendmentHandler) GetBookingAmendments(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	list, err := h.service.GetBookingAmendments(hosterID, mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetBookingAmendments handler: service error hoster=%s err=%v", hosterID, err)
+		writeAmendmentError(w, err)
+		return
+	}
+	response.OK(w, list, message.AmendmentRetrieved)
+}
+
+/*
+ApproveAmendment menangani POST /api/v1/hoster/amendment/{id}/approve
+
+Output sukses:
+- 200 OK + permintaan perubahan (status approved, booking sudah diperbarui)
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict / 500 Internal Server Error
+*/
+func (h *HosterAmendmentHandler) ApproveAmendment(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.service.ApproveAmendment, message.AmendmentApproved)
+}
+
+/*
+RejectAmendment menangani POST /api/v1/hoster/amendment/{id}/reject
+
+Output sukses:
+- 200 OK + permintaan perubahan (status rejected)
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict / 500 Internal Server Error
+*/
+func (h *HosterAmendmentHandler) RejectAmendment(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.service.RejectAmendment, message.AmendmentRejected)
+}
+
+/*
+decide menjalankan keputusan (approve / reject) dengan body catatan opsional.
+*/
+func (h *HosterAmendmentHandler) decide(w http.ResponseWriter, r *http.Request, fn func(hosterID, memberID, amendmentID string, req dto.DecideBookingAmendmentByHosterRequest) (*dto.BookingAmendmentResponse, error), successMsg string) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.DecideBookingAmendmentByHosterRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Printf("DecideAmendment: failed to decode JSON: %v", err)
+			response.BadRequest(w, message.BadRequest)
+			return
+		}
+	}
+
+	result, err := fn(hosterID, middleware.GetMemberID(r), mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("DecideAmendment handler: service error hoster=%s err=%v", hosterID, err)
+		writeAmendmentError(w, err)
+		return
+	}
+	response.OK(w, result, successMsg)
+}
+
+/*
+writeAmendmentError memetakan error service perubahan booking ke HTTP response.
+*/
+func writeAmendmentError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.AmendmentNotFound, fmt.Sprintf(message.NotFound, "booking"):
+		response.NotFound(w, err.Error())
+	case message.AmendmentAlreadyDecided,
+		message.AmendmentInvalidStatus,
+		message.AmendmentStale,
+		message.AmendmentUnavailable:
+		response.Error(w, http.StatusConflict, err.Error())
+	case message.AmendmentInvalidFilter, fmt.Sprintf(message.TooLong, "note"):
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,668 @@
+package amendment
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+AmendmentRepository adalah kontrak akses data permintaan perubahan booking.
+GetAmendmentBase, CreateAmendment, GetAmendment, GetAmendments, dan CancelAmendment
+juga dipakai fitur booking customer (ajukan, lihat, batalkan).
+*/
+type AmendmentRepository interface {
+	GetAmendmentBase(bookingID string) (*domain.BookingAmendment, error)
+	CreateAmendment(a *domain.BookingAmendment) error
+	GetAmendment(amendmentID string) (*dto.BookingAmendmentResponse, error)
+	GetAmendments(bookingID string) ([]dto.BookingAmendmentResponse, error)
+	CancelAmendment(bookingID, amendmentID string) error
+	BookingBelongsToHoster(hosterID, bookingID string) (bool, error)
+	ListAmendments(hosterID, status string) ([]dto.BookingAmendmentResponse, error)
+	ApproveAmendment(hosterID, amendmentID string, memberID, note *string) (string, error)
+	RejectAmendment(hosterID, amendmentID string, memberID, note *string) (string, error)
+}
+
+/*
+amendmentRepository adalah implementasi repository perubahan booking.
+*/
+type amendmentRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewAmendmentRepository membuat instance repository dengan koneksi database.
+
+Output:
+- AmendmentRepository siap digunakan
+*/
+func NewAmendmentRepository(db *sqlx.DB) AmendmentRepository {
+	return &amendmentRepository{db: db}
+}
+
+/*
+amendmentColumns adalah kolom response perubahan booking (alias a = booking_amendment).
+*/
+const amendmentColumns = `
+	a.id, a.booking_id, a.status, a.pricing,
+	a.old_end_date, a.new_end_date, a.old_total_days, a.new_total_days,
+	a.old_rental, a.new_rental, a.old_deposit, a.new_deposit, a.old_total, a.new_total,
+	a.customer_note, a.hoster_note, a.decided_by_member_id, a.decided_at, a.created_at`
+
+/*
+GetAmendmentBase mengambil kondisi booking saat ini sebagai dasar permintaan perubahan.
+
+Alur kerja:
+1. Header: nilai Old* dari booking, Pricing dari kebijakan store (default snapshot jika store sudah dihapus)
+2. Items: quantity & subtotal lama, harga snapshot, dan harga tambahan sesuai kebijakan
+  - snapshot → harga booking_item
+  - current  → harga varian / item saat ini (fallback ke booking_item jika item sudah dihapus)
+  - baris komponen paket selalu snapshot (harga paket sudah dibagi ke komponen)
+
+Output sukses:
+- (*domain.BookingAmendment, nil) → New* belum diisi
+Output error:
+- (nil, sql.ErrNoRows) → booking tidak ditemukan
+- (nil, error)         → query gagal
+*/
+func (r *amendmentRepository) GetAmendmentBase(bookingID string) (*domain.BookingAmendment, error) {
+	var a domain.BookingAmendment
+	err := r.db.Get(&a, `
+		SELECT b.id AS booking_id,
+		       COALESCE(t.amendment_pricing, 'snapshot') AS pricing,
+		       b.end_date AS old_end_date, b.total_days AS old_total_days,
+		       b.rental AS old_rental, b.deposit AS old_deposit, b.total AS old_total, b.discount
+		FROM booking b
+		LEFT JOIN tenant t ON t.id = b.tenant_id
+		WHERE b.id = $1
+	`, bookingID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetAmendmentBase: select booking error booking=%s err=%v", bookingID, err)
+		}
+		return nil, err
+	}
+
+	err = r.db.Select(&a.Items, `
+		SELECT bi.id AS booking_item_id, bi.item_id, bi.variant_id, bi.bundle_id, bi.quantity AS old_quantity,
+		       bi.price_per_day AS snapshot_price, bi.deposit_per_unit AS snapshot_deposit,
+		       CASE WHEN $2 = 'current' AND bi.bundle_id IS NULL THEN COALESCE(v.price_per_day, i.price_per_day, bi.price_per_day) ELSE bi.price_per_day END AS price_per_day,
+		       CASE WHEN $2 = 'current' AND bi.bundle_id IS NULL THEN COALESCE(v.deposit, i.deposit, bi.deposit_per_unit) ELSE bi.deposit_per_unit END AS deposit_per_unit,
+		       bi.subtotal_rental AS old_subtotal_rental, bi.subtotal_deposit AS old_subtotal_deposit
+		FROM booking_item bi
+		LEFT JOIN item i ON i.id = bi.item_id
+		LEFT JOIN item_variant v ON v.id = bi.variant_id
+		WHERE bi.booking_id = $1
+		ORDER BY bi.created_at, bi.id
+	`, bookingID, a.Pricing)
+	if err != nil {
+		log.Printf("GetAmendmentBase: select items error booking=%s err=%v", bookingID, err)
+		return nil, err
+	}
+	return &a, nil
+}
+
+/*
+CreateAmendment menyimpan permintaan perubahan booking (status pending).
+
+Alur kerja:
+1. Kunci booking (FOR UPDATE), pastikan masih on_progress / on_rent dan belum berubah sejak dasar perubahan diambil
+2. Cek ketersediaan stock untuk tambahan hari / unit
+3. Insert header + semua item dalam satu transaksi
+
+Output sukses:
+- nil (ID, CreatedAt, UpdatedAt terisi)
+Output error:
+- errors.New("invalid status") → booking tidak bisa diubah
+- errors.New("stale")          → booking berubah di tengah proses
+- errors.New("unavailable")    → stock tidak cukup
+- errors.New("pending")        → masih ada permintaan pending
+- error                        → query gagal
+*/
+func (r *amendmentRepository) CreateAmendment(a *domain.BookingAmendment) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("CreateAmendment: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	startDate, err := lockAmendableBooking(tx, a)
+	if err != nil {
+		return err
+	}
+
+	available, err := checkAvailability(tx, a, startDate)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return errors.New("unavailable")
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO booking_amendment (
+			booking_id, status, pricing, old_end_date, new_end_date, old_total_days, new_total_days,
+			old_rental, new_rental, old_deposit, new_deposit, old_total, new_total, customer_note
+		) VALUES ($1, 'pending', $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, status, created_at, updated_at
+	`, a.BookingID, a.Pricing, a.OldEndDate, a.NewEndDate, a.OldTotalDays, a.NewTotalDays,
+		a.OldRental, a.NewRental, a.OldDeposit, a.NewDeposit, a.OldTotal, a.NewTotal, a.CustomerNote,
+	).Scan(&a.ID, &a.Status, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New("pending")
+		}
+		log.Printf("CreateAmendment: insert amendment error booking=%s err=%v", a.BookingID, err)
+		return err
+	}
+
+	for _, item := range a.Items {
+		_, err := tx.Exec(`
+			INSERT INTO booking_amendment_item (
+				amendment_id, booking_item_id, old_quantity, new_quantity, price_per_day, deposit_per_unit,
+				old_subtotal_rental, new_subtotal_rental, old_subtotal_deposit, new_subtotal_deposit
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, a.ID, item.BookingItemID, item.OldQuantity, item.NewQuantity, item.PricePerDay, item.DepositPerUnit,
+			item.OldSubtotalRental, item.NewSubtotalRental, item.OldSubtotalDeposit, item.NewSubtotalDeposit)
+		if err != nil {
+			log.Printf("CreateAmendment: insert item error amendment=%s err=%v", a.ID, err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("CreateAmendment: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+GetAmendment mengambil satu permintaan perubahan beserta item-nya.
+
+Output sukses:
+- (*dto.BookingAmendmentResponse, nil)
+Output error:
+- (nil, sql.ErrNoRows) → tidak ditemukan
+- (nil, error)         → query gagal
+*/
+func (r *amendmentRepository) GetAmendment(amendmentID string) (*dto.BookingAmendmentResponse, error) {
+	var list []dto.BookingAmendmentResponse
+	err := r.db.Select(&list, `SELECT `+amendmentColumns+` FROM booking_amendment a WHERE a.id = $1`, amendmentID)
+	if err != nil {
+		log.Printf("GetAmendment: select error amendment=%s err=%v", amendmentID, err)
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	if err := r.attachItems(list); err != nil {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+/*
+GetAmendments mengambil riwayat permintaan perubahan sebuah booking (terbaru dulu).
+
+Output sukses:
+- ([]dto.BookingAmendmentResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *amendmentRepository) GetAmendments(bookingID string) ([]dto.BookingAmendmentResponse, error) {
+	list := []dto.BookingAmendmentResponse{}
+	err := r.db.Select(&list, `
+		SELECT `+amendmentColumns+`
+		FROM booking_amendment a
+		WHERE a.booking_id = $1
+		ORDER BY a.created_at DESC
+	`, bookingID)
+	if err != nil {
+		log.Printf("GetAmendments: select error booking=%s err=%v", bookingID, err)
+		return nil, err
+	}
+	if err := r.attachItems(list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+/*
+CancelAmendment membatalkan permintaan perubahan yang masih pending.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows            → permintaan tidak ada di booking ini
+- errors.New("decided")    → sudah diputuskan / dibatalkan
+- error                    → query gagal
+*/
+func (r *amendmentRepository) CancelAmendment(bookingID, amendmentID string) error {
+	var status string
+	err := r.db.Get(&status, `
+		WITH target AS (
+			SELECT id, status FROM booking_amendment WHERE id = $1 AND booking_id = $2
+		), updated AS (
+			UPDATE booking_amendment a
+			SET status = 'cancelled', updated_at = NOW()
+			FROM target
+			WHERE a.id = target.id AND target.status = 'pending'
+			RETURNING a.status
+		)
+		SELECT COALESCE((SELECT status FROM updated), (SELECT status FROM target))
+	`, amendmentID, bookingID)
+	if err != nil {
+		log.Printf("CancelAmendment: update error amendment=%s err=%v", amendmentID, err)
+		return err
+	}
+	return decisionResult(status, domain.AmendmentStatusCancelled)
+}
+
+/*
+BookingBelongsToHoster mengecek booking milik hoster.
+*/
+func (r *amendmentRepository) BookingBelongsToHoster(hosterID, bookingID string) (bool, error) {
+	var exists bool
+	err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM booking WHERE id = $1 AND hoster_id = $2)`, bookingID, hosterID)
+	if err != nil {
+		log.Printf("BookingBelongsToHoster: query error booking=%s err=%v", bookingID, err)
+	}
+	return exists, err
+}
+
+/*
+ListAmendments mengambil permintaan perubahan di semua booking hoster (terbaru dulu).
+
+Parameter:
+- status: filter status (kosong = semua)
+
+Output sukses:
+- ([]dto.BookingAmendmentResponse, nil) → CustomerName terisi
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *amendmentRepository) ListAmendments(hosterID, status string) ([]dto.BookingAmendmentResponse, error) {
+	list := []dto.BookingAmendmentResponse{}
+	err := r.db.Select(&list, `
+		SELECT `+amendmentColumns+`, COALESCE(NULLIF(bc.name, ''), c.full_name, '') AS customer_name
+		FROM booking_amendment a
+		JOIN booking b ON b.id = a.booking_id
+		LEFT JOIN booking_customer bc ON bc.booking_id = b.id
+		LEFT JOIN customer c ON c.id = b.user_id
+		WHERE b.hoster_id = $1 AND ($2 = '' OR a.status = $2)
+		ORDER BY a.created_at DESC
+	`, hosterID, status)
+	if err != nil {
+		log.Printf("ListAmendments: select error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	if err := r.attachItems(list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+/*
+ApproveAmendment menyetujui permintaan perubahan dan menerapkannya ke booking.
+
+Alur kerja:
+1. Kunci permintaan + booking milik hoster (FOR UPDATE)
+2. Permintaan harus pending, booking harus on_progress / on_rent dan belum berubah sejak permintaan dibuat
+3. Cek ulang ketersediaan stock
+4. Terapkan end_date, total_days, rental, deposit, total; outstanding bertambah sebesar selisih total (minimal 0)
+5. Jika batas pengembalian baru belum lewat, tanda terlambat (overdue_at) dilepas; denda yang sudah masuk tetap
+6. Terapkan quantity & subtotal setiap booking_item, tandai approved
+
+Output sukses:
+- (bookingID, nil)
+Output error:
+- ("", sql.ErrNoRows)              → permintaan tidak ada / bukan milik hoster
+- ("", errors.New("decided"))       → sudah diputuskan / dibatalkan
+- ("", errors.New("invalid status")) → booking tidak bisa diubah
+- ("", errors.New("stale"))         → booking berubah sejak permintaan dibuat
+- ("", errors.New("unavailable"))   → stock tidak cukup
+- ("", error)                       → query gagal
+*/
+func (r *amendmentRepository) ApproveAmendment(hosterID, amendmentID string, memberID, note *string) (string, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("ApproveAmendment: error starting transaction: %v", err)
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var a domain.BookingAmendment
+	err = tx.Get(&a, `
+		SELECT a.id, a.booking_id, a.status, a.old_end_date, a.new_end_date, a.new_total_days,
+		       a.old_rental, a.new_rental, a.old_deposit, a.new_deposit, a.old_total, a.new_total
+		FROM booking_amendment a
+		JOIN booking b ON b.id = a.booking_id
+		WHERE a.id = $1 AND b.hoster_id = $2
+		FOR UPDATE OF a
+	`, amendmentID, hosterID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("ApproveAmendment: select amendment error amendment=%s err=%v", amendmentID, err)
+		}
+		return "", err
+	}
+	if a.Status != domain.AmendmentStatusPending {
+		return "", errors.New("decided")
+	}
+
+	err = tx.Select(&a.Items, `
+		SELECT ai.booking_item_id, bi.item_id, bi.variant_id, ai.old_quantity, ai.new_quantity,
+		       ai.new_subtotal_rental, ai.new_subtotal_deposit
+		FROM booking_amendment_item ai
+		JOIN booking_item bi ON bi.id = ai.booking_item_id
+		WHERE ai.amendment_id = $1
+	`, amendmentID)
+	if err != nil {
+		log.Printf("ApproveAmendment: select items error amendment=%s err=%v", amendmentID, err)
+		return "", err
+	}
+
+	startDate, err := lockAmendableBooking(tx, &a)
+	if err != nil {
+		return "", err
+	}
+
+	available, err := checkAvailability(tx, &a, startDate)
+	if err != nil {
+		return "", err
+	}
+	if !available {
+		return "", errors.New("unavailable")
+	}
+
+	_, err = tx.Exec(`
+		UPDATE booking
+		SET end_date = $2, total_days = $3, rental = $4, deposit = $5, total = $6,
+		    outstanding = GREATEST(outstanding + $6 - $7, 0),
+		    overdue_at = CASE WHEN $2::date + COALESCE(return_time, TIME '24:00') > NOW() THEN NULL ELSE overdue_at END,
+		    updated_at = NOW()
+		WHERE id = $1
+	`, a.BookingID, a.NewEndDate, a.NewTotalDays, a.NewRental, a.NewDeposit, a.NewTotal, a.OldTotal)
+	if err != nil {
+		log.Printf("ApproveAmendment: update booking error booking=%s err=%v", a.BookingID, err)
+		return "", err
+	}
+
+	for _, item := range a.Items {
+		_, err := tx.Exec(`
+			UPDATE booking_item
+			SET quantity = $2, subtotal_rental = $3, subtotal_deposit = $4, updated_at = NOW()
+			WHERE id = $1
+		`, item.BookingItemID, item.NewQuantity, item.NewSubtotalRental, item.NewSubtotalDeposit)
+		if err != nil {
+			log.Printf("ApproveAmendment: update booking_item error item=%s err=%v", item.BookingItemID, err)
+			return "", err
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE booking_amendment
+		SET status = 'approved', hoster_note = $2, decided_by_member_id = $3, decided_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, amendmentID, note, memberID)
+	if err != nil {
+		log.Printf("ApproveAmendment: update amendment error amendment=%s err=%v", amendmentID, err)
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ApproveAmendment: error committing transaction: %v", err)
+		return "", err
+	}
+	return a.BookingID, nil
+}
+
+/*
+RejectAmendment menolak permintaan perubahan yang masih pending. Booking tidak berubah.
+
+Output sukses:
+- (bookingID, nil)
+Output error:
+- ("", sql.ErrNoRows)         → permintaan tidak ada / bukan milik hoster
+- ("", errors.New("decided")) → sudah diputuskan / dibatalkan
+- ("", error)                 → query gagal
+*/
+func (r *amendmentRepository) RejectAmendment(hosterID, amendmentID string, memberID, note *string) (string, error) {
+	var result struct {
+		BookingID string `db:"booking_id"`
+		Status    string `db:"status"`
+	}
+	err := r.db.Get(&result, `
+		WITH target AS (
+			SELECT a.id, a.booking_id, a.status
+			FROM booking_amendment a
+			JOIN booking b ON b.id = a.booking_id
+			WHERE a.id = $1 AND b.hoster_id = $2
+		), updated AS (
+			UPDATE booking_amendment a
+			SET status = 'rejected', hoster_note = $3, decided_by_member_id = $4, decided_at = NOW(), updated_at = NOW()
+			FROM target
+			WHERE a.id = target.id AND target.status = 'pending'
+			RETURNING a.status
+		)
+		SELECT target.booking_id, COALESCE((SELECT status FROM updated), target.status) AS status
+		FROM target
+	`, amendmentID, hosterID, note, memberID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("RejectAmendment: update error amendment=%s err=%v", amendmentID, err)
+		}
+		return "", err
+	}
+	if err := decisionResult(result.Status, domain.AmendmentStatusRejected); err != nil {
+		return "", err
+	}
+	return result.BookingID, nil
+}
+
+/*
+attachItems mengisi item setiap permintaan perubahan dalam satu query.
+*/
+func (r *amendmentRepository) attachItems(list []dto.BookingAmendmentResponse) error {
+	if len(list) == 0 {
+		return nil
+	}
+	ids := make([]string, len(list))
+	index := make(map[string]int, len(list))
+	for i := range list {
+		ids[i] = list[i].ID
+		index[list[i].ID] = i
+		list[i].TotalDifference = list[i].NewTotal - list[i].OldTotal
+		list[i].Items = []dto.BookingAmendmentItemResponse{}
+	}
+
+	var rows []struct {
+		AmendmentID string `db:"amendment_id"`
+		dto.BookingAmendmentItemResponse
+	}
+	err := r.db.Select(&rows, `
+		SELECT ai.amendment_id, ai.booking_item_id, bi.name AS item_name,
+		       ai.old_quantity, ai.new_quantity, ai.price_per_day, ai.deposit_per_unit,
+		       ai.old_subtotal_rental, ai.new_subtotal_rental, ai.old_subtotal_deposit, ai.new_subtotal_deposit
+		FROM booking_amendment_item ai
+		JOIN booking_item bi ON bi.id = ai.booking_item_id
+		WHERE ai.amendment_id = ANY($1::uuid[])
+		ORDER BY bi.created_at, bi.id
+	`, pq.Array(ids))
+	if err != nil {
+		log.Printf("attachItems: select error err=%v", err)
+		return err
+	}
+	for _, row := range rows {
+		i := index[row.AmendmentID]
+		list[i].Items = append(list[i].Items, row.BookingAmendmentItemResponse)
+	}
+	return nil
+}
+
+/*
+lockAmendableBooking mengunci booking dan memastikan masih sama dengan nilai lama permintaan.
+Perubahan quantity hanya boleh sebelum barang diambil (on_progress).
+
+Output sukses:
+- (start_date booking, nil)
+Output error:
+- errors.New("invalid status") / errors.New("stale") / error query
+*/
+func lockAmendableBooking(tx *sqlx.Tx, a *domain.BookingAmendment) (time.Time, error) {
+	var booking struct {
+		Status    string    `db:"status"`
+		StartDate time.Time `db:"start_date"`
+		EndDate   time.Time `db:"end_date"`
+		Rental    int       `db:"rental"`
+		Deposit   int       `db:"deposit"`
+		Total     int       `db:"total"`
+	}
+	err := tx.Get(&booking, `
+		SELECT status, start_date, end_date, rental, deposit, total
+		FROM booking
+		WHERE id = $1
+		FOR UPDATE
+	`, a.BookingID)
+	if err != nil {
+		log.Printf("lockAmendableBooking: select booking error booking=%s err=%v", a.BookingID, err)
+		return time.Time{}, err
+	}
+
+	quantityChanged := false
+	for _, item := range a.Items {
+		if item.NewQuantity != item.OldQuantity {
+			quantityChanged = true
+		}
+	}
+	switch {
+	case booking.Status == "on_progress":
+	case booking.Status == "on_rent" && !quantityChanged:
+	default:
+		return time.Time{}, errors.New("invalid status")
+	}
+
+	if !booking.EndDate.Equal(a.OldEndDate) || booking.Rental != a.OldRental ||
+		booking.Deposit != a.OldDeposit || booking.Total != a.OldTotal {
+		return time.Time{}, errors.New("stale")
+	}
+
+	var mismatched int
+	for _, item := range a.Items {
+		var quantity int
+		if err := tx.Get(&quantity, `SELECT quantity FROM booking_item WHERE id = $1 AND booking_id = $2`, item.BookingItemID, a.BookingID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return time.Time{}, errors.New("stale")
+			}
+			log.Printf("lockAmendableBooking: select booking_item error item=%s err=%v", item.BookingItemID, err)
+			return time.Time{}, err
+		}
+		if quantity != item.OldQuantity {
+			mismatched++
+		}
+	}
+	if mismatched > 0 {
+		return time.Time{}, errors.New("stale")
+	}
+	return booking.StartDate, nil
+}
+
+/*
+checkAvailability memastikan stock item cukup untuk tambahan hari / unit.
+
+Alur kerja:
+1. Hanya item yang quantity-nya bertambah atau booking-nya diperpanjang yang dicek
+  - quantity bertambah → rentang dicek dari start_date sampai end_date baru
+  - hanya diperpanjang → rentang dicek dari end_date lama sampai end_date baru
+
+2. Pemakaian = jumlah quantity booking lain yang aktif (on_progress, on_rent, pending yang masih di-lock)
+dan beririsan dengan rentang tersebut; on_rent yang terlambat tetap dihitung sampai barang kembali
+3. Tersedia jika stock - pemakaian >= quantity baru (perkiraan konservatif: semua booking yang beririsan dijumlahkan)
+4. Item bervarian dicek per varian: stock varian dan pemakaian varian yang sama
+
+Output:
+- (true, nil) jika semua item tersedia
+- (false, nil) jika ada item yang kurang
+- (false, error) jika query gagal
+*/
+func checkAvailability(tx *sqlx.Tx, a *domain.BookingAmendment, startDate time.Time) (bool, error) {
+	extended := a.NewEndDate.After(a.OldEndDate)
+
+	var itemIDs, variantIDs []string
+	var quantities []int64
+	var fromDates []time.Time
+	for _, item := range a.Items {
+		switch {
+		case item.NewQuantity > item.OldQuantity:
+			fromDates = append(fromDates, startDate)
+		case extended:
+			fromDates = append(fromDates, a.OldEndDate)
+		default:
+			continue
+		}
+		itemIDs = append(itemIDs, item.ItemID)
+		variantID := ""
+		if item.VariantID != nil {
+			variantID = *item.VariantID
+		}
+		variantIDs = append(variantIDs, variantID)
+		quantities = append(quantities, int64(item.NewQuantity))
+	}
+	if len(itemIDs) == 0 {
+		return true, nil
+	}
+
+	var short int
+	err := tx.Get(&short, `
+		WITH req AS (
+			SELECT r.item_id, NULLIF(r.variant_id, '')::uuid AS variant_id, SUM(r.qty) AS qty, MIN(r.from_date) AS from_date
+			FROM unnest($2::uuid[], $6::text[], $3::int[], $4::timestamptz[]) AS r(item_id, variant_id, qty, from_date)
+			GROUP BY r.item_id, r.variant_id
+		)
+		SELECT COUNT(*)
+		FROM req
+		JOIN item i ON i.id = req.item_id
+		LEFT JOIN item_variant v ON v.id = req.variant_id
+		WHERE req.qty > COALESCE(v.stock, i.stock) - COALESCE((
+			SELECT SUM(obi.quantity)
+			FROM booking ob
+			JOIN booking_item obi ON obi.booking_id = ob.id
+			WHERE obi.item_id = req.item_id
+			  AND (req.variant_id IS NULL OR obi.variant_id = req.variant_id)
+			  AND ob.id <> $1
+			  AND (ob.status IN ('on_progress', 'on_rent') OR (ob.status = 'pending' AND ob.locked_until > NOW()))
+			  AND ob.start_date <= $5
+			  AND (ob.end_date >= req.from_date OR ob.status = 'on_rent')
+		), 0)
+	`, a.BookingID, pq.Array(itemIDs), pq.Array(quantities), pq.Array(fromDates), a.NewEndDate, pq.Array(variantIDs))
+	if err != nil {
+		log.Printf("checkAvailability: query error booking=%s err=%v", a.BookingID, err)
+		return false, err
+	}
+	return short == 0, nil
+}
+
+/*
+decisionResult memetakan status akhir permintaan setelah update bersyarat.
+Status sama dengan target → berhasil, status lain → sudah diputuskan sebelumnya, kosong → tidak ditemukan.
+*/
+func decisionResult(status, target string) error {
+	switch status {
+	case target:
+		return nil
+	case "":
+		return sql.ErrNoRows
+	default:
+		return errors.New("decided")
+	}
+}
@@ -0,0 +1,45 @@
+package amendment
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupAmendmentRoutes mendaftarkan endpoint persetujuan perubahan booking (perpanjangan & quantity) untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET  /amendment?status=pending  → daftar permintaan perubahan semua booking
+  - GET  /booking/{id}/amendment    → riwayat permintaan perubahan satu booking
+  - POST /amendment/{id}/approve    → setujui & terapkan ke booking
+  - POST /amendment/{id}/reject     → tolak
+
+4. GET butuh permission bookings:view, keputusan butuh bookings:update (role toko)
+
+Output:
+- Router terkonfigurasi dengan endpoint perubahan booking hoster
+*/
+func SetupAmendmentRoutes(router *mux.Router, h *HosterAmendmentHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	protected.HandleFunc("/amendment", middleware.HosterPermission(domain.HosterPermBookingsView, h.ListAmendments)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/booking/{id}/amendment", middleware.HosterPermission(domain.HosterPermBookingsView, h.GetBookingAmendments)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/amendment/{id}/approve", middleware.HosterPermission(domain.HosterPermBookingsUpdate, h.ApproveAmendment)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/amendment/{id}/reject", middleware.HosterPermission(domain.HosterPermBookingsUpdate, h.RejectAmendment)).Methods("POST", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,223 @@
+package amendment
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+// MaxAmendmentNoteLength adalah panjang maksimal catatan hoster saat memutuskan perubahan booking.
+const MaxAmendmentNoteLength = 1000
+
+/*
+AmendmentService adalah kontrak logika bisnis persetujuan perubahan booking oleh hoster.
+*/
+type AmendmentService interface {
+	ListAmendments(hosterID, status string) ([]dto.BookingAmendmentResponse, error)
+	GetBookingAmendments(hosterID, bookingID string) ([]dto.BookingAmendmentResponse, error)
+	ApproveAmendment(hosterID, memberID, amendmentID string, req dto.DecideBookingAmendmentByHosterRequest) (*dto.BookingAmendmentResponse, error)
+	RejectAmendment(hosterID, memberID, amendmentID string, req dto.DecideBookingAmendmentByHosterRequest) (*dto.BookingAmendmentResponse, error)
+}
+
+/*
+AmendmentLedgerPoster adalah kontrak posting ledger booking (diimplementasikan repository ledger hoster).
+*/
+type AmendmentLedgerPoster interface {
+	PostBookingLedger(bookingID string, commissionRateBps int) (int, error)
+}
+
+/*
+amendmentService adalah implementasi service perubahan booking.
+*/
+type amendmentService struct {
+	repo   AmendmentRepository
+	ledger AmendmentLedgerPoster
+}
+
+/*
+NewAmendmentService membuat instance service dengan dependency injection.
+
+Output:
+- AmendmentService siap digunakan
+*/
+func NewAmendmentService(repo AmendmentRepository, ledger AmendmentLedgerPoster) AmendmentService {
+	return &amendmentService{repo: repo, ledger: ledger}
+}
+
+/*
+ListAmendments mengambil permintaan perubahan di semua booking hoster.
+
+Parameter:
+- status: pending / approved / rejected / cancelled (kosong = semua)
+
+Output sukses:
+- ([]dto.BookingAmendmentResponse, nil)
+Output error:
+- (nil, error) → unauthorized / filter invalid / internal error
+*/
+func (s *amendmentService) ListAmendments(hosterID, status string) ([]dto.BookingAmendmentResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	switch status {
+	case "", domain.AmendmentStatusPending, domain.AmendmentStatusApproved, domain.AmendmentStatusRejected, domain.AmendmentStatusCancelled:
+	default:
+		return nil, errors.New(message.AmendmentInvalidFilter)
+	}
+
+	list, err := s.repo.ListAmendments(hosterID, status)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return list, nil
+}
+
+/*
+GetBookingAmendments mengambil riwayat permintaan perubahan satu booking milik hoster.
+
+Output sukses:
+- ([]dto.BookingAmendmentResponse, nil)
+Output error:
+- (nil, error) → unauthorized / booking tidak ditemukan / internal error
+*/
+func (s *amendmentService) GetBookingAmendments(hosterID, bookingID string) ([]dto.BookingAmendmentResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(bookingID); err != nil {
+		return nil, fmt.Errorf(message.NotFound, "booking")
+	}
+
+	owned, err := s.repo.BookingBelongsToHoster(hosterID, bookingID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if !owned {
+		return nil, fmt.Errorf(message.NotFound, "booking")
+	}
+
+	list, err := s.repo.GetAmendments(bookingID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return list, nil
+}
+
+/*
+ApproveAmendment menyetujui permintaan perubahan dan menerapkan total baru ke booking.
+
+Alur kerja:
+1. Validasi catatan hoster (opsional)
+2. Terapkan perubahan via repository (cek status, perubahan booking, dan stock dalam satu transaksi)
+3. Posting selisih sewa & deposit ke ledger (gagal posting hanya di-log, disusulkan settlement)
+
+Output sukses:
+- (*dto.BookingAmendmentResponse, nil) → status approved
+Output error:
+- (nil, error) → unauthorized / not found / sudah diputuskan / booking berubah / stock tidak cukup / internal error
+*/
+func (s *amendmentService) ApproveAmendment(hosterID, memberID, amendmentID string, req dto.DecideBookingAmendmentByHosterRequest) (*dto.BookingAmendmentResponse, error) {
+	note, err := s.validateDecision(hosterID, amendmentID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingID, err := s.repo.ApproveAmendment(hosterID, amendmentID, optional(memberID), note)
+	if err != nil {
+		return nil, decisionError(err)
+	}
+	log.Printf("ApproveAmendment: hoster %s approved amendment %s for booking %s", hosterID, amendmentID, bookingID)
+
+	if _, err := s.ledger.PostBookingLedger(bookingID, config.GetPlatformCommissionBps()); err != nil {
+		log.Printf("ApproveAmendment: ledger posting failed booking=%s amendment=%s: %v", bookingID, amendmentID, err)
+	}
+	return s.getAmendment(amendmentID)
+}
+
+/*
+RejectAmendment menolak permintaan perubahan, booking tidak berubah.
+
+Output sukses:
+- (*dto.BookingAmendmentResponse, nil) → status rejected
+Output error:
+- (nil, error) → unauthorized / not found / sudah diputuskan / internal error
+*/
+func (s *amendmentService) RejectAmendment(hosterID, memberID, amendmentID string, req dto.DecideBookingAmendmentByHosterRequest) (*dto.BookingAmendmentResponse, error) {
+	note, err := s.validateDecision(hosterID, amendmentID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingID, err := s.repo.RejectAmendment(hosterID, amendmentID, optional(memberID), note)
+	if err != nil {
+		return nil, decisionError(err)
+	}
+	log.Printf("RejectAmendment: hoster %s rejected amendment %s for booking %s", hosterID, amendmentID, bookingID)
+	return s.getAmendment(amendmentID)
+}
+
+/*
+validateDecision memvalidasi hoster, ID permintaan, dan catatan keputusan.
+*/
+func (s *amendmentService) validateDecision(hosterID, amendmentID string, req dto.DecideBookingAmendmentByHosterRequest) (*string, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(amendmentID); err != nil {
+		return nil, errors.New(message.AmendmentNotFound)
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(note) > MaxAmendmentNoteLength {
+		return nil, fmt.Errorf(message.TooLong, "note")
+	}
+	return optional(note), nil
+}
+
+/*
+getAmendment mengambil permintaan perubahan terbaru untuk response.
+*/
+func (s *amendmentService) getAmendment(amendmentID string) (*dto.BookingAmendmentResponse, error) {
+	result, err := s.repo.GetAmendment(amendmentID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return result, nil
+}
+
+/*
+decisionError memetakan error repository saat memutuskan permintaan perubahan.
+*/
+func decisionError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return errors.New(message.AmendmentNotFound)
+	case err.Error() == "decided":
+		return errors.New(message.AmendmentAlreadyDecided)
+	case err.Error() == "invalid status":
+		return errors.New(message.AmendmentInvalidStatus)
+	case err.Error() == "stale":
+		return errors.New(message.AmendmentStale)
+	case err.Error() == "unavailable":
+		return errors.New(message.AmendmentUnavailable)
+	}
+	return errors.New(message.InternalError)
+}
+
+/*
+optional mengubah string kosong menjadi nil untuk kolom nullable.
+*/
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
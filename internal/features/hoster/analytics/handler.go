@@ -0,0 +1,119 @@
+package analytics
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterAnalyticsHandler menangani endpoint HTTP dashboard analytics hoster.
+*/
+type HosterAnalyticsHandler struct {
+	service HosterAnalyticsService
+}
+
+/*
+NewHosterAnalyticsHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterAnalyticsHandler siap digunakan
+*/
+func NewHosterAnalyticsHandler(s HosterAnalyticsService) *HosterAnalyticsHandler {
+	return &HosterAnalyticsHandler{service: s}
+}
+
+/*
+GetSummary menangani GET /api/v1/hoster/analytics/summary?from=YYYY-MM-DD&to=YYYY-MM-DD
+
+Output sukses:
+- 200 OK + ringkasan revenue, deposit, pembatalan, repeat customer, utilisasi
+Output error:
+- 400 Bad Request → periode tidak valid
+- 401 Unauthorized
+- 500 Internal Server Error
+*/
+func (h *HosterAnalyticsHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.service.GetSummary(middleware.GetUserID(r), parseFilter(r))
+	if err != nil {
+		log.Printf("GetSummary handler: service error: %v", err)
+		writeAnalyticsError(w, err)
+		return
+	}
+	response.OK(w, summary, message.AnalyticsRetrieved)
+}
+
+/*
+GetRevenue menangani GET /api/v1/hoster/analytics/revenue?from=&to=&granularity=day|week|month
+
+Output sukses:
+- 200 OK + time series revenue per bucket
+Output error:
+- 400 Bad Request → periode / granularity tidak valid
+- 401 Unauthorized
+- 500 Internal Server Error
+*/
+func (h *HosterAnalyticsHandler) GetRevenue(w http.ResponseWriter, r *http.Request) {
+	revenue, err := h.service.GetRevenue(middleware.GetUserID(r), parseFilter(r))
+	if err != nil {
+		log.Printf("GetRevenue handler: service error: %v", err)
+		writeAnalyticsError(w, err)
+		return
+	}
+	response.OK(w, revenue, message.AnalyticsRetrieved)
+}
+
+/*
+GetTopItems menangani GET /api/v1/hoster/analytics/items?from=&to=&sort=revenue|utilisation&limit=10
+
+Output sukses:
+- 200 OK + daftar item dengan revenue / utilisasi tertinggi
+Output error:
+- 400 Bad Request → periode / sort tidak valid
+- 401 Unauthorized
+- 500 Internal Server Error
+*/
+func (h *HosterAnalyticsHandler) GetTopItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.service.GetTopItems(middleware.GetUserID(r), parseFilter(r))
+	if err != nil {
+		log.Printf("GetTopItems handler: service error: %v", err)
+		writeAnalyticsError(w, err)
+		return
+	}
+	response.OK(w, items, message.AnalyticsRetrieved)
+}
+
+/*
+parseFilter membaca filter analytics dari query string.
+*/
+func parseFilter(r *http.Request) dto.AnalyticsFilterByHosterRequest {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	return dto.AnalyticsFilterByHosterRequest{
+		From:        query.Get("from"),
+		To:          query.Get("to"),
+		StoreID:     query.Get("store_id"),
+		Granularity: query.Get("granularity"),
+		Sort:        query.Get("sort"),
+		Limit:       limit,
+	}
+}
+
+/*
+writeAnalyticsError memetakan error service analytics ke HTTP response.
+*/
+func writeAnalyticsError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.AnalyticsInvalidPeriod, message.AnalyticsInvalidGranularity, message.AnalyticsInvalidSort, message.StoreInvalidID:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,241 @@
+package analytics
+
+import (
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/dto"
+)
+
+/*
+HosterAnalyticsRepository mendefinisikan query agregasi booking untuk dashboard analytics hoster.
+*/
+type HosterAnalyticsRepository interface {
+	GetSummary(q AnalyticsQuery) (*dto.AnalyticsSummaryByHosterResponse, error)
+	GetRevenueSeries(q AnalyticsQuery, granularity string) ([]dto.AnalyticsRevenuePointByHosterResponse, error)
+	GetItemPerformance(q AnalyticsQuery, orderBy string, limit *int) ([]dto.AnalyticsItemByHosterResponse, error)
+}
+
+/*
+hosterAnalyticsRepository adalah implementasi repository analytics hoster.
+*/
+type hosterAnalyticsRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewHosterAnalyticsRepository membuat instance repository dengan koneksi database.
+
+Output:
+- HosterAnalyticsRepository siap digunakan
+*/
+func NewHosterAnalyticsRepository(db *sqlx.DB) HosterAnalyticsRepository {
+	return &hosterAnalyticsRepository{db: db}
+}
+
+/*
+AnalyticsQuery adalah periode analytics yang sudah divalidasi service.
+From inklusif, To eksklusif, Days = jumlah hari periode (untuk stock-days).
+*/
+type AnalyticsQuery struct {
+	HosterID string
+	StoreID  string // Kosong = semua store hoster
+	From     time.Time
+	To       time.Time
+	Days     int
+}
+
+/*
+Status booking yang dipakai agregasi.
+- effective: booking yang benar-benar terjadi (sudah bayar dan tidak dibatalkan)
+- held: booking yang deposit-nya masih ditahan hoster (barang belum kembali)
+- cancelled: dibatalkan customer/hoster atau ditolak hoster
+*/
+const (
+	effectiveStatuses = `('on_progress', 'on_rent', 'completed')`
+	heldStatuses      = `('on_progress', 'on_rent')`
+	cancelledStatuses = `('cancelled', 'rejected')`
+)
+
+/*
+Kolom urutan yang diizinkan untuk GetItemPerformance (dipilih service, bukan input user langsung).
+*/
+const (
+	OrderByRevenue     = "gross_rental DESC, booked_unit_days DESC, i.name ASC"
+	OrderByUtilisation = "utilisation DESC, gross_rental DESC, i.name ASC"
+)
+
+/*
+GetSummary menghitung ringkasan revenue, pembatalan, dan repeat customer toko dalam periode.
+
+Alur kerja:
+1. Ambil booking toko (opsional satu store) yang start_date-nya di dalam periode (index booking(hoster_id, start_date))
+2. Agregasi dengan FILTER per kelompok status dalam satu kali scan
+3. Repeat customer: customer periode ini yang punya ≥ 2 booking efektif di toko ini sampai akhir periode
+
+Output sukses:
+- (*dto.AnalyticsSummaryByHosterResponse, nil) → field turunan (rate, utilisasi) dihitung service
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterAnalyticsRepository) GetSummary(q AnalyticsQuery) (*dto.AnalyticsSummaryByHosterResponse, error) {
+	query := `
+		WITH period_booking AS (
+			SELECT id, user_id, status, locked_until, rental, deposit, discount
+			FROM booking
+			WHERE hoster_id = $1 AND start_date >= $2 AND start_date < $3
+			  AND ($4 = '' OR tenant_id::text = $4)
+		),
+		period_customer AS (
+			SELECT DISTINCT user_id FROM period_booking WHERE status IN ` + effectiveStatuses + `
+		)
+		SELECT
+			COALESCE(SUM(rental) FILTER (WHERE status IN ` + effectiveStatuses + `), 0) AS gross_rental,
+			COALESCE(SUM(discount) FILTER (WHERE status IN ` + effectiveStatuses + `), 0) AS discounts,
+			COALESCE(SUM(deposit) FILTER (WHERE status IN ` + effectiveStatuses + `), 0) AS deposits_collected,
+			COALESCE(SUM(deposit) FILTER (WHERE status IN ` + heldStatuses + `), 0) AS deposits_held,
+			COUNT(*) FILTER (WHERE status IN ` + effectiveStatuses + `) AS bookings,
+			COUNT(*) FILTER (WHERE status IN ` + cancelledStatuses + `) AS cancellations,
+			COUNT(*) FILTER (WHERE status = 'pending' AND locked_until <= NOW()) AS expired,
+			(SELECT COUNT(*) FROM period_customer) AS unique_customers,
+			(
+				SELECT COUNT(*) FROM (
+					SELECT b.user_id
+					FROM booking b
+					JOIN period_customer pc ON pc.user_id = b.user_id
+					WHERE b.hoster_id = $1 AND b.start_date < $3 AND b.status IN ` + effectiveStatuses + `
+					  AND ($4 = '' OR b.tenant_id::text = $4)
+					GROUP BY b.user_id
+					HAVING COUNT(*) >= 2
+				) repeat_customer
+			) AS repeat_customers
+		FROM period_booking
+	`
+
+	var summary dto.AnalyticsSummaryByHosterResponse
+	if err := r.db.Get(&summary, query, q.HosterID, q.From, q.To, q.StoreID); err != nil {
+		log.Printf("GetSummary: query error hoster=%s: %v", q.HosterID, err)
+		return nil, err
+	}
+	return &summary, nil
+}
+
+/*
+GetRevenueSeries menghitung revenue per bucket waktu (day/week/month) dalam periode.
+
+Alur kerja:
+1. generate_series membuat semua bucket periode agar bucket kosong tetap muncul (nilai 0)
+2. Booking dikelompokkan ke bucket berdasarkan date_trunc(granularity, start_date)
+3. LEFT JOIN bucket ↔ agregasi, urut dari bucket terlama
+
+Output sukses:
+- ([]dto.AnalyticsRevenuePointByHosterResponse, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterAnalyticsRepository) GetRevenueSeries(q AnalyticsQuery, granularity string) ([]dto.AnalyticsRevenuePointByHosterResponse, error) {
+	query := `
+		WITH bucket AS (
+			SELECT generate_series(
+				date_trunc($4::text, $2::timestamptz),
+				$3::timestamptz - INTERVAL '1 second',
+				('1 ' || $4::text)::interval
+			) AS period_start
+		),
+		agg AS (
+			SELECT
+				date_trunc($4::text, start_date) AS period_start,
+				SUM(rental) FILTER (WHERE status IN ` + effectiveStatuses + `) AS gross_rental,
+				SUM(discount) FILTER (WHERE status IN ` + effectiveStatuses + `) AS discounts,
+				SUM(deposit) FILTER (WHERE status IN ` + effectiveStatuses + `) AS deposits_collected,
+				COUNT(*) FILTER (WHERE status IN ` + effectiveStatuses + `) AS bookings,
+				COUNT(*) FILTER (WHERE status IN ` + cancelledStatuses + `) AS cancellations
+			FROM booking
+			WHERE hoster_id = $1 AND start_date >= $2 AND start_date < $3
+			  AND ($5 = '' OR tenant_id::text = $5)
+			GROUP BY 1
+		)
+		SELECT
+			bucket.period_start,
+			COALESCE(agg.gross_rental, 0) AS gross_rental,
+			COALESCE(agg.discounts, 0) AS discounts,
+			COALESCE(agg.deposits_collected, 0) AS deposits_collected,
+			COALESCE(agg.bookings, 0) AS bookings,
+			COALESCE(agg.cancellations, 0) AS cancellations
+		FROM bucket
+		LEFT JOIN agg ON agg.period_start = bucket.period_start
+		ORDER BY bucket.period_start ASC
+	`
+
+	points := []dto.AnalyticsRevenuePointByHosterResponse{}
+	if err := r.db.Select(&points, query, q.HosterID, q.From, q.To, granularity, q.StoreID); err != nil {
+		log.Printf("GetRevenueSeries: query error hoster=%s granularity=%s: %v", q.HosterID, granularity, err)
+		return nil, err
+	}
+	return points, nil
+}
+
+/*
+GetItemPerformance menghitung revenue dan utilisasi per item milik hoster (opsional satu store) dalam periode.
+
+Alur kerja:
+1. Ambil booking efektif yang beririsan dengan periode (start_date < to AND end_date > from)
+2. booked_unit_days = quantity × jumlah hari irisan [start_date, end_date) dengan periode
+3. Revenue, jumlah booking, dan unit disewa hanya dari booking yang mulai di dalam periode
+4. stock_days = stock × jumlah hari periode, utilisation = booked_unit_days / stock_days
+5. Semua item ikut (LEFT JOIN) agar item yang tidak pernah disewa terlihat dengan utilisasi 0
+
+Output sukses:
+- ([]dto.AnalyticsItemByHosterResponse, nil) → limit nil = semua item
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterAnalyticsRepository) GetItemPerformance(q AnalyticsQuery, orderBy string, limit *int) ([]dto.AnalyticsItemByHosterResponse, error) {
+	query := `
+		WITH overlap AS (
+			SELECT
+				bi.item_id,
+				b.id AS booking_id,
+				bi.quantity,
+				bi.subtotal_rental,
+				b.start_date >= $2 AS starts_in_period,
+				bi.quantity * ROUND(
+					EXTRACT(EPOCH FROM (LEAST(b.end_date, $3) - GREATEST(b.start_date, $2))) / 86400
+				)::bigint AS unit_days
+			FROM booking b
+			JOIN booking_item bi ON bi.booking_id = b.id
+			WHERE b.hoster_id = $1
+			  AND b.status IN ` + effectiveStatuses + `
+			  AND b.start_date < $3 AND b.end_date > $2
+			  AND ($6 = '' OR b.tenant_id::text = $6)
+		)
+		SELECT
+			i.id AS item_id,
+			i.name,
+			i.stock,
+			COUNT(DISTINCT o.booking_id) FILTER (WHERE o.starts_in_period) AS bookings,
+			COALESCE(SUM(o.quantity) FILTER (WHERE o.starts_in_period), 0) AS units_rented,
+			COALESCE(SUM(o.subtotal_rental) FILTER (WHERE o.starts_in_period), 0) AS gross_rental,
+			COALESCE(SUM(o.unit_days), 0) AS booked_unit_days,
+			i.stock::bigint * $4::int AS stock_days,
+			CASE WHEN i.stock > 0 THEN
+				ROUND(COALESCE(SUM(o.unit_days), 0)::numeric / (i.stock::bigint * $4::int), 4)::float8
+			ELSE 0 END AS utilisation
+		FROM item i
+		LEFT JOIN overlap o ON o.item_id = i.id
+		WHERE i.hoster_id = $1
+		  AND ($6 = '' OR i.tenant_id::text = $6)
+		GROUP BY i.id, i.name, i.stock
+		ORDER BY ` + orderBy + `
+		LIMIT $5
+	`
+
+	items := []dto.AnalyticsItemByHosterResponse{}
+	if err := r.db.Select(&items, query, q.HosterID, q.From, q.To, q.Days, limit, q.StoreID); err != nil {
+		log.Printf("GetItemPerformance: query error hoster=%s: %v", q.HosterID, err)
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,42 @@
+package analytics
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupAnalyticsRoutes mendaftarkan endpoint dashboard analytics untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster/analytics
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET /summary → ringkasan revenue, deposit, pembatalan, repeat customer, utilisasi
+  - GET /revenue → time series revenue per hari/minggu/bulan
+  - GET /items   → item dengan revenue / utilisasi tertinggi
+
+Output:
+- Router terkonfigurasi dengan endpoint hoster yang aman dan siap digunakan
+*/
+func SetupAnalyticsRoutes(router *mux.Router, h *HosterAnalyticsHandler) {
+	protected := router.PathPrefix("/api/v1/hoster/analytics").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+	protected.Use(middleware.RequireHosterPermission(domain.HosterPermRevenueView))
+
+	protected.HandleFunc("/summary", h.GetSummary).Methods("GET")
+	protected.HandleFunc("/revenue", h.GetRevenue).Methods("GET")
+	protected.HandleFunc("/items", h.GetTopItems).Methods("GET")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,245 @@
+package analytics
+
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+HosterAnalyticsService adalah kontrak untuk logika bisnis dashboard analytics hoster.
+*/
+type HosterAnalyticsService interface {
+	GetSummary(hosterID string, filter dto.AnalyticsFilterByHosterRequest) (*dto.AnalyticsSummaryByHosterResponse, error)
+	GetRevenue(hosterID string, filter dto.AnalyticsFilterByHosterRequest) (*dto.AnalyticsRevenueByHosterResponse, error)
+	GetTopItems(hosterID string, filter dto.AnalyticsFilterByHosterRequest) (*dto.AnalyticsItemsByHosterResponse, error)
+}
+
+/*
+hosterAnalyticsService adalah implementasi service analytics hoster.
+*/
+type hosterAnalyticsService struct {
+	repo HosterAnalyticsRepository
+}
+
+/*
+NewHosterAnalyticsService membuat instance service dengan dependency injection.
+
+Output:
+- HosterAnalyticsService siap digunakan
+*/
+func NewHosterAnalyticsService(repo HosterAnalyticsRepository) HosterAnalyticsService {
+	return &hosterAnalyticsService{repo: repo}
+}
+
+/*
+Konstanta periode dan pagination analytics.
+*/
+const (
+	DefaultPeriodDays = 30
+	MaxPeriodDays     = 366
+	MaxDailyPoints    = 92 // granularity=day dibatasi ±3 bulan agar response tetap kecil
+	DefaultItemLimit  = 10
+	MaxItemLimit      = 100
+)
+
+/*
+GetSummary mengambil ringkasan kinerja toko dalam periode.
+
+Alur kerja:
+1. Validasi periode (default 30 hari terakhir, maks 366 hari)
+2. Ambil agregasi revenue, pembatalan, customer dari repository
+3. Ambil utilisasi seluruh item lalu jumlahkan booked_unit_days dan stock_days
+4. Hitung field turunan: net_rental, cancellation_rate, repeat_customer_rate, utilisation
+
+Output sukses:
+- (*dto.AnalyticsSummaryByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / AnalyticsInvalidPeriod / internal error
+*/
+func (s *hosterAnalyticsService) GetSummary(hosterID string, filter dto.AnalyticsFilterByHosterRequest) (*dto.AnalyticsSummaryByHosterResponse, error) {
+	q, err := parsePeriod(hosterID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.repo.GetSummary(q)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	items, err := s.repo.GetItemPerformance(q, OrderByRevenue, nil)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	for _, item := range items {
+		summary.BookedUnitDays += item.BookedUnitDays
+		summary.StockDays += item.StockDays
+	}
+
+	summary.Period = toPeriodResponse(q)
+	summary.NetRental = summary.GrossRental - summary.Discounts
+	summary.CancellationRate = ratio(int64(summary.Cancellations+summary.Expired), int64(summary.Bookings+summary.Cancellations+summary.Expired))
+	summary.RepeatCustomerRate = ratio(int64(summary.RepeatCustomers), int64(summary.UniqueCustomers))
+	summary.Utilisation = ratio(summary.BookedUnitDays, summary.StockDays)
+
+	return summary, nil
+}
+
+/*
+GetRevenue mengambil time series revenue per hari/minggu/bulan.
+
+Alur kerja:
+1. Validasi periode dan granularity (default month)
+2. granularity=day dibatasi maksimal 92 hari
+3. Ambil time series dari repository (bucket kosong bernilai 0)
+
+Output sukses:
+- (*dto.AnalyticsRevenueByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / AnalyticsInvalidPeriod / AnalyticsInvalidGranularity / internal error
+*/
+func (s *hosterAnalyticsService) GetRevenue(hosterID string, filter dto.AnalyticsFilterByHosterRequest) (*dto.AnalyticsRevenueByHosterResponse, error) {
+	q, err := parsePeriod(hosterID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	granularity := filter.Granularity
+	switch granularity {
+	case "":
+		granularity = "month"
+	case "day", "week", "month":
+	default:
+		return nil, errors.New(message.AnalyticsInvalidGranularity)
+	}
+	if granularity == "day" && q.Days > MaxDailyPoints {
+		return nil, errors.New(message.AnalyticsInvalidPeriod)
+	}
+
+	points, err := s.repo.GetRevenueSeries(q, granularity)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.AnalyticsRevenueByHosterResponse{
+		Period:      toPeriodResponse(q),
+		Granularity: granularity,
+		Points:      points,
+	}, nil
+}
+
+/*
+GetTopItems mengambil item dengan revenue / utilisasi tertinggi dalam periode.
+
+Alur kerja:
+1. Validasi periode, sort (revenue default / utilisation), limit (default 10, maks 100)
+2. Ambil performa item dari repository
+
+Output sukses:
+- (*dto.AnalyticsItemsByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / AnalyticsInvalidPeriod / AnalyticsInvalidSort / internal error
+*/
+func (s *hosterAnalyticsService) GetTopItems(hosterID string, filter dto.AnalyticsFilterByHosterRequest) (*dto.AnalyticsItemsByHosterResponse, error) {
+	q, err := parsePeriod(hosterID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, orderBy := filter.Sort, OrderByRevenue
+	switch sort {
+	case "", "revenue":
+		sort = "revenue"
+	case "utilisation":
+		orderBy = OrderByUtilisation
+	default:
+		return nil, errors.New(message.AnalyticsInvalidSort)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultItemLimit
+	}
+	if limit > MaxItemLimit {
+		limit = MaxItemLimit
+	}
+
+	items, err := s.repo.GetItemPerformance(q, orderBy, &limit)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.AnalyticsItemsByHosterResponse{
+		Period: toPeriodResponse(q),
+		Sort:   sort,
+		Items:  items,
+	}, nil
+}
+
+/*
+parsePeriod memvalidasi filter periode menjadi AnalyticsQuery.
+from & to inklusif (YYYY-MM-DD), disimpan sebagai [from, to+1 hari).
+Default: 30 hari terakhir termasuk hari ini. store_id (opsional) harus UUID.
+*/
+func parsePeriod(hosterID string, filter dto.AnalyticsFilterByHosterRequest) (AnalyticsQuery, error) {
+	if hosterID == "" {
+		return AnalyticsQuery{}, errors.New(message.Unauthorized)
+	}
+
+	if filter.StoreID != "" {
+		if _, err := uuid.Parse(filter.StoreID); err != nil {
+			return AnalyticsQuery{}, errors.New(message.StoreInvalidID)
+		}
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today.AddDate(0, 0, 1)
+	if filter.To != "" {
+		parsed, err := time.Parse("2006-01-02", filter.To)
+		if err != nil {
+			return AnalyticsQuery{}, errors.New(message.AnalyticsInvalidPeriod)
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -DefaultPeriodDays)
+	if filter.From != "" {
+		parsed, err := time.Parse("2006-01-02", filter.From)
+		if err != nil {
+			return AnalyticsQuery{}, errors.New(message.AnalyticsInvalidPeriod)
+		}
+		from = parsed
+	}
+
+	days := int(to.Sub(from).Hours() / 24)
+	if days <= 0 || days > MaxPeriodDays {
+		log.Printf("parsePeriod: invalid period from=%s to=%s days=%d", from.Format("2006-01-02"), to.Format("2006-01-02"), days)
+		return AnalyticsQuery{}, errors.New(message.AnalyticsInvalidPeriod)
+	}
+
+	return AnalyticsQuery{HosterID: hosterID, StoreID: filter.StoreID, From: from, To: to, Days: days}, nil
+}
+
+/*
+toPeriodResponse mengubah AnalyticsQuery menjadi DTO periode.
+*/
+func toPeriodResponse(q AnalyticsQuery) dto.AnalyticsPeriodResponse {
+	return dto.AnalyticsPeriodResponse{From: q.From, To: q.To, Days: q.Days}
+}
+
+/*
+ratio menghitung part/total dibulatkan 4 desimal (0 jika total 0).
+*/
+func ratio(part, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*10000) / 10000
+}
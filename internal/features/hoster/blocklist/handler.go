@@ -0,0 +1,128 @@
+package blocklist
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterBlocklistHandler menangani endpoint HTTP daftar blokir customer dari perspektif hoster.
+*/
+type HosterBlocklistHandler struct {
+	service BlocklistService
+}
+
+/*
+NewHosterBlocklistHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterBlocklistHandler siap digunakan
+*/
+func NewHosterBlocklistHandler(s BlocklistService) *HosterBlocklistHandler {
+	return &HosterBlocklistHandler{service: s}
+}
+
+/*
+ListBlocks menangani GET /api/v1/hoster/customer/blocked
+
+Output sukses:
+- 200 OK + daftar customer yang diblokir beserta catatan privat
+Output error:
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterBlocklistHandler) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	list, err := h.service.ListBlocks(hosterID)
+	if err != nil {
+		log.Printf("ListBlocks handler: service error hoster=%s err=%v", hosterID, err)
+		writeBlocklistError(w, err)
+		return
+	}
+	response.OK(w, list, message.CustomerBlockRetrieved)
+}
+
+/*
+BlockCustomer menangani PUT /api/v1/hoster/customer/{id}/block
+
+Output sukses:
+- 200 OK + data blokir
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterBlocklistHandler) BlockCustomer(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.BlockCustomerByHosterRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Printf("BlockCustomer: failed to decode JSON: %v", err)
+			response.BadRequest(w, message.BadRequest)
+			return
+		}
+	}
+
+	block, err := h.service.BlockCustomer(hosterID, middleware.GetMemberID(r), mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("BlockCustomer handler: service error hoster=%s err=%v", hosterID, err)
+		writeBlocklistError(w, err)
+		return
+	}
+	response.OK(w, block, message.CustomerBlocked)
+}
+
+/*
+UnblockCustomer menangani DELETE /api/v1/hoster/customer/{id}/block
+
+Output sukses:
+- 200 OK
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterBlocklistHandler) UnblockCustomer(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	if err := h.service.UnblockCustomer(hosterID, mux.Vars(r)["id"]); err != nil {
+		log.Printf("UnblockCustomer handler: service error hoster=%s err=%v", hosterID, err)
+		writeBlocklistError(w, err)
+		return
+	}
+	response.OK(w, nil, message.CustomerUnblocked)
+}
+
+/*
+writeBlocklistError memetakan error service blokir customer ke HTTP response.
+*/
+func writeBlocklistError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.CustomerBlockNotFound, fmt.Sprintf(message.NotFound, "customer"):
+		response.NotFound(w, err.Error())
+	case message.CustomerBlockNoDocument, fmt.Sprintf(message.TooLong, "note"):
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,191 @@
+package blocklist
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+BlocklistRepository adalah kontrak akses data daftar blokir customer milik hoster.
+*/
+type BlocklistRepository interface {
+	ListBlocks(hosterID string) ([]dto.CustomerBlockByHosterResponse, error)
+	GetBlock(hosterID, customerID string) (*dto.CustomerBlockByHosterResponse, error)
+	CustomerBookedWithHoster(hosterID, customerID string) (bool, error)
+	GetVerifiedDocument(customerID string) (documentType, numberHash string, err error)
+	UpsertBlock(block *domain.CustomerBlock) error
+	DeleteBlock(hosterID, customerID string) error
+}
+
+/*
+blocklistRepository adalah implementasi repository daftar blokir customer.
+*/
+type blocklistRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewBlocklistRepository membuat instance repository dengan koneksi database.
+
+Output:
+- BlocklistRepository siap digunakan
+*/
+func NewBlocklistRepository(db *sqlx.DB) BlocklistRepository {
+	return &blocklistRepository{db: db}
+}
+
+/*
+blockColumns adalah kolom response blokir (alias hb = hoster_customer_block, c = customer).
+*/
+const blockColumns = `
+	hb.customer_id, COALESCE(c.full_name, '') AS full_name, COALESCE(c.email, '') AS email,
+	COALESCE(c.phone_number, '') AS phone_number, hb.document_type, hb.note, hb.blocked_by_member_id,
+	hb.created_at, hb.updated_at`
+
+/*
+ListBlocks mengambil seluruh customer yang diblokir hoster (terbaru dulu).
+
+Output sukses:
+- ([]dto.CustomerBlockByHosterResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *blocklistRepository) ListBlocks(hosterID string) ([]dto.CustomerBlockByHosterResponse, error) {
+	list := []dto.CustomerBlockByHosterResponse{}
+	err := r.db.Select(&list, `
+		SELECT `+blockColumns+`
+		FROM hoster_customer_block hb
+		LEFT JOIN customer c ON c.id = hb.customer_id
+		WHERE hb.hoster_id = $1
+		ORDER BY hb.created_at DESC, hb.id
+	`, hosterID)
+	if err != nil {
+		log.Printf("ListBlocks: query error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return list, nil
+}
+
+/*
+GetBlock mengambil blokir satu customer milik hoster.
+
+Output sukses:
+- (*dto.CustomerBlockByHosterResponse, nil)
+Output error:
+- (nil, sql.ErrNoRows) → customer tidak diblokir
+- (nil, error)         → query gagal
+*/
+func (r *blocklistRepository) GetBlock(hosterID, customerID string) (*dto.CustomerBlockByHosterResponse, error) {
+	var block dto.CustomerBlockByHosterResponse
+	err := r.db.Get(&block, `
+		SELECT `+blockColumns+`
+		FROM hoster_customer_block hb
+		LEFT JOIN customer c ON c.id = hb.customer_id
+		WHERE hb.hoster_id = $1 AND hb.customer_id = $2
+	`, hosterID, customerID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetBlock: query error hoster=%s customer=%s err=%v", hosterID, customerID, err)
+		}
+		return nil, err
+	}
+	return &block, nil
+}
+
+/*
+CustomerBookedWithHoster mengecek customer pernah membuat booking di hoster.
+Hoster hanya bisa memblokir customer yang muncul di daftar pelanggannya.
+*/
+func (r *blocklistRepository) CustomerBookedWithHoster(hosterID, customerID string) (bool, error) {
+	var exists bool
+	err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM booking WHERE hoster_id = $1 AND user_id = $2)`, hosterID, customerID)
+	if err != nil {
+		log.Printf("CustomerBookedWithHoster: query error hoster=%s customer=%s err=%v", hosterID, customerID, err)
+	}
+	return exists, err
+}
+
+/*
+GetVerifiedDocument mengambil blind index dokumen identitas terverifikasi terbaru milik customer.
+
+Output sukses:
+- (documentType, numberHash, nil)
+Output error:
+- ("", "", sql.ErrNoRows) → belum ada dokumen approved dengan nomor dokumen
+- ("", "", error)         → query gagal
+*/
+func (r *blocklistRepository) GetVerifiedDocument(customerID string) (string, string, error) {
+	var doc struct {
+		DocumentType string `db:"document_type"`
+		NumberHash   string `db:"document_number_hash"`
+	}
+	err := r.db.Get(&doc, `
+		SELECT document_type, document_number_hash
+		FROM identity
+		WHERE user_id = $1 AND user_role = 'customer' AND status = 'approved'
+		  AND document_number_hash IS NOT NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, customerID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetVerifiedDocument: query error customer=%s err=%v", customerID, err)
+		}
+		return "", "", err
+	}
+	return doc.DocumentType, doc.NumberHash, nil
+}
+
+/*
+UpsertBlock menyimpan blokir customer; jika sudah diblokir, catatan & dokumen diperbarui.
+
+Output sukses:
+- nil (ID, CreatedAt, UpdatedAt terisi)
+Output error:
+- error → query gagal
+*/
+func (r *blocklistRepository) UpsertBlock(block *domain.CustomerBlock) error {
+	err := r.db.QueryRow(`
+		INSERT INTO hoster_customer_block (hoster_id, customer_id, document_type, document_number_hash, note, blocked_by_member_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (hoster_id, customer_id) DO UPDATE
+		SET document_type = EXCLUDED.document_type,
+		    document_number_hash = EXCLUDED.document_number_hash,
+		    note = EXCLUDED.note,
+		    blocked_by_member_id = EXCLUDED.blocked_by_member_id,
+		    updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`, block.HosterID, block.CustomerID, block.DocumentType, block.DocumentNumberHash, block.Note, block.BlockedByMemberID,
+	).Scan(&block.ID, &block.CreatedAt, &block.UpdatedAt)
+	if err != nil {
+		log.Printf("UpsertBlock: query error hoster=%s customer=%s err=%v", block.HosterID, block.CustomerID, err)
+	}
+	return err
+}
+
+/*
+DeleteBlock membuka blokir customer.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → customer tidak diblokir
+- error         → query gagal
+*/
+func (r *blocklistRepository) DeleteBlock(hosterID, customerID string) error {
+	res, err := r.db.Exec(`DELETE FROM hoster_customer_block WHERE hoster_id = $1 AND customer_id = $2`, hosterID, customerID)
+	if err != nil {
+		log.Printf("DeleteBlock: query error hoster=%s customer=%s err=%v", hosterID, customerID, err)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package blocklist
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupBlocklistRoutes mendaftarkan endpoint daftar blokir customer untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET    /customer/blocked    → daftar customer yang diblokir
+  - PUT    /customer/{id}/block → blokir customer (opsional ikut dokumen identitas terverifikasi)
+  - DELETE /customer/{id}/block → buka blokir
+
+4. GET butuh permission bookings:view, blokir / buka blokir butuh store:manage (role toko)
+
+Output:
+- Router terkonfigurasi dengan endpoint blokir customer hoster
+*/
+func SetupBlocklistRoutes(router *mux.Router, h *HosterBlocklistHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	protected.HandleFunc("/customer/blocked", middleware.HosterPermission(domain.HosterPermBookingsView, h.ListBlocks)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/customer/{id}/block", middleware.HosterPermission(domain.HosterPermStoreManage, h.BlockCustomer)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/customer/{id}/block", middleware.HosterPermission(domain.HosterPermStoreManage, h.UnblockCustomer)).Methods("DELETE", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
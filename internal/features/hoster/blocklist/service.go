@@ -0,0 +1,163 @@
+package blocklist
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+// MaxBlockNoteLength adalah panjang maksimal catatan privat blokir customer.
+const MaxBlockNoteLength = 1000
+
+/*
+BlocklistService adalah kontrak logika bisnis daftar blokir customer milik hoster.
+*/
+type BlocklistService interface {
+	ListBlocks(hosterID string) ([]dto.CustomerBlockByHosterResponse, error)
+	BlockCustomer(hosterID, memberID, customerID string, req dto.BlockCustomerByHosterRequest) (*dto.CustomerBlockByHosterResponse, error)
+	UnblockCustomer(hosterID, customerID string) error
+}
+
+/*
+blocklistService adalah implementasi service daftar blokir customer.
+*/
+type blocklistService struct {
+	repo BlocklistRepository
+}
+
+/*
+NewBlocklistService membuat instance service dengan dependency injection.
+
+Output:
+- BlocklistService siap digunakan
+*/
+func NewBlocklistService(repo BlocklistRepository) BlocklistService {
+	return &blocklistService{repo: repo}
+}
+
+/*
+ListBlocks mengambil daftar customer yang diblokir hoster.
+
+Output sukses:
+- ([]dto.CustomerBlockByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / internal error
+*/
+func (s *blocklistService) ListBlocks(hosterID string) ([]dto.CustomerBlockByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	list, err := s.repo.ListBlocks(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return list, nil
+}
+
+/*
+BlockCustomer memblokir customer agar tidak bisa booking item hoster.
+
+Alur kerja:
+1. Validasi catatan dan customer pernah booking di hoster
+2. Jika include_document, ambil blind index dokumen identitas terverifikasi customer
+3. Simpan blokir (blokir ulang memperbarui catatan & dokumen)
+
+Output sukses:
+- (*dto.CustomerBlockByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / customer tidak ditemukan / dokumen belum terverifikasi / internal error
+*/
+func (s *blocklistService) BlockCustomer(hosterID, memberID, customerID string, req dto.BlockCustomerByHosterRequest) (*dto.CustomerBlockByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(note) > MaxBlockNoteLength {
+		return nil, fmt.Errorf(message.TooLong, "note")
+	}
+	if err := s.ensureCustomer(hosterID, customerID); err != nil {
+		return nil, err
+	}
+
+	block := &domain.CustomerBlock{HosterID: hosterID, CustomerID: customerID}
+	if note != "" {
+		block.Note = &note
+	}
+	if memberID != "" {
+		block.BlockedByMemberID = &memberID
+	}
+	if req.IncludeDocument {
+		documentType, numberHash, err := s.repo.GetVerifiedDocument(customerID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.New(message.CustomerBlockNoDocument)
+			}
+			return nil, errors.New(message.InternalError)
+		}
+		block.DocumentType = &documentType
+		block.DocumentNumberHash = &numberHash
+	}
+
+	if err := s.repo.UpsertBlock(block); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	log.Printf("BlockCustomer: hoster %s blocked customer %s (document=%t)", hosterID, customerID, block.DocumentNumberHash != nil)
+
+	result, err := s.repo.GetBlock(hosterID, customerID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return result, nil
+}
+
+/*
+UnblockCustomer membuka blokir customer.
+
+Output sukses:
+- nil
+Output error:
+- error → unauthorized / customer tidak diblokir / internal error
+*/
+func (s *blocklistService) UnblockCustomer(hosterID, customerID string) error {
+	if hosterID == "" {
+		return errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(customerID); err != nil {
+		return errors.New(message.CustomerBlockNotFound)
+	}
+
+	if err := s.repo.DeleteBlock(hosterID, customerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.CustomerBlockNotFound)
+		}
+		return errors.New(message.InternalError)
+	}
+	log.Printf("UnblockCustomer: hoster %s unblocked customer %s", hosterID, customerID)
+	return nil
+}
+
+/*
+ensureCustomer memastikan customer ada di daftar pelanggan hoster (pernah booking).
+*/
+func (s *blocklistService) ensureCustomer(hosterID, customerID string) error {
+	if _, err := uuid.Parse(customerID); err != nil {
+		return fmt.Errorf(message.NotFound, "customer")
+	}
+	booked, err := s.repo.CustomerBookedWithHoster(hosterID, customerID)
+	if err != nil {
+		return errors.New(message.InternalError)
+	}
+	if !booked {
+		return fmt.Errorf(message.NotFound, "customer")
+	}
+	return nil
+}
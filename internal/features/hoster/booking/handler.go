@@ -3,14 +3,18 @@ package booking
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"lalan-be/internal/dto"
 	"lalan-be/internal/message"
 	"lalan-be/internal/middleware"
 	"lalan-be/internal/response"
+	"lalan-be/internal/utils"
 
 	"github.com/gorilla/mux"
 )
@@ -34,16 +38,20 @@ func NewHosterBookingHandler(s BookingService) *HosterBookingHandler {
 }
 
 /*
-GetListBookings menangani GET /api/v1/hoster/bookings
+GetListBookings menangani GET /api/v1/hoster/booking
+
+Query: status (dipisah koma), from, to, item_id, customer_id, store_id, q, sort, order, limit, cursor
 
 Alur kerja:
 1. Validasi method GET
 2. Ambil hosterID dari JWT context
-3. Panggil service untuk ambil daftar booking milik hoster
+3. Parse filter dari query string
+4. Panggil service untuk ambil satu halaman booking milik hoster
 
 Output sukses:
-- 200 OK + list booking ringkas
+- 200 OK + halaman booking ringkas + next_cursor
 Output error:
+- 400 Bad Request (filter / cursor tidak valid)
 - 401 Unauthorized / 500 Internal Server Error
 */
 func (h *HosterBookingHandler) GetListBooking(w http.ResponseWriter, r *http.Request) {
@@ -58,18 +66,113 @@ func (h *HosterBookingHandler) GetListBooking(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	bookings, err := h.service.GetListBookings(hosterID)
+	page, err := h.service.GetListBookings(hosterID, parseListFilter(r))
 	if err != nil {
 		log.Printf("GetListBookings handler: service error hoster=%s err=%v", hosterID, err)
-		if err.Error() == message.Unauthorized {
-			response.Unauthorized(w, message.Unauthorized)
-		} else {
-			response.Error(w, http.StatusInternalServerError, message.InternalError)
-		}
+		writeListError(w, err)
 		return
 	}
 
-	response.OK(w, bookings, message.Success)
+	response.OK(w, page, message.Success)
+}
+
+/*
+ExportBookings menangani GET /api/v1/hoster/booking/export?format=csv|xlsx
+
+Menerima filter yang sama dengan list booking (tanpa limit & cursor).
+File dikirim secara streaming sehingga export besar tidak ditampung di memori.
+
+Alur kerja:
+1. Ambil hosterID dari JWT context
+2. Validasi format & filter di service
+3. Kirim header Content-Type + Content-Disposition, lalu stream isi file
+
+Output sukses:
+- 200 OK + file bookings_YYYYMMDD.csv / .xlsx
+Output error:
+- 400 Bad Request (format / filter tidak valid)
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterBookingHandler) ExportBookings(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = "csv"
+	}
+
+	started := false
+	err := h.service.ExportBookings(hosterID, parseListFilter(r), format, func() io.Writer {
+		started = true
+
+		// Export besar bisa melebihi WriteTimeout server (15 detik)
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(5 * time.Minute)); err != nil {
+			log.Printf("ExportBookings handler: cannot extend write deadline: %v", err)
+		}
+
+		filename := fmt.Sprintf("bookings_%s.%s", time.Now().Format("20060102"), format)
+		w.Header().Set("Content-Type", utils.ExportContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		w.WriteHeader(http.StatusOK)
+		return w
+	})
+	if err != nil {
+		log.Printf("ExportBookings handler: service error hoster=%s format=%s err=%v", hosterID, format, err)
+		if started {
+			// Header sudah terkirim, file di sisi client akan terpotong
+			return
+		}
+		writeListError(w, err)
+	}
+}
+
+/*
+parseListFilter membaca filter list / export booking dari query string.
+*/
+func parseListFilter(r *http.Request) dto.BookingListFilterByHosterRequest {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	var statuses []string
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+
+	return dto.BookingListFilterByHosterRequest{
+		Status:     statuses,
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+		ItemID:     query.Get("item_id"),
+		CustomerID: query.Get("customer_id"),
+		StoreID:    query.Get("store_id"),
+		Search:     query.Get("q"),
+		Sort:       query.Get("sort"),
+		Order:      query.Get("order"),
+		Limit:      limit,
+		Cursor:     query.Get("cursor"),
+	}
+}
+
+/*
+writeListError memetakan error service list / export booking ke HTTP response.
+*/
+func writeListError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.BookingInvalidFilter, message.BookingInvalidCursor, message.BookingInvalidExport:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
 }
 
 /*
@@ -129,6 +232,34 @@ func (h *HosterBookingHandler) GetDetailBooking(w http.ResponseWriter, r *http.R
 	response.OK(w, detail, message.Success)
 }
 
+/*
+GetOverdueBookings menangani GET /api/v1/hoster/booking/overdue
+
+Output sukses:
+- 200 OK + daftar booking terlambat (paling lama terlambat lebih dulu)
+Output error:
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterBookingHandler) GetOverdueBookings(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	list, err := h.service.GetOverdueBookings(hosterID)
+	if err != nil {
+		log.Printf("GetOverdueBookings handler: service error hoster=%s err=%v", hosterID, err)
+		if err.Error() == message.Unauthorized {
+			response.Unauthorized(w, message.Unauthorized)
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+		return
+	}
+	response.OK(w, list, message.OverdueBookingsRetrieved)
+}
+
 // GetCustomerList menangani GET /api/v1/hoster/booking/customers
 // Mengembalikan daftar pelanggan yang melakukan booking pada hoster yang sedang login
 func (h *HosterBookingHandler) GetCustomerList(w http.ResponseWriter, r *http.Request) {
@@ -171,9 +302,10 @@ Alur kerja:
 Output sukses:
 - 200 OK + message sukses
 Output error:
-- 400 Bad Request (ID kosong / invalid JSON / invalid status)
+- 400 Bad Request (ID kosong / invalid JSON / invalid status / on_rent tanpa kode pickup)
 - 401 Unauthorized (bukan pemilik)
 - 404 Not Found (booking tidak ada)
+- 409 Conflict (status sudah diubah request lain)
 - 500 Internal Server Error
 */
 func (h *HosterBookingHandler) UpdateBookingStatus(w http.ResponseWriter, r *http.Request) {
@@ -212,7 +344,7 @@ func (h *HosterBookingHandler) UpdateBookingStatus(w http.ResponseWriter, r *htt
 	}
 
 	// Call service
-	err := h.service.UpdateBookingStatus(hosterID, bookingID, req.Status)
+	err := h.service.UpdateBookingStatus(hosterID, bookingID, req)
 	if err != nil {
 		log.Printf("UpdateBookingStatus: service error booking=%s status=%s err=%v", bookingID, req.Status, err)
 
@@ -221,8 +353,10 @@ func (h *HosterBookingHandler) UpdateBookingStatus(w http.ResponseWriter, r *htt
 			response.Unauthorized(w, message.Unauthorized)
 		case fmt.Sprintf(message.NotFound, "booking"):
 			response.NotFound(w, fmt.Sprintf(message.NotFound, "booking"))
-		case message.InvalidStatus:
-			response.BadRequest(w, message.InvalidStatus)
+		case message.InvalidStatus, message.BookingInvalidUnits, message.PickupCodeRequired:
+			response.BadRequest(w, err.Error())
+		case message.BookingStatusChanged:
+			response.Error(w, http.StatusConflict, err.Error())
 		default:
 			response.Error(w, http.StatusInternalServerError, message.InternalError)
 		}
@@ -231,3 +365,55 @@ func (h *HosterBookingHandler) UpdateBookingStatus(w http.ResponseWriter, r *htt
 
 	response.OK(w, nil, message.BookingStatusUpdated)
 }
+
+/*
+VerifyPickupCode menangani POST /api/v1/hoster/booking/pickup/verify
+Kode hasil scan QR customer dicocokkan dengan booking milik hoster, lalu booking langsung menjadi on_rent.
+
+Output sukses:
+- 200 OK + detail booking (untuk mencocokkan identitas customer)
+Output error:
+- 400 Bad Request  → body tidak valid / kode tidak valid / pilihan unit tidak valid
+- 401 Unauthorized → token tidak valid
+- 404 Not Found    → kode tidak cocok dengan booking hoster ini
+- 409 Conflict     → kode sudah dipakai / booking bukan on_progress / unit tidak cukup
+- 500 Internal Server Error
+*/
+func (h *HosterBookingHandler) VerifyPickupCode(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.VerifyPickupCodeByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("VerifyPickupCode: invalid JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, fmt.Sprintf(message.Required, "code"))
+		return
+	}
+
+	detail, err := h.service.VerifyPickupCode(hosterID, req)
+	if err != nil {
+		log.Printf("VerifyPickupCode: service error hoster=%s err=%v", hosterID, err)
+		switch err.Error() {
+		case message.Unauthorized:
+			response.Unauthorized(w, message.Unauthorized)
+		case message.PickupCodeInvalid, message.BookingInvalidUnits:
+			response.BadRequest(w, err.Error())
+		case message.PickupCodeNotFound:
+			response.NotFound(w, err.Error())
+		case message.PickupCodeUsed, message.InvalidStatus, message.BookingUnitsUnavailable:
+			response.Error(w, http.StatusConflict, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+
+	response.OK(w, detail, message.PickupCodeVerified)
+}
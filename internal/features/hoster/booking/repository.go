@@ -2,6 +2,8 @@ package booking
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"time"
 
@@ -17,11 +19,14 @@ HosterBookingRepository mendefinisikan operasi database khusus perspektif hoster
 Digunakan untuk dashboard dan detail booking yang dimiliki hoster.
 */
 type HosterBookingRepository interface {
-	GetListBookings(hosterID string) ([]dto.BookingListByHosterResponse, error)
+	GetListBookings(q BookingListQuery) ([]dto.BookingListByHosterResponse, error)
+	StreamBookings(q BookingListQuery, fn func(dto.BookingExportRowByHosterResponse) error) error
 	GetCustomerList(hosterID string) ([]dto.CustomerListByHosterResponse, error)
 	GetBookingDetail(bookingID string) (*dto.BookingDetailByHosterResponse, error)
-	UpdateBookingStatus(bookingID, newStatus string) error
+	UpdateBookingStatus(bookingID, currentStatus, newStatus string, returns []dto.BookingUnitReturnRequest) error
 	GetBookingStatus(bookingID string) (string, error)
+	RedeemPickupCode(hosterID, bookingID, nonce string, units []dto.BookingUnitAllocationRequest) error
+	GetOverdueBookings(hosterID string) ([]dto.OverdueBookingResponse, error)
 }
 
 /*
@@ -42,55 +47,188 @@ func NewHosterBookingRepository(db *sqlx.DB) HosterBookingRepository {
 }
 
 /*
-GetListBookings mengambil ringkasan semua booking yang dimiliki hoster.
+BookingSort adalah kolom sort list booking hoster beserta tipe SQL nilai cursor-nya.
+Hanya nilai yang didefinisikan di sini yang boleh masuk ke query (whitelist).
+*/
+type BookingSort struct {
+	Column string
+	Cast   string
+}
+
+var (
+	SortByCreatedAt = BookingSort{Column: "b.created_at", Cast: "timestamp"}
+	SortByStartDate = BookingSort{Column: "b.start_date", Cast: "timestamptz"}
+	SortByTotal     = BookingSort{Column: "b.total", Cast: "integer"}
+)
+
+/*
+BookingListQuery adalah filter list / export booking hoster yang sudah divalidasi service.
+*/
+type BookingListQuery struct {
+	HosterID    string
+	Statuses    []string
+	From        *time.Time // Awal rentang (inklusif), nil = tanpa batas
+	To          *time.Time // Akhir rentang (eksklusif), nil = tanpa batas
+	ItemID      string
+	CustomerID  string
+	StoreID     string
+	Search      string // Pola ILIKE yang sudah di-escape, kosong = tanpa pencarian
+	Sort        BookingSort
+	Desc        bool
+	CursorValue string // Nilai kolom sort baris terakhir halaman sebelumnya
+	CursorID    string // ID booking baris terakhir halaman sebelumnya
+	Limit       int    // 0 = tanpa batas (export)
+}
+
+/*
+hosterBookingFilter adalah FROM + WHERE bersama untuk list dan export booking hoster.
+Semua filter opsional memakai parameter statis ($2–$10) sehingga query tetap satu bentuk:
+$2 status, $3–$4 rentang tanggal sewa, $5 item, $6 customer, $7 pencarian, $8 store, $9–$10 cursor.
+*/
+const hosterBookingFilter = `
+		FROM booking b
+		LEFT JOIN (
+			SELECT booking_id,
+			       string_agg(name, ', ' ORDER BY name) AS item_name,
+			       SUM(quantity) AS quantity
+			FROM booking_item
+			GROUP BY booking_id
+		) items ON items.booking_id = b.id
+		LEFT JOIN booking_customer bc ON bc.booking_id = b.id
+		LEFT JOIN customer c ON c.id = b.user_id
+		WHERE b.hoster_id = $1
+		  AND (cardinality($2::text[]) = 0 OR b.status = ANY($2::text[]))
+		  AND ($3::timestamptz IS NULL OR b.end_date > $3::timestamptz)
+		  AND ($4::timestamptz IS NULL OR b.start_date < $4::timestamptz)
+		  AND ($5 = '' OR EXISTS (
+		      SELECT 1 FROM booking_item fi WHERE fi.booking_id = b.id AND fi.item_id::text = $5
+		  ))
+		  AND ($6 = '' OR b.user_id::text = $6)
+		  AND ($7 = '' OR bc.name ILIKE $7 OR c.full_name ILIKE $7 OR bc.phone ILIKE $7 OR c.phone_number ILIKE $7)
+		  AND ($8 = '' OR b.tenant_id::text = $8)
+		  AND ($10::uuid IS NULL OR (%[1]s, b.id) %[3]s ($9::%[2]s, $10::uuid))
+		ORDER BY %[1]s %[4]s, b.id %[4]s
+		LIMIT $11
+`
+
+/*
+buildBookingQuery menyusun query list / export dengan kolom SELECT dan urutan sort.
+Kolom sort berasal dari whitelist BookingSort, bukan input user.
+*/
+func buildBookingQuery(columns string, q BookingListQuery) (string, []interface{}) {
+	direction, op := "ASC", ">"
+	if q.Desc {
+		direction, op = "DESC", "<"
+	}
+	query := "SELECT " + columns + fmt.Sprintf(hosterBookingFilter, q.Sort.Column, q.Sort.Cast, op, direction)
+
+	var cursorValue, cursorID, limit interface{}
+	if q.CursorID != "" {
+		cursorValue, cursorID = q.CursorValue, q.CursorID
+	}
+	if q.Limit > 0 {
+		limit = q.Limit
+	}
+	statuses := q.Statuses
+	if statuses == nil {
+		statuses = []string{}
+	}
+
+	args := []interface{}{
+		q.HosterID, pq.Array(statuses), q.From, q.To, q.ItemID, q.CustomerID, q.Search,
+		q.StoreID, cursorValue, cursorID, limit,
+	}
+	return query, args
+}
+
+/*
+GetListBookings mengambil ringkasan booking hoster sesuai filter, sort, dan cursor.
 
 Alur kerja:
-1. Query JOIN booking + booking_item dengan filter hoster_id
-2. Agregasi item names dan total quantity
-3. Konversi string_agg → slice string untuk kemudahan di frontend
+1. Susun query dengan filter opsional + keyset (kolom sort, id) dari cursor
+2. Agregasi item names dan total quantity per booking
+3. Ambil maksimal q.Limit baris (service meminta limit+1 untuk mendeteksi halaman berikutnya)
 
 Output sukses:
 - ([]dto.BookingListByHosterResponse, nil)
 Output error:
 - (nil, error) → query gagal
 */
-func (r *hosterBookingRepository) GetListBookings(hosterID string) ([]dto.BookingListByHosterResponse, error) {
-	query := `
-		SELECT
+func (r *hosterBookingRepository) GetListBookings(q BookingListQuery) ([]dto.BookingListByHosterResponse, error) {
+	query, args := buildBookingQuery(`
 			b.id AS booking_id,
 			COALESCE(NULLIF(bc.name, ''), c.full_name, '') AS customer_name,
+			COALESCE(NULLIF(bc.phone, ''), c.phone_number, '') AS customer_phone,
 			COALESCE(items.item_name, '') AS item_name,
 			COALESCE(items.quantity, 0) AS quantity,
 			b.start_date::timestamptz AS start_date,
 			b.end_date::timestamptz AS end_date,
 			b.total,
-			b.status
-		FROM booking b
-		LEFT JOIN (
-			SELECT booking_id, 
-			       string_agg(name, ', ' ORDER BY name) AS item_name,
-			       SUM(quantity) AS quantity
-			FROM booking_item
-			GROUP BY booking_id
-		) items ON items.booking_id = b.id
-		LEFT JOIN booking_customer bc ON bc.booking_id = b.id
-		LEFT JOIN customer c ON c.id = b.user_id
-		WHERE b.hoster_id = $1
-		ORDER BY b.created_at DESC
-	`
+			b.status,
+			b.created_at`, q)
 
-	var rows []dto.BookingListByHosterResponse
-	if err := r.db.Select(&rows, query, hosterID); err != nil {
-		log.Printf("GetListBookings(hoster): db error hoster=%s err=%v", hosterID, err)
+	rows := []dto.BookingListByHosterResponse{}
+	if err := r.db.Select(&rows, query, args...); err != nil {
+		log.Printf("GetListBookings(hoster): db error hoster=%s err=%v", q.HosterID, err)
 		return nil, err
 	}
 
 	return rows, nil
 }
 
+/*
+StreamBookings membaca booking hoster sesuai filter baris per baris untuk export.
+Baris tidak ditampung di memori; setiap baris langsung diteruskan ke fn.
+
+Output sukses:
+- nil → semua baris sudah diteruskan ke fn
+Output error:
+- error → query gagal / fn mengembalikan error (iterasi dihentikan)
+*/
+func (r *hosterBookingRepository) StreamBookings(q BookingListQuery, fn func(dto.BookingExportRowByHosterResponse) error) error {
+	query, args := buildBookingQuery(`
+			b.id AS booking_id,
+			b.created_at,
+			b.status,
+			COALESCE(NULLIF(bc.name, ''), c.full_name, '') AS customer_name,
+			COALESCE(NULLIF(bc.phone, ''), c.phone_number, '') AS customer_phone,
+			COALESCE(NULLIF(bc.email, ''), c.email, '') AS customer_email,
+			COALESCE(items.item_name, '') AS item_name,
+			COALESCE(items.quantity, 0) AS quantity,
+			b.start_date::timestamptz AS start_date,
+			b.end_date::timestamptz AS end_date,
+			b.total_days,
+			b.delivery_type,
+			b.rental,
+			b.deposit,
+			b.discount,
+			b.total,
+			b.outstanding`, q)
+
+	rows, err := r.db.Queryx(query, args...)
+	if err != nil {
+		log.Printf("StreamBookings(hoster): query error hoster=%s err=%v", q.HosterID, err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dto.BookingExportRowByHosterResponse
+		if err := rows.StructScan(&row); err != nil {
+			log.Printf("StreamBookings(hoster): scan error hoster=%s err=%v", q.HosterID, err)
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // GetCustomerList mengambil daftar pelanggan yang melakukan pemesanan pada hoster tertentu.
 // Query akan memilih snapshot dari booking_customer jika tersedia, dan akan mencari
-// KTP terbaru per customer. Hasil dikembalikan unik per user (booking.user_id).
+// KTP terbaru per customer. Hasil dikembalikan unik per user (booking.user_id),
+// beserta penanda apakah customer sedang diblokir hoster.
 func (r *hosterBookingRepository) GetCustomerList(hosterID string) ([]dto.CustomerListByHosterResponse, error) {
 	query := `
 		SELECT DISTINCT ON (b.user_id)
@@ -100,17 +238,22 @@ func (r *hosterBookingRepository) GetCustomerList(hosterID string) ([]dto.Custom
 			COALESCE(bc.phone, c.phone_number, '') AS phone_number,
 			COALESCE(i_latest.id::text, '') AS ktp_id,
 			COALESCE(i_latest.created_at, bc.created_at) AS uploaded_at,
-			COALESCE(i_latest.ktp_url, '') AS ktp_photo,
+			COALESCE(i_latest.document_url, '') AS ktp_photo,
+			COALESCE(i_latest.document_type, '') AS document_type,
 			COALESCE(i_latest.status, '') AS status,
-			COALESCE(i_latest.reason, '') AS reason
+			COALESCE(i_latest.reason, '') AS reason,
+			EXISTS (
+				SELECT 1 FROM hoster_customer_block hb
+				WHERE hb.hoster_id = b.hoster_id AND hb.customer_id = b.user_id
+			) AS blocked
 		FROM booking b
 		LEFT JOIN booking_customer bc ON b.id = bc.booking_id
 		LEFT JOIN customer c ON b.user_id = c.id
 		-- Join with the latest identity per user
 		LEFT JOIN LATERAL (
-			SELECT id, ktp_url, status, reason, created_at
+			SELECT id, document_url, document_type, status, reason, created_at
 			FROM identity
-			WHERE user_id = b.user_id
+			WHERE user_id = b.user_id AND user_role = 'customer'
 			ORDER BY created_at DESC
 			LIMIT 1
 		) i_latest ON true
@@ -130,6 +273,43 @@ func (r *hosterBookingRepository) GetCustomerList(hosterID string) ([]dto.Custom
 	return rows, nil
 }
 
+/*
+GetOverdueBookings mengambil booking on_rent milik hoster yang melewati batas pengembalian
+(end_date + return_time, atau akhir hari end_date jika tanpa slot pengembalian).
+Diurutkan dari yang paling lama terlambat.
+
+Output sukses:
+- ([]dto.OverdueBookingResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterBookingRepository) GetOverdueBookings(hosterID string) ([]dto.OverdueBookingResponse, error) {
+	var rows []dto.OverdueBookingResponse
+	err := r.db.Select(&rows, `
+		SELECT b.id AS booking_id,
+		       COALESCE(bc.name, c.full_name, '') AS customer_name,
+		       COALESCE((
+		           SELECT string_agg(bi.name, ', ' ORDER BY bi.name)
+		           FROM booking_item bi WHERE bi.booking_id = b.id
+		       ), '') AS item_names,
+		       b.start_date, b.end_date,
+		       CEIL(EXTRACT(EPOCH FROM (NOW() - (b.end_date + COALESCE(b.return_time, TIME '24:00')))) / 86400)::int AS days_late,
+		       b.overdue_at, b.late_fee, b.outstanding
+		FROM booking b
+		LEFT JOIN booking_customer bc ON bc.booking_id = b.id
+		LEFT JOIN customer c ON c.id = b.user_id
+		WHERE b.hoster_id = $1
+		  AND b.status = 'on_rent'
+		  AND (b.end_date + COALESCE(b.return_time, TIME '24:00')) < NOW()
+		ORDER BY b.end_date ASC
+	`, hosterID)
+	if err != nil {
+		log.Printf("GetOverdueBookings(hoster): db error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return rows, nil
+}
+
 /*
 GetBookingDetail mengambil detail lengkap satu booking dari perspektif hoster.
 
@@ -149,7 +329,8 @@ func (r *hosterBookingRepository) GetBookingDetail(bookingID string) (*dto.Booki
 	var b domain.Booking
 	queryBooking := `
 		SELECT id, hoster_id, locked_until, start_date, end_date, total_days, delivery_type,
-			   rental, deposit, discount, total, outstanding, user_id, identity_id, status,
+			   rental, deposit, discount, total, outstanding, late_fee, overdue_at, user_id, identity_id, status,
+			   to_char(pickup_time, 'HH24:MI') AS pickup_time, to_char(return_time, 'HH24:MI') AS return_time,
 			   created_at, updated_at
 		FROM booking
 		WHERE id = $1
@@ -176,6 +357,7 @@ func (r *hosterBookingRepository) GetBookingDetail(bookingID string) (*dto.Booki
 		SELECT 
 			bi.id, bi.booking_id, bi.item_id, bi.name, bi.quantity,
 			bi.price_per_day, bi.deposit_per_unit, bi.subtotal_rental, bi.subtotal_deposit,
+			bi.variant_id, bi.variant_name, bi.bundle_id, bi.bundle_name, bi.bundle_quantity,
 			COALESCE(i.description, '') AS description,
 			CASE 
 				WHEN i.photos IS NOT NULL THEN 
@@ -199,6 +381,7 @@ func (r *hosterBookingRepository) GetBookingDetail(bookingID string) (*dto.Booki
 		err := rows.Scan(
 			&item.ID, &item.BookingID, &item.ItemID, &item.Name, &item.Quantity,
 			&item.PricePerDay, &item.DepositPerUnit, &item.SubtotalRental, &item.SubtotalDeposit,
+			&item.VariantID, &item.VariantName, &item.BundleID, &item.BundleName, &item.BundleQuantity,
 			&item.Description, pq.Array(&item.Photos),
 		)
 		if err != nil {
@@ -212,6 +395,27 @@ func (r *hosterBookingRepository) GetBookingDetail(bookingID string) (*dto.Booki
 		return nil, err
 	}
 
+	// Unit fisik yang diserahkan (item dengan unit tracking)
+	var units []dto.BookingItemUnitResponse
+	queryUnits := `
+		SELECT biu.booking_item_id, biu.unit_id, u.label, u.serial_number, biu.allocated_at, biu.returned_at
+		FROM booking_item_unit biu
+		JOIN item_unit u ON u.id = biu.unit_id
+		WHERE biu.booking_id = $1
+		ORDER BY u.label ASC
+	`
+	if err := r.db.Select(&units, queryUnits, bookingID); err != nil {
+		log.Printf("GetBookingDetail(hoster): error getting units for %s: %v", bookingID, err)
+		return nil, err
+	}
+	for _, unit := range units {
+		for i := range items {
+			if items[i].ID == unit.BookingItemID {
+				items[i].Units = append(items[i].Units, unit)
+			}
+		}
+	}
+
 	// Ambil snapshot customer (booking_customer)
 	var cust dto.CustomerInfoResponse
 	// booking_customer only contains snapshot fields: id, booking_id, name, phone, email, address, notes, created_at
@@ -228,14 +432,15 @@ func (r *hosterBookingRepository) GetBookingDetail(bookingID string) (*dto.Booki
 		return nil, err
 	}
 	if err == nil {
-		// try to attach identity/KTP data as enrichment
+		// try to attach identity document data as enrichment
 		var identity domain.Identity
 		if idErr := r.db.Get(&identity, `
-			SELECT id, user_id, ktp_url, verified, status, COALESCE(reason,'') AS reason, verified_at, created_at, updated_at
-			FROM identity WHERE user_id = $1 ORDER BY verified_at DESC NULLS LAST, created_at DESC LIMIT 1
+			SELECT id, user_id, document_type, document_url, verified, status, COALESCE(reason,'') AS reason, verified_at, created_at, updated_at
+			FROM identity WHERE user_id = $1 AND user_role = 'customer' ORDER BY verified_at DESC NULLS LAST, created_at DESC LIMIT 1
 		`, b.UserID); idErr == nil && identity.ID != "" {
 			cust.KTPID = identity.ID
-			cust.KTPPhoto = identity.KTPURL
+			cust.KTPPhoto = identity.DocumentURL
+			cust.DocumentType = string(identity.DocumentType)
 			cust.Status = identity.Status
 			cust.Reason = identity.Reason
 			if !identity.CreatedAt.IsZero() {
@@ -265,15 +470,16 @@ func (r *hosterBookingRepository) GetBookingDetail(bookingID string) (*dto.Booki
 			}
 		}
 
-		// Tambahkan data KTP dari identity jika ada
+		// Tambahkan data dokumen identitas jika ada
 		var identity domain.Identity
 		if err := r.db.Get(&identity, `
-			SELECT id, user_id, ktp_url, status, COALESCE(reason,'') AS reason, verified_at, created_at
-			FROM identity WHERE user_id = $1
+			SELECT id, user_id, document_type, document_url, status, COALESCE(reason,'') AS reason, verified_at, created_at
+			FROM identity WHERE user_id = $1 AND user_role = 'customer'
 			ORDER BY verified_at DESC NULLS LAST, created_at DESC LIMIT 1
 		`, b.UserID); err == nil && identity.ID != "" {
 			cust.KTPID = identity.ID
-			cust.KTPPhoto = identity.KTPURL
+			cust.KTPPhoto = identity.DocumentURL
+			cust.DocumentType = string(identity.DocumentType)
 			cust.Status = identity.Status
 			cust.Reason = identity.Reason
 			if !identity.CreatedAt.IsZero() {
@@ -307,6 +513,10 @@ func (r *hosterBookingRepository) GetBookingDetail(bookingID string) (*dto.Booki
 			Discount:             b.Discount,
 			Total:                b.Total,
 			Outstanding:          b.Outstanding,
+			LateFee:              b.LateFee,
+			OverdueAt:            b.OverdueAt,
+			PickupTime:           b.PickupTime,
+			ReturnTime:           b.ReturnTime,
 			Status:               b.Status,
 			LockedUntil:          lockedUntilPtr,
 			TimeRemainingMinutes: b.TimeRemainingMinutes,
@@ -327,6 +537,11 @@ func (r *hosterBookingRepository) GetBookingDetail(bookingID string) (*dto.Booki
 		},
 	}
 
+	// Versi T&C yang disetujui customer saat booking
+	if detail.AcceptedTerms, err = getAcceptedTerms(r.db, bookingID); err != nil {
+		return nil, err
+	}
+
 	log.Printf("GetBookingDetail(hoster): success booking=%s", bookingID)
 	return detail, nil
 }
@@ -359,20 +574,52 @@ func (r *hosterBookingRepository) GetBookingStatus(bookingID string) (string, er
 }
 
 /*
-UpdateBookingStatus mengupdate status booking.
+UpdateBookingStatus mengupdate status booking beserta pengembalian unit fisiknya dalam satu transaksi.
+Serah terima (on_rent) tidak lewat sini, hanya lewat RedeemPickupCode.
 
 Parameter:
 - bookingID: UUID booking yang akan diupdate
-- newStatus: Status baru (on_progress, on_rent, completed)
+- currentStatus: Status yang sudah divalidasi service (harus masih sama saat baris dikunci)
+- newStatus: Status baru (on_progress, completed)
+- returns: kondisi unit saat completed (opsional)
+
+Alur kerja:
+1. Kunci baris booking (FOR UPDATE) dan pastikan status masih currentStatus, request ganda / scan pickup yang berjalan bersamaan tidak diproses dua kali
+2. Update status (pending → on_progress: locked_until di-set ke NOW())
+3. completed → tutup alokasi dan kembalikan unit ke available (damaged → maintenance)
 
 Output:
 - nil - Sukses update
-- error - Gagal update (booking not found atau query error)
+- sql.ErrNoRows - booking tidak ditemukan
+- errors.New("status changed") - status booking sudah diubah request lain
+- errors.New("invalid units") - unit yang dikembalikan bukan bagian dari booking ini
+- error - query gagal
 
 Note: Validasi business logic (apakah transisi valid) dilakukan di service layer.
-Jika status berubah dari pending ke on_progress, locked_until akan di-set ke NOW() (expired).
 */
-func (r *hosterBookingRepository) UpdateBookingStatus(bookingID, newStatus string) error {
+func (r *hosterBookingRepository) UpdateBookingStatus(bookingID, currentStatus, newStatus string, returns []dto.BookingUnitReturnRequest) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("UpdateBookingStatus: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var lockedStatus string
+	err = tx.Get(&lockedStatus, `SELECT status FROM booking WHERE id = $1 FOR UPDATE`, bookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("UpdateBookingStatus: booking not found: %s", bookingID)
+		} else {
+			log.Printf("UpdateBookingStatus: lock error booking=%s err=%v", bookingID, err)
+		}
+		return err
+	}
+	if lockedStatus != currentStatus {
+		log.Printf("UpdateBookingStatus: status changed booking=%s expected=%s actual=%s", bookingID, currentStatus, lockedStatus)
+		return errors.New("status changed")
+	}
+
 	query := `
 		UPDATE booking 
 		SET status = $1, 
@@ -384,7 +631,7 @@ func (r *hosterBookingRepository) UpdateBookingStatus(bookingID, newStatus strin
 		WHERE id = $3
 	`
 
-	result, err := r.db.Exec(query, newStatus, newStatus, bookingID)
+	result, err := tx.Exec(query, newStatus, newStatus, bookingID)
 	if err != nil {
 		log.Printf("UpdateBookingStatus: exec error: %v", err)
 		return err
@@ -401,6 +648,316 @@ func (r *hosterBookingRepository) UpdateBookingStatus(bookingID, newStatus strin
 		return sql.ErrNoRows
 	}
 
+	if newStatus == "completed" {
+		if err := returnBookingUnits(tx, bookingID, returns); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("UpdateBookingStatus: error committing transaction: %v", err)
+		return err
+	}
+
 	log.Printf("UpdateBookingStatus: success booking=%s new_status=%s", bookingID, newStatus)
 	return nil
 }
+
+/*
+RedeemPickupCode menukar kode pickup: booking on_progress milik hoster langsung menjadi on_rent dalam satu transaksi.
+
+Alur kerja:
+1. Kunci baris booking milik hoster (FOR UPDATE) agar scan ganda tidak diproses dua kali
+2. Cocokkan nonce, pastikan kode belum dipakai dan booking masih on_progress
+3. Update status on_rent + pickup_code_used_at
+4. Alokasikan unit untuk item dengan unit tracking (pilihan hoster, kosong → otomatis)
+
+Output:
+- nil - Sukses
+- sql.ErrNoRows - booking tidak ada / bukan milik hoster / nonce tidak cocok
+- errors.New("used") - kode sudah dipakai
+- errors.New("invalid status") - booking bukan on_progress
+- errors.New("units unavailable") - unit available kurang dari quantity / unit pilihan tidak available
+- errors.New("invalid units") - booking_item / unit pilihan bukan bagian dari booking ini
+- error - query gagal
+*/
+func (r *hosterBookingRepository) RedeemPickupCode(hosterID, bookingID, nonce string, units []dto.BookingUnitAllocationRequest) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("RedeemPickupCode: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var booking struct {
+		Status           string     `db:"status"`
+		PickupCodeNonce  string     `db:"pickup_code_nonce"`
+		PickupCodeUsedAt *time.Time `db:"pickup_code_used_at"`
+	}
+	err = tx.Get(&booking, `
+		SELECT status, pickup_code_nonce, pickup_code_used_at
+		FROM booking
+		WHERE id = $1 AND hoster_id = $2
+		FOR UPDATE
+	`, bookingID, hosterID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("RedeemPickupCode: select error booking=%s err=%v", bookingID, err)
+		}
+		return err
+	}
+	switch {
+	case booking.PickupCodeNonce != nonce:
+		return sql.ErrNoRows
+	case booking.PickupCodeUsedAt != nil:
+		return errors.New("used")
+	case booking.Status != "on_progress":
+		return errors.New("invalid status")
+	}
+
+	_, err = tx.Exec(`
+		UPDATE booking
+		SET status = 'on_rent', pickup_code_used_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, bookingID)
+	if err != nil {
+		log.Printf("RedeemPickupCode: update error booking=%s err=%v", bookingID, err)
+		return err
+	}
+
+	if err := allocateBookingUnits(tx, bookingID, units); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("RedeemPickupCode: error committing transaction: %v", err)
+		return err
+	}
+
+	log.Printf("RedeemPickupCode: success booking=%s hoster=%s", bookingID, hosterID)
+	return nil
+}
+
+/*
+allocateBookingUnits mengalokasikan unit available ke booking_item yang item-nya memakai unit tracking.
+
+Alur kerja:
+1. Ambil booking_item dengan unit tracking, pilihan unit untuk item lain ditolak
+2. Unit dipilih hoster → harus tepat sejumlah quantity, milik item yang sama, dan available
+3. Tanpa pilihan → ambil unit available terbaik (kondisi good dulu, lalu label)
+4. Catat alokasi, ubah status unit → rented, dan catat riwayat "rented"
+*/
+func allocateBookingUnits(tx *sqlx.Tx, bookingID string, requested []dto.BookingUnitAllocationRequest) error {
+	var lines []struct {
+		ID       string `db:"id"`
+		ItemID   string `db:"item_id"`
+		Quantity int    `db:"quantity"`
+	}
+	err := tx.Select(&lines, `
+		SELECT bi.id, bi.item_id, bi.quantity
+		FROM booking_item bi
+		JOIN item i ON i.id = bi.item_id
+		WHERE bi.booking_id = $1 AND i.unit_tracking
+		ORDER BY bi.id
+	`, bookingID)
+	if err != nil {
+		log.Printf("allocateBookingUnits: query items error booking=%s err=%v", bookingID, err)
+		return err
+	}
+
+	chosen := make(map[string][]string, len(requested))
+	for _, req := range requested {
+		chosen[req.BookingItemID] = append(chosen[req.BookingItemID], req.UnitIDs...)
+	}
+	tracked := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		tracked[line.ID] = true
+	}
+	for bookingItemID := range chosen {
+		if !tracked[bookingItemID] {
+			return errors.New("invalid units")
+		}
+	}
+
+	for _, line := range lines {
+		var unitIDs []string
+		if ids, ok := chosen[line.ID]; ok {
+			if len(ids) != line.Quantity {
+				return errors.New("invalid units")
+			}
+			err = tx.Select(&unitIDs, `
+				SELECT id FROM item_unit
+				WHERE id = ANY($1::uuid[]) AND item_id = $2 AND status = 'available'
+				FOR UPDATE
+			`, pq.Array(ids), line.ItemID)
+		} else {
+			err = tx.Select(&unitIDs, `
+				SELECT id FROM item_unit
+				WHERE item_id = $1 AND status = 'available'
+				ORDER BY CASE condition WHEN 'good' THEN 0 WHEN 'fair' THEN 1 ELSE 2 END, label
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			`, line.ItemID, line.Quantity)
+		}
+		if err != nil {
+			log.Printf("allocateBookingUnits: select units error booking_item=%s err=%v", line.ID, err)
+			return err
+		}
+		if len(unitIDs) < line.Quantity {
+			log.Printf("allocateBookingUnits: booking_item=%s needs %d units, %d available", line.ID, line.Quantity, len(unitIDs))
+			return errors.New("units unavailable")
+		}
+
+		_, err = tx.Exec(`
+			WITH allocated AS (
+				INSERT INTO booking_item_unit (booking_item_id, booking_id, unit_id)
+				SELECT $1, $2, unnest($3::uuid[])
+				RETURNING unit_id
+			),
+			rented AS (
+				UPDATE item_unit u
+				SET status = 'rented', updated_at = NOW()
+				FROM allocated
+				WHERE u.id = allocated.unit_id
+				RETURNING u.id, u.condition
+			)
+			INSERT INTO item_unit_event (unit_id, booking_id, kind, condition, status)
+			SELECT id, $2, 'rented', condition, 'rented'
+			FROM rented
+		`, line.ID, bookingID, pq.Array(unitIDs))
+		if err != nil {
+			log.Printf("allocateBookingUnits: allocate error booking_item=%s err=%v", line.ID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+returnBookingUnits menutup alokasi unit booking saat selesai.
+
+Alur kerja:
+1. Kunci alokasi terbuka booking, kondisi untuk unit di luar booking ditolak
+2. Unit kembali available dengan kondisi baru (jika diisi); damaged → maintenance
+3. Catat riwayat "returned" beserta catatan, lalu sinkronkan stock item
+*/
+func returnBookingUnits(tx *sqlx.Tx, bookingID string, returns []dto.BookingUnitReturnRequest) error {
+	var open []struct {
+		ID        string `db:"id"`
+		UnitID    string `db:"unit_id"`
+		ItemID    string `db:"item_id"`
+		Condition string `db:"condition"`
+	}
+	err := tx.Select(&open, `
+		SELECT biu.id, biu.unit_id, u.item_id, u.condition
+		FROM booking_item_unit biu
+		JOIN item_unit u ON u.id = biu.unit_id
+		WHERE biu.booking_id = $1 AND biu.returned_at IS NULL
+		FOR UPDATE OF biu, u
+	`, bookingID)
+	if err != nil {
+		log.Printf("returnBookingUnits: query error booking=%s err=%v", bookingID, err)
+		return err
+	}
+
+	allocated := make(map[string]bool, len(open))
+	for _, allocation := range open {
+		allocated[allocation.UnitID] = true
+	}
+	byUnit := make(map[string]dto.BookingUnitReturnRequest, len(returns))
+	for _, ret := range returns {
+		if !allocated[ret.UnitID] {
+			return errors.New("invalid units")
+		}
+		byUnit[ret.UnitID] = ret
+	}
+
+	items := make(map[string]bool)
+	for _, allocation := range open {
+		condition, note := allocation.Condition, ""
+		if ret, ok := byUnit[allocation.UnitID]; ok {
+			if ret.Condition != "" {
+				condition = ret.Condition
+			}
+			note = ret.Note
+		}
+		status := domain.UnitStatusAvailable
+		if condition == domain.UnitConditionDamaged {
+			status = domain.UnitStatusMaintenance
+		}
+
+		_, err := tx.Exec(`UPDATE booking_item_unit SET returned_at = NOW() WHERE id = $1`, allocation.ID)
+		if err != nil {
+			log.Printf("returnBookingUnits: close allocation error id=%s err=%v", allocation.ID, err)
+			return err
+		}
+		_, err = tx.Exec(`
+			UPDATE item_unit SET status = $2, condition = $3, updated_at = NOW()
+			WHERE id = $1
+		`, allocation.UnitID, status, condition)
+		if err != nil {
+			log.Printf("returnBookingUnits: update unit error unit=%s err=%v", allocation.UnitID, err)
+			return err
+		}
+		_, err = tx.Exec(`
+			INSERT INTO item_unit_event (unit_id, booking_id, kind, condition, status, note)
+			VALUES ($1, $2, 'returned', $3, $4, NULLIF($5, ''))
+		`, allocation.UnitID, bookingID, condition, status, note)
+		if err != nil {
+			log.Printf("returnBookingUnits: insert event error unit=%s err=%v", allocation.UnitID, err)
+			return err
+		}
+		items[allocation.ItemID] = true
+	}
+
+	// Unit yang masuk maintenance tidak lagi dihitung sebagai stock
+	for itemID := range items {
+		_, err := tx.Exec(`
+			UPDATE item
+			SET stock = (
+				SELECT COUNT(*) FROM item_unit
+				WHERE item_id = $1 AND status IN ('available', 'rented')
+			), updated_at = NOW()
+			WHERE id = $1 AND unit_tracking
+		`, itemID)
+		if err != nil {
+			log.Printf("returnBookingUnits: sync stock error item=%s err=%v", itemID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+getAcceptedTerms mengambil versi T&C yang disetujui customer untuk satu booking.
+
+Output sukses:
+- ([]dto.TnCVersionResponse, nil) → slice kosong untuk booking sebelum T&C berversi
+Output error:
+- (nil, error) → query gagal
+*/
+func getAcceptedTerms(db *sqlx.DB, bookingID string) ([]dto.TnCVersionResponse, error) {
+	var rows []struct {
+		dto.TnCVersionResponse
+		Description pq.StringArray `db:"description"`
+	}
+	err := db.Select(&rows, `
+		SELECT v.id, v.version, v.tenant_id, v.item_id, v.created_by_member_id, v.created_at, a.accepted_at,
+		       ARRAY(SELECT jsonb_array_elements_text(v.description)) AS description
+		FROM booking_tnc_acceptance a
+		INNER JOIN tnc_version v ON v.id = a.tnc_version_id
+		WHERE a.booking_id = $1
+		ORDER BY v.item_id NULLS FIRST, v.created_at
+	`, bookingID)
+	if err != nil {
+		log.Printf("getAcceptedTerms(hoster): query error booking=%s err=%v", bookingID, err)
+		return nil, err
+	}
+
+	terms := make([]dto.TnCVersionResponse, len(rows))
+	for i, row := range rows {
+		terms[i] = row.TnCVersionResponse
+		terms[i].Description = row.Description
+	}
+	return terms, nil
+}
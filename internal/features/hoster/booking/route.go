@@ -1,6 +1,7 @@
 package booking
 
 import (
+	"lalan-be/internal/domain"
 	"lalan-be/internal/middleware"
 	"net/http"
 
@@ -14,9 +15,14 @@ Alur kerja:
 1. Buat subrouter dengan prefix /api/v1/hoster
 2. Terapkan middleware JWT → Hoster (protected route)
 3. Daftarkan endpoint:
-  - GET  /booking          → daftar semua booking milik hoster
+  - GET  /booking          → daftar booking milik hoster (filter, sort, cursor pagination)
+  - GET  /booking/export   → export booking terfilter ke CSV / XLSX
+  - GET  /booking/overdue  → booking on_rent yang melewati batas pengembalian + denda
   - GET  /booking/{id}     → detail satu booking
   - PUT  /booking/{id}/status → update status booking
+  - POST /booking/pickup/verify → verifikasi kode pickup (scan QR) → booking on_rent
+
+4. Permission role toko: bookings:view (list/detail), bookings:update_status (status), revenue:view (export)
 
 Output:
 - Router terkonfigurasi dengan endpoint hoster yang aman dan siap digunakan
@@ -29,10 +35,13 @@ func SetupBookingRoutes(router *mux.Router, h *HosterBookingHandler) {
 	protected.Use(middleware.Hoster)
 
 	// Route normal
-	protected.HandleFunc("/booking", h.GetListBooking).Methods("GET", "OPTIONS")
-	protected.HandleFunc("/booking/{id}", h.GetDetailBooking).Methods("GET", "OPTIONS")
-	protected.HandleFunc("/booking/status/{id}", h.UpdateBookingStatus).Methods("PUT", "OPTIONS")
-	protected.HandleFunc("/customer", h.GetCustomerList).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/booking", middleware.HosterPermission(domain.HosterPermBookingsView, h.GetListBooking)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/booking/export", middleware.HosterPermission(domain.HosterPermRevenueView, h.ExportBookings)).Methods("GET", "OPTIONS") // Harus sebelum /booking/{id}
+	protected.HandleFunc("/booking/overdue", middleware.HosterPermission(domain.HosterPermBookingsView, h.GetOverdueBookings)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/booking/{id}", middleware.HosterPermission(domain.HosterPermBookingsView, h.GetDetailBooking)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/booking/status/{id}", middleware.HosterPermission(domain.HosterPermBookingsUpdate, h.UpdateBookingStatus)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/booking/pickup/verify", middleware.HosterPermission(domain.HosterPermBookingsUpdate, h.VerifyPickupCode)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/customer", middleware.HosterPermission(domain.HosterPermBookingsView, h.GetCustomerList)).Methods("GET", "OPTIONS")
 
 	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
 	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -2,12 +2,23 @@ package booking
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
 	dto "lalan-be/internal/dto"
 	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
+
+	"github.com/google/uuid"
 )
 
 /*
@@ -15,19 +26,39 @@ BookingService adalah kontrak untuk logika bisnis domain booking dari perspektif
 Hanya menyediakan operasi read (list & detail) — hoster tidak bisa membuat booking.
 */
 type BookingService interface {
-	GetListBookings(hosterID string) ([]dto.BookingListByHosterResponse, error)
+	GetListBookings(hosterID string, filter dto.BookingListFilterByHosterRequest) (*dto.BookingListPageByHosterResponse, error)
+	ExportBookings(hosterID string, filter dto.BookingListFilterByHosterRequest, format string, open func() io.Writer) error
 	// GetCustomerList returns customers who placed bookings on the given hoster.
 	GetCustomerList(hosterID string) ([]dto.CustomerListByHosterResponse, error)
 	GetDetailBooking(hosterID, bookingID string) (*dto.BookingDetailByHosterResponse, error)
-	UpdateBookingStatus(hosterID, bookingID, newStatus string) error
+	UpdateBookingStatus(hosterID, bookingID string, req dto.UpdateBookingStatusByHosterRequest) error
+	VerifyPickupCode(hosterID string, req dto.VerifyPickupCodeByHosterRequest) (*dto.BookingDetailByHosterResponse, error)
+	GetOverdueBookings(hosterID string) ([]dto.OverdueBookingResponse, error)
+}
+
+/*
+BookingLedgerPoster adalah kontrak posting ledger booking (diimplementasikan repository ledger hoster).
+*/
+type BookingLedgerPoster interface {
+	PostBookingLedger(bookingID string, commissionRateBps int) (int, error)
+}
+
+/*
+BookingLateFeeAccruer adalah kontrak akrual denda keterlambatan booking (diimplementasikan repository late fee hoster).
+*/
+type BookingLateFeeAccruer interface {
+	AccrueLateFees(bookingID string) (int, error)
 }
 
 /*
 bookingService adalah implementasi konkret dari BookingService.
-Mengandung dependency ke repository untuk akses data.
+Mengandung dependency ke repository untuk akses data, ledger untuk posting payout hoster,
+dan late fee untuk akrual denda terakhir saat barang dikembalikan.
 */
 type bookingService struct {
-	repo HosterBookingRepository
+	repo     HosterBookingRepository
+	ledger   BookingLedgerPoster
+	lateFees BookingLateFeeAccruer
 }
 
 /*
@@ -36,35 +67,293 @@ NewBookingService membuat instance service dengan dependency injection.
 Output:
 - BookingService siap digunakan
 */
-func NewBookingService(repo HosterBookingRepository) BookingService {
-	return &bookingService{repo: repo}
+func NewBookingService(repo HosterBookingRepository, ledger BookingLedgerPoster, lateFees BookingLateFeeAccruer) BookingService {
+	return &bookingService{repo: repo, ledger: ledger, lateFees: lateFees}
+}
+
+/*
+Konstanta pagination list booking hoster dan validasi catatan unit.
+*/
+const (
+	DefaultListLimit  = 20
+	MaxListLimit      = 100
+	MaxUnitNoteLength = 1000
+)
+
+/*
+bookingSorts memetakan nilai query sort ke kolom BookingSort.
+*/
+var bookingSorts = map[string]BookingSort{
+	"created_at": SortByCreatedAt,
+	"start_date": SortByStartDate,
+	"total":      SortByTotal,
+}
+
+/*
+bookingStatuses adalah status booking yang boleh dipakai sebagai filter.
+*/
+var bookingStatuses = map[string]bool{
+	"pending":     true,
+	"on_progress": true,
+	"on_rent":     true,
+	"completed":   true,
+	"cancelled":   true,
+	"rejected":    true,
+}
+
+/*
+bookingCursor adalah isi cursor (base64 JSON) list booking hoster.
+Sort & order ikut disimpan agar cursor tidak dipakai dengan urutan yang berbeda.
+*/
+type bookingCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
 }
 
 /*
-GetListBookings mengambil daftar ringkas semua booking milik hoster.
+GetListBookings mengambil daftar booking milik hoster dengan filter, sort, dan cursor pagination.
 
 Alur kerja:
-1. Validasi hosterID tidak kosong
-2. Panggil repository
-3. Wrap error menjadi InternalError
+1. Validasi hosterID dan filter (status, tanggal, item, customer, sort, cursor)
+2. Ambil limit+1 baris dari repository untuk mendeteksi halaman berikutnya
+3. Buat next_cursor dari baris terakhir halaman ini
 
 Output sukses:
-- ([]dto.BookingListHosterDTO, nil)
+- (*dto.BookingListPageByHosterResponse, nil)
 Output error:
-- (nil, error) → unauthorized / internal error
+- (nil, error) → unauthorized / BookingInvalidFilter / BookingInvalidCursor / internal error
 */
-func (s *bookingService) GetListBookings(hosterID string) ([]dto.BookingListByHosterResponse, error) {
-	if hosterID == "" {
-		return nil, errors.New(message.Unauthorized)
+func (s *bookingService) GetListBookings(hosterID string, filter dto.BookingListFilterByHosterRequest) (*dto.BookingListPageByHosterResponse, error) {
+	q, err := buildListQuery(hosterID, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	bookings, err := s.repo.GetListBookings(hosterID)
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+	q.Limit = limit + 1
+
+	bookings, err := s.repo.GetListBookings(q)
 	if err != nil {
 		log.Printf("GetListBookings(hoster service): repo error for hoster %s: %v", hosterID, err)
 		return nil, errors.New(message.InternalError)
 	}
 
-	return bookings, nil
+	page := &dto.BookingListPageByHosterResponse{Items: bookings, Limit: limit}
+	if len(bookings) > limit {
+		page.Items = bookings[:limit]
+		page.HasMore = true
+		page.NextCursor = encodeBookingCursor(filter, page.Items[limit-1])
+	}
+
+	return page, nil
+}
+
+/*
+ExportBookings menulis seluruh booking hoster sesuai filter ke CSV / XLSX secara streaming.
+
+Alur kerja:
+1. Validasi format dan filter (limit & cursor diabaikan, export selalu dari awal)
+2. Panggil open() untuk mendapatkan writer (handler mengirim header HTTP di sini)
+3. Tulis header kolom lalu setiap baris dari repository langsung ke writer
+
+Output sukses:
+- nil
+Output error:
+- error → unauthorized / BookingInvalidExport / BookingInvalidFilter / internal error
+*/
+func (s *bookingService) ExportBookings(hosterID string, filter dto.BookingListFilterByHosterRequest, format string, open func() io.Writer) error {
+	if format != "csv" && format != "xlsx" {
+		return errors.New(message.BookingInvalidExport)
+	}
+
+	filter.Cursor = ""
+	q, err := buildListQuery(hosterID, filter)
+	if err != nil {
+		return err
+	}
+
+	writer, err := utils.NewRowWriter(format, open(), "Bookings")
+	if err != nil {
+		log.Printf("ExportBookings(hoster service): writer error hoster=%s err=%v", hosterID, err)
+		return errors.New(message.InternalError)
+	}
+
+	if err := writer.WriteRow(
+		"Booking ID", "Created At", "Status", "Customer Name", "Customer Phone", "Customer Email",
+		"Items", "Quantity", "Start Date", "End Date", "Total Days", "Delivery Type",
+		"Rental", "Deposit", "Discount", "Total", "Outstanding",
+	); err != nil {
+		return errors.New(message.InternalError)
+	}
+
+	count := 0
+	err = s.repo.StreamBookings(q, func(row dto.BookingExportRowByHosterResponse) error {
+		count++
+		return writer.WriteRow(
+			row.BookingID, row.CreatedAt, row.Status, row.CustomerName, row.CustomerPhone, row.CustomerEmail,
+			row.ItemName, row.Quantity, row.StartDate, row.EndDate, row.TotalDays, row.DeliveryType,
+			row.Rental, row.Deposit, row.Discount, row.Total, row.Outstanding,
+		)
+	})
+	if err != nil {
+		log.Printf("ExportBookings(hoster service): stream error hoster=%s rows=%d err=%v", hosterID, count, err)
+		return errors.New(message.InternalError)
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Printf("ExportBookings(hoster service): close error hoster=%s err=%v", hosterID, err)
+		return errors.New(message.InternalError)
+	}
+
+	log.Printf("ExportBookings(hoster service): success hoster=%s format=%s rows=%d", hosterID, format, count)
+	return nil
+}
+
+/*
+buildListQuery memvalidasi filter list / export menjadi BookingListQuery.
+from & to inklusif (YYYY-MM-DD), disimpan sebagai [from, to+1 hari).
+*/
+func buildListQuery(hosterID string, filter dto.BookingListFilterByHosterRequest) (BookingListQuery, error) {
+	if hosterID == "" {
+		return BookingListQuery{}, errors.New(message.Unauthorized)
+	}
+
+	q := BookingListQuery{HosterID: hosterID}
+
+	for _, status := range filter.Status {
+		if !bookingStatuses[status] {
+			return BookingListQuery{}, errors.New(message.BookingInvalidFilter)
+		}
+		q.Statuses = append(q.Statuses, status)
+	}
+
+	if filter.From != "" {
+		from, err := time.Parse("2006-01-02", filter.From)
+		if err != nil {
+			return BookingListQuery{}, errors.New(message.BookingInvalidFilter)
+		}
+		q.From = &from
+	}
+	if filter.To != "" {
+		to, err := time.Parse("2006-01-02", filter.To)
+		if err != nil {
+			return BookingListQuery{}, errors.New(message.BookingInvalidFilter)
+		}
+		to = to.AddDate(0, 0, 1)
+		q.To = &to
+	}
+	if q.From != nil && q.To != nil && !q.From.Before(*q.To) {
+		return BookingListQuery{}, errors.New(message.BookingInvalidFilter)
+	}
+
+	for _, id := range []string{filter.ItemID, filter.CustomerID, filter.StoreID} {
+		if id == "" {
+			continue
+		}
+		if _, err := uuid.Parse(id); err != nil {
+			return BookingListQuery{}, errors.New(message.BookingInvalidFilter)
+		}
+	}
+	q.ItemID, q.CustomerID, q.StoreID = filter.ItemID, filter.CustomerID, filter.StoreID
+
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		q.Search = "%" + likeEscaper.Replace(search) + "%"
+	}
+
+	sortName := filter.Sort
+	if sortName == "" {
+		sortName = "created_at"
+	}
+	sort, ok := bookingSorts[sortName]
+	if !ok {
+		return BookingListQuery{}, errors.New(message.BookingInvalidFilter)
+	}
+	q.Sort = sort
+
+	switch filter.Order {
+	case "", "desc":
+		q.Desc = true
+	case "asc":
+	default:
+		return BookingListQuery{}, errors.New(message.BookingInvalidFilter)
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeBookingCursor(filter.Cursor)
+		if err != nil || cursor.Sort != sortName || cursor.Order != orderName(q.Desc) {
+			return BookingListQuery{}, errors.New(message.BookingInvalidCursor)
+		}
+		q.CursorValue, q.CursorID = cursor.Value, cursor.ID
+	}
+
+	return q, nil
+}
+
+/*
+likeEscaper meng-escape karakter wildcard ILIKE agar input pencarian dicari apa adanya.
+*/
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+/*
+orderName mengembalikan nama urutan ("asc" / "desc") untuk disimpan di cursor.
+*/
+func orderName(desc bool) string {
+	if desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+/*
+encodeBookingCursor membuat cursor dari baris terakhir halaman.
+Nilai waktu disimpan sesuai tipe kolomnya (created_at tanpa zona waktu, start_date dengan zona waktu).
+*/
+func encodeBookingCursor(filter dto.BookingListFilterByHosterRequest, last dto.BookingListByHosterResponse) string {
+	cursor := bookingCursor{Sort: filter.Sort, Order: orderName(filter.Order != "asc"), ID: last.BookingID}
+	if cursor.Sort == "" {
+		cursor.Sort = "created_at"
+	}
+
+	switch cursor.Sort {
+	case "start_date":
+		cursor.Value = last.StartDate.Format(time.RFC3339Nano)
+	case "total":
+		cursor.Value = strconv.Itoa(int(last.Total))
+	default:
+		cursor.Value = last.CreatedAt.Format("2006-01-02T15:04:05.999999")
+	}
+
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+/*
+decodeBookingCursor membaca cursor dan memastikan ID booking berupa UUID valid.
+*/
+func decodeBookingCursor(value string) (bookingCursor, error) {
+	var cursor bookingCursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return cursor, err
+	}
+	if _, err := uuid.Parse(cursor.ID); err != nil {
+		return cursor, err
+	}
+	if cursor.Value == "" {
+		return cursor, errors.New("empty cursor value")
+	}
+	return cursor, nil
 }
 
 // GetCustomerList returns the list of customers who have made bookings on hosterID.
@@ -82,6 +371,29 @@ func (s *bookingService) GetCustomerList(hosterID string) ([]dto.CustomerListByH
 	return list, nil
 }
 
+/*
+GetOverdueBookings mengambil booking on_rent milik hoster yang melewati batas pengembalian.
+
+Output sukses:
+- ([]dto.OverdueBookingResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → unauthorized / internal error
+*/
+func (s *bookingService) GetOverdueBookings(hosterID string) ([]dto.OverdueBookingResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	list, err := s.repo.GetOverdueBookings(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if list == nil {
+		list = []dto.OverdueBookingResponse{}
+	}
+	return list, nil
+}
+
 /*
 GetDetailBooking mengambil detail lengkap satu booking milik hoster.
 
@@ -129,34 +441,45 @@ UpdateBookingStatus mengupdate status booking dengan validasi business logic.
 Parameter:
 - hosterID: UUID hoster yang login (untuk authorization)
 - bookingID: UUID booking yang akan diupdate
-- newStatus: Status baru (on_progress, on_rent, completed)
+- req: Status baru (on_progress, completed) + kondisi unit kembali (completed)
 
 Alur kerja:
 1. Validasi hosterID tidak kosong
 2. Ambil status booking saat ini
 3. Validasi authorization: pastikan booking milik hoster ini
 4. Validasi status transition (sequential only)
-5. Update status di repository
+5. Validasi returns (hanya completed)
+6. completed → akrual denda keterlambatan terakhir (sampai waktu pengembalian) ke outstanding
+7. Update status + pengembalian unit di repository (status dicek ulang di dalam transaksi)
+8. Posting ledger payout (on_progress → pembayaran, completed → pendapatan sewa + pengembalian deposit)
+
+Gagal posting ledger hanya dicatat di log (status tetap berubah), admin bisa mengulang lewat POST /api/v1/admin/settlement/sync.
 
 Valid transitions:
 - pending → on_progress
-- on_progress → on_rent
 - on_rent → completed
 
+on_progress → on_rent hanya lewat VerifyPickupCode, agar barang tidak bisa diserahkan tanpa verifikasi kode pickup customer.
+
 Output sukses:
 - nil
 Output error:
-- error → unauthorized / not found / invalid transition / internal error
+- error → unauthorized / not found / invalid transition / PickupCodeRequired / BookingStatusChanged / internal error
 */
-func (s *bookingService) UpdateBookingStatus(hosterID, bookingID, newStatus string) error {
+func (s *bookingService) UpdateBookingStatus(hosterID, bookingID string, req dto.UpdateBookingStatusByHosterRequest) error {
 	if hosterID == "" {
 		return errors.New(message.Unauthorized)
 	}
+	newStatus := req.Status
+
+	// Serah terima barang wajib lewat scan kode pickup
+	if newStatus == "on_rent" {
+		return errors.New(message.PickupCodeRequired)
+	}
 
 	// Validasi newStatus harus salah satu dari status yang valid
 	validStatuses := map[string]bool{
 		"on_progress": true,
-		"on_rent":     true,
 		"completed":   true,
 	}
 	if !validStatuses[newStatus] {
@@ -184,9 +507,8 @@ func (s *bookingService) UpdateBookingStatus(hosterID, bookingID, newStatus stri
 
 	// Validasi status transition (must be sequential)
 	validTransitions := map[string]string{
-		"pending":     "on_progress",
-		"on_progress": "on_rent",
-		"on_rent":     "completed",
+		"pending": "on_progress",
+		"on_rent": "completed",
 	}
 
 	expectedNext, exists := validTransitions[currentStatus]
@@ -200,13 +522,139 @@ func (s *bookingService) UpdateBookingStatus(hosterID, bookingID, newStatus stri
 		return errors.New(message.InvalidStatus)
 	}
 
+	if err := validateUnitChanges(newStatus, nil, req.Returns); err != nil {
+		return err
+	}
+
+	// Denda dihitung sampai saat barang kembali, setelah completed detektor tidak menyentuh booking ini lagi
+	if newStatus == "completed" {
+		if _, err := s.lateFees.AccrueLateFees(bookingID); err != nil {
+			log.Printf("UpdateBookingStatus: late fee accrual failed booking=%s: %v", bookingID, err)
+			return errors.New(message.InternalError)
+		}
+	}
+
 	// Update status di repository
-	err = s.repo.UpdateBookingStatus(bookingID, newStatus)
+	err = s.repo.UpdateBookingStatus(bookingID, currentStatus, newStatus, req.Returns)
 	if err != nil {
 		log.Printf("UpdateBookingStatus: update error: %v", err)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf(message.NotFound, "booking")
+		}
+		switch err.Error() {
+		case "status changed":
+			return errors.New(message.BookingStatusChanged)
+		case "units unavailable":
+			return errors.New(message.BookingUnitsUnavailable)
+		case "invalid units":
+			return errors.New(message.BookingInvalidUnits)
+		}
 		return errors.New(message.InternalError)
 	}
 
 	log.Printf("UpdateBookingStatus: success booking=%s %s→%s", bookingID, currentStatus, newStatus)
+
+	if newStatus == "on_progress" || newStatus == "completed" {
+		if _, err := s.ledger.PostBookingLedger(bookingID, config.GetPlatformCommissionBps()); err != nil {
+			log.Printf("UpdateBookingStatus: ledger posting failed booking=%s status=%s: %v", bookingID, newStatus, err)
+		}
+	}
+	return nil
+}
+
+/*
+VerifyPickupCode memverifikasi kode pickup hasil scan QR customer dan menyerahkan barang.
+
+Alur kerja:
+1. Verifikasi tanda tangan kode → booking ID + nonce, validasi pilihan unit (opsional)
+2. Tukar kode di repository: booking harus milik hoster ini, on_progress, kode belum dipakai
+3. Booking menjadi on_rent (unit tracking sesuai pilihan hoster, kosong → dialokasikan otomatis)
+4. Kembalikan detail booking agar hoster bisa mencocokkan identitas customer
+
+Output sukses:
+- (*dto.BookingDetailByHosterResponse, nil)
+Output error:
+- message.PickupCodeInvalid / BookingInvalidUnits → 400
+- message.PickupCodeNotFound → 404 (termasuk booking milik hoster lain)
+- message.PickupCodeUsed / InvalidStatus / BookingUnitsUnavailable → 409
+- error lain → internal error
+*/
+func (s *bookingService) VerifyPickupCode(hosterID string, req dto.VerifyPickupCodeByHosterRequest) (*dto.BookingDetailByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	bookingID, nonce, err := utils.ParsePickupCode(req.Code, config.GetPickupCodeKey())
+	if err != nil {
+		log.Printf("VerifyPickupCode: invalid code hoster=%s", hosterID)
+		return nil, errors.New(message.PickupCodeInvalid)
+	}
+
+	if err := validateUnitChanges("on_rent", req.Units, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RedeemPickupCode(hosterID, bookingID, nonce, req.Units); err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			log.Printf("VerifyPickupCode: code does not match hoster=%s booking=%s", hosterID, bookingID)
+			return nil, errors.New(message.PickupCodeNotFound)
+		case err.Error() == "used":
+			return nil, errors.New(message.PickupCodeUsed)
+		case err.Error() == "invalid status":
+			return nil, errors.New(message.InvalidStatus)
+		case err.Error() == "units unavailable":
+			return nil, errors.New(message.BookingUnitsUnavailable)
+		case err.Error() == "invalid units":
+			return nil, errors.New(message.BookingInvalidUnits)
+		}
+		log.Printf("VerifyPickupCode: redeem error booking=%s err=%v", bookingID, err)
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("VerifyPickupCode: booking %s picked up (hoster=%s)", bookingID, hosterID)
+	return s.GetDetailBooking(hosterID, bookingID)
+}
+
+/*
+validateUnitChanges memvalidasi pilihan unit (on_rent) dan kondisi unit kembali (completed).
+Kepemilikan unit terhadap booking dicek di repository di dalam transaksi.
+
+Output:
+- nil → valid (boleh kosong)
+- error → BookingInvalidUnits
+*/
+func validateUnitChanges(newStatus string, units []dto.BookingUnitAllocationRequest, returns []dto.BookingUnitReturnRequest) error {
+	if (len(units) > 0 && newStatus != "on_rent") || (len(returns) > 0 && newStatus != "completed") {
+		return errors.New(message.BookingInvalidUnits)
+	}
+
+	seen := make(map[string]bool)
+	for _, allocation := range units {
+		if _, err := uuid.Parse(allocation.BookingItemID); err != nil || len(allocation.UnitIDs) == 0 {
+			return errors.New(message.BookingInvalidUnits)
+		}
+		for _, unitID := range allocation.UnitIDs {
+			if _, err := uuid.Parse(unitID); err != nil || seen[unitID] {
+				return errors.New(message.BookingInvalidUnits)
+			}
+			seen[unitID] = true
+		}
+	}
+
+	for _, ret := range returns {
+		if _, err := uuid.Parse(ret.UnitID); err != nil || seen[ret.UnitID] {
+			return errors.New(message.BookingInvalidUnits)
+		}
+		seen[ret.UnitID] = true
+		switch ret.Condition {
+		case "", domain.UnitConditionGood, domain.UnitConditionFair, domain.UnitConditionDamaged:
+		default:
+			return errors.New(message.BookingInvalidUnits)
+		}
+		if len(ret.Note) > MaxUnitNoteLength {
+			return errors.New(message.BookingInvalidUnits)
+		}
+	}
 	return nil
 }
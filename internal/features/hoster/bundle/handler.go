@@ -0,0 +1,157 @@
+package bundle
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterBundleHandler menangani endpoint HTTP paket sewa untuk hoster.
+*/
+type HosterBundleHandler struct {
+	service BundleService
+}
+
+/*
+NewHosterBundleHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterBundleHandler siap digunakan
+*/
+func NewHosterBundleHandler(s BundleService) *HosterBundleHandler {
+	return &HosterBundleHandler{service: s}
+}
+
+/*
+ListBundles menangani GET /api/v1/hoster/bundle?store_id=uuid
+
+Output sukses:
+- 200 OK + daftar paket beserta komponen
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 500 Internal Server Error
+*/
+func (h *HosterBundleHandler) ListBundles(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	result, err := h.service.ListBundles(hosterID, r.URL.Query().Get("store_id"))
+	if err != nil {
+		log.Printf("ListBundles handler: service error hoster=%s err=%v", hosterID, err)
+		writeBundleError(w, err)
+		return
+	}
+	response.OK(w, result, message.BundleRetrieved)
+}
+
+/*
+GetBundle menangani GET /api/v1/hoster/bundle/{id}
+
+Output sukses:
+- 200 OK + detail paket
+Output error:
+- 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterBundleHandler) GetBundle(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	result, err := h.service.GetBundle(hosterID, mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetBundle handler: service error hoster=%s err=%v", hosterID, err)
+		writeBundleError(w, err)
+		return
+	}
+	response.OK(w, result, message.BundleRetrieved)
+}
+
+/*
+CreateBundle menangani POST /api/v1/hoster/bundle
+
+Output sukses:
+- 201 Created + detail paket
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 404 Not Found (store) / 500 Internal Server Error
+*/
+func (h *HosterBundleHandler) CreateBundle(w http.ResponseWriter, r *http.Request) {
+	var req dto.BundleByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("CreateBundle: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	hosterID := middleware.GetUserID(r)
+	result, err := h.service.CreateBundle(hosterID, req)
+	if err != nil {
+		log.Printf("CreateBundle handler: service error hoster=%s err=%v", hosterID, err)
+		writeBundleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusCreated, result, message.BundleCreated)
+}
+
+/*
+UpdateBundle menangani PUT /api/v1/hoster/bundle/{id}
+
+Output sukses:
+- 200 OK + detail paket terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterBundleHandler) UpdateBundle(w http.ResponseWriter, r *http.Request) {
+	var req dto.BundleByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("UpdateBundle: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	hosterID := middleware.GetUserID(r)
+	result, err := h.service.UpdateBundle(hosterID, mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("UpdateBundle handler: service error hoster=%s err=%v", hosterID, err)
+		writeBundleError(w, err)
+		return
+	}
+	response.OK(w, result, message.BundleUpdated)
+}
+
+/*
+DeleteBundle menangani DELETE /api/v1/hoster/bundle/{id}
+
+Output sukses:
+- 200 OK
+Output error:
+- 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterBundleHandler) DeleteBundle(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if err := h.service.DeleteBundle(hosterID, mux.Vars(r)["id"]); err != nil {
+		log.Printf("DeleteBundle handler: service error hoster=%s err=%v", hosterID, err)
+		writeBundleError(w, err)
+		return
+	}
+	response.OK(w, nil, message.BundleDeleted)
+}
+
+/*
+writeBundleError memetakan error service paket sewa ke HTTP response.
+*/
+func writeBundleError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.BundleNotFound, message.StoreNotFound:
+		response.NotFound(w, err.Error())
+	case message.BundleInvalid, message.BundleItemInvalid, message.StoreInvalidID:
+		response.BadRequest(w, err.Error())
+	case message.HosterUnverifiedItemLimit:
+		response.Forbidden(w, message.HosterUnverifiedItemLimit)
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
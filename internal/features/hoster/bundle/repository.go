@@ -0,0 +1,459 @@
+package bundle
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+BundleRepository adalah kontrak akses data paket sewa untuk hoster.
+*/
+type BundleRepository interface {
+	ResolveStoreID(hosterID, storeID string) (string, error)
+	GetComponentItems(hosterID string, itemIDs []string) ([]domain.BundleItem, error)
+	ListBundles(hosterID, storeID string) ([]dto.BundleByHosterResponse, error)
+	GetBundle(hosterID, bundleID string) (*dto.BundleByHosterResponse, error)
+	CreateBundle(bundle *domain.Bundle, maxActive int) error
+	UpdateBundle(bundle *domain.Bundle, maxActive int) error
+	DeleteBundle(hosterID, bundleID string) error
+	GetActiveItemQuota(hosterID, excludeBundleID string) (bool, int, error) // Status verifikasi toko + jumlah item & paket aktif
+}
+
+/*
+bundleRepository adalah implementasi repository paket sewa hoster.
+*/
+type bundleRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewBundleRepository membuat instance repository dengan koneksi database.
+
+Output:
+- BundleRepository siap digunakan
+*/
+func NewBundleRepository(db *sqlx.DB) BundleRepository {
+	return &bundleRepository{db: db}
+}
+
+/*
+GetActiveItemQuota mengambil status verifikasi toko dan jumlah listing aktif (item + paket dengan is_hidden = false).
+excludeBundleID tidak ikut dihitung (dipakai saat paket itu sendiri diubah / ditampilkan kembali).
+
+Output sukses:
+- (is_verified, jumlah listing aktif, nil)
+Output error:
+- (false, 0, sql.ErrNoRows) → hoster tidak ditemukan
+- (false, 0, error) → query gagal
+*/
+func (r *bundleRepository) GetActiveItemQuota(hosterID, excludeBundleID string) (bool, int, error) {
+	var quota struct {
+		IsVerified  bool `db:"is_verified"`
+		ActiveItems int  `db:"active_items"`
+	}
+	query := `
+		SELECT
+			h.is_verified,
+			(
+				SELECT COUNT(*) FROM item i
+				WHERE i.hoster_id = h.id AND i.is_hidden = false
+			) + (
+				SELECT COUNT(*) FROM bundle bd
+				WHERE bd.hoster_id = h.id AND bd.is_hidden = false
+				  AND ($2 = '' OR bd.id::text <> $2)
+			) AS active_items
+		FROM hoster h
+		WHERE h.id = $1
+	`
+	if err := r.db.Get(&quota, query, hosterID, excludeBundleID); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("GetActiveItemQuota: query error hoster=%s: %v", hosterID, err)
+		}
+		return false, 0, err
+	}
+	return quota.IsVerified, quota.ActiveItems, nil
+}
+
+/*
+activeItemQuota adalah kuota listing aktif hoster yang dikunci di awal transaksi (sama dengan repository item).
+*/
+type activeItemQuota struct {
+	hosterID string
+	verified bool
+	before   int // Jumlah listing aktif sebelum transaksi mengubah data
+}
+
+/*
+countActiveListingsQuery menghitung listing aktif hoster: item + paket yang tidak di-hide.
+*/
+const countActiveListingsQuery = `
+	SELECT
+		(SELECT COUNT(*) FROM item WHERE hoster_id = $1 AND is_hidden = false)
+		+ (SELECT COUNT(*) FROM bundle WHERE hoster_id = $1 AND is_hidden = false)
+`
+
+/*
+lockActiveItemQuota mengunci baris hoster (FOR UPDATE) lalu menghitung listing aktifnya,
+sehingga perubahan item & paket hoster yang sama antri di batas listing aktif.
+
+Output sukses:
+- (*activeItemQuota, nil)
+Output error:
+- (nil, sql.ErrNoRows) → hoster tidak ditemukan
+- (nil, error) → query gagal
+*/
+func lockActiveItemQuota(tx *sqlx.Tx, hosterID string) (*activeItemQuota, error) {
+	quota := &activeItemQuota{hosterID: hosterID}
+	if err := tx.Get(&quota.verified, `SELECT is_verified FROM hoster WHERE id = $1 FOR UPDATE`, hosterID); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("lockActiveItemQuota: lock hoster %s error: %v", hosterID, err)
+		}
+		return nil, err
+	}
+	if err := tx.Get(&quota.before, countActiveListingsQuery, hosterID); err != nil {
+		log.Printf("lockActiveItemQuota: count error hoster=%s: %v", hosterID, err)
+		return nil, err
+	}
+	return quota, nil
+}
+
+/*
+check memastikan perubahan di transaksi tidak menambah listing aktif toko belum terverifikasi melewati maxActive.
+
+Output:
+- nil → boleh commit
+- errors.New("active_item_limit") → batas terlewati
+- error → query gagal
+*/
+func (q *activeItemQuota) check(tx *sqlx.Tx, maxActive int) error {
+	if q.verified {
+		return nil
+	}
+	var after int
+	if err := tx.Get(&after, countActiveListingsQuery, q.hosterID); err != nil {
+		log.Printf("activeItemQuota.check: count error hoster=%s: %v", q.hosterID, err)
+		return err
+	}
+	if after > q.before && after > maxActive {
+		log.Printf("activeItemQuota.check: hoster %s unverified, active listings %d → %d (max %d)", q.hosterID, q.before, after, maxActive)
+		return errors.New("active_item_limit")
+	}
+	return nil
+}
+
+/*
+ResolveStoreID menentukan store paket: storeID kosong → store default hoster,
+storeID diisi → pastikan store milik hoster.
+
+Output sukses:
+- (tenant_id, nil)
+Output error:
+- ("", sql.ErrNoRows) → store tidak ada / bukan milik hoster
+- ("", error) → query gagal
+*/
+func (r *bundleRepository) ResolveStoreID(hosterID, storeID string) (string, error) {
+	var id string
+	query := `SELECT id FROM tenant WHERE hoster_id = $1 AND id = $2::uuid`
+	args := []interface{}{hosterID, storeID}
+	if storeID == "" {
+		query = `SELECT id FROM tenant WHERE hoster_id = $1 AND is_default`
+		args = args[:1]
+	}
+
+	if err := r.db.Get(&id, query, args...); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("ResolveStoreID(bundle): db error hoster=%s store=%s err=%v", hosterID, storeID, err)
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+/*
+GetComponentItems mengambil item milik hoster yang akan dijadikan komponen paket.
+Item yang tidak ditemukan / bukan milik hoster tidak ikut dikembalikan.
+
+Output sukses:
+- ([]domain.BundleItem, nil) → Quantity belum diisi
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *bundleRepository) GetComponentItems(hosterID string, itemIDs []string) ([]domain.BundleItem, error) {
+	var items []domain.BundleItem
+	err := r.db.Select(&items, `
+		SELECT id AS item_id, name, price_per_day, deposit, tenant_id, is_hidden,
+		       cardinality(variant_dimensions) > 0 AS has_variants
+		FROM item
+		WHERE hoster_id = $1 AND id = ANY($2::uuid[])
+	`, hosterID, pq.Array(itemIDs))
+	if err != nil {
+		log.Printf("GetComponentItems: query error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return items, nil
+}
+
+/*
+ListBundles mengambil semua paket milik hoster (opsional satu store) beserta komponennya.
+
+Output sukses:
+- ([]dto.BundleByHosterResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *bundleRepository) ListBundles(hosterID, storeID string) ([]dto.BundleByHosterResponse, error) {
+	bundles := []dto.BundleByHosterResponse{}
+	err := r.db.Select(&bundles, `
+		SELECT id, tenant_id, name, description, price_per_day, deposit, is_hidden, created_at, updated_at
+		FROM bundle
+		WHERE hoster_id = $1 AND ($2 = '' OR tenant_id::text = $2)
+		ORDER BY created_at DESC
+	`, hosterID, storeID)
+	if err != nil {
+		log.Printf("ListBundles: query error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return bundles, nil
+	}
+
+	ids := make([]string, len(bundles))
+	for i := range bundles {
+		ids[i] = bundles[i].ID
+	}
+	components, err := getBundleComponents(r.db, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bundles {
+		setBundleItems(&bundles[i], components[bundles[i].ID])
+	}
+	return bundles, nil
+}
+
+/*
+GetBundle mengambil satu paket milik hoster beserta komponennya.
+
+Output sukses:
+- (*dto.BundleByHosterResponse, nil)
+Output error:
+- (nil, sql.ErrNoRows) → paket tidak ditemukan / bukan milik hoster
+- (nil, error) → query gagal
+*/
+func (r *bundleRepository) GetBundle(hosterID, bundleID string) (*dto.BundleByHosterResponse, error) {
+	var bundle dto.BundleByHosterResponse
+	err := r.db.Get(&bundle, `
+		SELECT id, tenant_id, name, description, price_per_day, deposit, is_hidden, created_at, updated_at
+		FROM bundle
+		WHERE id = $1 AND hoster_id = $2
+	`, bundleID, hosterID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetBundle: query error bundle=%s err=%v", bundleID, err)
+		}
+		return nil, err
+	}
+
+	components, err := getBundleComponents(r.db, []string{bundleID})
+	if err != nil {
+		return nil, err
+	}
+	setBundleItems(&bundle, components[bundleID])
+	return &bundle, nil
+}
+
+/*
+getBundleComponents mengambil komponen beberapa paket sekaligus, dikelompokkan per bundle_id.
+*/
+func getBundleComponents(db *sqlx.DB, bundleIDs []string) (map[string][]dto.BundleItemResponse, error) {
+	var rows []struct {
+		BundleID string `db:"bundle_id"`
+		dto.BundleItemResponse
+		Photos pq.StringArray `db:"photos"`
+	}
+	err := db.Select(&rows, `
+		SELECT bi.bundle_id, bi.item_id, i.name, bi.quantity, i.stock, i.price_per_day, i.deposit,
+		       CASE WHEN i.photos IS NOT NULL THEN ARRAY(SELECT jsonb_array_elements_text(i.photos)) ELSE ARRAY[]::text[] END AS photos
+		FROM bundle_item bi
+		INNER JOIN item i ON i.id = bi.item_id
+		WHERE bi.bundle_id = ANY($1::uuid[])
+		ORDER BY i.name, bi.item_id
+	`, pq.Array(bundleIDs))
+	if err != nil {
+		log.Printf("getBundleComponents: query error err=%v", err)
+		return nil, err
+	}
+
+	components := make(map[string][]dto.BundleItemResponse, len(bundleIDs))
+	for _, row := range rows {
+		item := row.BundleItemResponse
+		item.Photos = row.Photos
+		components[row.BundleID] = append(components[row.BundleID], item)
+	}
+	return components, nil
+}
+
+/*
+setBundleItems mengisi komponen paket dan total harga komponen jika disewa terpisah.
+*/
+func setBundleItems(bundle *dto.BundleByHosterResponse, items []dto.BundleItemResponse) {
+	if items == nil {
+		items = []dto.BundleItemResponse{}
+	}
+	bundle.Items = items
+	bundle.ItemsValue = 0
+	for _, item := range items {
+		bundle.ItemsValue += item.PricePerDay * item.Quantity
+	}
+}
+
+/*
+CreateBundle menyimpan paket baru beserta komposisinya dalam satu transaksi.
+Baris hoster dikunci lebih dulu agar batas listing aktif toko belum terverifikasi (maxActive)
+tidak terlewati oleh create item / paket yang berjalan bersamaan.
+
+Output sukses:
+- nil (ID, CreatedAt, UpdatedAt terisi)
+Output error:
+- errors.New("active_item_limit") → toko belum terverifikasi sudah mencapai batas
+- error → query gagal
+*/
+func (r *bundleRepository) CreateBundle(bundle *domain.Bundle, maxActive int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("CreateBundle: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	quota, err := lockActiveItemQuota(tx, bundle.HosterID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO bundle (hoster_id, tenant_id, name, description, price_per_day, deposit, is_hidden)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`, bundle.HosterID, bundle.TenantID, bundle.Name, bundle.Description,
+		bundle.PricePerDay, bundle.Deposit, bundle.IsHidden,
+	).Scan(&bundle.ID, &bundle.CreatedAt, &bundle.UpdatedAt)
+	if err != nil {
+		log.Printf("CreateBundle: insert bundle error hoster=%s err=%v", bundle.HosterID, err)
+		return err
+	}
+
+	if err := insertBundleItems(tx, bundle); err != nil {
+		return err
+	}
+	if err := quota.check(tx, maxActive); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("CreateBundle: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+UpdateBundle mengubah data paket dan mengganti seluruh komposisinya dalam satu transaksi.
+Booking lama tidak berubah karena komposisi & harga sudah di-snapshot di booking_item.
+Menampilkan paket tersembunyi dicek terhadap batas listing aktif (maxActive) dengan baris hoster terkunci.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → paket tidak ditemukan / bukan milik hoster
+- errors.New("active_item_limit") → toko belum terverifikasi sudah mencapai batas
+- error → query gagal
+*/
+func (r *bundleRepository) UpdateBundle(bundle *domain.Bundle, maxActive int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("UpdateBundle: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	quota, err := lockActiveItemQuota(tx, bundle.HosterID)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`
+		UPDATE bundle
+		SET name = $3, description = $4, price_per_day = $5, deposit = $6, is_hidden = $7, updated_at = NOW()
+		WHERE id = $1 AND hoster_id = $2
+	`, bundle.ID, bundle.HosterID, bundle.Name, bundle.Description,
+		bundle.PricePerDay, bundle.Deposit, bundle.IsHidden)
+	if err != nil {
+		log.Printf("UpdateBundle: update bundle error bundle=%s err=%v", bundle.ID, err)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.Exec(`DELETE FROM bundle_item WHERE bundle_id = $1`, bundle.ID); err != nil {
+		log.Printf("UpdateBundle: delete items error bundle=%s err=%v", bundle.ID, err)
+		return err
+	}
+	if err := insertBundleItems(tx, bundle); err != nil {
+		return err
+	}
+	if err := quota.check(tx, maxActive); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("UpdateBundle: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+insertBundleItems menyimpan komposisi paket.
+*/
+func insertBundleItems(tx *sqlx.Tx, bundle *domain.Bundle) error {
+	for _, item := range bundle.Items {
+		if _, err := tx.Exec(`
+			INSERT INTO bundle_item (bundle_id, item_id, quantity) VALUES ($1, $2, $3)
+		`, bundle.ID, item.ItemID, item.Quantity); err != nil {
+			log.Printf("insertBundleItems: insert error bundle=%s item=%s err=%v", bundle.ID, item.ItemID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+DeleteBundle menghapus paket milik hoster.
+Booking yang sudah dibuat tetap utuh (bundle_id di booking_item menjadi NULL, snapshot nama tetap).
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → paket tidak ditemukan / bukan milik hoster
+- error → query gagal
+*/
+func (r *bundleRepository) DeleteBundle(hosterID, bundleID string) error {
+	res, err := r.db.Exec(`DELETE FROM bundle WHERE id = $1 AND hoster_id = $2`, bundleID, hosterID)
+	if err != nil {
+		log.Printf("DeleteBundle: delete error bundle=%s err=%v", bundleID, err)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
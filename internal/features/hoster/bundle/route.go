@@ -0,0 +1,47 @@
+package bundle
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupBundleRoutes mendaftarkan endpoint paket sewa untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET    /bundle       → daftar paket (?store_id= untuk satu store)
+  - POST   /bundle       → buat paket baru
+  - GET    /bundle/{id}  → detail paket
+  - PUT    /bundle/{id}  → ubah paket & ganti komposisi
+  - DELETE /bundle/{id}  → hapus paket (booking yang sudah ada tetap berjalan)
+
+4. GET butuh permission items:view, perubahan butuh items:manage (role toko)
+
+Output:
+- Router terkonfigurasi dengan endpoint paket sewa hoster
+*/
+func SetupBundleRoutes(router *mux.Router, h *HosterBundleHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	protected.HandleFunc("/bundle", middleware.HosterPermission(domain.HosterPermItemsView, h.ListBundles)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/bundle", middleware.HosterPermission(domain.HosterPermItemsManage, h.CreateBundle)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/bundle/{id}", middleware.HosterPermission(domain.HosterPermItemsView, h.GetBundle)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/bundle/{id}", middleware.HosterPermission(domain.HosterPermItemsManage, h.UpdateBundle)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/bundle/{id}", middleware.HosterPermission(domain.HosterPermItemsManage, h.DeleteBundle)).Methods("DELETE", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,317 @@
+package bundle
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+Konstanta validasi paket sewa.
+*/
+const (
+	MaxBundleNameLength  = 255
+	MaxBundleItems       = 20
+	MaxBundleItemQty     = 100
+	MinBundleTotalUnits  = 2
+	MaxBundleDescription = 2000
+)
+
+/*
+BundleService adalah kontrak logika bisnis paket sewa dari perspektif hoster.
+*/
+type BundleService interface {
+	ListBundles(hosterID, storeID string) ([]dto.BundleByHosterResponse, error)
+	GetBundle(hosterID, bundleID string) (*dto.BundleByHosterResponse, error)
+	CreateBundle(hosterID string, req dto.BundleByHosterRequest) (*dto.BundleByHosterResponse, error)
+	UpdateBundle(hosterID, bundleID string, req dto.BundleByHosterRequest) (*dto.BundleByHosterResponse, error)
+	DeleteBundle(hosterID, bundleID string) error
+}
+
+/*
+bundleService adalah implementasi service paket sewa hoster.
+*/
+type bundleService struct {
+	repo BundleRepository
+}
+
+/*
+NewBundleService membuat instance service dengan dependency injection.
+
+Output:
+- BundleService siap digunakan
+*/
+func NewBundleService(repo BundleRepository) BundleService {
+	return &bundleService{repo: repo}
+}
+
+/*
+ListBundles mengambil semua paket milik hoster, opsional difilter satu store.
+
+Output sukses:
+- ([]dto.BundleByHosterResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → unauthorized / StoreInvalidID / internal error
+*/
+func (s *bundleService) ListBundles(hosterID, storeID string) ([]dto.BundleByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if storeID != "" {
+		if _, err := uuid.Parse(storeID); err != nil {
+			return nil, errors.New(message.StoreInvalidID)
+		}
+	}
+
+	bundles, err := s.repo.ListBundles(hosterID, storeID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return bundles, nil
+}
+
+/*
+GetBundle mengambil detail paket milik hoster.
+
+Output sukses:
+- (*dto.BundleByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / BundleNotFound / internal error
+*/
+func (s *bundleService) GetBundle(hosterID, bundleID string) (*dto.BundleByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(bundleID); err != nil {
+		return nil, errors.New(message.BundleNotFound)
+	}
+
+	bundle, err := s.repo.GetBundle(hosterID, bundleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.BundleNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	return bundle, nil
+}
+
+/*
+CreateBundle membuat paket sewa baru.
+
+Alur kerja:
+1. Tentukan store paket (kosong = store default)
+2. Validasi data paket & komponen (lihat buildBundle)
+3. Paket yang langsung tampil → cek batas listing aktif toko belum terverifikasi
+4. Simpan paket beserta komposisinya
+
+Output sukses:
+- (*dto.BundleByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / StoreNotFound / BundleInvalid / BundleItemInvalid / HosterUnverifiedItemLimit / internal error
+*/
+func (s *bundleService) CreateBundle(hosterID string, req dto.BundleByHosterRequest) (*dto.BundleByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	storeID := strings.TrimSpace(req.StoreID)
+	if storeID != "" {
+		if _, err := uuid.Parse(storeID); err != nil {
+			return nil, errors.New(message.StoreNotFound)
+		}
+	}
+	tenantID, err := s.repo.ResolveStoreID(hosterID, storeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.StoreNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	bundle, err := s.buildBundle(hosterID, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+	if !bundle.IsHidden {
+		if err := s.checkActiveItemLimit(hosterID, ""); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.CreateBundle(bundle, config.GetUnverifiedHosterMaxActiveItems()); err != nil {
+		if err.Error() == "active_item_limit" {
+			return nil, errors.New(message.HosterUnverifiedItemLimit)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	log.Printf("CreateBundle: hoster %s created bundle %s with %d items", hosterID, bundle.ID, len(bundle.Items))
+
+	return s.GetBundle(hosterID, bundle.ID)
+}
+
+/*
+UpdateBundle mengubah data paket dan mengganti seluruh komposisinya.
+Store paket tidak bisa dipindah; komponen wajib dari store paket.
+Menampilkan paket yang tersembunyi menambah listing aktif → cek batas toko belum terverifikasi.
+
+Output sukses:
+- (*dto.BundleByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / BundleNotFound / BundleInvalid / BundleItemInvalid / HosterUnverifiedItemLimit / internal error
+*/
+func (s *bundleService) UpdateBundle(hosterID, bundleID string, req dto.BundleByHosterRequest) (*dto.BundleByHosterResponse, error) {
+	current, err := s.GetBundle(hosterID, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.buildBundle(hosterID, current.StoreID, req)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsHidden && !bundle.IsHidden {
+		if err := s.checkActiveItemLimit(hosterID, bundleID); err != nil {
+			return nil, err
+		}
+	}
+	bundle.ID = bundleID
+	if err := s.repo.UpdateBundle(bundle, config.GetUnverifiedHosterMaxActiveItems()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.BundleNotFound)
+		}
+		if err.Error() == "active_item_limit" {
+			return nil, errors.New(message.HosterUnverifiedItemLimit)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	log.Printf("UpdateBundle: hoster %s updated bundle %s", hosterID, bundleID)
+
+	return s.GetBundle(hosterID, bundleID)
+}
+
+/*
+DeleteBundle menghapus paket milik hoster. Booking paket yang sudah ada tetap berjalan.
+
+Output sukses:
+- nil
+Output error:
+- error → unauthorized / BundleNotFound / internal error
+*/
+func (s *bundleService) DeleteBundle(hosterID, bundleID string) error {
+	if hosterID == "" {
+		return errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(bundleID); err != nil {
+		return errors.New(message.BundleNotFound)
+	}
+
+	if err := s.repo.DeleteBundle(hosterID, bundleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.BundleNotFound)
+		}
+		return errors.New(message.InternalError)
+	}
+	log.Printf("DeleteBundle: hoster %s deleted bundle %s", hosterID, bundleID)
+	return nil
+}
+
+/*
+checkActiveItemLimit memastikan toko yang belum terverifikasi tidak melebihi batas listing aktif.
+Paket yang tampil dihitung bersama item (batas sama dengan hoster item, lihat UNVERIFIED_HOSTER_MAX_ACTIVE_ITEMS).
+
+Output sukses:
+- nil → boleh menambah/menampilkan paket
+Output error:
+- error → HosterUnverifiedItemLimit / Unauthorized (hoster tidak ditemukan) / InternalError
+*/
+func (s *bundleService) checkActiveItemLimit(hosterID, excludeBundleID string) error {
+	verified, active, err := s.repo.GetActiveItemQuota(hosterID, excludeBundleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.Unauthorized)
+		}
+		return errors.New(message.InternalError)
+	}
+	if !verified && active >= config.GetUnverifiedHosterMaxActiveItems() {
+		log.Printf("checkActiveItemLimit: hoster %s unverified with %d active listings", hosterID, active)
+		return errors.New(message.HosterUnverifiedItemLimit)
+	}
+	return nil
+}
+
+/*
+buildBundle memvalidasi payload paket dan membentuk entity Bundle.
+
+Alur kerja:
+1. Nama wajib (maks 255), deskripsi maks 2000, price_per_day > 0, deposit >= 0
+2. Komponen: 1 - 20 item berbeda, quantity 1 - 100, total minimal 2 unit
+3. Semua item milik hoster, berada di store paket, dan tidak bervarian
+
+Output:
+- (*domain.Bundle, nil)
+- (nil, error) → BundleInvalid / BundleItemInvalid / internal error
+*/
+func (s *bundleService) buildBundle(hosterID, tenantID string, req dto.BundleByHosterRequest) (*domain.Bundle, error) {
+	name := strings.TrimSpace(req.Name)
+	description := strings.TrimSpace(req.Description)
+	if name == "" || len(name) > MaxBundleNameLength || len(description) > MaxBundleDescription {
+		return nil, errors.New(message.BundleInvalid)
+	}
+	if req.PricePerDay <= 0 || req.Deposit < 0 {
+		return nil, errors.New(message.BundleInvalid)
+	}
+	if len(req.Items) == 0 || len(req.Items) > MaxBundleItems {
+		return nil, errors.New(message.BundleInvalid)
+	}
+
+	quantities := make(map[string]int, len(req.Items))
+	itemIDs := make([]string, 0, len(req.Items))
+	totalUnits := 0
+	for _, item := range req.Items {
+		if _, err := uuid.Parse(item.ItemID); err != nil {
+			return nil, errors.New(message.BundleItemInvalid)
+		}
+		if _, dup := quantities[item.ItemID]; dup || item.Quantity <= 0 || item.Quantity > MaxBundleItemQty {
+			return nil, errors.New(message.BundleInvalid)
+		}
+		quantities[item.ItemID] = item.Quantity
+		itemIDs = append(itemIDs, item.ItemID)
+		totalUnits += item.Quantity
+	}
+	if totalUnits < MinBundleTotalUnits {
+		return nil, errors.New(message.BundleInvalid)
+	}
+
+	components, err := s.repo.GetComponentItems(hosterID, itemIDs)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if len(components) != len(itemIDs) {
+		return nil, errors.New(message.BundleItemInvalid)
+	}
+	for i := range components {
+		if components[i].TenantID != tenantID || components[i].HasVariants {
+			return nil, errors.New(message.BundleItemInvalid)
+		}
+		components[i].Quantity = quantities[components[i].ItemID]
+	}
+
+	return &domain.Bundle{
+		HosterID:    hosterID,
+		TenantID:    tenantID,
+		Name:        name,
+		Description: description,
+		PricePerDay: req.PricePerDay,
+		Deposit:     req.Deposit,
+		IsHidden:    req.IsHidden,
+		Items:       components,
+	}, nil
+}
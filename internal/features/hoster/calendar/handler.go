@@ -0,0 +1,152 @@
+package calendar
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterCalendarHandler menangani endpoint HTTP feed iCal hoster.
+*/
+type HosterCalendarHandler struct {
+	service HosterCalendarService
+}
+
+/*
+NewHosterCalendarHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterCalendarHandler siap digunakan
+*/
+func NewHosterCalendarHandler(s HosterCalendarService) *HosterCalendarHandler {
+	return &HosterCalendarHandler{service: s}
+}
+
+/*
+GetFeed menangani GET /api/v1/hoster/profile/calendar
+
+Output sukses:
+- 200 OK + status link feed (active, created_at)
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterCalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.service.GetFeed(middleware.GetUserID(r))
+	if err != nil {
+		log.Printf("GetFeed handler: service error: %v", err)
+		writeCalendarError(w, err)
+		return
+	}
+	response.OK(w, feed, message.CalendarFeedRetrieved)
+}
+
+/*
+RegenerateFeed menangani POST /api/v1/hoster/profile/calendar
+
+Membuat link feed baru; link lama (jika ada) langsung tidak berlaku.
+
+Output sukses:
+- 200 OK + feed_url (hanya ditampilkan sekali)
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterCalendarHandler) RegenerateFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.service.RegenerateFeed(middleware.GetUserID(r), publicBaseURL(r))
+	if err != nil {
+		log.Printf("RegenerateFeed handler: service error: %v", err)
+		writeCalendarError(w, err)
+		return
+	}
+	response.OK(w, feed, message.CalendarFeedRegenerated)
+}
+
+/*
+RevokeFeed menangani DELETE /api/v1/hoster/profile/calendar
+
+Output sukses:
+- 200 OK
+Output error:
+- 401 Unauthorized / 404 Not Found (feed tidak aktif) / 500 Internal Server Error
+*/
+func (h *HosterCalendarHandler) RevokeFeed(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RevokeFeed(middleware.GetUserID(r)); err != nil {
+		log.Printf("RevokeFeed handler: service error: %v", err)
+		writeCalendarError(w, err)
+		return
+	}
+	response.OK(w, nil, message.CalendarFeedRevoked)
+}
+
+/*
+GetICS menangani GET /api/v1/calendar/{token}.ics (publik, tanpa JWT)
+
+Dipanggil aplikasi kalender (Google Calendar, Apple Calendar, Outlook) secara berkala.
+Token rahasia di URL adalah satu-satunya autentikasi.
+
+Output sukses:
+- 200 OK + text/calendar
+Output error:
+- 404 Not Found → token tidak dikenal / sudah dicabut
+- 500 Internal Server Error
+*/
+func (h *HosterCalendarHandler) GetICS(w http.ResponseWriter, r *http.Request) {
+	body, err := h.service.RenderFeed(mux.Vars(r)["token"])
+	if err != nil {
+		if err.Error() != message.CalendarFeedNotFound {
+			log.Printf("GetICS handler: service error: %v", err)
+		}
+		writeCalendarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="bookings.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("GetICS handler: write error: %v", err)
+	}
+}
+
+/*
+publicBaseURL mengembalikan base URL API untuk feed_url.
+Prioritas PUBLIC_API_URL, fallback ke host request (menghormati X-Forwarded-Proto di belakang proxy).
+*/
+func publicBaseURL(r *http.Request) string {
+	if base := config.GetPublicAPIURL(); base != "" {
+		return base
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "https" || proto == "http" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
+
+/*
+writeCalendarError memetakan error service feed iCal ke HTTP response.
+*/
+func writeCalendarError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.HosterNotFound:
+		response.NotFound(w, message.ProfileNotFound)
+	case message.CalendarFeedNotFound:
+		response.NotFound(w, message.CalendarFeedNotFound)
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,224 @@
+package calendar
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/domain"
+)
+
+/*
+HosterCalendarRepository mendefinisikan operasi database untuk feed iCal hoster.
+*/
+type HosterCalendarRepository interface {
+	GetTokenCreatedAt(hosterID string) (*time.Time, error)
+	SetTokenHash(hosterID, tokenHash string) (time.Time, error)
+	ClearToken(hosterID string) error
+	GetHosterByTokenHash(tokenHash string) (*domain.Hoster, error)
+	GetCalendarBookings(hosterID string, from, to time.Time) ([]CalendarBooking, error)
+}
+
+/*
+CalendarBooking adalah data satu booking yang ditampilkan sebagai event pickup & return.
+*/
+type CalendarBooking struct {
+	ID              string    `db:"id"`
+	Status          string    `db:"status"`
+	StartDate       time.Time `db:"start_date"`
+	EndDate         time.Time `db:"end_date"`
+	DeliveryType    string    `db:"delivery_type"`
+	Items           string    `db:"items"` // "Tenda Dome 4P x2, Carrier 60L x1"
+	CustomerName    string    `db:"customer_name"`
+	CustomerPhone   string    `db:"customer_phone"`
+	CustomerEmail   string    `db:"customer_email"`
+	CustomerAddress string    `db:"customer_address"`
+	Notes           string    `db:"notes"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+/*
+hosterCalendarRepository adalah implementasi repository untuk feed iCal hoster.
+*/
+type hosterCalendarRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewHosterCalendarRepository membuat instance repository dengan koneksi database.
+
+Output:
+- HosterCalendarRepository siap digunakan
+*/
+func NewHosterCalendarRepository(db *sqlx.DB) HosterCalendarRepository {
+	return &hosterCalendarRepository{db: db}
+}
+
+/*
+GetTokenCreatedAt mengambil waktu pembuatan token feed hoster.
+
+Output sukses:
+- (*time.Time, nil) → feed aktif
+- (nil, nil) → hoster belum / tidak lagi punya feed
+Output error:
+- (nil, sql.ErrNoRows) → hoster tidak ditemukan
+- (nil, error) → query gagal
+*/
+func (r *hosterCalendarRepository) GetTokenCreatedAt(hosterID string) (*time.Time, error) {
+	var createdAt sql.NullTime
+	query := `
+		SELECT CASE WHEN calendar_token_hash IS NULL THEN NULL ELSE calendar_token_created_at END
+		FROM hoster
+		WHERE id = $1
+	`
+	if err := r.db.QueryRow(query, hosterID).Scan(&createdAt); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("GetTokenCreatedAt: db error hoster=%s err=%v", hosterID, err)
+		}
+		return nil, err
+	}
+	if !createdAt.Valid {
+		return nil, nil
+	}
+	return &createdAt.Time, nil
+}
+
+/*
+SetTokenHash menyimpan hash token feed baru (menimpa token lama).
+
+Output sukses:
+- (time.Time, nil) → waktu token dibuat
+Output error:
+- (time.Time{}, sql.ErrNoRows) → hoster tidak ditemukan
+- (time.Time{}, error) → query gagal
+*/
+func (r *hosterCalendarRepository) SetTokenHash(hosterID, tokenHash string) (time.Time, error) {
+	var createdAt time.Time
+	query := `
+		UPDATE hoster
+		SET calendar_token_hash = $1,
+		    calendar_token_created_at = NOW(),
+		    updated_at = NOW()
+		WHERE id = $2
+		RETURNING calendar_token_created_at
+	`
+	if err := r.db.QueryRow(query, tokenHash, hosterID).Scan(&createdAt); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("SetTokenHash: db error hoster=%s err=%v", hosterID, err)
+		}
+		return time.Time{}, err
+	}
+	return createdAt, nil
+}
+
+/*
+ClearToken mencabut token feed hoster.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → hoster tidak ditemukan / feed memang tidak aktif
+- error → query gagal
+*/
+func (r *hosterCalendarRepository) ClearToken(hosterID string) error {
+	query := `
+		UPDATE hoster
+		SET calendar_token_hash = NULL,
+		    calendar_token_created_at = NULL,
+		    updated_at = NOW()
+		WHERE id = $1 AND calendar_token_hash IS NOT NULL
+	`
+	res, err := r.db.Exec(query, hosterID)
+	if err != nil {
+		log.Printf("ClearToken: db error hoster=%s err=%v", hosterID, err)
+		return err
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+/*
+GetHosterByTokenHash mencari hoster pemilik token feed.
+
+Output sukses:
+- (*domain.Hoster, nil) → hanya id & store_name yang terisi
+Output error:
+- (nil, sql.ErrNoRows) → token tidak dikenal / sudah dicabut
+- (nil, error) → query gagal
+*/
+func (r *hosterCalendarRepository) GetHosterByTokenHash(tokenHash string) (*domain.Hoster, error) {
+	var hoster domain.Hoster
+	query := `
+		SELECT id, store_name
+		FROM hoster
+		WHERE calendar_token_hash = $1
+	`
+	if err := r.db.Get(&hoster, query, tokenHash); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("GetHosterByTokenHash: db error err=%v", err)
+		}
+		return nil, err
+	}
+	return &hoster, nil
+}
+
+/*
+GetCalendarBookings mengambil booking hoster yang beririsan dengan [from, to).
+
+Alur kerja:
+1. Ambil booking efektif (on_progress, on_rent, completed) + pending yang masih di-lock
+2. Gabungkan nama & quantity item, kontak customer dari snapshot booking_customer (fallback akun customer)
+3. Urutkan berdasarkan tanggal mulai sewa
+
+Output sukses:
+- ([]CalendarBooking, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterCalendarRepository) GetCalendarBookings(hosterID string, from, to time.Time) ([]CalendarBooking, error) {
+	query := `
+		SELECT
+			b.id,
+			b.status,
+			b.start_date::timestamptz AS start_date,
+			b.end_date::timestamptz AS end_date,
+			b.delivery_type,
+			COALESCE(items.items, '') AS items,
+			COALESCE(NULLIF(bc.name, ''), c.full_name, '') AS customer_name,
+			COALESCE(NULLIF(bc.phone, ''), c.phone_number, '') AS customer_phone,
+			COALESCE(NULLIF(bc.email, ''), c.email, '') AS customer_email,
+			COALESCE(bc.address, '') AS customer_address,
+			COALESCE(bc.notes, '') AS notes,
+			b.updated_at
+		FROM booking b
+		LEFT JOIN (
+			SELECT booking_id,
+			       string_agg(name || ' x' || quantity, ', ' ORDER BY name) AS items
+			FROM booking_item
+			GROUP BY booking_id
+		) items ON items.booking_id = b.id
+		LEFT JOIN booking_customer bc ON bc.booking_id = b.id
+		LEFT JOIN customer c ON c.id = b.user_id
+		WHERE b.hoster_id = $1
+		  AND (
+		      b.status IN ('on_progress', 'on_rent', 'completed')
+		      OR (b.status = 'pending' AND b.locked_until > NOW())
+		  )
+		  AND b.end_date >= $2
+		  AND b.start_date < $3
+		ORDER BY b.start_date, b.id
+	`
+
+	bookings := []CalendarBooking{}
+	if err := r.db.Select(&bookings, query, hosterID, from, to); err != nil {
+		log.Printf("GetCalendarBookings: db error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return bookings, nil
+}
@@ -0,0 +1,45 @@
+package calendar
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupCalendarRoutes mendaftarkan endpoint feed iCal hoster.
+
+Alur kerja:
+1. Daftarkan endpoint publik (tanpa JWT, diautentikasi token rahasia di URL):
+  - GET /api/v1/calendar/{token}.ics → file iCalendar booking hoster
+
+2. Buat subrouter /api/v1/hoster/profile/calendar dengan middleware JWT → Hoster:
+  - GET    → status link feed
+  - POST   → buat / buat ulang link feed (link lama tidak berlaku)
+  - DELETE → cabut link feed
+
+Output:
+- Router terkonfigurasi dengan endpoint feed iCal
+*/
+func SetupCalendarRoutes(router *mux.Router, h *HosterCalendarHandler) {
+	router.HandleFunc("/api/v1/calendar/{token:[A-Za-z0-9_-]+}.ics", h.GetICS).Methods("GET")
+
+	protected := router.PathPrefix("/api/v1/hoster/profile/calendar").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+	protected.Use(middleware.RequireHosterPermission(domain.HosterPermStoreManage))
+
+	protected.HandleFunc("", h.GetFeed).Methods("GET")
+	protected.HandleFunc("", h.RegenerateFeed).Methods("POST")
+	protected.HandleFunc("", h.RevokeFeed).Methods("DELETE")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,250 @@
+package calendar
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
+)
+
+/*
+HosterCalendarService adalah kontrak untuk logika bisnis feed iCal hoster.
+*/
+type HosterCalendarService interface {
+	GetFeed(hosterID string) (*dto.CalendarFeedByHosterResponse, error)
+	RegenerateFeed(hosterID, baseURL string) (*dto.CalendarFeedByHosterResponse, error)
+	RevokeFeed(hosterID string) error
+	RenderFeed(token string) ([]byte, error)
+}
+
+/*
+hosterCalendarService adalah implementasi service feed iCal hoster.
+*/
+type hosterCalendarService struct {
+	repo HosterCalendarRepository
+}
+
+/*
+NewHosterCalendarService membuat instance service dengan dependency injection.
+
+Output:
+- HosterCalendarService siap digunakan
+*/
+func NewHosterCalendarService(repo HosterCalendarRepository) HosterCalendarService {
+	return &hosterCalendarService{repo: repo}
+}
+
+/*
+Konstanta feed iCal.
+*/
+const (
+	FeedPastDays   = 90  // Booking yang selesai lebih dari 90 hari lalu tidak dikirim
+	FeedFutureDays = 365 // Booking yang mulai lebih dari 1 tahun lagi tidak dikirim
+	tokenBytes     = 32
+)
+
+/*
+GetFeed mengambil status link feed iCal hoster.
+
+Output sukses:
+- (*dto.CalendarFeedByHosterResponse, nil) → active=false jika belum dibuat / sudah dicabut
+Output error:
+- (nil, error) → unauthorized / not found / internal error
+*/
+func (s *hosterCalendarService) GetFeed(hosterID string) (*dto.CalendarFeedByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	createdAt, err := s.repo.GetTokenCreatedAt(hosterID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.HosterNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.CalendarFeedByHosterResponse{Active: createdAt != nil, CreatedAt: createdAt}, nil
+}
+
+/*
+RegenerateFeed membuat token feed baru dan mengembalikan URL .ics.
+
+Alur kerja:
+1. Generate token acak 32 byte (base64url)
+2. Simpan hash SHA-256 token (token lama otomatis tidak berlaku)
+3. Kembalikan feed URL lengkap (hanya sekali ini token asli terlihat)
+
+Output sukses:
+- (*dto.CalendarFeedByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / not found / internal error
+*/
+func (s *hosterCalendarService) RegenerateFeed(hosterID, baseURL string) (*dto.CalendarFeedByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		log.Printf("RegenerateFeed(calendar service): failed to generate token: %v", err)
+		return nil, errors.New(message.InternalError)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	createdAt, err := s.repo.SetTokenHash(hosterID, hashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.HosterNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("RegenerateFeed(calendar service): new feed token for hoster %s", hosterID)
+	return &dto.CalendarFeedByHosterResponse{
+		Active:    true,
+		CreatedAt: &createdAt,
+		FeedURL:   fmt.Sprintf("%s/api/v1/calendar/%s.ics", strings.TrimRight(baseURL, "/"), token),
+	}, nil
+}
+
+/*
+RevokeFeed mencabut link feed iCal hoster.
+
+Output sukses:
+- nil
+Output error:
+- error → unauthorized / CalendarFeedNotFound / internal error
+*/
+func (s *hosterCalendarService) RevokeFeed(hosterID string) error {
+	if hosterID == "" {
+		return errors.New(message.Unauthorized)
+	}
+
+	if err := s.repo.ClearToken(hosterID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.CalendarFeedNotFound)
+		}
+		return errors.New(message.InternalError)
+	}
+
+	log.Printf("RevokeFeed(calendar service): feed revoked for hoster %s", hosterID)
+	return nil
+}
+
+/*
+RenderFeed membuat isi file .ics untuk token feed.
+
+Alur kerja:
+1. Cari hoster dari hash token (token tidak dikenal / dicabut → not found)
+2. Ambil booking 90 hari ke belakang s/d 1 tahun ke depan
+3. Setiap booking menjadi 2 event all-day: pickup (tanggal mulai) dan return (tanggal selesai)
+
+Output sukses:
+- ([]byte isi VCALENDAR, nil)
+Output error:
+- (nil, error) → CalendarFeedNotFound / internal error
+*/
+func (s *hosterCalendarService) RenderFeed(token string) ([]byte, error) {
+	if token == "" {
+		return nil, errors.New(message.CalendarFeedNotFound)
+	}
+
+	hoster, err := s.repo.GetHosterByTokenHash(hashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.CalendarFeedNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	bookings, err := s.repo.GetCalendarBookings(hoster.ID, today.AddDate(0, 0, -FeedPastDays), today.AddDate(0, 0, FeedFutureDays))
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	events := make([]utils.ICalEvent, 0, len(bookings)*2)
+	for _, b := range bookings {
+		events = append(events, bookingEvents(b)...)
+	}
+
+	var buf bytes.Buffer
+	if err := utils.WriteICalendar(&buf, "Lalan - "+hoster.StoreName, events); err != nil {
+		log.Printf("RenderFeed(calendar service): write error hoster=%s err=%v", hoster.ID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	return buf.Bytes(), nil
+}
+
+/*
+bookingEvents mengubah satu booking menjadi event pickup dan return.
+Booking pending (belum dikonfirmasi) ditandai TENTATIVE.
+*/
+func bookingEvents(b CalendarBooking) []utils.ICalEvent {
+	status := "CONFIRMED"
+	if b.Status == "pending" {
+		status = "TENTATIVE"
+	}
+
+	var desc strings.Builder
+	fmt.Fprintf(&desc, "Booking: %s\nStatus: %s\n", b.ID, b.Status)
+	fmt.Fprintf(&desc, "Items: %s\n", b.Items)
+	fmt.Fprintf(&desc, "Customer: %s\n", b.CustomerName)
+	if b.CustomerPhone != "" {
+		fmt.Fprintf(&desc, "Phone: %s\n", b.CustomerPhone)
+	}
+	if b.CustomerEmail != "" {
+		fmt.Fprintf(&desc, "Email: %s\n", b.CustomerEmail)
+	}
+	fmt.Fprintf(&desc, "Delivery: %s\n", b.DeliveryType)
+	fmt.Fprintf(&desc, "Rental: %s - %s", b.StartDate.UTC().Format("2006-01-02"), b.EndDate.UTC().Format("2006-01-02"))
+	if b.Notes != "" {
+		fmt.Fprintf(&desc, "\nNotes: %s", b.Notes)
+	}
+
+	pickup := b.StartDate.UTC().Truncate(24 * time.Hour)
+	ret := b.EndDate.UTC().Truncate(24 * time.Hour)
+
+	return []utils.ICalEvent{
+		{
+			UID:         b.ID + "-pickup@lalan",
+			Summary:     fmt.Sprintf("Pickup: %s (%s)", b.Items, b.CustomerName),
+			Description: desc.String(),
+			Location:    b.CustomerAddress,
+			Start:       pickup,
+			End:         pickup.AddDate(0, 0, 1),
+			Status:      status,
+			UpdatedAt:   b.UpdatedAt,
+		},
+		{
+			UID:         b.ID + "-return@lalan",
+			Summary:     fmt.Sprintf("Return: %s (%s)", b.Items, b.CustomerName),
+			Description: desc.String(),
+			Location:    b.CustomerAddress,
+			Start:       ret,
+			End:         ret.AddDate(0, 0, 1),
+			Status:      status,
+			UpdatedAt:   b.UpdatedAt,
+		},
+	}
+}
+
+/*
+hashToken mengembalikan SHA-256 (hex) dari token feed.
+*/
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,178 @@
+package handover
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterHandoverHandler menangani endpoint HTTP berita acara serah terima (handover) dari perspektif hoster.
+*/
+type HosterHandoverHandler struct {
+	service HandoverService
+}
+
+/*
+NewHosterHandoverHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterHandoverHandler siap digunakan
+*/
+func NewHosterHandoverHandler(s HandoverService) *HosterHandoverHandler {
+	return &HosterHandoverHandler{service: s}
+}
+
+/*
+GetHandovers menangani GET /api/v1/hoster/booking/{id}/handover
+
+Output sukses:
+- 200 OK + { booking_id, pickup, return, comparison }
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterHandoverHandler) GetHandovers(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	result, err := h.service.GetHandovers(hosterID, mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetHandovers handler: service error hoster=%s err=%v", hosterID, err)
+		writeHandoverError(w, err)
+		return
+	}
+	response.OK(w, result, message.HandoverRetrieved)
+}
+
+/*
+SubmitHandover menangani POST /api/v1/hoster/booking/{id}/handover/{kind} (kind: pickup / return)
+
+Alur kerja:
+1. Ambil hosterID + memberID (staff pencatat) dari JWT context
+2. Parse multipart form (max 50 MB)
+3. Decode field "data" (JSON dto.SubmitHandoverByHosterRequest)
+4. Ambil foto baru dari field photos_{booking_item_id}, harus gambar
+5. Panggil service.SubmitHandover()
+
+Output sukses:
+- 200 OK + data handover terbaru
+Output error:
+- 400 Bad Request  → form / isi handover tidak valid
+- 401 Unauthorized → token tidak valid
+- 404 Not Found    → booking tidak ditemukan
+- 409 Conflict     → status booking tidak sesuai / pickup belum diisi / sudah dikonfirmasi customer
+- 500 Internal     → kegagalan storage / database
+*/
+func (h *HosterHandoverHandler) SubmitHandover(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(50 << 20); err != nil {
+		log.Printf("SubmitHandover: failed to parse multipart form: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	var req dto.SubmitHandoverByHosterRequest
+	if err := json.Unmarshal([]byte(r.FormValue("data")), &req); err != nil {
+		log.Printf("SubmitHandover: failed to decode data field: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	photos, closeFiles, errMsg := parseHandoverPhotos(r)
+	defer closeFiles()
+	if errMsg != "" {
+		response.BadRequest(w, errMsg)
+		return
+	}
+
+	vars := mux.Vars(r)
+	result, err := h.service.SubmitHandover(r.Context(), hosterID, middleware.GetMemberID(r), vars["id"], vars["kind"], req, photos)
+	if err != nil {
+		log.Printf("SubmitHandover handler: service error hoster=%s err=%v", hosterID, err)
+		writeHandoverError(w, err)
+		return
+	}
+	response.OK(w, result, message.HandoverSaved)
+}
+
+/*
+parseHandoverPhotos mengambil foto baru dari field multipart photos_{booking_item_id}.
+
+Output sukses:
+- (photos, closeFiles, "")
+Output error:
+- (nil, closeFiles, pesan error) → file gagal dibuka / bukan gambar
+*/
+func parseHandoverPhotos(r *http.Request) ([]HandoverPhoto, func(), string) {
+	var photos []HandoverPhoto
+	var opened []io.Closer
+	closeFiles := func() {
+		for _, c := range opened {
+			c.Close()
+		}
+	}
+
+	for field, headers := range r.MultipartForm.File {
+		bookingItemID, ok := strings.CutPrefix(field, "photos_")
+		if !ok {
+			continue
+		}
+		for _, header := range headers {
+			contentType := header.Header.Get("Content-Type")
+			if !strings.HasPrefix(contentType, "image/") {
+				return nil, closeFiles, message.UploadInvalidContentType
+			}
+			file, err := header.Open()
+			if err != nil {
+				log.Printf("parseHandoverPhotos: failed to open %s: %v", field, err)
+				return nil, closeFiles, message.BadRequest
+			}
+			opened = append(opened, file)
+			photos = append(photos, HandoverPhoto{BookingItemID: bookingItemID, Reader: file, ContentType: contentType})
+		}
+	}
+
+	return photos, closeFiles, ""
+}
+
+/*
+writeHandoverError memetakan error service handover ke HTTP response.
+*/
+func writeHandoverError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case fmt.Sprintf(message.NotFound, "booking"):
+		response.NotFound(w, err.Error())
+	case message.HandoverInvalidStatus, message.HandoverPickupRequired, message.HandoverLocked:
+		response.Error(w, http.StatusConflict, err.Error())
+	case message.HandoverInvalidKind,
+		message.HandoverInvalidItems,
+		message.HandoverInvalidCondition,
+		message.HandoverInvalidChecklist,
+		message.HandoverInvalidPhotos,
+		message.UploadInvalidContentType,
+		fmt.Sprintf(message.TooLong, "notes"):
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,317 @@
+package handover
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+HandoverRepository adalah kontrak akses data berita acara serah terima (handover) booking.
+GetHandovers dan ConfirmHandover juga dipakai fitur booking customer (tampil & konfirmasi).
+*/
+type HandoverRepository interface {
+	GetBookingForHandover(hosterID, bookingID string) (string, []string, error) // Status booking + ID booking_item
+	GetHandovers(bookingID string) (*dto.BookingHandoversResponse, error)
+	SaveHandover(handover *domain.BookingHandover) error
+	ConfirmHandover(bookingID, kind, note string) error
+}
+
+/*
+handoverRepository adalah implementasi repository handover.
+*/
+type handoverRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewHandoverRepository membuat instance repository dengan koneksi database.
+
+Output:
+- HandoverRepository siap digunakan
+*/
+func NewHandoverRepository(db *sqlx.DB) HandoverRepository {
+	return &handoverRepository{db: db}
+}
+
+/*
+GetBookingForHandover mengambil status booking milik hoster beserta ID setiap booking_item.
+
+Output sukses:
+- (status, bookingItemIDs, nil)
+Output error:
+- ("", nil, sql.ErrNoRows) → booking tidak ada / bukan milik hoster
+- ("", nil, error)         → query gagal
+*/
+func (r *handoverRepository) GetBookingForHandover(hosterID, bookingID string) (string, []string, error) {
+	var status string
+	err := r.db.Get(&status, `SELECT status FROM booking WHERE id = $1 AND hoster_id = $2`, bookingID, hosterID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetBookingForHandover: select booking error booking=%s err=%v", bookingID, err)
+		}
+		return "", nil, err
+	}
+
+	var itemIDs []string
+	if err := r.db.Select(&itemIDs, `SELECT id FROM booking_item WHERE booking_id = $1 ORDER BY id`, bookingID); err != nil {
+		log.Printf("GetBookingForHandover: select booking items error booking=%s err=%v", bookingID, err)
+		return "", nil, err
+	}
+	return status, itemIDs, nil
+}
+
+/*
+GetHandovers mengambil handover pickup & return sebuah booking beserta perbandingan kondisinya.
+
+Alur kerja:
+1. Ambil header handover (maksimal satu per jenis)
+2. Ambil item setiap handover + nama booking_item, checklist & photos di-unmarshal dari JSONB
+3. Jika handover return ada: bandingkan per booking_item dengan pickup
+  - condition_changed → kondisi berbeda
+  - checklist_regressions → poin yang dicentang saat pickup tapi tidak dicentang / tidak ada saat return
+
+Output sukses:
+- (*dto.BookingHandoversResponse, nil) → pickup / return nil jika belum diisi
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *handoverRepository) GetHandovers(bookingID string) (*dto.BookingHandoversResponse, error) {
+	result := &dto.BookingHandoversResponse{BookingID: bookingID, Comparison: []dto.HandoverComparisonResponse{}}
+
+	var headers []dto.BookingHandoverResponse
+	err := r.db.Select(&headers, `
+		SELECT id, kind, notes, recorded_by_member_id, hoster_confirmed_at,
+		       customer_confirmed_at, customer_note, updated_at
+		FROM booking_handover
+		WHERE booking_id = $1
+	`, bookingID)
+	if err != nil {
+		log.Printf("GetHandovers: select handover error booking=%s err=%v", bookingID, err)
+		return nil, err
+	}
+
+	for i := range headers {
+		h := &headers[i]
+		var rows []struct {
+			dto.BookingHandoverItemResponse
+			Checklist json.RawMessage `db:"checklist"`
+			Photos    json.RawMessage `db:"photos"`
+		}
+		err := r.db.Select(&rows, `
+			SELECT hi.booking_item_id, bi.name AS item_name, hi.condition, hi.notes, hi.checklist, hi.photos
+			FROM booking_handover_item hi
+			JOIN booking_item bi ON bi.id = hi.booking_item_id
+			WHERE hi.handover_id = $1
+			ORDER BY bi.name, hi.booking_item_id
+		`, h.ID)
+		if err != nil {
+			log.Printf("GetHandovers: select handover items error handover=%s err=%v", h.ID, err)
+			return nil, err
+		}
+
+		h.Items = make([]dto.BookingHandoverItemResponse, 0, len(rows))
+		for _, row := range rows {
+			item := row.BookingHandoverItemResponse
+			if err := json.Unmarshal(row.Checklist, &item.Checklist); err != nil {
+				log.Printf("GetHandovers: failed to unmarshal checklist handover=%s err=%v", h.ID, err)
+				return nil, err
+			}
+			if err := json.Unmarshal(row.Photos, &item.Photos); err != nil {
+				log.Printf("GetHandovers: failed to unmarshal photos handover=%s err=%v", h.ID, err)
+				return nil, err
+			}
+			h.Items = append(h.Items, item)
+		}
+
+		switch h.Kind {
+		case domain.HandoverKindPickup:
+			result.Pickup = h
+		case domain.HandoverKindReturn:
+			result.Return = h
+		}
+	}
+
+	if result.Return == nil {
+		return result, nil
+	}
+
+	var comparison []struct {
+		dto.HandoverComparisonResponse
+		Regressions pq.StringArray `db:"checklist_regressions"`
+	}
+	err = r.db.Select(&comparison, `
+		SELECT bi.id AS booking_item_id, bi.name AS item_name,
+		       p.condition AS pickup_condition, rt.condition AS return_condition,
+		       COALESCE(p.condition <> rt.condition, FALSE) AS condition_changed,
+		       ARRAY(
+		           SELECT pc->>'label'
+		           FROM jsonb_array_elements(COALESCE(p.checklist, '[]'::jsonb)) pc
+		           WHERE (pc->>'checked')::boolean
+		             AND NOT EXISTS (
+		                 SELECT 1 FROM jsonb_array_elements(COALESCE(rt.checklist, '[]'::jsonb)) rc
+		                 WHERE LOWER(rc->>'label') = LOWER(pc->>'label') AND (rc->>'checked')::boolean
+		             )
+		       ) AS checklist_regressions
+		FROM booking_item bi
+		LEFT JOIN booking_handover ph ON ph.booking_id = bi.booking_id AND ph.kind = 'pickup'
+		LEFT JOIN booking_handover_item p ON p.handover_id = ph.id AND p.booking_item_id = bi.id
+		LEFT JOIN booking_handover_item rt ON rt.handover_id = $2 AND rt.booking_item_id = bi.id
+		WHERE bi.booking_id = $1
+		ORDER BY bi.name, bi.id
+	`, bookingID, result.Return.ID)
+	if err != nil {
+		log.Printf("GetHandovers: comparison query error booking=%s err=%v", bookingID, err)
+		return nil, err
+	}
+	for _, row := range comparison {
+		item := row.HandoverComparisonResponse
+		item.ChecklistRegressions = []string(row.Regressions)
+		if item.ChecklistRegressions == nil {
+			item.ChecklistRegressions = []string{}
+		}
+		result.Comparison = append(result.Comparison, item)
+	}
+	return result, nil
+}
+
+/*
+SaveHandover membuat / mengisi ulang handover sebuah booking dalam satu transaksi.
+Pengisian oleh hoster sekaligus konfirmasi hoster (hoster_confirmed_at = NOW()).
+
+Alur kerja:
+1. Kunci handover jenis yang sama (FOR UPDATE) jika sudah ada
+2. Sudah dikonfirmasi customer → tolak (record dikunci)
+3. Insert / update header, konfirmasi customer di-reset karena isi berubah
+4. Hapus item lama lalu insert item baru (checklist & photos sebagai JSONB)
+
+Output sukses:
+- nil → handover.ID terisi
+Output error:
+- errors.New("confirmed") → handover sudah dikonfirmasi customer
+- error → query gagal
+*/
+func (r *handoverRepository) SaveHandover(handover *domain.BookingHandover) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("SaveHandover: begin tx error: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var existing struct {
+		ID                  string     `db:"id"`
+		CustomerConfirmedAt *time.Time `db:"customer_confirmed_at"`
+	}
+	err = tx.Get(&existing, `
+		SELECT id, customer_confirmed_at FROM booking_handover
+		WHERE booking_id = $1 AND kind = $2
+		FOR UPDATE
+	`, handover.BookingID, handover.Kind)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		err = tx.Get(&handover.ID, `
+			INSERT INTO booking_handover (booking_id, kind, notes, recorded_by_member_id)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`, handover.BookingID, handover.Kind, handover.Notes, handover.RecordedByMemberID)
+		if err != nil {
+			log.Printf("SaveHandover: insert handover error booking=%s err=%v", handover.BookingID, err)
+			return err
+		}
+	case err != nil:
+		log.Printf("SaveHandover: select handover error booking=%s err=%v", handover.BookingID, err)
+		return err
+	case existing.CustomerConfirmedAt != nil:
+		return errors.New("confirmed")
+	default:
+		handover.ID = existing.ID
+		_, err = tx.Exec(`
+			UPDATE booking_handover
+			SET notes = $2, recorded_by_member_id = $3, hoster_confirmed_at = NOW(),
+			    customer_confirmed_at = NULL, customer_note = NULL, updated_at = NOW()
+			WHERE id = $1
+		`, handover.ID, handover.Notes, handover.RecordedByMemberID)
+		if err != nil {
+			log.Printf("SaveHandover: update handover error handover=%s err=%v", handover.ID, err)
+			return err
+		}
+		if _, err = tx.Exec(`DELETE FROM booking_handover_item WHERE handover_id = $1`, handover.ID); err != nil {
+			log.Printf("SaveHandover: delete items error handover=%s err=%v", handover.ID, err)
+			return err
+		}
+	}
+
+	for _, item := range handover.Items {
+		checklistJSON, err := json.Marshal(item.Checklist)
+		if err != nil {
+			return err
+		}
+		photosJSON, err := json.Marshal(item.Photos)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+			INSERT INTO booking_handover_item (handover_id, booking_item_id, condition, notes, checklist, photos)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, handover.ID, item.BookingItemID, item.Condition, item.Notes, checklistJSON, photosJSON)
+		if err != nil {
+			log.Printf("SaveHandover: insert item error handover=%s booking_item=%s err=%v", handover.ID, item.BookingItemID, err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("SaveHandover: commit error handover=%s err=%v", handover.ID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+ConfirmHandover mencatat konfirmasi customer atas handover (opsional dengan catatan).
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows           → handover jenis tersebut belum diisi hoster
+- errors.New("confirmed") → sudah dikonfirmasi sebelumnya
+- error                   → query gagal
+*/
+func (r *handoverRepository) ConfirmHandover(bookingID, kind, note string) error {
+	var customerNote *string
+	if note != "" {
+		customerNote = &note
+	}
+
+	res, err := r.db.Exec(`
+		UPDATE booking_handover
+		SET customer_confirmed_at = NOW(), customer_note = $3, updated_at = NOW()
+		WHERE booking_id = $1 AND kind = $2 AND customer_confirmed_at IS NULL
+	`, bookingID, kind, customerNote)
+	if err != nil {
+		log.Printf("ConfirmHandover: update error booking=%s kind=%s err=%v", bookingID, kind, err)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM booking_handover WHERE booking_id = $1 AND kind = $2)`, bookingID, kind); err != nil {
+		log.Printf("ConfirmHandover: exists check error booking=%s kind=%s err=%v", bookingID, kind, err)
+		return err
+	}
+	if exists {
+		return errors.New("confirmed")
+	}
+	return sql.ErrNoRows
+}
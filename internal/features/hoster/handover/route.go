@@ -0,0 +1,41 @@
+package handover
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupHandoverRoutes mendaftarkan endpoint berita acara serah terima (handover) booking untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET  /booking/{id}/handover        → handover pickup & return + perbandingan kondisi
+  - POST /booking/{id}/handover/{kind} → isi / isi ulang handover pickup atau return (multipart)
+
+4. GET butuh permission bookings:view, pengisian butuh bookings:update (role toko)
+
+Output:
+- Router terkonfigurasi dengan endpoint handover hoster
+*/
+func SetupHandoverRoutes(router *mux.Router, h *HosterHandoverHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	protected.HandleFunc("/booking/{id}/handover", middleware.HosterPermission(domain.HosterPermBookingsView, h.GetHandovers)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/booking/{id}/handover/{kind}", middleware.HosterPermission(domain.HosterPermBookingsUpdate, h.SubmitHandover)).Methods("POST", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,341 @@
+package handover
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
+)
+
+// Batas isi handover.
+const (
+	MaxHandoverPhotosPerItem   = 6    // Foto per booking_item (lama yang dipertahankan + baru)
+	MaxHandoverChecklistPoints = 30   // Poin checklist per booking_item
+	MaxChecklistLabelLength    = 100  // Panjang label satu poin checklist
+	MaxHandoverNoteLength      = 1000 // Panjang catatan handover / item / konfirmasi customer
+)
+
+/*
+HandoverService adalah kontrak logika bisnis berita acara serah terima (handover) untuk hoster.
+*/
+type HandoverService interface {
+	GetHandovers(hosterID, bookingID string) (*dto.BookingHandoversResponse, error)
+	SubmitHandover(ctx context.Context, hosterID, memberID, bookingID, kind string, req dto.SubmitHandoverByHosterRequest, photos []HandoverPhoto) (*dto.BookingHandoversResponse, error)
+}
+
+/*
+handoverService adalah implementasi service handover hoster.
+*/
+type handoverService struct {
+	repo    HandoverRepository
+	storage utils.Storage
+	config  config.StorageConfig
+}
+
+/*
+NewHandoverService membuat instance service dengan dependency injection.
+
+Output:
+- HandoverService siap digunakan
+*/
+func NewHandoverService(repo HandoverRepository, storage utils.Storage, cfg config.StorageConfig) HandoverService {
+	return &handoverService{repo: repo, storage: storage, config: cfg}
+}
+
+/*
+HandoverPhoto adalah satu foto baru untuk sebuah booking_item yang dikirim via multipart form.
+*/
+type HandoverPhoto struct {
+	BookingItemID string
+	Reader        io.Reader
+	ContentType   string // image/jpeg, image/png, image/webp
+}
+
+/*
+handoverPhotoExtensions memetakan content type foto handover ke ekstensi file.
+*/
+var handoverPhotoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+/*
+GetHandovers mengembalikan handover pickup & return booking milik hoster beserta perbandingannya.
+
+Output sukses:
+- (*dto.BookingHandoversResponse, nil)
+Output error:
+- (nil, error) → unauthorized / booking tidak ditemukan / internal error
+*/
+func (s *handoverService) GetHandovers(hosterID, bookingID string) (*dto.BookingHandoversResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if _, _, err := s.repo.GetBookingForHandover(hosterID, bookingID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf(message.NotFound, "booking")
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	result, err := s.repo.GetHandovers(bookingID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return result, nil
+}
+
+/*
+SubmitHandover membuat / mengisi ulang handover pickup atau return.
+Pengisian oleh hoster sekaligus konfirmasi hoster; konfirmasi customer di-reset jika record diisi ulang.
+
+Alur kerja:
+1. Validasi jenis handover dan status booking:
+  - pickup → booking on_progress / on_rent
+  - return → booking on_rent / completed, handover pickup wajib sudah ada
+
+2. Sudah dikonfirmasi customer → tolak (record dikunci sebagai bukti)
+3. Validasi item: setiap booking_item tepat satu kali, kondisi, checklist, catatan
+4. Foto lama yang dipertahankan harus berasal dari record lama, total foto per item maksimal 6
+5. Upload foto baru ke bucket hoster: {hosterID}/handover/{bookingID}/{kind}_DDMMYYYY_{uuid}.ext
+6. Simpan via repository, foto baru dihapus lagi jika DB gagal; foto lama yang dibuang dihapus dari storage
+
+Output sukses:
+- (*dto.BookingHandoversResponse, nil) → data handover terbaru
+Output error:
+- (nil, error) → message.Handover* / UploadInvalidContentType / booking tidak ditemukan / internal error
+*/
+func (s *handoverService) SubmitHandover(ctx context.Context, hosterID, memberID, bookingID, kind string, req dto.SubmitHandoverByHosterRequest, photos []HandoverPhoto) (*dto.BookingHandoversResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if kind != domain.HandoverKindPickup && kind != domain.HandoverKindReturn {
+		return nil, errors.New(message.HandoverInvalidKind)
+	}
+
+	status, bookingItemIDs, err := s.repo.GetBookingForHandover(hosterID, bookingID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf(message.NotFound, "booking")
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	allowed := map[string][]string{
+		domain.HandoverKindPickup: {"on_progress", "on_rent"},
+		domain.HandoverKindReturn: {"on_rent", "completed"},
+	}
+	if !containsString(allowed[kind], status) {
+		return nil, errors.New(message.HandoverInvalidStatus)
+	}
+
+	current, err := s.repo.GetHandovers(bookingID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if kind == domain.HandoverKindReturn && current.Pickup == nil {
+		return nil, errors.New(message.HandoverPickupRequired)
+	}
+	previous := current.Pickup
+	if kind == domain.HandoverKindReturn {
+		previous = current.Return
+	}
+	if previous != nil && previous.CustomerConfirmedAt != nil {
+		return nil, errors.New(message.HandoverLocked)
+	}
+
+	handover, err := buildHandover(bookingID, kind, memberID, req, bookingItemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Foto lama per booking_item: yang dipertahankan harus berasal dari record lama
+	oldPhotos := map[string]map[string]bool{}
+	if previous != nil {
+		for _, item := range previous.Items {
+			oldPhotos[item.BookingItemID] = map[string]bool{}
+			for _, url := range item.Photos {
+				oldPhotos[item.BookingItemID][url] = true
+			}
+		}
+	}
+	newCount := map[string]int{}
+	for _, p := range photos {
+		newCount[p.BookingItemID]++
+	}
+	itemIndex := map[string]int{}
+	for i, item := range handover.Items {
+		itemIndex[item.BookingItemID] = i
+		for _, url := range item.Photos {
+			if !oldPhotos[item.BookingItemID][url] {
+				return nil, errors.New(message.HandoverInvalidPhotos)
+			}
+		}
+		if len(item.Photos)+newCount[item.BookingItemID] > MaxHandoverPhotosPerItem {
+			return nil, errors.New(message.HandoverInvalidPhotos)
+		}
+	}
+
+	var uploaded []string
+	cleanup := func() {
+		for _, url := range uploaded {
+			_ = s.storage.Delete(ctx, url, s.config.HosterBucket)
+		}
+	}
+
+	// Generate filename: {kind}_DDMMYYYY_{uuid}.ext
+	dateStr := time.Now().Format("02012006")
+	for _, p := range photos {
+		i, ok := itemIndex[p.BookingItemID]
+		if !ok {
+			cleanup()
+			return nil, errors.New(message.HandoverInvalidItems)
+		}
+		ext, ok := handoverPhotoExtensions[p.ContentType]
+		if !ok {
+			cleanup()
+			return nil, errors.New(message.UploadInvalidContentType)
+		}
+		path := fmt.Sprintf("%s/handover/%s/%s_%s_%s%s", hosterID, bookingID, kind, dateStr, uuid.New().String()[:8], ext)
+
+		url, err := s.storage.Upload(ctx, p.Reader, path, p.ContentType, s.config.HosterBucket)
+		if err != nil {
+			cleanup()
+			log.Printf("SubmitHandover: failed to upload photo booking=%s err=%v", bookingID, err)
+			return nil, errors.New(message.InternalError)
+		}
+		uploaded = append(uploaded, url)
+		handover.Items[i].Photos = append(handover.Items[i].Photos, url)
+	}
+
+	if err := s.repo.SaveHandover(handover); err != nil {
+		cleanup()
+		if err.Error() == "confirmed" {
+			return nil, errors.New(message.HandoverLocked)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	// Foto lama yang tidak dipertahankan dihapus dari storage (best effort, sisa dibersihkan storage GC)
+	if previous != nil {
+		kept := map[string]bool{}
+		for _, item := range handover.Items {
+			for _, url := range item.Photos {
+				kept[url] = true
+			}
+		}
+		for _, item := range previous.Items {
+			for _, url := range item.Photos {
+				if !kept[url] {
+					if err := s.storage.Delete(ctx, url, s.config.HosterBucket); err != nil {
+						log.Printf("SubmitHandover: failed to delete removed photo %s: %v", url, err)
+					}
+				}
+			}
+		}
+	}
+
+	log.Printf("SubmitHandover: hoster %s recorded %s handover for booking %s (%d new photos)", hosterID, kind, bookingID, len(uploaded))
+
+	result, err := s.repo.GetHandovers(bookingID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return result, nil
+}
+
+/*
+buildHandover memvalidasi request dan membangun entity handover.
+Setiap booking_item wajib muncul tepat satu kali.
+*/
+func buildHandover(bookingID, kind, memberID string, req dto.SubmitHandoverByHosterRequest, bookingItemIDs []string) (*domain.BookingHandover, error) {
+	notes := strings.TrimSpace(req.Notes)
+	if len(notes) > MaxHandoverNoteLength {
+		return nil, fmt.Errorf(message.TooLong, "notes")
+	}
+	if len(req.Items) != len(bookingItemIDs) {
+		return nil, errors.New(message.HandoverInvalidItems)
+	}
+
+	handover := &domain.BookingHandover{
+		BookingID: bookingID,
+		Kind:      kind,
+		Notes:     optionalString(notes),
+		Items:     make([]domain.BookingHandoverItem, 0, len(req.Items)),
+	}
+	if memberID != "" {
+		handover.RecordedByMemberID = &memberID
+	}
+
+	seen := map[string]bool{}
+	for _, item := range req.Items {
+		if !containsString(bookingItemIDs, item.BookingItemID) || seen[item.BookingItemID] {
+			return nil, errors.New(message.HandoverInvalidItems)
+		}
+		seen[item.BookingItemID] = true
+
+		switch item.Condition {
+		case domain.UnitConditionGood, domain.UnitConditionFair, domain.UnitConditionDamaged:
+		default:
+			return nil, errors.New(message.HandoverInvalidCondition)
+		}
+
+		itemNotes := strings.TrimSpace(item.Notes)
+		if len(itemNotes) > MaxHandoverNoteLength {
+			return nil, fmt.Errorf(message.TooLong, "notes")
+		}
+
+		if len(item.Checklist) > MaxHandoverChecklistPoints {
+			return nil, errors.New(message.HandoverInvalidChecklist)
+		}
+		checklist := make([]domain.HandoverChecklistItem, 0, len(item.Checklist))
+		for _, point := range item.Checklist {
+			label := strings.TrimSpace(point.Label)
+			if label == "" || len(label) > MaxChecklistLabelLength {
+				return nil, errors.New(message.HandoverInvalidChecklist)
+			}
+			checklist = append(checklist, domain.HandoverChecklistItem{Label: label, Checked: point.Checked})
+		}
+
+		photos := item.Photos
+		if photos == nil {
+			photos = []string{}
+		}
+		handover.Items = append(handover.Items, domain.BookingHandoverItem{
+			BookingItemID: item.BookingItemID,
+			Condition:     item.Condition,
+			Notes:         optionalString(itemNotes),
+			Checklist:     checklist,
+			Photos:        photos,
+		})
+	}
+	return handover, nil
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
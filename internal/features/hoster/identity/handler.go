@@ -0,0 +1,174 @@
+package identity
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+	"lalan-be/internal/utils"
+)
+
+/*
+HosterIdentityHandler menangani endpoint HTTP untuk verifikasi identitas (KYC) hoster.
+*/
+type HosterIdentityHandler struct {
+	service HosterIdentityService
+}
+
+/*
+NewHosterIdentityHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterIdentityHandler siap digunakan
+*/
+func NewHosterIdentityHandler(s HosterIdentityService) *HosterIdentityHandler {
+	return &HosterIdentityHandler{service: s}
+}
+
+/*
+SubmitIdentity menangani POST /api/v1/hoster/identity dan PUT /api/v1/hoster/identity (upload ulang).
+Setiap pengajuan disimpan sebagai record baru dengan status "pending".
+
+Alur kerja:
+1. Ambil hosterID dari JWT context
+2. Parse multipart form (max 20 MB, dokumen usaha bisa berupa PDF)
+3. Ambil field dokumen identitas + nomor NIB/NPWP (opsional)
+4. Ambil file "document" (wajib), "selfie"/"back" (opsional, gambar), "nib"/"npwp" (opsional, gambar/PDF)
+5. Panggil service.SubmitIdentity()
+
+Output sukses:
+- 200 OK, message "identity document uploaded successfully" (POST) / "identity document updated successfully" (PUT)
+Output error:
+- 400 Bad Request  → form tidak valid / file tidak didukung / dokumen tidak valid
+- 401 Unauthorized → token tidak valid
+- 500 Internal     → kegagalan storage / database
+*/
+func (h *HosterIdentityHandler) SubmitIdentity(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(20 << 20); err != nil {
+		log.Printf("HosterIdentity.SubmitIdentity: failed to parse multipart form: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	input := utils.DocumentInput{
+		Type:    r.FormValue("document_type"),
+		Number:  r.FormValue("document_number"),
+		Country: r.FormValue("document_country"),
+		Expiry:  r.FormValue("document_expiry"),
+	}
+	business := map[domain.BusinessDocumentType]string{
+		domain.BusinessDocumentNIB:  strings.TrimSpace(r.FormValue("nib_number")),
+		domain.BusinessDocumentNPWP: strings.TrimSpace(r.FormValue("npwp_number")),
+	}
+
+	files, closeFiles, errMsg := parseIdentityFiles(r)
+	defer closeFiles()
+	if errMsg != "" {
+		response.BadRequest(w, errMsg)
+		return
+	}
+
+	if err := h.service.SubmitIdentity(r.Context(), hosterID, input, business, files); err != nil {
+		log.Printf("HosterIdentity.SubmitIdentity: service error: %v", err)
+		writeIdentityError(w, err)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		response.OK(w, nil, message.IdentityUpdated)
+		return
+	}
+	response.OK(w, nil, message.IdentityUploaded)
+}
+
+/*
+GetIdentityStatus menangani GET /api/v1/hoster/identity
+
+Output sukses:
+- 200 OK + status pengajuan terakhir (nomor dokumen tersamar) + store_verified
+Output error:
+- 401 Unauthorized → token tidak valid
+- 404 Not Found    → hoster belum pernah mengajukan verifikasi
+- 500 Internal Server Error
+*/
+func (h *HosterIdentityHandler) GetIdentityStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.service.GetIdentityStatus(middleware.GetUserID(r))
+	if err != nil {
+		writeIdentityError(w, err)
+		return
+	}
+	if status == nil {
+		response.NotFound(w, message.IdentityNotFound)
+		return
+	}
+	response.OK(w, status, message.IdentityStatusRetrieved)
+}
+
+/*
+parseIdentityFiles mengambil file dokumen dari multipart form.
+File "document" wajib dan selalu di urutan pertama, foto identitas harus gambar, dokumen usaha boleh gambar/PDF.
+
+Output sukses:
+- (files, closeFiles, "")
+Output error:
+- (nil, closeFiles, pesan error) → file dokumen tidak ada / tipe file tidak didukung
+*/
+func parseIdentityFiles(r *http.Request) ([]IdentityFile, func(), string) {
+	var files []IdentityFile
+	var opened []io.Closer
+	closeFiles := func() {
+		for _, c := range opened {
+			c.Close()
+		}
+	}
+
+	for _, field := range []string{"document", "selfie", "back", "nib", "npwp"} {
+		file, header, err := r.FormFile(field)
+		if err != nil {
+			if field == "document" {
+				return nil, closeFiles, message.IdentityDocumentRequired
+			}
+			continue
+		}
+		opened = append(opened, file)
+
+		contentType := header.Header.Get("Content-Type")
+		isImage := strings.HasPrefix(contentType, "image/")
+		isBusinessPDF := (field == "nib" || field == "npwp") && contentType == "application/pdf"
+		if !isImage && !isBusinessPDF {
+			return nil, closeFiles, message.UploadInvalidContentType
+		}
+		files = append(files, IdentityFile{Kind: field, Reader: file, ContentType: contentType})
+	}
+
+	return files, closeFiles, ""
+}
+
+/*
+writeIdentityError memetakan error service ke HTTP response.
+*/
+func writeIdentityError(w http.ResponseWriter, err error) {
+	if utils.IsDocumentValidationError(err) || utils.IsBusinessDocumentValidationError(err) {
+		response.BadRequest(w, err.Error())
+		return
+	}
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.IdentityDocumentRequired, message.UploadInvalidContentType:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,161 @@
+package identity
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+HosterIdentityRepository mendefinisikan operasi database untuk verifikasi identitas (KYC) hoster.
+*/
+type HosterIdentityRepository interface {
+	CreateIdentity(req *dto.UploadIdentityByHosterRequest) error
+	GetLatestIdentity(hosterID string) (*domain.Identity, error)
+	IsStoreVerified(hosterID string) (bool, error)
+}
+
+/*
+hosterIdentityRepository adalah implementasi repository untuk identitas hoster.
+*/
+type hosterIdentityRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewHosterIdentityRepository membuat instance repository dengan koneksi database.
+
+Output:
+- HosterIdentityRepository siap digunakan
+*/
+func NewHosterIdentityRepository(db *sqlx.DB) HosterIdentityRepository {
+	return &hosterIdentityRepository{db: db}
+}
+
+/*
+CreateIdentity menyimpan record dokumen identitas baru milik hoster (user_role = 'hoster').
+
+Alur kerja:
+1. Marshal foto tambahan dan dokumen usaha (NIB/NPWP) ke JSONB
+2. Insert ke tabel identity dengan status awal "pending"
+3. duplicate_flag dihitung langsung di query: nomor dokumen (jenis sama) sudah terverifikasi di akun hoster lain
+
+Output sukses:
+- nil → insert berhasil
+Output error:
+- error → marshal gagal / query gagal
+*/
+func (r *hosterIdentityRepository) CreateIdentity(req *dto.UploadIdentityByHosterRequest) error {
+	extraPhotos := req.ExtraPhotos
+	if extraPhotos == nil {
+		extraPhotos = []dto.IdentityPhotoRequest{}
+	}
+	extraJSON, err := json.Marshal(extraPhotos)
+	if err != nil {
+		return err
+	}
+
+	businessDocs := req.BusinessDocuments
+	if businessDocs == nil {
+		businessDocs = []dto.BusinessDocumentRequest{}
+	}
+	businessJSON, err := json.Marshal(businessDocs)
+	if err != nil {
+		return err
+	}
+
+	var holderSex *string
+	if req.HolderSex != "" {
+		holderSex = &req.HolderSex
+	}
+
+	query := `
+		INSERT INTO identity (
+			user_id, user_role, document_type, document_url, document_country, document_expiry,
+			extra_photos, business_documents, verified, status, reason, verified_at, created_at, updated_at,
+			document_number_encrypted, document_number_hash, holder_birth_date, holder_sex,
+			duplicate_flag
+		) VALUES (
+			$1, 'hoster', $2, $3, $4, $5, $6, $7, false, 'pending', '', NULL, NOW(), NOW(),
+			$8, $9, $10, $11,
+			EXISTS (
+				SELECT 1 FROM identity
+				WHERE document_type = $2 AND document_number_hash = $9
+				  AND verified = true AND user_id <> $1 AND user_role = 'hoster'
+			)
+		)
+	`
+
+	_, err = r.db.Exec(query,
+		req.UserID,
+		req.DocumentType,
+		req.DocumentURL,
+		req.DocumentCountry,
+		req.DocumentExpiryDate,
+		extraJSON,
+		businessJSON,
+		req.DocumentNumberEncrypted,
+		req.DocumentNumberHash,
+		req.HolderBirthDate,
+		holderSex,
+	)
+	if err != nil {
+		log.Printf("CreateIdentity: insert error hoster=%s: %v", req.UserID, err)
+	}
+	return err
+}
+
+/*
+GetLatestIdentity mengambil record identity terakhir milik hoster.
+
+Output sukses:
+- (*domain.Identity, nil) → record ditemukan
+- (nil, nil)              → hoster belum pernah upload dokumen
+Output error:
+- (nil, error)            → kesalahan database
+*/
+func (r *hosterIdentityRepository) GetLatestIdentity(hosterID string) (*domain.Identity, error) {
+	var identity domain.Identity
+	query := `
+		SELECT
+			id, user_id, user_role, document_type, document_url, document_country,
+			document_expiry, extra_photos, business_documents, verified, status,
+			COALESCE(reason, '') AS reason, verified_at, created_at, updated_at,
+			document_number_encrypted, holder_birth_date, holder_sex, duplicate_flag
+		FROM identity
+		WHERE user_id = $1 AND user_role = 'hoster'
+		ORDER BY created_at DESC, verified_at DESC NULLS LAST
+		LIMIT 1
+	`
+
+	err := r.db.Get(&identity, query, hosterID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		log.Printf("GetLatestIdentity: query error hoster=%s: %v", hosterID, err)
+		return nil, err
+	}
+	return &identity, nil
+}
+
+/*
+IsStoreVerified mengecek badge "toko terverifikasi" milik hoster.
+
+Output sukses:
+- (true/false, nil)
+Output error:
+- (false, sql.ErrNoRows) → hoster tidak ditemukan
+- (false, error)         → query gagal
+*/
+func (r *hosterIdentityRepository) IsStoreVerified(hosterID string) (bool, error) {
+	var verified bool
+	err := r.db.Get(&verified, `SELECT is_verified FROM hoster WHERE id = $1`, hosterID)
+	return verified, err
+}
@@ -0,0 +1,42 @@
+package identity
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupIdentityRoutes mendaftarkan endpoint verifikasi identitas (KYC) untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - POST /identity → ajukan verifikasi toko (dokumen identitas + NIB/NPWP opsional)
+  - PUT  /identity → ajukan ulang (record baru, status kembali pending)
+  - GET  /identity → status pengajuan terakhir + badge toko terverifikasi
+
+Output:
+- Router terkonfigurasi dengan endpoint hoster yang aman dan siap digunakan
+*/
+func SetupIdentityRoutes(router *mux.Router, h *HosterIdentityHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+	protected.Use(middleware.RequireHosterPermission(domain.HosterPermAccountVerify))
+
+	protected.HandleFunc("/identity", h.SubmitIdentity).Methods("POST")
+	protected.HandleFunc("/identity", h.SubmitIdentity).Methods("PUT")
+	protected.HandleFunc("/identity", h.GetIdentityStatus).Methods("GET")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
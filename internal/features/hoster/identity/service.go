@@ -0,0 +1,253 @@
+package identity
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
+)
+
+/*
+HosterIdentityService adalah kontrak untuk logika bisnis verifikasi identitas (KYC) hoster.
+*/
+type HosterIdentityService interface {
+	SubmitIdentity(ctx context.Context, hosterID string, input utils.DocumentInput, business map[domain.BusinessDocumentType]string, files []IdentityFile) error
+	GetIdentityStatus(hosterID string) (*dto.IdentityStatusByHosterResponse, error)
+}
+
+/*
+hosterIdentityService adalah implementasi service untuk identitas hoster.
+*/
+type hosterIdentityService struct {
+	repo    HosterIdentityRepository
+	storage utils.Storage
+	config  config.StorageConfig
+}
+
+/*
+NewHosterIdentityService membuat instance service dengan dependency injection.
+
+Output:
+- HosterIdentityService siap digunakan
+*/
+func NewHosterIdentityService(repo HosterIdentityRepository, storage utils.Storage, cfg config.StorageConfig) HosterIdentityService {
+	return &hosterIdentityService{repo: repo, storage: storage, config: cfg}
+}
+
+/*
+IdentityFile adalah satu file foto dokumen yang dikirim hoster via multipart form.
+*/
+type IdentityFile struct {
+	Kind        string // "document", "selfie", "back", "nib", "npwp"
+	Reader      io.Reader
+	ContentType string // image/jpeg, image/png, image/webp, application/pdf (khusus dokumen usaha)
+}
+
+/*
+SubmitIdentity menyimpan pengajuan verifikasi toko (upload pertama maupun upload ulang).
+Setiap pengajuan = record baru dengan status "pending", record lama tidak diubah.
+
+Alur kerja:
+1. Validasi hosterID dan foto dokumen identitas
+2. Validasi dokumen identitas (KTP/SIM/paspor/KITAS) lalu enkripsi nomor + blind index
+3. Validasi pasangan nomor + file dokumen usaha (NIB 13 digit, NPWP 15/16 digit), nomor dienkripsi
+4. Upload semua file ke bucket hoster: {hosterID}/identity/{kind}_DDMMYYYY_{uuid}.ext
+5. Simpan record identity (user_role = hoster), file dihapus lagi jika DB gagal
+
+Output sukses:
+- nil
+Output error:
+- error → hosterID kosong / dokumen tidak valid (message.Document* / NIK* / BusinessDocument*) / gagal upload / gagal insert DB
+*/
+func (s *hosterIdentityService) SubmitIdentity(ctx context.Context, hosterID string, input utils.DocumentInput, business map[domain.BusinessDocumentType]string, files []IdentityFile) error {
+	if hosterID == "" {
+		return errors.New(message.Unauthorized)
+	}
+	if len(files) == 0 || files[0].Kind != "document" || files[0].Reader == nil {
+		return errors.New(message.IdentityDocumentRequired)
+	}
+
+	doc, err := utils.ProtectDocument(input)
+	if err != nil {
+		return err
+	}
+
+	// Nomor dan file dokumen usaha wajib berpasangan
+	hasFile := map[string]bool{}
+	for _, f := range files {
+		hasFile[f.Kind] = true
+	}
+	encrypted := map[domain.BusinessDocumentType]string{}
+	for _, docType := range []domain.BusinessDocumentType{domain.BusinessDocumentNIB, domain.BusinessDocumentNPWP} {
+		number := business[docType]
+		switch {
+		case number == "" && !hasFile[string(docType)]:
+			continue
+		case number == "":
+			return errors.New(message.BusinessDocumentNumberRequired)
+		case !hasFile[string(docType)]:
+			return errors.New(message.BusinessDocumentFileRequired)
+		}
+		if encrypted[docType], err = utils.ProtectBusinessDocumentNumber(docType, number); err != nil {
+			return err
+		}
+	}
+
+	req := &dto.UploadIdentityByHosterRequest{
+		UploadIdentityByCustomerRequest: dto.UploadIdentityByCustomerRequest{
+			UserID:                  hosterID,
+			DocumentType:            string(doc.Type),
+			DocumentCountry:         doc.Country,
+			DocumentExpiryDate:      doc.Expiry,
+			DocumentNumberEncrypted: doc.Encrypted,
+			DocumentNumberHash:      doc.Hash,
+			HolderBirthDate:         doc.BirthDate,
+			HolderSex:               doc.Sex,
+		},
+	}
+
+	var uploaded []string
+	cleanup := func() {
+		for _, url := range uploaded {
+			_ = s.storage.Delete(ctx, url, s.config.HosterBucket)
+		}
+	}
+
+	// Generate filename: {kind}_DDMMYYYY_{uuid}.ext
+	dateStr := time.Now().Format("02012006")
+	for _, f := range files {
+		_, isBusinessDoc := encrypted[domain.BusinessDocumentType(f.Kind)]
+		ext, ok := identityFileExtensions[f.ContentType]
+		if !ok || (ext == ".pdf" && !isBusinessDoc) {
+			cleanup()
+			return errors.New(message.UploadInvalidContentType)
+		}
+		path := fmt.Sprintf("%s/identity/%s_%s_%s%s", hosterID, f.Kind, dateStr, uuid.New().String()[:8], ext)
+
+		url, err := s.storage.Upload(ctx, f.Reader, path, f.ContentType, s.config.HosterBucket)
+		if err != nil {
+			cleanup()
+			return fmt.Errorf("failed to upload hoster identity %s: %w", f.Kind, err)
+		}
+		uploaded = append(uploaded, url)
+
+		switch {
+		case f.Kind == "document":
+			req.DocumentURL = url
+		case isBusinessDoc:
+			req.BusinessDocuments = append(req.BusinessDocuments, dto.BusinessDocumentRequest{
+				Type:            f.Kind,
+				NumberEncrypted: encrypted[domain.BusinessDocumentType(f.Kind)],
+				URL:             url,
+			})
+		default:
+			req.ExtraPhotos = append(req.ExtraPhotos, dto.IdentityPhotoRequest{Kind: f.Kind, URL: url})
+		}
+	}
+
+	if err := s.repo.CreateIdentity(req); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to save hoster identity record: %w", err)
+	}
+
+	log.Printf("SubmitIdentity(hoster service): hoster %s submitted %s with %d business documents", hosterID, doc.Type, len(req.BusinessDocuments))
+	return nil
+}
+
+/*
+GetIdentityStatus mengembalikan status pengajuan verifikasi toko terakhir + badge toko terverifikasi.
+
+Output sukses:
+- (*dto.IdentityStatusByHosterResponse, nil) → record ditemukan
+- (nil, nil)                                 → hoster belum pernah mengajukan verifikasi
+Output error:
+- (nil, error) → unauthorized / internal error
+*/
+func (s *hosterIdentityService) GetIdentityStatus(hosterID string) (*dto.IdentityStatusByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	model, err := s.repo.GetLatestIdentity(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if model == nil {
+		return nil, nil
+	}
+
+	storeVerified, err := s.repo.IsStoreVerified(hosterID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("GetIdentityStatus(hoster service): failed to load store badge hoster=%s: %v", hosterID, err)
+		return nil, errors.New(message.InternalError)
+	}
+
+	resp := &dto.IdentityStatusByHosterResponse{
+		IdentityStatusByCustomerResponse: dto.IdentityStatusByCustomerResponse{
+			IdentityID:      model.ID,
+			UserID:          model.UserID,
+			DocumentType:    string(model.DocumentType),
+			DocumentURL:     model.DocumentURL,
+			DocumentCountry: model.DocumentCountry,
+			DocumentExpiry:  model.DocumentExpiry,
+			CreatedAt:       model.CreatedAt,
+			Status:          model.Status,
+			Verified:        model.Verified,
+			Reason:          model.Reason,
+			VerifiedAt:      model.VerifiedAt,
+		},
+		StoreVerified: storeVerified,
+	}
+
+	if len(model.ExtraPhotos) > 0 {
+		if err := json.Unmarshal(model.ExtraPhotos, &resp.ExtraPhotos); err != nil {
+			log.Printf("GetIdentityStatus(hoster service): invalid extra_photos for %s: %v", model.ID, err)
+		}
+	}
+
+	// Nomor dokumen hanya ditampilkan tersamar ke hoster
+	if model.DocumentNumberEncrypted != nil {
+		if number, err := utils.RevealDocumentNumber(*model.DocumentNumberEncrypted); err == nil {
+			resp.DocumentNumberMasked = utils.MaskDocumentNumber(model.DocumentType, number)
+		}
+	}
+
+	var businessDocs []domain.BusinessDocument
+	if len(model.BusinessDocuments) > 0 {
+		if err := json.Unmarshal(model.BusinessDocuments, &businessDocs); err != nil {
+			log.Printf("GetIdentityStatus(hoster service): invalid business_documents for %s: %v", model.ID, err)
+		}
+	}
+	for _, doc := range businessDocs {
+		item := dto.BusinessDocumentResponse{Type: string(doc.Type), URL: doc.URL}
+		if number, err := utils.RevealDocumentNumber(doc.NumberEncrypted); err == nil {
+			item.NumberMasked = utils.MaskDocumentNumber(domain.DocumentType(doc.Type), number)
+		}
+		resp.BusinessDocuments = append(resp.BusinessDocuments, item)
+	}
+
+	return resp, nil
+}
+
+/*
+identityFileExtensions memetakan content type file dokumen ke ekstensi file.
+PDF hanya diterima untuk dokumen usaha (NIB/NPWP biasanya berupa scan PDF dari OSS/DJP).
+*/
+var identityFileExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
@@ -2,10 +2,15 @@ package item
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"log"
 	"mime/multipart"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -15,6 +20,7 @@ import (
 	"lalan-be/internal/message"
 	"lalan-be/internal/middleware"
 	"lalan-be/internal/response"
+	"lalan-be/internal/utils"
 )
 
 /*
@@ -41,12 +47,13 @@ GetListItem menangani GET /api/v1/hoster/items
 Alur kerja:
 1. Validasi method GET
 2. Ambil hosterID dari JWT context
-3. Panggil service untuk ambil daftar item milik hoster
+3. Ambil query param opsional store_id untuk filter per store
+4. Panggil service untuk ambil daftar item milik hoster
 
 Output sukses:
 - 200 OK + list item ringkas
 Output error:
-- 401 Unauthorized / 500 Internal Server Error
+- 400 Bad Request (store_id tidak valid) / 401 Unauthorized / 500 Internal Server Error
 */
 func (h *HosterItemHandler) GetListItem(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -60,9 +67,13 @@ func (h *HosterItemHandler) GetListItem(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	items, err := h.service.GetListItem(hosterID)
+	items, err := h.service.GetListItem(hosterID, r.URL.Query().Get("store_id"))
 	if err != nil {
 		log.Printf("GetListItem handler: service error hoster=%s err=%v", hosterID, err)
+		if err.Error() == message.StoreInvalidID {
+			response.BadRequest(w, message.StoreInvalidID)
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, message.InternalError)
 		return
 	}
@@ -213,6 +224,7 @@ func (h *HosterItemHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 		Deposit:     deposit,
 		Discount:    discount,
 		CategoryID:  categoryID,
+		TenantID:    r.FormValue("store_id"), // Kosong = store default
 	}
 
 	// Panggil service
@@ -224,6 +236,10 @@ func (h *HosterItemHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 			response.BadRequest(w, message.BadRequest)
 		case message.Unauthorized:
 			response.Unauthorized(w, message.Unauthorized)
+		case message.HosterUnverifiedItemLimit:
+			response.Forbidden(w, message.HosterUnverifiedItemLimit)
+		case message.StoreNotFound:
+			response.NotFound(w, message.StoreNotFound)
 		default:
 			response.Error(w, http.StatusInternalServerError, message.InternalError)
 		}
@@ -332,6 +348,12 @@ func (h *HosterItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 			response.BadRequest(w, message.BadRequest)
 		case message.Unauthorized:
 			response.Unauthorized(w, message.Unauthorized)
+		case message.StoreNotFound:
+			response.NotFound(w, message.StoreNotFound)
+		case message.ItemStockFromUnits:
+			response.BadRequest(w, message.ItemStockFromUnits)
+		case message.ItemStockFromVariants:
+			response.BadRequest(w, message.ItemStockFromVariants)
 		default:
 			response.Error(w, http.StatusInternalServerError, message.InternalError)
 		}
@@ -385,6 +407,7 @@ Output sukses:
 - 200 OK
 Output error:
 - 400 Bad Request / 401 Unauthorized / 500 Internal Server Error
+- 403 Forbidden → toko belum terverifikasi dan item aktif sudah mencapai batas
 */
 func (h *HosterItemHandler) UpdateVisibility(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPatch {
@@ -419,6 +442,8 @@ func (h *HosterItemHandler) UpdateVisibility(w http.ResponseWriter, r *http.Requ
 			response.Unauthorized(w, message.Unauthorized)
 		case message.BadRequest:
 			response.BadRequest(w, message.BadRequest)
+		case message.HosterUnverifiedItemLimit:
+			response.Forbidden(w, message.HosterUnverifiedItemLimit)
 		default:
 			response.Error(w, http.StatusInternalServerError, message.InternalError)
 		}
@@ -427,3 +452,117 @@ func (h *HosterItemHandler) UpdateVisibility(w http.ResponseWriter, r *http.Requ
 
 	response.OK(w, nil, message.ItemVisibilityUpdated)
 }
+
+/*
+ExportItems menangani GET /api/v1/hoster/item/export?format=csv|xlsx&store_id=uuid
+
+File memakai kolom yang sama dengan file import, sehingga bisa diedit lalu diunggah kembali.
+
+Output sukses:
+- 200 OK + file items_YYYYMMDD.csv / .xlsx
+Output error:
+- 400 Bad Request (format / store_id tidak valid) / 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterItemHandler) ExportItems(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	format := strings.ToLower(strings.TrimSpace(query.Get("format")))
+	if format == "" {
+		format = "csv"
+	}
+
+	started := false
+	err := h.service.ExportItems(hosterID, strings.TrimSpace(query.Get("store_id")), format, func() io.Writer {
+		started = true
+
+		filename := fmt.Sprintf("items_%s.%s", time.Now().Format("20060102"), format)
+		w.Header().Set("Content-Type", utils.ExportContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		w.WriteHeader(http.StatusOK)
+		return w
+	})
+	if err != nil {
+		log.Printf("ExportItems handler: service error hoster=%s format=%s err=%v", hosterID, format, err)
+		if started {
+			// Header sudah terkirim, file di sisi client akan terpotong
+			return
+		}
+		switch err.Error() {
+		case message.Unauthorized:
+			response.Unauthorized(w, message.Unauthorized)
+		case message.ItemExportInvalid, message.StoreInvalidID:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+	}
+}
+
+/*
+ImportItems menangani POST /api/v1/hoster/item/import (multipart: file, mode)
+
+Alur kerja:
+1. Ambil hosterID dari JWT context
+2. Parse multipart (maksimal 10MB), format dari ekstensi file (.csv / .xlsx)
+3. Panggil service untuk validasi per baris dan simpan
+
+Output sukses:
+- 200 OK + laporan import (created, updated, failed, errors per baris)
+Output error:
+- 400 Bad Request (file / mode tidak valid, atau all_or_nothing ditolak + laporan per baris)
+- 403 Forbidden (toko belum terverifikasi melewati batas item aktif)
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterItemHandler) ImportItems(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		log.Printf("ImportItems: failed to parse multipart: %v", err)
+		response.BadRequest(w, message.ItemImportInvalidFile)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.BadRequest(w, fmt.Sprintf(message.Required, "file"))
+		return
+	}
+	defer file.Close()
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	if format != "csv" && format != "xlsx" {
+		response.BadRequest(w, message.ItemImportInvalidFile)
+		return
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(r.FormValue("mode")))
+	result, err := h.service.ImportItems(hosterID, format, mode, file)
+	if err != nil {
+		log.Printf("ImportItems handler: service error hoster=%s err=%v", hosterID, err)
+		switch err.Error() {
+		case message.Unauthorized:
+			response.Unauthorized(w, message.Unauthorized)
+		case message.ItemImportRejected:
+			response.BadRequestWithDetails(w, err.Error(), result)
+		case message.ItemImportInvalidFile, message.ItemImportInvalidMode, message.ItemImportTooManyRows:
+			response.BadRequest(w, err.Error())
+		case message.HosterUnverifiedItemLimit:
+			response.Forbidden(w, message.HosterUnverifiedItemLimit)
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+
+	response.OK(w, result, message.ItemImportCompleted)
+}
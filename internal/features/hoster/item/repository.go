@@ -3,6 +3,7 @@ package item
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"lalan-be/internal/domain"
 	"lalan-be/internal/dto"
@@ -17,15 +18,20 @@ HosterItemRepository mendefinisikan operasi database khusus perspektif hoster.
 Digunakan untuk dashboard item yang dimiliki hoster.
 */
 type HosterItemRepository interface {
-	GetListItem(hosterID string) ([]dto.ItemListByHosterResponse, error)
+	GetListItem(hosterID, storeID string) ([]dto.ItemListByHosterResponse, error)
 	GetItemDetail(hosterID, itemID string) (*dto.ItemDetailByHosterResponse, error)
-	CreateItem(item *domain.Item) (*dto.ItemDetailByHosterResponse, error)
+	CreateItem(item *domain.Item, maxActive int) (*dto.ItemDetailByHosterResponse, error)
 	DeleteItem(hosterID, itemID string) error
 	GetItemPhotos(itemID string) ([]string, error) // Return array URL photos
 	UpdateItem(hosterID, itemID string, req *dto.UpdateItemRequestRequest) error
-	GetCategory() ([]dto.CategoryResponse, error)                  // Get all categories for dropdown
-	HasActiveBookings(itemID string) (bool, error)                 // Cek apakah item punya booking aktif
-	UpdateVisibility(hosterID, itemID string, isHidden bool) error // Toggle visibility item
+	GetCategory() ([]dto.CategoryResponse, error)                         // Get all categories for dropdown
+	HasActiveBookings(itemID string) (bool, error)                        // Cek apakah item punya booking aktif
+	GetActiveItemQuota(hosterID, excludeItemID string) (bool, int, error) // Status verifikasi toko + jumlah item aktif
+	ResolveStoreID(hosterID, storeID string) (string, error)              // Store tujuan item (kosong = store default)
+	GetStoreIDs(hosterID string) ([]string, string, error)                // Semua store hoster + store default (untuk import)
+	GetItemsForExport(hosterID, storeID string) ([]dto.ItemExportRowByHosterResponse, error)
+	ImportItems(hosterID string, creates, updates []*domain.Item, maxActive int) error // Simpan hasil import dalam satu transaksi
+	UpdateVisibility(hosterID, itemID string, isHidden bool, maxActive int) error      // Toggle visibility item
 }
 
 /*
@@ -49,7 +55,7 @@ func NewHosterItemRepository(db *sqlx.DB) HosterItemRepository {
 GetListItem mengambil ringkasan semua item yang dimiliki hoster.
 
 Alur kerja:
-1. Query item dengan filter hoster_id
+1. Query item dengan filter hoster_id (dan store_id jika diisi)
 2. Order by name untuk kemudahan di frontend
 
 Output sukses:
@@ -57,7 +63,7 @@ Output sukses:
 Output error:
 - (nil, error) → query gagal
 */
-func (r *hosterItemRepository) GetListItem(hosterID string) ([]dto.ItemListByHosterResponse, error) {
+func (r *hosterItemRepository) GetListItem(hosterID, storeID string) ([]dto.ItemListByHosterResponse, error) {
 	query := `
         SELECT
             id,
@@ -65,14 +71,18 @@ func (r *hosterItemRepository) GetListItem(hosterID string) ([]dto.ItemListByHos
             stock,
             price_per_day,
             pickup_type,
-            is_hidden
+            is_hidden,
+            tenant_id,
+            unit_tracking,
+            cardinality(variant_dimensions) > 0 AS has_variants
         FROM item
         WHERE hoster_id = $1
+          AND ($2 = '' OR tenant_id::text = $2)
         ORDER BY created_at DESC
     `
 
 	var items []dto.ItemListByHosterResponse
-	err := r.db.Select(&items, query, hosterID)
+	err := r.db.Select(&items, query, hosterID, storeID)
 	if err != nil {
 		log.Printf("GetListItem: database error for hoster %s: %v", hosterID, err)
 		return nil, err
@@ -98,26 +108,30 @@ func (r *hosterItemRepository) GetItemDetail(hosterID, itemID string) (*dto.Item
 	var (
 		detail dto.ItemDetailByHosterResponse
 		row    struct {
-			ID          string          `db:"id"`
-			Name        string          `db:"name"`
-			Description sql.NullString  `db:"description"`
-			Photos      json.RawMessage `db:"photos"`
-			Stock       int             `db:"stock"`
-			PickupType  string          `db:"pickup_type"`
-			PricePerDay int             `db:"price_per_day"`
-			Deposit     int             `db:"deposit"`
-			Discount    sql.NullInt64   `db:"discount"`
-			CreatedAt   sql.NullTime    `db:"created_at"`
-			UpdatedAt   sql.NullTime    `db:"updated_at"`
-			CategoryID  string          `db:"category_id"`
-			HosterID    string          `db:"hoster_id"`
-			IsHidden    bool            `db:"is_hidden"`
+			ID           string          `db:"id"`
+			Name         string          `db:"name"`
+			Description  sql.NullString  `db:"description"`
+			Photos       json.RawMessage `db:"photos"`
+			Stock        int             `db:"stock"`
+			PickupType   string          `db:"pickup_type"`
+			PricePerDay  int             `db:"price_per_day"`
+			Deposit      int             `db:"deposit"`
+			Discount     sql.NullInt64   `db:"discount"`
+			CreatedAt    sql.NullTime    `db:"created_at"`
+			UpdatedAt    sql.NullTime    `db:"updated_at"`
+			CategoryID   string          `db:"category_id"`
+			HosterID     string          `db:"hoster_id"`
+			IsHidden     bool            `db:"is_hidden"`
+			TenantID     string          `db:"tenant_id"`
+			UnitTracking bool            `db:"unit_tracking"`
+			HasVariants  bool            `db:"has_variants"`
 		}
 	)
 
 	query := `
 		SELECT id, name, description, photos, stock, pickup_type,
-		       price_per_day, deposit, discount, created_at, updated_at, category_id, hoster_id, is_hidden
+		       price_per_day, deposit, discount, created_at, updated_at, category_id, hoster_id, is_hidden, tenant_id, unit_tracking,
+		       cardinality(variant_dimensions) > 0 AS has_variants
 		FROM item 
 		WHERE id = $1 AND hoster_id = $2
 	`
@@ -166,6 +180,9 @@ func (r *hosterItemRepository) GetItemDetail(hosterID, itemID string) (*dto.Item
 	detail.CategoryID = row.CategoryID
 	detail.HosterID = row.HosterID
 	detail.IsHidden = row.IsHidden
+	detail.StoreID = row.TenantID
+	detail.UnitTracking = row.UnitTracking
+	detail.HasVariants = row.HasVariants
 
 	return &detail, nil
 }
@@ -174,16 +191,19 @@ func (r *hosterItemRepository) GetItemDetail(hosterID, itemID string) (*dto.Item
 CreateItem menyimpan item baru ke dalam database.
 
 Alur kerja:
-1. Menyusun query INSERT untuk menambah item
-2. Menjalankan query dengan parameter yang diberikan
-3. Mengembalikan ID item yang baru dibuat
+1. Kunci kuota listing aktif hoster (lihat lockActiveItemQuota)
+2. Menyusun query INSERT untuk menambah item
+3. Menjalankan query dengan parameter yang diberikan
+4. Pastikan batas listing aktif toko belum terverifikasi tidak terlewati (maxActive)
+5. Mengembalikan ID item yang baru dibuat
 
 Output sukses:
 - (id_item_baru, nil)
 Output error:
+- (nil, errors.New("active_item_limit")) → toko belum terverifikasi sudah mencapai batas
 - ("", error) → query gagal
 */
-func (r *hosterItemRepository) CreateItem(item *domain.Item) (*dto.ItemDetailByHosterResponse, error) {
+func (r *hosterItemRepository) CreateItem(item *domain.Item, maxActive int) (*dto.ItemDetailByHosterResponse, error) {
 	// mulai transaction
 	tx, err := r.db.Beginx()
 	if err != nil {
@@ -192,11 +212,16 @@ func (r *hosterItemRepository) CreateItem(item *domain.Item) (*dto.ItemDetailByH
 	}
 	defer tx.Rollback()
 
+	quota, err := lockActiveItemQuota(tx, item.HosterID)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
         INSERT INTO item (
             id, hoster_id, name, description, photos, stock, pickup_type,
-            price_per_day, deposit, discount, category_id, created_at, updated_at
-        ) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,NOW(),NOW())
+            price_per_day, deposit, discount, category_id, tenant_id, created_at, updated_at
+        ) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,NOW(),NOW())
     `
 	photosJSON, err := json.Marshal(item.Photos)
 	if err != nil {
@@ -207,13 +232,17 @@ func (r *hosterItemRepository) CreateItem(item *domain.Item) (*dto.ItemDetailByH
 	_, err = tx.Exec(query,
 		item.ID, item.HosterID, item.Name, item.Description, photosJSON,
 		item.Stock, item.PickupType, item.PricePerDay, item.Deposit, item.Discount,
-		item.CategoryID,
+		item.CategoryID, item.TenantID,
 	)
 	if err != nil {
 		log.Printf("CreateItem: error inserting item %s: %v", item.ID, err)
 		return nil, err
 	}
 
+	if err = quota.check(tx, maxActive); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		log.Printf("CreateItem: error committing transaction: %v", err)
 		return nil, err
@@ -237,12 +266,13 @@ func (r *hosterItemRepository) CreateItem(item *domain.Item) (*dto.ItemDetailByH
 			UpdatedAt   sql.NullTime    `db:"updated_at"`
 			CategoryID  string          `db:"category_id"`
 			HosterID    string          `db:"hoster_id"`
+			TenantID    string          `db:"tenant_id"`
 		}
 	)
 
 	getQuery := `
         SELECT id, name, description, photos, stock, pickup_type,
-               price_per_day, deposit, discount, created_at, updated_at, category_id, hoster_id
+               price_per_day, deposit, discount, created_at, updated_at, category_id, hoster_id, tenant_id
         FROM item WHERE id = $1
     `
 	err = r.db.Get(&row, getQuery, item.ID)
@@ -282,6 +312,7 @@ func (r *hosterItemRepository) CreateItem(item *domain.Item) (*dto.ItemDetailByH
 	detail.Deposit = row.Deposit
 	detail.CategoryID = row.CategoryID
 	detail.HosterID = row.HosterID
+	detail.StoreID = row.TenantID
 
 	return &detail, nil
 }
@@ -450,6 +481,11 @@ func (r *hosterItemRepository) UpdateItem(hosterID, itemID string, req *dto.Upda
 		args = append(args, *req.Description)
 		argIndex++
 	}
+	if req.StoreID != nil {
+		setParts = append(setParts, fmt.Sprintf("tenant_id = $%d", argIndex))
+		args = append(args, *req.StoreID)
+		argIndex++
+	}
 
 	if len(setParts) == 0 {
 		return fmt.Errorf("no fields to update")
@@ -532,16 +568,18 @@ func (r *hosterItemRepository) HasActiveBookings(itemID string) (bool, error) {
 UpdateVisibility mengubah status visibility item (is_hidden).
 
 Alur kerja:
-1. Mulai transaction
+1. Mulai transaction, kunci kuota listing aktif hoster (lihat lockActiveItemQuota)
 2. Cek ownership item
 3. Update field is_hidden
-4. Commit transaction
+4. Pastikan batas listing aktif toko belum terverifikasi tidak terlewati (maxActive)
+5. Commit transaction
 
 Output:
 - nil - Sukses
+- errors.New("active_item_limit") - toko belum terverifikasi sudah mencapai batas
 - error - Gagal (item not found atau bukan milik hoster)
 */
-func (r *hosterItemRepository) UpdateVisibility(hosterID, itemID string, isHidden bool) error {
+func (r *hosterItemRepository) UpdateVisibility(hosterID, itemID string, isHidden bool, maxActive int) error {
 	tx, err := r.db.Beginx()
 	if err != nil {
 		log.Printf("UpdateVisibility: error starting transaction: %v", err)
@@ -549,6 +587,11 @@ func (r *hosterItemRepository) UpdateVisibility(hosterID, itemID string, isHidde
 	}
 	defer tx.Rollback()
 
+	quota, err := lockActiveItemQuota(tx, hosterID)
+	if err != nil {
+		return err
+	}
+
 	// Cek ownership
 	var ownerID string
 	if err := tx.Get(&ownerID, `SELECT hoster_id FROM item WHERE id = $1`, itemID); err != nil {
@@ -571,6 +614,9 @@ func (r *hosterItemRepository) UpdateVisibility(hosterID, itemID string, isHidde
 		log.Printf("UpdateVisibility: error updating item %s: %v", itemID, err)
 		return err
 	}
+	if err := quota.check(tx, maxActive); err != nil {
+		return err
+	}
 
 	if err = tx.Commit(); err != nil {
 		log.Printf("UpdateVisibility: error committing transaction for item %s: %v", itemID, err)
@@ -580,3 +626,294 @@ func (r *hosterItemRepository) UpdateVisibility(hosterID, itemID string, isHidde
 	log.Printf("UpdateVisibility: successfully updated item %s is_hidden=%v", itemID, isHidden)
 	return nil
 }
+
+/*
+GetActiveItemQuota mengambil status verifikasi toko dan jumlah item aktif (is_hidden = false) milik hoster.
+Paket sewa yang tampil ikut dihitung karena juga tampil di storefront sebagai listing.
+excludeItemID tidak ikut dihitung (dipakai saat item itu sendiri akan ditampilkan kembali).
+
+Output sukses:
+- (is_verified, jumlah item aktif, nil)
+Output error:
+- (false, 0, sql.ErrNoRows) → hoster tidak ditemukan
+- (false, 0, error) → query gagal
+*/
+func (r *hosterItemRepository) GetActiveItemQuota(hosterID, excludeItemID string) (bool, int, error) {
+	var quota struct {
+		IsVerified  bool `db:"is_verified"`
+		ActiveItems int  `db:"active_items"`
+	}
+	query := `
+		SELECT
+			h.is_verified,
+			(
+				SELECT COUNT(*) FROM item i
+				WHERE i.hoster_id = h.id AND i.is_hidden = false
+				  AND ($2 = '' OR i.id::text <> $2)
+			) + (
+				SELECT COUNT(*) FROM bundle bd
+				WHERE bd.hoster_id = h.id AND bd.is_hidden = false
+			) AS active_items
+		FROM hoster h
+		WHERE h.id = $1
+	`
+	if err := r.db.Get(&quota, query, hosterID, excludeItemID); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("GetActiveItemQuota: query error hoster=%s: %v", hosterID, err)
+		}
+		return false, 0, err
+	}
+	return quota.IsVerified, quota.ActiveItems, nil
+}
+
+/*
+activeItemQuota adalah kuota listing aktif hoster yang dikunci di awal transaksi.
+*/
+type activeItemQuota struct {
+	hosterID string
+	verified bool
+	before   int // Jumlah listing aktif sebelum transaksi mengubah data
+}
+
+/*
+countActiveListingsQuery menghitung listing aktif hoster: item + paket yang tidak di-hide.
+*/
+const countActiveListingsQuery = `
+	SELECT
+		(SELECT COUNT(*) FROM item WHERE hoster_id = $1 AND is_hidden = false)
+		+ (SELECT COUNT(*) FROM bundle WHERE hoster_id = $1 AND is_hidden = false)
+`
+
+/*
+lockActiveItemQuota mengunci baris hoster (FOR UPDATE) lalu menghitung listing aktifnya.
+Create / import / tampilkan item (dan paket) yang berjalan bersamaan untuk hoster yang sama
+jadi antri, sehingga batas toko belum terverifikasi tidak bisa dilewati lewat request paralel.
+
+Output sukses:
+- (*activeItemQuota, nil)
+Output error:
+- (nil, sql.ErrNoRows) → hoster tidak ditemukan
+- (nil, error) → query gagal
+*/
+func lockActiveItemQuota(tx *sqlx.Tx, hosterID string) (*activeItemQuota, error) {
+	quota := &activeItemQuota{hosterID: hosterID}
+	if err := tx.Get(&quota.verified, `SELECT is_verified FROM hoster WHERE id = $1 FOR UPDATE`, hosterID); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("lockActiveItemQuota: lock hoster %s error: %v", hosterID, err)
+		}
+		return nil, err
+	}
+	if err := tx.Get(&quota.before, countActiveListingsQuery, hosterID); err != nil {
+		log.Printf("lockActiveItemQuota: count error hoster=%s: %v", hosterID, err)
+		return nil, err
+	}
+	return quota, nil
+}
+
+/*
+check memastikan perubahan di transaksi tidak menambah listing aktif toko belum terverifikasi melewati maxActive.
+Listing lama yang sudah melebihi batas tetap dibiarkan selama jumlahnya tidak bertambah.
+
+Output:
+- nil → boleh commit
+- errors.New("active_item_limit") → batas terlewati
+- error → query gagal
+*/
+func (q *activeItemQuota) check(tx *sqlx.Tx, maxActive int) error {
+	if q.verified {
+		return nil
+	}
+	var after int
+	if err := tx.Get(&after, countActiveListingsQuery, q.hosterID); err != nil {
+		log.Printf("activeItemQuota.check: count error hoster=%s: %v", q.hosterID, err)
+		return err
+	}
+	if after > q.before && after > maxActive {
+		log.Printf("activeItemQuota.check: hoster %s unverified, active listings %d → %d (max %d)", q.hosterID, q.before, after, maxActive)
+		return errors.New("active_item_limit")
+	}
+	return nil
+}
+
+/*
+ResolveStoreID menentukan store (tenant) tempat item disimpan.
+
+Alur kerja:
+1. storeID kosong → pakai store default hoster
+2. storeID diisi → pastikan store milik hoster
+
+Output sukses:
+- (tenant_id, nil)
+Output error:
+- ("", sql.ErrNoRows) → store tidak ada / bukan milik hoster
+- ("", error) → query gagal
+*/
+func (r *hosterItemRepository) ResolveStoreID(hosterID, storeID string) (string, error) {
+	var id string
+	query := `SELECT id FROM tenant WHERE hoster_id = $1 AND id = $2::uuid`
+	args := []interface{}{hosterID, storeID}
+	if storeID == "" {
+		query = `SELECT id FROM tenant WHERE hoster_id = $1 AND is_default`
+		args = args[:1]
+	}
+
+	if err := r.db.Get(&id, query, args...); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("ResolveStoreID: db error hoster=%s store=%s err=%v", hosterID, storeID, err)
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+/*
+GetStoreIDs mengambil semua store milik hoster beserta store default-nya.
+
+Output sukses:
+- ([]tenant_id, default tenant_id, nil)
+Output error:
+- (nil, "", error) → query gagal
+*/
+func (r *hosterItemRepository) GetStoreIDs(hosterID string) ([]string, string, error) {
+	var stores []struct {
+		ID        string `db:"id"`
+		IsDefault bool   `db:"is_default"`
+	}
+	if err := r.db.Select(&stores, `SELECT id, is_default FROM tenant WHERE hoster_id = $1`, hosterID); err != nil {
+		log.Printf("GetStoreIDs: db error hoster=%s err=%v", hosterID, err)
+		return nil, "", err
+	}
+
+	ids := make([]string, 0, len(stores))
+	defaultID := ""
+	for _, store := range stores {
+		ids = append(ids, store.ID)
+		if store.IsDefault {
+			defaultID = store.ID
+		}
+	}
+	return ids, defaultID, nil
+}
+
+/*
+GetItemsForExport mengambil katalog item hoster untuk file export (format sama dengan file import).
+
+Alur kerja:
+1. Query item hoster (opsional satu store) dengan nama kategori
+2. Urut nama agar file mudah dibaca
+
+Output sukses:
+- ([]dto.ItemExportRowByHosterResponse, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterItemRepository) GetItemsForExport(hosterID, storeID string) ([]dto.ItemExportRowByHosterResponse, error) {
+	query := `
+		SELECT
+			i.id,
+			i.name,
+			COALESCE(i.description, '') AS description,
+			COALESCE(c.name, '') AS category_name,
+			i.pickup_type,
+			i.price_per_day,
+			i.deposit,
+			COALESCE(i.discount, 0) AS discount,
+			i.stock,
+			i.tenant_id,
+			i.is_hidden
+		FROM item i
+		LEFT JOIN category c ON c.id = i.category_id
+		WHERE i.hoster_id = $1
+		  AND ($2 = '' OR i.tenant_id::text = $2)
+		ORDER BY i.name ASC, i.created_at ASC
+	`
+
+	var items []dto.ItemExportRowByHosterResponse
+	if err := r.db.Select(&items, query, hosterID, storeID); err != nil {
+		log.Printf("GetItemsForExport: db error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return items, nil
+}
+
+/*
+ImportItems menyimpan hasil import dalam satu transaksi.
+
+Alur kerja:
+1. Kunci kuota listing aktif hoster (lihat lockActiveItemQuota)
+2. Insert item baru (tanpa foto)
+3. Update item lama milik hoster (semua kolom file, foto tidak disentuh)
+4. Pastikan batas listing aktif toko belum terverifikasi tidak terlewati (maxActive)
+5. Satu query gagal → seluruh import di-rollback
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → item yang di-update bukan milik hoster
+- errors.New("active_item_limit") → import melewati batas toko belum terverifikasi
+- error → query gagal
+*/
+func (r *hosterItemRepository) ImportItems(hosterID string, creates, updates []*domain.Item, maxActive int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("ImportItems: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	quota, err := lockActiveItemQuota(tx, hosterID)
+	if err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO item (
+			id, hoster_id, name, description, photos, stock, pickup_type,
+			price_per_day, deposit, discount, category_id, tenant_id, is_hidden, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, '[]'::jsonb, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
+	`
+	for _, item := range creates {
+		_, err := tx.Exec(insertQuery,
+			item.ID, item.HosterID, item.Name, item.Description,
+			item.Stock, item.PickupType, item.PricePerDay, item.Deposit, item.Discount,
+			item.CategoryID, item.TenantID, item.IsHidden,
+		)
+		if err != nil {
+			log.Printf("ImportItems: error inserting item %s: %v", item.Name, err)
+			return err
+		}
+	}
+
+	updateQuery := `
+		UPDATE item
+		SET name = $3, description = $4, stock = $5, pickup_type = $6,
+		    price_per_day = $7, deposit = $8, discount = $9, category_id = $10,
+		    tenant_id = $11, is_hidden = $12, updated_at = NOW()
+		WHERE id = $1 AND hoster_id = $2
+	`
+	for _, item := range updates {
+		result, err := tx.Exec(updateQuery,
+			item.ID, item.HosterID, item.Name, item.Description,
+			item.Stock, item.PickupType, item.PricePerDay, item.Deposit, item.Discount,
+			item.CategoryID, item.TenantID, item.IsHidden,
+		)
+		if err != nil {
+			log.Printf("ImportItems: error updating item %s: %v", item.ID, err)
+			return err
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			log.Printf("ImportItems: item %s not owned by hoster %s", item.ID, item.HosterID)
+			return sql.ErrNoRows
+		}
+	}
+
+	if err := quota.check(tx, maxActive); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ImportItems: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
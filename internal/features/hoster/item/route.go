@@ -1,6 +1,7 @@
 package item
 
 import (
+	"lalan-be/internal/domain"
 	"lalan-be/internal/middleware"
 	"net/http"
 
@@ -18,6 +19,10 @@ Alur kerja:
   - POST /item         → buat item baru oleh hoster
   - PUT  /item/{id}    → update item milik hoster berdasarkan ID
   - DELETE /item/{id}  → hapus item milik hoster berdasarkan ID
+  - GET  /item/export  → export katalog ke CSV / XLSX (format file import)
+  - POST /item/import  → import item massal dari CSV / XLSX (all_or_nothing / partial)
+
+4. GET butuh permission items:view, perubahan data butuh items:manage (role toko)
 
 Output:
 - Router terkonfigurasi dengan endpoint hoster yang aman dan siap digunakan
@@ -30,13 +35,15 @@ func SetupItemRoutes(router *mux.Router, h *HosterItemHandler) {
 	protected.Use(middleware.Hoster)
 
 	// Route normal (use singular "item")
-	protected.HandleFunc("/item", h.GetListItem).Methods("GET", "OPTIONS")
-	protected.HandleFunc("/item", h.CreateItem).Methods("POST", "OPTIONS")
-	protected.HandleFunc("/item/category", h.GetCategory).Methods("GET", "OPTIONS") // Dropdown categories
-	protected.HandleFunc("/item/{id}", h.GetItemDetail).Methods("GET", "OPTIONS")
-	protected.HandleFunc("/item/{id}", h.UpdateItem).Methods("PUT", "OPTIONS")
-	protected.HandleFunc("/item/{id}", h.DeleteItem).Methods("DELETE", "OPTIONS")
-	protected.HandleFunc("/item/visibility/{id}", h.UpdateVisibility).Methods("PATCH", "OPTIONS") // Toggle visibility
+	protected.HandleFunc("/item", middleware.HosterPermission(domain.HosterPermItemsView, h.GetListItem)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/item", middleware.HosterPermission(domain.HosterPermItemsManage, h.CreateItem)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/item/category", middleware.HosterPermission(domain.HosterPermItemsView, h.GetCategory)).Methods("GET", "OPTIONS") // Dropdown categories
+	protected.HandleFunc("/item/export", middleware.HosterPermission(domain.HosterPermItemsView, h.ExportItems)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/item/import", middleware.HosterPermission(domain.HosterPermItemsManage, h.ImportItems)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/item/{id}", middleware.HosterPermission(domain.HosterPermItemsView, h.GetItemDetail)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/item/{id}", middleware.HosterPermission(domain.HosterPermItemsManage, h.UpdateItem)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/item/{id}", middleware.HosterPermission(domain.HosterPermItemsManage, h.DeleteItem)).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/item/visibility/{id}", middleware.HosterPermission(domain.HosterPermItemsManage, h.UpdateVisibility)).Methods("PATCH", "OPTIONS") // Toggle visibility
 
 	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
 	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -4,11 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"io"
 	"log"
 	"mime/multipart"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"lalan-be/internal/config"
 	"lalan-be/internal/domain"
 	"lalan-be/internal/dto"
@@ -21,13 +26,15 @@ ItemService adalah kontrak untuk logika bisnis domain item dari perspektif hoste
 Menyediakan operasi read (list) dan create untuk hoster.
 */
 type ItemService interface {
-	GetListItem(hosterID string) ([]dto.ItemListByHosterResponse, error)
+	GetListItem(hosterID, storeID string) ([]dto.ItemListByHosterResponse, error)
 	GetItemDetail(hosterID, itemID string) (*dto.ItemDetailByHosterResponse, error)
 	CreateItem(ctx context.Context, item *domain.Item, photoFiles []*multipart.FileHeader) (*dto.ItemDetailByHosterResponse, error)
 	DeleteItem(hosterID, itemID string) error
 	UpdateItem(hosterID, itemID string, req *dto.UpdateItemRequestRequest) error
 	GetCategory() ([]dto.CategoryResponse, error)                  // Get categories for dropdown
 	ToggleVisibility(hosterID, itemID string, isHidden bool) error // Toggle item visibility
+	ImportItems(hosterID, format, mode string, file io.Reader) (*dto.ImportItemsByHosterResponse, error)
+	ExportItems(hosterID, storeID, format string, open func() io.Writer) error
 }
 
 /*
@@ -54,21 +61,26 @@ func NewItemService(repo HosterItemRepository, storage utils.Storage, config con
 GetListItem mengambil daftar ringkas semua item milik hoster.
 
 Alur kerja:
-1. Validasi hosterID tidak kosong
+1. Validasi hosterID tidak kosong dan storeID (jika diisi) berupa UUID
 2. Panggil repository
 3. Wrap error menjadi InternalError
 
 Output sukses:
 - ([]dto.ItemListByHosterResponse, nil)
 Output error:
-- (nil, error) → unauthorized / internal error
+- (nil, error) → unauthorized / StoreInvalidID / internal error
 */
-func (s *itemService) GetListItem(hosterID string) ([]dto.ItemListByHosterResponse, error) {
+func (s *itemService) GetListItem(hosterID, storeID string) ([]dto.ItemListByHosterResponse, error) {
 	if hosterID == "" {
 		return nil, errors.New(message.Unauthorized)
 	}
+	if storeID != "" {
+		if _, err := uuid.Parse(storeID); err != nil {
+			return nil, errors.New(message.StoreInvalidID)
+		}
+	}
 
-	items, err := s.repo.GetListItem(hosterID)
+	items, err := s.repo.GetListItem(hosterID, storeID)
 	if err != nil {
 		log.Printf("GetListItem(hoster service): repo error for hoster %s: %v", hosterID, err)
 		return nil, errors.New(message.InternalError)
@@ -115,13 +127,15 @@ CreateItem menyimpan item baru (oleh hoster).
 
 Alur kerja:
 1. Validasi input minimal (hoster/user id, name, stock, price_per_day, pickup_type)
-2. Panggil repository untuk menyimpan item
-3. Wrap error menjadi InternalError / BadRequest
+2. Toko belum terverifikasi → tolak jika item aktif sudah mencapai batas (UNVERIFIED_HOSTER_MAX_ACTIVE_ITEMS)
+3. Tentukan store item (store_id kosong → store default hoster)
+4. Panggil repository untuk menyimpan item
+5. Wrap error menjadi InternalError / BadRequest
 
 Output sukses:
 - (*dto.ItemDetailByHosterResponse, nil)
 Output error:
-- (nil, error) → unauthorized / bad request / internal error
+- (nil, error) → unauthorized / bad request / HosterUnverifiedItemLimit / StoreNotFound / internal error
 */
 func (s *itemService) CreateItem(ctx context.Context, item *domain.Item, photoFiles []*multipart.FileHeader) (*dto.ItemDetailByHosterResponse, error) {
 	// Validasi existing
@@ -145,6 +159,18 @@ func (s *itemService) CreateItem(ctx context.Context, item *domain.Item, photoFi
 		log.Printf("CreateItem: invalid pickup_type")
 		return nil, errors.New(message.BadRequest)
 	}
+
+	// Item baru langsung tampil → cek batas item aktif untuk toko yang belum terverifikasi
+	if err := s.checkActiveItemLimit(item.HosterID, ""); err != nil {
+		return nil, err
+	}
+
+	storeID, err := s.resolveStore(item.HosterID, item.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	item.TenantID = storeID
+
 	// Handle upload jika ada photoFiles
 	if len(photoFiles) > 0 {
 		var photoURLs []string
@@ -164,8 +190,11 @@ func (s *itemService) CreateItem(ctx context.Context, item *domain.Item, photoFi
 	}
 
 	// Panggil repository
-	created, err := s.repo.CreateItem(item)
+	created, err := s.repo.CreateItem(item, config.GetUnverifiedHosterMaxActiveItems())
 	if err != nil {
+		if err.Error() == "active_item_limit" {
+			return nil, errors.New(message.HosterUnverifiedItemLimit)
+		}
 		log.Printf("CreateItem(hoster service): repo error for hoster %s: %v", item.HosterID, err)
 		return nil, errors.New(message.InternalError)
 	}
@@ -236,6 +265,9 @@ Validasi:
   - hosterID tidak kosong -> Unauthorized
   - itemID tidak kosong, req tidak nil -> BadRequest
   - Validasi field req (stock >= 0, pickup_type valid, dll.)
+  - store_id (jika diisi) harus store milik hoster -> StoreNotFound
+  - stock tidak bisa diubah jika unit tracking aktif -> ItemStockFromUnits
+  - stock tidak bisa diubah jika item bervarian -> ItemStockFromVariants
 
 Business:
   - Panggil repo.UpdateItem
@@ -264,6 +296,33 @@ func (s *itemService) UpdateItem(hosterID, itemID string, req *dto.UpdateItemReq
 	if req.PricePerDay != nil && *req.PricePerDay <= 0 {
 		return errors.New(message.BadRequest)
 	}
+	if req.StoreID != nil {
+		if *req.StoreID == "" {
+			return errors.New(message.BadRequest)
+		}
+		storeID, err := s.resolveStore(hosterID, *req.StoreID)
+		if err != nil {
+			return err
+		}
+		req.StoreID = &storeID
+	}
+
+	// Item dengan unit tracking / varian → stock mengikuti jumlah unit aktif / stock varian
+	if req.Stock != nil {
+		detail, err := s.repo.GetItemDetail(hosterID, itemID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New(message.BadRequest)
+			}
+			return errors.New(message.InternalError)
+		}
+		if detail.UnitTracking {
+			return errors.New(message.ItemStockFromUnits)
+		}
+		if detail.HasVariants {
+			return errors.New(message.ItemStockFromVariants)
+		}
+	}
 
 	// Panggil repo.UpdateItem
 	if err := s.repo.UpdateItem(hosterID, itemID, req); err != nil {
@@ -295,6 +354,7 @@ Tidak ada validasi booking - hoster bisa hide/show kapan saja.
 Validasi:
   - hosterID tidak kosong -> Unauthorized
   - itemID tidak kosong -> BadRequest
+  - tampilkan item (is_hidden=false) oleh toko belum terverifikasi yang sudah mencapai batas -> HosterUnverifiedItemLimit
 
 Business:
   - Panggil repo.UpdateVisibility
@@ -307,11 +367,20 @@ func (s *itemService) ToggleVisibility(hosterID, itemID string, isHidden bool) e
 		return errors.New(message.BadRequest)
 	}
 
+	if !isHidden {
+		if err := s.checkActiveItemLimit(hosterID, itemID); err != nil {
+			return err
+		}
+	}
+
 	// Tidak ada validasi booking - selalu bisa hide/show
-	if err := s.repo.UpdateVisibility(hosterID, itemID, isHidden); err != nil {
+	if err := s.repo.UpdateVisibility(hosterID, itemID, isHidden, config.GetUnverifiedHosterMaxActiveItems()); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return errors.New(message.BadRequest)
 		}
+		if err.Error() == "active_item_limit" {
+			return errors.New(message.HosterUnverifiedItemLimit)
+		}
 		log.Printf("ToggleVisibility(service): repo error hoster=%s item=%s err=%v", hosterID, itemID, err)
 		return errors.New(message.InternalError)
 	}
@@ -319,3 +388,463 @@ func (s *itemService) ToggleVisibility(hosterID, itemID string, isHidden bool) e
 	log.Printf("ToggleVisibility: item %s is_hidden=%v by hoster %s", itemID, isHidden, hosterID)
 	return nil
 }
+
+/*
+checkActiveItemLimit memastikan toko yang belum terverifikasi tidak melebihi batas item aktif.
+Toko terverifikasi tidak dibatasi. Item yang sudah aktif sebelum aturan ini tidak di-hide otomatis.
+Ini hanya cek awal (sebelum upload foto); batas ditegakkan lagi di repository dengan baris hoster terkunci.
+
+Output sukses:
+- nil → boleh menambah/menampilkan item
+Output error:
+- error → HosterUnverifiedItemLimit / Unauthorized (hoster tidak ditemukan) / InternalError
+*/
+func (s *itemService) checkActiveItemLimit(hosterID, excludeItemID string) error {
+	verified, active, err := s.repo.GetActiveItemQuota(hosterID, excludeItemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.Unauthorized)
+		}
+		return errors.New(message.InternalError)
+	}
+	if !verified && active >= config.GetUnverifiedHosterMaxActiveItems() {
+		log.Printf("checkActiveItemLimit: hoster %s unverified with %d active items", hosterID, active)
+		return errors.New(message.HosterUnverifiedItemLimit)
+	}
+	return nil
+}
+
+/*
+resolveStore memastikan store tujuan item milik hoster.
+storeID kosong berarti store default.
+
+Output:
+- (tenant_id, nil)
+- ("", error) → StoreNotFound / internal error
+*/
+func (s *itemService) resolveStore(hosterID, storeID string) (string, error) {
+	if storeID != "" {
+		if _, err := uuid.Parse(storeID); err != nil {
+			return "", errors.New(message.StoreNotFound)
+		}
+	}
+
+	id, err := s.repo.ResolveStoreID(hosterID, storeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errors.New(message.StoreNotFound)
+		}
+		log.Printf("resolveStore(hoster service): repo error hoster=%s store=%s err=%v", hosterID, storeID, err)
+		return "", errors.New(message.InternalError)
+	}
+	return id, nil
+}
+
+/*
+Konstanta import item.
+*/
+const (
+	MaxImportRows       = 1000
+	ImportModeAllOrNone = "all_or_nothing"
+	ImportModePartial   = "partial"
+)
+
+/*
+itemFileColumns adalah header file export / import item (urutan kolom file export).
+*/
+var itemFileColumns = []string{
+	"ID", "Name", "Description", "Category", "Pickup Type",
+	"Price Per Day", "Deposit", "Discount", "Stock", "Store ID", "Hidden",
+}
+
+/*
+itemRequiredColumns adalah kolom yang wajib ada di header file import.
+*/
+var itemRequiredColumns = []string{"Name", "Category", "Pickup Type", "Price Per Day", "Stock"}
+
+/*
+ExportItems menulis katalog item hoster ke CSV / XLSX dengan format yang sama seperti file import.
+
+Alur kerja:
+1. Validasi format dan store_id (opsional)
+2. Ambil katalog dari repository
+3. Panggil open() untuk mendapatkan writer, tulis header lalu setiap item
+
+Output sukses:
+- nil
+Output error:
+- error → unauthorized / ItemExportInvalid / StoreInvalidID / internal error
+*/
+func (s *itemService) ExportItems(hosterID, storeID, format string, open func() io.Writer) error {
+	if hosterID == "" {
+		return errors.New(message.Unauthorized)
+	}
+	if format != "csv" && format != "xlsx" {
+		return errors.New(message.ItemExportInvalid)
+	}
+	if storeID != "" {
+		if _, err := uuid.Parse(storeID); err != nil {
+			return errors.New(message.StoreInvalidID)
+		}
+	}
+
+	items, err := s.repo.GetItemsForExport(hosterID, storeID)
+	if err != nil {
+		return errors.New(message.InternalError)
+	}
+
+	writer, err := utils.NewRowWriter(format, open(), "Items")
+	if err != nil {
+		log.Printf("ExportItems(hoster service): writer error hoster=%s err=%v", hosterID, err)
+		return errors.New(message.InternalError)
+	}
+
+	header := make([]any, len(itemFileColumns))
+	for i, column := range itemFileColumns {
+		header[i] = column
+	}
+	if err := writer.WriteRow(header...); err != nil {
+		return errors.New(message.InternalError)
+	}
+
+	for _, item := range items {
+		err := writer.WriteRow(
+			item.ID, item.Name, item.Description, item.CategoryName, string(item.PickupType),
+			item.PricePerDay, item.Deposit, item.Discount, item.Stock, item.StoreID, item.IsHidden,
+		)
+		if err != nil {
+			log.Printf("ExportItems(hoster service): write error hoster=%s err=%v", hosterID, err)
+			return errors.New(message.InternalError)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Printf("ExportItems(hoster service): close error hoster=%s err=%v", hosterID, err)
+		return errors.New(message.InternalError)
+	}
+	return nil
+}
+
+/*
+ImportItems membuat / memperbarui item hoster secara massal dari file CSV / XLSX.
+
+Alur kerja:
+1. Validasi mode (all_or_nothing default, partial) lalu baca file (maksimal 1000 baris data)
+2. Cocokkan header dengan kolom export (huruf besar/kecil dan spasi/underscore diabaikan)
+3. Validasi setiap baris dengan aturan yang sama seperti CreateItem:
+  - name wajib, kategori (nama / ID) harus ada, pickup type valid
+  - price_per_day > 0, deposit / discount / stock >= 0
+  - store milik hoster (kosong → store default / store item saat ini)
+  - ID kosong → item baru, ID terisi → update item milik hoster
+  - toko belum terverifikasi tidak boleh melebihi batas item aktif
+
+4. all_or_nothing: ada baris gagal → tidak ada yang disimpan, laporan + ItemImportRejected
+5. Simpan baris valid dalam satu transaksi
+
+Output sukses:
+- (*dto.ImportItemsByHosterResponse, nil)
+Output error:
+- (*dto.ImportItemsByHosterResponse, error) → ItemImportRejected (laporan per baris terisi)
+- (nil, error) → unauthorized / ItemImportInvalidMode / ItemImportInvalidFile / ItemImportTooManyRows / internal error
+- (nil, error) → HosterUnverifiedItemLimit (import paralel lain sudah memakai sisa kuota)
+*/
+func (s *itemService) ImportItems(hosterID, format, mode string, file io.Reader) (*dto.ImportItemsByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if mode == "" {
+		mode = ImportModeAllOrNone
+	}
+	if mode != ImportModeAllOrNone && mode != ImportModePartial {
+		return nil, errors.New(message.ItemImportInvalidMode)
+	}
+
+	rows, err := utils.ReadRows(format, file, MaxImportRows+1)
+	if err != nil {
+		if errors.Is(err, utils.ErrTooManyRows) {
+			return nil, errors.New(message.ItemImportTooManyRows)
+		}
+		log.Printf("ImportItems(hoster service): read error hoster=%s format=%s err=%v", hosterID, format, err)
+		return nil, errors.New(message.ItemImportInvalidFile)
+	}
+	if len(rows) < 2 {
+		return nil, errors.New(message.ItemImportInvalidFile)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[normalizeImportColumn(name)] = i
+	}
+	for _, name := range itemRequiredColumns {
+		if _, ok := columns[normalizeImportColumn(name)]; !ok {
+			return nil, errors.New(message.ItemImportInvalidFile)
+		}
+	}
+
+	importer, err := s.newItemImporter(hosterID, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ImportItemsByHosterResponse{Mode: mode, Errors: []dto.ItemImportRowErrorResponse{}}
+	var creates, updates []*domain.Item
+	for i, record := range rows[1:] {
+		if isEmptyImportRow(record) {
+			continue
+		}
+		result.TotalRows++
+
+		item, isUpdate, rowErr := importer.parseRow(record)
+		if rowErr != nil {
+			rowErr.Row = i + 2
+			result.Errors = append(result.Errors, *rowErr)
+			continue
+		}
+		if isUpdate {
+			updates = append(updates, item)
+		} else {
+			creates = append(creates, item)
+		}
+	}
+	result.Failed = len(result.Errors)
+
+	if result.TotalRows == 0 {
+		return nil, errors.New(message.ItemImportInvalidFile)
+	}
+	if mode == ImportModeAllOrNone && result.Failed > 0 {
+		return result, errors.New(message.ItemImportRejected)
+	}
+
+	if len(creates)+len(updates) > 0 {
+		if err := s.repo.ImportItems(hosterID, creates, updates, importer.maxActive); err != nil {
+			if err.Error() == "active_item_limit" {
+				return nil, errors.New(message.HosterUnverifiedItemLimit)
+			}
+			log.Printf("ImportItems(hoster service): repo error hoster=%s err=%v", hosterID, err)
+			return nil, errors.New(message.InternalError)
+		}
+	}
+
+	result.Committed = true
+	result.Created = len(creates)
+	result.Updated = len(updates)
+	log.Printf("ImportItems(hoster service): hoster %s created=%d updated=%d failed=%d", hosterID, result.Created, result.Updated, result.Failed)
+	return result, nil
+}
+
+/*
+itemImporter menyimpan data referensi (kategori, store, item lama, kuota) selama satu proses import.
+*/
+type itemImporter struct {
+	hosterID     string
+	columns      map[string]int
+	categories   map[string]string // nama (lowercase) / ID → ID kategori
+	stores       map[string]bool
+	defaultStore string
+	existing     map[string]dto.ItemListByHosterResponse
+	seen         map[string]bool
+	verified     bool
+	active       int
+	maxActive    int
+}
+
+/*
+newItemImporter memuat data referensi import dari repository.
+*/
+func (s *itemService) newItemImporter(hosterID string, columns map[string]int) (*itemImporter, error) {
+	categories, err := s.repo.GetCategory()
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	storeIDs, defaultStore, err := s.repo.GetStoreIDs(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	items, err := s.repo.GetListItem(hosterID, "")
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	verified, active, err := s.repo.GetActiveItemQuota(hosterID, "")
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.Unauthorized)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	importer := &itemImporter{
+		hosterID:     hosterID,
+		columns:      columns,
+		categories:   make(map[string]string, len(categories)*2),
+		stores:       make(map[string]bool, len(storeIDs)),
+		defaultStore: defaultStore,
+		existing:     make(map[string]dto.ItemListByHosterResponse, len(items)),
+		seen:         make(map[string]bool),
+		verified:     verified,
+		active:       active,
+		maxActive:    config.GetUnverifiedHosterMaxActiveItems(),
+	}
+	for _, category := range categories {
+		importer.categories[strings.ToLower(category.Name)] = category.ID
+		importer.categories[strings.ToLower(category.ID)] = category.ID
+	}
+	for _, id := range storeIDs {
+		importer.stores[strings.ToLower(id)] = true
+	}
+	for _, item := range items {
+		importer.existing[strings.ToLower(item.ID)] = item
+	}
+	return importer, nil
+}
+
+/*
+parseRow memvalidasi satu baris file import menjadi item siap simpan.
+
+Output sukses:
+- (*domain.Item, isUpdate, nil)
+Output error:
+- (nil, false, *dto.ItemImportRowErrorResponse) → kolom dan pesan kesalahan (nomor baris diisi pemanggil)
+*/
+func (imp *itemImporter) parseRow(record []string) (*domain.Item, bool, *dto.ItemImportRowErrorResponse) {
+	cell := func(column string) string {
+		i, ok := imp.columns[normalizeImportColumn(column)]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	fail := func(column, msg string) (*domain.Item, bool, *dto.ItemImportRowErrorResponse) {
+		return nil, false, &dto.ItemImportRowErrorResponse{Column: column, Message: msg}
+	}
+
+	item := &domain.Item{
+		HosterID:    imp.hosterID,
+		Name:        cell("Name"),
+		Description: cell("Description"),
+		PickupType:  domain.PickupMethod(strings.ToLower(cell("Pickup Type"))),
+	}
+
+	var current *dto.ItemListByHosterResponse
+	if id := strings.ToLower(cell("ID")); id != "" {
+		existing, ok := imp.existing[id]
+		if !ok {
+			return fail("ID", message.ItemNotFound)
+		}
+		if imp.seen[id] {
+			return fail("ID", message.ItemImportDuplicateID)
+		}
+		current = &existing
+		item.ID = existing.ID
+	} else {
+		item.ID = uuid.New().String()
+	}
+
+	if item.Name == "" {
+		return fail("Name", fmt.Sprintf(message.Required, "name"))
+	}
+
+	if cell("Category") == "" {
+		return fail("Category", fmt.Sprintf(message.Required, "category"))
+	}
+	categoryID, ok := imp.categories[strings.ToLower(cell("Category"))]
+	if !ok {
+		return fail("Category", fmt.Sprintf(message.NotFound, "category"))
+	}
+	item.CategoryID = categoryID
+
+	if !(item.PickupType == domain.PickupMethodSelfPickup || item.PickupType == domain.PickupMethodDelivery || item.PickupType == domain.PickupMethodBoth) {
+		return fail("Pickup Type", fmt.Sprintf(message.InvalidFormat, "pickup type"))
+	}
+
+	numbers := []struct {
+		column   string
+		target   *int
+		min      int
+		optional bool
+	}{
+		{"Price Per Day", &item.PricePerDay, 1, false},
+		{"Deposit", &item.Deposit, 0, true},
+		{"Discount", &item.Discount, 0, true},
+		{"Stock", &item.Stock, 0, false},
+	}
+	for _, number := range numbers {
+		raw := cell(number.column)
+		if raw == "" && number.optional {
+			continue
+		}
+		if raw == "" {
+			return fail(number.column, fmt.Sprintf(message.Required, strings.ToLower(number.column)))
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < number.min {
+			return fail(number.column, fmt.Sprintf(message.InvalidFormat, strings.ToLower(number.column)))
+		}
+		*number.target = value
+	}
+
+	if current != nil && current.UnitTracking && item.Stock != current.Stock {
+		return fail("Stock", message.ItemStockFromUnits)
+	}
+	if current != nil && current.HasVariants && item.Stock != current.Stock {
+		return fail("Stock", message.ItemStockFromVariants)
+	}
+
+	switch storeID := strings.ToLower(cell("Store ID")); {
+	case storeID != "":
+		if !imp.stores[storeID] {
+			return fail("Store ID", message.StoreNotFound)
+		}
+		item.TenantID = storeID
+	case current != nil:
+		item.TenantID = current.StoreID
+	case imp.defaultStore != "":
+		item.TenantID = imp.defaultStore
+	default:
+		return fail("Store ID", message.StoreNotFound)
+	}
+
+	switch strings.ToLower(cell("Hidden")) {
+	case "":
+		item.IsHidden = current != nil && current.IsHidden
+	case "true", "yes", "1":
+		item.IsHidden = true
+	case "false", "no", "0":
+		item.IsHidden = false
+	default:
+		return fail("Hidden", fmt.Sprintf(message.InvalidFormat, "hidden"))
+	}
+
+	// Item baru yang tampil / item tersembunyi yang ditampilkan menambah item aktif
+	becomesActive := !item.IsHidden && (current == nil || current.IsHidden)
+	if becomesActive && !imp.verified && imp.active >= imp.maxActive {
+		return fail("Hidden", message.HosterUnverifiedItemLimit)
+	}
+	if becomesActive {
+		imp.active++
+	}
+
+	if current != nil {
+		imp.seen[strings.ToLower(item.ID)] = true
+	}
+	return item, current != nil, nil
+}
+
+/*
+normalizeImportColumn menyamakan nama kolom header: "Price Per Day", "price_per_day" → "priceperday".
+*/
+func normalizeImportColumn(name string) string {
+	replacer := strings.NewReplacer(" ", "", "_", "", "-", "")
+	return replacer.Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+/*
+isEmptyImportRow mengecek baris tanpa isi (dilewati, tidak dihitung sebagai baris gagal).
+*/
+func isEmptyImportRow(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
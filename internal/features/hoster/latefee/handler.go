@@ -0,0 +1,179 @@
+package latefee
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterLateFeeHandler menangani endpoint HTTP aturan denda keterlambatan dari perspektif hoster.
+*/
+type HosterLateFeeHandler struct {
+	service LateFeeService
+}
+
+/*
+NewHosterLateFeeHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterLateFeeHandler siap digunakan
+*/
+func NewHosterLateFeeHandler(s LateFeeService) *HosterLateFeeHandler {
+	return &HosterLateFeeHandler{service: s}
+}
+
+/*
+GetRules menangani GET /api/v1/hoster/late-fee
+
+Output sukses:
+- 200 OK + { default, items }
+Output error:
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterLateFeeHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	rules, err := h.service.GetRules(hosterID)
+	if err != nil {
+		log.Printf("GetRules handler: service error hoster=%s err=%v", hosterID, err)
+		writeLateFeeError(w, err)
+		return
+	}
+	response.OK(w, rules, message.LateFeeRetrieved)
+}
+
+/*
+SetDefaultRule menangani PUT /api/v1/hoster/late-fee
+
+Output sukses:
+- 200 OK + semua aturan terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterLateFeeHandler) SetDefaultRule(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.SetLateFeeRuleByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("SetDefaultRule: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	rules, err := h.service.SetDefaultRule(hosterID, req)
+	if err != nil {
+		log.Printf("SetDefaultRule handler: service error hoster=%s err=%v", hosterID, err)
+		writeLateFeeError(w, err)
+		return
+	}
+	response.OK(w, rules, message.LateFeeSaved)
+}
+
+/*
+DeleteDefaultRule menangani DELETE /api/v1/hoster/late-fee
+
+Output sukses:
+- 200 OK
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterLateFeeHandler) DeleteDefaultRule(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	if err := h.service.DeleteDefaultRule(hosterID); err != nil {
+		log.Printf("DeleteDefaultRule handler: service error hoster=%s err=%v", hosterID, err)
+		writeLateFeeError(w, err)
+		return
+	}
+	response.OK(w, nil, message.LateFeeDeleted)
+}
+
+/*
+SetItemRule menangani PUT /api/v1/hoster/item/{id}/late-fee
+
+Output sukses:
+- 200 OK + semua aturan terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterLateFeeHandler) SetItemRule(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.SetLateFeeRuleByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("SetItemRule: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	rules, err := h.service.SetItemRule(hosterID, mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("SetItemRule handler: service error hoster=%s err=%v", hosterID, err)
+		writeLateFeeError(w, err)
+		return
+	}
+	response.OK(w, rules, message.LateFeeSaved)
+}
+
+/*
+DeleteItemRule menangani DELETE /api/v1/hoster/item/{id}/late-fee
+
+Output sukses:
+- 200 OK
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterLateFeeHandler) DeleteItemRule(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	if err := h.service.DeleteItemRule(hosterID, mux.Vars(r)["id"]); err != nil {
+		log.Printf("DeleteItemRule handler: service error hoster=%s err=%v", hosterID, err)
+		writeLateFeeError(w, err)
+		return
+	}
+	response.OK(w, nil, message.LateFeeDeleted)
+}
+
+/*
+writeLateFeeError memetakan error service denda keterlambatan ke HTTP response.
+*/
+func writeLateFeeError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.ItemNotFound, message.LateFeeNotFound:
+		response.NotFound(w, err.Error())
+	case message.LateFeeInvalidType, message.LateFeeInvalidAmount, message.LateFeeInvalidGrace:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,205 @@
+package latefee
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+LateFeeRepository adalah kontrak akses data aturan denda keterlambatan dan akrual denda booking.
+AccrueLateFees juga dipakai update status booking hoster (akrual terakhir sebelum completed).
+*/
+type LateFeeRepository interface {
+	GetRules(hosterID string) ([]dto.LateFeeRuleResponse, error)
+	UpsertRule(rule *domain.LateFeeRule) error
+	DeleteRule(hosterID string, itemID *string) error
+	HosterOwnsItem(hosterID, itemID string) (bool, error)
+	AccrueLateFees(bookingID string) (int, error)
+}
+
+/*
+lateFeeRepository adalah implementasi repository denda keterlambatan.
+*/
+type lateFeeRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewLateFeeRepository membuat instance repository dengan koneksi database.
+
+Output:
+- LateFeeRepository siap digunakan
+*/
+func NewLateFeeRepository(db *sqlx.DB) LateFeeRepository {
+	return &lateFeeRepository{db: db}
+}
+
+/*
+GetRules mengambil aturan default (item_id NULL, urutan pertama) dan aturan khusus item milik hoster.
+
+Output sukses:
+- ([]dto.LateFeeRuleResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *lateFeeRepository) GetRules(hosterID string) ([]dto.LateFeeRuleResponse, error) {
+	var rules []dto.LateFeeRuleResponse
+	err := r.db.Select(&rules, `
+		SELECT r.item_id, i.name AS item_name, r.fee_type, r.amount, r.grace_hours, r.updated_at
+		FROM late_fee_rule r
+		LEFT JOIN item i ON i.id = r.item_id
+		WHERE r.hoster_id = $1
+		ORDER BY r.item_id NULLS FIRST, i.name
+	`, hosterID)
+	if err != nil {
+		log.Printf("GetRules: query error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return rules, nil
+}
+
+/*
+UpsertRule membuat / mengganti aturan default (ItemID nil) atau aturan khusus item.
+
+Output sukses:
+- nil
+Output error:
+- error → query gagal
+*/
+func (r *lateFeeRepository) UpsertRule(rule *domain.LateFeeRule) error {
+	conflict := `(hoster_id) WHERE item_id IS NULL`
+	if rule.ItemID != nil {
+		conflict = `(item_id) WHERE item_id IS NOT NULL`
+	}
+	_, err := r.db.Exec(`
+		INSERT INTO late_fee_rule (hoster_id, item_id, fee_type, amount, grace_hours)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT `+conflict+` DO UPDATE
+		SET fee_type = EXCLUDED.fee_type,
+		    amount = EXCLUDED.amount,
+		    grace_hours = EXCLUDED.grace_hours,
+		    updated_at = NOW()
+	`, rule.HosterID, rule.ItemID, rule.FeeType, rule.Amount, rule.GraceHours)
+	if err != nil {
+		log.Printf("UpsertRule: exec error hoster=%s err=%v", rule.HosterID, err)
+	}
+	return err
+}
+
+/*
+DeleteRule menghapus aturan default (itemID nil) atau aturan khusus item.
+Denda yang sudah masuk ke outstanding tidak berubah.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → aturan tidak ada
+- error         → query gagal
+*/
+func (r *lateFeeRepository) DeleteRule(hosterID string, itemID *string) error {
+	res, err := r.db.Exec(`
+		DELETE FROM late_fee_rule
+		WHERE hoster_id = $1 AND item_id IS NOT DISTINCT FROM $2
+	`, hosterID, itemID)
+	if err != nil {
+		log.Printf("DeleteRule: exec error hoster=%s err=%v", hosterID, err)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+/*
+HosterOwnsItem mengecek item milik hoster.
+*/
+func (r *lateFeeRepository) HosterOwnsItem(hosterID, itemID string) (bool, error) {
+	var exists bool
+	err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM item WHERE id = $1 AND hoster_id = $2)`, itemID, hosterID)
+	if err != nil {
+		log.Printf("HosterOwnsItem: query error item=%s err=%v", itemID, err)
+	}
+	return exists, err
+}
+
+/*
+AccrueLateFees menandai booking on_rent yang melewati batas pengembalian dan menambahkan dendanya ke outstanding.
+
+Alur kerja:
+1. Batas pengembalian = end_date + return_time (slot pengembalian), tanpa slot → akhir hari end_date
+2. Aturan per booking_item: aturan khusus item, jika tidak ada → default hoster, tidak ada keduanya → tanpa denda
+3. Hari terlambat per item = pembulatan ke atas (sekarang - (batas + grace_hours)) / 24 jam, minimal 0
+4. Denda per item per hari:
+  - fixed   → amount x quantity
+  - percent → harga sewa harian baris x amount / 10000, harga harian = price_per_day x quantity,
+    baris komponen paket memakai snapshot harga paket (subtotal_rental / total_days) karena
+    price_per_day komponen hanya bagian pembulatan dari harga paket
+
+5. Total denda dihitung ulang dari awal (idempotent), hanya selisih kenaikannya yang ditambahkan ke outstanding
+6. overdue_at diisi saat pertama kali terdeteksi
+
+Parameter:
+- bookingID: kosong = semua booking, terisi = satu booking saja (akrual terakhir sebelum completed)
+
+Output sukses:
+- (jumlah booking yang baru ditandai / dendanya bertambah, nil)
+Output error:
+- (0, error) → query gagal
+*/
+func (r *lateFeeRepository) AccrueLateFees(bookingID string) (int, error) {
+	res, err := r.db.Exec(`
+		WITH fees AS (
+			SELECT b.id AS booking_id,
+			       COALESCE(SUM(
+			           CASE rule.fee_type
+			               WHEN 'fixed' THEN rule.amount::bigint * bi.quantity
+			               WHEN 'percent' THEN (
+			                   CASE WHEN bi.bundle_quantity IS NOT NULL AND b.total_days > 0
+			                       THEN bi.subtotal_rental::bigint / b.total_days
+			                       ELSE bi.price_per_day::bigint * bi.quantity
+			                   END
+			               ) * rule.amount / 10000
+			           END
+			           * GREATEST(0, CEIL(EXTRACT(EPOCH FROM (
+			               NOW() - (b.end_date + COALESCE(b.return_time, TIME '24:00') + make_interval(hours => rule.grace_hours))
+			           )) / 86400))
+			       ), 0)::int AS fee
+			FROM booking b
+			JOIN booking_item bi ON bi.booking_id = b.id
+			LEFT JOIN LATERAL (
+				SELECT fee_type, amount, grace_hours
+				FROM late_fee_rule
+				WHERE hoster_id = b.hoster_id AND (item_id = bi.item_id OR item_id IS NULL)
+				ORDER BY item_id NULLS LAST
+				LIMIT 1
+			) rule ON TRUE
+			WHERE b.status = 'on_rent'
+			  AND (b.end_date + COALESCE(b.return_time, TIME '24:00')) < NOW()
+			  AND ($1 = '' OR b.id::text = $1)
+			GROUP BY b.id
+		)
+		UPDATE booking b
+		SET overdue_at = COALESCE(b.overdue_at, NOW()),
+		    outstanding = b.outstanding + GREATEST(f.fee - b.late_fee, 0),
+		    late_fee = GREATEST(f.fee, b.late_fee),
+		    updated_at = NOW()
+		FROM fees f
+		WHERE b.id = f.booking_id
+		  AND (b.overdue_at IS NULL OR f.fee > b.late_fee)
+	`, bookingID)
+	if err != nil {
+		log.Printf("AccrueLateFees: exec error booking=%q err=%v", bookingID, err)
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
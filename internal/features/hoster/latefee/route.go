@@ -0,0 +1,47 @@
+package latefee
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupLateFeeRoutes mendaftarkan endpoint aturan denda keterlambatan untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET    /late-fee          → aturan default toko + aturan khusus item
+  - PUT    /late-fee          → atur aturan default toko
+  - DELETE /late-fee          → hapus aturan default toko
+  - PUT    /item/{id}/late-fee → atur aturan khusus item
+  - DELETE /item/{id}/late-fee → hapus aturan khusus item (kembali ke default)
+
+4. GET butuh permission items:view, aturan toko butuh store:manage, aturan item butuh items:manage
+
+Output:
+- Router terkonfigurasi dengan endpoint denda keterlambatan hoster
+*/
+func SetupLateFeeRoutes(router *mux.Router, h *HosterLateFeeHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	protected.HandleFunc("/late-fee", middleware.HosterPermission(domain.HosterPermItemsView, h.GetRules)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/late-fee", middleware.HosterPermission(domain.HosterPermStoreManage, h.SetDefaultRule)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/late-fee", middleware.HosterPermission(domain.HosterPermStoreManage, h.DeleteDefaultRule)).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/item/{id}/late-fee", middleware.HosterPermission(domain.HosterPermItemsManage, h.SetItemRule)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/item/{id}/late-fee", middleware.HosterPermission(domain.HosterPermItemsManage, h.DeleteItemRule)).Methods("DELETE", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,253 @@
+package latefee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+Konstanta validasi aturan denda keterlambatan.
+*/
+const (
+	MaxPercentBps = 10000
+	MaxGraceHours = 168
+)
+
+/*
+LateFeeService adalah kontrak logika bisnis aturan denda keterlambatan dan detektor booking terlambat.
+*/
+type LateFeeService interface {
+	GetRules(hosterID string) (*dto.LateFeeRulesByHosterResponse, error)
+	SetDefaultRule(hosterID string, req dto.SetLateFeeRuleByHosterRequest) (*dto.LateFeeRulesByHosterResponse, error)
+	SetItemRule(hosterID, itemID string, req dto.SetLateFeeRuleByHosterRequest) (*dto.LateFeeRulesByHosterResponse, error)
+	DeleteDefaultRule(hosterID string) error
+	DeleteItemRule(hosterID, itemID string) error
+	RunOverdueDetector(ctx context.Context, interval time.Duration)
+}
+
+/*
+lateFeeService adalah implementasi service denda keterlambatan.
+*/
+type lateFeeService struct {
+	repo LateFeeRepository
+}
+
+/*
+NewLateFeeService membuat instance service dengan dependency injection.
+
+Output:
+- LateFeeService siap digunakan
+*/
+func NewLateFeeService(repo LateFeeRepository) LateFeeService {
+	return &lateFeeService{repo: repo}
+}
+
+/*
+GetRules mengambil aturan default toko dan aturan khusus item milik hoster.
+
+Output sukses:
+- (*dto.LateFeeRulesByHosterResponse, nil) → default nil jika belum diatur
+Output error:
+- (nil, error) → unauthorized / internal error
+*/
+func (s *lateFeeService) GetRules(hosterID string) (*dto.LateFeeRulesByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	rules, err := s.repo.GetRules(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	result := &dto.LateFeeRulesByHosterResponse{Items: []dto.LateFeeRuleResponse{}}
+	for i := range rules {
+		if rules[i].ItemID == nil {
+			result.Default = &rules[i]
+			continue
+		}
+		result.Items = append(result.Items, rules[i])
+	}
+	return result, nil
+}
+
+/*
+SetDefaultRule membuat / mengganti aturan default toko (berlaku untuk item tanpa aturan khusus).
+
+Output sukses:
+- (*dto.LateFeeRulesByHosterResponse, nil) → semua aturan terbaru
+Output error:
+- (nil, error) → unauthorized / validasi / internal error
+*/
+func (s *lateFeeService) SetDefaultRule(hosterID string, req dto.SetLateFeeRuleByHosterRequest) (*dto.LateFeeRulesByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	return s.saveRule(hosterID, nil, req)
+}
+
+/*
+SetItemRule membuat / mengganti aturan khusus item (menimpa aturan default toko).
+
+Output sukses:
+- (*dto.LateFeeRulesByHosterResponse, nil) → semua aturan terbaru
+Output error:
+- (nil, error) → unauthorized / ItemNotFound / validasi / internal error
+*/
+func (s *lateFeeService) SetItemRule(hosterID, itemID string, req dto.SetLateFeeRuleByHosterRequest) (*dto.LateFeeRulesByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if err := s.checkItem(hosterID, itemID); err != nil {
+		return nil, err
+	}
+	return s.saveRule(hosterID, &itemID, req)
+}
+
+/*
+DeleteDefaultRule menghapus aturan default toko.
+Denda yang sudah masuk ke outstanding booking tidak berubah.
+
+Output sukses:
+- nil
+Output error:
+- error → unauthorized / LateFeeNotFound / internal error
+*/
+func (s *lateFeeService) DeleteDefaultRule(hosterID string) error {
+	if hosterID == "" {
+		return errors.New(message.Unauthorized)
+	}
+	return s.deleteRule(hosterID, nil)
+}
+
+/*
+DeleteItemRule menghapus aturan khusus item (item kembali memakai aturan default toko).
+
+Output sukses:
+- nil
+Output error:
+- error → unauthorized / ItemNotFound / LateFeeNotFound / internal error
+*/
+func (s *lateFeeService) DeleteItemRule(hosterID, itemID string) error {
+	if hosterID == "" {
+		return errors.New(message.Unauthorized)
+	}
+	if err := s.checkItem(hosterID, itemID); err != nil {
+		return err
+	}
+	return s.deleteRule(hosterID, &itemID)
+}
+
+/*
+RunOverdueDetector menjalankan deteksi booking terlambat secara berkala sampai ctx dibatalkan.
+
+Alur kerja:
+1. Jalankan akrual sekali saat start (menyusul waktu server mati)
+2. Ulangi setiap interval
+3. Gagal akrual hanya dicatat di log, dicoba lagi di putaran berikutnya
+
+Dijalankan sebagai goroutine dari main.
+*/
+func (s *lateFeeService) RunOverdueDetector(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.repo.AccrueLateFees(""); err != nil {
+			log.Printf("RunOverdueDetector: accrual failed: %v", err)
+		} else if n > 0 {
+			log.Printf("RunOverdueDetector: %d overdue booking(s) updated", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("RunOverdueDetector: stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+saveRule memvalidasi dan menyimpan aturan, lalu mengembalikan semua aturan terbaru.
+*/
+func (s *lateFeeService) saveRule(hosterID string, itemID *string, req dto.SetLateFeeRuleByHosterRequest) (*dto.LateFeeRulesByHosterResponse, error) {
+	if err := validateRule(req); err != nil {
+		return nil, err
+	}
+
+	rule := &domain.LateFeeRule{
+		HosterID:   hosterID,
+		ItemID:     itemID,
+		FeeType:    req.FeeType,
+		Amount:     req.Amount,
+		GraceHours: req.GraceHours,
+	}
+	if err := s.repo.UpsertRule(rule); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("saveRule: late fee rule saved hoster=%s item=%v type=%s amount=%d", hosterID, itemID, req.FeeType, req.Amount)
+	return s.GetRules(hosterID)
+}
+
+/*
+deleteRule menghapus aturan dan memetakan error repository.
+*/
+func (s *lateFeeService) deleteRule(hosterID string, itemID *string) error {
+	if err := s.repo.DeleteRule(hosterID, itemID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New(message.LateFeeNotFound)
+		}
+		return errors.New(message.InternalError)
+	}
+	return nil
+}
+
+/*
+checkItem memastikan item ada dan milik hoster.
+*/
+func (s *lateFeeService) checkItem(hosterID, itemID string) error {
+	if _, err := uuid.Parse(itemID); err != nil {
+		return errors.New(message.ItemNotFound)
+	}
+	owned, err := s.repo.HosterOwnsItem(hosterID, itemID)
+	if err != nil {
+		return errors.New(message.InternalError)
+	}
+	if !owned {
+		return errors.New(message.ItemNotFound)
+	}
+	return nil
+}
+
+/*
+validateRule memvalidasi tipe, nominal, dan toleransi aturan denda.
+*/
+func validateRule(req dto.SetLateFeeRuleByHosterRequest) error {
+	switch req.FeeType {
+	case domain.LateFeeTypeFixed:
+		if req.Amount <= 0 {
+			return errors.New(message.LateFeeInvalidAmount)
+		}
+	case domain.LateFeeTypePercent:
+		if req.Amount <= 0 || req.Amount > MaxPercentBps {
+			return errors.New(message.LateFeeInvalidAmount)
+		}
+	default:
+		return errors.New(message.LateFeeInvalidType)
+	}
+	if req.GraceHours < 0 || req.GraceHours > MaxGraceHours {
+		return errors.New(message.LateFeeInvalidGrace)
+	}
+	return nil
+}
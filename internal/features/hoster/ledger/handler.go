@@ -0,0 +1,102 @@
+package ledger
+
+import (
+	"log"
+	"net/http"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterLedgerHandler menangani endpoint HTTP saldo dan statement payout hoster.
+*/
+type HosterLedgerHandler struct {
+	service HosterLedgerService
+}
+
+/*
+NewHosterLedgerHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterLedgerHandler siap digunakan
+*/
+func NewHosterLedgerHandler(s HosterLedgerService) *HosterLedgerHandler {
+	return &HosterLedgerHandler{service: s}
+}
+
+/*
+GetBalance menangani GET /api/v1/hoster/ledger/balance
+
+Output sukses:
+- 200 OK + saldo payable, dalam settlement, sewa tertunda, deposit ditahan
+Output error:
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterLedgerHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	balance, err := h.service.GetBalance(middleware.GetUserID(r))
+	if err != nil {
+		log.Printf("GetBalance handler: service error: %v", err)
+		writeLedgerError(w, err)
+		return
+	}
+	response.OK(w, balance, message.LedgerBalanceRetrieved)
+}
+
+/*
+GetStatement menangani GET /api/v1/hoster/ledger/statement?from=YYYY-MM-DD&to=YYYY-MM-DD
+
+Output sukses:
+- 200 OK + opening/closing balance dan transaksi ledger periode
+Output error:
+- 400 Bad Request (periode tidak valid) / 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterLedgerHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := dto.LedgerStatementFilterByHosterRequest{
+		From: query.Get("from"),
+		To:   query.Get("to"),
+	}
+
+	statement, err := h.service.GetStatement(middleware.GetUserID(r), filter)
+	if err != nil {
+		log.Printf("GetStatement handler: service error: %v", err)
+		writeLedgerError(w, err)
+		return
+	}
+	response.OK(w, statement, message.LedgerStatementRetrieved)
+}
+
+/*
+GetSettlements menangani GET /api/v1/hoster/ledger/settlements
+
+Output sukses:
+- 200 OK + riwayat settlement (payout) hoster
+Output error:
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterLedgerHandler) GetSettlements(w http.ResponseWriter, r *http.Request) {
+	settlements, err := h.service.GetSettlements(middleware.GetUserID(r))
+	if err != nil {
+		log.Printf("GetSettlements handler: service error: %v", err)
+		writeLedgerError(w, err)
+		return
+	}
+	response.OK(w, settlements, message.SettlementRetrieved)
+}
+
+/*
+writeLedgerError memetakan error service ledger ke HTTP response.
+*/
+func writeLedgerError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.LedgerInvalidPeriod:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
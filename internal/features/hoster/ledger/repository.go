@@ -0,0 +1,277 @@
+package ledger
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/utils"
+)
+
+/*
+HosterLedgerRepository adalah kontrak akses data ledger payout hoster.
+PostBookingLedger juga dipakai fitur lain (update status booking, sync admin) agar posting hanya ada di satu tempat.
+*/
+type HosterLedgerRepository interface {
+	PostBookingLedger(bookingID string, commissionRateBps int) (int, error)
+	GetBalance(hosterID string) (*dto.LedgerBalanceByHosterResponse, error)
+	GetPayableBefore(hosterID string, before time.Time) (int64, error)
+	GetStatementLines(hosterID string, from, to time.Time) ([]dto.LedgerStatementLineByHosterResponse, error)
+	GetSettlements(hosterID string) ([]dto.SettlementByHosterResponse, error)
+}
+
+/*
+hosterLedgerRepository adalah implementasi repository ledger hoster.
+*/
+type hosterLedgerRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewHosterLedgerRepository membuat instance repository dengan koneksi database.
+
+Output:
+- HosterLedgerRepository siap digunakan
+*/
+func NewHosterLedgerRepository(db *sqlx.DB) HosterLedgerRepository {
+	return &hosterLedgerRepository{db: db}
+}
+
+/*
+PostBookingLedger memposting transaksi ledger booking yang belum ada.
+
+Alur kerja:
+1. Kunci baris booking (FOR UPDATE) agar posting paralel untuk booking yang sama berurutan
+2. Ambil perubahan booking yang disetujui (urut decided_at)
+3. Turunkan transaksi yang seharusnya ada dengan utils.BuildBookingLedger
+4. Insert header dengan ON CONFLICT DO NOTHING (unik per booking + kind, atau per amendment) → transaksi yang sudah ada tidak disentuh
+5. Insert baris debit / kredit untuk transaksi baru, commit
+
+Output sukses:
+- (jumlah transaksi baru, nil) → 0 jika ledger booking sudah lengkap
+Output error:
+- (0, sql.ErrNoRows) → booking tidak ditemukan
+- (0, error) → query gagal
+*/
+func (r *hosterLedgerRepository) PostBookingLedger(bookingID string, commissionRateBps int) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("PostBookingLedger: error starting transaction: %v", err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var booking domain.Booking
+	err = tx.Get(&booking, `
+		SELECT id, hoster_id, status, rental, deposit, discount, late_fee, locked_until, updated_at
+		FROM booking
+		WHERE id = $1
+		FOR UPDATE
+	`, bookingID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("PostBookingLedger: get booking error booking=%s err=%v", bookingID, err)
+		}
+		return 0, err
+	}
+
+	var amendments []domain.BookingAmendment
+	err = tx.Select(&amendments, `
+		SELECT id, old_rental, new_rental, old_deposit, new_deposit, decided_at
+		FROM booking_amendment
+		WHERE booking_id = $1 AND status = 'approved'
+		ORDER BY decided_at ASC, id ASC
+	`, bookingID)
+	if err != nil {
+		log.Printf("PostBookingLedger: get amendments error booking=%s err=%v", bookingID, err)
+		return 0, err
+	}
+
+	posted := 0
+	for _, t := range utils.BuildBookingLedger(booking, amendments, commissionRateBps) {
+		var id string
+		err := tx.QueryRow(`
+			INSERT INTO ledger_transaction (hoster_id, booking_id, amendment_id, kind, commission_rate_bps, description, occurred_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT DO NOTHING
+			RETURNING id
+		`, t.HosterID, t.BookingID, t.AmendmentID, t.Kind, t.CommissionRateBps, t.Description, t.OccurredAt).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue // Sudah pernah diposting
+		}
+		if err != nil {
+			log.Printf("PostBookingLedger: insert transaction error booking=%s kind=%s err=%v", bookingID, t.Kind, err)
+			return 0, err
+		}
+
+		for _, e := range t.Entries {
+			_, err := tx.Exec(`
+				INSERT INTO ledger_entry (transaction_id, hoster_id, account, debit, credit)
+				VALUES ($1, $2, $3, $4, $5)
+			`, id, t.HosterID, e.Account, e.Debit, e.Credit)
+			if err != nil {
+				log.Printf("PostBookingLedger: insert entry error booking=%s kind=%s account=%s err=%v", bookingID, t.Kind, e.Account, err)
+				return 0, err
+			}
+		}
+		posted++
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("PostBookingLedger: error committing transaction: %v", err)
+		return 0, err
+	}
+	return posted, nil
+}
+
+/*
+GetBalance menghitung saldo akun ledger hoster dan jumlah yang sedang dalam settlement.
+
+Alur kerja:
+1. Agregasi ledger_entry hoster per akun (index ledger_entry(hoster_id, account))
+2. Jumlahkan settlement hoster yang masih pending
+
+Output sukses:
+- (*dto.LedgerBalanceByHosterResponse, nil) → Unsettled & CommissionRateBps diisi service
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterLedgerRepository) GetBalance(hosterID string) (*dto.LedgerBalanceByHosterResponse, error) {
+	var balance dto.LedgerBalanceByHosterResponse
+	query := `
+		SELECT
+			COALESCE(SUM(credit - debit) FILTER (WHERE account = 'hoster_payable'), 0) AS payable,
+			COALESCE(SUM(credit - debit) FILTER (WHERE account = 'unearned_rental'), 0) AS pending_rental,
+			COALESCE(SUM(credit - debit) FILTER (WHERE account = 'customer_deposit'), 0) AS deposits_held,
+			COALESCE(SUM(credit - debit) FILTER (WHERE account = 'platform_commission'), 0) AS commission_total,
+			COALESCE(SUM(debit) FILTER (WHERE account = 'hoster_payable'), 0) AS paid_out_total,
+			(
+				SELECT COALESCE(SUM(amount), 0)
+				FROM settlement
+				WHERE hoster_id = $1 AND status = 'pending'
+			) AS in_settlement
+		FROM ledger_entry
+		WHERE hoster_id = $1
+	`
+	if err := r.db.Get(&balance, query, hosterID); err != nil {
+		log.Printf("GetBalance: query error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return &balance, nil
+}
+
+/*
+GetPayableBefore menghitung saldo hoster_payable sebelum waktu tertentu (opening balance statement).
+
+Output sukses:
+- (int64, nil)
+Output error:
+- (0, error) → query gagal
+*/
+func (r *hosterLedgerRepository) GetPayableBefore(hosterID string, before time.Time) (int64, error) {
+	var balance int64
+	query := `
+		SELECT COALESCE(SUM(e.credit - e.debit), 0)
+		FROM ledger_entry e
+		JOIN ledger_transaction t ON t.id = e.transaction_id
+		WHERE e.hoster_id = $1
+		  AND e.account = 'hoster_payable'
+		  AND t.occurred_at < $2
+	`
+	if err := r.db.Get(&balance, query, hosterID, before); err != nil {
+		log.Printf("GetPayableBefore: query error hoster=%s err=%v", hosterID, err)
+		return 0, err
+	}
+	return balance, nil
+}
+
+/*
+GetStatementLines mengambil transaksi ledger hoster dalam periode beserta baris debit / kredit-nya.
+
+Alur kerja:
+1. Ambil header transaksi [from, to) urut waktu kejadian (index ledger_transaction(hoster_id, occurred_at))
+2. Ambil semua baris untuk transaksi tersebut dalam satu query, lalu kelompokkan per transaksi
+
+Output sukses:
+- ([]dto.LedgerStatementLineByHosterResponse, nil) → PayableChange & Balance diisi service
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterLedgerRepository) GetStatementLines(hosterID string, from, to time.Time) ([]dto.LedgerStatementLineByHosterResponse, error) {
+	lines := []dto.LedgerStatementLineByHosterResponse{}
+	query := `
+		SELECT id, kind, booking_id, settlement_id, description, commission_rate_bps, occurred_at
+		FROM ledger_transaction
+		WHERE hoster_id = $1
+		  AND occurred_at >= $2
+		  AND occurred_at < $3
+		ORDER BY occurred_at ASC, created_at ASC, id ASC
+	`
+	if err := r.db.Select(&lines, query, hosterID, from, to); err != nil {
+		log.Printf("GetStatementLines: query error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return lines, nil
+	}
+
+	ids := make([]string, len(lines))
+	for i, line := range lines {
+		ids[i] = line.TransactionID
+	}
+
+	var entries []struct {
+		TransactionID string `db:"transaction_id"`
+		dto.LedgerEntryResponse
+	}
+	entryQuery := `
+		SELECT transaction_id, account, debit, credit
+		FROM ledger_entry
+		WHERE transaction_id = ANY($1::uuid[])
+		ORDER BY debit DESC, account ASC
+	`
+	if err := r.db.Select(&entries, entryQuery, pq.Array(ids)); err != nil {
+		log.Printf("GetStatementLines: entry query error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+
+	byTransaction := make(map[string][]dto.LedgerEntryResponse, len(lines))
+	for _, e := range entries {
+		byTransaction[e.TransactionID] = append(byTransaction[e.TransactionID], e.LedgerEntryResponse)
+	}
+	for i := range lines {
+		lines[i].Entries = byTransaction[lines[i].TransactionID]
+	}
+	return lines, nil
+}
+
+/*
+GetSettlements mengambil riwayat settlement hoster, terbaru dulu.
+
+Output sukses:
+- ([]dto.SettlementByHosterResponse, nil) → slice kosong jika belum ada
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterLedgerRepository) GetSettlements(hosterID string) ([]dto.SettlementByHosterResponse, error) {
+	settlements := []dto.SettlementByHosterResponse{}
+	query := `
+		SELECT s.id, s.batch_id, b.period_end, s.amount, s.transaction_count,
+		       s.status, s.paid_at, s.reference, s.created_at
+		FROM settlement s
+		JOIN settlement_batch b ON b.id = s.batch_id
+		WHERE s.hoster_id = $1
+		ORDER BY s.created_at DESC
+	`
+	if err := r.db.Select(&settlements, query, hosterID); err != nil {
+		log.Printf("GetSettlements: query error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return settlements, nil
+}
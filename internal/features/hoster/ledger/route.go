@@ -0,0 +1,42 @@
+package ledger
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupLedgerRoutes mendaftarkan endpoint saldo dan statement payout hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster/ledger
+2. Terapkan middleware JWT → Hoster → permission revenue:view
+3. Daftarkan endpoint:
+  - GET /balance     → saldo payable, dalam settlement, sewa tertunda, deposit ditahan
+  - GET /statement   → statement ledger per periode dengan saldo berjalan
+  - GET /settlements → riwayat payout
+
+Output:
+- Router terkonfigurasi dengan endpoint ledger hoster
+*/
+func SetupLedgerRoutes(router *mux.Router, h *HosterLedgerHandler) {
+	protected := router.PathPrefix("/api/v1/hoster/ledger").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+	protected.Use(middleware.RequireHosterPermission(domain.HosterPermRevenueView))
+
+	protected.HandleFunc("/balance", h.GetBalance).Methods("GET")
+	protected.HandleFunc("/statement", h.GetStatement).Methods("GET")
+	protected.HandleFunc("/settlements", h.GetSettlements).Methods("GET")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,172 @@
+package ledger
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+HosterLedgerService adalah kontrak logika bisnis saldo dan statement payout hoster.
+*/
+type HosterLedgerService interface {
+	GetBalance(hosterID string) (*dto.LedgerBalanceByHosterResponse, error)
+	GetStatement(hosterID string, filter dto.LedgerStatementFilterByHosterRequest) (*dto.LedgerStatementByHosterResponse, error)
+	GetSettlements(hosterID string) ([]dto.SettlementByHosterResponse, error)
+}
+
+/*
+hosterLedgerService adalah implementasi service ledger hoster.
+*/
+type hosterLedgerService struct {
+	repo HosterLedgerRepository
+}
+
+/*
+NewHosterLedgerService membuat instance service dengan dependency injection.
+
+Output:
+- HosterLedgerService siap digunakan
+*/
+func NewHosterLedgerService(repo HosterLedgerRepository) HosterLedgerService {
+	return &hosterLedgerService{repo: repo}
+}
+
+/*
+Konstanta periode statement.
+*/
+const (
+	DefaultStatementDays = 30
+	MaxStatementDays     = 366
+)
+
+/*
+GetBalance mengambil saldo payout hoster.
+
+Output sukses:
+- (*dto.LedgerBalanceByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / internal error
+*/
+func (s *hosterLedgerService) GetBalance(hosterID string) (*dto.LedgerBalanceByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	balance, err := s.repo.GetBalance(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	balance.Unsettled = balance.Payable - balance.InSettlement
+	balance.CommissionRateBps = config.GetPlatformCommissionBps()
+	return balance, nil
+}
+
+/*
+GetStatement menyusun statement ledger hoster dalam periode.
+
+Alur kerja:
+1. Parse periode (default 30 hari terakhir, maksimal 366 hari)
+2. Ambil opening balance hoster_payable sebelum periode
+3. Ambil transaksi periode, hitung perubahan payable dan saldo berjalan per transaksi
+
+Output sukses:
+- (*dto.LedgerStatementByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / LedgerInvalidPeriod / internal error
+*/
+func (s *hosterLedgerService) GetStatement(hosterID string, filter dto.LedgerStatementFilterByHosterRequest) (*dto.LedgerStatementByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	from, to, err := parseStatementPeriod(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := s.repo.GetPayableBefore(hosterID, from)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	lines, err := s.repo.GetStatementLines(hosterID, from, to)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	balance := opening
+	for i := range lines {
+		for _, e := range lines[i].Entries {
+			if e.Account == domain.LedgerAccountHosterPayable {
+				lines[i].PayableChange += e.Credit - e.Debit
+			}
+		}
+		balance += lines[i].PayableChange
+		lines[i].Balance = balance
+	}
+
+	return &dto.LedgerStatementByHosterResponse{
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		ClosingBalance: balance,
+		Lines:          lines,
+	}, nil
+}
+
+/*
+GetSettlements mengambil riwayat settlement (payout) hoster.
+
+Output sukses:
+- ([]dto.SettlementByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / internal error
+*/
+func (s *hosterLedgerService) GetSettlements(hosterID string) ([]dto.SettlementByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	settlements, err := s.repo.GetSettlements(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return settlements, nil
+}
+
+/*
+parseStatementPeriod mengubah from/to (YYYY-MM-DD, inklusif) menjadi rentang [from, to) UTC.
+*/
+func parseStatementPeriod(filter dto.LedgerStatementFilterByHosterRequest) (time.Time, time.Time, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today.AddDate(0, 0, 1)
+	if filter.To != "" {
+		parsed, err := time.Parse("2006-01-02", filter.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New(message.LedgerInvalidPeriod)
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -DefaultStatementDays)
+	if filter.From != "" {
+		parsed, err := time.Parse("2006-01-02", filter.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New(message.LedgerInvalidPeriod)
+		}
+		from = parsed
+	}
+
+	days := int(to.Sub(from).Hours() / 24)
+	if days <= 0 || days > MaxStatementDays {
+		log.Printf("parseStatementPeriod: invalid period from=%s to=%s days=%d", from.Format("2006-01-02"), to.Format("2006-01-02"), days)
+		return time.Time{}, time.Time{}, errors.New(message.LedgerInvalidPeriod)
+	}
+	return from, to, nil
+}
@@ -2,6 +2,7 @@ package profile
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 
@@ -123,3 +124,118 @@ func (h *HosterProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Requ
 
 	response.OK(w, result, message.ProfileUpdated)
 }
+
+/*
+UploadProfilePhoto menangani PUT /api/v1/hoster/profile/photo (multipart, field "file")
+
+Output sukses:
+- 200 OK + profil terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterProfileHandler) UploadProfilePhoto(w http.ResponseWriter, r *http.Request) {
+	h.uploadMedia(w, r, HosterMediaProfilePhoto, message.ProfilePhotoUpdated)
+}
+
+/*
+DeleteProfilePhoto menangani DELETE /api/v1/hoster/profile/photo
+
+Output sukses:
+- 200 OK + profil terbaru
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterProfileHandler) DeleteProfilePhoto(w http.ResponseWriter, r *http.Request) {
+	h.deleteMedia(w, r, HosterMediaProfilePhoto, message.ProfilePhotoDeleted)
+}
+
+/*
+UploadBanner menangani PUT /api/v1/hoster/profile/banner (multipart, field "file")
+
+Output sukses:
+- 200 OK + profil terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterProfileHandler) UploadBanner(w http.ResponseWriter, r *http.Request) {
+	h.uploadMedia(w, r, HosterMediaBanner, message.StoreBannerUpdated)
+}
+
+/*
+DeleteBanner menangani DELETE /api/v1/hoster/profile/banner
+
+Output sukses:
+- 200 OK + profil terbaru
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterProfileHandler) DeleteBanner(w http.ResponseWriter, r *http.Request) {
+	h.deleteMedia(w, r, HosterMediaBanner, message.StoreBannerDeleted)
+}
+
+/*
+uploadMedia membaca file multipart lalu meneruskannya ke service sesuai jenis foto.
+*/
+func (h *HosterProfileHandler) uploadMedia(w http.ResponseWriter, r *http.Request, media HosterMedia, successMsg string) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		log.Printf("UploadMedia: failed to parse multipart form: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+	_, header, err := r.FormFile("file")
+	if err != nil {
+		response.BadRequest(w, fmt.Sprintf(message.Required, "file"))
+		return
+	}
+
+	result, err := h.service.UploadMedia(r.Context(), hosterID, media, header)
+	if err != nil {
+		log.Printf("UploadMedia handler: service error hoster=%s media=%s err=%v", hosterID, media, err)
+		writeMediaError(w, err)
+		return
+	}
+
+	response.OK(w, result, successMsg)
+}
+
+/*
+deleteMedia menghapus foto profil / banner toko sesuai jenis foto.
+*/
+func (h *HosterProfileHandler) deleteMedia(w http.ResponseWriter, r *http.Request, media HosterMedia, successMsg string) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	result, err := h.service.DeleteMedia(r.Context(), hosterID, media)
+	if err != nil {
+		log.Printf("DeleteMedia handler: service error hoster=%s media=%s err=%v", hosterID, media, err)
+		writeMediaError(w, err)
+		return
+	}
+
+	response.OK(w, result, successMsg)
+}
+
+/*
+writeMediaError memetakan error service foto profil / banner ke HTTP response.
+*/
+func writeMediaError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.ProfileNotFound:
+		response.NotFound(w, message.ProfileNotFound)
+	case message.UploadInvalidContentType, fmt.Sprintf(message.Required, "file"), fmt.Sprintf(message.FileTooLarge, "file"):
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
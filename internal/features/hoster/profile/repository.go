@@ -16,6 +16,7 @@ HosterProfileRepository mendefinisikan operasi database untuk profile hoster.
 type HosterProfileRepository interface {
 	GetProfile(hosterID string) (*dto.HosterProfileResponse, error)
 	UpdateProfile(hosterID string, req *dto.UpdateHosterProfileRequest) error
+	ReplaceMedia(hosterID string, media HosterMedia, url *string) (string, error)
 }
 
 /*
@@ -56,19 +57,23 @@ func (r *hosterProfileRepository) GetProfile(hosterID string) (*dto.HosterProfil
 		PhoneNumber  string       `db:"phone_number"`
 		Address      string       `db:"address"`
 		StoreName    string       `db:"store_name"`
+		Slug         string       `db:"slug"`
 		Description  string       `db:"description"`
 		Website      string       `db:"website"`
 		Instagram    string       `db:"instagram"`
 		Tiktok       string       `db:"tiktok"`
 		ProfilePhoto string       `db:"profile_photo"`
+		BannerPhoto  string       `db:"banner_photo"`
 		CreatedAt    sql.NullTime `db:"created_at"`
 	}
 
 	query := `
 		SELECT 
 			id, full_name, email, phone_number, address,
-			store_name, description, website, instagram, tiktok,
-			profile_photo, created_at
+			store_name, slug, description, website, instagram, tiktok,
+			COALESCE(profile_photo, '') AS profile_photo,
+			COALESCE(banner_photo, '') AS banner_photo,
+			created_at
 		FROM hoster
 		WHERE id = $1
 	`
@@ -99,11 +104,13 @@ func (r *hosterProfileRepository) GetProfile(hosterID string) (*dto.HosterProfil
 		PhoneNumber:   row.PhoneNumber,
 		Address:       row.Address,
 		StoreName:     row.StoreName,
+		Slug:          row.Slug,
 		Description:   row.Description,
 		Website:       row.Website,
 		Instagram:     row.Instagram,
 		Tiktok:        row.Tiktok,
 		ProfilePhoto:  row.ProfilePhoto,
+		BannerPhoto:   row.BannerPhoto,
 		JoinedAt:      joinedAt,
 		DaysSinceJoin: daysSinceJoin,
 	}
@@ -160,3 +167,47 @@ func (r *hosterProfileRepository) UpdateProfile(hosterID string, req *dto.Update
 
 	return nil
 }
+
+/*
+ReplaceMedia mengganti URL foto profil / banner hoster dan mengembalikan URL lama.
+
+Alur kerja:
+1. Lock baris hoster (FOR UPDATE) dan ambil URL lama
+2. Update kolom dengan URL baru (nil = hapus)
+
+Output sukses:
+- (URL lama, nil) → string kosong jika sebelumnya belum ada
+Output error:
+- ("", sql.ErrNoRows) → hoster tidak ditemukan
+- ("", error)         → query gagal
+*/
+func (r *hosterProfileRepository) ReplaceMedia(hosterID string, media HosterMedia, url *string) (string, error) {
+	column := string(media)
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("ReplaceMedia: error starting transaction: %v", err)
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var oldURL string
+	err = tx.Get(&oldURL, `SELECT COALESCE(`+column+`, '') FROM hoster WHERE id = $1 FOR UPDATE`, hosterID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("ReplaceMedia: error locking hoster %s: %v", hosterID, err)
+		}
+		return "", err
+	}
+
+	if _, err = tx.Exec(`UPDATE hoster SET `+column+` = $2, updated_at = NOW() WHERE id = $1`, hosterID, url); err != nil {
+		log.Printf("ReplaceMedia: error updating %s for hoster %s: %v", column, hosterID, err)
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Printf("ReplaceMedia: error committing transaction: %v", err)
+		return "", err
+	}
+	return oldURL, nil
+}
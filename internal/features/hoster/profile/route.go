@@ -5,6 +5,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"lalan-be/internal/domain"
 	"lalan-be/internal/middleware"
 )
 
@@ -17,6 +18,10 @@ Alur kerja:
 3. Daftarkan endpoint:
   - GET /profile → tampilkan profil hoster
   - PUT /profile → update profil hoster (address, phone_number, description, website, instagram, tiktok)
+  - PUT    /profile/photo  → upload / ganti foto profil (multipart, field "file")
+  - DELETE /profile/photo  → hapus foto profil
+  - PUT    /profile/banner → upload / ganti banner toko (multipart, field "file")
+  - DELETE /profile/banner → hapus banner toko
 
 Output:
 - Router terkonfigurasi dengan endpoint hoster yang aman dan siap digunakan
@@ -30,7 +35,11 @@ func SetupProfileRoutes(router *mux.Router, h *HosterProfileHandler) {
 
 	// Route normal
 	protected.HandleFunc("/profile", h.GetProfile).Methods("GET", "OPTIONS")
-	protected.HandleFunc("/profile", h.UpdateProfile).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/profile", middleware.HosterPermission(domain.HosterPermStoreManage, h.UpdateProfile)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/profile/photo", middleware.HosterPermission(domain.HosterPermStoreManage, h.UploadProfilePhoto)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/profile/photo", middleware.HosterPermission(domain.HosterPermStoreManage, h.DeleteProfilePhoto)).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/profile/banner", middleware.HosterPermission(domain.HosterPermStoreManage, h.UploadBanner)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/profile/banner", middleware.HosterPermission(domain.HosterPermStoreManage, h.DeleteBanner)).Methods("DELETE", "OPTIONS")
 
 	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
 	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
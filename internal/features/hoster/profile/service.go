@@ -1,27 +1,59 @@
 package profile
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"mime/multipart"
+	"strings"
 
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
 	"lalan-be/internal/dto"
 	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
 )
 
+/*
+HosterMedia adalah foto hoster yang bisa di-upload (nilainya nama kolom di tabel hoster).
+*/
+type HosterMedia string
+
+const (
+	HosterMediaProfilePhoto HosterMedia = "profile_photo"
+	HosterMediaBanner       HosterMedia = "banner_photo"
+)
+
+/*
+mediaExtensions memetakan Content-Type gambar ke ekstensi file di bucket.
+*/
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/jpg":  ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
 /*
 HosterProfileService adalah kontrak untuk logika bisnis profile hoster.
 */
 type HosterProfileService interface {
 	GetProfile(hosterID string) (*dto.HosterProfileResponse, error)
 	UpdateProfile(hosterID string, req *dto.UpdateHosterProfileRequest) (*dto.HosterProfileResponse, error)
+	UploadMedia(ctx context.Context, hosterID string, media HosterMedia, fileHeader *multipart.FileHeader) (*dto.HosterProfileResponse, error)
+	DeleteMedia(ctx context.Context, hosterID string, media HosterMedia) (*dto.HosterProfileResponse, error)
 }
 
 /*
 hosterProfileService adalah implementasi service untuk profile hoster.
 */
 type hosterProfileService struct {
-	repo HosterProfileRepository
+	repo    HosterProfileRepository
+	storage utils.Storage
+	config  config.StorageConfig
 }
 
 /*
@@ -30,8 +62,8 @@ NewHosterProfileService membuat instance service dengan dependency injection.
 Output:
 - HosterProfileService siap digunakan
 */
-func NewHosterProfileService(repo HosterProfileRepository) HosterProfileService {
-	return &hosterProfileService{repo: repo}
+func NewHosterProfileService(repo HosterProfileRepository, storage utils.Storage, cfg config.StorageConfig) HosterProfileService {
+	return &hosterProfileService{repo: repo, storage: storage, config: cfg}
 }
 
 /*
@@ -105,3 +137,107 @@ func (s *hosterProfileService) UpdateProfile(hosterID string, req *dto.UpdateHos
 
 	return updated, nil
 }
+
+/*
+UploadMedia meng-upload foto profil / banner toko dan mengganti yang lama.
+
+Alur kerja:
+1. Validasi file gambar (jpg, jpeg, png, webp) maksimal utils.MaxImageSize, sama seperti foto item
+2. Upload ke bucket hoster: {hosterID}/profile/{kolom}_{uuid}.ext
+3. Simpan URL baru, objek baru dihapus lagi jika gagal simpan
+4. Hapus objek lama dari bucket (best-effort, sisa orphan dibersihkan storage GC admin)
+5. Return profil terbaru
+
+Output sukses:
+- (*dto.HosterProfileResponse, nil)
+Output error:
+- (nil, error) → unauthorized / file wajib / UploadInvalidContentType / FileTooLarge / ProfileNotFound / internal error
+*/
+func (s *hosterProfileService) UploadMedia(ctx context.Context, hosterID string, media HosterMedia, fileHeader *multipart.FileHeader) (*dto.HosterProfileResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if fileHeader == nil || fileHeader.Size <= 0 {
+		return nil, fmt.Errorf(message.Required, "file")
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(fileHeader.Header.Get("Content-Type")))
+	ext, ok := mediaExtensions[contentType]
+	if !ok || !utils.AllowedImageTypes[contentType] {
+		return nil, errors.New(message.UploadInvalidContentType)
+	}
+	if fileHeader.Size > utils.MaxImageSize {
+		return nil, fmt.Errorf(message.FileTooLarge, "file")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("UploadMedia service: failed to open file for hoster %s: %v", hosterID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	defer file.Close()
+
+	path := fmt.Sprintf("%s/profile/%s_%s%s", hosterID, media, uuid.New().String(), ext)
+	url, err := s.storage.Upload(ctx, file, path, contentType, s.config.HosterBucket)
+	if err != nil {
+		log.Printf("UploadMedia service: upload failed for hoster %s: %v", hosterID, err)
+		return nil, fmt.Errorf(message.UploadFailed, media)
+	}
+
+	oldURL, err := s.repo.ReplaceMedia(hosterID, media, &url)
+	if err != nil {
+		s.deleteObject(ctx, url)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.ProfileNotFound)
+		}
+		log.Printf("UploadMedia service: repo error for hoster %s: %v", hosterID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	s.deleteObject(ctx, oldURL)
+
+	return s.GetProfile(hosterID)
+}
+
+/*
+DeleteMedia menghapus foto profil / banner toko.
+
+Alur kerja:
+1. Kosongkan kolom di database
+2. Hapus objek dari bucket (best-effort)
+3. Return profil terbaru
+
+Output sukses:
+- (*dto.HosterProfileResponse, nil) → tetap sukses jika memang belum ada foto
+Output error:
+- (nil, error) → unauthorized / ProfileNotFound / internal error
+*/
+func (s *hosterProfileService) DeleteMedia(ctx context.Context, hosterID string, media HosterMedia) (*dto.HosterProfileResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	oldURL, err := s.repo.ReplaceMedia(hosterID, media, nil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.ProfileNotFound)
+		}
+		log.Printf("DeleteMedia service: repo error for hoster %s: %v", hosterID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	s.deleteObject(ctx, oldURL)
+
+	return s.GetProfile(hosterID)
+}
+
+/*
+deleteObject menghapus objek foto dari bucket hoster, kegagalan hanya dicatat di log.
+*/
+func (s *hosterProfileService) deleteObject(ctx context.Context, url string) {
+	if url == "" {
+		return
+	}
+	path := utils.ExtractPathFromURL(url, s.config.Domain, s.config.HosterBucket)
+	if err := s.storage.Delete(ctx, path, s.config.HosterBucket); err != nil {
+		log.Printf("deleteObject: failed to delete %s: %v", url, err)
+	}
+}
@@ -0,0 +1,155 @@
+package schedule
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterScheduleHandler menangani endpoint HTTP jam buka, hari libur, dan jadwal serah terima hoster.
+*/
+type HosterScheduleHandler struct {
+	service HosterScheduleService
+}
+
+/*
+NewHosterScheduleHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterScheduleHandler siap digunakan
+*/
+func NewHosterScheduleHandler(s HosterScheduleService) *HosterScheduleHandler {
+	return &HosterScheduleHandler{service: s}
+}
+
+/*
+GetHours menangani GET /api/v1/hoster/store/{id}/hours
+
+Output sukses:
+- 200 OK + pengaturan slot, jam buka mingguan, hari libur mendatang
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterScheduleHandler) GetHours(w http.ResponseWriter, r *http.Request) {
+	hours, err := h.service.GetHours(middleware.GetUserID(r), mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetHours handler: service error: %v", err)
+		writeScheduleError(w, err)
+		return
+	}
+	response.OK(w, hours, message.StoreHoursRetrieved)
+}
+
+/*
+UpdateHours menangani PUT /api/v1/hoster/store/{id}/hours
+
+Output sukses:
+- 200 OK + jam buka terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterScheduleHandler) UpdateHours(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdateStoreHoursByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("UpdateHours: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	hours, err := h.service.UpdateHours(middleware.GetUserID(r), mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("UpdateHours handler: service error: %v", err)
+		writeScheduleError(w, err)
+		return
+	}
+	response.OK(w, hours, message.StoreHoursUpdated)
+}
+
+/*
+AddClosure menangani POST /api/v1/hoster/store/{id}/closure
+
+Output sukses:
+- 200 OK + hari libur yang disimpan
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterScheduleHandler) AddClosure(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateStoreClosureByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("AddClosure: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	closure, err := h.service.AddClosure(middleware.GetUserID(r), mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("AddClosure handler: service error: %v", err)
+		writeScheduleError(w, err)
+		return
+	}
+	response.OK(w, closure, message.StoreClosureAdded)
+}
+
+/*
+DeleteClosure menangani DELETE /api/v1/hoster/store/{id}/closure/{date}
+
+Output sukses:
+- 200 OK
+Output error:
+- 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterScheduleHandler) DeleteClosure(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.service.DeleteClosure(middleware.GetUserID(r), vars["id"], vars["date"]); err != nil {
+		log.Printf("DeleteClosure handler: service error: %v", err)
+		writeScheduleError(w, err)
+		return
+	}
+	response.OK(w, nil, message.StoreClosureDeleted)
+}
+
+/*
+GetSchedule menangani GET /api/v1/hoster/schedule?date=YYYY-MM-DD&store_id=
+
+Output sukses:
+- 200 OK + daftar pickup & pengembalian di tanggal tersebut (default hari ini)
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterScheduleHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	schedule, err := h.service.GetSchedule(middleware.GetUserID(r), query.Get("store_id"), query.Get("date"))
+	if err != nil {
+		log.Printf("GetSchedule handler: service error: %v", err)
+		writeScheduleError(w, err)
+		return
+	}
+	response.OK(w, schedule, message.ScheduleRetrieved)
+}
+
+/*
+writeScheduleError memetakan error service jadwal store ke HTTP response.
+*/
+func writeScheduleError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.StoreNotFound, message.StoreClosureNotFound:
+		response.NotFound(w, err.Error())
+	case message.BadRequest,
+		message.StoreHoursInvalid,
+		message.StoreClosureInvalid,
+		message.ScheduleInvalidDate:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,276 @@
+package schedule
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+HosterScheduleRepository adalah kontrak akses data jam buka, hari libur, dan jadwal serah terima store hoster.
+*/
+type HosterScheduleRepository interface {
+	GetSlotSettings(hosterID, storeID string) (int, int, error)
+	GetHours(storeID string) ([]dto.StoreHoursDayResponse, error)
+	ReplaceHours(hosterID, storeID string, slotMinutes, slotCapacity int, days []domain.StoreOperatingHours) error
+	ListClosures(storeID string, from time.Time) ([]dto.StoreClosureResponse, error)
+	AddClosure(closure *domain.StoreClosure) error
+	DeleteClosure(storeID string, date time.Time) error
+	GetScheduleBookings(hosterID string, storeID *string, date time.Time) ([]dto.ScheduleBookingByHosterResponse, []dto.ScheduleBookingByHosterResponse, error)
+}
+
+/*
+hosterScheduleRepository adalah implementasi repository jadwal store hoster.
+*/
+type hosterScheduleRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewHosterScheduleRepository membuat instance repository dengan koneksi database.
+
+Output:
+- HosterScheduleRepository siap digunakan
+*/
+func NewHosterScheduleRepository(db *sqlx.DB) HosterScheduleRepository {
+	return &hosterScheduleRepository{db: db}
+}
+
+/*
+GetSlotSettings mengambil pengaturan slot store milik hoster (sekaligus cek kepemilikan store).
+
+Output sukses:
+- (slot_minutes, slot_capacity, nil)
+Output error:
+- (0, 0, sql.ErrNoRows) → store tidak ada / bukan milik hoster
+- (0, 0, error) → query gagal
+*/
+func (r *hosterScheduleRepository) GetSlotSettings(hosterID, storeID string) (int, int, error) {
+	var settings struct {
+		SlotMinutes  int `db:"slot_minutes"`
+		SlotCapacity int `db:"slot_capacity"`
+	}
+	query := `SELECT slot_minutes, slot_capacity FROM tenant WHERE id = $1 AND hoster_id = $2`
+	if err := r.db.Get(&settings, query, storeID, hosterID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetSlotSettings: db error store=%s err=%v", storeID, err)
+		}
+		return 0, 0, err
+	}
+	return settings.SlotMinutes, settings.SlotCapacity, nil
+}
+
+/*
+GetHours mengambil jam buka mingguan store, urut weekday.
+
+Output sukses:
+- ([]dto.StoreHoursDayResponse, nil) → kosong jika store belum mengatur jam buka
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterScheduleRepository) GetHours(storeID string) ([]dto.StoreHoursDayResponse, error) {
+	query := `
+		SELECT weekday,
+		       to_char(open_time, 'HH24:MI') AS open_time,
+		       to_char(close_time, 'HH24:MI') AS close_time
+		FROM store_operating_hours
+		WHERE tenant_id = $1
+		ORDER BY weekday
+	`
+	days := []dto.StoreHoursDayResponse{}
+	if err := r.db.Select(&days, query, storeID); err != nil {
+		log.Printf("GetHours: db error store=%s err=%v", storeID, err)
+		return nil, err
+	}
+	return days, nil
+}
+
+/*
+ReplaceHours menyimpan pengaturan slot dan mengganti seluruh jam buka mingguan store dalam satu transaksi.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → store tidak ditemukan
+- error → query gagal
+*/
+func (r *hosterScheduleRepository) ReplaceHours(hosterID, storeID string, slotMinutes, slotCapacity int, days []domain.StoreOperatingHours) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("ReplaceHours: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE tenant
+		SET slot_minutes = $1, slot_capacity = $2, updated_at = NOW()
+		WHERE id = $3 AND hoster_id = $4
+	`, slotMinutes, slotCapacity, storeID, hosterID)
+	if err != nil {
+		log.Printf("ReplaceHours: failed to update slot settings store=%s err=%v", storeID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.Exec(`DELETE FROM store_operating_hours WHERE tenant_id = $1`, storeID); err != nil {
+		log.Printf("ReplaceHours: failed to clear hours store=%s err=%v", storeID, err)
+		return err
+	}
+
+	for _, day := range days {
+		_, err := tx.Exec(`
+			INSERT INTO store_operating_hours (tenant_id, weekday, open_time, close_time)
+			VALUES ($1, $2, $3, $4)
+		`, storeID, day.Weekday, day.OpenTime, day.CloseTime)
+		if err != nil {
+			log.Printf("ReplaceHours: failed to insert weekday=%d store=%s err=%v", day.Weekday, storeID, err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ReplaceHours: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+ListClosures mengambil hari libur store mulai tanggal from, urut tanggal.
+
+Output sukses:
+- ([]dto.StoreClosureResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterScheduleRepository) ListClosures(storeID string, from time.Time) ([]dto.StoreClosureResponse, error) {
+	query := `
+		SELECT to_char(closure_date, 'YYYY-MM-DD') AS closure_date, reason
+		FROM store_closure
+		WHERE tenant_id = $1 AND closure_date >= $2
+		ORDER BY closure_date
+	`
+	closures := []dto.StoreClosureResponse{}
+	if err := r.db.Select(&closures, query, storeID, from); err != nil {
+		log.Printf("ListClosures: db error store=%s err=%v", storeID, err)
+		return nil, err
+	}
+	return closures, nil
+}
+
+/*
+AddClosure menyimpan hari libur store.
+Tanggal yang sudah ditandai libur hanya diperbarui alasannya.
+
+Output sukses:
+- nil (ID & created_at terisi)
+Output error:
+- error → query gagal
+*/
+func (r *hosterScheduleRepository) AddClosure(closure *domain.StoreClosure) error {
+	query := `
+		INSERT INTO store_closure (tenant_id, closure_date, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, closure_date) DO UPDATE SET reason = EXCLUDED.reason
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRow(query, closure.TenantID, closure.ClosureDate, closure.Reason).Scan(&closure.ID, &closure.CreatedAt)
+	if err != nil {
+		log.Printf("AddClosure: db error store=%s err=%v", closure.TenantID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+DeleteClosure menghapus hari libur store pada tanggal tertentu.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → tanggal tersebut bukan hari libur store
+- error → query gagal
+*/
+func (r *hosterScheduleRepository) DeleteClosure(storeID string, date time.Time) error {
+	res, err := r.db.Exec(`DELETE FROM store_closure WHERE tenant_id = $1 AND closure_date = $2`, storeID, date)
+	if err != nil {
+		log.Printf("DeleteClosure: db error store=%s err=%v", storeID, err)
+		return err
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+/*
+GetScheduleBookings mengambil booking yang dijadwalkan pickup dan pengembalian pada satu tanggal.
+
+Alur kerja:
+1. Pickup: booking lunas (on_progress) atau sudah diambil (on_rent) dengan start_date = tanggal
+2. Pengembalian: booking on_rent atau sudah selesai (completed) dengan end_date = tanggal
+3. Filter store opsional, urut jam slot (booking tanpa slot di akhir)
+
+Output sukses:
+- (pickups, returns, nil) → masing-masing bisa kosong
+Output error:
+- (nil, nil, error) → query gagal
+*/
+func (r *hosterScheduleRepository) GetScheduleBookings(hosterID string, storeID *string, date time.Time) ([]dto.ScheduleBookingByHosterResponse, []dto.ScheduleBookingByHosterResponse, error) {
+	query := `
+		SELECT
+			b.id AS booking_id,
+			to_char(%[1]s, 'HH24:MI') AS slot_time,
+			b.tenant_id AS store_id,
+			COALESCE(t.name, '') AS store_name,
+			COALESCE(NULLIF(bc.name, ''), c.full_name, '') AS customer_name,
+			COALESCE(NULLIF(bc.phone, ''), c.phone_number, '') AS customer_phone,
+			b.delivery_type,
+			COALESCE(items.item_names, '') AS item_names,
+			COALESCE(items.total_items, 0) AS total_items,
+			b.status
+		FROM booking b
+		LEFT JOIN tenant t ON t.id = b.tenant_id
+		LEFT JOIN (
+			SELECT booking_id,
+			       string_agg(name || ' x' || quantity, ', ' ORDER BY name) AS item_names,
+			       SUM(quantity) AS total_items
+			FROM booking_item
+			GROUP BY booking_id
+		) items ON items.booking_id = b.id
+		LEFT JOIN booking_customer bc ON bc.booking_id = b.id
+		LEFT JOIN customer c ON c.id = b.user_id
+		WHERE b.hoster_id = $1
+		  AND ($2::uuid IS NULL OR b.tenant_id = $2)
+		  AND %[2]s = $3
+		  AND b.status IN (%[3]s)
+		ORDER BY %[1]s NULLS LAST, b.created_at
+	`
+
+	pickups := []dto.ScheduleBookingByHosterResponse{}
+	pickupQuery := fmt.Sprintf(query, "b.pickup_time", "b.start_date", "'on_progress', 'on_rent'")
+	if err := r.db.Select(&pickups, pickupQuery, hosterID, storeID, date); err != nil {
+		log.Printf("GetScheduleBookings: pickup query error hoster=%s err=%v", hosterID, err)
+		return nil, nil, err
+	}
+
+	returns := []dto.ScheduleBookingByHosterResponse{}
+	returnQuery := fmt.Sprintf(query, "b.return_time", "b.end_date", "'on_rent', 'completed'")
+	if err := r.db.Select(&returns, returnQuery, hosterID, storeID, date); err != nil {
+		log.Printf("GetScheduleBookings: return query error hoster=%s err=%v", hosterID, err)
+		return nil, nil, err
+	}
+	return pickups, returns, nil
+}
@@ -0,0 +1,47 @@
+package schedule
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupScheduleRoutes mendaftarkan endpoint jam buka, hari libur, dan jadwal serah terima hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET    /store/{id}/hours          → pengaturan slot, jam buka mingguan, hari libur (semua role toko)
+  - PUT    /store/{id}/hours          → ganti jam buka & pengaturan slot (store:manage)
+  - POST   /store/{id}/closure        → tandai hari libur (store:manage)
+  - DELETE /store/{id}/closure/{date} → hapus hari libur (store:manage)
+  - GET    /schedule                  → jadwal pickup & pengembalian harian (bookings:view)
+
+Output:
+- Router terkonfigurasi dengan endpoint jadwal store hoster
+*/
+func SetupScheduleRoutes(router *mux.Router, h *HosterScheduleHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	manage := domain.HosterPermStoreManage
+
+	protected.HandleFunc("/store/{id}/hours", h.GetHours).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/store/{id}/hours", middleware.HosterPermission(manage, h.UpdateHours)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/store/{id}/closure", middleware.HosterPermission(manage, h.AddClosure)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/store/{id}/closure/{date}", middleware.HosterPermission(manage, h.DeleteClosure)).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/schedule", middleware.HosterPermission(domain.HosterPermBookingsView, h.GetSchedule)).Methods("GET", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
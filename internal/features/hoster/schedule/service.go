@@ -0,0 +1,293 @@
+package schedule
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
+)
+
+/*
+HosterScheduleService adalah kontrak untuk logika bisnis jam buka, hari libur, dan jadwal serah terima hoster.
+*/
+type HosterScheduleService interface {
+	GetHours(hosterID, storeID string) (*dto.StoreHoursByHosterResponse, error)
+	UpdateHours(hosterID, storeID string, req dto.UpdateStoreHoursByHosterRequest) (*dto.StoreHoursByHosterResponse, error)
+	AddClosure(hosterID, storeID string, req dto.CreateStoreClosureByHosterRequest) (*dto.StoreClosureResponse, error)
+	DeleteClosure(hosterID, storeID, date string) error
+	GetSchedule(hosterID, storeID, date string) (*dto.ScheduleByHosterResponse, error)
+}
+
+/*
+hosterScheduleService adalah implementasi service jadwal store hoster.
+*/
+type hosterScheduleService struct {
+	repo HosterScheduleRepository
+}
+
+/*
+NewHosterScheduleService membuat instance service dengan dependency injection.
+
+Output:
+- HosterScheduleService siap digunakan
+*/
+func NewHosterScheduleService(repo HosterScheduleRepository) HosterScheduleService {
+	return &hosterScheduleService{repo: repo}
+}
+
+/*
+Batas pengaturan jadwal store.
+*/
+const (
+	MaxClosureReasonLength = 255
+	MaxSlotCapacity        = 100
+)
+
+/*
+allowedSlotMinutes adalah panjang slot yang didukung (mengikuti CHECK di tabel tenant).
+*/
+var allowedSlotMinutes = map[int]bool{15: true, 30: true, 60: true, 120: true}
+
+/*
+GetHours mengambil pengaturan slot, jam buka mingguan, dan hari libur mendatang store.
+
+Output sukses:
+- (*dto.StoreHoursByHosterResponse, nil)
+Output error:
+- (nil, error) → Unauthorized / StoreNotFound / internal error
+*/
+func (s *hosterScheduleService) GetHours(hosterID, storeID string) (*dto.StoreHoursByHosterResponse, error) {
+	slotMinutes, slotCapacity, err := s.getSlotSettings(hosterID, storeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildHours(storeID, slotMinutes, slotCapacity)
+}
+
+/*
+UpdateHours mengganti pengaturan slot dan jam buka mingguan store.
+
+Alur kerja:
+1. Pastikan store milik hoster
+2. Validasi slot_minutes, slot_capacity, weekday unik 0-6, jam HH:MM dengan jam buka < jam tutup
+3. Setiap hari buka minimal muat satu slot
+4. Simpan (hari yang tidak dikirim dianggap tutup, days kosong = tanpa jam buka / slot bebas)
+
+Catatan: booking yang sudah ada tidak diubah walau slotnya tidak lagi tersedia.
+
+Output sukses:
+- (*dto.StoreHoursByHosterResponse, nil) → jam buka terbaru
+Output error:
+- (nil, error) → Unauthorized / StoreNotFound / StoreHoursInvalid / internal error
+*/
+func (s *hosterScheduleService) UpdateHours(hosterID, storeID string, req dto.UpdateStoreHoursByHosterRequest) (*dto.StoreHoursByHosterResponse, error) {
+	if _, _, err := s.getSlotSettings(hosterID, storeID); err != nil {
+		return nil, err
+	}
+
+	if !allowedSlotMinutes[req.SlotMinutes] || req.SlotCapacity <= 0 || req.SlotCapacity > MaxSlotCapacity {
+		return nil, errors.New(message.StoreHoursInvalid)
+	}
+
+	seen := map[int]bool{}
+	days := make([]domain.StoreOperatingHours, 0, len(req.Days))
+	for _, d := range req.Days {
+		if d.Weekday < 0 || d.Weekday > 6 || seen[d.Weekday] {
+			return nil, errors.New(message.StoreHoursInvalid)
+		}
+		seen[d.Weekday] = true
+
+		open, err := utils.ParseClock(strings.TrimSpace(d.OpenTime))
+		if err != nil {
+			return nil, errors.New(message.StoreHoursInvalid)
+		}
+		closing, err := utils.ParseClock(strings.TrimSpace(d.CloseTime))
+		if err != nil || closing-open < req.SlotMinutes {
+			return nil, errors.New(message.StoreHoursInvalid)
+		}
+
+		days = append(days, domain.StoreOperatingHours{
+			TenantID:  storeID,
+			Weekday:   d.Weekday,
+			OpenTime:  utils.FormatClock(open),
+			CloseTime: utils.FormatClock(closing),
+		})
+	}
+
+	if err := s.repo.ReplaceHours(hosterID, storeID, req.SlotMinutes, req.SlotCapacity, days); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.StoreNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("UpdateHours(schedule service): hoster %s set %d open days on store %s", hosterID, len(days), storeID)
+	return s.buildHours(storeID, req.SlotMinutes, req.SlotCapacity)
+}
+
+/*
+AddClosure menandai satu tanggal sebagai hari libur store.
+
+Alur kerja:
+1. Pastikan store milik hoster
+2. Tanggal wajib YYYY-MM-DD mulai hari ini, alasan opsional (maks 255 karakter)
+3. Simpan (tanggal yang sudah libur hanya diperbarui alasannya)
+
+Catatan: booking yang sudah ada di tanggal tersebut tidak dibatalkan otomatis, cek lewat jadwal harian.
+
+Output sukses:
+- (*dto.StoreClosureResponse, nil)
+Output error:
+- (nil, error) → Unauthorized / StoreNotFound / StoreClosureInvalid / BadRequest / internal error
+*/
+func (s *hosterScheduleService) AddClosure(hosterID, storeID string, req dto.CreateStoreClosureByHosterRequest) (*dto.StoreClosureResponse, error) {
+	if _, _, err := s.getSlotSettings(hosterID, storeID); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(req.Date))
+	if err != nil || date.Before(time.Now().UTC().Truncate(24*time.Hour)) {
+		return nil, errors.New(message.StoreClosureInvalid)
+	}
+
+	var reason *string
+	if trimmed := strings.TrimSpace(req.Reason); trimmed != "" {
+		if len(trimmed) > MaxClosureReasonLength {
+			return nil, errors.New(message.BadRequest)
+		}
+		reason = &trimmed
+	}
+
+	closure := &domain.StoreClosure{TenantID: storeID, ClosureDate: date, Reason: reason}
+	if err := s.repo.AddClosure(closure); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("AddClosure(schedule service): hoster %s closed store %s on %s", hosterID, storeID, date.Format("2006-01-02"))
+	return &dto.StoreClosureResponse{Date: date.Format("2006-01-02"), Reason: reason}, nil
+}
+
+/*
+DeleteClosure membuka kembali store pada tanggal yang sebelumnya ditandai libur.
+
+Output sukses:
+- nil
+Output error:
+- error → Unauthorized / StoreNotFound / StoreClosureNotFound / internal error
+*/
+func (s *hosterScheduleService) DeleteClosure(hosterID, storeID, date string) error {
+	if _, _, err := s.getSlotSettings(hosterID, storeID); err != nil {
+		return err
+	}
+
+	closureDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return errors.New(message.StoreClosureNotFound)
+	}
+
+	if err := s.repo.DeleteClosure(storeID, closureDate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.StoreClosureNotFound)
+		}
+		return errors.New(message.InternalError)
+	}
+	return nil
+}
+
+/*
+GetSchedule mengambil jadwal serah terima harian hoster.
+
+Alur kerja:
+1. Tanggal default hari ini, format YYYY-MM-DD
+2. store_id opsional, jika diisi harus store milik hoster
+3. Ambil daftar pickup & pengembalian, urut jam slot
+
+Output sukses:
+- (*dto.ScheduleByHosterResponse, nil)
+Output error:
+- (nil, error) → Unauthorized / ScheduleInvalidDate / StoreNotFound / internal error
+*/
+func (s *hosterScheduleService) GetSchedule(hosterID, storeID, date string) (*dto.ScheduleByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	if date = strings.TrimSpace(date); date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, errors.New(message.ScheduleInvalidDate)
+		}
+		day = parsed
+	}
+
+	var store *string
+	if storeID = strings.TrimSpace(storeID); storeID != "" {
+		if _, _, err := s.getSlotSettings(hosterID, storeID); err != nil {
+			return nil, err
+		}
+		store = &storeID
+	}
+
+	pickups, returns, err := s.repo.GetScheduleBookings(hosterID, store, day)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.ScheduleByHosterResponse{
+		Date:    day.Format("2006-01-02"),
+		Pickups: pickups,
+		Returns: returns,
+	}, nil
+}
+
+/*
+getSlotSettings memvalidasi ID lalu mengambil pengaturan slot store milik hoster.
+*/
+func (s *hosterScheduleService) getSlotSettings(hosterID, storeID string) (int, int, error) {
+	if hosterID == "" {
+		return 0, 0, errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(storeID); err != nil {
+		return 0, 0, errors.New(message.StoreNotFound)
+	}
+
+	slotMinutes, slotCapacity, err := s.repo.GetSlotSettings(hosterID, storeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, errors.New(message.StoreNotFound)
+		}
+		return 0, 0, errors.New(message.InternalError)
+	}
+	return slotMinutes, slotCapacity, nil
+}
+
+/*
+buildHours menyusun response jam buka store beserta hari libur mulai hari ini.
+*/
+func (s *hosterScheduleService) buildHours(storeID string, slotMinutes, slotCapacity int) (*dto.StoreHoursByHosterResponse, error) {
+	days, err := s.repo.GetHours(storeID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	closures, err := s.repo.ListClosures(storeID, time.Now().UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.StoreHoursByHosterResponse{
+		StoreID:      storeID,
+		SlotMinutes:  slotMinutes,
+		SlotCapacity: slotCapacity,
+		Days:         days,
+		Closures:     closures,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterStoreHandler menangani endpoint HTTP store (cabang toko) hoster.
+*/
+type HosterStoreHandler struct {
+	service HosterStoreService
+}
+
+/*
+NewHosterStoreHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterStoreHandler siap digunakan
+*/
+func NewHosterStoreHandler(s HosterStoreService) *HosterStoreHandler {
+	return &HosterStoreHandler{service: s}
+}
+
+/*
+ListStores menangani GET /api/v1/hoster/store
+
+Output sukses:
+- 200 OK + daftar store (default di urutan pertama)
+Output error:
+- 401 Unauthorized / 500 Internal Server Error
+*/
+func (h *HosterStoreHandler) ListStores(w http.ResponseWriter, r *http.Request) {
+	stores, err := h.service.ListStores(middleware.GetUserID(r))
+	if err != nil {
+		log.Printf("ListStores handler: service error: %v", err)
+		writeStoreError(w, err)
+		return
+	}
+	response.OK(w, stores, message.StoreRetrieved)
+}
+
+/*
+CreateStore menangani POST /api/v1/hoster/store
+
+Output sukses:
+- 200 OK + store baru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 500 Internal Server Error
+*/
+func (h *HosterStoreHandler) CreateStore(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateStoreByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("CreateStore: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	store, err := h.service.CreateStore(middleware.GetUserID(r), req)
+	if err != nil {
+		log.Printf("CreateStore handler: service error: %v", err)
+		writeStoreError(w, err)
+		return
+	}
+	response.OK(w, store, message.StoreCreated)
+}
+
+/*
+UpdateStore menangani PUT /api/v1/hoster/store/{id}
+
+Output sukses:
+- 200 OK + store terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterStoreHandler) UpdateStore(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdateStoreByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("UpdateStore: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	store, err := h.service.UpdateStore(middleware.GetUserID(r), mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("UpdateStore handler: service error: %v", err)
+		writeStoreError(w, err)
+		return
+	}
+	response.OK(w, store, message.StoreUpdated)
+}
+
+/*
+DeleteStore menangani DELETE /api/v1/hoster/store/{id}
+
+Output sukses:
+- 200 OK
+Output error:
+- 400 Bad Request (store default / masih punya item / booking aktif)
+- 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterStoreHandler) DeleteStore(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteStore(middleware.GetUserID(r), mux.Vars(r)["id"]); err != nil {
+		log.Printf("DeleteStore handler: service error: %v", err)
+		writeStoreError(w, err)
+		return
+	}
+	response.OK(w, nil, message.StoreDeleted)
+}
+
+/*
+writeStoreError memetakan error service store ke HTTP response.
+*/
+func writeStoreError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.StoreNotFound:
+		response.NotFound(w, message.StoreNotFound)
+	case message.BadRequest,
+		message.StoreNameExists,
+		message.StoreDefaultRequired,
+		message.StoreHasItems,
+		message.StoreHasActiveBookings:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,256 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+HosterStoreRepository mendefinisikan operasi database untuk store (tenant) milik hoster.
+*/
+type HosterStoreRepository interface {
+	ListStores(hosterID string) ([]dto.StoreByHosterResponse, error)
+	GetStore(hosterID, storeID string) (*domain.Tenant, error)
+	GetStoreUsage(storeID string) (int, int, error)
+	CreateStore(t *domain.Tenant) error
+	UpdateStore(t *domain.Tenant) error
+	DeleteStore(hosterID, storeID string) error
+}
+
+/*
+hosterStoreRepository adalah implementasi repository store hoster.
+*/
+type hosterStoreRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewHosterStoreRepository membuat instance repository dengan koneksi database.
+
+Output:
+- HosterStoreRepository siap digunakan
+*/
+func NewHosterStoreRepository(db *sqlx.DB) HosterStoreRepository {
+	return &hosterStoreRepository{db: db}
+}
+
+/*
+activeBookingCondition adalah booking yang masih berjalan di sebuah store.
+*/
+const activeBookingCondition = `(b.status IN ('on_progress', 'on_rent') OR (b.status = 'pending' AND b.locked_until > NOW()))`
+
+/*
+ListStores mengambil semua store hoster beserta jumlah item dan booking aktif.
+Store default selalu di urutan pertama.
+
+Output sukses:
+- ([]dto.StoreByHosterResponse, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterStoreRepository) ListStores(hosterID string) ([]dto.StoreByHosterResponse, error) {
+	query := `
+		SELECT
+			t.id, t.name, t.address, t.city, t.phone_number,
+			t.delivery_enabled, t.delivery_fee, t.delivery_radius_km, t.is_default, t.amendment_pricing,
+			(SELECT COUNT(*) FROM item i WHERE i.tenant_id = t.id) AS item_count,
+			(SELECT COUNT(*) FROM booking b WHERE b.tenant_id = t.id AND ` + activeBookingCondition + `) AS active_bookings,
+			t.created_at, t.updated_at
+		FROM tenant t
+		WHERE t.hoster_id = $1
+		ORDER BY t.is_default DESC, t.name ASC
+	`
+	stores := []dto.StoreByHosterResponse{}
+	if err := r.db.Select(&stores, query, hosterID); err != nil {
+		log.Printf("ListStores: db error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return stores, nil
+}
+
+/*
+GetStore mengambil satu store milik hoster.
+
+Output sukses:
+- (*domain.Tenant, nil)
+Output error:
+- (nil, sql.ErrNoRows) → store tidak ada / bukan milik hoster
+- (nil, error) → query gagal
+*/
+func (r *hosterStoreRepository) GetStore(hosterID, storeID string) (*domain.Tenant, error) {
+	var t domain.Tenant
+	query := `
+		SELECT id, name, hoster_id, address, city, phone_number,
+		       delivery_enabled, delivery_fee, delivery_radius_km, is_default, amendment_pricing,
+		       slot_minutes, slot_capacity, created_at, updated_at
+		FROM tenant
+		WHERE id = $1 AND hoster_id = $2
+	`
+	if err := r.db.Get(&t, query, storeID, hosterID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetStore: db error store=%s err=%v", storeID, err)
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+/*
+GetStoreUsage menghitung item dan booking aktif di store (dipakai sebelum menghapus store).
+
+Output:
+- (jumlah item, jumlah booking aktif, nil)
+- (0, 0, error) jika query gagal
+*/
+func (r *hosterStoreRepository) GetStoreUsage(storeID string) (int, int, error) {
+	var usage struct {
+		Items    int `db:"items"`
+		Bookings int `db:"bookings"`
+	}
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM item i WHERE i.tenant_id = $1) AS items,
+			(SELECT COUNT(*) FROM booking b WHERE b.tenant_id = $1 AND ` + activeBookingCondition + `) AS bookings
+	`
+	if err := r.db.Get(&usage, query, storeID); err != nil {
+		log.Printf("GetStoreUsage: db error store=%s err=%v", storeID, err)
+		return 0, 0, err
+	}
+	return usage.Items, usage.Bookings, nil
+}
+
+/*
+CreateStore menyimpan store baru.
+Jika store baru ditandai default, store default lama dilepas dalam transaksi yang sama.
+
+Output sukses:
+- nil (ID, pengaturan slot default, created_at, updated_at terisi)
+Output error:
+- errors.New("duplicate") → nama store sudah dipakai hoster ini
+- error → query gagal
+*/
+func (r *hosterStoreRepository) CreateStore(t *domain.Tenant) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("CreateStore: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if t.IsDefault {
+		if _, err := tx.Exec(`UPDATE tenant SET is_default = false WHERE hoster_id = $1 AND is_default`, t.HosterID); err != nil {
+			log.Printf("CreateStore: failed to clear default hoster=%s err=%v", t.HosterID, err)
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO tenant (
+			name, hoster_id, address, city, phone_number,
+			delivery_enabled, delivery_fee, delivery_radius_km, is_default, amendment_pricing
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, slot_minutes, slot_capacity, created_at, updated_at
+	`
+	err = tx.QueryRow(query,
+		t.Name, t.HosterID, t.Address, t.City, t.PhoneNumber,
+		t.DeliveryEnabled, t.DeliveryFee, t.DeliveryRadiusKm, t.IsDefault, t.AmendmentPricing,
+	).Scan(&t.ID, &t.SlotMinutes, &t.SlotCapacity, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New("duplicate")
+		}
+		log.Printf("CreateStore: db error hoster=%s err=%v", t.HosterID, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("CreateStore: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+UpdateStore menyimpan perubahan store.
+Jika store dijadikan default, store default lama dilepas dalam transaksi yang sama.
+
+Output sukses:
+- nil
+Output error:
+- errors.New("duplicate") → nama store sudah dipakai hoster ini
+- sql.ErrNoRows → store tidak ditemukan
+- error → query gagal
+*/
+func (r *hosterStoreRepository) UpdateStore(t *domain.Tenant) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("UpdateStore: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if t.IsDefault {
+		if _, err := tx.Exec(`UPDATE tenant SET is_default = false WHERE hoster_id = $1 AND is_default AND id <> $2`, t.HosterID, t.ID); err != nil {
+			log.Printf("UpdateStore: failed to clear default hoster=%s err=%v", t.HosterID, err)
+			return err
+		}
+	}
+
+	query := `
+		UPDATE tenant
+		SET name = $1, address = $2, city = $3, phone_number = $4,
+		    delivery_enabled = $5, delivery_fee = $6, delivery_radius_km = $7,
+		    is_default = $8, amendment_pricing = $9, updated_at = NOW()
+		WHERE id = $10 AND hoster_id = $11
+		RETURNING updated_at
+	`
+	err = tx.QueryRow(query,
+		t.Name, t.Address, t.City, t.PhoneNumber,
+		t.DeliveryEnabled, t.DeliveryFee, t.DeliveryRadiusKm,
+		t.IsDefault, t.AmendmentPricing, t.ID, t.HosterID,
+	).Scan(&t.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New("duplicate")
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("UpdateStore: db error store=%s err=%v", t.ID, err)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("UpdateStore: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+DeleteStore menghapus store yang bukan default.
+Item masih menahan store lewat foreign key (ON DELETE RESTRICT), service sudah mengecek lebih dulu.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → store tidak ditemukan / store default
+- error → query gagal
+*/
+func (r *hosterStoreRepository) DeleteStore(hosterID, storeID string) error {
+	res, err := r.db.Exec(`DELETE FROM tenant WHERE id = $1 AND hoster_id = $2 AND NOT is_default`, storeID, hosterID)
+	if err != nil {
+		log.Printf("DeleteStore: db error store=%s err=%v", storeID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupStoreRoutes mendaftarkan endpoint store (cabang toko) hoster.
+
+Alur kerja:
+1. Buat subrouter /api/v1/hoster/store dengan middleware JWT → Hoster
+2. Daftarkan endpoint:
+  - GET    ""     → daftar store (semua role toko, dipakai untuk memilih store di dashboard)
+  - POST   ""     → buka store baru (store:manage)
+  - PUT    /{id}  → ubah store / jadikan default (store:manage)
+  - DELETE /{id}  → hapus store kosong yang bukan default (store:manage)
+
+Output:
+- Router terkonfigurasi dengan endpoint store
+*/
+func SetupStoreRoutes(router *mux.Router, h *HosterStoreHandler) {
+	protected := router.PathPrefix("/api/v1/hoster/store").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	manage := domain.HosterPermStoreManage
+
+	protected.HandleFunc("", h.ListStores).Methods("GET")
+	protected.HandleFunc("", middleware.HosterPermission(manage, h.CreateStore)).Methods("POST")
+	protected.HandleFunc("/{id}", middleware.HosterPermission(manage, h.UpdateStore)).Methods("PUT")
+	protected.HandleFunc("/{id}", middleware.HosterPermission(manage, h.DeleteStore)).Methods("DELETE")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
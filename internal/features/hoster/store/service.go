@@ -0,0 +1,288 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+HosterStoreService adalah kontrak untuk logika bisnis store (cabang toko) hoster.
+*/
+type HosterStoreService interface {
+	ListStores(hosterID string) ([]dto.StoreByHosterResponse, error)
+	CreateStore(hosterID string, req dto.CreateStoreByHosterRequest) (*domain.Tenant, error)
+	UpdateStore(hosterID, storeID string, req dto.UpdateStoreByHosterRequest) (*domain.Tenant, error)
+	DeleteStore(hosterID, storeID string) error
+}
+
+/*
+hosterStoreService adalah implementasi service store hoster.
+*/
+type hosterStoreService struct {
+	repo HosterStoreRepository
+}
+
+/*
+NewHosterStoreService membuat instance service dengan dependency injection.
+
+Output:
+- HosterStoreService siap digunakan
+*/
+func NewHosterStoreService(repo HosterStoreRepository) HosterStoreService {
+	return &hosterStoreService{repo: repo}
+}
+
+/*
+Batas panjang field store (mengikuti kolom tabel tenant).
+*/
+const (
+	MaxStoreNameLength = 255
+	MaxCityLength      = 100
+	MaxPhoneLength     = 20
+)
+
+/*
+ListStores mengambil semua store milik hoster.
+
+Output sukses:
+- ([]dto.StoreByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / internal error
+*/
+func (s *hosterStoreService) ListStores(hosterID string) ([]dto.StoreByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	stores, err := s.repo.ListStores(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return stores, nil
+}
+
+/*
+CreateStore membuat cabang toko baru.
+
+Alur kerja:
+1. Validasi nama, alamat, kota, telepon, pengaturan delivery, dan kebijakan harga perubahan booking (default snapshot)
+2. Simpan store (jika is_default, store default lama otomatis dilepas)
+
+Output sukses:
+- (*domain.Tenant, nil)
+Output error:
+- (nil, error) → BadRequest / StoreNameExists / internal error
+*/
+func (s *hosterStoreService) CreateStore(hosterID string, req dto.CreateStoreByHosterRequest) (*domain.Tenant, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	store := &domain.Tenant{
+		HosterID:         hosterID,
+		Name:             strings.TrimSpace(req.Name),
+		Address:          strings.TrimSpace(req.Address),
+		City:             strings.TrimSpace(req.City),
+		PhoneNumber:      trimOptional(req.PhoneNumber),
+		DeliveryEnabled:  req.DeliveryEnabled == nil || *req.DeliveryEnabled,
+		DeliveryFee:      req.DeliveryFee,
+		DeliveryRadiusKm: req.DeliveryRadiusKm,
+		IsDefault:        req.IsDefault,
+		AmendmentPricing: req.AmendmentPricing,
+	}
+	if store.AmendmentPricing == "" {
+		store.AmendmentPricing = domain.AmendmentPricingSnapshot
+	}
+	if err := validateStore(store); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateStore(store); err != nil {
+		if err.Error() == "duplicate" {
+			return nil, errors.New(message.StoreNameExists)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("CreateStore(store service): hoster %s created store %s (%s)", hosterID, store.ID, store.Name)
+	return store, nil
+}
+
+/*
+UpdateStore mengubah data store.
+
+Alur kerja:
+1. Ambil store (harus milik hoster)
+2. Terapkan field yang dikirim (delivery_radius_km = 0 menghapus batas jangkauan)
+3. Store default tidak bisa dilepas langsung, jadikan store lain default
+4. Validasi ulang lalu simpan
+
+Output sukses:
+- (*domain.Tenant, nil)
+Output error:
+- (nil, error) → StoreNotFound / BadRequest / StoreNameExists / StoreDefaultRequired / internal error
+*/
+func (s *hosterStoreService) UpdateStore(hosterID, storeID string, req dto.UpdateStoreByHosterRequest) (*domain.Tenant, error) {
+	store, err := s.getStore(hosterID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		store.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Address != nil {
+		store.Address = strings.TrimSpace(*req.Address)
+	}
+	if req.City != nil {
+		store.City = strings.TrimSpace(*req.City)
+	}
+	if req.PhoneNumber != nil {
+		store.PhoneNumber = trimOptional(req.PhoneNumber)
+	}
+	if req.DeliveryEnabled != nil {
+		store.DeliveryEnabled = *req.DeliveryEnabled
+	}
+	if req.DeliveryFee != nil {
+		store.DeliveryFee = *req.DeliveryFee
+	}
+	if req.DeliveryRadiusKm != nil {
+		store.DeliveryRadiusKm = req.DeliveryRadiusKm
+		if *req.DeliveryRadiusKm == 0 {
+			store.DeliveryRadiusKm = nil
+		}
+	}
+	if req.AmendmentPricing != nil {
+		store.AmendmentPricing = *req.AmendmentPricing
+	}
+	if req.IsDefault != nil {
+		if store.IsDefault && !*req.IsDefault {
+			return nil, errors.New(message.StoreDefaultRequired)
+		}
+		store.IsDefault = *req.IsDefault
+	}
+
+	if err := validateStore(store); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateStore(store); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.StoreNotFound)
+		}
+		if err.Error() == "duplicate" {
+			return nil, errors.New(message.StoreNameExists)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	return store, nil
+}
+
+/*
+DeleteStore menghapus cabang toko.
+
+Alur kerja:
+1. Store default tidak bisa dihapus
+2. Store yang masih punya item atau booking aktif tidak bisa dihapus
+3. Riwayat booking lama tetap tersimpan (tenant_id menjadi NULL)
+
+Output sukses:
+- nil
+Output error:
+- error → StoreNotFound / StoreDefaultRequired / StoreHasItems / StoreHasActiveBookings / internal error
+*/
+func (s *hosterStoreService) DeleteStore(hosterID, storeID string) error {
+	store, err := s.getStore(hosterID, storeID)
+	if err != nil {
+		return err
+	}
+	if store.IsDefault {
+		return errors.New(message.StoreDefaultRequired)
+	}
+
+	items, bookings, err := s.repo.GetStoreUsage(store.ID)
+	if err != nil {
+		return errors.New(message.InternalError)
+	}
+	if items > 0 {
+		return errors.New(message.StoreHasItems)
+	}
+	if bookings > 0 {
+		return errors.New(message.StoreHasActiveBookings)
+	}
+
+	if err := s.repo.DeleteStore(hosterID, store.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.StoreNotFound)
+		}
+		return errors.New(message.InternalError)
+	}
+
+	log.Printf("DeleteStore(store service): hoster %s deleted store %s", hosterID, store.ID)
+	return nil
+}
+
+/*
+getStore memvalidasi ID lalu mengambil store milik hoster.
+*/
+func (s *hosterStoreService) getStore(hosterID, storeID string) (*domain.Tenant, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(storeID); err != nil {
+		return nil, errors.New(message.StoreNotFound)
+	}
+
+	store, err := s.repo.GetStore(hosterID, storeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.StoreNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	return store, nil
+}
+
+/*
+validateStore memeriksa field wajib dan batas nilai store.
+*/
+func validateStore(t *domain.Tenant) error {
+	if t.Name == "" || len(t.Name) > MaxStoreNameLength {
+		return errors.New(message.BadRequest)
+	}
+	if t.Address == "" || len(t.City) > MaxCityLength {
+		return errors.New(message.BadRequest)
+	}
+	if t.PhoneNumber != nil && len(*t.PhoneNumber) > MaxPhoneLength {
+		return errors.New(message.BadRequest)
+	}
+	if t.DeliveryFee < 0 || (t.DeliveryRadiusKm != nil && *t.DeliveryRadiusKm <= 0) {
+		return errors.New(message.BadRequest)
+	}
+	if t.AmendmentPricing != domain.AmendmentPricingSnapshot && t.AmendmentPricing != domain.AmendmentPricingCurrent {
+		return errors.New(message.BadRequest)
+	}
+	return nil
+}
+
+/*
+trimOptional merapikan field opsional; string kosong disimpan sebagai NULL.
+*/
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
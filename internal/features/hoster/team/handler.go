@@ -0,0 +1,223 @@
+package team
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterTeamHandler menangani endpoint HTTP tim toko (owner, manager, staff counter).
+*/
+type HosterTeamHandler struct {
+	service HosterTeamService
+}
+
+/*
+NewHosterTeamHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterTeamHandler siap digunakan
+*/
+func NewHosterTeamHandler(s HosterTeamService) *HosterTeamHandler {
+	return &HosterTeamHandler{service: s}
+}
+
+/*
+GetAccess menangani GET /api/v1/hoster/team/me
+
+Dipakai frontend untuk menyembunyikan menu yang tidak boleh diakses role toko.
+
+Output sukses:
+- 200 OK + store_id, member_id, role, permissions
+Output error:
+- 401 Unauthorized
+*/
+func (h *HosterTeamHandler) GetAccess(w http.ResponseWriter, r *http.Request) {
+	access, err := h.service.GetAccess(middleware.GetUserID(r), middleware.GetMemberID(r), middleware.GetHosterRole(r))
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+	response.OK(w, access, message.Success)
+}
+
+/*
+ListMembers menangani GET /api/v1/hoster/team
+
+Output sukses:
+- 200 OK + daftar staff toko
+Output error:
+- 401 Unauthorized / 403 Forbidden / 500 Internal Server Error
+*/
+func (h *HosterTeamHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	members, err := h.service.ListMembers(middleware.GetUserID(r))
+	if err != nil {
+		log.Printf("ListMembers handler: service error: %v", err)
+		writeTeamError(w, err)
+		return
+	}
+	response.OK(w, members, message.TeamRetrieved)
+}
+
+/*
+InviteMember menangani POST /api/v1/hoster/team
+
+Alur kerja:
+1. Parse JSON body
+2. Validasi nama & format email
+3. Panggil service (validasi role, cek email, buat token undangan)
+
+Output sukses:
+- 200 OK + data staff + invite_token
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 500 Internal Server Error
+*/
+func (h *HosterTeamHandler) InviteMember(w http.ResponseWriter, r *http.Request) {
+	var req dto.InviteMemberByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("InviteMember: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.FullName) == "" {
+		response.BadRequest(w, fmt.Sprintf(message.Required, "full_name"))
+		return
+	}
+	if !EmailPattern.MatchString(strings.TrimSpace(req.Email)) {
+		response.BadRequest(w, fmt.Sprintf(message.InvalidFormat, "email"))
+		return
+	}
+
+	result, err := h.service.InviteMember(middleware.GetUserID(r), req)
+	if err != nil {
+		log.Printf("InviteMember handler: service error: %v", err)
+		writeTeamError(w, err)
+		return
+	}
+	response.OK(w, result, message.TeamMemberInvited)
+}
+
+/*
+UpdateMember menangani PUT /api/v1/hoster/team/{id}
+
+Output sukses:
+- 200 OK + data staff terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterTeamHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdateMemberByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("UpdateMember: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+	if req.Role == "" && req.Status == "" {
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	result, err := h.service.UpdateMember(middleware.GetUserID(r), mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("UpdateMember handler: service error: %v", err)
+		writeTeamError(w, err)
+		return
+	}
+	response.OK(w, result, message.TeamMemberUpdated)
+}
+
+/*
+ResendInvite menangani POST /api/v1/hoster/team/{id}/invite
+
+Output sukses:
+- 200 OK + invite_token baru (token lama tidak berlaku)
+Output error:
+- 400 Bad Request (sudah diterima) / 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterTeamHandler) ResendInvite(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.ResendInvite(middleware.GetUserID(r), mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("ResendInvite handler: service error: %v", err)
+		writeTeamError(w, err)
+		return
+	}
+	response.OK(w, result, message.TeamInviteResent)
+}
+
+/*
+RemoveMember menangani DELETE /api/v1/hoster/team/{id}
+
+Output sukses:
+- 200 OK
+Output error:
+- 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterTeamHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RemoveMember(middleware.GetUserID(r), mux.Vars(r)["id"]); err != nil {
+		log.Printf("RemoveMember handler: service error: %v", err)
+		writeTeamError(w, err)
+		return
+	}
+	response.OK(w, nil, message.TeamMemberRemoved)
+}
+
+/*
+AcceptInvite menangani POST /api/v1/hoster/team/accept (publik, tanpa JWT)
+
+Staff membuat password dari token undangan, lalu login lewat /api/v1/auth/login.
+
+Output sukses:
+- 200 OK
+Output error:
+- 400 Bad Request → token salah / kedaluwarsa, password terlalu pendek
+- 500 Internal Server Error
+*/
+func (h *HosterTeamHandler) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+	var req dto.AcceptInviteByMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("AcceptInvite: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	if err := h.service.AcceptInvite(req); err != nil {
+		log.Printf("AcceptInvite handler: service error: %v", err)
+		writeTeamError(w, err)
+		return
+	}
+	response.OK(w, nil, message.TeamInviteAccepted)
+}
+
+/*
+writeTeamError memetakan error service tim toko ke HTTP response.
+*/
+func writeTeamError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.TeamMemberNotFound:
+		response.NotFound(w, message.TeamMemberNotFound)
+	case message.BadRequest,
+		message.EmailAlreadyExists,
+		message.TeamInvalidRole,
+		message.TeamInvalidStatus,
+		message.TeamInviteInvalid,
+		message.TeamInviteNotPending,
+		message.TeamMemberNotActivated,
+		message.TeamPasswordTooShort:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
@@ -0,0 +1,283 @@
+package team
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+HosterTeamRepository mendefinisikan operasi database untuk staff toko (hoster_member).
+*/
+type HosterTeamRepository interface {
+	ListMembers(hosterID string) ([]dto.MemberByHosterResponse, error)
+	IsEmailTaken(email string) (bool, error)
+	CreateMember(m *domain.HosterMember) error
+	GetMember(hosterID, memberID string) (*domain.HosterMember, error)
+	UpdateMember(hosterID, memberID string, role domain.HosterRole, status domain.HosterMemberStatus) error
+	SetInvite(hosterID, memberID, tokenHash string, expiresAt time.Time) error
+	DeleteMember(hosterID, memberID string) error
+	AcceptInvite(email, tokenHash, passwordHash string) error
+	ResolveMember(memberID string) (string, domain.HosterRole, bool, error)
+}
+
+/*
+hosterTeamRepository adalah implementasi repository staff toko.
+*/
+type hosterTeamRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewHosterTeamRepository membuat instance repository dengan koneksi database.
+
+Output:
+- HosterTeamRepository siap digunakan
+*/
+func NewHosterTeamRepository(db *sqlx.DB) HosterTeamRepository {
+	return &hosterTeamRepository{db: db}
+}
+
+/*
+ListMembers mengambil semua staff toko (urut: aktif dulu, lalu nama).
+
+Output sukses:
+- ([]dto.MemberByHosterResponse, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *hosterTeamRepository) ListMembers(hosterID string) ([]dto.MemberByHosterResponse, error) {
+	query := `
+		SELECT id, full_name, email, role, status, invite_expires_at, last_login_at, created_at
+		FROM hoster_member
+		WHERE hoster_id = $1
+		ORDER BY CASE status WHEN 'active' THEN 0 WHEN 'invited' THEN 1 ELSE 2 END, full_name
+	`
+	members := []dto.MemberByHosterResponse{}
+	if err := r.db.Select(&members, query, hosterID); err != nil {
+		log.Printf("ListMembers: db error hoster=%s err=%v", hosterID, err)
+		return nil, err
+	}
+	return members, nil
+}
+
+/*
+IsEmailTaken mengecek apakah email sudah dipakai akun lain (admin, hoster, customer, staff).
+Email login harus unik lintas tabel karena login mencari di semua tabel.
+
+Output:
+- (true, nil) jika email sudah dipakai
+- (false, error) jika query gagal
+*/
+func (r *hosterTeamRepository) IsEmailTaken(email string) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM admin WHERE LOWER(email) = LOWER($1))
+		    OR EXISTS (SELECT 1 FROM hoster WHERE LOWER(email) = LOWER($1))
+		    OR EXISTS (SELECT 1 FROM customer WHERE LOWER(email) = LOWER($1))
+		    OR EXISTS (SELECT 1 FROM hoster_member WHERE LOWER(email) = LOWER($1))
+	`
+	var taken bool
+	if err := r.db.Get(&taken, query, email); err != nil {
+		log.Printf("IsEmailTaken: db error err=%v", err)
+		return false, err
+	}
+	return taken, nil
+}
+
+/*
+CreateMember menyimpan staff baru dengan status invited.
+
+Output sukses:
+- nil (ID, created_at, updated_at terisi)
+Output error:
+- errors.New("duplicate") → email sudah dipakai staff lain
+- error → query gagal
+*/
+func (r *hosterTeamRepository) CreateMember(m *domain.HosterMember) error {
+	query := `
+		INSERT INTO hoster_member (
+			hoster_id, full_name, email, role, status,
+			invite_token_hash, invite_expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(query,
+		m.HosterID, m.FullName, m.Email, m.Role, m.Status,
+		m.InviteTokenHash, m.InviteExpiresAt,
+	).Scan(&m.ID, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New("duplicate")
+		}
+		log.Printf("CreateMember: db error hoster=%s err=%v", m.HosterID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+GetMember mengambil satu staff milik toko.
+
+Output sukses:
+- (*domain.HosterMember, nil)
+Output error:
+- (nil, sql.ErrNoRows) → staff tidak ada / bukan milik toko ini
+- (nil, error) → query gagal
+*/
+func (r *hosterTeamRepository) GetMember(hosterID, memberID string) (*domain.HosterMember, error) {
+	var m domain.HosterMember
+	query := `
+		SELECT id, hoster_id, full_name, email, password_hash, role, status,
+		       invite_token_hash, invite_expires_at, last_login_at, created_at, updated_at
+		FROM hoster_member
+		WHERE id = $1 AND hoster_id = $2
+	`
+	if err := r.db.Get(&m, query, memberID, hosterID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetMember: db error member=%s err=%v", memberID, err)
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+/*
+UpdateMember mengubah role dan status staff.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → staff tidak ditemukan
+- error → query gagal
+*/
+func (r *hosterTeamRepository) UpdateMember(hosterID, memberID string, role domain.HosterRole, status domain.HosterMemberStatus) error {
+	query := `
+		UPDATE hoster_member
+		SET role = $1, status = $2, updated_at = NOW()
+		WHERE id = $3 AND hoster_id = $4
+	`
+	res, err := r.db.Exec(query, role, status, memberID, hosterID)
+	if err != nil {
+		log.Printf("UpdateMember: db error member=%s err=%v", memberID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+/*
+SetInvite mengganti token undangan staff yang belum menerima undangan.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → staff tidak ditemukan / sudah tidak berstatus invited
+- error → query gagal
+*/
+func (r *hosterTeamRepository) SetInvite(hosterID, memberID, tokenHash string, expiresAt time.Time) error {
+	query := `
+		UPDATE hoster_member
+		SET invite_token_hash = $1, invite_expires_at = $2, updated_at = NOW()
+		WHERE id = $3 AND hoster_id = $4 AND status = 'invited'
+	`
+	res, err := r.db.Exec(query, tokenHash, expiresAt, memberID, hosterID)
+	if err != nil {
+		log.Printf("SetInvite: db error member=%s err=%v", memberID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+/*
+DeleteMember menghapus staff dari toko.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → staff tidak ditemukan
+- error → query gagal
+*/
+func (r *hosterTeamRepository) DeleteMember(hosterID, memberID string) error {
+	res, err := r.db.Exec(`DELETE FROM hoster_member WHERE id = $1 AND hoster_id = $2`, memberID, hosterID)
+	if err != nil {
+		log.Printf("DeleteMember: db error member=%s err=%v", memberID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+/*
+AcceptInvite mengaktifkan staff: simpan password dan hapus token undangan.
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → email / token salah, undangan kedaluwarsa, atau sudah diterima
+- error → query gagal
+*/
+func (r *hosterTeamRepository) AcceptInvite(email, tokenHash, passwordHash string) error {
+	query := `
+		UPDATE hoster_member
+		SET password_hash = $1,
+		    status = 'active',
+		    invite_token_hash = NULL,
+		    invite_expires_at = NULL,
+		    updated_at = NOW()
+		WHERE LOWER(email) = LOWER($2)
+		  AND status = 'invited'
+		  AND invite_token_hash = $3
+		  AND invite_expires_at > NOW()
+	`
+	res, err := r.db.Exec(query, passwordHash, email, tokenHash)
+	if err != nil {
+		log.Printf("AcceptInvite: db error err=%v", err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+/*
+ResolveMember memetakan ID staff ke toko dan role terbaru (dipakai middleware.Hoster).
+
+Output sukses:
+- (storeID, role, true, nil) → staff aktif
+- ("", "", false, nil) → staff tidak ada / sudah dinonaktifkan
+Output error:
+- ("", "", false, error) → query gagal
+*/
+func (r *hosterTeamRepository) ResolveMember(memberID string) (string, domain.HosterRole, bool, error) {
+	var row struct {
+		HosterID string                    `db:"hoster_id"`
+		Role     domain.HosterRole         `db:"role"`
+		Status   domain.HosterMemberStatus `db:"status"`
+	}
+	err := r.db.Get(&row, `SELECT hoster_id, role, status FROM hoster_member WHERE id = $1`, memberID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", false, nil
+		}
+		return "", "", false, err
+	}
+	if row.Status != domain.HosterMemberActive {
+		return "", "", false, nil
+	}
+	return row.HosterID, row.Role, true, nil
+}
@@ -0,0 +1,52 @@
+package team
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupTeamRoutes mendaftarkan endpoint tim toko.
+
+Alur kerja:
+1. Daftarkan endpoint publik (tanpa JWT):
+  - POST /api/v1/hoster/team/accept → staff menerima undangan & membuat password
+
+2. Buat subrouter /api/v1/hoster/team dengan middleware JWT → Hoster:
+  - GET    /me           → role & permission user yang sedang login (owner / staff)
+  - GET    ""            → daftar staff (team:manage)
+  - POST   ""            → undang staff (team:manage)
+  - PUT    /{id}         → ubah role / status staff (team:manage)
+  - DELETE /{id}         → hapus staff (team:manage)
+  - POST   /{id}/invite  → kirim ulang undangan (team:manage)
+
+Output:
+- Router terkonfigurasi dengan endpoint tim toko
+*/
+func SetupTeamRoutes(router *mux.Router, h *HosterTeamHandler) {
+	router.HandleFunc("/api/v1/hoster/team/accept", h.AcceptInvite).Methods("POST")
+
+	protected := router.PathPrefix("/api/v1/hoster/team").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	manage := domain.HosterPermTeamManage
+
+	protected.HandleFunc("/me", h.GetAccess).Methods("GET")
+	protected.HandleFunc("", middleware.HosterPermission(manage, h.ListMembers)).Methods("GET")
+	protected.HandleFunc("", middleware.HosterPermission(manage, h.InviteMember)).Methods("POST")
+	protected.HandleFunc("/{id}", middleware.HosterPermission(manage, h.UpdateMember)).Methods("PUT")
+	protected.HandleFunc("/{id}", middleware.HosterPermission(manage, h.RemoveMember)).Methods("DELETE")
+	protected.HandleFunc("/{id}/invite", middleware.HosterPermission(manage, h.ResendInvite)).Methods("POST")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
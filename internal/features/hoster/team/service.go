@@ -0,0 +1,397 @@
+package team
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+HosterTeamService adalah kontrak untuk logika bisnis tim toko.
+*/
+type HosterTeamService interface {
+	GetAccess(storeID, memberID string, role domain.HosterRole) (*dto.TeamAccessByHosterResponse, error)
+	ListMembers(hosterID string) ([]dto.MemberByHosterResponse, error)
+	InviteMember(hosterID string, req dto.InviteMemberByHosterRequest) (*dto.MemberInviteByHosterResponse, error)
+	UpdateMember(hosterID, memberID string, req dto.UpdateMemberByHosterRequest) (*dto.MemberByHosterResponse, error)
+	ResendInvite(hosterID, memberID string) (*dto.MemberInviteByHosterResponse, error)
+	RemoveMember(hosterID, memberID string) error
+	AcceptInvite(req dto.AcceptInviteByMemberRequest) error
+}
+
+/*
+hosterTeamService adalah implementasi service tim toko.
+*/
+type hosterTeamService struct {
+	repo HosterTeamRepository
+}
+
+/*
+NewHosterTeamService membuat instance service dengan dependency injection.
+
+Output:
+- HosterTeamService siap digunakan
+*/
+func NewHosterTeamService(repo HosterTeamRepository) HosterTeamService {
+	return &hosterTeamService{repo: repo}
+}
+
+/*
+Konstanta undangan staff.
+*/
+const (
+	InviteTTL         = 7 * 24 * time.Hour // Undangan berlaku 7 hari
+	MinPasswordLength = 8
+	inviteTokenBytes  = 24
+)
+
+/*
+EmailPattern adalah format email yang diterima untuk undangan staff (sama dengan validasi login).
+*/
+var EmailPattern = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+/*
+GetAccess mengembalikan identitas & daftar permission user toko yang sedang login.
+
+Output sukses:
+- (*dto.TeamAccessByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized
+*/
+func (s *hosterTeamService) GetAccess(storeID, memberID string, role domain.HosterRole) (*dto.TeamAccessByHosterResponse, error) {
+	if storeID == "" || role == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	perms := role.Permissions()
+	names := make([]string, 0, len(perms))
+	for _, p := range perms {
+		names = append(names, string(p))
+	}
+
+	return &dto.TeamAccessByHosterResponse{
+		StoreID:     storeID,
+		MemberID:    memberID,
+		Role:        string(role),
+		Permissions: names,
+	}, nil
+}
+
+/*
+ListMembers mengambil semua staff toko.
+
+Output sukses:
+- ([]dto.MemberByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / internal error
+*/
+func (s *hosterTeamService) ListMembers(hosterID string) ([]dto.MemberByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	members, err := s.repo.ListMembers(hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return members, nil
+}
+
+/*
+InviteMember mengundang staff baru ke toko.
+
+Alur kerja:
+1. Validasi nama, email, dan role (hanya manager / staff, owner tidak bisa diundang)
+2. Pastikan email belum dipakai akun lain (admin, hoster, customer, staff)
+3. Generate token undangan acak, simpan hash SHA-256 + masa berlaku 7 hari
+4. Kembalikan token asli (dev mode, seperti OTP)
+
+Output sukses:
+- (*dto.MemberInviteByHosterResponse, nil)
+Output error:
+- (nil, error) → validasi / EmailAlreadyExists / internal error
+*/
+func (s *hosterTeamService) InviteMember(hosterID string, req dto.InviteMemberByHosterRequest) (*dto.MemberInviteByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	fullName := strings.TrimSpace(req.FullName)
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	role := domain.HosterRole(strings.TrimSpace(req.Role))
+
+	if fullName == "" || !EmailPattern.MatchString(email) {
+		return nil, errors.New(message.BadRequest)
+	}
+	if !role.IsAssignable() {
+		return nil, errors.New(message.TeamInvalidRole)
+	}
+
+	taken, err := s.repo.IsEmailTaken(email)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if taken {
+		return nil, errors.New(message.EmailAlreadyExists)
+	}
+
+	token, tokenHash, err := newInviteToken()
+	if err != nil {
+		log.Printf("InviteMember(team service): failed to generate token: %v", err)
+		return nil, errors.New(message.InternalError)
+	}
+	expiresAt := time.Now().Add(InviteTTL)
+
+	member := &domain.HosterMember{
+		HosterID:        hosterID,
+		FullName:        fullName,
+		Email:           email,
+		Role:            role,
+		Status:          domain.HosterMemberInvited,
+		InviteTokenHash: &tokenHash,
+		InviteExpiresAt: &expiresAt,
+	}
+	if err := s.repo.CreateMember(member); err != nil {
+		if err.Error() == "duplicate" {
+			return nil, errors.New(message.EmailAlreadyExists)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("InviteMember(team service): hoster %s invited %s as %s", hosterID, email, role)
+	return &dto.MemberInviteByHosterResponse{
+		Member:          toMemberResponse(member),
+		InviteToken:     token,
+		InviteExpiresAt: expiresAt,
+	}, nil
+}
+
+/*
+UpdateMember mengubah role dan/atau status staff.
+
+Alur kerja:
+1. Ambil staff (harus milik toko ini)
+2. Validasi role (manager / staff) dan status (active / disabled)
+3. Staff yang belum menerima undangan tidak bisa langsung diaktifkan
+4. Simpan perubahan (berlaku di request berikutnya karena middleware membaca role terbaru)
+
+Output sukses:
+- (*dto.MemberByHosterResponse, nil)
+Output error:
+- (nil, error) → TeamMemberNotFound / TeamInvalidRole / TeamInvalidStatus / TeamMemberNotActivated / internal error
+*/
+func (s *hosterTeamService) UpdateMember(hosterID, memberID string, req dto.UpdateMemberByHosterRequest) (*dto.MemberByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	member, err := s.getMember(hosterID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	role := member.Role
+	if req.Role != "" {
+		role = domain.HosterRole(req.Role)
+		if !role.IsAssignable() {
+			return nil, errors.New(message.TeamInvalidRole)
+		}
+	}
+
+	status := member.Status
+	if req.Status != "" {
+		status = domain.HosterMemberStatus(req.Status)
+		if status != domain.HosterMemberActive && status != domain.HosterMemberDisabled {
+			return nil, errors.New(message.TeamInvalidStatus)
+		}
+		if status == domain.HosterMemberActive && member.PasswordHash == nil {
+			return nil, errors.New(message.TeamMemberNotActivated)
+		}
+	}
+
+	if err := s.repo.UpdateMember(hosterID, memberID, role, status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.TeamMemberNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	member.Role = role
+	member.Status = status
+	resp := toMemberResponse(member)
+	return &resp, nil
+}
+
+/*
+ResendInvite membuat token undangan baru untuk staff yang belum menerima undangan.
+Token lama otomatis tidak berlaku.
+
+Output sukses:
+- (*dto.MemberInviteByHosterResponse, nil)
+Output error:
+- (nil, error) → TeamMemberNotFound / TeamInviteNotPending / internal error
+*/
+func (s *hosterTeamService) ResendInvite(hosterID, memberID string) (*dto.MemberInviteByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	member, err := s.getMember(hosterID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Status != domain.HosterMemberInvited {
+		return nil, errors.New(message.TeamInviteNotPending)
+	}
+
+	token, tokenHash, err := newInviteToken()
+	if err != nil {
+		log.Printf("ResendInvite(team service): failed to generate token: %v", err)
+		return nil, errors.New(message.InternalError)
+	}
+	expiresAt := time.Now().Add(InviteTTL)
+
+	if err := s.repo.SetInvite(hosterID, memberID, tokenHash, expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.TeamInviteNotPending)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	member.InviteExpiresAt = &expiresAt
+	return &dto.MemberInviteByHosterResponse{
+		Member:          toMemberResponse(member),
+		InviteToken:     token,
+		InviteExpiresAt: expiresAt,
+	}, nil
+}
+
+/*
+RemoveMember menghapus staff dari toko. Token staff langsung tidak berlaku.
+
+Output sukses:
+- nil
+Output error:
+- error → TeamMemberNotFound / internal error
+*/
+func (s *hosterTeamService) RemoveMember(hosterID, memberID string) error {
+	if hosterID == "" {
+		return errors.New(message.Unauthorized)
+	}
+
+	if err := s.repo.DeleteMember(hosterID, memberID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.TeamMemberNotFound)
+		}
+		return errors.New(message.InternalError)
+	}
+
+	log.Printf("RemoveMember(team service): hoster %s removed member %s", hosterID, memberID)
+	return nil
+}
+
+/*
+AcceptInvite mengaktifkan akun staff dengan token undangan dan password baru.
+
+Alur kerja:
+1. Validasi email, token, dan panjang password (minimal 8 karakter)
+2. Hash password (bcrypt)
+3. Aktifkan staff jika email + hash token cocok dan undangan belum kedaluwarsa
+
+Output sukses:
+- nil → staff bisa login lewat /auth/login
+Output error:
+- error → BadRequest / TeamPasswordTooShort / TeamInviteInvalid / internal error
+*/
+func (s *hosterTeamService) AcceptInvite(req dto.AcceptInviteByMemberRequest) error {
+	email := strings.TrimSpace(req.Email)
+	token := strings.TrimSpace(req.Token)
+	if email == "" || token == "" {
+		return errors.New(message.BadRequest)
+	}
+	if len(req.Password) < MinPasswordLength {
+		return errors.New(message.TeamPasswordTooShort)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New(message.InternalError)
+	}
+
+	if err := s.repo.AcceptInvite(email, hashInviteToken(token), string(hash)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New(message.TeamInviteInvalid)
+		}
+		return errors.New(message.InternalError)
+	}
+
+	log.Printf("AcceptInvite(team service): invitation accepted by %s", email)
+	return nil
+}
+
+/*
+getMember mengambil staff milik toko dan memetakan error repository.
+*/
+func (s *hosterTeamService) getMember(hosterID, memberID string) (*domain.HosterMember, error) {
+	if memberID == "" {
+		return nil, errors.New(message.TeamMemberNotFound)
+	}
+
+	member, err := s.repo.GetMember(hosterID, memberID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.TeamMemberNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	return member, nil
+}
+
+/*
+toMemberResponse memetakan domain.HosterMember ke DTO (tanpa hash password / token).
+*/
+func toMemberResponse(m *domain.HosterMember) dto.MemberByHosterResponse {
+	return dto.MemberByHosterResponse{
+		ID:              m.ID,
+		FullName:        m.FullName,
+		Email:           m.Email,
+		Role:            string(m.Role),
+		Status:          string(m.Status),
+		InviteExpiresAt: m.InviteExpiresAt,
+		LastLoginAt:     m.LastLoginAt,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+/*
+newInviteToken membuat token undangan acak (base64url) beserta hash-nya.
+*/
+func newInviteToken() (string, string, error) {
+	raw := make([]byte, inviteTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashInviteToken(token), nil
+}
+
+/*
+hashInviteToken mengembalikan SHA-256 (hex) dari token undangan.
+*/
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
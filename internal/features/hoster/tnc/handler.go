@@ -64,14 +64,18 @@ func (h *HosterTnCHandler) CreateTnC(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.service.CreateTnC(hosterID, &req)
+	result, err := h.service.CreateTnC(hosterID, middleware.GetMemberID(r), &req)
 	if err != nil {
 		log.Printf("CreateTnC handler: service error hoster=%s err=%v", hosterID, err)
 		switch err.Error() {
-		case message.BadRequest:
-			response.BadRequest(w, message.BadRequest)
+		case message.BadRequest, message.TnCInvalidScope:
+			response.BadRequest(w, err.Error())
 		case message.Unauthorized:
 			response.Unauthorized(w, message.Unauthorized)
+		case message.StoreNotFound, message.ItemNotFound:
+			response.NotFound(w, err.Error())
+		case message.TnCAlreadyExists:
+			response.Error(w, http.StatusConflict, err.Error())
 		default:
 			response.Error(w, http.StatusInternalServerError, message.InternalError)
 		}
@@ -123,7 +127,7 @@ func (h *HosterTnCHandler) UpdateTnC(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.service.UpdateTnC(hosterID, tncID, &req)
+	result, err := h.service.UpdateTnC(hosterID, middleware.GetMemberID(r), tncID, &req)
 	if err != nil {
 		log.Printf("UpdateTnC handler: service error hoster=%s tnc=%s err=%v", hosterID, tncID, err)
 		switch err.Error() {
@@ -131,7 +135,7 @@ func (h *HosterTnCHandler) UpdateTnC(w http.ResponseWriter, r *http.Request) {
 			response.BadRequest(w, message.BadRequest)
 		case message.Unauthorized:
 			response.Unauthorized(w, message.Unauthorized)
-		case message.NotFound:
+		case message.TnCNotFound:
 			response.NotFound(w, message.TnCNotFound)
 		default:
 			response.Error(w, http.StatusInternalServerError, message.InternalError)
@@ -148,8 +152,9 @@ GetTnC menangani GET /api/v1/hoster/tnc
 Alur kerja:
 1. Validasi method GET
 2. Ambil userID dari JWT context
-3. Panggil service
-4. Return response
+3. Ambil query param opsional store_id / item_id (keduanya kosong = T&C umum hoster)
+4. Panggil service
+5. Return response
 
 Output sukses:
 - 200 OK + T&C data
@@ -168,14 +173,17 @@ func (h *HosterTnCHandler) GetTnC(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.service.GetTnC(hosterID)
+	query := r.URL.Query()
+	result, err := h.service.GetTnC(hosterID, query.Get("store_id"), query.Get("item_id"))
 	if err != nil {
 		log.Printf("GetTnC handler: service error hoster=%s err=%v", hosterID, err)
 		switch err.Error() {
 		case message.Unauthorized:
 			response.Unauthorized(w, message.Unauthorized)
-		case message.NotFound:
-			response.NotFound(w, message.TnCNotFound)
+		case message.TnCInvalidScope:
+			response.BadRequest(w, err.Error())
+		case message.StoreNotFound, message.ItemNotFound, message.TnCNotFound:
+			response.NotFound(w, err.Error())
 		default:
 			response.Error(w, http.StatusInternalServerError, message.InternalError)
 		}
@@ -184,3 +192,35 @@ func (h *HosterTnCHandler) GetTnC(w http.ResponseWriter, r *http.Request) {
 
 	response.OK(w, result, message.TnCRetrieved)
 }
+
+/*
+GetTnCVersions menangani GET /api/v1/hoster/tnc/{id}/versions
+
+Output sukses:
+- 200 OK + riwayat versi T&C (terbaru dulu)
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterTnCHandler) GetTnCVersions(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	versions, err := h.service.GetTnCVersions(hosterID, mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetTnCVersions handler: service error hoster=%s err=%v", hosterID, err)
+		switch err.Error() {
+		case message.Unauthorized:
+			response.Unauthorized(w, message.Unauthorized)
+		case message.TnCNotFound:
+			response.NotFound(w, message.TnCNotFound)
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+
+	response.OK(w, versions, message.TnCVersionsRetrieved)
+}
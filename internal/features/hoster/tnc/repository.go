@@ -3,9 +3,12 @@ package tnc
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"log"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"lalan-be/internal/domain"
 	"lalan-be/internal/dto"
@@ -15,9 +18,13 @@ import (
 TnCRepository mendefinisikan operasi database untuk Terms and Conditions.
 */
 type TnCRepository interface {
-	CreateTnC(tnc *domain.TermsAndConditions) error
-	UpdateTnC(tncID, hosterID string, description []string) error
-	GetTnCByHosterID(hosterID string) (*dto.TnCResponse, error)
+	CreateTnC(tnc *domain.TermsAndConditions, memberID *string) error
+	UpdateTnC(tncID, hosterID string, memberID *string, description []string) error
+	GetTnCByHosterID(hosterID, storeID, itemID string) (*dto.TnCResponse, error)
+	GetTnCByID(tncID, hosterID string) (*dto.TnCResponse, error)
+	GetTnCVersions(tncID, hosterID string) ([]dto.TnCVersionResponse, error)
+	StoreExists(hosterID, storeID string) (bool, error)
+	ItemExists(hosterID, itemID string) (bool, error)
 }
 
 /*
@@ -38,85 +45,155 @@ func NewTnCRepository(db *sqlx.DB) TnCRepository {
 }
 
 /*
-CreateTnC menyimpan T&C baru ke database.
+tncColumns adalah kolom T&C beserta versi terbarunya (alias t = tnc, v = tnc_version).
+*/
+const tncColumns = `
+	t.id, t.hoster_id, t.tenant_id, t.item_id, t.description, t.created_at, t.updated_at,
+	COALESCE(v.id::text, '') AS version_id, COALESCE(v.version, 0) AS version
+`
+
+/*
+CreateTnC menyimpan T&C baru ke database beserta versi pertamanya.
 
 Alur kerja:
 1. Marshal description array ke JSONB
-2. Insert ke tabel tnc
-3. Return error jika gagal
+2. Insert ke tabel tnc lalu insert tnc_version (version 1) dalam satu transaksi
+3. Tandai versi tersebut sebagai versi terbaru (current_version_id)
 
 Output sukses:
 - nil
 Output error:
+- errors.New("exists") → scope (umum / store / item) sudah punya T&C
 - error → insert gagal
 */
-func (r *tncRepository) CreateTnC(tnc *domain.TermsAndConditions) error {
+func (r *tncRepository) CreateTnC(tnc *domain.TermsAndConditions, memberID *string) error {
 	descriptionJSON, err := json.Marshal(tnc.Description)
 	if err != nil {
 		log.Printf("CreateTnC: failed to marshal description: %v", err)
 		return err
 	}
 
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("CreateTnC: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO tnc (id, hoster_id, description, created_at, updated_at)
-		VALUES ($1, $2, $3, NOW(), NOW())
+		INSERT INTO tnc (id, hoster_id, tenant_id, item_id, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
 	`
-
-	_, err = r.db.Exec(query, tnc.ID, tnc.UserID, descriptionJSON)
+	_, err = tx.Exec(query, tnc.ID, tnc.UserID, tnc.TenantID, tnc.ItemID, descriptionJSON)
 	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New("exists")
+		}
 		log.Printf("CreateTnC: error inserting tnc %s: %v", tnc.ID, err)
 		return err
 	}
 
+	if err := insertVersion(tx, tnc.ID, memberID, descriptionJSON); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("CreateTnC: error committing transaction: %v", err)
+		return err
+	}
 	return nil
 }
 
 /*
-UpdateTnC memperbarui description T&C.
+UpdateTnC membuat versi baru T&C. Versi lama tidak diubah.
 
 Alur kerja:
 1. Marshal description array ke JSONB
-2. Update dengan filter tncID dan userID (ownership check)
-3. Return error jika tidak ada row yang di-update
+2. Kunci tnc dengan filter tncID dan hosterID (ownership check)
+3. Insert tnc_version (version + 1), salin description & current_version_id ke tnc
 
 Output sukses:
 - nil
 Output error:
-- error → update gagal atau TnC tidak ditemukan
+- sql.ErrNoRows → TnC tidak ditemukan
+- error → update gagal
 */
-func (r *tncRepository) UpdateTnC(tncID, hosterID string, description []string) error {
+func (r *tncRepository) UpdateTnC(tncID, hosterID string, memberID *string, description []string) error {
 	descriptionJSON, err := json.Marshal(description)
 	if err != nil {
 		log.Printf("UpdateTnC: failed to marshal description: %v", err)
 		return err
 	}
 
-	query := `
-		UPDATE tnc
-		SET description = $1, updated_at = NOW()
-		WHERE id = $2 AND hoster_id = $3
-	`
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("UpdateTnC: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
 
-	res, err := r.db.Exec(query, descriptionJSON, tncID, hosterID)
+	var id string
+	err = tx.Get(&id, `SELECT id FROM tnc WHERE id = $1 AND hoster_id = $2 FOR UPDATE`, tncID, hosterID)
 	if err != nil {
-		log.Printf("UpdateTnC: error updating tnc %s: %v", tncID, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("UpdateTnC: no tnc %s for hoster %s", tncID, hosterID)
+		} else {
+			log.Printf("UpdateTnC: error locking tnc %s: %v", tncID, err)
+		}
 		return err
 	}
 
-	rows, _ := res.RowsAffected()
-	if rows == 0 {
-		log.Printf("UpdateTnC: no rows affected for tnc %s hoster %s", tncID, hosterID)
-		return sql.ErrNoRows
+	if err := insertVersion(tx, tncID, memberID, descriptionJSON); err != nil {
+		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		log.Printf("UpdateTnC: error committing transaction: %v", err)
+		return err
+	}
 	return nil
 }
 
 /*
-GetTnCByHosterID mengambil T&C berdasarkan hoster_id.
+insertVersion menambah versi baru T&C lalu menjadikannya versi terbaru di tnc.
+Dipanggil saat tnc sudah terkunci (baru dibuat atau FOR UPDATE) sehingga nomor versi aman.
+*/
+func insertVersion(tx *sqlx.Tx, tncID string, memberID *string, descriptionJSON []byte) error {
+	var versionID string
+	err := tx.Get(&versionID, `
+		INSERT INTO tnc_version (tnc_id, hoster_id, tenant_id, item_id, version, description, created_by_member_id)
+		SELECT t.id, t.hoster_id, t.tenant_id, t.item_id,
+		       COALESCE((SELECT MAX(version) FROM tnc_version WHERE tnc_id = t.id), 0) + 1,
+		       $2, $3
+		FROM tnc t
+		WHERE t.id = $1
+		RETURNING id
+	`, tncID, descriptionJSON, memberID)
+	if err != nil {
+		log.Printf("insertVersion: error inserting version for tnc %s: %v", tncID, err)
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE tnc
+		SET description = $2, current_version_id = $3, updated_at = NOW()
+		WHERE id = $1
+	`, tncID, descriptionJSON, versionID)
+	if err != nil {
+		log.Printf("insertVersion: error updating tnc %s: %v", tncID, err)
+	}
+	return err
+}
+
+/*
+GetTnCByHosterID mengambil T&C berdasarkan hoster_id dan scope.
 
 Alur kerja:
-1. Query tnc dengan filter hoster_id
+1. Query tnc dengan filter hoster_id, tenant_id, dan item_id
+  - storeID & itemID kosong → T&C umum (tenant_id & item_id NULL)
+  - storeID diisi → T&C khusus store
+  - itemID diisi → T&C khusus item
+
 2. Unmarshal description dari JSONB
 3. Map ke DTO response
 
@@ -125,31 +202,131 @@ Output sukses:
 Output error:
 - (nil, error) → TnC tidak ditemukan
 */
-func (r *tncRepository) GetTnCByHosterID(hosterID string) (*dto.TnCResponse, error) {
+func (r *tncRepository) GetTnCByHosterID(hosterID, storeID, itemID string) (*dto.TnCResponse, error) {
+	query := `
+		SELECT ` + tncColumns + `
+		FROM tnc t
+		LEFT JOIN tnc_version v ON v.id = t.current_version_id
+		WHERE t.hoster_id = $1
+		  AND t.tenant_id IS NOT DISTINCT FROM NULLIF($2, '')::uuid
+		  AND t.item_id IS NOT DISTINCT FROM NULLIF($3, '')::uuid
+		LIMIT 1
+	`
+	return r.getTnC("GetTnCByHosterID", query, hosterID, storeID, itemID)
+}
+
+/*
+GetTnCByID mengambil satu T&C milik hoster berdasarkan ID.
+
+Output sukses:
+- (*dto.TnCResponse, nil)
+Output error:
+- (nil, error) → TnC tidak ditemukan
+*/
+func (r *tncRepository) GetTnCByID(tncID, hosterID string) (*dto.TnCResponse, error) {
+	query := `
+		SELECT ` + tncColumns + `
+		FROM tnc t
+		LEFT JOIN tnc_version v ON v.id = t.current_version_id
+		WHERE t.id = $1 AND t.hoster_id = $2
+	`
+	return r.getTnC("GetTnCByID", query, tncID, hosterID)
+}
+
+/*
+GetTnCVersions mengambil seluruh versi T&C milik hoster (terbaru dulu).
+
+Output sukses:
+- ([]dto.TnCVersionResponse, nil)
+Output error:
+- (nil, error) → query / unmarshal gagal
+*/
+func (r *tncRepository) GetTnCVersions(tncID, hosterID string) ([]dto.TnCVersionResponse, error) {
+	var rows []struct {
+		dto.TnCVersionResponse
+		Description pq.StringArray `db:"description"`
+	}
+	err := r.db.Select(&rows, `
+		SELECT id, version, tenant_id, item_id, created_by_member_id, created_at,
+		       ARRAY(SELECT jsonb_array_elements_text(description)) AS description
+		FROM tnc_version
+		WHERE tnc_id = $1 AND hoster_id = $2
+		ORDER BY version DESC
+	`, tncID, hosterID)
+	if err != nil {
+		log.Printf("GetTnCVersions: query error tnc=%s err=%v", tncID, err)
+		return nil, err
+	}
+
+	versions := make([]dto.TnCVersionResponse, len(rows))
+	for i, row := range rows {
+		versions[i] = row.TnCVersionResponse
+		versions[i].Description = row.Description
+	}
+	return versions, nil
+}
+
+/*
+StoreExists mengecek apakah store (tenant) milik hoster.
+
+Output:
+- (true, nil) jika store milik hoster
+- (false, nil) jika tidak ada
+- (false, error) jika query gagal
+*/
+func (r *tncRepository) StoreExists(hosterID, storeID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM tenant WHERE id = $1 AND hoster_id = $2)`
+	if err := r.db.Get(&exists, query, storeID, hosterID); err != nil {
+		log.Printf("StoreExists: db error hoster=%s store=%s err=%v", hosterID, storeID, err)
+		return false, err
+	}
+	return exists, nil
+}
+
+/*
+ItemExists mengecek apakah item milik hoster.
+
+Output:
+- (true, nil) jika item milik hoster
+- (false, nil) jika tidak ada
+- (false, error) jika query gagal
+*/
+func (r *tncRepository) ItemExists(hosterID, itemID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM item WHERE id = $1 AND hoster_id = $2)`
+	if err := r.db.Get(&exists, query, itemID, hosterID); err != nil {
+		log.Printf("ItemExists: db error hoster=%s item=%s err=%v", hosterID, itemID, err)
+		return false, err
+	}
+	return exists, nil
+}
+
+/*
+getTnC menjalankan query satu baris T&C lalu memetakan ke DTO response.
+*/
+func (r *tncRepository) getTnC(caller, query string, args ...interface{}) (*dto.TnCResponse, error) {
 	var (
 		response dto.TnCResponse
 		row      struct {
 			ID          string          `db:"id"`
 			HosterID    string          `db:"hoster_id"`
+			TenantID    sql.NullString  `db:"tenant_id"`
+			ItemID      sql.NullString  `db:"item_id"`
+			VersionID   string          `db:"version_id"`
+			Version     int             `db:"version"`
 			Description json.RawMessage `db:"description"`
 			CreatedAt   sql.NullTime    `db:"created_at"`
 			UpdatedAt   sql.NullTime    `db:"updated_at"`
 		}
 	)
 
-	query := `
-		SELECT id, hoster_id, description, created_at, updated_at
-		FROM tnc
-		WHERE hoster_id = $1
-		LIMIT 1
-	`
-
-	err := r.db.Get(&row, query, hosterID)
+	err := r.db.Get(&row, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Printf("GetTnCByHosterID: tnc not found for hoster %s", hosterID)
+			log.Printf("%s: tnc not found for %v", caller, args)
 		} else {
-			log.Printf("GetTnCByHosterID: database error for hoster %s: %v", hosterID, err)
+			log.Printf("%s: database error for %v: %v", caller, args, err)
 		}
 		return nil, err
 	}
@@ -158,7 +335,7 @@ func (r *tncRepository) GetTnCByHosterID(hosterID string) (*dto.TnCResponse, err
 	if len(row.Description) > 0 {
 		var description []string
 		if err = json.Unmarshal(row.Description, &description); err != nil {
-			log.Printf("GetTnCByHosterID: failed to unmarshal description: %v", err)
+			log.Printf("%s: failed to unmarshal description: %v", caller, err)
 			return nil, err
 		}
 		response.Description = description
@@ -167,6 +344,14 @@ func (r *tncRepository) GetTnCByHosterID(hosterID string) (*dto.TnCResponse, err
 	// Map fields
 	response.ID = row.ID
 	response.HosterID = row.HosterID
+	response.VersionID = row.VersionID
+	response.Version = row.Version
+	if row.TenantID.Valid {
+		response.StoreID = &row.TenantID.String
+	}
+	if row.ItemID.Valid {
+		response.ItemID = &row.ItemID.String
+	}
 	if row.CreatedAt.Valid {
 		response.CreatedAt = row.CreatedAt.Time
 	}
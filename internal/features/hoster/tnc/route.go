@@ -5,6 +5,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"lalan-be/internal/domain"
 	"lalan-be/internal/middleware"
 )
 
@@ -15,9 +16,10 @@ Alur kerja:
 1. Buat subrouter dengan prefix /api/v1/hoster
 2. Terapkan middleware JWT → Hoster (protected route)
 3. Daftarkan endpoint:
-  - GET  /tnc      → tampilkan T&C milik hoster
-  - POST /tnc      → buat T&C baru oleh hoster
-  - PUT  /tnc/{id} → update T&C milik hoster berdasarkan ID
+  - GET  /tnc                → tampilkan T&C milik hoster (?store_id= / ?item_id= untuk T&C khusus)
+  - POST /tnc                → buat T&C baru oleh hoster (umum, khusus store, atau khusus item)
+  - PUT  /tnc/{id}           → terbitkan versi baru T&C milik hoster berdasarkan ID
+  - GET  /tnc/{id}/versions  → riwayat versi T&C (versi lama tidak pernah diubah)
 
 Output:
 - Router terkonfigurasi dengan endpoint hoster yang aman dan siap digunakan
@@ -30,9 +32,10 @@ func SetupTnCRoutes(router *mux.Router, h *HosterTnCHandler) {
 	protected.Use(middleware.Hoster)
 
 	// Route normal (use singular "tnc")
-	protected.HandleFunc("/tnc", h.GetTnC).Methods("GET", "OPTIONS")
-	protected.HandleFunc("/tnc", h.CreateTnC).Methods("POST", "OPTIONS")
-	protected.HandleFunc("/tnc/{id}", h.UpdateTnC).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/tnc", middleware.HosterPermission(domain.HosterPermItemsView, h.GetTnC)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/tnc", middleware.HosterPermission(domain.HosterPermStoreManage, h.CreateTnC)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/tnc/{id}", middleware.HosterPermission(domain.HosterPermStoreManage, h.UpdateTnC)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/tnc/{id}/versions", middleware.HosterPermission(domain.HosterPermItemsView, h.GetTnCVersions)).Methods("GET", "OPTIONS")
 
 	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
 	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
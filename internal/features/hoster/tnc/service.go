@@ -16,9 +16,10 @@ import (
 TnCService adalah kontrak untuk logika bisnis T&C.
 */
 type TnCService interface {
-	CreateTnC(hosterID string, req *dto.CreateTnCRequest) (*dto.TnCResponse, error)
-	UpdateTnC(hosterID, tncID string, req *dto.UpdateTnCRequest) (*dto.TnCResponse, error)
-	GetTnC(hosterID string) (*dto.TnCResponse, error)
+	CreateTnC(hosterID, memberID string, req *dto.CreateTnCRequest) (*dto.TnCResponse, error)
+	UpdateTnC(hosterID, memberID, tncID string, req *dto.UpdateTnCRequest) (*dto.TnCResponse, error)
+	GetTnC(hosterID, storeID, itemID string) (*dto.TnCResponse, error)
+	GetTnCVersions(hosterID, tncID string) ([]dto.TnCVersionResponse, error)
 }
 
 /*
@@ -43,16 +44,18 @@ CreateTnC membuat T&C baru untuk hoster.
 
 Alur kerja:
 1. Validasi userID dan description tidak kosong
-2. Build domain entity
-3. Panggil repository
-4. Return response
+2. store_id (opsional) harus store milik hoster → T&C khusus store
+3. item_id (opsional) harus item milik hoster → T&C khusus item
+4. Build domain entity
+5. Panggil repository (sekaligus membuat versi 1)
+6. Return response
 
 Output sukses:
 - (*dto.TnCResponse, nil)
 Output error:
-- (nil, error) → unauthorized / bad request / internal error
+- (nil, error) → unauthorized / bad request / TnCInvalidScope / StoreNotFound / ItemNotFound / TnCAlreadyExists / internal error
 */
-func (s *tncService) CreateTnC(hosterID string, req *dto.CreateTnCRequest) (*dto.TnCResponse, error) {
+func (s *tncService) CreateTnC(hosterID, memberID string, req *dto.CreateTnCRequest) (*dto.TnCResponse, error) {
 	if hosterID == "" {
 		return nil, errors.New(message.Unauthorized)
 	}
@@ -61,21 +64,40 @@ func (s *tncService) CreateTnC(hosterID string, req *dto.CreateTnCRequest) (*dto
 		return nil, errors.New(message.BadRequest)
 	}
 
+	if req.StoreID != "" && req.ItemID != "" {
+		return nil, errors.New(message.TnCInvalidScope)
+	}
+	if err := s.checkStore(hosterID, req.StoreID); err != nil {
+		return nil, err
+	}
+	if err := s.checkItem(hosterID, req.ItemID); err != nil {
+		return nil, err
+	}
+
 	// Build entity
 	tnc := &domain.TermsAndConditions{
 		ID:          uuid.New().String(),
 		UserID:      hosterID,
 		Description: req.Description,
 	}
+	if req.StoreID != "" {
+		tnc.TenantID = &req.StoreID
+	}
+	if req.ItemID != "" {
+		tnc.ItemID = &req.ItemID
+	}
 
 	// Save to DB
-	if err := s.repo.CreateTnC(tnc); err != nil {
+	if err := s.repo.CreateTnC(tnc, optional(memberID)); err != nil {
+		if err.Error() == "exists" {
+			return nil, errors.New(message.TnCAlreadyExists)
+		}
 		log.Printf("CreateTnC service: repo error for hoster %s: %v", hosterID, err)
 		return nil, errors.New(message.InternalError)
 	}
 
 	// Get created TnC
-	created, err := s.repo.GetTnCByHosterID(hosterID)
+	created, err := s.repo.GetTnCByID(tnc.ID, hosterID)
 	if err != nil {
 		log.Printf("CreateTnC service: failed to get created tnc for hoster %s: %v", hosterID, err)
 		return nil, errors.New(message.InternalError)
@@ -85,11 +107,11 @@ func (s *tncService) CreateTnC(hosterID string, req *dto.CreateTnCRequest) (*dto
 }
 
 /*
-UpdateTnC memperbarui T&C hoster.
+UpdateTnC menerbitkan versi baru T&C hoster. Versi lama tetap tersimpan untuk booking yang sudah menyetujuinya.
 
 Alur kerja:
 1. Validasi userID, tncID, dan description tidak kosong
-2. Panggil repository update
+2. Panggil repository (insert versi baru)
 3. Get updated data
 4. Return response
 
@@ -98,7 +120,7 @@ Output sukses:
 Output error:
 - (nil, error) → unauthorized / bad request / not found / internal error
 */
-func (s *tncService) UpdateTnC(hosterID, tncID string, req *dto.UpdateTnCRequest) (*dto.TnCResponse, error) {
+func (s *tncService) UpdateTnC(hosterID, memberID, tncID string, req *dto.UpdateTnCRequest) (*dto.TnCResponse, error) {
 	if hosterID == "" {
 		return nil, errors.New(message.Unauthorized)
 	}
@@ -106,9 +128,12 @@ func (s *tncService) UpdateTnC(hosterID, tncID string, req *dto.UpdateTnCRequest
 	if tncID == "" || req == nil || len(req.Description) == 0 {
 		return nil, errors.New(message.BadRequest)
 	}
+	if _, err := uuid.Parse(tncID); err != nil {
+		return nil, errors.New(message.TnCNotFound)
+	}
 
 	// Update in DB
-	if err := s.repo.UpdateTnC(tncID, hosterID, req.Description); err != nil {
+	if err := s.repo.UpdateTnC(tncID, hosterID, optional(memberID), req.Description); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New(message.TnCNotFound)
 		}
@@ -117,7 +142,7 @@ func (s *tncService) UpdateTnC(hosterID, tncID string, req *dto.UpdateTnCRequest
 	}
 
 	// Get updated TnC
-	updated, err := s.repo.GetTnCByHosterID(hosterID)
+	updated, err := s.repo.GetTnCByID(tncID, hosterID)
 	if err != nil {
 		log.Printf("UpdateTnC service: failed to get updated tnc for hoster %s: %v", hosterID, err)
 		return nil, errors.New(message.InternalError)
@@ -131,20 +156,30 @@ GetTnC mengambil T&C berdasarkan hosterID.
 
 Alur kerja:
 1. Validasi hosterID tidak kosong
-2. Panggil repository
-3. Return response
+2. storeID & itemID kosong → T&C umum hoster, storeID diisi → T&C khusus store, itemID diisi → T&C khusus item
+3. Panggil repository
+4. Return response
 
 Output sukses:
 - (*dto.TnCResponse, nil)
 Output error:
-- (nil, error) → unauthorized / not found / internal error
+- (nil, error) → unauthorized / TnCInvalidScope / StoreNotFound / ItemNotFound / not found / internal error
 */
-func (s *tncService) GetTnC(hosterID string) (*dto.TnCResponse, error) {
+func (s *tncService) GetTnC(hosterID, storeID, itemID string) (*dto.TnCResponse, error) {
 	if hosterID == "" {
 		return nil, errors.New(message.Unauthorized)
 	}
+	if storeID != "" && itemID != "" {
+		return nil, errors.New(message.TnCInvalidScope)
+	}
+	if err := s.checkStore(hosterID, storeID); err != nil {
+		return nil, err
+	}
+	if err := s.checkItem(hosterID, itemID); err != nil {
+		return nil, err
+	}
 
-	tnc, err := s.repo.GetTnCByHosterID(hosterID)
+	tnc, err := s.repo.GetTnCByHosterID(hosterID, storeID, itemID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New(message.TnCNotFound)
@@ -155,3 +190,89 @@ func (s *tncService) GetTnC(hosterID string) (*dto.TnCResponse, error) {
 
 	return tnc, nil
 }
+
+/*
+GetTnCVersions mengambil riwayat versi T&C milik hoster (terbaru dulu).
+
+Output sukses:
+- ([]dto.TnCVersionResponse, nil)
+Output error:
+- (nil, error) → unauthorized / not found / internal error
+*/
+func (s *tncService) GetTnCVersions(hosterID, tncID string) ([]dto.TnCVersionResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(tncID); err != nil {
+		return nil, errors.New(message.TnCNotFound)
+	}
+
+	versions, err := s.repo.GetTnCVersions(tncID, hosterID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if len(versions) == 0 {
+		return nil, errors.New(message.TnCNotFound)
+	}
+	return versions, nil
+}
+
+/*
+checkStore memastikan store_id (jika diisi) adalah store milik hoster.
+
+Output:
+- nil jika kosong atau valid
+- error → StoreNotFound / internal error
+*/
+func (s *tncService) checkStore(hosterID, storeID string) error {
+	if storeID == "" {
+		return nil
+	}
+	if _, err := uuid.Parse(storeID); err != nil {
+		return errors.New(message.StoreNotFound)
+	}
+
+	exists, err := s.repo.StoreExists(hosterID, storeID)
+	if err != nil {
+		return errors.New(message.InternalError)
+	}
+	if !exists {
+		return errors.New(message.StoreNotFound)
+	}
+	return nil
+}
+
+/*
+checkItem memastikan item_id (jika diisi) adalah item milik hoster.
+
+Output:
+- nil jika kosong atau valid
+- error → ItemNotFound / internal error
+*/
+func (s *tncService) checkItem(hosterID, itemID string) error {
+	if itemID == "" {
+		return nil
+	}
+	if _, err := uuid.Parse(itemID); err != nil {
+		return errors.New(message.ItemNotFound)
+	}
+
+	exists, err := s.repo.ItemExists(hosterID, itemID)
+	if err != nil {
+		return errors.New(message.InternalError)
+	}
+	if !exists {
+		return errors.New(message.ItemNotFound)
+	}
+	return nil
+}
+
+/*
+optional mengubah string kosong menjadi nil untuk kolom nullable.
+*/
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,231 @@
+package unit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterUnitHandler menangani endpoint HTTP unit fisik (aset) item dari perspektif hoster.
+*/
+type HosterUnitHandler struct {
+	service UnitService
+}
+
+/*
+NewHosterUnitHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterUnitHandler siap digunakan
+*/
+func NewHosterUnitHandler(s UnitService) *HosterUnitHandler {
+	return &HosterUnitHandler{service: s}
+}
+
+/*
+ListUnits menangani GET /api/v1/hoster/item/{id}/units?status=available
+
+Output sukses:
+- 200 OK + { item_id, unit_tracking, stock, units }
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterUnitHandler) ListUnits(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	status := strings.TrimSpace(r.URL.Query().Get("status"))
+	result, err := h.service.ListUnits(hosterID, mux.Vars(r)["id"], status)
+	if err != nil {
+		log.Printf("ListUnits handler: service error hoster=%s err=%v", hosterID, err)
+		writeUnitError(w, err)
+		return
+	}
+	response.OK(w, result, message.UnitRetrieved)
+}
+
+/*
+SetUnitTracking menangani PUT /api/v1/hoster/item/{id}/unit-tracking
+
+Output sukses:
+- 200 OK + daftar unit dan stock terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterUnitHandler) SetUnitTracking(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.SetUnitTrackingByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("SetUnitTracking: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	result, err := h.service.SetUnitTracking(hosterID, mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("SetUnitTracking handler: service error hoster=%s err=%v", hosterID, err)
+		writeUnitError(w, err)
+		return
+	}
+	response.OK(w, result, message.UnitTrackingUpdated)
+}
+
+/*
+CreateUnit menangani POST /api/v1/hoster/item/{id}/units
+
+Output sukses:
+- 200 OK + unit baru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict (label dipakai) / 500 Internal Server Error
+*/
+func (h *HosterUnitHandler) CreateUnit(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.CreateItemUnitByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("CreateUnit: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	unit, err := h.service.CreateUnit(hosterID, mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("CreateUnit handler: service error hoster=%s err=%v", hosterID, err)
+		writeUnitError(w, err)
+		return
+	}
+	response.OK(w, unit, message.UnitCreated)
+}
+
+/*
+UpdateUnit menangani PUT /api/v1/hoster/unit/{id}
+
+Output sukses:
+- 200 OK + unit terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 409 Conflict (label dipakai / unit rented) / 500 Internal Server Error
+*/
+func (h *HosterUnitHandler) UpdateUnit(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.UpdateItemUnitByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("UpdateUnit: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	unit, err := h.service.UpdateUnit(hosterID, mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("UpdateUnit handler: service error hoster=%s err=%v", hosterID, err)
+		writeUnitError(w, err)
+		return
+	}
+	response.OK(w, unit, message.UnitUpdated)
+}
+
+/*
+AddDamageNote menangani POST /api/v1/hoster/unit/{id}/damage
+
+Output sukses:
+- 200 OK + riwayat unit terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterUnitHandler) AddDamageNote(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.CreateUnitDamageNoteByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("AddDamageNote: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	history, err := h.service.AddDamageNote(hosterID, mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("AddDamageNote handler: service error hoster=%s err=%v", hosterID, err)
+		writeUnitError(w, err)
+		return
+	}
+	response.OK(w, history, message.UnitDamageRecorded)
+}
+
+/*
+GetUnitHistory menangani GET /api/v1/hoster/unit/{id}/history
+
+Output sukses:
+- 200 OK + { unit, events }
+Output error:
+- 401 Unauthorized / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterUnitHandler) GetUnitHistory(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	if hosterID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	history, err := h.service.GetUnitHistory(hosterID, mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetUnitHistory handler: service error hoster=%s err=%v", hosterID, err)
+		writeUnitError(w, err)
+		return
+	}
+	response.OK(w, history, message.UnitRetrieved)
+}
+
+/*
+writeUnitError memetakan error service unit ke HTTP response.
+*/
+func writeUnitError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.ItemNotFound, message.UnitNotFound, fmt.Sprintf(message.NotFound, "booking"):
+		response.NotFound(w, err.Error())
+	case message.UnitLabelExists, message.UnitRented, message.UnitTrackingVariants:
+		response.Error(w, http.StatusConflict, err.Error())
+	case message.UnitInvalidCondition,
+		message.UnitInvalidStatus,
+		fmt.Sprintf(message.Required, "label"),
+		fmt.Sprintf(message.Required, "note"),
+		fmt.Sprintf(message.TooLong, "label"),
+		fmt.Sprintf(message.TooLong, "serial_number"),
+		fmt.Sprintf(message.TooLong, "notes"),
+		fmt.Sprintf(message.TooLong, "note"):
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
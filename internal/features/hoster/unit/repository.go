@@ -0,0 +1,401 @@
+package unit
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+UnitRepository adalah kontrak akses data unit fisik (aset) item untuk hoster.
+*/
+type UnitRepository interface {
+	GetItemTracking(hosterID, itemID string) (bool, int, error) // Status unit tracking + stock item milik hoster
+	SetUnitTracking(hosterID, itemID string, enabled bool) error
+	ItemHasVariants(itemID string) (bool, error)
+	ListUnits(itemID, status string) ([]dto.ItemUnitByHosterResponse, error)
+	CreateUnit(unit *domain.ItemUnit) error
+	GetUnit(hosterID, unitID string) (*dto.ItemUnitByHosterResponse, error)
+	UpdateUnit(hosterID string, unit *dto.ItemUnitByHosterResponse, note string) error
+	AddDamageNote(unitID, bookingID, condition, note string) error
+	GetUnitEvents(unitID string) ([]dto.ItemUnitEventByHosterResponse, error)
+	HosterOwnsBooking(hosterID, bookingID string) (bool, error)
+}
+
+/*
+unitRepository adalah implementasi repository unit hoster.
+*/
+type unitRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewUnitRepository membuat instance repository dengan koneksi database.
+
+Output:
+- UnitRepository siap digunakan
+*/
+func NewUnitRepository(db *sqlx.DB) UnitRepository {
+	return &unitRepository{db: db}
+}
+
+/*
+unitColumns adalah kolom unit beserta booking yang sedang memakainya (alokasi terbuka).
+*/
+const unitColumns = `
+	u.id, u.item_id, u.label, u.serial_number, u.condition, u.status, u.notes,
+	(SELECT biu.booking_id FROM booking_item_unit biu WHERE biu.unit_id = u.id AND biu.returned_at IS NULL LIMIT 1) AS current_booking_id,
+	u.created_at, u.updated_at
+`
+
+/*
+syncItemStock menyamakan stock item dengan jumlah unit aktif (available + rented).
+Hanya berlaku untuk item dengan unit_tracking aktif. Dipanggil di dalam transaksi setiap kali status unit berubah.
+*/
+func syncItemStock(tx *sqlx.Tx, itemID string) error {
+	_, err := tx.Exec(`
+		UPDATE item
+		SET stock = (
+			SELECT COUNT(*) FROM item_unit
+			WHERE item_id = $1 AND status IN ('available', 'rented')
+		), updated_at = NOW()
+		WHERE id = $1 AND unit_tracking
+	`, itemID)
+	if err != nil {
+		log.Printf("syncItemStock: update error item=%s err=%v", itemID, err)
+	}
+	return err
+}
+
+/*
+insertUnitEvent mencatat satu baris riwayat unit di dalam transaksi.
+*/
+func insertUnitEvent(tx *sqlx.Tx, unitID, bookingID, kind, condition, status, note string) error {
+	_, err := tx.Exec(`
+		INSERT INTO item_unit_event (unit_id, booking_id, kind, condition, status, note)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''))
+	`, unitID, bookingID, kind, condition, status, note)
+	if err != nil {
+		log.Printf("insertUnitEvent: insert error unit=%s kind=%s err=%v", unitID, kind, err)
+	}
+	return err
+}
+
+/*
+GetItemTracking mengambil status unit tracking dan stock item milik hoster.
+
+Output sukses:
+- (unit_tracking, stock, nil)
+Output error:
+- (false, 0, sql.ErrNoRows) → item tidak ditemukan / bukan milik hoster
+- (false, 0, error) → query gagal
+*/
+func (r *unitRepository) GetItemTracking(hosterID, itemID string) (bool, int, error) {
+	var row struct {
+		UnitTracking bool `db:"unit_tracking"`
+		Stock        int  `db:"stock"`
+	}
+	err := r.db.Get(&row, `SELECT unit_tracking, stock FROM item WHERE id = $1 AND hoster_id = $2`, itemID, hosterID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetItemTracking: query error item=%s err=%v", itemID, err)
+		}
+		return false, 0, err
+	}
+	return row.UnitTracking, row.Stock, nil
+}
+
+/*
+ItemHasVariants mengecek item memakai varian (unit tracking tidak bisa dipakai bersamaan).
+
+Output:
+- (true, nil) jika item punya dimensi varian
+- (false, error) jika query gagal
+*/
+func (r *unitRepository) ItemHasVariants(itemID string) (bool, error) {
+	var hasVariants bool
+	err := r.db.Get(&hasVariants, `SELECT cardinality(variant_dimensions) > 0 FROM item WHERE id = $1`, itemID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("ItemHasVariants: query error item=%s err=%v", itemID, err)
+		return false, err
+	}
+	return hasVariants, nil
+}
+
+/*
+SetUnitTracking mengaktifkan / mematikan unit tracking item.
+
+Alur kerja:
+1. Update kolom unit_tracking (hanya item milik hoster)
+2. Jika diaktifkan → stock langsung disamakan dengan jumlah unit aktif
+3. Jika dimatikan → stock terakhir dipertahankan dan bisa diubah manual lagi
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → item tidak ditemukan / bukan milik hoster
+- error → query gagal
+*/
+func (r *unitRepository) SetUnitTracking(hosterID, itemID string, enabled bool) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("SetUnitTracking: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE item SET unit_tracking = $3, updated_at = NOW()
+		WHERE id = $1 AND hoster_id = $2
+	`, itemID, hosterID, enabled)
+	if err != nil {
+		log.Printf("SetUnitTracking: update error item=%s err=%v", itemID, err)
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if enabled {
+		if err := syncItemStock(tx, itemID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("SetUnitTracking: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+ListUnits mengambil unit sebuah item (opsional filter status), urut label.
+
+Output sukses:
+- ([]dto.ItemUnitByHosterResponse, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *unitRepository) ListUnits(itemID, status string) ([]dto.ItemUnitByHosterResponse, error) {
+	units := []dto.ItemUnitByHosterResponse{}
+	query := `
+		SELECT ` + unitColumns + `
+		FROM item_unit u
+		WHERE u.item_id = $1
+		  AND ($2 = '' OR u.status = $2)
+		ORDER BY u.label ASC
+	`
+	if err := r.db.Select(&units, query, itemID, status); err != nil {
+		log.Printf("ListUnits: query error item=%s err=%v", itemID, err)
+		return nil, err
+	}
+	return units, nil
+}
+
+/*
+CreateUnit mendaftarkan unit baru.
+
+Alur kerja:
+1. Insert unit (label unik per item, tanpa membedakan huruf besar/kecil)
+2. Catat riwayat "created"
+3. Sinkronkan stock item
+
+Output sukses:
+- nil (unit.ID, CreatedAt, UpdatedAt terisi)
+Output error:
+- errors.New("duplicate") → label sudah dipakai di item yang sama
+- error → query gagal
+*/
+func (r *unitRepository) CreateUnit(unit *domain.ItemUnit) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("CreateUnit: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowx(`
+		INSERT INTO item_unit (item_id, hoster_id, label, serial_number, condition, status, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`, unit.ItemID, unit.HosterID, unit.Label, unit.SerialNumber, unit.Condition, unit.Status, unit.Notes).
+		Scan(&unit.ID, &unit.CreatedAt, &unit.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New("duplicate")
+		}
+		log.Printf("CreateUnit: insert error item=%s err=%v", unit.ItemID, err)
+		return err
+	}
+
+	if err := insertUnitEvent(tx, unit.ID, "", domain.UnitEventCreated, unit.Condition, unit.Status, ""); err != nil {
+		return err
+	}
+	if err := syncItemStock(tx, unit.ItemID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("CreateUnit: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+GetUnit mengambil satu unit milik hoster.
+
+Output sukses:
+- (*dto.ItemUnitByHosterResponse, nil)
+Output error:
+- (nil, sql.ErrNoRows) → unit tidak ditemukan / bukan milik hoster
+- (nil, error) → query gagal
+*/
+func (r *unitRepository) GetUnit(hosterID, unitID string) (*dto.ItemUnitByHosterResponse, error) {
+	var unit dto.ItemUnitByHosterResponse
+	query := `
+		SELECT ` + unitColumns + `
+		FROM item_unit u
+		WHERE u.id = $1 AND u.hoster_id = $2
+	`
+	if err := r.db.Get(&unit, query, unitID, hosterID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetUnit: query error unit=%s err=%v", unitID, err)
+		}
+		return nil, err
+	}
+	return &unit, nil
+}
+
+/*
+UpdateUnit menyimpan perubahan unit yang sudah divalidasi service.
+
+Alur kerja:
+1. Update unit milik hoster, unit yang sedang rented tidak boleh berubah status
+2. Catat riwayat "updated" dengan kondisi, status, dan alasan perubahan
+3. Sinkronkan stock item
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → unit tidak ditemukan / sedang rented
+- errors.New("duplicate") → label sudah dipakai di item yang sama
+- error → query gagal
+*/
+func (r *unitRepository) UpdateUnit(hosterID string, unit *dto.ItemUnitByHosterResponse, note string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("UpdateUnit: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE item_unit
+		SET label = $3, serial_number = $4, condition = $5, status = $6, notes = $7, updated_at = NOW()
+		WHERE id = $1 AND hoster_id = $2
+		  AND (status <> 'rented' OR $6 = 'rented')
+	`, unit.ID, hosterID, unit.Label, unit.SerialNumber, unit.Condition, unit.Status, unit.Notes)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New("duplicate")
+		}
+		log.Printf("UpdateUnit: update error unit=%s err=%v", unit.ID, err)
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertUnitEvent(tx, unit.ID, "", domain.UnitEventUpdated, unit.Condition, unit.Status, note); err != nil {
+		return err
+	}
+	if err := syncItemStock(tx, unit.ItemID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("UpdateUnit: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+AddDamageNote mencatat kerusakan unit (opsional sekaligus mengubah kondisi).
+
+Output sukses:
+- nil
+Output error:
+- error → query gagal
+*/
+func (r *unitRepository) AddDamageNote(unitID, bookingID, condition, note string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("AddDamageNote: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if condition != "" {
+		_, err := tx.Exec(`UPDATE item_unit SET condition = $2, updated_at = NOW() WHERE id = $1`, unitID, condition)
+		if err != nil {
+			log.Printf("AddDamageNote: update condition error unit=%s err=%v", unitID, err)
+			return err
+		}
+	}
+
+	if err := insertUnitEvent(tx, unitID, bookingID, domain.UnitEventDamage, condition, "", note); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("AddDamageNote: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+GetUnitEvents mengambil riwayat unit terbaru dulu, beserta nama penerima booking terkait.
+
+Output sukses:
+- ([]dto.ItemUnitEventByHosterResponse, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *unitRepository) GetUnitEvents(unitID string) ([]dto.ItemUnitEventByHosterResponse, error) {
+	events := []dto.ItemUnitEventByHosterResponse{}
+	query := `
+		SELECT e.id, e.kind, e.booking_id, bc.name AS customer_name, e.condition, e.status, e.note, e.created_at
+		FROM item_unit_event e
+		LEFT JOIN booking_customer bc ON bc.booking_id = e.booking_id
+		WHERE e.unit_id = $1
+		ORDER BY e.created_at DESC, e.id DESC
+	`
+	if err := r.db.Select(&events, query, unitID); err != nil {
+		log.Printf("GetUnitEvents: query error unit=%s err=%v", unitID, err)
+		return nil, err
+	}
+	return events, nil
+}
+
+/*
+HosterOwnsBooking mengecek booking milik hoster (untuk catatan kerusakan yang menyebut booking).
+*/
+func (r *unitRepository) HosterOwnsBooking(hosterID, bookingID string) (bool, error) {
+	var exists bool
+	err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM booking WHERE id = $1 AND hoster_id = $2)`, bookingID, hosterID)
+	if err != nil {
+		log.Printf("HosterOwnsBooking: query error booking=%s err=%v", bookingID, err)
+		return false, err
+	}
+	return exists, nil
+}
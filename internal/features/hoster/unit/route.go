@@ -0,0 +1,49 @@
+package unit
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupUnitRoutes mendaftarkan endpoint unit fisik (aset) item untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET  /item/{id}/units         → daftar unit item (filter status)
+  - POST /item/{id}/units         → daftarkan unit baru
+  - PUT  /item/{id}/unit-tracking → aktif / matikan unit tracking (stock dari unit aktif)
+  - PUT  /unit/{id}               → ubah label, serial, kondisi, status, catatan unit
+  - POST /unit/{id}/damage        → catat kerusakan unit
+  - GET  /unit/{id}/history       → riwayat sewa, pengembalian, dan kerusakan unit
+
+4. GET butuh permission items:view, perubahan data butuh items:manage (role toko)
+
+Output:
+- Router terkonfigurasi dengan endpoint unit hoster
+*/
+func SetupUnitRoutes(router *mux.Router, h *HosterUnitHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	protected.HandleFunc("/item/{id}/units", middleware.HosterPermission(domain.HosterPermItemsView, h.ListUnits)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/item/{id}/units", middleware.HosterPermission(domain.HosterPermItemsManage, h.CreateUnit)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/item/{id}/unit-tracking", middleware.HosterPermission(domain.HosterPermItemsManage, h.SetUnitTracking)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/unit/{id}", middleware.HosterPermission(domain.HosterPermItemsManage, h.UpdateUnit)).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/unit/{id}/damage", middleware.HosterPermission(domain.HosterPermItemsManage, h.AddDamageNote)).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/unit/{id}/history", middleware.HosterPermission(domain.HosterPermItemsView, h.GetUnitHistory)).Methods("GET", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
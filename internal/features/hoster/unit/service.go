@@ -0,0 +1,416 @@
+package unit
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+Konstanta validasi unit.
+*/
+const (
+	MaxLabelLength  = 100
+	MaxSerialLength = 100
+	MaxNoteLength   = 1000
+)
+
+/*
+unitConditions adalah kondisi unit yang valid.
+*/
+var unitConditions = map[string]bool{
+	domain.UnitConditionGood:    true,
+	domain.UnitConditionFair:    true,
+	domain.UnitConditionDamaged: true,
+}
+
+/*
+unitManualStatuses adalah status yang boleh diatur hoster (rented hanya lewat booking).
+*/
+var unitManualStatuses = map[string]bool{
+	domain.UnitStatusAvailable:   true,
+	domain.UnitStatusMaintenance: true,
+	domain.UnitStatusRetired:     true,
+}
+
+/*
+UnitService adalah kontrak logika bisnis unit fisik (aset) item dari perspektif hoster.
+*/
+type UnitService interface {
+	ListUnits(hosterID, itemID, status string) (*dto.ItemUnitListByHosterResponse, error)
+	SetUnitTracking(hosterID, itemID string, req dto.SetUnitTrackingByHosterRequest) (*dto.ItemUnitListByHosterResponse, error)
+	CreateUnit(hosterID, itemID string, req dto.CreateItemUnitByHosterRequest) (*dto.ItemUnitByHosterResponse, error)
+	UpdateUnit(hosterID, unitID string, req dto.UpdateItemUnitByHosterRequest) (*dto.ItemUnitByHosterResponse, error)
+	AddDamageNote(hosterID, unitID string, req dto.CreateUnitDamageNoteByHosterRequest) (*dto.ItemUnitHistoryByHosterResponse, error)
+	GetUnitHistory(hosterID, unitID string) (*dto.ItemUnitHistoryByHosterResponse, error)
+}
+
+/*
+unitService adalah implementasi service unit hoster.
+*/
+type unitService struct {
+	repo UnitRepository
+}
+
+/*
+NewUnitService membuat instance service dengan dependency injection.
+
+Output:
+- UnitService siap digunakan
+*/
+func NewUnitService(repo UnitRepository) UnitService {
+	return &unitService{repo: repo}
+}
+
+/*
+ListUnits mengambil semua unit sebuah item milik hoster.
+
+Output sukses:
+- (*dto.ItemUnitListByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / ItemNotFound / UnitInvalidStatus / internal error
+*/
+func (s *unitService) ListUnits(hosterID, itemID, status string) (*dto.ItemUnitListByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if status != "" && !unitManualStatuses[status] && status != domain.UnitStatusRented {
+		return nil, errors.New(message.UnitInvalidStatus)
+	}
+
+	tracking, stock, err := s.getItem(hosterID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := s.repo.ListUnits(itemID, status)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.ItemUnitListByHosterResponse{
+		ItemID:       itemID,
+		UnitTracking: tracking,
+		Stock:        stock,
+		Units:        units,
+	}, nil
+}
+
+/*
+SetUnitTracking mengaktifkan / mematikan unit tracking item.
+Saat aktif, stock item disamakan dengan jumlah unit available + rented.
+Item bervarian tidak bisa memakai unit tracking (stock mengikuti varian).
+
+Output sukses:
+- (*dto.ItemUnitListByHosterResponse, nil) → daftar unit + stock terbaru
+Output error:
+- (nil, error) → unauthorized / ItemNotFound / UnitTrackingVariants / internal error
+*/
+func (s *unitService) SetUnitTracking(hosterID, itemID string, req dto.SetUnitTrackingByHosterRequest) (*dto.ItemUnitListByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if _, err := uuid.Parse(itemID); err != nil {
+		return nil, errors.New(message.ItemNotFound)
+	}
+
+	if req.Enabled {
+		hasVariants, err := s.repo.ItemHasVariants(itemID)
+		if err != nil {
+			return nil, errors.New(message.InternalError)
+		}
+		if hasVariants {
+			return nil, errors.New(message.UnitTrackingVariants)
+		}
+	}
+
+	if err := s.repo.SetUnitTracking(hosterID, itemID, req.Enabled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.ItemNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("SetUnitTracking(unit service): hoster %s item %s enabled=%t", hosterID, itemID, req.Enabled)
+	return s.ListUnits(hosterID, itemID, "")
+}
+
+/*
+CreateUnit mendaftarkan unit baru di item milik hoster.
+
+Alur kerja:
+1. Validasi label (wajib, maksimal 100), serial (maksimal 100), kondisi (default good)
+2. Pastikan item milik hoster
+3. Simpan unit (status available) dan catat riwayat
+
+Output sukses:
+- (*dto.ItemUnitByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / Required / TooLong / UnitInvalidCondition / ItemNotFound / UnitLabelExists / internal error
+*/
+func (s *unitService) CreateUnit(hosterID, itemID string, req dto.CreateItemUnitByHosterRequest) (*dto.ItemUnitByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	label := strings.TrimSpace(req.Label)
+	serial := strings.TrimSpace(req.SerialNumber)
+	notes := strings.TrimSpace(req.Notes)
+	condition := strings.TrimSpace(req.Condition)
+	if condition == "" {
+		condition = domain.UnitConditionGood
+	}
+	if err := validateUnitFields(label, serial, condition, notes); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := s.getItem(hosterID, itemID); err != nil {
+		return nil, err
+	}
+
+	unit := &domain.ItemUnit{
+		ItemID:       itemID,
+		HosterID:     hosterID,
+		Label:        label,
+		SerialNumber: optionalString(serial),
+		Condition:    condition,
+		Status:       domain.UnitStatusAvailable,
+		Notes:        optionalString(notes),
+	}
+	if err := s.repo.CreateUnit(unit); err != nil {
+		if err.Error() == "duplicate" {
+			return nil, errors.New(message.UnitLabelExists)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	return s.getUnit(hosterID, unit.ID)
+}
+
+/*
+UpdateUnit mengubah label, serial, kondisi, status, atau catatan unit.
+
+Alur kerja:
+1. Ambil unit milik hoster, unit rented tidak bisa diubah statusnya (diatur booking)
+2. Gabungkan field yang diisi dengan data lama lalu validasi
+3. Simpan dan catat riwayat "updated" (note = alasan perubahan)
+
+Output sukses:
+- (*dto.ItemUnitByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / UnitNotFound / UnitRented / UnitInvalidStatus / UnitInvalidCondition / UnitLabelExists / internal error
+*/
+func (s *unitService) UpdateUnit(hosterID, unitID string, req dto.UpdateItemUnitByHosterRequest) (*dto.ItemUnitByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	unit, err := s.getUnit(hosterID, unitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status != nil {
+		status := strings.TrimSpace(*req.Status)
+		if unit.Status == domain.UnitStatusRented && status != domain.UnitStatusRented {
+			return nil, errors.New(message.UnitRented)
+		}
+		if status != unit.Status && !unitManualStatuses[status] {
+			return nil, errors.New(message.UnitInvalidStatus)
+		}
+		unit.Status = status
+	}
+	if req.Label != nil {
+		unit.Label = strings.TrimSpace(*req.Label)
+	}
+	if req.SerialNumber != nil {
+		unit.SerialNumber = optionalString(strings.TrimSpace(*req.SerialNumber))
+	}
+	if req.Condition != nil {
+		unit.Condition = strings.TrimSpace(*req.Condition)
+	}
+	if req.Notes != nil {
+		unit.Notes = optionalString(strings.TrimSpace(*req.Notes))
+	}
+
+	note := strings.TrimSpace(req.Note)
+	serial, notes := "", ""
+	if unit.SerialNumber != nil {
+		serial = *unit.SerialNumber
+	}
+	if unit.Notes != nil {
+		notes = *unit.Notes
+	}
+	if err := validateUnitFields(unit.Label, serial, unit.Condition, notes); err != nil {
+		return nil, err
+	}
+	if len(note) > MaxNoteLength {
+		return nil, fmt.Errorf(message.TooLong, "note")
+	}
+
+	if err := s.repo.UpdateUnit(hosterID, unit, note); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Unit berubah menjadi rented di antara pengecekan dan update
+			return nil, errors.New(message.UnitRented)
+		}
+		if err.Error() == "duplicate" {
+			return nil, errors.New(message.UnitLabelExists)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	return s.getUnit(hosterID, unitID)
+}
+
+/*
+AddDamageNote mencatat kerusakan unit, opsional sekaligus mengubah kondisi dan menyebut booking penyebabnya.
+
+Output sukses:
+- (*dto.ItemUnitHistoryByHosterResponse, nil) → riwayat terbaru
+Output error:
+- (nil, error) → unauthorized / Required / TooLong / UnitInvalidCondition / UnitNotFound / NotFound booking / internal error
+*/
+func (s *unitService) AddDamageNote(hosterID, unitID string, req dto.CreateUnitDamageNoteByHosterRequest) (*dto.ItemUnitHistoryByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, fmt.Errorf(message.Required, "note")
+	}
+	if len(note) > MaxNoteLength {
+		return nil, fmt.Errorf(message.TooLong, "note")
+	}
+	condition := strings.TrimSpace(req.Condition)
+	if condition != "" && !unitConditions[condition] {
+		return nil, errors.New(message.UnitInvalidCondition)
+	}
+
+	if _, err := s.getUnit(hosterID, unitID); err != nil {
+		return nil, err
+	}
+
+	bookingID := strings.TrimSpace(req.BookingID)
+	if bookingID != "" {
+		if _, err := uuid.Parse(bookingID); err != nil {
+			return nil, fmt.Errorf(message.NotFound, "booking")
+		}
+		owned, err := s.repo.HosterOwnsBooking(hosterID, bookingID)
+		if err != nil {
+			return nil, errors.New(message.InternalError)
+		}
+		if !owned {
+			return nil, fmt.Errorf(message.NotFound, "booking")
+		}
+	}
+
+	if err := s.repo.AddDamageNote(unitID, bookingID, condition, note); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("AddDamageNote(unit service): hoster %s unit %s booking=%s condition=%s", hosterID, unitID, bookingID, condition)
+	return s.GetUnitHistory(hosterID, unitID)
+}
+
+/*
+GetUnitHistory mengambil detail unit beserta riwayat sewa, pengembalian, perubahan, dan kerusakan.
+
+Output sukses:
+- (*dto.ItemUnitHistoryByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / UnitNotFound / internal error
+*/
+func (s *unitService) GetUnitHistory(hosterID, unitID string) (*dto.ItemUnitHistoryByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	unit, err := s.getUnit(hosterID, unitID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.GetUnitEvents(unitID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.ItemUnitHistoryByHosterResponse{Unit: *unit, Events: events}, nil
+}
+
+/*
+getItem memastikan item milik hoster dan mengembalikan status unit tracking + stock.
+*/
+func (s *unitService) getItem(hosterID, itemID string) (bool, int, error) {
+	if _, err := uuid.Parse(itemID); err != nil {
+		return false, 0, errors.New(message.ItemNotFound)
+	}
+
+	tracking, stock, err := s.repo.GetItemTracking(hosterID, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, 0, errors.New(message.ItemNotFound)
+		}
+		return false, 0, errors.New(message.InternalError)
+	}
+	return tracking, stock, nil
+}
+
+/*
+getUnit mengambil unit milik hoster.
+*/
+func (s *unitService) getUnit(hosterID, unitID string) (*dto.ItemUnitByHosterResponse, error) {
+	if _, err := uuid.Parse(unitID); err != nil {
+		return nil, errors.New(message.UnitNotFound)
+	}
+
+	unit, err := s.repo.GetUnit(hosterID, unitID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.UnitNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	return unit, nil
+}
+
+/*
+validateUnitFields memvalidasi field unit yang diisi hoster.
+*/
+func validateUnitFields(label, serial, condition, notes string) error {
+	if label == "" {
+		return fmt.Errorf(message.Required, "label")
+	}
+	if len(label) > MaxLabelLength {
+		return fmt.Errorf(message.TooLong, "label")
+	}
+	if len(serial) > MaxSerialLength {
+		return fmt.Errorf(message.TooLong, "serial_number")
+	}
+	if len(notes) > MaxNoteLength {
+		return fmt.Errorf(message.TooLong, "notes")
+	}
+	if !unitConditions[condition] {
+		return errors.New(message.UnitInvalidCondition)
+	}
+	return nil
+}
+
+/*
+optionalString mengubah string kosong menjadi nil (kolom nullable).
+*/
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,95 @@
+package variant
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+HosterVariantHandler menangani endpoint HTTP varian item untuk hoster.
+*/
+type HosterVariantHandler struct {
+	service VariantService
+}
+
+/*
+NewHosterVariantHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *HosterVariantHandler siap digunakan
+*/
+func NewHosterVariantHandler(s VariantService) *HosterVariantHandler {
+	return &HosterVariantHandler{service: s}
+}
+
+/*
+ListVariants menangani GET /api/v1/hoster/item/{id}/variants
+
+Output sukses:
+- 200 OK + { item_id, dimensions, stock, variants }
+Output error:
+- 401 Unauthorized / 403 Forbidden / 404 Not Found / 500 Internal Server Error
+*/
+func (h *HosterVariantHandler) ListVariants(w http.ResponseWriter, r *http.Request) {
+	hosterID := middleware.GetUserID(r)
+	result, err := h.service.ListVariants(hosterID, mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("ListVariants handler: service error hoster=%s err=%v", hosterID, err)
+		writeVariantError(w, err)
+		return
+	}
+	response.OK(w, result, message.VariantRetrieved)
+}
+
+/*
+ReplaceVariants menangani PUT /api/v1/hoster/item/{id}/variants
+
+Output sukses:
+- 200 OK + dimensi, varian, dan stock item terbaru
+Output error:
+- 400 Bad Request / 401 Unauthorized / 403 Forbidden / 404 Not Found
+- 409 Conflict (kombinasi varian ganda / unit tracking aktif / komponen paket) / 500 Internal Server Error
+*/
+func (h *HosterVariantHandler) ReplaceVariants(w http.ResponseWriter, r *http.Request) {
+	var req dto.ReplaceItemVariantsByHosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("ReplaceVariants: failed to decode JSON: %v", err)
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	hosterID := middleware.GetUserID(r)
+	result, err := h.service.ReplaceVariants(hosterID, mux.Vars(r)["id"], req)
+	if err != nil {
+		log.Printf("ReplaceVariants handler: service error hoster=%s err=%v", hosterID, err)
+		writeVariantError(w, err)
+		return
+	}
+	response.OK(w, result, message.VariantUpdated)
+}
+
+/*
+writeVariantError memetakan error service varian ke HTTP response.
+*/
+func writeVariantError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.Unauthorized:
+		response.Unauthorized(w, message.Unauthorized)
+	case message.ItemNotFound, message.VariantNotFound:
+		response.NotFound(w, err.Error())
+	case message.VariantDuplicate, message.VariantUnitTracking, message.VariantInBundle:
+		response.Error(w, http.StatusConflict, err.Error())
+	case message.VariantInvalid:
+		response.BadRequest(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+	}
+}
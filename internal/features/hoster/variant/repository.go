@@ -0,0 +1,250 @@
+package variant
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+)
+
+/*
+VariantRepository adalah kontrak akses data varian item untuk hoster.
+*/
+type VariantRepository interface {
+	GetItemVariantState(hosterID, itemID string) (*ItemVariantState, error)
+	ListVariants(itemID string) ([]dto.ItemVariantByHosterResponse, error)
+	ReplaceVariants(hosterID, itemID string, dimensions []string, variants []domain.ItemVariant) error
+}
+
+/*
+ItemVariantState adalah data item yang menentukan pengelolaan varian.
+*/
+type ItemVariantState struct {
+	Dimensions   pq.StringArray `db:"variant_dimensions"`
+	UnitTracking bool           `db:"unit_tracking"`
+	Stock        int            `db:"stock"`
+}
+
+/*
+variantRepository adalah implementasi repository varian item hoster.
+*/
+type variantRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewVariantRepository membuat instance repository dengan koneksi database.
+
+Output:
+- VariantRepository siap digunakan
+*/
+func NewVariantRepository(db *sqlx.DB) VariantRepository {
+	return &variantRepository{db: db}
+}
+
+/*
+GetItemVariantState mengambil dimensi varian, status unit tracking, dan stock item milik hoster.
+
+Output sukses:
+- (*ItemVariantState, nil)
+Output error:
+- (nil, sql.ErrNoRows) → item tidak ditemukan / bukan milik hoster
+- (nil, error) → query gagal
+*/
+func (r *variantRepository) GetItemVariantState(hosterID, itemID string) (*ItemVariantState, error) {
+	var state ItemVariantState
+	err := r.db.Get(&state, `
+		SELECT variant_dimensions, unit_tracking, stock
+		FROM item
+		WHERE id = $1 AND hoster_id = $2
+	`, itemID, hosterID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetItemVariantState: query error item=%s err=%v", itemID, err)
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+/*
+ListVariants mengambil varian aktif item, urut sort_order.
+
+Output sukses:
+- ([]dto.ItemVariantByHosterResponse, nil) → bisa kosong
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *variantRepository) ListVariants(itemID string) ([]dto.ItemVariantByHosterResponse, error) {
+	var rows []struct {
+		dto.ItemVariantByHosterResponse
+		OptionValues pq.StringArray `db:"option_values"`
+	}
+	err := r.db.Select(&rows, `
+		SELECT id, name, option_values, stock, price_per_day, deposit, created_at, updated_at
+		FROM item_variant
+		WHERE item_id = $1 AND is_active
+		ORDER BY sort_order, created_at
+	`, itemID)
+	if err != nil {
+		log.Printf("ListVariants: query error item=%s err=%v", itemID, err)
+		return nil, err
+	}
+
+	variants := make([]dto.ItemVariantByHosterResponse, len(rows))
+	for i, row := range rows {
+		variants[i] = row.ItemVariantByHosterResponse
+		variants[i].Options = row.OptionValues
+	}
+	return variants, nil
+}
+
+/*
+ReplaceVariants mengganti seluruh varian item dalam satu transaksi.
+
+Alur kerja:
+1. Kunci item milik hoster (FOR UPDATE), item dengan unit tracking ditolak,
+item yang menjadi komponen paket sewa tidak boleh diberi varian
+2. Varian ber-ID harus varian aktif item ini
+3. Varian lama yang tidak dikirim dihapus; yang pernah dipakai booking hanya dinonaktifkan (stock 0)
+4. Update varian ber-ID, insert varian baru (nama yang sama dengan varian nonaktif diaktifkan kembali)
+5. Simpan dimensi dan samakan stock item dengan jumlah stock varian aktif
+(tanpa dimensi → stock terakhir dipertahankan dan bisa diubah manual lagi)
+
+Output sukses:
+- nil (ID varian baru terisi)
+Output error:
+- sql.ErrNoRows → item tidak ditemukan / bukan milik hoster
+- errors.New("unit_tracking") → item memakai unit tracking
+- errors.New("in_bundle") → item komponen paket sewa
+- errors.New("variant_not_found") → ID varian bukan milik item
+- errors.New("duplicate") → kombinasi varian bentrok dengan varian lain
+- error → query gagal
+*/
+func (r *variantRepository) ReplaceVariants(hosterID, itemID string, dimensions []string, variants []domain.ItemVariant) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("ReplaceVariants: error starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var unitTracking bool
+	if err := tx.Get(&unitTracking, `SELECT unit_tracking FROM item WHERE id = $1 AND hoster_id = $2 FOR UPDATE`, itemID, hosterID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("ReplaceVariants: lock item error item=%s err=%v", itemID, err)
+		}
+		return err
+	}
+	if unitTracking {
+		return errors.New("unit_tracking")
+	}
+	if len(dimensions) > 0 {
+		var inBundle bool
+		if err := tx.Get(&inBundle, `SELECT EXISTS (SELECT 1 FROM bundle_item WHERE item_id = $1)`, itemID); err != nil {
+			log.Printf("ReplaceVariants: check bundle error item=%s err=%v", itemID, err)
+			return err
+		}
+		if inBundle {
+			return errors.New("in_bundle")
+		}
+	}
+
+	keepIDs := []string{}
+	for _, v := range variants {
+		if v.ID != "" {
+			keepIDs = append(keepIDs, v.ID)
+		}
+	}
+
+	var owned int
+	if err := tx.Get(&owned, `
+		SELECT COUNT(*) FROM item_variant WHERE item_id = $1 AND is_active AND id = ANY($2::uuid[])
+	`, itemID, pq.Array(keepIDs)); err != nil {
+		log.Printf("ReplaceVariants: check variants error item=%s err=%v", itemID, err)
+		return err
+	}
+	if owned != len(keepIDs) {
+		return errors.New("variant_not_found")
+	}
+
+	// Varian yang tidak dikirim: hapus jika belum pernah dipakai booking, selain itu nonaktifkan
+	if _, err := tx.Exec(`
+		DELETE FROM item_variant v
+		WHERE v.item_id = $1 AND NOT (v.id = ANY($2::uuid[]))
+		  AND NOT EXISTS (SELECT 1 FROM booking_item bi WHERE bi.variant_id = v.id)
+	`, itemID, pq.Array(keepIDs)); err != nil {
+		log.Printf("ReplaceVariants: delete variants error item=%s err=%v", itemID, err)
+		return err
+	}
+	if _, err := tx.Exec(`
+		UPDATE item_variant SET is_active = false, stock = 0, updated_at = NOW()
+		WHERE item_id = $1 AND is_active AND NOT (id = ANY($2::uuid[]))
+	`, itemID, pq.Array(keepIDs)); err != nil {
+		log.Printf("ReplaceVariants: deactivate variants error item=%s err=%v", itemID, err)
+		return err
+	}
+
+	for i := range variants {
+		v := &variants[i]
+		if v.ID != "" {
+			_, err = tx.Exec(`
+				UPDATE item_variant
+				SET name = $3, option_values = $4, stock = $5, price_per_day = $6, deposit = $7,
+				    sort_order = $8, updated_at = NOW()
+				WHERE id = $1 AND item_id = $2
+			`, v.ID, itemID, v.Name, pq.Array(v.OptionValues), v.Stock, v.PricePerDay, v.Deposit, v.SortOrder)
+		} else {
+			// Nama yang sama dengan varian nonaktif → aktifkan kembali, dengan varian aktif → duplikat
+			err = tx.QueryRow(`
+				INSERT INTO item_variant (item_id, hoster_id, name, option_values, stock, price_per_day, deposit, sort_order)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				ON CONFLICT (item_id, LOWER(name)) DO UPDATE
+				SET option_values = EXCLUDED.option_values, stock = EXCLUDED.stock,
+				    price_per_day = EXCLUDED.price_per_day, deposit = EXCLUDED.deposit,
+				    sort_order = EXCLUDED.sort_order, is_active = true, updated_at = NOW()
+				WHERE NOT item_variant.is_active
+				RETURNING id
+			`, itemID, hosterID, v.Name, pq.Array(v.OptionValues), v.Stock, v.PricePerDay, v.Deposit, v.SortOrder).Scan(&v.ID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("duplicate")
+			}
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate") {
+				return errors.New("duplicate")
+			}
+			log.Printf("ReplaceVariants: save variant error item=%s name=%s err=%v", itemID, v.Name, err)
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE item
+		SET variant_dimensions = $2,
+		    stock = CASE
+		        WHEN cardinality($2::text[]) > 0 THEN (
+		            SELECT COALESCE(SUM(stock), 0) FROM item_variant WHERE item_id = $1 AND is_active
+		        )
+		        ELSE stock
+		    END,
+		    updated_at = NOW()
+		WHERE id = $1
+	`, itemID, pq.Array(dimensions))
+	if err != nil {
+		log.Printf("ReplaceVariants: update item error item=%s err=%v", itemID, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ReplaceVariants: error committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
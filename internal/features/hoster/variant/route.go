@@ -0,0 +1,41 @@
+package variant
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+)
+
+/*
+SetupVariantRoutes mendaftarkan endpoint varian item untuk hoster.
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/hoster
+2. Terapkan middleware JWT → Hoster (protected route)
+3. Daftarkan endpoint:
+  - GET /item/{id}/variants → dimensi & varian aktif item
+  - PUT /item/{id}/variants → ganti seluruh dimensi & varian (stock item = jumlah stock varian)
+
+4. GET butuh permission items:view, perubahan butuh items:manage (role toko)
+
+Output:
+- Router terkonfigurasi dengan endpoint varian hoster
+*/
+func SetupVariantRoutes(router *mux.Router, h *HosterVariantHandler) {
+	protected := router.PathPrefix("/api/v1/hoster").Subrouter()
+
+	// JWT + Role check
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+
+	protected.HandleFunc("/item/{id}/variants", middleware.HosterPermission(domain.HosterPermItemsView, h.ListVariants)).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/item/{id}/variants", middleware.HosterPermission(domain.HosterPermItemsManage, h.ReplaceVariants)).Methods("PUT", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
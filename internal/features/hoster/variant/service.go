@@ -0,0 +1,230 @@
+package variant
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+)
+
+/*
+Konstanta validasi varian.
+*/
+const (
+	MaxDimensions        = 3
+	MaxVariants          = 100
+	MaxOptionLength      = 50
+	VariantNameSeparator = " / "
+)
+
+/*
+VariantService adalah kontrak logika bisnis varian item dari perspektif hoster.
+*/
+type VariantService interface {
+	ListVariants(hosterID, itemID string) (*dto.ItemVariantListByHosterResponse, error)
+	ReplaceVariants(hosterID, itemID string, req dto.ReplaceItemVariantsByHosterRequest) (*dto.ItemVariantListByHosterResponse, error)
+}
+
+/*
+variantService adalah implementasi service varian item hoster.
+*/
+type variantService struct {
+	repo VariantRepository
+}
+
+/*
+NewVariantService membuat instance service dengan dependency injection.
+
+Output:
+- VariantService siap digunakan
+*/
+func NewVariantService(repo VariantRepository) VariantService {
+	return &variantService{repo: repo}
+}
+
+/*
+ListVariants mengambil dimensi dan varian aktif item milik hoster.
+
+Output sukses:
+- (*dto.ItemVariantListByHosterResponse, nil)
+Output error:
+- (nil, error) → unauthorized / ItemNotFound / internal error
+*/
+func (s *variantService) ListVariants(hosterID, itemID string) (*dto.ItemVariantListByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	state, err := s.getItem(hosterID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := s.repo.ListVariants(itemID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	dimensions := []string(state.Dimensions)
+	if dimensions == nil {
+		dimensions = []string{}
+	}
+	return &dto.ItemVariantListByHosterResponse{
+		ItemID:     itemID,
+		Dimensions: dimensions,
+		Stock:      state.Stock,
+		Variants:   variants,
+	}, nil
+}
+
+/*
+ReplaceVariants mengganti seluruh dimensi dan varian item.
+
+Alur kerja:
+1. Pastikan item milik hoster dan tidak memakai unit tracking
+2. Validasi dimensi (maks 3, unik) dan varian (maks 100, satu opsi per dimensi, kombinasi unik,
+stock >= 0, override price_per_day > 0 dan deposit >= 0)
+3. Simpan via repository (varian lama yang tidak dikirim dihapus / dinonaktifkan, stock item disamakan)
+
+Output sukses:
+- (*dto.ItemVariantListByHosterResponse, nil) → varian terbaru
+Output error:
+- (nil, error) → unauthorized / ItemNotFound / VariantNotFound / VariantInvalid / VariantDuplicate /
+VariantUnitTracking / VariantInBundle / internal error
+*/
+func (s *variantService) ReplaceVariants(hosterID, itemID string, req dto.ReplaceItemVariantsByHosterRequest) (*dto.ItemVariantListByHosterResponse, error) {
+	if hosterID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	state, err := s.getItem(hosterID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if state.UnitTracking {
+		return nil, errors.New(message.VariantUnitTracking)
+	}
+
+	dimensions, err := normalizeOptions(req.Dimensions)
+	if err != nil || len(dimensions) > MaxDimensions {
+		return nil, errors.New(message.VariantInvalid)
+	}
+	if (len(dimensions) == 0) != (len(req.Variants) == 0) || len(req.Variants) > MaxVariants {
+		return nil, errors.New(message.VariantInvalid)
+	}
+	if hasDuplicate(dimensions) {
+		return nil, errors.New(message.VariantInvalid)
+	}
+
+	variants := make([]domain.ItemVariant, len(req.Variants))
+	names := make([]string, len(req.Variants))
+	ids := map[string]bool{}
+	for i, v := range req.Variants {
+		if v.ID != "" {
+			if _, err := uuid.Parse(v.ID); err != nil || ids[v.ID] {
+				return nil, errors.New(message.VariantNotFound)
+			}
+			ids[v.ID] = true
+		}
+
+		options, err := normalizeOptions(v.Options)
+		if err != nil || len(options) != len(dimensions) {
+			return nil, errors.New(message.VariantInvalid)
+		}
+		if v.Stock < 0 || (v.PricePerDay != nil && *v.PricePerDay <= 0) || (v.Deposit != nil && *v.Deposit < 0) {
+			return nil, errors.New(message.VariantInvalid)
+		}
+
+		variants[i] = domain.ItemVariant{
+			ID:           v.ID,
+			ItemID:       itemID,
+			HosterID:     hosterID,
+			Name:         strings.Join(options, VariantNameSeparator),
+			OptionValues: options,
+			Stock:        v.Stock,
+			PricePerDay:  v.PricePerDay,
+			Deposit:      v.Deposit,
+			IsActive:     true,
+			SortOrder:    i,
+		}
+		names[i] = variants[i].Name
+	}
+	if hasDuplicate(names) {
+		return nil, errors.New(message.VariantDuplicate)
+	}
+
+	if err := s.repo.ReplaceVariants(hosterID, itemID, dimensions, variants); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.ItemNotFound)
+		}
+		switch err.Error() {
+		case "unit_tracking":
+			return nil, errors.New(message.VariantUnitTracking)
+		case "in_bundle":
+			return nil, errors.New(message.VariantInBundle)
+		case "variant_not_found":
+			return nil, errors.New(message.VariantNotFound)
+		case "duplicate":
+			return nil, errors.New(message.VariantDuplicate)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	log.Printf("ReplaceVariants(variant service): hoster %s item %s now has %d variants", hosterID, itemID, len(variants))
+	return s.ListVariants(hosterID, itemID)
+}
+
+/*
+getItem memastikan item milik hoster dan mengembalikan data varian item.
+*/
+func (s *variantService) getItem(hosterID, itemID string) (*ItemVariantState, error) {
+	if _, err := uuid.Parse(itemID); err != nil {
+		return nil, errors.New(message.ItemNotFound)
+	}
+
+	state, err := s.repo.GetItemVariantState(hosterID, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.ItemNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	return state, nil
+}
+
+/*
+normalizeOptions merapikan nama dimensi / opsi varian; kosong atau terlalu panjang ditolak.
+"/" tidak diizinkan karena dipakai sebagai pemisah nama varian.
+*/
+func normalizeOptions(values []string) ([]string, error) {
+	normalized := make([]string, len(values))
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || len(value) > MaxOptionLength || strings.Contains(value, "/") {
+			return nil, errors.New(message.VariantInvalid)
+		}
+		normalized[i] = value
+	}
+	return normalized, nil
+}
+
+/*
+hasDuplicate mengecek nilai ganda (tidak membedakan huruf besar / kecil).
+*/
+func hasDuplicate(values []string) bool {
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		key := strings.ToLower(value)
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+	}
+	return false
+}
@@ -3,7 +3,9 @@ package public
 import (
 	"log"
 	"net/http"
+	"strconv"
 
+	"lalan-be/internal/dto"
 	"lalan-be/internal/message"
 	"lalan-be/internal/response"
 
@@ -54,12 +56,14 @@ GetAllItems menangani endpoint GET /public/items.
 
 Alur kerja:
 1. Validasi method
-2. Panggil service untuk ambil semua item publik
-3. Return data atau 500 jika service gagal
+2. Ambil query param opsional store_id untuk katalog per store
+3. Panggil service untuk ambil semua item publik
+4. Return data atau 500 jika service gagal
 
 Output sukses:
 - 200 OK + list item (DTO)
 Output error:
+- 400 Bad Request (store_id tidak valid)
 - 405 / 500 Internal Server Error
 */
 func (h *PublicHandler) GetAllItems(w http.ResponseWriter, r *http.Request) {
@@ -70,9 +74,13 @@ func (h *PublicHandler) GetAllItems(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items, err := h.service.GetAllItems()
+	items, err := h.service.GetAllItems(r.URL.Query().Get("store_id"))
 	if err != nil {
 		log.Printf("GetAllItems: service error: %v", err)
+		if err.Error() == message.StoreInvalidID {
+			response.BadRequest(w, message.StoreInvalidID)
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, message.InternalError)
 		return
 	}
@@ -160,6 +168,118 @@ func (h *PublicHandler) GetItemDetail(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, itemDetail, message.ItemRetrieved)
 }
 
+/*
+GetAllBundles menangani endpoint GET /public/bundle.
+
+Alur kerja:
+1. Validasi method
+2. Ambil query param opsional store_id untuk katalog per store
+3. Panggil service untuk ambil paket sewa publik
+
+Output sukses:
+- 200 OK + list paket (komponen, harga paket, jumlah tersedia)
+Output error:
+- 400 Bad Request (store_id tidak valid)
+- 405 / 500 Internal Server Error
+*/
+func (h *PublicHandler) GetAllBundles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.MethodNotAllowed(w, message.MethodNotAllowed)
+		return
+	}
+
+	bundles, err := h.service.GetAllBundles(r.URL.Query().Get("store_id"))
+	if err != nil {
+		log.Printf("GetAllBundles: service error: %v", err)
+		if err.Error() == message.StoreInvalidID {
+			response.BadRequest(w, message.StoreInvalidID)
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+		return
+	}
+
+	response.OK(w, bundles, message.BundleRetrieved)
+}
+
+/*
+GetBundleDetail menangani endpoint GET /public/bundle/{id}.
+
+Output sukses:
+- 200 OK + paket, store, versi T&C, dan tanggal penuh
+Output error:
+- 404 Not Found jika paket tidak ditemukan
+- 405 / 500 Internal Server Error
+*/
+func (h *PublicHandler) GetBundleDetail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.MethodNotAllowed(w, message.MethodNotAllowed)
+		return
+	}
+
+	detail, err := h.service.GetBundleDetail(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetBundleDetail: service error: %v", err)
+		if err.Error() == message.BundleNotFound {
+			response.NotFound(w, message.BundleNotFound)
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.InternalError)
+		return
+	}
+
+	response.OK(w, detail, message.BundleRetrieved)
+}
+
+/*
+GetStorefront menangani endpoint GET /public/hoster/{id}.
+{id} boleh UUID hoster atau slug toko (link yang dibagikan ke Instagram/TikTok).
+
+Alur kerja:
+1. Validasi method
+2. Ambil id/slug dari path dan filter item dari query (store_id, category_id, page, limit)
+3. Panggil service untuk menyusun halaman toko
+
+Output sukses:
+- 200 OK + profil toko, store, T&C, kategori, rating, jumlah sewa selesai, item (paginated)
+Output error:
+- 400 Bad Request (filter tidak valid)
+- 404 Not Found (toko tidak ditemukan)
+- 405 / 500 Internal Server Error
+*/
+func (h *PublicHandler) GetStorefront(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.MethodNotAllowed(w, message.MethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	filter := dto.StorefrontFilterPublicRequest{
+		StoreID:    query.Get("store_id"),
+		CategoryID: query.Get("category_id"),
+		Page:       page,
+		Limit:      limit,
+	}
+
+	storefront, err := h.service.GetStorefront(mux.Vars(r)["id"], filter)
+	if err != nil {
+		log.Printf("GetStorefront: service error: %v", err)
+		switch err.Error() {
+		case message.HosterNotFound:
+			response.NotFound(w, message.HosterNotFound)
+		case message.StorefrontInvalidFilter:
+			response.BadRequest(w, message.StorefrontInvalidFilter)
+		default:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		}
+		return
+	}
+
+	response.OK(w, storefront, message.StorefrontRetrieved)
+}
+
 /*
 NewPublicHandler membuat instance PublicHandler dengan dependency injection.
 
@@ -1,13 +1,17 @@
 package public
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"lalan-be/internal/domain"
 	"lalan-be/internal/dto"
 	"log"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 /*
@@ -60,7 +64,7 @@ func (r *publicRepository) GetAllCategory() ([]*domain.Category, error) {
 GetAllItems mengambil semua item publik beserta foto dalam format JSON.
 
 Alur kerja:
-1. Query semua kolom dari tabel item
+1. Query semua kolom dari tabel item + status verifikasi toko (JOIN hoster), filter store_id jika diisi
 2. Manual scan + json.Unmarshal untuk field photos (karena tipe []string di DB disimpan sebagai JSON)
 3. Append ke slice hasil
 
@@ -69,25 +73,44 @@ Output sukses:
 Output error:
 - (nil, error) → query / scan / unmarshal gagal
 */
-func (r *publicRepository) GetAllItems() ([]*domain.Item, error) {
+func (r *publicRepository) GetAllItems(storeID string) ([]*domain.Item, error) {
 	query := `
-		SELECT
-			id, name, description, photos, stock, pickup_type,
-			price_per_day, deposit, discount, category_id, hoster_id,
-			created_at, updated_at
-		FROM item
-		WHERE is_hidden = false
-		ORDER BY created_at DESC
+		SELECT ` + publicItemColumns + `
+		FROM item i
+		INNER JOIN hoster h ON h.id = i.hoster_id
+		WHERE i.is_hidden = false
+		  AND ($1 = '' OR i.tenant_id::text = $1)
+		ORDER BY i.created_at DESC
 	`
 
-	var items []*domain.Item
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, storeID)
 	if err != nil {
 		log.Printf("GetAllItems query error: %v", err)
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanPublicItems(rows, "GetAllItems")
+}
+
+/*
+publicItemColumns adalah kolom item publik (alias i = item, h = hoster) untuk scanPublicItems.
+*/
+const publicItemColumns = `
+			i.id, i.name, i.description, i.photos, i.stock, i.pickup_type,
+			i.price_per_day, i.deposit, i.discount, i.category_id, i.hoster_id,
+			i.tenant_id, i.created_at, i.updated_at, h.is_verified`
+
+/*
+scanPublicItems membaca hasil query publicItemColumns dan unmarshal JSON photos.
+
+Output sukses:
+- ([]*domain.Item, nil) → slice kosong jika tidak ada baris
+Output error:
+- (nil, error) → scan / unmarshal gagal
+*/
+func scanPublicItems(rows *sql.Rows, caller string) ([]*domain.Item, error) {
+	items := make([]*domain.Item, 0)
 	for rows.Next() {
 		var item domain.Item
 		var photosJSON []byte
@@ -95,26 +118,22 @@ func (r *publicRepository) GetAllItems() ([]*domain.Item, error) {
 		err := rows.Scan(
 			&item.ID, &item.Name, &item.Description, &photosJSON, &item.Stock,
 			&item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount,
-			&item.CategoryID, &item.HosterID, &item.CreatedAt, &item.UpdatedAt,
+			&item.CategoryID, &item.HosterID, &item.TenantID, &item.CreatedAt, &item.UpdatedAt,
+			&item.HosterVerified,
 		)
 		if err != nil {
-			log.Printf("GetAllItems scan error: %v", err)
+			log.Printf("%s scan error: %v", caller, err)
 			return nil, err
 		}
 
 		if err := json.Unmarshal(photosJSON, &item.Photos); err != nil {
-			log.Printf("GetAllItems unmarshal photos error: %v", err)
+			log.Printf("%s unmarshal photos error: %v", caller, err)
 			return nil, err
 		}
 
 		items = append(items, &item)
 	}
-
-	if items == nil {
-		items = make([]*domain.Item, 0)
-	}
-
-	return items, nil
+	return items, rows.Err()
 }
 
 /*
@@ -170,13 +189,13 @@ func (r *publicRepository) GetAllTermsAndConditions() ([]*domain.TermsAndConditi
 }
 
 /*
-GetItemDetail mengambil detail lengkap item dengan JOIN ke category, hoster, dan tnc.
+GetItemDetail mengambil detail lengkap item dengan JOIN ke category, hoster, store, dan tnc.
 
 Parameter:
 - itemID: UUID item yang ingin diambil detail lengkapnya
 
 Alur kerja:
-1. Eksekusi query JOIN 3 tabel (item, category, hoster, tnc)
+1. Eksekusi query JOIN (item, category, hoster, tenant, tnc)
 2. Manual scan semua field dari hasil JOIN
 3. Unmarshal JSON untuk field photos (item) dan description (tnc)
 4. Mapping hasil scan ke struct ItemDetailResponse
@@ -190,7 +209,8 @@ Output error:
 SQL Query:
 - JOIN item dengan category via category_id
 - JOIN item dengan hoster via hoster_id
-- LEFT JOIN tnc via hoster.id (LEFT karena tnc bisa NULL)
+- JOIN item dengan tenant (store) via tenant_id
+- LEFT JOIN LATERAL tnc: T&C khusus store lebih diutamakan, fallback ke T&C umum hoster
 */
 func (r *publicRepository) GetItemDetail(itemID string) (*dto.ItemDetailResponse, error) {
 	query := `
@@ -201,14 +221,25 @@ func (r *publicRepository) GetItemDetail(itemID string) (*dto.ItemDetailResponse
 			
 			c.id AS category_id, c.name AS category_name, c.description AS category_description,
 			
-			h.id AS hoster_id, h.full_name, h.store_name, h.description AS hoster_description,
-			h.phone_number, h.address, h.profile_photo, h.website, h.instagram, h.tiktok,
+			h.id AS hoster_id, h.full_name, h.store_name, h.slug, h.description AS hoster_description,
+			h.phone_number, h.address, h.profile_photo, h.banner_photo, h.website, h.instagram, h.tiktok,
+			h.is_verified,
+
+			s.id AS store_id, s.name AS store_name, s.address AS store_address, s.city AS store_city,
+			s.phone_number AS store_phone_number, s.delivery_enabled, s.delivery_fee, s.delivery_radius_km,
 			
 			t.description AS tnc_description
 		FROM item i
 		INNER JOIN category c ON c.id = i.category_id
 		INNER JOIN hoster h ON h.id = i.hoster_id
-		LEFT JOIN tnc t ON t.hoster_id = h.id
+		INNER JOIN tenant s ON s.id = i.tenant_id
+		LEFT JOIN LATERAL (
+			SELECT description
+			FROM tnc
+			WHERE hoster_id = h.id AND item_id IS NULL AND (tenant_id = s.id OR tenant_id IS NULL)
+			ORDER BY tenant_id NULLS LAST
+			LIMIT 1
+		) t ON true
 		WHERE i.id = $1 AND i.is_hidden = false
 	`
 
@@ -218,7 +249,7 @@ func (r *publicRepository) GetItemDetail(itemID string) (*dto.ItemDetailResponse
 		tncDescriptionJSON []byte
 
 		// Nullable fields
-		hosterProfilePhoto, hosterWebsite, hosterInstagram, hosterTiktok *string
+		hosterProfilePhoto, hosterBannerPhoto, hosterWebsite, hosterInstagram, hosterTiktok *string
 	)
 
 	err := r.db.QueryRow(query, itemID).Scan(
@@ -244,13 +275,26 @@ func (r *publicRepository) GetItemDetail(itemID string) (*dto.ItemDetailResponse
 		&itemDetail.Hoster.ID,
 		&itemDetail.Hoster.FullName,
 		&itemDetail.Hoster.StoreName,
+		&itemDetail.Hoster.Slug,
 		&itemDetail.Hoster.Description,
 		&itemDetail.Hoster.PhoneNumber,
 		&itemDetail.Hoster.Address,
 		&hosterProfilePhoto,
+		&hosterBannerPhoto,
 		&hosterWebsite,
 		&hosterInstagram,
 		&hosterTiktok,
+		&itemDetail.Hoster.Verified,
+
+		// Store fields
+		&itemDetail.Store.ID,
+		&itemDetail.Store.Name,
+		&itemDetail.Store.Address,
+		&itemDetail.Store.City,
+		&itemDetail.Store.PhoneNumber,
+		&itemDetail.Store.DeliveryEnabled,
+		&itemDetail.Store.DeliveryFee,
+		&itemDetail.Store.DeliveryRadiusKm,
 
 		// TnC field
 		&tncDescriptionJSON,
@@ -280,6 +324,9 @@ func (r *publicRepository) GetItemDetail(itemID string) (*dto.ItemDetailResponse
 	if hosterProfilePhoto != nil {
 		itemDetail.Hoster.ProfilePhoto = *hosterProfilePhoto
 	}
+	if hosterBannerPhoto != nil {
+		itemDetail.Hoster.BannerPhoto = *hosterBannerPhoto
+	}
 	if hosterWebsite != nil {
 		itemDetail.Hoster.Website = *hosterWebsite
 	}
@@ -420,15 +467,631 @@ func parseDate(dateStr string) (time.Time, error) {
 	return time.Time{}, err
 }
 
+/*
+GetStorefrontHoster mengambil profil publik toko berdasarkan UUID hoster atau slug.
+
+Alur kerja:
+1. ref berbentuk UUID → cari berdasarkan id, selain itu berdasarkan slug
+2. Mapping kolom nullable (foto, sosial media) ke string kosong
+
+Output sukses:
+- (*dto.StorefrontPublicResponse, nil) → hanya Hoster & JoinedAt yang terisi
+Output error:
+- (nil, sql.ErrNoRows) → toko tidak ditemukan
+- (nil, error) → query gagal
+*/
+func (r *publicRepository) GetStorefrontHoster(ref string) (*dto.StorefrontPublicResponse, error) {
+	var row struct {
+		ID           string         `db:"id"`
+		FullName     string         `db:"full_name"`
+		StoreName    string         `db:"store_name"`
+		Slug         string         `db:"slug"`
+		Description  sql.NullString `db:"description"`
+		PhoneNumber  sql.NullString `db:"phone_number"`
+		Address      string         `db:"address"`
+		ProfilePhoto sql.NullString `db:"profile_photo"`
+		BannerPhoto  sql.NullString `db:"banner_photo"`
+		Website      sql.NullString `db:"website"`
+		Instagram    sql.NullString `db:"instagram"`
+		Tiktok       sql.NullString `db:"tiktok"`
+		IsVerified   bool           `db:"is_verified"`
+		CreatedAt    time.Time      `db:"created_at"`
+	}
+
+	where := "slug = $1"
+	if _, err := uuid.Parse(ref); err == nil {
+		where = "id = $1::uuid"
+	}
+	query := `
+		SELECT id, full_name, store_name, slug, description, phone_number, address,
+		       profile_photo, banner_photo, website, instagram, tiktok, is_verified, created_at
+		FROM hoster
+		WHERE ` + where
+
+	if err := r.db.Get(&row, query, ref); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("GetStorefrontHoster repository error: %v", err)
+		}
+		return nil, err
+	}
+
+	return &dto.StorefrontPublicResponse{
+		Hoster: dto.HosterDetail{
+			ID:           row.ID,
+			FullName:     row.FullName,
+			StoreName:    row.StoreName,
+			Slug:         row.Slug,
+			Description:  row.Description.String,
+			PhoneNumber:  row.PhoneNumber.String,
+			Address:      row.Address,
+			ProfilePhoto: row.ProfilePhoto.String,
+			BannerPhoto:  row.BannerPhoto.String,
+			Website:      row.Website.String,
+			Instagram:    row.Instagram.String,
+			Tiktok:       row.Tiktok.String,
+			Verified:     row.IsVerified,
+		},
+		JoinedAt: row.CreatedAt,
+	}, nil
+}
+
+/*
+GetStorefrontStores mengambil semua store (cabang) toko, store default di urutan pertama.
+
+Output sukses:
+- ([]dto.StorePublicResponse, nil)
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *publicRepository) GetStorefrontStores(hosterID string) ([]dto.StorePublicResponse, error) {
+	query := `
+		SELECT id, name, address, city, phone_number, delivery_enabled, delivery_fee, delivery_radius_km
+		FROM tenant
+		WHERE hoster_id = $1
+		ORDER BY is_default DESC, name ASC
+	`
+	stores := []dto.StorePublicResponse{}
+	if err := r.db.Select(&stores, query, hosterID); err != nil {
+		log.Printf("GetStorefrontStores repository error: %v", err)
+		return nil, err
+	}
+	return stores, nil
+}
+
+/*
+GetStorefrontCategories mengambil kategori yang punya item tampil di toko beserta jumlahnya.
+
+Output sukses:
+- ([]dto.StorefrontCategoryResponse, nil) → urut dari item terbanyak
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *publicRepository) GetStorefrontCategories(hosterID string) ([]dto.StorefrontCategoryResponse, error) {
+	query := `
+		SELECT c.id, c.name, COUNT(*) AS item_count
+		FROM item i
+		INNER JOIN category c ON c.id = i.category_id
+		WHERE i.hoster_id = $1 AND i.is_hidden = false
+		GROUP BY c.id, c.name
+		ORDER BY item_count DESC, c.name ASC
+	`
+	categories := []dto.StorefrontCategoryResponse{}
+	if err := r.db.Select(&categories, query, hosterID); err != nil {
+		log.Printf("GetStorefrontCategories repository error: %v", err)
+		return nil, err
+	}
+	return categories, nil
+}
+
+/*
+GetItemTnCVersions mengambil versi T&C terbaru yang wajib disetujui saat booking item:
+T&C khusus store (fallback ke T&C umum hoster) ditambah T&C khusus item.
+
+Output sukses:
+- ([]dto.TnCVersionResponse, nil) → slice kosong jika hoster belum membuat T&C
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *publicRepository) GetItemTnCVersions(itemID string) ([]dto.TnCVersionResponse, error) {
+	var rows []struct {
+		dto.TnCVersionResponse
+		Description pq.StringArray `db:"description"`
+	}
+	query := `
+		SELECT v.id, v.version, v.tenant_id, v.item_id, v.created_at,
+		       ARRAY(SELECT jsonb_array_elements_text(v.description)) AS description
+		FROM item i
+		INNER JOIN tnc t ON t.hoster_id = i.hoster_id
+		INNER JOIN tnc_version v ON v.id = t.current_version_id
+		WHERE i.id = $1
+		  AND (
+		      t.item_id = i.id
+		      OR (t.item_id IS NULL AND t.id = (
+		          SELECT s.id FROM tnc s
+		          WHERE s.hoster_id = i.hoster_id AND s.item_id IS NULL
+		            AND (s.tenant_id = i.tenant_id OR s.tenant_id IS NULL)
+		          ORDER BY s.tenant_id NULLS LAST
+		          LIMIT 1
+		      ))
+		  )
+		ORDER BY t.item_id NULLS FIRST
+	`
+	if err := r.db.Select(&rows, query, itemID); err != nil {
+		log.Printf("GetItemTnCVersions repository error: %v", err)
+		return nil, err
+	}
+
+	versions := make([]dto.TnCVersionResponse, len(rows))
+	for i, row := range rows {
+		versions[i] = row.TnCVersionResponse
+		versions[i].Description = row.Description
+	}
+	return versions, nil
+}
+
+/*
+GetItemVariants mengambil dimensi dan varian aktif item beserta ketersediaannya.
+
+Alur kerja:
+1. Ambil dimensi varian item
+2. Ambil varian aktif dengan harga & deposit efektif (override varian, fallback ke item)
+3. Hitung tanggal penuh per varian (mulai hari ini): jumlah unit di booking aktif >= stock varian
+
+Output sukses:
+- ([]string, []dto.ItemVariantPublicResponse, nil) → keduanya kosong jika item tanpa varian
+Output error:
+- (nil, nil, error) → query gagal
+*/
+func (r *publicRepository) GetItemVariants(itemID string) ([]string, []dto.ItemVariantPublicResponse, error) {
+	var dimensions pq.StringArray
+	if err := r.db.Get(&dimensions, `SELECT variant_dimensions FROM item WHERE id = $1`, itemID); err != nil {
+		log.Printf("GetItemVariants dimensions error: %v", err)
+		return nil, nil, err
+	}
+	if len(dimensions) == 0 {
+		return []string{}, []dto.ItemVariantPublicResponse{}, nil
+	}
+
+	var rows []struct {
+		ID           string         `db:"id"`
+		Name         string         `db:"name"`
+		OptionValues pq.StringArray `db:"option_values"`
+		Stock        int            `db:"stock"`
+		PricePerDay  int            `db:"price_per_day"`
+		Deposit      int            `db:"deposit"`
+	}
+	err := r.db.Select(&rows, `
+		SELECT v.id, v.name, v.option_values, v.stock,
+		       COALESCE(v.price_per_day, i.price_per_day) AS price_per_day,
+		       COALESCE(v.deposit, i.deposit) AS deposit
+		FROM item_variant v
+		INNER JOIN item i ON i.id = v.item_id
+		WHERE v.item_id = $1 AND v.is_active
+		ORDER BY v.sort_order, v.created_at
+	`, itemID)
+	if err != nil {
+		log.Printf("GetItemVariants variants error: %v", err)
+		return nil, nil, err
+	}
+
+	var fullRows []struct {
+		VariantID string `db:"variant_id"`
+		Date      string `db:"date"`
+	}
+	err = r.db.Select(&fullRows, `
+		SELECT v.id AS variant_id, to_char(d, 'YYYY-MM-DD') AS date
+		FROM item_variant v
+		INNER JOIN booking_item bi ON bi.variant_id = v.id
+		INNER JOIN booking b ON b.id = bi.booking_id
+		CROSS JOIN LATERAL generate_series(GREATEST(b.start_date, CURRENT_DATE), b.end_date, INTERVAL '1 day') AS d
+		WHERE v.item_id = $1 AND v.is_active
+		  AND (
+		      b.status IN ('on_progress', 'on_rent', 'completed')
+		      OR (b.status = 'pending' AND b.locked_until > NOW())
+		  )
+		  AND b.end_date >= CURRENT_DATE
+		GROUP BY v.id, v.stock, d
+		HAVING SUM(bi.quantity) >= v.stock
+		ORDER BY d
+	`, itemID)
+	if err != nil {
+		log.Printf("GetItemVariants fully booked dates error: %v", err)
+		return nil, nil, err
+	}
+	fullDates := make(map[string][]string)
+	for _, row := range fullRows {
+		fullDates[row.VariantID] = append(fullDates[row.VariantID], row.Date)
+	}
+
+	variants := make([]dto.ItemVariantPublicResponse, len(rows))
+	for i, row := range rows {
+		dates := fullDates[row.ID]
+		if dates == nil {
+			dates = []string{}
+		}
+		variants[i] = dto.ItemVariantPublicResponse{
+			ID:               row.ID,
+			Name:             row.Name,
+			Options:          row.OptionValues,
+			Stock:            row.Stock,
+			PricePerDay:      row.PricePerDay,
+			Deposit:          row.Deposit,
+			Available:        row.Stock > 0,
+			FullyBookedDates: dates,
+		}
+	}
+	return dimensions, variants, nil
+}
+
+/*
+publicBundleCondition adalah syarat paket tampil di katalog publik (alias b = bundle):
+tidak disembunyikan, punya komponen, dan semua komponen masih tampil, di store paket, dan tidak bervarian.
+*/
+const publicBundleCondition = `
+		b.is_hidden = false
+		AND EXISTS (SELECT 1 FROM bundle_item pbi WHERE pbi.bundle_id = b.id)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM bundle_item pbi
+			INNER JOIN item pi ON pi.id = pbi.item_id
+			WHERE pbi.bundle_id = b.id
+			  AND (pi.is_hidden OR pi.tenant_id <> b.tenant_id OR cardinality(pi.variant_dimensions) > 0)
+		)`
+
+/*
+GetAllBundles mengambil paket sewa publik (opsional satu store) beserta komponennya.
+
+Alur kerja:
+1. Query paket yang memenuhi publicBundleCondition, filter store_id jika diisi
+2. Ambil komponen semua paket sekaligus
+3. Hitung foto, total harga komponen, dan jumlah paket tersedia menurut stock komponen
+
+Output sukses:
+- ([]dto.BundlePublicResponse, nil) → slice kosong jika tidak ada paket
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *publicRepository) GetAllBundles(storeID string) ([]dto.BundlePublicResponse, error) {
+	bundles := []dto.BundlePublicResponse{}
+	err := r.db.Select(&bundles, `
+		SELECT b.id, b.name, b.description, b.price_per_day, b.deposit,
+		       b.tenant_id AS store_id, b.hoster_id, h.is_verified AS hoster_verified,
+		       b.created_at, b.updated_at
+		FROM bundle b
+		INNER JOIN hoster h ON h.id = b.hoster_id
+		WHERE `+publicBundleCondition+`
+		  AND ($1 = '' OR b.tenant_id::text = $1)
+		ORDER BY b.created_at DESC
+	`, storeID)
+	if err != nil {
+		log.Printf("GetAllBundles query error: %v", err)
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return bundles, nil
+	}
+
+	ids := make([]string, len(bundles))
+	for i := range bundles {
+		ids[i] = bundles[i].ID
+	}
+	components, err := r.getBundleComponents(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bundles {
+		setPublicBundleItems(&bundles[i], components[bundles[i].ID])
+	}
+	return bundles, nil
+}
+
+/*
+GetBundleDetail mengambil detail paket publik beserta store, komponen, dan tanggal penuh.
+
+Output sukses:
+- (*dto.BundleDetailPublicResponse, nil) → TnCVersions belum diisi
+Output error:
+- (nil, sql.ErrNoRows) → paket tidak ditemukan / tidak tampil di katalog
+- (nil, error) → query gagal
+*/
+func (r *publicRepository) GetBundleDetail(bundleID string) (*dto.BundleDetailPublicResponse, error) {
+	var row struct {
+		dto.BundlePublicResponse
+		Store dto.StorePublicResponse `db:"store"`
+	}
+	err := r.db.QueryRowx(`
+		SELECT b.id, b.name, b.description, b.price_per_day, b.deposit,
+		       b.tenant_id AS store_id, b.hoster_id, h.is_verified AS hoster_verified,
+		       b.created_at, b.updated_at,
+		       s.id AS "store.id", s.name AS "store.name", s.address AS "store.address", s.city AS "store.city",
+		       s.phone_number AS "store.phone_number", s.delivery_enabled AS "store.delivery_enabled",
+		       s.delivery_fee AS "store.delivery_fee", s.delivery_radius_km AS "store.delivery_radius_km"
+		FROM bundle b
+		INNER JOIN hoster h ON h.id = b.hoster_id
+		INNER JOIN tenant s ON s.id = b.tenant_id
+		WHERE b.id = $1 AND `+publicBundleCondition+`
+	`, bundleID).StructScan(&row)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("GetBundleDetail repository error: %v", err)
+		}
+		return nil, err
+	}
+
+	components, err := r.getBundleComponents([]string{bundleID})
+	if err != nil {
+		return nil, err
+	}
+	setPublicBundleItems(&row.BundlePublicResponse, components[bundleID])
+
+	// Tanggal (mulai hari ini) di mana minimal satu komponen tidak cukup untuk satu paket
+	bookedDates := []string{}
+	err = r.db.Select(&bookedDates, `
+		SELECT DISTINCT to_char(d, 'YYYY-MM-DD') AS date
+		FROM bundle_item bi
+		INNER JOIN item i ON i.id = bi.item_id
+		INNER JOIN booking_item obi ON obi.item_id = bi.item_id
+		INNER JOIN booking b ON b.id = obi.booking_id
+		CROSS JOIN LATERAL generate_series(GREATEST(b.start_date, CURRENT_DATE), b.end_date, INTERVAL '1 day') AS d
+		WHERE bi.bundle_id = $1
+		  AND (
+		      b.status IN ('on_progress', 'on_rent', 'completed')
+		      OR (b.status = 'pending' AND b.locked_until > NOW())
+		  )
+		  AND b.end_date >= CURRENT_DATE
+		GROUP BY bi.item_id, bi.quantity, i.stock, d
+		HAVING i.stock - SUM(obi.quantity) < bi.quantity
+		ORDER BY date
+	`, bundleID)
+	if err != nil {
+		log.Printf("GetBundleDetail booked dates error: %v", err)
+		return nil, err
+	}
+
+	return &dto.BundleDetailPublicResponse{
+		Bundle:      row.BundlePublicResponse,
+		Store:       row.Store,
+		BookedDates: bookedDates,
+	}, nil
+}
+
+/*
+getBundleComponents mengambil komponen beberapa paket sekaligus, dikelompokkan per bundle_id.
+*/
+func (r *publicRepository) getBundleComponents(bundleIDs []string) (map[string][]dto.BundleItemResponse, error) {
+	var rows []struct {
+		BundleID string `db:"bundle_id"`
+		dto.BundleItemResponse
+		Photos pq.StringArray `db:"photos"`
+	}
+	err := r.db.Select(&rows, `
+		SELECT bi.bundle_id, bi.item_id, i.name, bi.quantity, i.stock, i.price_per_day, i.deposit,
+		       CASE WHEN i.photos IS NOT NULL THEN ARRAY(SELECT jsonb_array_elements_text(i.photos)) ELSE ARRAY[]::text[] END AS photos
+		FROM bundle_item bi
+		INNER JOIN item i ON i.id = bi.item_id
+		WHERE bi.bundle_id = ANY($1::uuid[])
+		ORDER BY i.name, bi.item_id
+	`, pq.Array(bundleIDs))
+	if err != nil {
+		log.Printf("getBundleComponents query error: %v", err)
+		return nil, err
+	}
+
+	components := make(map[string][]dto.BundleItemResponse, len(bundleIDs))
+	for _, row := range rows {
+		item := row.BundleItemResponse
+		item.Photos = row.Photos
+		components[row.BundleID] = append(components[row.BundleID], item)
+	}
+	return components, nil
+}
+
+/*
+setPublicBundleItems mengisi komponen paket publik beserta turunannya:
+foto pertama setiap komponen, total harga komponen, dan jumlah paket tersedia (min stock / quantity).
+*/
+func setPublicBundleItems(bundle *dto.BundlePublicResponse, items []dto.BundleItemResponse) {
+	if items == nil {
+		items = []dto.BundleItemResponse{}
+	}
+	bundle.Items = items
+	bundle.Photos = []string{}
+	bundle.ItemsValue = 0
+	bundle.Available = 0
+	for i, item := range items {
+		if len(item.Photos) > 0 {
+			bundle.Photos = append(bundle.Photos, item.Photos[0])
+		}
+		bundle.ItemsValue += item.PricePerDay * item.Quantity
+		if available := item.Stock / item.Quantity; i == 0 || available < bundle.Available {
+			bundle.Available = available
+		}
+	}
+}
+
+/*
+GetBundleTnCVersions mengambil versi T&C terbaru yang wajib disetujui saat booking paket:
+T&C khusus store paket (fallback ke T&C umum hoster) ditambah T&C khusus setiap komponen.
+
+Output sukses:
+- ([]dto.TnCVersionResponse, nil) → slice kosong jika hoster belum membuat T&C
+Output error:
+- (nil, error) → query gagal
+*/
+func (r *publicRepository) GetBundleTnCVersions(bundleID string) ([]dto.TnCVersionResponse, error) {
+	var rows []struct {
+		dto.TnCVersionResponse
+		Description pq.StringArray `db:"description"`
+	}
+	query := `
+		SELECT v.id, v.version, v.tenant_id, v.item_id, v.created_at,
+		       ARRAY(SELECT jsonb_array_elements_text(v.description)) AS description
+		FROM bundle b
+		INNER JOIN tnc t ON t.hoster_id = b.hoster_id
+		INNER JOIN tnc_version v ON v.id = t.current_version_id
+		WHERE b.id = $1
+		  AND (
+		      t.item_id IN (SELECT item_id FROM bundle_item WHERE bundle_id = b.id)
+		      OR (t.item_id IS NULL AND t.id = (
+		          SELECT s.id FROM tnc s
+		          WHERE s.hoster_id = b.hoster_id AND s.item_id IS NULL
+		            AND (s.tenant_id = b.tenant_id OR s.tenant_id IS NULL)
+		          ORDER BY s.tenant_id NULLS LAST
+		          LIMIT 1
+		      ))
+		  )
+		ORDER BY t.item_id NULLS FIRST
+	`
+	if err := r.db.Select(&rows, query, bundleID); err != nil {
+		log.Printf("GetBundleTnCVersions repository error: %v", err)
+		return nil, err
+	}
+
+	versions := make([]dto.TnCVersionResponse, len(rows))
+	for i, row := range rows {
+		versions[i] = row.TnCVersionResponse
+		versions[i].Description = row.Description
+	}
+	return versions, nil
+}
+
+/*
+GetGeneralTermsAndConditions mengambil T&C umum hoster (tnc tanpa tenant_id dan item_id).
+
+Output sukses:
+- ([]string, nil) → slice kosong jika hoster belum membuat T&C
+Output error:
+- (nil, error) → query / unmarshal gagal
+*/
+func (r *publicRepository) GetGeneralTermsAndConditions(hosterID string) ([]string, error) {
+	var descriptionJSON []byte
+	query := `SELECT description FROM tnc WHERE hoster_id = $1 AND tenant_id IS NULL AND item_id IS NULL LIMIT 1`
+	if err := r.db.Get(&descriptionJSON, query, hosterID); err != nil {
+		if err == sql.ErrNoRows {
+			return []string{}, nil
+		}
+		log.Printf("GetGeneralTermsAndConditions repository error: %v", err)
+		return nil, err
+	}
+
+	terms := []string{}
+	if err := json.Unmarshal(descriptionJSON, &terms); err != nil {
+		log.Printf("GetGeneralTermsAndConditions unmarshal description error: %v", err)
+		return nil, err
+	}
+	return terms, nil
+}
+
+/*
+GetCompletedRentals menghitung jumlah booking toko yang sudah selesai.
+
+Output:
+- (jumlah, nil)
+- (0, error) → query gagal
+*/
+func (r *publicRepository) GetCompletedRentals(hosterID string) (int, error) {
+	var total int
+	query := `SELECT COUNT(*) FROM booking WHERE hoster_id = $1 AND status = 'completed'`
+	if err := r.db.Get(&total, query, hosterID); err != nil {
+		log.Printf("GetCompletedRentals repository error: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+/*
+GetStorefrontRating menghitung ringkasan rating toko dari ulasan booking selesai.
+
+Output:
+- (jumlah ulasan + rata-rata 1 desimal, nil) → average nil jika belum ada ulasan
+- (kosong, error) → query gagal
+*/
+func (r *publicRepository) GetStorefrontRating(hosterID string) (dto.StorefrontRatingResponse, error) {
+	var rating dto.StorefrontRatingResponse
+	query := `
+		SELECT COUNT(*) AS count, ROUND(AVG(rating)::numeric, 1)::float8 AS average
+		FROM booking_review
+		WHERE hoster_id = $1
+	`
+	if err := r.db.Get(&rating, query, hosterID); err != nil {
+		log.Printf("GetStorefrontRating repository error: %v", err)
+		return dto.StorefrontRatingResponse{}, err
+	}
+	return rating, nil
+}
+
+/*
+GetStorefrontItems mengambil satu halaman item tampil milik toko.
+
+Alur kerja:
+1. Hitung total item yang cocok dengan filter (store, kategori)
+2. Ambil item halaman ini, terbaru dulu (index item(hoster_id, created_at) WHERE is_hidden = false)
+
+Output sukses:
+- ([]*domain.Item, total, nil)
+Output error:
+- (nil, 0, error) → query / scan gagal
+*/
+func (r *publicRepository) GetStorefrontItems(hosterID string, filter dto.StorefrontFilterPublicRequest, limit, offset int) ([]*domain.Item, int, error) {
+	where := `
+		WHERE i.hoster_id = $1 AND i.is_hidden = false
+		  AND ($2 = '' OR i.tenant_id::text = $2)
+		  AND ($3 = '' OR i.category_id::text = $3)
+	`
+
+	var total int
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM item i`+where, hosterID, filter.StoreID, filter.CategoryID); err != nil {
+		log.Printf("GetStorefrontItems count error: %v", err)
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + publicItemColumns + `
+		FROM item i
+		INNER JOIN hoster h ON h.id = i.hoster_id
+	` + where + `
+		ORDER BY i.created_at DESC, i.id DESC
+		LIMIT $4 OFFSET $5
+	`
+	rows, err := r.db.Query(query, hosterID, filter.StoreID, filter.CategoryID, limit, offset)
+	if err != nil {
+		log.Printf("GetStorefrontItems query error: %v", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items, err := scanPublicItems(rows, "GetStorefrontItems")
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
 /*
 PublicRepository adalah kontrak untuk operasi data publik.
 Digunakan oleh service layer untuk dependency injection.
 */
 type PublicRepository interface {
 	GetAllCategory() ([]*domain.Category, error)
-	GetAllItems() ([]*domain.Item, error)
+	GetAllItems(storeID string) ([]*domain.Item, error)
 	GetAllTermsAndConditions() ([]*domain.TermsAndConditions, error)
 	GetItemDetail(itemID string) (*dto.ItemDetailResponse, error)
+	GetItemTnCVersions(itemID string) ([]dto.TnCVersionResponse, error)
+	GetItemVariants(itemID string) ([]string, []dto.ItemVariantPublicResponse, error)
+
+	// Paket sewa
+	GetAllBundles(storeID string) ([]dto.BundlePublicResponse, error)
+	GetBundleDetail(bundleID string) (*dto.BundleDetailPublicResponse, error)
+	GetBundleTnCVersions(bundleID string) ([]dto.TnCVersionResponse, error)
+
+	// Storefront hoster
+	GetStorefrontHoster(ref string) (*dto.StorefrontPublicResponse, error)
+	GetStorefrontStores(hosterID string) ([]dto.StorePublicResponse, error)
+	GetStorefrontCategories(hosterID string) ([]dto.StorefrontCategoryResponse, error)
+	GetGeneralTermsAndConditions(hosterID string) ([]string, error)
+	GetCompletedRentals(hosterID string) (int, error)
+	GetStorefrontRating(hosterID string) (dto.StorefrontRatingResponse, error)
+	GetStorefrontItems(hosterID string, filter dto.StorefrontFilterPublicRequest, limit, offset int) ([]*domain.Item, int, error)
 }
 
 /*
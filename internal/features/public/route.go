@@ -8,12 +8,18 @@ import (
 SetupPublicRoutes mendaftarkan endpoint publik (tanpa autentikasi).
 
 Route:
-- GET /api/v1/public/item        -> GetAllItems (list item untuk halaman home)
-- GET /api/v1/public/item/{id}   -> GetItemDetail (detail item dengan JOIN: category + hoster + tnc)
+- GET /api/v1/public/item        -> GetAllItems (list item untuk halaman home, ?store_id= untuk katalog per store)
+- GET /api/v1/public/item/{id}   -> GetItemDetail (detail item dengan JOIN: category + hoster + store + tnc)
+- GET /api/v1/public/bundle      -> GetAllBundles (paket sewa, ?store_id= untuk katalog per store)
+- GET /api/v1/public/bundle/{id} -> GetBundleDetail (paket + komponen + store + tnc + tanggal penuh)
+- GET /api/v1/public/hoster/{id} -> GetStorefront (halaman toko, {id} = UUID hoster atau slug)
 */
 func SetupPublicRoutes(router *mux.Router, h *PublicHandler) {
 	public := router.PathPrefix("/api/v1/public").Subrouter()
 
 	public.HandleFunc("/item", h.GetAllItems).Methods("GET")
 	public.HandleFunc("/item/{id}", h.GetItemDetail).Methods("GET")
+	public.HandleFunc("/bundle", h.GetAllBundles).Methods("GET")
+	public.HandleFunc("/bundle/{id}", h.GetBundleDetail).Methods("GET")
+	public.HandleFunc("/hoster/{id}", h.GetStorefront).Methods("GET")
 }
@@ -1,8 +1,12 @@
 package public
 
 import (
+	"database/sql"
 	"errors"
 
+	"github.com/google/uuid"
+
+	"lalan-be/internal/domain"
 	"lalan-be/internal/dto"
 	"lalan-be/internal/message"
 )
@@ -51,40 +55,138 @@ func (s *publicService) GetAllCategory() ([]dto.CategoryPublicResponse, error) {
 GetAllItems mengambil semua item publik.
 
 Langkah:
-1. Panggil repository untuk ambil data model
-2. Mapping dari model ke DTO
+1. Validasi storeID (opsional) berupa UUID
+2. Panggil repository untuk ambil data model
+3. Mapping dari model ke DTO
 
 Output:
 - ([]dto.ItemDTO, nil) jika sukses
-- (nil, error) jika terjadi kesalahan internal
+- (nil, error) jika store_id tidak valid / terjadi kesalahan internal
 */
-func (s *publicService) GetAllItems() ([]dto.ItemPublicResponse, error) {
-	items, err := s.repo.GetAllItems()
+func (s *publicService) GetAllItems(storeID string) ([]dto.ItemPublicResponse, error) {
+	if storeID != "" {
+		if _, err := uuid.Parse(storeID); err != nil {
+			return nil, errors.New(message.StoreInvalidID)
+		}
+	}
+
+	items, err := s.repo.GetAllItems(storeID)
 	if err != nil {
 		return nil, errors.New(message.InternalError)
 	}
 
-	// Mapping Model -> DTO
-	dtos := make([]dto.ItemPublicResponse, 0)
+	return toItemPublicResponses(items), nil
+}
+
+/*
+toItemPublicResponses memetakan model item ke DTO item publik.
+*/
+func toItemPublicResponses(items []*domain.Item) []dto.ItemPublicResponse {
+	dtos := make([]dto.ItemPublicResponse, 0, len(items))
 	for _, item := range items {
 		dtos = append(dtos, dto.ItemPublicResponse{
-			ID:          item.ID,
-			Name:        item.Name,
-			Description: item.Description,
-			Photos:      item.Photos,
-			Stock:       item.Stock,
-			PickupType:  string(item.PickupType),
-			PricePerDay: item.PricePerDay,
-			Deposit:     item.Deposit,
-			Discount:    item.Discount,
-			CreatedAt:   item.CreatedAt,
-			UpdatedAt:   item.UpdatedAt,
-			CategoryID:  item.CategoryID,
-			HosterID:    item.HosterID,
+			ID:             item.ID,
+			Name:           item.Name,
+			Description:    item.Description,
+			Photos:         item.Photos,
+			Stock:          item.Stock,
+			PickupType:     string(item.PickupType),
+			PricePerDay:    item.PricePerDay,
+			Deposit:        item.Deposit,
+			Discount:       item.Discount,
+			CreatedAt:      item.CreatedAt,
+			UpdatedAt:      item.UpdatedAt,
+			CategoryID:     item.CategoryID,
+			HosterID:       item.HosterID,
+			StoreID:        item.TenantID,
+			HosterVerified: item.HosterVerified,
 		})
 	}
+	return dtos
+}
 
-	return dtos, nil
+/*
+Batas pagination item di storefront.
+*/
+const (
+	DefaultStorefrontLimit = 20
+	MaxStorefrontLimit     = 100
+)
+
+/*
+GetStorefront mengambil halaman toko publik berdasarkan UUID hoster atau slug.
+
+Langkah:
+1. Validasi filter (store_id & category_id harus UUID), normalisasi page/limit
+2. Ambil profil toko (404 jika tidak ada)
+3. Ambil store, T&C umum, kategori, ringkasan rating (ulasan booking), jumlah sewa selesai, dan satu halaman item tampil
+
+Output:
+- (*dto.StorefrontPublicResponse, nil) jika sukses
+- (nil, error) → HosterNotFound / StorefrontInvalidFilter / InternalError
+*/
+func (s *publicService) GetStorefront(ref string, filter dto.StorefrontFilterPublicRequest) (*dto.StorefrontPublicResponse, error) {
+	if ref == "" {
+		return nil, errors.New(message.HosterNotFound)
+	}
+	for _, id := range []string{filter.StoreID, filter.CategoryID} {
+		if id == "" {
+			continue
+		}
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, errors.New(message.StorefrontInvalidFilter)
+		}
+	}
+
+	page, limit := filter.Page, filter.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultStorefrontLimit
+	}
+	if limit > MaxStorefrontLimit {
+		limit = MaxStorefrontLimit
+	}
+
+	storefront, err := s.repo.GetStorefrontHoster(ref)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.HosterNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	hosterID := storefront.Hoster.ID
+
+	if storefront.Stores, err = s.repo.GetStorefrontStores(hosterID); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if storefront.TermsAndConditions, err = s.repo.GetGeneralTermsAndConditions(hosterID); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if storefront.Categories, err = s.repo.GetStorefrontCategories(hosterID); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if storefront.Rating, err = s.repo.GetStorefrontRating(hosterID); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if storefront.CompletedRentals, err = s.repo.GetCompletedRentals(hosterID); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	items, total, err := s.repo.GetStorefrontItems(hosterID, filter, limit, (page-1)*limit)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	storefront.Items = dto.StorefrontItemPagePublicResponse{
+		Items:      toItemPublicResponses(items),
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}
+
+	return storefront, nil
 }
 
 /*
@@ -127,7 +229,8 @@ Parameter:
 
 Langkah:
 1. Panggil repository untuk ambil data JOIN (sudah dalam format DTO)
-2. Return langsung karena repository sudah mapping ke DTO
+2. Lengkapi versi T&C yang wajib disetujui saat booking (tnc_versions)
+3. Lengkapi dimensi & varian beserta tanggal penuh per varian (variants)
 
 Output:
 - (*dto.ItemDetailResponse, nil) jika sukses
@@ -143,18 +246,90 @@ func (s *publicService) GetItemDetail(itemID string) (*dto.ItemDetailResponse, e
 		return nil, errors.New(message.InternalError)
 	}
 
+	tncVersions, err := s.repo.GetItemTnCVersions(itemID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	itemDetail.TnCVersions = tncVersions
+
+	dimensions, variants, err := s.repo.GetItemVariants(itemID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	itemDetail.VariantDimensions = dimensions
+	itemDetail.Variants = variants
+
 	return itemDetail, nil
 }
 
+/*
+GetAllBundles mengambil paket sewa publik, opsional satu store.
+
+Output:
+- ([]dto.BundlePublicResponse, nil) jika sukses
+- (nil, message.StoreInvalidID) jika store_id bukan UUID
+- (nil, message.InternalError) jika terjadi kesalahan internal
+*/
+func (s *publicService) GetAllBundles(storeID string) ([]dto.BundlePublicResponse, error) {
+	if storeID != "" {
+		if _, err := uuid.Parse(storeID); err != nil {
+			return nil, errors.New(message.StoreInvalidID)
+		}
+	}
+
+	bundles, err := s.repo.GetAllBundles(storeID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	return bundles, nil
+}
+
+/*
+GetBundleDetail mengambil detail paket sewa publik.
+
+Langkah:
+1. Ambil paket, store, komponen, dan tanggal penuh dari repository
+2. Lengkapi versi T&C yang wajib disetujui saat booking (store / umum + khusus komponen)
+
+Output:
+- (*dto.BundleDetailPublicResponse, nil) jika sukses
+- (nil, message.BundleNotFound) jika paket tidak ditemukan / tidak tampil
+- (nil, message.InternalError) jika terjadi kesalahan internal
+*/
+func (s *publicService) GetBundleDetail(bundleID string) (*dto.BundleDetailPublicResponse, error) {
+	if _, err := uuid.Parse(bundleID); err != nil {
+		return nil, errors.New(message.BundleNotFound)
+	}
+
+	detail, err := s.repo.GetBundleDetail(bundleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.BundleNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+
+	tncVersions, err := s.repo.GetBundleTnCVersions(bundleID)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	detail.TnCVersions = tncVersions
+
+	return detail, nil
+}
+
 /*
 PublicService adalah kontrak untuk logika bisnis fitur publik.
 Digunakan oleh handler untuk dependency injection.
 */
 type PublicService interface {
 	GetAllCategory() ([]dto.CategoryPublicResponse, error)
-	GetAllItems() ([]dto.ItemPublicResponse, error)
+	GetAllItems(storeID string) ([]dto.ItemPublicResponse, error)
 	GetAllTermsAndConditions() ([]dto.TermsAndConditionsPublicResponse, error)
 	GetItemDetail(itemID string) (*dto.ItemDetailResponse, error)
+	GetStorefront(ref string, filter dto.StorefrontFilterPublicRequest) (*dto.StorefrontPublicResponse, error)
+	GetAllBundles(storeID string) ([]dto.BundlePublicResponse, error)
+	GetBundleDetail(bundleID string) (*dto.BundleDetailPublicResponse, error)
 }
 
 /*
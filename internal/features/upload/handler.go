@@ -0,0 +1,151 @@
+package upload
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/response"
+)
+
+/*
+UploadHandler menangani endpoint HTTP untuk upload langsung ke bucket (presigned PUT).
+Dipakai oleh hoster (foto item) dan customer (dokumen identitas + foto tambahan).
+*/
+type UploadHandler struct {
+	service UploadService
+}
+
+/*
+NewUploadHandler membuat instance handler dengan dependency injection.
+
+Output:
+- *UploadHandler siap digunakan
+*/
+func NewUploadHandler(s UploadService) *UploadHandler {
+	return &UploadHandler{service: s}
+}
+
+/*
+CreateSession menangani POST /api/v1/upload/session
+
+Alur kerja:
+1. Validasi method POST
+2. Ambil userID + role dari JWT context
+3. Decode body (target, target_id, content_type, file_size)
+4. Panggil service untuk membuat session + presigned PUT URL
+
+Output sukses:
+- 201 Created + upload_url, headers, expires_at
+Output error:
+- 400 Bad Request (body / target / content type tidak valid)
+- 401 Unauthorized / 403 Forbidden (target tidak sesuai role / role toko tanpa items:manage)
+- 404 Not Found (item tidak ada atau bukan milik hoster)
+- 500 Internal Server Error
+*/
+func (h *UploadHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MethodNotAllowed)
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	var req dto.CreateUploadSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	session, err := h.service.CreateSession(r.Context(), userID, middleware.GetUserRole(r), req)
+	if err != nil {
+		log.Printf("CreateSession handler: service error user=%s err=%v", userID, err)
+		switch err.Error() {
+		case message.Unauthorized:
+			response.Unauthorized(w, message.Unauthorized)
+		case message.Forbidden:
+			response.Forbidden(w, message.Forbidden)
+		case message.ItemNotFound, message.IdentityNotFound:
+			response.NotFound(w, err.Error())
+		case message.InternalError:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		default:
+			response.BadRequest(w, err.Error())
+		}
+		return
+	}
+
+	response.Success(w, http.StatusCreated, session, message.UploadSessionCreated)
+}
+
+/*
+FinalizeSession menangani POST /api/v1/upload/session/{id}/finalize
+
+Alur kerja:
+1. Validasi method POST
+2. Ambil userID dari JWT context dan session ID dari path
+3. Decode body (opsional, data dokumen wajib untuk identity_document)
+4. Panggil service untuk verifikasi objek + attach ke item/identity
+
+Output sukses:
+- 200 OK + URL publik file dan ID record tujuan
+Output error:
+- 400 Bad Request (objek belum di-upload / tidak valid / dokumen tidak valid / session kadaluarsa atau sudah ditutup)
+- 401 Unauthorized
+- 403 Forbidden (role toko tanpa items:manage)
+- 404 Not Found (session atau item tidak ditemukan)
+- 500 Internal Server Error
+*/
+func (h *UploadHandler) FinalizeSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MethodNotAllowed)
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		response.Unauthorized(w, message.Unauthorized)
+		return
+	}
+
+	sessionID := mux.Vars(r)["id"]
+	if sessionID == "" {
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	var req dto.FinalizeUploadSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(w, message.BadRequest)
+		return
+	}
+
+	result, err := h.service.FinalizeSession(r.Context(), userID, sessionID, req)
+	if err != nil {
+		log.Printf("FinalizeSession handler: service error user=%s session=%s err=%v", userID, sessionID, err)
+		switch err.Error() {
+		case message.Unauthorized:
+			response.Unauthorized(w, message.Unauthorized)
+		case message.UploadSessionNotFound, message.ItemNotFound, message.IdentityNotFound:
+			response.NotFound(w, err.Error())
+		case message.InternalError:
+			response.Error(w, http.StatusInternalServerError, message.InternalError)
+		default:
+			response.BadRequest(w, err.Error())
+		}
+		return
+	}
+
+	response.OK(w, result, message.UploadSessionFinalized)
+}
@@ -0,0 +1,325 @@
+package upload
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+
+	"github.com/jmoiron/sqlx"
+)
+
+/*
+UploadRepository adalah kontrak untuk akses data upload session.
+Termasuk operasi attach objek ke item/identity saat finalize.
+*/
+type UploadRepository interface {
+	CreateSession(session *domain.UploadSession) error
+	GetSession(sessionID, userID string) (*domain.UploadSession, error)
+	IsItemOwner(itemID, hosterID string) (bool, error)
+	IsPendingIdentityOwner(identityID, userID string) (bool, error)
+	AttachItemPhoto(session *domain.UploadSession, url string) error
+	AttachIdentityDocument(session *domain.UploadSession, req *dto.UploadIdentityByCustomerRequest) (string, error)
+	AttachIdentityExtraPhoto(session *domain.UploadSession, url string) error
+	MarkSessionFailed(sessionID, reason string) error
+}
+
+/*
+uploadRepository adalah implementasi konkret dari UploadRepository.
+*/
+type uploadRepository struct {
+	db *sqlx.DB
+}
+
+/*
+NewUploadRepository membuat instance repository dengan koneksi database.
+
+Output:
+- UploadRepository siap digunakan
+*/
+func NewUploadRepository(db *sqlx.DB) UploadRepository {
+	return &uploadRepository{db: db}
+}
+
+/*
+CreateSession menyimpan upload session baru dengan status "pending".
+
+Output sukses:
+- nil → session.ID & CreatedAt terisi
+Output error:
+- error → query gagal
+*/
+func (r *uploadRepository) CreateSession(session *domain.UploadSession) error {
+	query := `
+		INSERT INTO upload_session (
+			user_id, target, target_id, photo_kind, bucket, path, content_type, status, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, 'pending', $8)
+		RETURNING id, status, created_at, updated_at
+	`
+
+	err := r.db.QueryRowx(query,
+		session.UserID, session.Target, session.TargetID, session.PhotoKind, session.Bucket,
+		session.Path, session.ContentType, session.ExpiresAt,
+	).Scan(&session.ID, &session.Status, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		log.Printf("CreateSession: insert error user=%s: %v", session.UserID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+GetSession mengambil upload session milik user tertentu.
+
+Output sukses:
+- (*domain.UploadSession, nil)
+Output error:
+- (nil, sql.ErrNoRows) → session tidak ada / bukan milik user
+- (nil, error) → query gagal
+*/
+func (r *uploadRepository) GetSession(sessionID, userID string) (*domain.UploadSession, error) {
+	var session domain.UploadSession
+	query := `
+		SELECT
+			id, user_id, target, target_id, photo_kind, bucket, path, content_type,
+			status, reason, expires_at, finalized_at, created_at, updated_at
+		FROM upload_session
+		WHERE id = $1 AND user_id = $2
+	`
+
+	if err := r.db.Get(&session, query, sessionID, userID); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("GetSession: query error session=%s: %v", sessionID, err)
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+/*
+IsItemOwner mengecek apakah item dimiliki oleh hoster tertentu.
+
+Output sukses:
+- (true, nil)  → item milik hoster
+- (false, nil) → item tidak ada / bukan milik hoster
+Output error:
+- (false, error) → query gagal
+*/
+func (r *uploadRepository) IsItemOwner(itemID, hosterID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM item WHERE id = $1 AND hoster_id = $2)`
+
+	if err := r.db.Get(&exists, query, itemID, hosterID); err != nil {
+		log.Printf("IsItemOwner: query error item=%s: %v", itemID, err)
+		return false, err
+	}
+	return exists, nil
+}
+
+/*
+IsPendingIdentityOwner mengecek apakah identity dimiliki user dan masih menunggu verifikasi.
+Foto tambahan hanya boleh ditempel ke dokumen yang belum diproses admin.
+
+Output sukses:
+- (true, nil)  → identity milik user dan berstatus pending
+- (false, nil) → identity tidak ada / bukan milik user / sudah diproses
+Output error:
+- (false, error) → query gagal
+*/
+func (r *uploadRepository) IsPendingIdentityOwner(identityID, userID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM identity WHERE id = $1 AND user_id = $2 AND user_role = 'customer' AND status = 'pending')`
+
+	if err := r.db.Get(&exists, query, identityID, userID); err != nil {
+		log.Printf("IsPendingIdentityOwner: query error identity=%s: %v", identityID, err)
+		return false, err
+	}
+	return exists, nil
+}
+
+/*
+AttachItemPhoto menambahkan URL foto ke item.photos dan menutup session dalam satu transaksi.
+
+Alur kerja:
+1. Append URL ke JSONB array item.photos (cek ownership via hoster_id)
+2. Update session → status "finalized" (hanya jika masih pending)
+3. Commit
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → item tidak ditemukan / bukan milik hoster
+- error UploadSessionClosed → session sudah ditutup request lain
+- error → query gagal
+*/
+func (r *uploadRepository) AttachItemPhoto(session *domain.UploadSession, url string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("AttachItemPhoto: begin tx error: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE item
+		SET photos = COALESCE(photos, '[]'::jsonb) || jsonb_build_array($1::text),
+			updated_at = NOW()
+		WHERE id = $2 AND hoster_id = $3
+	`, url, session.TargetID, session.UserID)
+	if err != nil {
+		log.Printf("AttachItemPhoto: update item error session=%s: %v", session.ID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := finalizeSession(tx, session.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+AttachIdentityDocument membuat record identity baru (status pending, nomor dokumen terenkripsi) dan menutup session dalam satu transaksi.
+
+Output sukses:
+- (identityID, nil)
+Output error:
+- ("", error UploadSessionClosed) → session sudah ditutup request lain
+- ("", error) → query gagal
+*/
+func (r *uploadRepository) AttachIdentityDocument(session *domain.UploadSession, req *dto.UploadIdentityByCustomerRequest) (string, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("AttachIdentityDocument: begin tx error: %v", err)
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var holderSex *string
+	if req.HolderSex != "" {
+		holderSex = &req.HolderSex
+	}
+
+	var identityID string
+	err = tx.QueryRow(`
+		INSERT INTO identity (
+			user_id, document_type, document_url, document_country, document_expiry, extra_photos,
+			verified, status, reason, verified_at, created_at, updated_at,
+			document_number_encrypted, document_number_hash, holder_birth_date, holder_sex, duplicate_flag
+		) VALUES (
+			$1, $2, $3, $4, $5, '[]'::jsonb,
+			false, 'pending', '', NULL, NOW(), NOW(), $6, $7, $8, $9,
+			EXISTS (
+				SELECT 1 FROM identity
+				WHERE document_type = $2 AND document_number_hash = $7
+				  AND verified = true AND user_id <> $1 AND user_role = 'customer'
+			)
+		)
+		RETURNING id
+	`, session.UserID, req.DocumentType, req.DocumentURL, req.DocumentCountry, req.DocumentExpiryDate,
+		req.DocumentNumberEncrypted, req.DocumentNumberHash, req.HolderBirthDate, holderSex).Scan(&identityID)
+	if err != nil {
+		log.Printf("AttachIdentityDocument: insert identity error session=%s: %v", session.ID, err)
+		return "", err
+	}
+
+	if err := finalizeSession(tx, session.ID); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return identityID, nil
+}
+
+/*
+AttachIdentityExtraPhoto menambahkan foto tambahan (selfie/sisi belakang) ke identity.extra_photos dan menutup session dalam satu transaksi.
+
+Alur kerja:
+1. Append {"kind", "url"} ke JSONB array extra_photos (hanya identity milik user yang masih pending)
+2. Update session → status "finalized"
+3. Commit
+
+Output sukses:
+- nil
+Output error:
+- sql.ErrNoRows → identity tidak ditemukan / bukan milik user / sudah diproses admin
+- error UploadSessionClosed → session sudah ditutup request lain
+- error → query gagal
+*/
+func (r *uploadRepository) AttachIdentityExtraPhoto(session *domain.UploadSession, url string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		log.Printf("AttachIdentityExtraPhoto: begin tx error: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE identity
+		SET extra_photos = COALESCE(extra_photos, '[]'::jsonb)
+				|| jsonb_build_array(jsonb_build_object('kind', $1::text, 'url', $2::text)),
+			updated_at = NOW()
+		WHERE id = $3 AND user_id = $4 AND status = 'pending'
+	`, session.PhotoKind, url, session.TargetID, session.UserID)
+	if err != nil {
+		log.Printf("AttachIdentityExtraPhoto: update identity error session=%s: %v", session.ID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := finalizeSession(tx, session.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+MarkSessionFailed menandai session gagal beserta alasannya.
+
+Output sukses:
+- nil
+Output error:
+- error → query gagal
+*/
+func (r *uploadRepository) MarkSessionFailed(sessionID, reason string) error {
+	_, err := r.db.Exec(`
+		UPDATE upload_session
+		SET status = 'failed', reason = $1, updated_at = NOW()
+		WHERE id = $2 AND status = 'pending'
+	`, reason, sessionID)
+	if err != nil {
+		log.Printf("MarkSessionFailed: update error session=%s: %v", sessionID, err)
+	}
+	return err
+}
+
+/*
+finalizeSession menutup session pending di dalam transaksi yang sedang berjalan.
+Mencegah finalize ganda jika dua request datang bersamaan.
+*/
+func finalizeSession(tx *sqlx.Tx, sessionID string) error {
+	res, err := tx.Exec(`
+		UPDATE upload_session
+		SET status = 'finalized', finalized_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, sessionID)
+	if err != nil {
+		log.Printf("finalizeSession: update error session=%s: %v", sessionID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return errors.New(message.UploadSessionClosed)
+	}
+	return nil
+}
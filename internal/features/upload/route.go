@@ -0,0 +1,60 @@
+package upload
+
+import (
+	"lalan-be/internal/domain"
+	"lalan-be/internal/middleware"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+/*
+SetupUploadRoutes mendaftarkan endpoint upload langsung ke bucket (presigned PUT).
+
+Alur kerja:
+1. Buat subrouter dengan prefix /api/v1/upload
+2. Terapkan middleware JWT (role dicek di service sesuai target upload)
+3. Request hoster → middleware Hoster (staff harus masih aktif) + permission items:manage,
+semua target milik hoster (foto item) hanya boleh diubah role toko yang mengelola item
+4. Daftarkan endpoint:
+  - POST /session               → minta presigned PUT URL
+  - POST /session/{id}/finalize → verifikasi objek & attach ke item/identity
+
+Output:
+- Router terkonfigurasi dengan endpoint upload untuk hoster & customer
+*/
+func SetupUploadRoutes(router *mux.Router, h *UploadHandler) {
+	protected := router.PathPrefix("/api/v1/upload").Subrouter()
+
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(hosterUploadAccess)
+
+	protected.HandleFunc("/session", h.CreateSession).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/session/{id}/finalize", h.FinalizeSession).Methods("POST", "OPTIONS")
+
+	// Opsional: handler khusus OPTIONS biar return 204 (lebih bersih)
+	protected.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+/*
+hosterUploadAccess menerapkan cek akses toko hanya untuk request role hoster.
+Customer diteruskan apa adanya (target identitas dicek di service).
+
+Output sukses:
+- Lanjut ke handler berikutnya
+Output error:
+- 401 Unauthorized → staff sudah dinonaktifkan / dihapus
+- 403 Forbidden → role toko tidak memiliki permission items:manage
+*/
+func hosterUploadAccess(next http.Handler) http.Handler {
+	hoster := middleware.Hoster(middleware.RequireHosterPermission(domain.HosterPermItemsManage)(next))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if middleware.GetUserRole(r) != "hoster" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		hoster.ServeHTTP(w, r)
+	})
+}
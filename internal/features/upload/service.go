@@ -0,0 +1,317 @@
+package upload
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/dto"
+	"lalan-be/internal/message"
+	"lalan-be/internal/utils"
+)
+
+/*
+UploadSessionTTL adalah batas waktu antara pembuatan session dan finalize.
+Presigned URL sendiri berlaku selama utils.PresignedURLExpiry.
+*/
+const UploadSessionTTL = 1 * time.Hour
+
+/*
+imageExtensions memetakan Content-Type gambar ke ekstensi file di bucket.
+*/
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/jpg":  ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+/*
+UploadService adalah kontrak untuk logika bisnis upload langsung ke bucket.
+*/
+type UploadService interface {
+	CreateSession(ctx context.Context, userID, role string, req dto.CreateUploadSessionRequest) (*dto.UploadSessionResponse, error)
+	FinalizeSession(ctx context.Context, userID, sessionID string, req dto.FinalizeUploadSessionRequest) (*dto.FinalizeUploadSessionResponse, error)
+}
+
+/*
+uploadService adalah implementasi konkret dari UploadService.
+*/
+type uploadService struct {
+	repo    UploadRepository
+	storage utils.Storage
+	config  config.StorageConfig
+}
+
+/*
+NewUploadService membuat instance service dengan dependency injection.
+
+Output:
+- UploadService siap digunakan
+*/
+func NewUploadService(repo UploadRepository, storage utils.Storage, config config.StorageConfig) UploadService {
+	return &uploadService{repo: repo, storage: storage, config: config}
+}
+
+/*
+CreateSession menerbitkan presigned PUT URL untuk satu file.
+
+Alur kerja:
+1. Validasi target sesuai role (item_photo → hoster, identity_document / identity_extra → customer)
+2. Validasi content type (gambar saja) dan file_size jika dikirim
+3. item_photo → pastikan item milik hoster, identity_extra → pastikan identity milik customer & masih pending
+4. Tentukan path di server (client tidak bisa memilih path)
+5. Simpan session pending lalu generate presigned PUT URL
+
+Output sukses:
+- (*dto.UploadSessionResponse, nil)
+Output error:
+- (nil, error) → unauthorized / target atau content type tidak valid / item atau identity tidak ditemukan / internal error
+*/
+func (s *uploadService) CreateSession(ctx context.Context, userID, role string, req dto.CreateUploadSessionRequest) (*dto.UploadSessionResponse, error) {
+	if userID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(req.ContentType))
+	ext, ok := imageExtensions[contentType]
+	if !ok || !utils.AllowedImageTypes[contentType] {
+		return nil, errors.New(message.UploadInvalidContentType)
+	}
+	if req.FileSize < 0 || req.FileSize > utils.MaxImageSize {
+		return nil, fmt.Errorf(message.FileTooLarge, "file")
+	}
+
+	session := &domain.UploadSession{
+		UserID:      userID,
+		Target:      domain.UploadTarget(req.Target),
+		ContentType: contentType,
+		ExpiresAt:   time.Now().Add(UploadSessionTTL),
+	}
+	fileName := uuid.New().String() + ext
+
+	// identity_ktp adalah nama target lama sebelum dokumen identitas digeneralisasi
+	if session.Target == domain.UploadTargetIdentityKTP {
+		session.Target = domain.UploadTargetIdentityDocument
+	}
+
+	switch session.Target {
+	case domain.UploadTargetItemPhoto:
+		if role != "hoster" {
+			return nil, errors.New(message.Forbidden)
+		}
+		if req.TargetID == "" {
+			return nil, errors.New(message.BadRequest)
+		}
+		owned, err := s.repo.IsItemOwner(req.TargetID, userID)
+		if err != nil {
+			return nil, errors.New(message.InternalError)
+		}
+		if !owned {
+			return nil, errors.New(message.ItemNotFound)
+		}
+		targetID := req.TargetID
+		session.TargetID = &targetID
+		session.Bucket = s.config.HosterBucket
+		session.Path = fmt.Sprintf("%s/item/%s/%s", userID, targetID, fileName) // {hosterID}/item/{itemID}/{uuid}.ext
+	case domain.UploadTargetIdentityDocument:
+		if role != "customer" {
+			return nil, errors.New(message.Forbidden)
+		}
+		session.Bucket = s.config.CustomerBucket
+		session.Path = fmt.Sprintf("identity/%s/document_%s", userID, fileName) // identity/{userID}/document_{uuid}.ext
+	case domain.UploadTargetIdentityExtra:
+		if role != "customer" {
+			return nil, errors.New(message.Forbidden)
+		}
+		kind := domain.IdentityPhotoKind(req.PhotoKind)
+		if kind != domain.IdentityPhotoSelfie && kind != domain.IdentityPhotoBack {
+			return nil, errors.New(message.IdentityInvalidPhotoKind)
+		}
+		if req.TargetID == "" {
+			return nil, errors.New(message.BadRequest)
+		}
+		owned, err := s.repo.IsPendingIdentityOwner(req.TargetID, userID)
+		if err != nil {
+			return nil, errors.New(message.InternalError)
+		}
+		if !owned {
+			return nil, errors.New(message.IdentityNotFound)
+		}
+		targetID, photoKind := req.TargetID, string(kind)
+		session.TargetID = &targetID
+		session.PhotoKind = &photoKind
+		session.Bucket = s.config.CustomerBucket
+		session.Path = fmt.Sprintf("identity/%s/%s_%s", userID, photoKind, fileName) // identity/{userID}/{kind}_{uuid}.ext
+	default:
+		return nil, errors.New(message.UploadInvalidTarget)
+	}
+
+	if err := s.repo.CreateSession(session); err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+
+	uploadURL, err := s.storage.GetPresignedUploadURL(ctx, session.Path, contentType, utils.PresignedURLExpiry, session.Bucket)
+	if err != nil {
+		log.Printf("CreateSession(upload service): presign failed session=%s: %v", session.ID, err)
+		_ = s.repo.MarkSessionFailed(session.ID, "presign failed")
+		return nil, errors.New(message.InternalError)
+	}
+
+	return &dto.UploadSessionResponse{
+		SessionID: session.ID,
+		UploadURL: uploadURL,
+		Method:    "PUT",
+		Headers:   map[string]string{"Content-Type": contentType},
+		Path:      session.Path,
+		MaxSize:   utils.MaxImageSize,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+/*
+FinalizeSession memverifikasi objek yang sudah di-upload client lalu menempelkannya ke record tujuan.
+
+Alur kerja:
+1. Ambil session milik user, pastikan masih pending dan belum kadaluarsa
+2. identity_document → validasi dokumen dulu (session tetap pending jika data salah, client bisa ulang finalize)
+3. Cek objek ada di bucket via Storage.Exists
+4. Validasi ukuran ≤ MaxImageSize dan Content-Type sama dengan session (tidak valid → objek dihapus, session failed)
+5. Attach ke item.photos (item_photo), record identity baru (identity_document), atau identity.extra_photos (identity_extra)
+
+Output sukses:
+- (*dto.FinalizeUploadSessionResponse, nil)
+Output error:
+- (nil, error) → session tidak ditemukan / kadaluarsa / sudah ditutup / objek tidak ada / tidak valid / internal error
+*/
+func (s *uploadService) FinalizeSession(ctx context.Context, userID, sessionID string, req dto.FinalizeUploadSessionRequest) (*dto.FinalizeUploadSessionResponse, error) {
+	if userID == "" {
+		return nil, errors.New(message.Unauthorized)
+	}
+	if sessionID == "" {
+		return nil, errors.New(message.BadRequest)
+	}
+
+	session, err := s.repo.GetSession(sessionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(message.UploadSessionNotFound)
+		}
+		return nil, errors.New(message.InternalError)
+	}
+	if session.Status != "pending" {
+		return nil, errors.New(message.UploadSessionClosed)
+	}
+	if time.Now().After(session.ExpiresAt) {
+		_ = s.repo.MarkSessionFailed(session.ID, "expired")
+		return nil, errors.New(message.UploadSessionExpired)
+	}
+
+	// Session lama mungkin masih bertarget identity_ktp
+	if session.Target == domain.UploadTargetIdentityKTP {
+		session.Target = domain.UploadTargetIdentityDocument
+	}
+
+	var doc *utils.ProtectedDocument
+	if session.Target == domain.UploadTargetIdentityDocument {
+		number := req.DocumentNumber
+		if number == "" {
+			number = req.NIK
+		}
+		doc, err = utils.ProtectDocument(utils.DocumentInput{
+			Type:    req.DocumentType,
+			Number:  number,
+			Country: req.DocumentCountry,
+			Expiry:  req.DocumentExpiry,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	exists, err := s.storage.Exists(ctx, session.Path, session.Bucket)
+	if err != nil {
+		log.Printf("FinalizeSession(upload service): exists check failed session=%s: %v", session.ID, err)
+		return nil, errors.New(message.InternalError)
+	}
+	if !exists {
+		return nil, errors.New(message.UploadObjectNotFound)
+	}
+
+	obj, err := s.storage.Stat(ctx, session.Path, session.Bucket)
+	if err != nil {
+		return nil, errors.New(message.InternalError)
+	}
+	if obj.Size <= 0 || obj.Size > utils.MaxImageSize || !strings.EqualFold(obj.ContentType, session.ContentType) {
+		log.Printf("FinalizeSession(upload service): invalid object session=%s size=%d type=%s", session.ID, obj.Size, obj.ContentType)
+		if err := s.storage.Delete(ctx, session.Path, session.Bucket); err != nil {
+			log.Printf("FinalizeSession(upload service): failed to delete invalid object %s: %v", session.Path, err)
+		}
+		_ = s.repo.MarkSessionFailed(session.ID, message.UploadObjectInvalid)
+		return nil, errors.New(message.UploadObjectInvalid)
+	}
+
+	url := utils.BuildPublicURL(s.config.Domain, session.Bucket, session.Path)
+	result := &dto.FinalizeUploadSessionResponse{
+		SessionID: session.ID,
+		Target:    string(session.Target),
+		URL:       url,
+	}
+
+	switch session.Target {
+	case domain.UploadTargetItemPhoto:
+		if err := s.repo.AttachItemPhoto(session, url); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.New(message.ItemNotFound)
+			}
+			if err.Error() == message.UploadSessionClosed {
+				return nil, err
+			}
+			return nil, errors.New(message.InternalError)
+		}
+		result.TargetID = *session.TargetID
+	case domain.UploadTargetIdentityDocument:
+		identityID, err := s.repo.AttachIdentityDocument(session, &dto.UploadIdentityByCustomerRequest{
+			UserID:                  userID,
+			DocumentType:            string(doc.Type),
+			DocumentCountry:         doc.Country,
+			DocumentURL:             url,
+			DocumentExpiryDate:      doc.Expiry,
+			DocumentNumberEncrypted: doc.Encrypted,
+			DocumentNumberHash:      doc.Hash,
+			HolderBirthDate:         doc.BirthDate,
+			HolderSex:               doc.Sex,
+		})
+		if err != nil {
+			if err.Error() == message.UploadSessionClosed {
+				return nil, err
+			}
+			return nil, errors.New(message.InternalError)
+		}
+		result.TargetID = identityID
+	case domain.UploadTargetIdentityExtra:
+		if err := s.repo.AttachIdentityExtraPhoto(session, url); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.New(message.IdentityNotFound)
+			}
+			if err.Error() == message.UploadSessionClosed {
+				return nil, err
+			}
+			return nil, errors.New(message.InternalError)
+		}
+		result.TargetID = *session.TargetID
+	default:
+		return nil, errors.New(message.UploadInvalidTarget)
+	}
+
+	log.Printf("FinalizeSession(upload service): session=%s attached to %s %s", session.ID, session.Target, result.TargetID)
+	return result, nil
+}
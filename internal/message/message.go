@@ -29,11 +29,21 @@ const (
 	ItemRetrieved         = "item retrieved successfully"
 	ItemHasActiveBookings = "item masih terikat dengan pesanan aktif dan tidak dapat dihapus"
 	ItemVisibilityUpdated = "item visibility updated successfully"
+	ItemImportCompleted   = "items imported"
+	ItemImportRejected    = "import rejected, fix the listed rows and upload again"
+	ItemImportInvalidFile = "invalid import file, upload a csv or xlsx file using the export header"
+	ItemImportTooManyRows = "import file exceeds the maximum of 1000 items"
+	ItemImportInvalidMode = "invalid mode, allowed: all_or_nothing, partial"
+	ItemExportInvalid     = "invalid export format, allowed: csv, xlsx"
+	ItemImportDuplicateID = "item ID appears more than once in the file"
+	ItemStockFromUnits    = "stock is derived from units while unit tracking is enabled"
+	ItemStockFromVariants = "stock is derived from variants, update the variant stock instead"
 
 	// Authentication & Authorization
 	LoginFailed            = "invalid email or password"
 	AdminAccessRequired    = "admin access required"
 	HosterAccessRequired   = "hoster access required"
+	HosterPermissionDenied = "your store role does not allow this action"
 	CustomerAccessRequired = "customer access required"
 	InvalidStatus          = "invalid status"
 	BookingConflict        = "booking conflict"
@@ -58,6 +68,20 @@ const (
 	BookingNotCancellable   = "booking cannot be cancelled"
 	BookingOverlap          = "booking time overlaps"
 	BookingStatusUpdated    = "booking status updated successfully"
+	BookingInvalidFilter    = "invalid booking filter"
+	BookingInvalidCursor    = "invalid or expired cursor"
+	BookingInvalidExport    = "export format must be csv or xlsx"
+	BookingUnitsUnavailable = "not enough available units to hand over this booking"
+	BookingInvalidUnits     = "invalid unit allocation for this booking"
+	BookingStatusChanged    = "booking status was changed by another request, please reload"
+	PickupCodeRetrieved     = "pickup code retrieved"
+	PickupCodeUnavailable   = "pickup code is only available for paid bookings that have not been picked up"
+	PickupCodeInvalid       = "invalid pickup code"
+	PickupCodeNotFound      = "pickup code does not match any of your bookings"
+	PickupCodeUsed          = "pickup code has already been used"
+	PickupCodeVerified      = "pickup verified, booking is now on rent"
+	PickupCodeRequired      = "items can only be handed over by verifying the customer's pickup code"
+	PickupQRInvalidFormat   = "qr format must be png or svg"
 
 	// KTP
 	KTPUploaded                = "KTP uploaded successfully"
@@ -75,6 +99,68 @@ const (
 	KTPRequired                = "KTP upload required"
 	KTPUploadFailed            = "failed to upload KTP"
 
+	// IDENTITY DOCUMENT (KTP, SIM, Passport, KITAS)
+	IdentityUploaded            = "identity document uploaded successfully"
+	IdentityUpdated             = "identity document updated successfully"
+	IdentityStatusRetrieved     = "identity status retrieved"
+	IdentityListRetrieved       = "identity list retrieved successfully"
+	IdentityApproved            = "identity approved"
+	IdentityRejected            = "identity rejected"
+	IdentityRequired            = "identity document upload required"
+	IdentityRejectedUploadNew   = "identity document rejected, please upload a new one"
+	IdentityDocumentRequired    = "identity document photo required"
+	IdentityInvalidType         = "invalid document type, allowed: ktp, sim, passport, kitas"
+	IdentityInvalidPhotoKind    = "invalid photo kind, allowed: selfie, back"
+	IdentityNotFound            = "identity not found"
+	DocumentNumberRequired      = "document number required"
+	DocumentNumberInvalid       = "invalid document number"
+	DocumentCountryInvalid      = "invalid document country, use ISO 3166-1 alpha-3 code"
+	DocumentExpiryRequired      = "document expiry date required (YYYY-MM-DD)"
+	DocumentExpired             = "document expired"
+	DocumentExpiresBeforeRental = "identity document expires before the rental ends"
+	DocumentAlreadyVerified     = "document already verified on another account"
+
+	// IDENTITY REVIEW QUEUE (Admin)
+	IdentityQueueRetrieved         = "identity review queue retrieved"
+	IdentityHistoryRetrieved       = "identity review history retrieved"
+	IdentityClaimed                = "identity claimed for review"
+	IdentityReleased               = "identity released back to queue"
+	IdentityClaimedByOther         = "identity is being reviewed by another admin"
+	IdentityNotClaimed             = "identity is not claimed by you"
+	IdentityAlreadyReviewed        = "identity already reviewed"
+	IdentityInvalidLease           = "invalid lease_minutes, allowed 1-60"
+	IdentityInvalidFilter          = "invalid queue filter"
+	RejectionTemplateRequired      = "template_id required when rejecting identity"
+	RejectionTemplateNotFound      = "rejection template not found"
+	RejectionTemplateInactive      = "rejection template is inactive"
+	RejectionTemplateCodeExists    = "rejection template code already exists"
+	RejectionTemplateFieldRequired = "code, title and reason required"
+	RejectionTemplateListRetrieved = "rejection templates retrieved"
+	RejectionTemplateCreated       = "rejection template created"
+	RejectionTemplateUpdated       = "rejection template updated"
+	RejectionTemplateDeactivated   = "rejection template deactivated"
+
+	// HOSTER IDENTITY (KYC hoster)
+	BusinessDocumentNumberRequired = "business document number required when the document photo is uploaded"
+	BusinessDocumentFileRequired   = "business document photo required when the number is filled"
+	BusinessDocumentNIBInvalid     = "invalid NIB: must be 13 digits"
+	BusinessDocumentNPWPInvalid    = "invalid NPWP: must be 15 or 16 digits"
+	HosterUnverifiedItemLimit      = "unverified store has reached the active item limit, please verify your identity"
+	IdentityInvalidRole            = "invalid role filter, allowed: customer, hoster"
+
+	// ANALYTICS (Hoster)
+	AnalyticsRetrieved          = "analytics retrieved successfully"
+	AnalyticsInvalidPeriod      = "invalid period: use from/to YYYY-MM-DD, max 366 days (92 days for daily granularity)"
+	AnalyticsInvalidGranularity = "invalid granularity, allowed: day, week, month"
+	AnalyticsInvalidSort        = "invalid sort, allowed: revenue, utilisation"
+
+	// NIK
+	NIKRequired         = "NIK required"
+	NIKInvalidLength    = "invalid NIK: must be 16 digits"
+	NIKInvalidRegion    = "invalid NIK: unknown region code"
+	NIKInvalidBirthDate = "invalid NIK: invalid birth date"
+	NIKInvalidSequence  = "invalid NIK: invalid sequence number"
+
 	// ID Validation
 	UserIDRequired     = "user ID required"
 	HosterIDRequired   = "hoster ID required"
@@ -97,9 +183,203 @@ const (
 	ProfileUpdated   = "profile updated successfully"
 	ProfileNotFound  = "profile not found"
 
+	// PROFILE MEDIA (foto profil & banner toko)
+	ProfilePhotoUpdated = "profile photo updated successfully"
+	ProfilePhotoDeleted = "profile photo deleted successfully"
+	StoreBannerUpdated  = "store banner updated successfully"
+	StoreBannerDeleted  = "store banner deleted successfully"
+
+	// TEAM (staff toko)
+	TeamRetrieved          = "team retrieved successfully"
+	TeamMemberInvited      = "team member invited"
+	TeamMemberUpdated      = "team member updated"
+	TeamMemberRemoved      = "team member removed"
+	TeamInviteResent       = "invitation resent"
+	TeamInviteAccepted     = "invitation accepted, you can now log in"
+	TeamMemberNotFound     = "team member not found"
+	TeamInvalidRole        = "invalid role, allowed: manager, staff"
+	TeamInvalidStatus      = "invalid status, allowed: active, disabled"
+	TeamInviteInvalid      = "invalid or expired invitation"
+	TeamInviteNotPending   = "member has already accepted the invitation"
+	TeamMemberNotActivated = "member has not accepted the invitation yet"
+	TeamPasswordTooShort   = "password must be at least 8 characters"
+
+	// STORE (cabang toko / tenant)
+	StoreRetrieved         = "stores retrieved successfully"
+	StoreCreated           = "store created"
+	StoreUpdated           = "store updated"
+	StoreDeleted           = "store deleted"
+	StoreNotFound          = "store not found"
+	StoreInvalidID         = "invalid store_id"
+	StoreNameExists        = "store name already used"
+	StoreHasItems          = "store still has items, move or delete them first"
+	StoreHasActiveBookings = "store still has active bookings"
+	StoreDefaultRequired   = "default store cannot be removed, set another store as default first"
+	StoreDeliveryDisabled  = "this store does not offer delivery"
+	BookingMixedStores     = "all items in a booking must come from the same store"
+
+	// STORE SCHEDULE (jam buka, hari libur & slot serah terima)
+	StoreHoursRetrieved  = "store hours retrieved successfully"
+	StoreHoursUpdated    = "store hours updated"
+	StoreHoursInvalid    = "invalid store hours: weekday 0-6 once each, HH:MM open before close, slot_minutes 15/30/60/120, slot_capacity > 0"
+	StoreClosureAdded    = "store closure added"
+	StoreClosureDeleted  = "store closure deleted"
+	StoreClosureNotFound = "store closure not found"
+	StoreClosureInvalid  = "invalid closure date, use YYYY-MM-DD from today onwards"
+	StoreSlotsRetrieved  = "store slots retrieved successfully"
+	StoreClosedOnDate    = "store is closed on the selected pickup or return date"
+	ScheduleRetrieved    = "schedule retrieved successfully"
+	ScheduleInvalidDate  = "invalid date, use YYYY-MM-DD"
+	BookingSlotRequired  = "pickup_time and return_time are required for this store"
+	BookingSlotInvalid   = "pickup_time or return_time is outside the store's available slots"
+	BookingSlotFull      = "selected pickup or return slot is fully booked"
+
+	// STOREFRONT (halaman toko publik)
+	StorefrontRetrieved     = "storefront retrieved successfully"
+	StorefrontInvalidFilter = "invalid storefront filter"
+
+	// LEDGER & SETTLEMENT (payout hoster)
+	LedgerBalanceRetrieved     = "balance retrieved successfully"
+	LedgerStatementRetrieved   = "statement retrieved successfully"
+	LedgerInvalidPeriod        = "invalid period: use from/to YYYY-MM-DD, max 366 days"
+	LedgerSynced               = "ledger synced with bookings"
+	SettlementRetrieved        = "settlements retrieved successfully"
+	SettlementBatchCreated     = "settlement batch created"
+	SettlementBatchPaid        = "settlement batch marked as paid"
+	SettlementBatchNotFound    = "settlement batch not found"
+	SettlementBatchAlreadyPaid = "settlement batch already paid"
+	SettlementInvalidPeriod    = "invalid period_end: use YYYY-MM-DD before today"
+	SettlementInvalidStatus    = "invalid status, allowed: pending, paid"
+	SettlementNothingDue       = "no hoster balance due up to period_end"
+
+	// ITEM UNIT (aset fisik per item)
+	UnitRetrieved        = "units retrieved successfully"
+	UnitCreated          = "unit created"
+	UnitUpdated          = "unit updated"
+	UnitNotFound         = "unit not found"
+	UnitLabelExists      = "unit label already exists for this item"
+	UnitInvalidCondition = "invalid condition, allowed: good, fair, damaged"
+	UnitInvalidStatus    = "invalid status, allowed: available, maintenance, retired"
+	UnitRented           = "unit is rented, update it after the booking is completed"
+	UnitTrackingUpdated  = "unit tracking updated"
+	UnitDamageRecorded   = "damage note recorded"
+	UnitTrackingVariants = "unit tracking is not available for items with variants"
+
+	// ITEM VARIANT (ukuran, warna, dll.)
+	VariantRetrieved           = "variants retrieved successfully"
+	VariantUpdated             = "variants updated"
+	VariantNotFound            = "variant not found"
+	VariantInvalid             = "invalid variants: up to 3 dimensions and 100 variants, each variant needs one non-empty option per dimension, stock >= 0, price_per_day > 0, deposit >= 0"
+	VariantDuplicate           = "variant option combination appears more than once"
+	VariantUnitTracking        = "variants are not available while unit tracking is enabled"
+	BookingVariantRequired     = "variant_id is required for items with variants"
+	BookingVariantInvalid      = "variant not found or no longer available for this item"
+	BookingVariantPriceChanged = "variant price has changed, refresh the item and try again"
+	BookingVariantUnavailable  = "selected variant does not have enough stock for the rental dates"
+	VariantInBundle            = "items that are part of a bundle cannot have variants, remove them from the bundle first"
+
+	// BUNDLE (paket sewa berisi beberapa item)
+	BundleRetrieved           = "bundles retrieved successfully"
+	BundleCreated             = "bundle created"
+	BundleUpdated             = "bundle updated"
+	BundleDeleted             = "bundle deleted"
+	BundleNotFound            = "bundle not found"
+	BundleInvalid             = "invalid bundle: name is required (max 255 characters), price_per_day > 0, deposit >= 0, up to 20 distinct items with quantity 1 - 100 and at least 2 units in total"
+	BundleItemInvalid         = "bundle items must belong to the bundle store and cannot have variants"
+	BookingBundleInvalid      = "bundle not found or no longer available"
+	BookingBundlePriceChanged = "bundle price has changed, refresh the bundle and try again"
+	BookingBundleUnavailable  = "bundle items do not have enough stock for the rental dates"
+	BookingItemUnavailable    = "items do not have enough stock for the rental dates"
+	AmendmentBundleQuantity   = "quantity of bundle items cannot be changed, only the end date can be extended"
+
+	// HANDOVER (serah terima barang)
+	HandoverRetrieved        = "handover retrieved successfully"
+	HandoverSaved            = "handover saved"
+	HandoverConfirmed        = "handover confirmed"
+	HandoverNotFound         = "handover not found"
+	HandoverInvalidKind      = "invalid handover kind, allowed: pickup, return"
+	HandoverInvalidStatus    = "handover cannot be recorded in the current booking status"
+	HandoverPickupRequired   = "record the pickup handover before the return handover"
+	HandoverInvalidItems     = "handover must list every booking item exactly once"
+	HandoverInvalidCondition = "invalid condition, allowed: good, fair, damaged"
+	HandoverInvalidChecklist = "invalid checklist, max 30 points with a label of up to 100 characters"
+	HandoverInvalidPhotos    = "invalid photos, max 6 images per item"
+	HandoverLocked           = "handover already confirmed by the customer and can no longer be changed"
+	HandoverAlreadyConfirmed = "handover already confirmed"
+
+	// LATE FEE (denda keterlambatan)
+	LateFeeRetrieved         = "late fee rules retrieved successfully"
+	LateFeeSaved             = "late fee rule saved"
+	LateFeeDeleted           = "late fee rule deleted"
+	LateFeeNotFound          = "late fee rule not found"
+	LateFeeInvalidType       = "invalid fee_type, allowed: fixed, percent"
+	LateFeeInvalidAmount     = "invalid amount, fixed must be greater than 0 and percent between 1 and 10000 basis points"
+	LateFeeInvalidGrace      = "invalid grace_hours, allowed: 0 - 168"
+	OverdueBookingsRetrieved = "overdue bookings retrieved successfully"
+
+	// BOOKING AMENDMENT (perpanjangan & perubahan quantity)
+	AmendmentRetrieved      = "booking amendments retrieved successfully"
+	AmendmentRequested      = "booking amendment requested, waiting for hoster approval"
+	AmendmentApproved       = "booking amendment approved"
+	AmendmentRejected       = "booking amendment rejected"
+	AmendmentCancelled      = "booking amendment cancelled"
+	AmendmentNotFound       = "booking amendment not found"
+	AmendmentNoChanges      = "amendment must extend end_date or change at least one quantity"
+	AmendmentInvalidEndDate = "invalid end_date, must be YYYY-MM-DD after the current end date"
+	AmendmentInvalidItems   = "invalid items, each booking_item_id must belong to the booking and quantity must be greater than 0"
+	AmendmentInvalidStatus  = "booking can only be amended while paid (on_progress) or rented (on_rent)"
+	AmendmentQuantityLocked = "quantity can only be changed before items are picked up"
+	AmendmentPending        = "booking already has a pending amendment"
+	AmendmentUnavailable    = "not enough stock for the requested dates or quantity"
+	AmendmentStale          = "booking has changed since the amendment was requested"
+	AmendmentAlreadyDecided = "booking amendment has already been decided or cancelled"
+	AmendmentInvalidFilter  = "invalid status filter, allowed: pending, approved, rejected, cancelled"
+
+	// BOOKING REVIEW (ulasan customer)
+	ReviewCreated       = "review submitted"
+	ReviewInvalidRating = "invalid rating, allowed: 1 - 5"
+	ReviewNotAllowed    = "only completed bookings can be reviewed"
+	ReviewAlreadyExists = "booking has already been reviewed"
+
+	// CUSTOMER BLOCKLIST (blokir customer per hoster)
+	CustomerBlockRetrieved  = "blocked customers retrieved successfully"
+	CustomerBlocked         = "customer blocked"
+	CustomerUnblocked       = "customer unblocked"
+	CustomerBlockNotFound   = "customer is not blocked"
+	CustomerBlockNoDocument = "customer has no verified identity document to block"
+	BookingNotAvailable     = "booking for these items cannot be processed at the moment"
+
+	// CALENDAR FEED (iCal)
+	CalendarFeedRetrieved   = "calendar feed retrieved successfully"
+	CalendarFeedRegenerated = "calendar feed link generated, previous link is no longer valid"
+	CalendarFeedRevoked     = "calendar feed link revoked"
+	CalendarFeedNotFound    = "calendar feed not found"
+
 	// TERMS AND CONDITIONS (TnC)
 	TnCCreated   = "terms and conditions created successfully"
 	TnCUpdated   = "terms and conditions updated successfully"
 	TnCRetrieved = "terms and conditions retrieved successfully"
 	TnCNotFound  = "terms and conditions not found"
+
+	// TERMS AND CONDITIONS VERSION (versi & persetujuan booking)
+	TnCAlreadyExists      = "terms and conditions already exist for this scope, update them to publish a new version"
+	TnCInvalidScope       = "store_id and item_id cannot be set together"
+	TnCVersionsRetrieved  = "terms and conditions versions retrieved successfully"
+	TnCAcceptanceRequired = "please review and accept the current terms and conditions"
+
+	// STORAGE
+	StorageGCCompleted     = "storage garbage collection completed"
+	StorageGCDryRun        = "storage garbage collection dry-run completed"
+	StorageGCInvalidPeriod = "grace period must be at least 1 hour"
+
+	// UPLOAD SESSION
+	UploadSessionCreated     = "upload session created"
+	UploadSessionFinalized   = "upload finalized"
+	UploadSessionNotFound    = "upload session not found"
+	UploadSessionExpired     = "upload session expired"
+	UploadSessionClosed      = "upload session already finalized or failed"
+	UploadInvalidTarget      = "invalid upload target"
+	UploadInvalidContentType = "invalid content type, allowed: jpg, jpeg, png, webp"
+	UploadObjectNotFound     = "uploaded file not found, upload to the presigned URL first"
+	UploadObjectInvalid      = "uploaded file does not match the session (size or content type)"
 )
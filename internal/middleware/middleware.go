@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
 	"lalan-be/internal/message"
 	"lalan-be/internal/response"
 
@@ -22,18 +23,43 @@ type contextKey string
 
 /*
 UserIDKey dan UserRoleKey adalah key untuk menyimpan data user dari JWT ke dalam request context.
+MemberIDKey dan HosterRoleKey hanya terisi untuk user role hoster (owner atau staff toko).
 */
 const (
-	UserIDKey   contextKey = "user_id"
-	UserRoleKey contextKey = "user_role"
+	UserIDKey     contextKey = "user_id"
+	UserRoleKey   contextKey = "user_role"
+	MemberIDKey   contextKey = "member_id"
+	HosterRoleKey contextKey = "hoster_role"
 )
 
 /*
 Claims adalah custom JWT claims yang menyimpan role user selain standard claims.
+Untuk staff toko: Subject = ID staff, StoreID = ID hoster, MemberRole = manager / staff.
 */
 type Claims struct {
 	jwt.RegisteredClaims
-	Role string `json:"role"`
+	Role       string `json:"role"`
+	StoreID    string `json:"store_id,omitempty"`
+	MemberRole string `json:"member_role,omitempty"`
+}
+
+/*
+HosterMemberResolver memetakan ID staff ke toko dan role terbaru.
+active=false jika staff sudah dinonaktifkan / dihapus.
+*/
+type HosterMemberResolver func(memberID string) (storeID string, role domain.HosterRole, active bool, err error)
+
+/*
+hosterMemberResolver dipasang sekali saat startup (lihat SetHosterMemberResolver).
+*/
+var hosterMemberResolver HosterMemberResolver
+
+/*
+SetHosterMemberResolver memasang resolver staff toko yang dipakai middleware Hoster.
+Tanpa resolver, role staff diambil dari JWT apa adanya (tidak bisa dicabut sebelum token expired).
+*/
+func SetHosterMemberResolver(fn HosterMemberResolver) {
+	hosterMemberResolver = fn
 }
 
 /*
@@ -109,6 +135,31 @@ func GetUserID(r *http.Request) string {
 	return ""
 }
 
+/*
+GetMemberID mengambil ID staff toko dari context.
+
+Output sukses:
+- string memberID jika request dilakukan staff
+- string kosong jika request dilakukan owner / bukan hoster
+*/
+func GetMemberID(r *http.Request) string {
+	if val := r.Context().Value(MemberIDKey); val != nil {
+		return val.(string)
+	}
+	return ""
+}
+
+/*
+GetHosterRole mengambil role user di dalam toko (owner, manager, staff) dari context.
+Hanya terisi setelah middleware Hoster.
+*/
+func GetHosterRole(r *http.Request) domain.HosterRole {
+	if val := r.Context().Value(HosterRoleKey); val != nil {
+		return val.(domain.HosterRole)
+	}
+	return ""
+}
+
 /*
 GetUserRole mengambil role user dari context.
 
@@ -176,9 +227,14 @@ func JWTMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Simpan ke context
+		// Simpan ke context (staff toko → user_id = ID toko, member_id = ID staff)
 		ctx := context.WithValue(r.Context(), UserIDKey, claims.Subject)
 		ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+		if claims.StoreID != "" {
+			ctx = context.WithValue(ctx, UserIDKey, claims.StoreID)
+			ctx = context.WithValue(ctx, MemberIDKey, claims.Subject)
+			ctx = context.WithValue(ctx, HosterRoleKey, domain.HosterRole(claims.MemberRole))
+		}
 		r = r.WithContext(ctx)
 
 		if isDev {
@@ -212,27 +268,85 @@ func Admin(next http.Handler) http.Handler {
 }
 
 /*
-Hoster adalah middleware yang memastikan user memiliki role "hoster".
+Hoster adalah middleware yang memastikan user memiliki role "hoster" (owner atau staff toko).
+
+Alur kerja:
+1. Tolak jika role bukan hoster
+2. Owner → hoster_role = owner
+3. Staff → cek ke resolver bahwa staff masih aktif & masih di toko yang sama, lalu pakai role terbaru
 
 Output sukses:
-- Lanjut ke handler berikutnya
+- Lanjut ke handler berikutnya (GetUserID = ID toko, GetHosterRole = owner/manager/staff)
 Output error:
 - 403 Forbidden → role bukan hoster
+- 401 Unauthorized → staff sudah dinonaktifkan / dipindah
 */
 func Hoster(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		role := GetUserRole(r)
 		userID := GetUserID(r)
-		log.Printf("Hoster middleware: checking access - user_id=%s role=%s", userID, role)
+		memberID := GetMemberID(r)
+		log.Printf("Hoster middleware: checking access - user_id=%s member_id=%s role=%s", userID, memberID, role)
 
 		if role != "hoster" {
 			response.Forbidden(w, message.HosterAccessRequired)
 			return
 		}
-		next.ServeHTTP(w, r)
+
+		hosterRole := domain.HosterRoleOwner
+		if memberID != "" {
+			hosterRole = GetHosterRole(r)
+			if hosterMemberResolver != nil {
+				storeID, currentRole, active, err := hosterMemberResolver(memberID)
+				if err != nil {
+					log.Printf("Hoster middleware: resolve member %s failed: %v", memberID, err)
+					response.Error(w, http.StatusInternalServerError, message.InternalError)
+					return
+				}
+				if !active || storeID != userID {
+					response.Unauthorized(w, message.Unauthorized)
+					return
+				}
+				hosterRole = currentRole
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), HosterRoleKey, hosterRole)
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+/*
+HosterPermission membungkus handler agar hanya bisa diakses role toko yang memiliki permission.
+Dipakai setelah middleware Hoster, contoh:
+
+	protected.HandleFunc("/item", middleware.HosterPermission(domain.HosterPermItemsManage, h.CreateItem))
+
+Output sukses:
+- Lanjut ke handler
+Output error:
+- 403 Forbidden → role toko tidak memiliki permission
+*/
+func HosterPermission(perm domain.HosterPermission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !GetHosterRole(r).Can(perm) {
+			log.Printf("HosterPermission: denied user_id=%s member_id=%s role=%s perm=%s", GetUserID(r), GetMemberID(r), GetHosterRole(r), perm)
+			response.Forbidden(w, message.HosterPermissionDenied)
+			return
+		}
+		next(w, r)
+	}
+}
+
+/*
+RequireHosterPermission adalah versi middleware (untuk subrouter.Use) dari HosterPermission.
+*/
+func RequireHosterPermission(perm domain.HosterPermission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return HosterPermission(perm, next.ServeHTTP)
+	}
+}
+
 /*
 Customer adalah middleware yang memastikan user memiliki role "customer".
 
@@ -278,6 +392,31 @@ func GenerateToken(userID, role string) (string, error) {
 	return token.SignedString(config.GetJWTSecret())
 }
 
+/*
+GenerateMemberToken membuat JWT staff toko (role hoster) dengan masa berlaku 1 jam.
+
+Output sukses:
+- (string token, nil)
+Output error:
+- ("", error) → gagal signing token
+*/
+func GenerateMemberToken(memberID, storeID, memberRole string) (string, error) {
+	expiration := time.Now().Add(1 * time.Hour)
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   memberID,
+			ExpiresAt: jwt.NewNumericDate(expiration),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		Role:       "hoster",
+		StoreID:    storeID,
+		MemberRole: memberRole,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(config.GetJWTSecret())
+}
+
 /*
 RefreshToken memvalidasi token lama dan mengeluarkan token baru dengan data yang sama.
 
@@ -296,5 +435,8 @@ func RefreshToken(oldTokenString string) (string, error) {
 		return "", err
 	}
 
+	if claims.StoreID != "" {
+		return GenerateMemberToken(claims.Subject, claims.StoreID, claims.MemberRole)
+	}
 	return GenerateToken(claims.Subject, claims.Role)
 }
@@ -57,6 +57,17 @@ type FileMetadata struct {
 	UploadedAt  time.Time
 }
 
+/*
+StorageObject berisi informasi ringkas satu objek di bucket.
+Digunakan sebagai return value List().
+*/
+type StorageObject struct {
+	Path         string
+	Size         int64
+	ContentType  string // Hanya terisi dari Stat(), kosong untuk List()
+	LastModified time.Time
+}
+
 /*
 Storage adalah kontrak (interface) untuk semua operasi object storage.
 Implementasi saat ini: Supabase (S3-compatible).
@@ -67,6 +78,9 @@ type Storage interface {
 	Delete(ctx context.Context, url string, bucket string) error
 	Exists(ctx context.Context, path string, bucket string) (bool, error)                                  // Tambah bucket
 	GetPresignedURL(ctx context.Context, path string, expiry time.Duration, bucket string) (string, error) // Tambah bucket
+	List(ctx context.Context, prefix string, bucket string) ([]StorageObject, error)
+	Stat(ctx context.Context, path string, bucket string) (*StorageObject, error)
+	GetPresignedUploadURL(ctx context.Context, path string, contentType string, expiry time.Duration, bucket string) (string, error)
 }
 
 /*
@@ -327,6 +341,121 @@ func (s *SupabaseStorage) GetPresignedURL(ctx context.Context, path string, expi
 	return result.URL, nil
 }
 
+/*
+GetPresignedUploadURL menghasilkan URL sementara untuk upload langsung (PUT) ke bucket.
+URL terikat ke path dan Content-Type tertentu, client wajib mengirim header Content-Type yang sama.
+
+Output sukses:
+- string URL presigned PUT (default expiry 15 menit)
+Output error:
+- error → gagal init client / generate presign
+*/
+func (s *SupabaseStorage) GetPresignedUploadURL(ctx context.Context, path string, contentType string, expiry time.Duration, bucket string) (string, error) {
+	client, err := s.getClient()
+	if err != nil {
+		return "", err
+	}
+
+	path = sanitizePath(path)
+	if expiry == 0 {
+		expiry = PresignedURLExpiry
+	}
+
+	presignClient := s3.NewPresignClient(client)
+	result, err := presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(path),
+		ContentType: aws.String(contentType),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		log.Printf("SupabaseStorage GetPresignedUploadURL: failed for %s: %v", path, err)
+		return "", fmt.Errorf("failed to generate presigned upload URL: %w", err)
+	}
+
+	log.Printf("SupabaseStorage GetPresignedUploadURL: generated for %s (expires in %v)", path, expiry)
+	return result.URL, nil
+}
+
+/*
+Stat mengambil metadata satu objek (ukuran, Content-Type, waktu modifikasi).
+
+Output sukses:
+- *StorageObject
+Output error:
+- error → objek tidak ada / gagal init client / network
+*/
+func (s *SupabaseStorage) Stat(ctx context.Context, path string, bucket string) (*StorageObject, error) {
+	client, err := s.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	path = sanitizePath(path)
+
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(path),
+	})
+	if err != nil {
+		log.Printf("SupabaseStorage Stat: failed for %s: %v", path, err)
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return &StorageObject{
+		Path:         path,
+		Size:         aws.ToInt64(head.ContentLength),
+		ContentType:  aws.ToString(head.ContentType),
+		LastModified: aws.ToTime(head.LastModified),
+	}, nil
+}
+
+/*
+List mengambil semua objek di bucket dengan prefix tertentu (kosong = seluruh bucket).
+
+Alur kerja:
+1. Lazy init client
+2. ListObjectsV2 dengan paginator sampai halaman terakhir
+3. Mapping ke []StorageObject
+
+Output sukses:
+- []StorageObject (bisa kosong)
+Output error:
+- error → gagal init client / network
+*/
+func (s *SupabaseStorage) List(ctx context.Context, prefix string, bucket string) ([]StorageObject, error) {
+	client, err := s.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+	if prefix = sanitizePath(prefix); prefix != "" {
+		input.Prefix = aws.String(prefix + "/")
+	}
+
+	var objects []StorageObject
+	paginator := s3.NewListObjectsV2Paginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("SupabaseStorage List: failed to list bucket %s prefix %s: %v", bucket, prefix, err)
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		for _, obj := range page.Contents {
+			objects = append(objects, StorageObject{
+				Path:         aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+	}
+
+	log.Printf("SupabaseStorage List: found %d objects in bucket %s", len(objects), bucket)
+	return objects, nil
+}
+
 /*
 buildPublicURL membangun URL publik untuk file yang sudah di-upload.
 */
@@ -419,3 +548,10 @@ func ExtractPathFromURL(url, storageDomain, bucket string) string {
 	}
 	return url
 }
+
+/*
+BuildPublicURL membangun URL publik Supabase dari path relatif (kebalikan ExtractPathFromURL).
+*/
+func BuildPublicURL(storageDomain, bucket, path string) string {
+	return fmt.Sprintf("%s/%s/%s", storageDomain, bucket, sanitizePath(path))
+}
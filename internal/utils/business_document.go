@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/message"
+)
+
+var (
+	nibNumberPattern  = regexp.MustCompile(`^[0-9]{13}$`)
+	npwpNumberPattern = regexp.MustCompile(`^([0-9]{15}|[0-9]{16})$`)
+)
+
+/*
+ProtectBusinessDocumentNumber memvalidasi nomor dokumen usaha hoster lalu mengenkripsinya.
+
+Aturan per jenis:
+- nib  → 13 digit
+- npwp → 15 digit (format lama) atau 16 digit (format baru / NIK)
+Titik, strip, dan spasi pada nomor diabaikan.
+
+Output sukses:
+- (nomor terenkripsi, nil)
+Output error:
+- ("", error) → pesan message.BusinessDocument*
+*/
+func ProtectBusinessDocumentNumber(docType domain.BusinessDocumentType, raw string) (string, error) {
+	number := normalizeDocumentNumber(raw)
+	if number == "" {
+		return "", errors.New(message.BusinessDocumentNumberRequired)
+	}
+
+	switch docType {
+	case domain.BusinessDocumentNIB:
+		if !nibNumberPattern.MatchString(number) {
+			return "", errors.New(message.BusinessDocumentNIBInvalid)
+		}
+	case domain.BusinessDocumentNPWP:
+		if !npwpNumberPattern.MatchString(number) {
+			return "", errors.New(message.BusinessDocumentNPWPInvalid)
+		}
+	}
+
+	return EncryptString(number, config.GetNIKEncryptionKey())
+}
+
+/*
+IsBusinessDocumentValidationError mengecek apakah error berasal dari validasi dokumen usaha (→ 400 Bad Request).
+*/
+func IsBusinessDocumentValidationError(err error) bool {
+	switch err.Error() {
+	case message.BusinessDocumentNumberRequired, message.BusinessDocumentFileRequired,
+		message.BusinessDocumentNIBInvalid, message.BusinessDocumentNPWPInvalid:
+		return true
+	}
+	return false
+}
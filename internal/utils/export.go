@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+/*
+RowWriter menulis data tabular baris per baris (streaming) ke CSV atau XLSX.
+Close wajib dipanggil untuk flush data terakhir / menutup file XLSX.
+*/
+type RowWriter interface {
+	WriteRow(values ...any) error
+	Close() error
+}
+
+/*
+NewRowWriter membuat RowWriter sesuai format ("csv" atau "xlsx").
+
+Output sukses:
+- (RowWriter, nil)
+Output error:
+- (nil, error) → format tidak dikenal / gagal menulis header file XLSX
+*/
+func NewRowWriter(format string, w io.Writer, sheetName string) (RowWriter, error) {
+	switch format {
+	case "csv":
+		return &csvRowWriter{w: csv.NewWriter(w)}, nil
+	case "xlsx":
+		return newXLSXRowWriter(w, sheetName)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+/*
+ExportContentType mengembalikan Content-Type HTTP untuk format export.
+*/
+func ExportContentType(format string) string {
+	if format == "xlsx" {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ===================================================================
+// CSV
+// ===================================================================
+
+/*
+csvRowWriter menulis CSV dan flush setiap 100 baris agar data langsung terkirim ke client.
+*/
+type csvRowWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func (c *csvRowWriter) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = sanitizeCSVCell(formatCell(v))
+	}
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+	c.rows++
+	if c.rows%100 == 0 {
+		c.w.Flush()
+	}
+	return c.w.Error()
+}
+
+func (c *csvRowWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+/*
+sanitizeCSVCell mencegah CSV/formula injection saat file dibuka di Excel/Sheets
+(nilai yang diawali =, +, -, @ diberi prefix petik tunggal).
+*/
+func sanitizeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value // angka negatif tetap angka
+		}
+		return "'" + value
+	}
+	return value
+}
+
+// ===================================================================
+// XLSX
+// ===================================================================
+
+/*
+xlsxRowWriter menulis workbook satu sheet lewat stream writer excelize,
+baris yang sudah ditulis tidak ditahan di memori.
+*/
+type xlsxRowWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	rows   int
+}
+
+func newXLSXRowWriter(w io.Writer, sheetName string) (*xlsxRowWriter, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName(f.GetSheetName(0), sheetName); err != nil {
+		f.Close()
+		return nil, err
+	}
+	stream, err := f.NewStreamWriter(sheetName)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &xlsxRowWriter{w: w, file: f, stream: stream}, nil
+}
+
+func (x *xlsxRowWriter) WriteRow(values ...any) error {
+	x.rows++
+	cells := make([]any, len(values))
+	for i, v := range values {
+		switch v.(type) {
+		case int, int64, float64:
+			cells[i] = v
+		default:
+			cells[i] = formatCell(v)
+		}
+	}
+	cell, err := excelize.CoordinatesToCellName(1, x.rows)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, cells)
+}
+
+func (x *xlsxRowWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.w)
+}
+
+/*
+formatCell mengubah nilai sel menjadi teks (waktu → "YYYY-MM-DD HH:MM").
+*/
+func formatCell(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case time.Time:
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02 15:04")
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+		return formatCell(*t)
+	default:
+		return fmt.Sprint(t)
+	}
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSanitizeCSVCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"Tenda", "Tenda"},
+		{"=SUM(A1)", "'=SUM(A1)"},
+		{"+62812", "+62812"},
+		{"+abc", "'+abc"},
+		{"@cmd", "'@cmd"},
+		{"-abc", "'-abc"},
+		{"-5", "-5"},
+		{"-2.5", "-2.5"},
+		{"\tx", "'\tx"},
+	}
+	for _, tt := range tests {
+		if got := sanitizeCSVCell(tt.value); got != tt.want {
+			t.Errorf("sanitizeCSVCell(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+		if back := unsanitizeCSVCell(sanitizeCSVCell(tt.value)); back != tt.value {
+			t.Errorf("unsanitizeCSVCell(sanitizeCSVCell(%q)) = %q", tt.value, back)
+		}
+	}
+}
+
+func TestFormatCell(t *testing.T) {
+	ts := time.Date(2025, 12, 1, 9, 30, 0, 0, time.UTC)
+	var nilTime *time.Time
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"nil", nil, ""},
+		{"string", "abc", "abc"},
+		{"int", 150000, "150000"},
+		{"bool", true, "true"},
+		{"time", ts, "2025-12-01 09:30"},
+		{"zero time", time.Time{}, ""},
+		{"time pointer", &ts, "2025-12-01 09:30"},
+		{"nil time pointer", nilTime, ""},
+	}
+	for _, tt := range tests {
+		if got := formatCell(tt.value); got != tt.want {
+			t.Errorf("%s: formatCell = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCSVRowWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewRowWriter("csv", &buf, "ignored")
+	if err != nil {
+		t.Fatalf("NewRowWriter: %v", err)
+	}
+	if err := w.WriteRow("name", "price"); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+	if err := w.WriteRow("=HYPERLINK(\"x\")", -5); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := "name,price\n\"'=HYPERLINK(\"\"x\"\")\",-5\n"
+	if buf.String() != want {
+		t.Fatalf("csv = %q, want %q", buf.String(), want)
+	}
+
+	rows, err := ReadRows("csv", &buf, 10)
+	if err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	if rows[1][0] != "=HYPERLINK(\"x\")" || rows[1][1] != "-5" {
+		t.Fatalf("round trip row = %q", rows[1])
+	}
+}
+
+func TestXLSXRowWriterEscapesText(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewRowWriter("xlsx", &buf, `Booking "Desember"`)
+	if err != nil {
+		t.Fatalf("NewRowWriter: %v", err)
+	}
+	if err := w.WriteRow("a<b>&c", "  spasi  ", nil, 12, 2.5); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	rows, err := ReadRows("xlsx", bytes.NewReader(buf.Bytes()), 10)
+	if err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	want := []string{"a<b>&c", "  spasi  ", "", "12", "2.5"}
+	if strings.Join(rows[0], "|") != strings.Join(want, "|") {
+		t.Fatalf("row = %q, want %q", rows[0], want)
+	}
+}
+
+func TestNewRowWriterUnsupportedFormat(t *testing.T) {
+	if _, err := NewRowWriter("pdf", &bytes.Buffer{}, "x"); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+	if got := ExportContentType("xlsx"); !strings.Contains(got, "spreadsheetml") {
+		t.Fatalf("ExportContentType(xlsx) = %q", got)
+	}
+	if got := ExportContentType("csv"); !strings.HasPrefix(got, "text/csv") {
+		t.Fatalf("ExportContentType(csv) = %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+ICalEvent adalah satu event sepanjang hari (all-day) di feed iCalendar.
+Start & End hanya dipakai tanggalnya (UTC), End eksklusif sesuai RFC 5545.
+*/
+type ICalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	Status      string // "CONFIRMED" atau "TENTATIVE"
+	UpdatedAt   time.Time
+}
+
+/*
+WriteICalendar menulis VCALENDAR (RFC 5545) berisi events ke w.
+
+Alur kerja:
+1. Tulis header kalender (nama kalender + interval refresh 1 jam)
+2. Tulis setiap event sebagai VEVENT all-day
+3. Semua teks di-escape dan baris panjang di-fold maksimal 75 octet
+
+Output sukses:
+- nil
+Output error:
+- error → gagal menulis ke w
+*/
+func WriteICalendar(w io.Writer, name string, events []ICalEvent) error {
+	bw := bufio.NewWriter(w)
+	now := time.Now().UTC().Format("20060102T150405Z")
+
+	writeICalLine(bw, "BEGIN:VCALENDAR")
+	writeICalLine(bw, "VERSION:2.0")
+	writeICalLine(bw, "PRODID:-//Lalan//Hoster Booking Calendar//ID")
+	writeICalLine(bw, "CALSCALE:GREGORIAN")
+	writeICalLine(bw, "METHOD:PUBLISH")
+	writeICalLine(bw, "X-WR-CALNAME:"+escapeICalText(name))
+	writeICalLine(bw, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeICalLine(bw, "X-PUBLISHED-TTL:PT1H")
+
+	for _, event := range events {
+		stamp := now
+		if !event.UpdatedAt.IsZero() {
+			stamp = event.UpdatedAt.UTC().Format("20060102T150405Z")
+		}
+
+		writeICalLine(bw, "BEGIN:VEVENT")
+		writeICalLine(bw, "UID:"+escapeICalText(event.UID))
+		writeICalLine(bw, "DTSTAMP:"+stamp)
+		writeICalLine(bw, "LAST-MODIFIED:"+stamp)
+		writeICalLine(bw, "DTSTART;VALUE=DATE:"+event.Start.UTC().Format("20060102"))
+		writeICalLine(bw, "DTEND;VALUE=DATE:"+event.End.UTC().Format("20060102"))
+		writeICalLine(bw, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(bw, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			writeICalLine(bw, "LOCATION:"+escapeICalText(event.Location))
+		}
+		if event.Status != "" {
+			writeICalLine(bw, "STATUS:"+event.Status)
+		}
+		writeICalLine(bw, "TRANSP:TRANSPARENT")
+		writeICalLine(bw, "END:VEVENT")
+	}
+
+	writeICalLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+/*
+escapeICalText meng-escape karakter khusus nilai TEXT iCalendar (\ ; , dan baris baru).
+*/
+func escapeICalText(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "").Replace(value)
+}
+
+/*
+writeICalLine menulis satu content line diakhiri CRLF.
+Baris > 75 octet di-fold (CRLF + spasi) tanpa memotong karakter UTF-8.
+*/
+func writeICalLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // baris lanjutan diawali spasi
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/domain"
+	"lalan-be/internal/message"
+)
+
+var (
+	simNumberPattern      = regexp.MustCompile(`^[0-9]{12,14}$`)
+	passportNumberPattern = regexp.MustCompile(`^[A-Z0-9]{6,9}$`)
+	kitasNumberPattern    = regexp.MustCompile(`^[A-Z0-9]{8,20}$`)
+	countryCodePattern    = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+/*
+DocumentInput adalah data dokumen identitas mentah dari client.
+*/
+type DocumentInput struct {
+	Type    string // "ktp", "sim", "passport", "kitas" (kosong = "ktp")
+	Number  string // NIK / nomor SIM / nomor paspor / nomor KITAS
+	Country string // ISO 3166-1 alpha-3, wajib untuk passport & kitas
+	Expiry  string // YYYY-MM-DD, wajib untuk sim, passport, kitas
+}
+
+/*
+ProtectedDocument adalah dokumen identitas yang sudah tervalidasi dan siap disimpan.
+*/
+type ProtectedDocument struct {
+	Type      domain.DocumentType
+	Number    string     // Nomor ter-normalisasi (hanya untuk proses, tidak disimpan)
+	Country   string     // ISO 3166-1 alpha-3
+	Expiry    *time.Time // nil untuk KTP
+	BirthDate *time.Time // Hanya KTP (decode NIK)
+	Sex       string     // Hanya KTP (decode NIK)
+	Encrypted string     // AES-256-GCM, disimpan di identity.document_number_encrypted
+	Hash      string     // HMAC-SHA256, disimpan di identity.document_number_hash
+}
+
+/*
+ParseDocumentType memvalidasi jenis dokumen (kosong dianggap KTP untuk kompatibilitas).
+*/
+func ParseDocumentType(raw string) (domain.DocumentType, error) {
+	switch t := domain.DocumentType(strings.ToLower(strings.TrimSpace(raw))); t {
+	case "":
+		return domain.DocumentTypeKTP, nil
+	case domain.DocumentTypeKTP, domain.DocumentTypeSIM, domain.DocumentTypePassport, domain.DocumentTypeKITAS:
+		return t, nil
+	default:
+		return "", errors.New(message.IdentityInvalidType)
+	}
+}
+
+/*
+ProtectDocument memvalidasi dokumen sesuai jenisnya lalu menyiapkan nomor terenkripsi + blind index.
+
+Aturan per jenis:
+- ktp      → NIK valid (ParseNIK), negara IDN, tanpa masa berlaku
+- sim      → 12-14 digit, negara IDN, masa berlaku wajib & belum lewat
+- passport → 6-9 alfanumerik, negara wajib, masa berlaku wajib & belum lewat
+- kitas    → 8-20 alfanumerik, negara asal wajib (bukan IDN), masa berlaku wajib & belum lewat
+
+Output sukses:
+- (*ProtectedDocument, nil)
+Output error:
+- (nil, error) → pesan message.Identity* / message.Document* / message.NIK*
+*/
+func ProtectDocument(in DocumentInput) (*ProtectedDocument, error) {
+	docType, err := ParseDocumentType(in.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &ProtectedDocument{
+		Type:    docType,
+		Number:  normalizeDocumentNumber(in.Number),
+		Country: strings.ToUpper(strings.TrimSpace(in.Country)),
+	}
+
+	switch docType {
+	case domain.DocumentTypeKTP:
+		info, err := ParseNIK(in.Number)
+		if err != nil {
+			return nil, err
+		}
+		doc.Number = info.NIK
+		doc.Country = "IDN"
+		doc.BirthDate = &info.BirthDate
+		doc.Sex = info.Sex
+	case domain.DocumentTypeSIM:
+		if doc.Number == "" {
+			return nil, errors.New(message.DocumentNumberRequired)
+		}
+		if !simNumberPattern.MatchString(doc.Number) {
+			return nil, errors.New(message.DocumentNumberInvalid)
+		}
+		doc.Country = "IDN"
+	case domain.DocumentTypePassport, domain.DocumentTypeKITAS:
+		if doc.Number == "" {
+			return nil, errors.New(message.DocumentNumberRequired)
+		}
+		pattern := passportNumberPattern
+		if docType == domain.DocumentTypeKITAS {
+			pattern = kitasNumberPattern
+		}
+		if !pattern.MatchString(doc.Number) {
+			return nil, errors.New(message.DocumentNumberInvalid)
+		}
+		if !countryCodePattern.MatchString(doc.Country) {
+			return nil, errors.New(message.DocumentCountryInvalid)
+		}
+		if docType == domain.DocumentTypeKITAS && doc.Country == "IDN" {
+			return nil, errors.New(message.DocumentCountryInvalid)
+		}
+	}
+
+	if docType != domain.DocumentTypeKTP {
+		expiry, err := parseDocumentExpiry(in.Expiry)
+		if err != nil {
+			return nil, err
+		}
+		doc.Expiry = expiry
+	}
+
+	doc.Encrypted, err = EncryptString(doc.Number, config.GetNIKEncryptionKey())
+	if err != nil {
+		return nil, err
+	}
+	doc.Hash = DocumentBlindIndex(doc.Type, doc.Country, doc.Number)
+
+	return doc, nil
+}
+
+/*
+DocumentBlindIndex menghasilkan blind index nomor dokumen.
+KTP memakai NIK saja (kompatibel dengan data lama), jenis lain memakai "tipe:negara:nomor".
+*/
+func DocumentBlindIndex(docType domain.DocumentType, country, number string) string {
+	value := number
+	if docType != domain.DocumentTypeKTP {
+		value = string(docType) + ":" + country + ":" + number
+	}
+	return BlindIndex(value, config.GetNIKBlindIndexKey())
+}
+
+/*
+RevealDocumentNumber mendekripsi nomor dokumen yang tersimpan di database.
+*/
+func RevealDocumentNumber(encrypted string) (string, error) {
+	return DecryptString(encrypted, config.GetNIKEncryptionKey())
+}
+
+/*
+MaskDocumentNumber menyamarkan nomor dokumen untuk ditampilkan.
+KTP: 6 digit wilayah + 4 digit terakhir, jenis lain: 2 karakter awal + 3 karakter terakhir.
+*/
+func MaskDocumentNumber(docType domain.DocumentType, number string) string {
+	if docType == domain.DocumentTypeKTP || docType == "" {
+		return MaskNIK(number)
+	}
+	if len(number) <= 5 {
+		return strings.Repeat("*", len(number))
+	}
+	return number[:2] + strings.Repeat("*", len(number)-5) + number[len(number)-3:]
+}
+
+/*
+normalizeDocumentNumber menghapus spasi/titik/strip dan mengubah ke huruf besar.
+*/
+func normalizeDocumentNumber(raw string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "", "/", "").Replace(strings.TrimSpace(raw)))
+}
+
+/*
+parseDocumentExpiry memvalidasi masa berlaku dokumen (wajib, format YYYY-MM-DD, belum lewat).
+*/
+func parseDocumentExpiry(raw string) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, errors.New(message.DocumentExpiryRequired)
+	}
+	expiry, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, errors.New(message.DocumentExpiryRequired)
+	}
+	if expiry.Before(time.Now().Truncate(24 * time.Hour)) {
+		return nil, errors.New(message.DocumentExpired)
+	}
+	return &expiry, nil
+}
+
+/*
+IsDocumentValidationError mengecek apakah error berasal dari validasi dokumen (→ 400 Bad Request).
+*/
+func IsDocumentValidationError(err error) bool {
+	switch err.Error() {
+	case message.IdentityInvalidType, message.DocumentNumberRequired, message.DocumentNumberInvalid,
+		message.DocumentCountryInvalid, message.DocumentExpiryRequired, message.DocumentExpired,
+		message.NIKRequired, message.NIKInvalidLength, message.NIKInvalidRegion,
+		message.NIKInvalidBirthDate, message.NIKInvalidSequence:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+/*
+ErrTooManyRows dikembalikan ReadRows jika file melebihi batas baris.
+*/
+var ErrTooManyRows = errors.New("too many rows")
+
+/*
+maxXLSXUnzipSize membatasi ukuran isi file XLSX setelah dekompresi,
+agar file zip kecil yang mengembang sangat besar tidak menghabiskan memori.
+*/
+const maxXLSXUnzipSize = 50 << 20
+
+/*
+ReadRows membaca file CSV / XLSX (sheet pertama) menjadi baris-baris teks.
+Kebalikan dari NewRowWriter, sehingga file hasil export bisa diunggah kembali.
+
+Alur kerja:
+1. CSV: BOM dibuang, jumlah kolom per baris boleh berbeda, prefix petik anti formula injection dibuang
+2. XLSX (github.com/xuri/excelize): sheet pertama di workbook, semua sel dibaca sebagai teks mentah
+3. Baris kosong di tengah XLSX tetap dikembalikan (slice kosong) agar nomor baris sama dengan di file
+
+Output sukses:
+- ([][]string, nil) → index 0 = baris 1 (header)
+Output error:
+- (nil, ErrTooManyRows) → lebih dari maxRows baris
+- (nil, error) → format tidak dikenal / file rusak
+*/
+func ReadRows(format string, r io.Reader, maxRows int) ([][]string, error) {
+	switch format {
+	case "csv":
+		return readCSVRows(r, maxRows)
+	case "xlsx":
+		return readXLSXRows(r, maxRows)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+}
+
+// ===================================================================
+// CSV
+// ===================================================================
+
+func readCSVRows(r io.Reader, maxRows int) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) >= maxRows {
+			return nil, ErrTooManyRows
+		}
+		if len(rows) == 0 && len(record) > 0 {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+		}
+		for i, value := range record {
+			record[i] = unsanitizeCSVCell(value)
+		}
+		rows = append(rows, record)
+	}
+}
+
+/*
+unsanitizeCSVCell membuang prefix petik tunggal yang ditambahkan sanitizeCSVCell saat export.
+*/
+func unsanitizeCSVCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// ===================================================================
+// XLSX
+// ===================================================================
+
+func readXLSXRows(r io.Reader, maxRows int) ([][]string, error) {
+	f, err := excelize.OpenReader(r, excelize.Options{
+		RawCellValue:      true,
+		UnzipSizeLimit:    maxXLSXUnzipSize,
+		UnzipXMLSizeLimit: maxXLSXUnzipSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("xlsx file has no worksheet")
+	}
+	iter, err := f.Rows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var rows [][]string
+	for iter.Next() {
+		record, err := iter.Columns()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, record)
+		if len(record) > 0 && len(rows) > maxRows {
+			return nil, ErrTooManyRows
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	// Baris kosong di akhir sheet tidak dikembalikan
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// buildXLSX membuat fixture XLSX lewat excelize: cells = referensi sel → nilai di sheet pertama.
+func buildXLSX(t *testing.T, cells map[string]any) []byte {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	for ref, value := range cells {
+		if err := f.SetCellValue("Sheet1", ref, value); err != nil {
+			t.Fatalf("SetCellValue %s: %v", ref, err)
+		}
+	}
+	// Sheet kedua tidak boleh ikut terbaca
+	if _, err := f.NewSheet("Lain"); err != nil {
+		t.Fatalf("NewSheet: %v", err)
+	}
+	if err := f.SetCellValue("Lain", "A1", "bukan sheet pertama"); err != nil {
+		t.Fatalf("SetCellValue Lain: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadRowsXLSX(t *testing.T) {
+	data := buildXLSX(t, map[string]any{
+		"A1": "name", "B1": "price_per_day",
+		"A2": "Tenda Dome", "B2": 150000,
+		"A4": "Kompor", "C4": " spasi ",
+	})
+
+	got, err := ReadRows("xlsx", bytes.NewReader(data), 10)
+	if err != nil {
+		t.Fatalf("ReadRows error: %v", err)
+	}
+	want := [][]string{
+		{"name", "price_per_day"},
+		{"Tenda Dome", "150000"},
+		nil, // baris kosong di tengah tetap menempati nomor baris
+		{"Kompor", "", " spasi "},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("rows = %q, want %q", got, want)
+	}
+	for i := range want {
+		if strings.Join(got[i], "|") != strings.Join(want[i], "|") {
+			t.Fatalf("row %d = %q, want %q", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestReadRowsXLSXErrors(t *testing.T) {
+	if _, err := ReadRows("xlsx", strings.NewReader("name,price\nTenda,1000\n"), 100); err == nil {
+		t.Fatal("expected error for non-xlsx file")
+	}
+
+	data := buildXLSX(t, map[string]any{"A1": "name", "A11": "Tenda"})
+	if _, err := ReadRows("xlsx", bytes.NewReader(data), 10); !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("error = %v, want ErrTooManyRows", err)
+	}
+}
+
+func TestReadRowsXLSXRoundTrip(t *testing.T) {
+	const total = 5000
+
+	var buf bytes.Buffer
+	w, err := NewRowWriter("xlsx", &buf, "Items & <Stok>")
+	if err != nil {
+		t.Fatalf("NewRowWriter: %v", err)
+	}
+	header := []any{"name", "price_per_day", "note"}
+	if err := w.WriteRow(header...); err != nil {
+		t.Fatalf("WriteRow header: %v", err)
+	}
+	for i := 1; i < total; i++ {
+		if err := w.WriteRow(fmt.Sprintf("Item <%d> & \"co\"", i), i*1000, "=SUM(A1)"); err != nil {
+			t.Fatalf("WriteRow %d: %v", i, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	rows, err := ReadRows("xlsx", bytes.NewReader(buf.Bytes()), total)
+	if err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	if len(rows) != total {
+		t.Fatalf("len(rows) = %d, want %d", len(rows), total)
+	}
+	if !reflect.DeepEqual(rows[0], []string{"name", "price_per_day", "note"}) {
+		t.Fatalf("header = %q", rows[0])
+	}
+	want := []string{"Item <4999> & \"co\"", "4999000", "=SUM(A1)"}
+	if !reflect.DeepEqual(rows[total-1], want) {
+		t.Fatalf("last row = %q, want %q", rows[total-1], want)
+	}
+
+	if _, err := ReadRows("xlsx", bytes.NewReader(buf.Bytes()), total-1); !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("error = %v, want ErrTooManyRows", err)
+	}
+}
+
+func TestReadRowsCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		maxRows int
+		want    [][]string
+		wantErr error
+	}{
+		{
+			name:    "BOM dibuang dan kolom boleh berbeda",
+			input:   "\ufeffname,price\nTenda,1000,extra\nKompor\n",
+			maxRows: 10,
+			want:    [][]string{{"name", "price"}, {"Tenda", "1000", "extra"}, {"Kompor"}},
+		},
+		{
+			name:    "prefix anti formula injection dibuang",
+			input:   "'=SUM(A1),'-abc,'x,-5\n",
+			maxRows: 10,
+			want:    [][]string{{"=SUM(A1)", "-abc", "'x", "-5"}},
+		},
+		{
+			name:    "melebihi batas baris",
+			input:   "a\nb\nc\n",
+			maxRows: 2,
+			wantErr: ErrTooManyRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadRows("csv", strings.NewReader(tt.input), tt.maxRows)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadRows error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRowsUnsupportedFormat(t *testing.T) {
+	if _, err := ReadRows("ods", strings.NewReader(""), 10); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
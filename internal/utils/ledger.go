@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"lalan-be/internal/domain"
+)
+
+/*
+MaxCommissionRateBps adalah tarif komisi maksimal (10000 basis point = 100%).
+*/
+const MaxCommissionRateBps = 10000
+
+/*
+CommissionAmount menghitung komisi platform dari sewa bersih.
+Dibulatkan half-up ke rupiah terdekat agar hasil sama di setiap perhitungan ulang.
+*/
+func CommissionAmount(netRental int64, rateBps int) int64 {
+	if netRental <= 0 || rateBps <= 0 {
+		return 0
+	}
+	return (netRental*int64(rateBps) + MaxCommissionRateBps/2) / MaxCommissionRateBps
+}
+
+/*
+BuildBookingLedger menurunkan transaksi ledger yang seharusnya ada untuk satu booking.
+Hanya memakai kolom booking (rental, discount, deposit, late_fee, status, locked_until, updated_at),
+perubahan booking yang disetujui (urut decided_at), dan tarif komisi, sehingga hasilnya bisa direproduksi kapan saja.
+
+Alur kerja:
+1. Status on_progress / on_rent / completed → booking_payment (waktu = locked_until, di-set saat dibayar)
+  - Debit platform_cash sebesar sewa bersih + deposit
+  - Kredit unearned_rental (sewa bersih) dan customer_deposit (deposit)
+  - Jika ada perubahan booking, nilai yang dibayar = nilai lama perubahan pertama
+
+2. Setiap perubahan booking → booking_amendment (waktu = decided_at) sebesar selisih sewa bersih & deposit
+  - Bertambah: debit platform_cash, kredit unearned_rental / customer_deposit
+  - Berkurang: kebalikannya
+
+3. Status completed → rental_income dan deposit_refund (waktu = updated_at, status completed final)
+  - rental_income: debit unearned_rental, kredit platform_commission (komisi) + hoster_payable (sisanya)
+  - deposit_refund: debit customer_deposit, kredit platform_cash
+  - late_fee (jika ada denda): debit platform_cash, kredit platform_commission (komisi) + hoster_payable (sisanya)
+
+4. Baris bernilai 0 tidak dibuat, transaksi tanpa baris dilewati
+
+Output:
+- []domain.LedgerTransaction (tanpa ID), kosong jika booking belum dibayar
+*/
+func BuildBookingLedger(b domain.Booking, amendments []domain.BookingAmendment, commissionRateBps int) []domain.LedgerTransaction {
+	var txs []domain.LedgerTransaction
+
+	switch b.Status {
+	case "on_progress", "on_rent", "completed":
+	default:
+		return txs
+	}
+
+	netRental := netBookingRental(b.Rental, b.Discount)
+	deposit := int64(b.Deposit)
+
+	paidRental, paidDeposit := netRental, deposit
+	if len(amendments) > 0 {
+		paidRental = netBookingRental(amendments[0].OldRental, b.Discount)
+		paidDeposit = int64(amendments[0].OldDeposit)
+	}
+
+	payment := newBookingTransaction(b, domain.LedgerKindBookingPayment, b.LockedUntil)
+	payment.Description = fmt.Sprintf("Pembayaran booking %s", b.ID)
+	payment.Entries = ledgerEntries(
+		debit(domain.LedgerAccountPlatformCash, paidRental+paidDeposit),
+		credit(domain.LedgerAccountUnearnedRental, paidRental),
+		credit(domain.LedgerAccountCustomerDeposit, paidDeposit),
+	)
+	txs = appendTransaction(txs, payment)
+
+	for _, a := range amendments {
+		rentalDelta := netBookingRental(a.NewRental, b.Discount) - netBookingRental(a.OldRental, b.Discount)
+		depositDelta := int64(a.NewDeposit - a.OldDeposit)
+
+		amendmentID := a.ID
+		change := newBookingTransaction(b, domain.LedgerKindBookingAmendment, b.UpdatedAt)
+		change.AmendmentID = &amendmentID
+		if a.DecidedAt != nil {
+			change.OccurredAt = *a.DecidedAt
+		}
+		change.Description = fmt.Sprintf("Perubahan booking %s (amendment %s)", b.ID, a.ID)
+		change.Entries = ledgerEntries(
+			debit(domain.LedgerAccountPlatformCash, positive(rentalDelta)+positive(depositDelta)),
+			credit(domain.LedgerAccountUnearnedRental, positive(rentalDelta)),
+			credit(domain.LedgerAccountCustomerDeposit, positive(depositDelta)),
+			debit(domain.LedgerAccountUnearnedRental, positive(-rentalDelta)),
+			debit(domain.LedgerAccountCustomerDeposit, positive(-depositDelta)),
+			credit(domain.LedgerAccountPlatformCash, positive(-rentalDelta)+positive(-depositDelta)),
+		)
+		txs = appendTransaction(txs, change)
+	}
+
+	if b.Status != "completed" {
+		return txs
+	}
+
+	commission := CommissionAmount(netRental, commissionRateBps)
+	income := newBookingTransaction(b, domain.LedgerKindRentalIncome, b.UpdatedAt)
+	income.CommissionRateBps = commissionRateBps
+	income.Description = fmt.Sprintf("Pendapatan sewa booking %s (komisi %d bps)", b.ID, commissionRateBps)
+	income.Entries = ledgerEntries(
+		debit(domain.LedgerAccountUnearnedRental, netRental),
+		credit(domain.LedgerAccountPlatformCommission, commission),
+		credit(domain.LedgerAccountHosterPayable, netRental-commission),
+	)
+	txs = appendTransaction(txs, income)
+
+	refund := newBookingTransaction(b, domain.LedgerKindDepositRefund, b.UpdatedAt)
+	refund.Description = fmt.Sprintf("Pengembalian deposit booking %s", b.ID)
+	refund.Entries = ledgerEntries(
+		debit(domain.LedgerAccountCustomerDeposit, deposit),
+		credit(domain.LedgerAccountPlatformCash, deposit),
+	)
+	txs = appendTransaction(txs, refund)
+
+	// Denda final saat completed (akrual terakhir dijalankan sebelum status berubah)
+	lateFee := positive(int64(b.LateFee))
+	lateFeeCommission := CommissionAmount(lateFee, commissionRateBps)
+	penalty := newBookingTransaction(b, domain.LedgerKindLateFee, b.UpdatedAt)
+	penalty.CommissionRateBps = commissionRateBps
+	penalty.Description = fmt.Sprintf("Denda keterlambatan booking %s (komisi %d bps)", b.ID, commissionRateBps)
+	penalty.Entries = ledgerEntries(
+		debit(domain.LedgerAccountPlatformCash, lateFee),
+		credit(domain.LedgerAccountPlatformCommission, lateFeeCommission),
+		credit(domain.LedgerAccountHosterPayable, lateFee-lateFeeCommission),
+	)
+	return appendTransaction(txs, penalty)
+}
+
+/*
+netBookingRental menghitung sewa bersih (sewa - diskon, minimal 0).
+*/
+func netBookingRental(rental, discount int) int64 {
+	net := int64(rental) - int64(discount)
+	if net < 0 {
+		return 0
+	}
+	return net
+}
+
+/*
+positive mengembalikan amount jika lebih dari 0, selain itu 0.
+*/
+func positive(amount int64) int64 {
+	if amount < 0 {
+		return 0
+	}
+	return amount
+}
+
+/*
+newBookingTransaction membuat header transaksi ledger untuk booking.
+*/
+func newBookingTransaction(b domain.Booking, kind string, occurredAt time.Time) domain.LedgerTransaction {
+	bookingID := b.ID
+	return domain.LedgerTransaction{
+		HosterID:   b.HosterID,
+		BookingID:  &bookingID,
+		Kind:       kind,
+		OccurredAt: occurredAt,
+	}
+}
+
+/*
+appendTransaction menambahkan transaksi hanya jika punya baris ledger.
+*/
+func appendTransaction(txs []domain.LedgerTransaction, tx domain.LedgerTransaction) []domain.LedgerTransaction {
+	if len(tx.Entries) == 0 {
+		return txs
+	}
+	return append(txs, tx)
+}
+
+/*
+ledgerEntries membuang baris bernilai 0.
+*/
+func ledgerEntries(entries ...domain.LedgerEntry) []domain.LedgerEntry {
+	var result []domain.LedgerEntry
+	for _, e := range entries {
+		if e.Debit > 0 || e.Credit > 0 {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func debit(account string, amount int64) domain.LedgerEntry {
+	return domain.LedgerEntry{Account: account, Debit: amount}
+}
+
+func credit(account string, amount int64) domain.LedgerEntry {
+	return domain.LedgerEntry{Account: account, Credit: amount}
+}
@@ -0,0 +1,459 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"lalan-be/internal/domain"
+)
+
+var (
+	testPaidAt      = time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	testCompletedAt = time.Date(2025, 12, 5, 17, 0, 0, 0, time.UTC)
+)
+
+func testLedgerBooking(status string, rental, discount, deposit int) domain.Booking {
+	return domain.Booking{
+		ID:          "booking-1",
+		HosterID:    "hoster-1",
+		Status:      status,
+		Rental:      rental,
+		Discount:    discount,
+		Deposit:     deposit,
+		LockedUntil: testPaidAt,
+		UpdatedAt:   testCompletedAt,
+	}
+}
+
+// assertBalanced memastikan setiap transaksi seimbang dan setiap baris hanya mengisi satu sisi.
+func assertBalanced(t *testing.T, txs []domain.LedgerTransaction) {
+	t.Helper()
+	for _, tx := range txs {
+		var debits, credits int64
+		for _, e := range tx.Entries {
+			if e.Debit < 0 || e.Credit < 0 || (e.Debit == 0) == (e.Credit == 0) {
+				t.Errorf("%s: invalid entry %+v", tx.Kind, e)
+			}
+			debits += e.Debit
+			credits += e.Credit
+		}
+		if debits != credits {
+			t.Errorf("%s: debit %d != credit %d", tx.Kind, debits, credits)
+		}
+		if tx.HosterID != "hoster-1" || tx.BookingID == nil || *tx.BookingID != "booking-1" {
+			t.Errorf("%s: wrong owner hoster=%s booking=%v", tx.Kind, tx.HosterID, tx.BookingID)
+		}
+	}
+}
+
+// accountBalances menjumlahkan (debit - kredit) per akun dari beberapa transaksi.
+func accountBalances(txs []domain.LedgerTransaction) map[string]int64 {
+	balances := make(map[string]int64)
+	for _, tx := range txs {
+		for _, e := range tx.Entries {
+			balances[e.Account] += e.Debit - e.Credit
+		}
+	}
+	return balances
+}
+
+func ledgerKinds(txs []domain.LedgerTransaction) []string {
+	kinds := make([]string, len(txs))
+	for i, tx := range txs {
+		kinds[i] = tx.Kind
+	}
+	return kinds
+}
+
+func findTransaction(t *testing.T, txs []domain.LedgerTransaction, kind string) domain.LedgerTransaction {
+	t.Helper()
+	for _, tx := range txs {
+		if tx.Kind == kind {
+			return tx
+		}
+	}
+	t.Fatalf("transaction %s not found in %v", kind, ledgerKinds(txs))
+	return domain.LedgerTransaction{}
+}
+
+func TestCommissionAmount(t *testing.T) {
+	tests := []struct {
+		net  int64
+		bps  int
+		want int64
+	}{
+		{100000, 1000, 10000},
+		{12345, 1000, 1235}, // 1234.5 → 1235 (half-up)
+		{12344, 1000, 1234}, // 1234.4 → 1234
+		{100, 250, 3},       // 2.5 → 3
+		{99, 250, 2},        // 2.475 → 2
+		{1, 5000, 1},        // 0.5 → 1
+		{1, 4999, 0},
+		{333333, 333, 11100}, // 11099.99 → 11100
+		{5000, MaxCommissionRateBps, 5000},
+		{5000, 0, 0},
+		{0, 1000, 0},
+		{-5000, 1000, 0},
+	}
+	for _, tt := range tests {
+		if got := CommissionAmount(tt.net, tt.bps); got != tt.want {
+			t.Errorf("CommissionAmount(%d, %d) = %d, want %d", tt.net, tt.bps, got, tt.want)
+		}
+	}
+}
+
+func TestBuildBookingLedger(t *testing.T) {
+	tests := []struct {
+		name      string
+		booking   domain.Booking
+		bps       int
+		wantKinds []string
+		// Saldo akhir per akun (debit - kredit), akun yang tidak disebut harus 0
+		wantBalances map[string]int64
+	}{
+		{
+			name:      "pending belum dibayar",
+			booking:   testLedgerBooking("pending", 300000, 0, 500000),
+			bps:       1000,
+			wantKinds: nil,
+		},
+		{
+			name:      "cancelled tidak diposting",
+			booking:   testLedgerBooking("cancelled", 300000, 0, 500000),
+			bps:       1000,
+			wantKinds: nil,
+		},
+		{
+			name:      "on_progress hanya pembayaran",
+			booking:   testLedgerBooking("on_progress", 300000, 50000, 500000),
+			bps:       1000,
+			wantKinds: []string{domain.LedgerKindBookingPayment},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:    750000,
+				domain.LedgerAccountUnearnedRental:  -250000,
+				domain.LedgerAccountCustomerDeposit: -500000,
+			},
+		},
+		{
+			name:      "on_rent masih pembayaran saja",
+			booking:   testLedgerBooking("on_rent", 300000, 0, 0),
+			bps:       1000,
+			wantKinds: []string{domain.LedgerKindBookingPayment},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:   300000,
+				domain.LedgerAccountUnearnedRental: -300000,
+			},
+		},
+		{
+			name:      "completed: komisi dibulatkan half-up, deposit kembali",
+			booking:   testLedgerBooking("completed", 12345, 0, 500000),
+			bps:       1000,
+			wantKinds: []string{domain.LedgerKindBookingPayment, domain.LedgerKindRentalIncome, domain.LedgerKindDepositRefund},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:       12345,
+				domain.LedgerAccountPlatformCommission: -1235,
+				domain.LedgerAccountHosterPayable:      -11110,
+			},
+		},
+		{
+			name:      "completed tanpa deposit dan tanpa komisi",
+			booking:   testLedgerBooking("completed", 99999, 0, 0),
+			bps:       0,
+			wantKinds: []string{domain.LedgerKindBookingPayment, domain.LedgerKindRentalIncome},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:   99999,
+				domain.LedgerAccountHosterPayable:  -99999,
+				domain.LedgerAccountUnearnedRental: 0,
+			},
+		},
+		{
+			name:      "diskon melebihi sewa → sewa bersih 0",
+			booking:   testLedgerBooking("completed", 100000, 150000, 200000),
+			bps:       1000,
+			wantKinds: []string{domain.LedgerKindBookingPayment, domain.LedgerKindDepositRefund},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash: 0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := BuildBookingLedger(tt.booking, nil, tt.bps)
+			assertBalanced(t, txs)
+
+			kinds := ledgerKinds(txs)
+			if len(kinds) != len(tt.wantKinds) {
+				t.Fatalf("kinds = %v, want %v", kinds, tt.wantKinds)
+			}
+			for i := range kinds {
+				if kinds[i] != tt.wantKinds[i] {
+					t.Fatalf("kinds = %v, want %v", kinds, tt.wantKinds)
+				}
+			}
+
+			balances := accountBalances(txs)
+			for account, got := range balances {
+				if got != tt.wantBalances[account] {
+					t.Errorf("balance %s = %d, want %d", account, got, tt.wantBalances[account])
+				}
+			}
+			for account, want := range tt.wantBalances {
+				if balances[account] != want {
+					t.Errorf("balance %s = %d, want %d", account, balances[account], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildBookingLedgerTimestampsAndCommission(t *testing.T) {
+	txs := BuildBookingLedger(testLedgerBooking("completed", 250001, 1, 100000), nil, 1250)
+
+	payment := findTransaction(t, txs, domain.LedgerKindBookingPayment)
+	if !payment.OccurredAt.Equal(testPaidAt) {
+		t.Errorf("payment occurred_at = %v, want %v", payment.OccurredAt, testPaidAt)
+	}
+
+	income := findTransaction(t, txs, domain.LedgerKindRentalIncome)
+	if !income.OccurredAt.Equal(testCompletedAt) {
+		t.Errorf("income occurred_at = %v, want %v", income.OccurredAt, testCompletedAt)
+	}
+	if income.CommissionRateBps != 1250 {
+		t.Errorf("commission_rate_bps = %d, want 1250", income.CommissionRateBps)
+	}
+	var commission, payable int64
+	for _, e := range income.Entries {
+		switch e.Account {
+		case domain.LedgerAccountPlatformCommission:
+			commission += e.Credit
+		case domain.LedgerAccountHosterPayable:
+			payable += e.Credit
+		}
+	}
+	if commission != 31250 || commission+payable != 250000 {
+		t.Errorf("commission = %d, payable = %d, want 31250 + 218750", commission, payable)
+	}
+}
+
+func testAmendment(id string, oldRental, newRental, oldDeposit, newDeposit int, decidedAt *time.Time) domain.BookingAmendment {
+	return domain.BookingAmendment{
+		ID:         id,
+		BookingID:  "booking-1",
+		Status:     domain.AmendmentStatusApproved,
+		OldRental:  oldRental,
+		NewRental:  newRental,
+		OldDeposit: oldDeposit,
+		NewDeposit: newDeposit,
+		DecidedAt:  decidedAt,
+	}
+}
+
+func TestBuildBookingLedgerAmendments(t *testing.T) {
+	decidedAt := time.Date(2025, 12, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		booking    domain.Booking // Rental & Deposit = nilai setelah perubahan terakhir
+		amendments []domain.BookingAmendment
+		bps        int
+		// Selisih per transaksi booking_amendment (urut): platform_cash (debit - kredit)
+		wantCashDeltas []int64
+		wantBalances   map[string]int64
+	}{
+		{
+			name:           "perpanjangan menambah sewa",
+			booking:        testLedgerBooking("on_rent", 400000, 0, 500000),
+			amendments:     []domain.BookingAmendment{testAmendment("a1", 300000, 400000, 500000, 500000, &decidedAt)},
+			bps:            1000,
+			wantCashDeltas: []int64{100000},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:    900000,
+				domain.LedgerAccountUnearnedRental:  -400000,
+				domain.LedgerAccountCustomerDeposit: -500000,
+			},
+		},
+		{
+			name:           "quantity berkurang dibalik penuh lalu selesai",
+			booking:        testLedgerBooking("completed", 200000, 0, 300000),
+			amendments:     []domain.BookingAmendment{testAmendment("a1", 300000, 200000, 500000, 300000, &decidedAt)},
+			bps:            1000,
+			wantCashDeltas: []int64{-300000},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:       200000,
+				domain.LedgerAccountPlatformCommission: -20000,
+				domain.LedgerAccountHosterPayable:      -180000,
+			},
+		},
+		{
+			name:           "sewa naik, deposit turun dalam satu perubahan",
+			booking:        testLedgerBooking("on_rent", 350000, 0, 400000),
+			amendments:     []domain.BookingAmendment{testAmendment("a1", 300000, 350000, 500000, 400000, &decidedAt)},
+			bps:            1000,
+			wantCashDeltas: []int64{-50000},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:    750000,
+				domain.LedgerAccountUnearnedRental:  -350000,
+				domain.LedgerAccountCustomerDeposit: -400000,
+			},
+		},
+		{
+			name:    "dua perubahan berantai dengan diskon",
+			booking: testLedgerBooking("completed", 350000, 50000, 0),
+			amendments: []domain.BookingAmendment{
+				testAmendment("a1", 300000, 400000, 0, 0, &decidedAt),
+				testAmendment("a2", 400000, 350000, 0, 0, nil),
+			},
+			bps:            333,
+			wantCashDeltas: []int64{100000, -50000},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:       300000,
+				domain.LedgerAccountPlatformCommission: -9990,
+				domain.LedgerAccountHosterPayable:      -290010,
+			},
+		},
+		{
+			name:           "diskon menutup sewa lama, selisih dari sewa bersih 0",
+			booking:        testLedgerBooking("on_rent", 300000, 150000, 0),
+			amendments:     []domain.BookingAmendment{testAmendment("a1", 100000, 300000, 0, 0, &decidedAt)},
+			bps:            1000,
+			wantCashDeltas: []int64{150000},
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:   150000,
+				domain.LedgerAccountUnearnedRental: -150000,
+			},
+		},
+		{
+			name:           "perubahan tanpa selisih tidak diposting",
+			booking:        testLedgerBooking("on_rent", 300000, 0, 100000),
+			amendments:     []domain.BookingAmendment{testAmendment("a1", 300000, 300000, 100000, 100000, &decidedAt)},
+			bps:            1000,
+			wantCashDeltas: nil,
+			wantBalances: map[string]int64{
+				domain.LedgerAccountPlatformCash:    400000,
+				domain.LedgerAccountUnearnedRental:  -300000,
+				domain.LedgerAccountCustomerDeposit: -100000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := BuildBookingLedger(tt.booking, tt.amendments, tt.bps)
+			assertBalanced(t, txs)
+
+			var cashDeltas []int64
+			for _, tx := range txs {
+				if tx.Kind != domain.LedgerKindBookingAmendment {
+					continue
+				}
+				if tx.AmendmentID == nil {
+					t.Fatalf("amendment transaction without amendment_id")
+				}
+				cashDeltas = append(cashDeltas, accountBalances([]domain.LedgerTransaction{tx})[domain.LedgerAccountPlatformCash])
+			}
+			if len(cashDeltas) != len(tt.wantCashDeltas) {
+				t.Fatalf("cash deltas = %v, want %v", cashDeltas, tt.wantCashDeltas)
+			}
+			for i := range cashDeltas {
+				if cashDeltas[i] != tt.wantCashDeltas[i] {
+					t.Fatalf("cash deltas = %v, want %v", cashDeltas, tt.wantCashDeltas)
+				}
+			}
+
+			balances := accountBalances(txs)
+			for account, got := range balances {
+				if got != tt.wantBalances[account] {
+					t.Errorf("balance %s = %d, want %d", account, got, tt.wantBalances[account])
+				}
+			}
+			for account, want := range tt.wantBalances {
+				if balances[account] != want {
+					t.Errorf("balance %s = %d, want %d", account, balances[account], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildBookingLedgerAmendmentMetadata(t *testing.T) {
+	decidedAt := time.Date(2025, 12, 3, 12, 0, 0, 0, time.UTC)
+	amendments := []domain.BookingAmendment{
+		testAmendment("a1", 300000, 400000, 0, 0, &decidedAt),
+		testAmendment("a2", 400000, 450000, 0, 0, nil),
+	}
+	txs := BuildBookingLedger(testLedgerBooking("on_rent", 450000, 0, 0), amendments, 1000)
+
+	var got []domain.LedgerTransaction
+	for _, tx := range txs {
+		if tx.Kind == domain.LedgerKindBookingAmendment {
+			got = append(got, tx)
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("amendment transactions = %d, want 2", len(got))
+	}
+	if *got[0].AmendmentID != "a1" || !got[0].OccurredAt.Equal(decidedAt) {
+		t.Errorf("first amendment = %s at %v, want a1 at %v", *got[0].AmendmentID, got[0].OccurredAt, decidedAt)
+	}
+	if *got[1].AmendmentID != "a2" || !got[1].OccurredAt.Equal(testCompletedAt) {
+		t.Errorf("second amendment = %s at %v, want a2 at updated_at %v", *got[1].AmendmentID, got[1].OccurredAt, testCompletedAt)
+	}
+
+	payment := findTransaction(t, txs, domain.LedgerKindBookingPayment)
+	if cash := accountBalances([]domain.LedgerTransaction{payment})[domain.LedgerAccountPlatformCash]; cash != 300000 {
+		t.Errorf("payment cash = %d, want original 300000", cash)
+	}
+}
+
+func TestBuildBookingLedgerLateFee(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         string
+		lateFee        int
+		bps            int
+		wantCommission int64 // 0 dan wantPosted false → transaksi late_fee tidak ada
+		wantPosted     bool
+	}{
+		{"completed dengan denda, komisi dibulatkan", "completed", 33335, 1000, 3334, true},
+		{"completed dengan denda tanpa komisi", "completed", 50000, 0, 0, true},
+		{"completed tanpa denda", "completed", 0, 1000, 0, false},
+		{"masih on_rent, denda belum final", "on_rent", 50000, 1000, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testLedgerBooking(tt.status, 300000, 0, 100000)
+			b.LateFee = tt.lateFee
+			txs := BuildBookingLedger(b, nil, tt.bps)
+			assertBalanced(t, txs)
+
+			var penalty *domain.LedgerTransaction
+			for i := range txs {
+				if txs[i].Kind == domain.LedgerKindLateFee {
+					penalty = &txs[i]
+				}
+			}
+			if (penalty != nil) != tt.wantPosted {
+				t.Fatalf("late_fee posted = %v, want %v (kinds %v)", penalty != nil, tt.wantPosted, ledgerKinds(txs))
+			}
+			if penalty == nil {
+				return
+			}
+
+			balances := accountBalances([]domain.LedgerTransaction{*penalty})
+			if balances[domain.LedgerAccountPlatformCash] != int64(tt.lateFee) {
+				t.Errorf("platform_cash = %d, want %d", balances[domain.LedgerAccountPlatformCash], tt.lateFee)
+			}
+			if -balances[domain.LedgerAccountPlatformCommission] != tt.wantCommission {
+				t.Errorf("commission = %d, want %d", -balances[domain.LedgerAccountPlatformCommission], tt.wantCommission)
+			}
+			if -balances[domain.LedgerAccountHosterPayable] != int64(tt.lateFee)-tt.wantCommission {
+				t.Errorf("hoster_payable = %d, want %d", -balances[domain.LedgerAccountHosterPayable], int64(tt.lateFee)-tt.wantCommission)
+			}
+			if penalty.CommissionRateBps != tt.bps || !penalty.OccurredAt.Equal(testCompletedAt) {
+				t.Errorf("late_fee bps=%d at %v, want %d at %v", penalty.CommissionRateBps, penalty.OccurredAt, tt.bps, testCompletedAt)
+			}
+		})
+	}
+}
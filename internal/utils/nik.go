@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"lalan-be/internal/message"
+)
+
+/*
+validProvinceCodes adalah daftar kode provinsi (2 digit pertama NIK) yang diterbitkan Dukcapil.
+*/
+var validProvinceCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true, "31": true, "32": true, "33": true, "34": true, "35": true, "36": true,
+	"51": true, "52": true, "53": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "72": true, "73": true, "74": true, "75": true, "76": true,
+	"81": true, "82": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true,
+}
+
+/*
+NIKInfo berisi data yang ter-encode di dalam NIK (Nomor Induk Kependudukan).
+*/
+type NIKInfo struct {
+	NIK          string
+	ProvinceCode string    // Digit 1-2
+	RegencyCode  string    // Digit 3-4 (kabupaten/kota)
+	DistrictCode string    // Digit 5-6 (kecamatan)
+	BirthDate    time.Time // Digit 7-12 (DDMMYY, DD + 40 untuk perempuan)
+	Sex          string    // "male" atau "female"
+	Sequence     string    // Digit 13-16
+}
+
+/*
+ParseNIK memvalidasi struktur NIK 16 digit dan mengekstrak informasi di dalamnya.
+
+Alur kerja:
+1. Bersihkan spasi/titik, pastikan 16 digit angka
+2. Validasi kode provinsi, kabupaten/kota, kecamatan tidak nol
+3. Decode tanggal lahir (DD > 40 → perempuan) dan pastikan tanggal valid & tidak di masa depan
+4. Nomor urut tidak boleh 0000
+
+Output sukses:
+- (*NIKInfo, nil)
+Output error:
+- (nil, error) → pesan message.NIKInvalid*
+*/
+func ParseNIK(raw string) (*NIKInfo, error) {
+	nik := strings.NewReplacer(" ", "", ".", "", "-", "").Replace(strings.TrimSpace(raw))
+	if nik == "" {
+		return nil, errors.New(message.NIKRequired)
+	}
+	if len(nik) != 16 {
+		return nil, errors.New(message.NIKInvalidLength)
+	}
+	for _, c := range nik {
+		if c < '0' || c > '9' {
+			return nil, errors.New(message.NIKInvalidLength)
+		}
+	}
+
+	info := &NIKInfo{
+		NIK:          nik,
+		ProvinceCode: nik[0:2],
+		RegencyCode:  nik[2:4],
+		DistrictCode: nik[4:6],
+		Sequence:     nik[12:16],
+		Sex:          "male",
+	}
+
+	if !validProvinceCodes[info.ProvinceCode] || info.RegencyCode == "00" || info.DistrictCode == "00" {
+		return nil, errors.New(message.NIKInvalidRegion)
+	}
+
+	day, _ := strconv.Atoi(nik[6:8])
+	month, _ := strconv.Atoi(nik[8:10])
+	year, _ := strconv.Atoi(nik[10:12])
+	if day > 40 {
+		day -= 40
+		info.Sex = "female"
+	}
+
+	// Tahun 2 digit: jika lebih besar dari tahun sekarang → abad 1900-an
+	now := time.Now()
+	century := 2000
+	if year > now.Year()%100 {
+		century = 1900
+	}
+	birthDate := time.Date(century+year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if day < 1 || month < 1 || month > 12 || birthDate.Day() != day || birthDate.After(now) {
+		return nil, errors.New(message.NIKInvalidBirthDate)
+	}
+	info.BirthDate = birthDate
+
+	if info.Sequence == "0000" {
+		return nil, errors.New(message.NIKInvalidSequence)
+	}
+
+	return info, nil
+}
+
+/*
+MaskNIK menyamarkan NIK untuk ditampilkan (6 digit wilayah + 4 digit terakhir).
+Contoh: 3171011505900001 → 317101******0001
+*/
+func MaskNIK(nik string) string {
+	if len(nik) != 16 {
+		return ""
+	}
+	return nik[:6] + strings.Repeat("*", 6) + nik[12:]
+}
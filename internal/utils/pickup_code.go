@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+/*
+ErrInvalidPickupCode dikembalikan ParsePickupCode jika format / tanda tangan kode pickup tidak valid.
+*/
+var ErrInvalidPickupCode = errors.New("invalid pickup code")
+
+// pickupCodePrefix menandai versi format kode pickup, ikut ditandatangani.
+const pickupCodePrefix = "LP1"
+
+/*
+SignPickupCode membuat kode pickup bertanda tangan untuk sebuah booking.
+Format: LP1.{base64url(booking_id 16 byte + nonce)}.{base64url(HMAC-SHA256 16 byte)} (±60 karakter, muat di QR versi 4).
+
+Nonce (hex) disimpan di booking dan hanya bisa ditukar sekali, sehingga kode lama tidak bisa dipakai ulang.
+
+Output sukses:
+- (kode, nil)
+Output error:
+- ("", error) → booking ID bukan UUID / nonce bukan hex
+*/
+func SignPickupCode(bookingID, nonce string, key []byte) (string, error) {
+	id, err := uuid.Parse(bookingID)
+	if err != nil {
+		return "", err
+	}
+	nonceBytes, err := hex.DecodeString(nonce)
+	if err != nil || len(nonceBytes) == 0 {
+		return "", errors.New("invalid pickup code nonce")
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(append(id[:], nonceBytes...))
+	signed := pickupCodePrefix + "." + payload
+	return signed + "." + base64.RawURLEncoding.EncodeToString(pickupCodeMAC(signed, key)), nil
+}
+
+/*
+ParsePickupCode memverifikasi tanda tangan kode pickup hasil SignPickupCode.
+Spasi di awal / akhir (hasil scan / input manual) diabaikan.
+
+Output sukses:
+- (bookingID, nonce hex, nil)
+Output error:
+- ("", "", ErrInvalidPickupCode) → format salah / tanda tangan tidak cocok
+*/
+func ParsePickupCode(code string, key []byte) (string, string, error) {
+	parts := strings.Split(strings.TrimSpace(code), ".")
+	if len(parts) != 3 || parts[0] != pickupCodePrefix {
+		return "", "", ErrInvalidPickupCode
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, pickupCodeMAC(parts[0]+"."+parts[1], key)) {
+		return "", "", ErrInvalidPickupCode
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || len(payload) <= 16 {
+		return "", "", ErrInvalidPickupCode
+	}
+	id, err := uuid.FromBytes(payload[:16])
+	if err != nil {
+		return "", "", ErrInvalidPickupCode
+	}
+	return id.String(), hex.EncodeToString(payload[16:]), nil
+}
+
+func pickupCodeMAC(signed string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)[:16]
+}
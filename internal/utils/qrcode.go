@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+/*
+ErrQRCodeTooLong dikembalikan EncodeQRCode jika teks melebihi kapasitas QR code level M.
+*/
+var ErrQRCodeTooLong = errors.New("text too long for qr code")
+
+/*
+QRCode adalah matriks modul QR code (true = hitam), sudah termasuk quiet zone 4 modul.
+*/
+type QRCode struct {
+	Size    int
+	modules [][]bool
+}
+
+/*
+EncodeQRCode membuat QR code dari teks dengan error correction level M (github.com/skip2/go-qrcode).
+
+Output sukses:
+- (*QRCode, nil)
+Output error:
+- (nil, ErrQRCodeTooLong) → teks melebihi kapasitas versi 40
+- (nil, error)            → encoder gagal
+*/
+func EncodeQRCode(text string) (*QRCode, error) {
+	qr, err := qrcode.New(text, qrcode.Medium)
+	if err != nil {
+		if err.Error() == "content too long to encode" {
+			return nil, ErrQRCodeTooLong
+		}
+		return nil, err
+	}
+	modules := qr.Bitmap()
+	return &QRCode{Size: len(modules), modules: modules}, nil
+}
+
+/*
+Black mengembalikan true jika modul (x = kolom, y = baris) berwarna hitam.
+*/
+func (q *QRCode) Black(x, y int) bool {
+	return x >= 0 && y >= 0 && x < q.Size && y < q.Size && q.modules[y][x]
+}
+
+/*
+WritePNG menulis QR code sebagai PNG hitam-putih, scale = ukuran pixel per modul.
+*/
+func (q *QRCode) WritePNG(w io.Writer, scale int) error {
+	if scale < 1 {
+		scale = 1
+	}
+	full := q.Size * scale
+	img := image.NewGray(image.Rect(0, 0, full, full))
+	for y := 0; y < full; y++ {
+		for x := 0; x < full; x++ {
+			c := color.Gray{Y: 255}
+			if q.Black(x/scale, y/scale) {
+				c = color.Gray{Y: 0}
+			}
+			img.SetGray(x, y, c)
+		}
+	}
+	return png.Encode(w, img)
+}
+
+/*
+WriteSVG menulis QR code sebagai SVG (satu path), scale = ukuran pixel per modul untuk width/height.
+*/
+func (q *QRCode) WriteSVG(w io.Writer, scale int) error {
+	if scale < 1 {
+		scale = 1
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, q.Size*scale, q.Size*scale, q.Size, q.Size)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="#ffffff"/><path fill="#000000" d="`, q.Size, q.Size)
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.modules[y][x] {
+				fmt.Fprintf(bw, "M%d,%dh1v1h-1z", x, y)
+			}
+		}
+	}
+	bw.WriteString(`"/></svg>`)
+	return bw.Flush()
+}
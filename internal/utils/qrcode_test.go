@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestEncodeQRCodeTooLong(t *testing.T) {
+	if _, err := EncodeQRCode(strings.Repeat("a", 4000)); !errors.Is(err, ErrQRCodeTooLong) {
+		t.Fatalf("error = %v, want ErrQRCodeTooLong", err)
+	}
+}
+
+func TestEncodeQRCodeFinderPattern(t *testing.T) {
+	qr, err := EncodeQRCode("LP1.test")
+	if err != nil {
+		t.Fatalf("EncodeQRCode: %v", err)
+	}
+	// Quiet zone 4 modul putih, lalu finder pattern 7x7 di pojok kiri atas
+	if qr.Black(0, 0) || qr.Black(3, 3) {
+		t.Fatalf("quiet zone must be white")
+	}
+	if !qr.Black(4, 4) || !qr.Black(10, 10) || qr.Black(5, 5) || !qr.Black(7, 7) {
+		t.Fatalf("finder pattern not found at top-left")
+	}
+}
+
+func TestQRCodeWritePNGAndSVG(t *testing.T) {
+	qr, err := EncodeQRCode("LP1.test")
+	if err != nil {
+		t.Fatalf("EncodeQRCode: %v", err)
+	}
+
+	const scale = 3
+	var buf bytes.Buffer
+	if err := qr.WritePNG(&buf, scale); err != nil {
+		t.Fatalf("WritePNG: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode: %v", err)
+	}
+	full := qr.Size * scale
+	if b := img.Bounds(); b.Dx() != full || b.Dy() != full {
+		t.Fatalf("png size = %dx%d, want %dx%d", b.Dx(), b.Dy(), full, full)
+	}
+	for y := 0; y < full; y++ {
+		for x := 0; x < full; x++ {
+			r, _, _, _ := img.At(x, y).RGBA()
+			if black := r == 0; black != qr.Black(x/scale, y/scale) {
+				t.Fatalf("png pixel (%d,%d) black = %v", x, y, black)
+			}
+		}
+	}
+
+	buf.Reset()
+	if err := qr.WriteSVG(&buf, scale); err != nil {
+		t.Fatalf("WriteSVG: %v", err)
+	}
+	dark := 0
+	for y := 0; y < qr.Size; y++ {
+		for x := 0; x < qr.Size; x++ {
+			if qr.Black(x, y) {
+				dark++
+			}
+		}
+	}
+	svg := buf.String()
+	if n := strings.Count(svg, "h1v1h-1z"); n != dark {
+		t.Fatalf("svg modules = %d, want %d", n, dark)
+	}
+	if !strings.Contains(svg, fmt.Sprintf(`width="%d"`, full)) {
+		t.Fatalf("svg missing width %d", full)
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+/*
+MaxSlugLength adalah panjang maksimal slug dasar (sebelum sufiks angka "-2", "-3", ...).
+*/
+const MaxSlugLength = 100
+
+/*
+DefaultSlug dipakai jika nama toko tidak menghasilkan karakter slug sama sekali.
+*/
+const DefaultSlug = "toko"
+
+/*
+Slugify mengubah nama toko menjadi slug URL yang mudah dibaca.
+
+Alur kerja:
+1. Huruf kecil, hanya a-z dan 0-9 yang dipertahankan
+2. Karakter lain (spasi, tanda baca, emoji) menjadi satu tanda "-"
+3. "-" di awal / akhir dibuang, panjang dibatasi MaxSlugLength
+
+Output:
+- slug, contoh: "Kamera Jogja & Co." → "kamera-jogja-co"
+- DefaultSlug jika hasil kosong
+*/
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := strings.TrimRight(b.String(), "-")
+	if len(slug) > MaxSlugLength {
+		slug = strings.TrimRight(slug[:MaxSlugLength], "-")
+	}
+	if slug == "" {
+		return DefaultSlug
+	}
+	return slug
+}
+
+/*
+NextSlug memilih slug yang belum dipakai dari slug dasar dan daftar slug yang sudah ada
+(slug dasar itu sendiri atau slug dasar + "-angka").
+
+Output:
+- base jika belum dipakai, selain itu base-N dengan N terkecil (mulai 2) yang masih kosong
+*/
+func NextSlug(base string, taken []string) string {
+	used := make(map[string]bool, len(taken))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+	if !used[base] {
+		return base
+	}
+	for n := 2; ; n++ {
+		candidate := base + "-" + strconv.Itoa(n)
+		if !used[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+ParseClock mengubah jam "HH:MM" (atau "HH:MM:SS" dari kolom TIME) menjadi menit sejak tengah malam.
+
+Output sukses:
+- (menit, nil)
+Output error:
+- (0, error) → format jam tidak valid
+*/
+func ParseClock(value string) (int, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Hour()*60 + t.Minute(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid clock %q, use HH:MM", value)
+}
+
+/*
+FormatClock mengubah menit sejak tengah malam menjadi "HH:MM".
+*/
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+/*
+BuildTimeSlots membuat daftar jam mulai slot di antara jam buka dan jam tutup.
+Slot terakhir harus selesai paling lambat saat jam tutup.
+
+Output:
+- []string jam mulai slot (HH:MM), kosong jika jam tidak valid
+*/
+func BuildTimeSlots(openTime, closeTime string, slotMinutes int) []string {
+	open, err := ParseClock(openTime)
+	if err != nil || slotMinutes <= 0 {
+		return []string{}
+	}
+	closing, err := ParseClock(closeTime)
+	if err != nil {
+		return []string{}
+	}
+
+	slots := []string{}
+	for start := open; start+slotMinutes <= closing; start += slotMinutes {
+		slots = append(slots, FormatClock(start))
+	}
+	return slots
+}
@@ -0,0 +1,88 @@
+/*
+Kebijakan harga perubahan booking per store.
+- snapshot : tambahan hari / unit dihargai dengan harga saat booking dibuat (booking_item)
+- current  : tambahan hari / unit dihargai dengan harga item saat ini
+Pengurangan unit selalu dihitung dengan harga snapshot.
+*/
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS amendment_pricing VARCHAR(10) NOT NULL DEFAULT 'snapshot'
+        CHECK (amendment_pricing IN ('snapshot', 'current'));
+
+/*
+Permintaan perubahan booking dari customer (perpanjang end_date dan / atau ubah quantity item).
+Nilai lama & baru disimpan sebagai jejak audit; booking baru berubah setelah hoster menyetujui.
+Hanya satu permintaan pending per booking.
+*/
+CREATE TABLE IF NOT EXISTS booking_amendment (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL REFERENCES booking(id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    pricing VARCHAR(10) NOT NULL,
+    old_end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    new_end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    old_total_days INTEGER NOT NULL,
+    new_total_days INTEGER NOT NULL,
+    old_rental INTEGER NOT NULL,
+    new_rental INTEGER NOT NULL,
+    old_deposit INTEGER NOT NULL,
+    new_deposit INTEGER NOT NULL,
+    old_total INTEGER NOT NULL,
+    new_total INTEGER NOT NULL,
+    customer_note TEXT,
+    hoster_note TEXT,
+    decided_by_member_id UUID REFERENCES hoster_member(id) ON DELETE SET NULL, -- NULL = diputuskan owner
+    decided_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_booking_amendment_status CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    CONSTRAINT chk_booking_amendment_pricing CHECK (pricing IN ('snapshot', 'current')),
+    CONSTRAINT chk_booking_amendment_end_date CHECK (new_end_date >= old_end_date)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_booking_amendment_pending
+    ON booking_amendment(booking_id) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_booking_amendment_booking_created_at
+    ON booking_amendment(booking_id, created_at DESC);
+
+/*
+Perubahan per booking_item. Semua item booking dicatat (termasuk yang quantity-nya tetap)
+agar subtotal baru bisa diterapkan apa adanya saat disetujui.
+price_per_day & deposit_per_unit = harga yang dipakai untuk tambahan sesuai kebijakan.
+*/
+CREATE TABLE IF NOT EXISTS booking_amendment_item (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    amendment_id UUID NOT NULL REFERENCES booking_amendment(id) ON DELETE CASCADE,
+    booking_item_id UUID NOT NULL REFERENCES booking_item(id) ON DELETE CASCADE,
+    old_quantity INTEGER NOT NULL,
+    new_quantity INTEGER NOT NULL CHECK (new_quantity > 0),
+    price_per_day INTEGER NOT NULL,
+    deposit_per_unit INTEGER NOT NULL,
+    old_subtotal_rental INTEGER NOT NULL,
+    new_subtotal_rental INTEGER NOT NULL,
+    old_subtotal_deposit INTEGER NOT NULL,
+    new_subtotal_deposit INTEGER NOT NULL,
+    CONSTRAINT uq_booking_amendment_item UNIQUE (amendment_id, booking_item_id)
+);
+
+/*
+Ledger: perubahan yang disetujui setelah booking dibayar diposting sebagai transaksi booking_amendment
+(selisih sewa bersih & deposit). Satu transaksi per amendment, sehingga unik (booking_id, kind)
+hanya berlaku untuk transaksi tanpa amendment_id.
+*/
+ALTER TABLE ledger_transaction
+    ADD COLUMN IF NOT EXISTS amendment_id UUID REFERENCES booking_amendment(id) ON DELETE RESTRICT;
+
+ALTER TABLE ledger_transaction
+    DROP CONSTRAINT IF EXISTS chk_ledger_transaction_kind,
+    DROP CONSTRAINT IF EXISTS uq_ledger_transaction_booking_kind;
+
+ALTER TABLE ledger_transaction
+    ADD CONSTRAINT chk_ledger_transaction_kind
+        CHECK (kind IN ('booking_payment', 'booking_amendment', 'rental_income', 'deposit_refund', 'late_fee', 'payout'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ledger_transaction_booking_kind
+    ON ledger_transaction(booking_id, kind) WHERE amendment_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ledger_transaction_amendment
+    ON ledger_transaction(amendment_id) WHERE amendment_id IS NOT NULL;
@@ -0,0 +1,14 @@
+/*
+Menambahkan index gabungan hoster_id + start_date di tabel booking.
+Dipakai query analytics hoster (ringkasan, time series revenue) yang selalu memfilter
+booking satu toko dalam rentang tanggal mulai sewa, sehingga tidak perlu materialized rollup.
+*/
+CREATE INDEX IF NOT EXISTS idx_booking_hoster_start_date
+    ON booking(hoster_id, start_date);
+
+/*
+Menambahkan index gabungan hoster_id + user_id di tabel booking.
+Mempercepat perhitungan repeat customer (jumlah booking per customer di satu toko).
+*/
+CREATE INDEX IF NOT EXISTS idx_booking_hoster_user_id
+    ON booking(hoster_id, user_id);
@@ -0,0 +1,41 @@
+/*
+Berita acara serah terima barang (handover) per booking.
+Dua jenis: pickup (barang diserahkan ke customer) dan return (barang dikembalikan).
+Hoster mengisi checklist, kondisi, catatan, dan foto per booking_item; pengisian oleh
+hoster sekaligus menjadi konfirmasi hoster. Customer lalu mengonfirmasi (opsional dengan
+catatan keberatan). Setelah customer mengonfirmasi, record tidak bisa diubah lagi.
+*/
+CREATE TABLE IF NOT EXISTS booking_handover (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL REFERENCES booking(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL,                      -- pickup / return
+    notes TEXT,
+    recorded_by_member_id UUID REFERENCES hoster_member(id) ON DELETE SET NULL, -- NULL = diisi owner
+    hoster_confirmed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    customer_confirmed_at TIMESTAMP,
+    customer_note TEXT,                             -- Catatan customer saat konfirmasi (misal: keberatan kondisi)
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_booking_handover_kind CHECK (kind IN ('pickup', 'return')),
+    CONSTRAINT uq_booking_handover_kind UNIQUE (booking_id, kind)
+);
+
+/*
+Detail handover per booking_item.
+checklist: [{"label": "Frame lengkap", "checked": true}, ...]
+photos: ["https://.../handover/....jpg", ...] (bucket hoster, ikut dihitung storage GC)
+*/
+CREATE TABLE IF NOT EXISTS booking_handover_item (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    handover_id UUID NOT NULL REFERENCES booking_handover(id) ON DELETE CASCADE,
+    booking_item_id UUID NOT NULL REFERENCES booking_item(id) ON DELETE CASCADE,
+    condition VARCHAR(20) NOT NULL,
+    notes TEXT,
+    checklist JSONB NOT NULL DEFAULT '[]',
+    photos JSONB NOT NULL DEFAULT '[]',
+    CONSTRAINT chk_booking_handover_item_condition CHECK (condition IN ('good', 'fair', 'damaged')),
+    CONSTRAINT uq_booking_handover_item UNIQUE (handover_id, booking_item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_handover_item_handover
+    ON booking_handover_item(handover_id);
@@ -0,0 +1,7 @@
+/*
+Menambahkan index gabungan hoster_id + created_at + id di tabel booking.
+Dipakai list booking hoster (default sort created_at terbaru) dengan cursor pagination
+berbasis keyset (created_at, id) sehingga halaman berikutnya tidak perlu OFFSET.
+*/
+CREATE INDEX IF NOT EXISTS idx_booking_hoster_created_at_id
+    ON booking(hoster_id, created_at DESC, id DESC);
@@ -0,0 +1,10 @@
+/*
+Kode pickup (QR) per booking.
+Kode = booking_id + pickup_code_nonce yang ditandatangani HMAC (PICKUP_CODE_SECRET), dibagikan ke
+customer saat booking sudah dibayar (on_progress). Saat hoster memindai kode, booking langsung
+menjadi on_rent dan pickup_code_used_at diisi sehingga kode tidak bisa dipakai lagi.
+Nilai default volatile → setiap baris lama juga mendapat nonce acak sendiri.
+*/
+ALTER TABLE booking
+    ADD COLUMN IF NOT EXISTS pickup_code_nonce VARCHAR(32) NOT NULL DEFAULT substr(md5(gen_random_uuid()::text), 1, 16),
+    ADD COLUMN IF NOT EXISTS pickup_code_used_at TIMESTAMP;
@@ -0,0 +1,22 @@
+/*
+Ulasan customer atas booking yang sudah selesai (completed).
+Satu booking maksimal satu ulasan; rating 1 - 5 bintang, komentar opsional.
+hoster_id disalin dari booking agar ringkasan rating storefront tidak perlu join booking.
+*/
+CREATE TABLE IF NOT EXISTS booking_review (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL REFERENCES booking(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES customer(id) ON DELETE CASCADE,
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_booking_review_rating CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT uq_booking_review_booking UNIQUE (booking_id)
+);
+
+/*
+Index untuk ringkasan rating per toko (storefront publik).
+*/
+CREATE INDEX IF NOT EXISTS idx_booking_review_hoster_id
+    ON booking_review(hoster_id);
@@ -0,0 +1,49 @@
+/*
+Paket sewa (bundle) berisi beberapa item dengan harga & deposit paket sendiri.
+Contoh: "Paket Camping 2 Orang" = 1 tenda + 2 sleeping bag + 1 kompor.
+Semua komponen wajib dari store yang sama dengan paket dan tidak bervarian.
+*/
+CREATE TABLE IF NOT EXISTS bundle (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenant(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    price_per_day INTEGER NOT NULL CHECK (price_per_day > 0), -- Harga paket per hari
+    deposit INTEGER NOT NULL DEFAULT 0 CHECK (deposit >= 0),   -- Deposit per paket
+    is_hidden BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bundle_hoster
+    ON bundle(hoster_id, tenant_id);
+
+/*
+Komposisi paket: item dan jumlah unit per satu paket.
+Item yang dihapus hoster ikut keluar dari komposisi paket.
+*/
+CREATE TABLE IF NOT EXISTS bundle_item (
+    bundle_id UUID NOT NULL REFERENCES bundle(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES item(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (bundle_id, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bundle_item_item
+    ON bundle_item(item_id);
+
+/*
+Booking paket disimpan sebagai satu baris booking_item per komponen
+(quantity = jumlah paket × quantity komponen) agar stock setiap komponen ikut ter-reservasi.
+bundle_name & bundle_quantity adalah snapshot paket saat booking dibuat;
+harga & deposit paket dibagi ke baris komponen (jumlah subtotal = harga paket).
+*/
+ALTER TABLE booking_item
+    ADD COLUMN IF NOT EXISTS bundle_id UUID REFERENCES bundle(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS bundle_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS bundle_quantity INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_booking_item_bundle
+    ON booking_item(bundle_id)
+    WHERE bundle_id IS NOT NULL;
@@ -0,0 +1,30 @@
+/*
+Daftar blokir customer per hoster.
+Customer yang diblokir tidak bisa membuat booking untuk item milik hoster tersebut.
+Blokir selalu berdasarkan customer_id; opsional juga berdasarkan dokumen identitas terverifikasi
+(document_type + document_number_hash, blind index NIK / nomor dokumen) sehingga akun baru dengan
+dokumen yang sama ikut tertolak. Catatan (note) hanya terlihat oleh hoster.
+*/
+CREATE TABLE IF NOT EXISTS hoster_customer_block (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE CASCADE,
+    customer_id UUID NOT NULL REFERENCES customer(id) ON DELETE CASCADE,
+    document_type VARCHAR(20),
+    document_number_hash VARCHAR(64),
+    note TEXT,
+    blocked_by_member_id UUID REFERENCES hoster_member(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_hoster_customer_block UNIQUE (hoster_id, customer_id),
+    CONSTRAINT chk_hoster_customer_block_document CHECK ((document_type IS NULL) = (document_number_hash IS NULL))
+);
+
+/*
+Index untuk pengecekan blokir berdasarkan dokumen saat booking dibuat
+dan agregasi jumlah blokir per customer untuk admin.
+*/
+CREATE INDEX IF NOT EXISTS idx_hoster_customer_block_document
+    ON hoster_customer_block(hoster_id, document_type, document_number_hash)
+    WHERE document_number_hash IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_hoster_customer_block_customer_id
+    ON hoster_customer_block(customer_id);
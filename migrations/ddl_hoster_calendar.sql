@@ -0,0 +1,16 @@
+/*
+Menambahkan token feed iCalendar (.ics) per hoster.
+Yang disimpan hanya hash SHA-256 dari token rahasia (token asli hanya ditampilkan sekali saat dibuat).
+Token dicabut dengan mengosongkan kolom, dibuat ulang dengan menimpa hash lama.
+*/
+ALTER TABLE hoster
+    ADD COLUMN IF NOT EXISTS calendar_token_hash VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS calendar_token_created_at TIMESTAMP WITH TIME ZONE;
+
+/*
+Menambahkan unique index pada calendar_token_hash (hanya yang terisi).
+Dipakai endpoint feed publik untuk mencari hoster dari token.
+*/
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hoster_calendar_token_hash
+    ON hoster(calendar_token_hash)
+    WHERE calendar_token_hash IS NOT NULL;
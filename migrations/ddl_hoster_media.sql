@@ -0,0 +1,7 @@
+/*
+Menambahkan kolom banner_photo di tabel hoster untuk banner halaman toko (storefront).
+profile_photo sudah ada sejak awal, keduanya diisi lewat upload multipart di /hoster/profile/photo dan /hoster/profile/banner.
+NULL berarti belum ada foto / banner.
+*/
+ALTER TABLE hoster
+    ADD COLUMN IF NOT EXISTS banner_photo VARCHAR(500);
@@ -0,0 +1,33 @@
+/*
+Membuat tabel hoster_member untuk staff toko yang login terpisah dari akun owner (hoster).
+Role: manager / staff (owner = akun hoster itu sendiri, tidak disimpan di tabel ini).
+Status: invited (belum buat password) → active → disabled.
+Token undangan hanya disimpan dalam bentuk hash SHA-256.
+*/
+CREATE TABLE IF NOT EXISTS hoster_member (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE CASCADE,
+    full_name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('manager', 'staff')),
+    status VARCHAR(20) NOT NULL DEFAULT 'invited' CHECK (status IN ('invited', 'active', 'disabled')),
+    invite_token_hash VARCHAR(64),
+    invite_expires_at TIMESTAMP WITH TIME ZONE,
+    last_login_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+/*
+Menambahkan unique index email (case-insensitive).
+Satu email hanya bisa menjadi staff di satu toko, dan dipakai saat login.
+*/
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hoster_member_email
+    ON hoster_member(LOWER(email));
+
+/*
+Menambahkan index hoster_id untuk daftar staff per toko.
+*/
+CREATE INDEX IF NOT EXISTS idx_hoster_member_hoster_id
+    ON hoster_member(hoster_id);
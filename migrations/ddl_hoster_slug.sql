@@ -0,0 +1,47 @@
+/*
+Menambahkan kolom slug di tabel hoster untuk URL storefront publik yang mudah dibagikan.
+Slug dibuat sekali dari store_name dan tidak berubah, agar link yang sudah dibagikan tetap valid.
+*/
+ALTER TABLE hoster
+    ADD COLUMN IF NOT EXISTS slug VARCHAR(120);
+
+/*
+Mengisi slug hoster lama dari store_name.
+Nama toko yang menghasilkan slug sama diberi sufiks -2, -3, ... sesuai urutan bergabung.
+*/
+WITH base AS (
+    SELECT
+        id,
+        COALESCE(
+            NULLIF(LEFT(TRIM(BOTH '-' FROM regexp_replace(LOWER(store_name), '[^a-z0-9]+', '-', 'g')), 100), ''),
+            'toko'
+        ) AS slug,
+        created_at
+    FROM hoster
+    WHERE slug IS NULL
+),
+numbered AS (
+    SELECT id, slug, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY created_at, id) AS n
+    FROM base
+)
+UPDATE hoster h
+SET slug = CASE WHEN numbered.n = 1 THEN numbered.slug ELSE numbered.slug || '-' || numbered.n END
+FROM numbered
+WHERE h.id = numbered.id;
+
+ALTER TABLE hoster
+    ALTER COLUMN slug SET NOT NULL;
+
+/*
+Menambahkan unique index pada slug hoster.
+Menjamin satu slug hanya menunjuk ke satu toko dan mempercepat lookup storefront.
+*/
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hoster_slug
+    ON hoster(slug);
+
+/*
+Menambahkan index item publik per hoster untuk halaman storefront (item tampil, terbaru dulu).
+*/
+CREATE INDEX IF NOT EXISTS idx_item_hoster_visible_created_at
+    ON item(hoster_id, created_at DESC)
+    WHERE is_hidden = false;
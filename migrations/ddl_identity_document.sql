@@ -0,0 +1,40 @@
+/*
+Menggeneralisasi tabel identity dari KTP saja menjadi beberapa jenis dokumen (KTP, SIM, paspor, KITAS).
+Kolom ktp_url dan kolom NIK diganti nama agar berlaku untuk semua jenis dokumen.
+Data lama otomatis bertipe 'ktp' dengan negara penerbit 'IDN'.
+*/
+ALTER TABLE identity RENAME COLUMN ktp_url TO document_url;
+ALTER TABLE identity RENAME COLUMN nik_encrypted TO document_number_encrypted;
+ALTER TABLE identity RENAME COLUMN nik_hash TO document_number_hash;
+ALTER TABLE identity RENAME COLUMN nik_birth_date TO holder_birth_date;
+ALTER TABLE identity RENAME COLUMN nik_sex TO holder_sex;
+
+ALTER TABLE identity
+    ADD COLUMN document_type VARCHAR(20) NOT NULL DEFAULT 'ktp'
+        CHECK (document_type IN ('ktp', 'sim', 'passport', 'kitas')),
+    ADD COLUMN document_country CHAR(3) NOT NULL DEFAULT 'IDN',
+    ADD COLUMN document_expiry DATE,
+    ADD COLUMN extra_photos JSONB;
+
+/*
+Mengganti index blind index NIK menjadi index per jenis dokumen.
+Mempercepat pengecekan dokumen yang sudah terverifikasi di akun lain.
+*/
+DROP INDEX IF EXISTS idx_identity_nik_hash;
+CREATE INDEX idx_identity_document_number_hash
+    ON identity(document_type, document_number_hash);
+
+/*
+Menambahkan target upload session untuk dokumen identitas umum dan foto tambahan (selfie, sisi belakang).
+identity_ktp tetap diterima sebagai alias lama dari identity_document.
+*/
+ALTER TABLE upload_session DROP CONSTRAINT IF EXISTS upload_session_target_check;
+ALTER TABLE upload_session
+    ADD CONSTRAINT upload_session_target_check
+        CHECK (target IN ('item_photo', 'identity_ktp', 'identity_document', 'identity_extra'));
+
+/*
+Menambahkan kolom photo_kind di upload_session untuk target identity_extra ("selfie" / "back").
+*/
+ALTER TABLE upload_session
+    ADD COLUMN photo_kind VARCHAR(20);
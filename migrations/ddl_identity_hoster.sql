@@ -0,0 +1,30 @@
+/*
+Melepas foreign key identity.user_id → customer(id).
+Tabel identity sekarang dipakai bersama oleh customer dan hoster, pemilik dibedakan lewat kolom user_role.
+*/
+ALTER TABLE identity
+    DROP CONSTRAINT IF EXISTS identity_user_id_fkey;
+
+/*
+Menambahkan kolom user_role dan business_documents ke tabel identity.
+user_role menandai pemilik dokumen (customer/hoster), data lama otomatis dianggap milik customer.
+business_documents berisi dokumen usaha hoster (NIB/NPWP) dalam bentuk JSONB: [{type, number_encrypted, url}].
+*/
+ALTER TABLE identity
+    ADD COLUMN user_role VARCHAR(20) NOT NULL DEFAULT 'customer' CHECK (user_role IN ('customer', 'hoster')),
+    ADD COLUMN business_documents JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+/*
+Menambahkan index gabungan user_role + user_id di tabel identity.
+Mempercepat pengambilan dokumen terakhir per user dan filter antrian review per role.
+*/
+CREATE INDEX idx_identity_user_role_user_id
+    ON identity(user_role, user_id);
+
+/*
+Menambahkan status verifikasi ke tabel hoster.
+is_verified diisi saat admin approve dokumen identitas hoster (badge "toko terverifikasi") dan di-reset saat ditolak.
+*/
+ALTER TABLE hoster
+    ADD COLUMN is_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN verified_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,18 @@
+/*
+Menambahkan kolom NIK ke tabel identity.
+NIK disimpan terenkripsi (AES-256-GCM) dan blind index (HMAC-SHA256) untuk deteksi duplikat tanpa dekripsi.
+Tanggal lahir dan jenis kelamin hasil decode NIK disimpan untuk dicocokkan admin dengan foto KTP.
+*/
+ALTER TABLE identity
+    ADD COLUMN nik_encrypted TEXT,
+    ADD COLUMN nik_hash VARCHAR(64),
+    ADD COLUMN nik_birth_date DATE,
+    ADD COLUMN nik_sex VARCHAR(10),
+    ADD COLUMN duplicate_flag BOOLEAN NOT NULL DEFAULT FALSE;
+
+/*
+Menambahkan index pada kolom nik_hash di tabel identity.
+Mempercepat pengecekan NIK yang sudah terverifikasi di akun lain.
+*/
+CREATE INDEX idx_identity_nik_hash
+    ON identity(nik_hash);
@@ -0,0 +1,73 @@
+/*
+Menambahkan kolom claim (lease) dan reviewer ke tabel identity.
+claimed_by + claimed_until menandai admin yang sedang meninjau dokumen, lease otomatis habis setelah claimed_until.
+reviewed_by + rejection_template_id mencatat admin yang memutuskan dan template alasan penolakan yang dipakai.
+*/
+ALTER TABLE identity
+    ADD COLUMN claimed_by UUID REFERENCES admin(id) ON DELETE SET NULL,
+    ADD COLUMN claimed_until TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reviewed_by UUID REFERENCES admin(id) ON DELETE SET NULL,
+    ADD COLUMN rejection_template_id UUID;
+
+/*
+Membuat tabel identity_rejection_template untuk daftar alasan penolakan yang dikelola admin.
+Template non-aktif tidak bisa dipilih lagi, tapi tetap dirujuk oleh keputusan lama.
+*/
+CREATE TABLE identity_rejection_template (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE identity
+    ADD CONSTRAINT identity_rejection_template_id_fkey
+        FOREIGN KEY (rejection_template_id) REFERENCES identity_rejection_template(id) ON DELETE SET NULL;
+
+/*
+Membuat trigger untuk memperbarui kolom updated_at di tabel identity_rejection_template.
+*/
+CREATE TRIGGER update_identity_rejection_template_updated_at
+    BEFORE UPDATE ON identity_rejection_template
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+/*
+Template awal alasan penolakan yang paling sering dipakai reviewer.
+*/
+INSERT INTO identity_rejection_template (code, title, reason) VALUES
+    ('blurry_photo', 'Foto buram', 'Foto dokumen buram atau terpotong, silakan upload ulang dengan foto yang jelas dan utuh'),
+    ('document_expired', 'Dokumen kadaluarsa', 'Dokumen identitas sudah tidak berlaku, silakan upload dokumen yang masih berlaku'),
+    ('data_mismatch', 'Data tidak cocok', 'Nomor dokumen tidak sesuai dengan foto dokumen yang di-upload'),
+    ('selfie_mismatch', 'Selfie tidak cocok', 'Wajah pada selfie tidak sesuai dengan foto pada dokumen'),
+    ('wrong_document', 'Jenis dokumen salah', 'Foto yang di-upload bukan dokumen identitas yang dipilih');
+
+/*
+Membuat tabel identity_review_log sebagai audit trail setiap aksi reviewer (claim, release, approve, reject).
+*/
+CREATE TABLE identity_review_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    identity_id UUID NOT NULL REFERENCES identity(id) ON DELETE CASCADE,
+    admin_id UUID REFERENCES admin(id) ON DELETE SET NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('claim', 'release', 'approved', 'rejected')),
+    reason TEXT,
+    rejection_template_id UUID REFERENCES identity_rejection_template(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+/*
+Menambahkan index pada kolom identity_id di tabel identity_review_log.
+Mempercepat pengambilan riwayat review per dokumen.
+*/
+CREATE INDEX idx_identity_review_log_identity_id
+    ON identity_review_log(identity_id, created_at);
+
+/*
+Menambahkan index pada kolom claimed_by di tabel identity.
+Mempercepat filter antrian "milik saya" / "tersedia".
+*/
+CREATE INDEX idx_identity_claimed_by
+    ON identity(claimed_by, claimed_until);
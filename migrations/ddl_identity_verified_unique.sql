@@ -0,0 +1,22 @@
+/*
+Menjamin satu nomor dokumen (jenis + blind index) hanya terverifikasi di satu akun per role.
+Pengecekan duplikat saat approve (HasVerifiedDuplicateDocument) tidak cukup jika dua pengajuan
+dengan nomor yang sama di-approve bersamaan, sehingga aturan yang sama ditegakkan database.
+
+Dipakai exclusion constraint (bukan unique index) karena akun yang sama boleh punya lebih dari satu
+dokumen terverifikasi dengan nomor yang sama (dokumen diperbarui setelah kadaluarsa), dan orang yang
+sama boleh punya akun customer dan akun hoster. Yang ditolak hanya user_id berbeda.
+
+Catatan: jika data lama sudah berisi duplikat, constraint gagal dibuat; selesaikan duplikat
+(lihat duplicate_flag) lebih dulu.
+*/
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE identity
+    ADD CONSTRAINT excl_identity_verified_document
+        EXCLUDE USING gist (
+            document_type WITH =,
+            document_number_hash WITH =,
+            user_role WITH =,
+            user_id WITH <>
+        ) WHERE (verified AND document_number_hash IS NOT NULL);
@@ -0,0 +1,76 @@
+/*
+Pelacakan unit fisik (aset) per item.
+Item dengan unit_tracking = true punya baris item_unit untuk setiap barang fisik
+(misal: tenda #1, tenda #2), dan item.stock diturunkan dari jumlah unit aktif
+(status available / rented). Item tanpa unit_tracking tetap memakai stock manual.
+*/
+ALTER TABLE item
+    ADD COLUMN IF NOT EXISTS unit_tracking BOOLEAN NOT NULL DEFAULT false;
+
+/*
+Satu baris per unit fisik.
+status:
+- available   : siap disewakan
+- rented      : sedang dibawa customer (dialokasikan ke booking_item saat pickup)
+- maintenance : sedang diperbaiki / dibersihkan, tidak dihitung sebagai stock
+- retired     : tidak dipakai lagi (rusak total / hilang / dijual)
+*/
+CREATE TABLE IF NOT EXISTS item_unit (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES item(id) ON DELETE CASCADE,
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL,                    -- Nama unit yang mudah dikenali, misal "Tenda #3"
+    serial_number VARCHAR(100),
+    condition VARCHAR(20) NOT NULL DEFAULT 'good',  -- good → fair → damaged
+    status VARCHAR(20) NOT NULL DEFAULT 'available',
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_item_unit_condition CHECK (condition IN ('good', 'fair', 'damaged')),
+    CONSTRAINT chk_item_unit_status CHECK (status IN ('available', 'rented', 'maintenance', 'retired'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_item_unit_label
+    ON item_unit(item_id, LOWER(label));
+
+CREATE INDEX IF NOT EXISTS idx_item_unit_item_status
+    ON item_unit(item_id, status);
+
+/*
+Alokasi unit ke baris booking_item saat barang diserahkan (on_progress → on_rent).
+returned_at diisi saat booking selesai. Satu unit hanya boleh punya satu alokasi terbuka.
+*/
+CREATE TABLE IF NOT EXISTS booking_item_unit (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_item_id UUID NOT NULL REFERENCES booking_item(id) ON DELETE CASCADE,
+    booking_id UUID NOT NULL REFERENCES booking(id) ON DELETE CASCADE,
+    unit_id UUID NOT NULL REFERENCES item_unit(id) ON DELETE CASCADE,
+    allocated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    returned_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_booking_item_unit_open
+    ON booking_item_unit(unit_id)
+    WHERE returned_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_booking_item_unit_booking
+    ON booking_item_unit(booking_id);
+
+/*
+Riwayat per unit: dibuat, perubahan kondisi / status, disewa, dikembalikan, catatan kerusakan.
+booking_id terisi untuk kejadian yang terkait booking.
+*/
+CREATE TABLE IF NOT EXISTS item_unit_event (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    unit_id UUID NOT NULL REFERENCES item_unit(id) ON DELETE CASCADE,
+    booking_id UUID REFERENCES booking(id) ON DELETE SET NULL,
+    kind VARCHAR(20) NOT NULL,
+    condition VARCHAR(20),                          -- Kondisi unit setelah kejadian
+    status VARCHAR(20),                             -- Status unit setelah kejadian
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_item_unit_event_kind CHECK (kind IN ('created', 'updated', 'rented', 'returned', 'damage'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_unit_event_unit
+    ON item_unit_event(unit_id, created_at DESC);
@@ -0,0 +1,46 @@
+/*
+Dimensi varian item (misal: ["Ukuran", "Warna"]).
+Array kosong = item tanpa varian (stock manual / unit tracking).
+Jika terisi, item.stock = jumlah stock varian aktif dan tidak bisa diubah manual.
+*/
+ALTER TABLE item
+    ADD COLUMN IF NOT EXISTS variant_dimensions TEXT[] NOT NULL DEFAULT '{}';
+
+/*
+Satu baris per kombinasi varian (misal: Ukuran 40 / Merah).
+option_values urut sesuai item.variant_dimensions, name = gabungan option_values ("40 / Merah").
+price_per_day & deposit NULL = ikut harga item.
+Varian yang dihapus tetapi pernah dipakai booking hanya dinonaktifkan (is_active = false) agar riwayat tetap utuh.
+*/
+CREATE TABLE IF NOT EXISTS item_variant (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES item(id) ON DELETE CASCADE,
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    option_values TEXT[] NOT NULL,
+    stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    price_per_day INTEGER CHECK (price_per_day > 0),
+    deposit INTEGER CHECK (deposit >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_item_variant_name
+    ON item_variant(item_id, LOWER(name));
+
+CREATE INDEX IF NOT EXISTS idx_item_variant_item_active
+    ON item_variant(item_id, is_active, sort_order);
+
+/*
+Varian yang disewa per baris booking_item (NULL = item tanpa varian).
+variant_name adalah snapshot nama varian saat booking dibuat.
+*/
+ALTER TABLE booking_item
+    ADD COLUMN IF NOT EXISTS variant_id UUID REFERENCES item_variant(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS variant_name VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_booking_item_variant
+    ON booking_item(variant_id)
+    WHERE variant_id IS NOT NULL;
@@ -0,0 +1,57 @@
+/*
+Aturan denda keterlambatan pengembalian.
+Satu aturan default per hoster (item_id NULL) dan opsional satu aturan khusus per item (menimpa default).
+fee_type:
+- fixed   : amount = rupiah per unit per hari terlambat
+- percent : amount = basis point dari harga sewa per hari (price_per_day x quantity) per hari terlambat (1000 = 10%)
+grace_hours: toleransi setelah batas pengembalian sebelum denda mulai dihitung.
+*/
+CREATE TABLE IF NOT EXISTS late_fee_rule (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE CASCADE,
+    item_id UUID REFERENCES item(id) ON DELETE CASCADE,
+    fee_type VARCHAR(10) NOT NULL,
+    amount INTEGER NOT NULL,
+    grace_hours INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_late_fee_rule_type CHECK (fee_type IN ('fixed', 'percent')),
+    CONSTRAINT chk_late_fee_rule_amount CHECK (amount > 0),
+    CONSTRAINT chk_late_fee_rule_grace CHECK (grace_hours BETWEEN 0 AND 168)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_late_fee_rule_hoster_default
+    ON late_fee_rule(hoster_id) WHERE item_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_late_fee_rule_item
+    ON late_fee_rule(item_id) WHERE item_id IS NOT NULL;
+
+/*
+Status keterlambatan booking.
+Batas pengembalian = end_date + return_time (slot pengembalian), tanpa slot → akhir hari end_date.
+overdue_at diisi detektor saat booking on_rent melewati batas pengembalian, late_fee adalah total
+denda yang sudah ditambahkan ke outstanding (dihitung ulang setiap detektor berjalan, tidak pernah turun).
+*/
+ALTER TABLE booking
+    ADD COLUMN IF NOT EXISTS late_fee INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS overdue_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_booking_on_rent_end_date
+    ON booking(end_date) WHERE status = 'on_rent';
+
+/*
+Ledger: denda keterlambatan diakui saat booking completed sebagai transaksi late_fee
+(kas platform → komisi + hutang ke hoster), unik per booking lewat uq_ledger_transaction_booking_kind.
+late_fee ikut di-settle bersama rental_income.
+*/
+ALTER TABLE ledger_transaction
+    DROP CONSTRAINT IF EXISTS chk_ledger_transaction_kind;
+
+ALTER TABLE ledger_transaction
+    ADD CONSTRAINT chk_ledger_transaction_kind
+        CHECK (kind IN ('booking_payment', 'booking_amendment', 'rental_income', 'deposit_refund', 'late_fee', 'payout'));
+
+DROP INDEX IF EXISTS idx_ledger_transaction_unsettled;
+
+CREATE INDEX IF NOT EXISTS idx_ledger_transaction_unsettled
+    ON ledger_transaction(occurred_at)
+    WHERE kind IN ('rental_income', 'late_fee') AND settlement_id IS NULL;
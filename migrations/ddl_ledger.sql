@@ -0,0 +1,93 @@
+/*
+Ledger double-entry uang yang dikumpulkan platform atas nama hoster.
+Setiap kejadian keuangan (pembayaran booking, pengakuan pendapatan sewa + komisi,
+pengembalian deposit, payout) dicatat sebagai satu ledger_transaction dengan
+minimal dua ledger_entry yang total debit = total kredit.
+
+Akun yang dipakai (kolom account):
+- platform_cash       : kas platform (uang customer yang sudah diterima)
+- unearned_rental     : sewa yang sudah dibayar tapi booking belum selesai
+- customer_deposit    : deposit customer yang sedang ditahan
+- hoster_payable      : hutang platform ke hoster (saldo yang akan di-payout)
+- platform_commission : pendapatan komisi platform
+*/
+CREATE TABLE IF NOT EXISTS settlement_batch (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    period_end DATE NOT NULL,                       -- Transaksi s.d. akhir tanggal ini ikut di-settle
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending → paid
+    created_by UUID NOT NULL REFERENCES admin(id),
+    paid_by UUID REFERENCES admin(id),
+    paid_at TIMESTAMP,
+    reference VARCHAR(255),                         -- Nomor referensi transfer bank
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_settlement_batch_status CHECK (status IN ('pending', 'paid'))
+);
+
+/*
+Satu baris settlement per hoster di dalam batch.
+amount = total kredit bersih hoster_payable dari transaksi yang di-settle.
+*/
+CREATE TABLE IF NOT EXISTS settlement (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    batch_id UUID NOT NULL REFERENCES settlement_batch(id) ON DELETE RESTRICT,
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE RESTRICT,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    transaction_count INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    paid_at TIMESTAMP,
+    reference VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_settlement_status CHECK (status IN ('pending', 'paid')),
+    CONSTRAINT uq_settlement_batch_hoster UNIQUE (batch_id, hoster_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_settlement_hoster_created_at
+    ON settlement(hoster_id, created_at DESC);
+
+/*
+Header transaksi ledger.
+- booking_id + kind unik → posting dari booking idempotent (aman diulang lewat sync)
+- commission_rate_bps disimpan agar nilai komisi bisa dihitung ulang persis sama
+- settlement_id: untuk rental_income = batch settlement yang mencakupnya, untuk payout = settlement yang dibayar
+*/
+CREATE TABLE IF NOT EXISTS ledger_transaction (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE RESTRICT,
+    booking_id UUID REFERENCES booking(id) ON DELETE RESTRICT,
+    settlement_id UUID REFERENCES settlement(id) ON DELETE RESTRICT,
+    kind VARCHAR(30) NOT NULL,
+    commission_rate_bps INTEGER NOT NULL DEFAULT 0,
+    description TEXT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_ledger_transaction_kind CHECK (kind IN ('booking_payment', 'rental_income', 'deposit_refund', 'payout')),
+    CONSTRAINT uq_ledger_transaction_booking_kind UNIQUE (booking_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_transaction_hoster_occurred_at
+    ON ledger_transaction(hoster_id, occurred_at);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_transaction_unsettled
+    ON ledger_transaction(occurred_at)
+    WHERE kind = 'rental_income' AND settlement_id IS NULL;
+
+/*
+Baris debit / kredit. Tepat satu sisi yang terisi per baris.
+hoster_id diduplikasi dari header agar saldo per akun hoster cukup satu scan index.
+*/
+CREATE TABLE IF NOT EXISTS ledger_entry (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES ledger_transaction(id) ON DELETE CASCADE,
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE RESTRICT,
+    account VARCHAR(30) NOT NULL,
+    debit BIGINT NOT NULL DEFAULT 0,
+    credit BIGINT NOT NULL DEFAULT 0,
+    CONSTRAINT chk_ledger_entry_account CHECK (account IN ('platform_cash', 'unearned_rental', 'customer_deposit', 'hoster_payable', 'platform_commission')),
+    CONSTRAINT chk_ledger_entry_side CHECK (debit >= 0 AND credit >= 0 AND (debit = 0) <> (credit = 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entry_hoster_account
+    ON ledger_entry(hoster_id, account);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entry_transaction_id
+    ON ledger_entry(transaction_id);
@@ -0,0 +1,51 @@
+/*
+Menambahkan pengaturan slot waktu serah terima di tabel tenant (store).
+slot_minutes = panjang satu slot pickup / pengembalian, slot_capacity = jumlah serah terima maksimal per slot.
+*/
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS slot_minutes INTEGER NOT NULL DEFAULT 60 CHECK (slot_minutes IN (15, 30, 60, 120)),
+    ADD COLUMN IF NOT EXISTS slot_capacity INTEGER NOT NULL DEFAULT 5 CHECK (slot_capacity > 0);
+
+/*
+Membuat tabel store_operating_hours untuk jam buka mingguan store.
+weekday mengikuti Go time.Weekday (0 = Minggu ... 6 = Sabtu), satu rentang jam per hari.
+Hari yang tidak punya baris dianggap tutup. Store tanpa baris sama sekali belum mengatur jam buka
+(booking tanpa slot tetap diterima agar data lama tetap berjalan).
+*/
+CREATE TABLE IF NOT EXISTS store_operating_hours (
+    tenant_id UUID NOT NULL REFERENCES tenant(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    open_time TIME NOT NULL,
+    close_time TIME NOT NULL,
+    PRIMARY KEY (tenant_id, weekday),
+    CHECK (close_time > open_time)
+);
+
+/*
+Membuat tabel store_closure untuk hari libur / tutup khusus (menimpa jam buka mingguan).
+*/
+CREATE TABLE IF NOT EXISTS store_closure (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenant(id) ON DELETE CASCADE,
+    closure_date DATE NOT NULL,
+    reason VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (tenant_id, closure_date)
+);
+
+/*
+Menambahkan jam pickup & pengembalian di booking (jam lokal store, tanggal tetap start_date / end_date).
+NULL = booking lama atau store belum mengatur jam buka.
+*/
+ALTER TABLE booking
+    ADD COLUMN IF NOT EXISTS pickup_time TIME,
+    ADD COLUMN IF NOT EXISTS return_time TIME;
+
+/*
+Menambahkan index untuk menghitung kapasitas slot dan jadwal harian hoster.
+*/
+CREATE INDEX IF NOT EXISTS idx_booking_tenant_start_pickup
+    ON booking(tenant_id, start_date, pickup_time);
+
+CREATE INDEX IF NOT EXISTS idx_booking_tenant_end_return
+    ON booking(tenant_id, end_date, return_time);
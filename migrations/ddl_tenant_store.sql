@@ -0,0 +1,106 @@
+/*
+Mengubah tabel tenant menjadi cabang toko (store) milik hoster.
+Satu hoster bisa punya banyak store (one-to-many), masing-masing dengan alamat,
+kontak, dan pengaturan delivery sendiri. Tepat satu store per hoster ditandai default.
+*/
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS address TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS city VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS phone_number VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS delivery_enabled BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN IF NOT EXISTS delivery_fee INTEGER NOT NULL DEFAULT 0 CHECK (delivery_fee >= 0),
+    ADD COLUMN IF NOT EXISTS delivery_radius_km INTEGER CHECK (delivery_radius_km IS NULL OR delivery_radius_km > 0),
+    ADD COLUMN IF NOT EXISTS is_default BOOLEAN NOT NULL DEFAULT false;
+
+/*
+Menambahkan unique index agar setiap hoster hanya punya satu store default
+dan nama store tidak kembar dalam satu hoster.
+*/
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenant_hoster_default
+    ON tenant(hoster_id)
+    WHERE is_default;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenant_hoster_name
+    ON tenant(hoster_id, LOWER(name));
+
+/*
+Membuat store default untuk hoster yang belum punya store.
+Nama & alamat diambil dari profil hoster agar data lama tetap tampil seperti sebelumnya.
+*/
+INSERT INTO tenant (name, hoster_id, address, phone_number, is_default)
+SELECT h.store_name, h.id, h.address, h.phone_number, true
+FROM hoster h
+WHERE NOT EXISTS (SELECT 1 FROM tenant t WHERE t.hoster_id = h.id);
+
+UPDATE tenant t
+SET is_default = true
+WHERE t.id = (
+    SELECT t2.id FROM tenant t2
+    WHERE t2.hoster_id = t.hoster_id
+    ORDER BY t2.created_at, t2.id
+    LIMIT 1
+)
+AND NOT EXISTS (SELECT 1 FROM tenant t3 WHERE t3.hoster_id = t.hoster_id AND t3.is_default);
+
+/*
+Menambahkan tenant_id di tabel item. Item lama dipindahkan ke store default hoster-nya.
+Store tidak bisa dihapus selama masih punya item (ON DELETE RESTRICT).
+*/
+ALTER TABLE item
+    ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenant(id) ON DELETE RESTRICT;
+
+UPDATE item i
+SET tenant_id = t.id
+FROM tenant t
+WHERE i.tenant_id IS NULL AND t.hoster_id = i.hoster_id AND t.is_default;
+
+ALTER TABLE item
+    ALTER COLUMN tenant_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_item_tenant_id
+    ON item(tenant_id);
+
+/*
+Menambahkan tenant_id di tabel booking (store tempat barang diambil / dikirim).
+Booking lama diisi dari store item pertamanya. Jika store dihapus, riwayat booking tetap ada
+(tenant_id menjadi NULL, masih terikat ke hoster_id).
+*/
+ALTER TABLE booking
+    ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenant(id) ON DELETE SET NULL;
+
+UPDATE booking b
+SET tenant_id = (
+    SELECT i.tenant_id
+    FROM booking_item bi
+    JOIN item i ON i.id = bi.item_id
+    WHERE bi.booking_id = b.id
+    LIMIT 1
+)
+WHERE b.tenant_id IS NULL;
+
+UPDATE booking b
+SET tenant_id = t.id
+FROM tenant t
+WHERE b.tenant_id IS NULL AND t.hoster_id = b.hoster_id AND t.is_default;
+
+CREATE INDEX IF NOT EXISTS idx_booking_hoster_tenant_created_at
+    ON booking(hoster_id, tenant_id, created_at DESC);
+
+/*
+Menambahkan tenant_id di tabel tnc.
+tenant_id NULL = T&C umum hoster (berlaku untuk semua store yang tidak punya T&C sendiri).
+*/
+ALTER TABLE tnc
+    ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenant(id) ON DELETE CASCADE;
+
+ALTER TABLE tnc
+    DROP CONSTRAINT IF EXISTS tnc_user_id_key,
+    DROP CONSTRAINT IF EXISTS tnc_hoster_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tnc_hoster_general
+    ON tnc(hoster_id)
+    WHERE tenant_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tnc_tenant_id
+    ON tnc(tenant_id)
+    WHERE tenant_id IS NOT NULL;
@@ -0,0 +1,80 @@
+/*
+Versi T&C yang tidak bisa diubah (immutable) dan T&C khusus item.
+tnc tetap menjadi "scope" T&C (umum hoster, khusus store, atau khusus item) dengan description
+berisi salinan versi terbaru; setiap perubahan menambah baris tnc_version baru.
+tnc_version menyimpan snapshot scope (tenant_id / item_id tanpa FK) agar tetap ada
+walaupun store / item dihapus, sehingga booking lama selalu bisa merujuk versi yang disetujui.
+*/
+ALTER TABLE tnc
+    ADD COLUMN IF NOT EXISTS item_id UUID REFERENCES item(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS current_version_id UUID;
+
+DROP INDEX IF EXISTS idx_tnc_hoster_general;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tnc_hoster_general
+    ON tnc(hoster_id)
+    WHERE tenant_id IS NULL AND item_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tnc_item_id
+    ON tnc(item_id)
+    WHERE item_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS tnc_version (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tnc_id UUID REFERENCES tnc(id) ON DELETE SET NULL,
+    hoster_id UUID NOT NULL REFERENCES hoster(id) ON DELETE CASCADE,
+    tenant_id UUID,
+    item_id UUID,
+    version INTEGER NOT NULL,
+    description JSONB NOT NULL,
+    created_by_member_id UUID REFERENCES hoster_member(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_tnc_version UNIQUE (tnc_id, version),
+    CONSTRAINT chk_tnc_version_positive CHECK (version > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tnc_version_hoster_id
+    ON tnc_version(hoster_id);
+
+/*
+Versi 1 untuk T&C yang sudah ada, lalu tandai sebagai versi terbaru.
+*/
+INSERT INTO tnc_version (tnc_id, hoster_id, tenant_id, version, description, created_at)
+SELECT id, hoster_id, tenant_id, 1, description, COALESCE(updated_at, created_at, NOW())
+FROM tnc
+WHERE NOT EXISTS (SELECT 1 FROM tnc_version v WHERE v.tnc_id = tnc.id);
+
+UPDATE tnc
+SET current_version_id = v.id
+FROM tnc_version v
+WHERE v.tnc_id = tnc.id AND v.version = 1 AND tnc.current_version_id IS NULL;
+
+ALTER TABLE tnc
+    ADD CONSTRAINT fk_tnc_current_version FOREIGN KEY (current_version_id) REFERENCES tnc_version(id);
+
+/*
+Menolak perubahan isi versi T&C. Perubahan T&C selalu berupa versi baru.
+*/
+CREATE OR REPLACE FUNCTION prevent_tnc_version_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'tnc_version is immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER prevent_tnc_version_update
+BEFORE UPDATE OF description, version, hoster_id, tenant_id, item_id ON tnc_version
+FOR EACH ROW
+EXECUTE FUNCTION prevent_tnc_version_update();
+
+/*
+Versi T&C yang disetujui customer saat membuat booking (bukti saat sengketa).
+*/
+CREATE TABLE IF NOT EXISTS booking_tnc_acceptance (
+    booking_id UUID NOT NULL REFERENCES booking(id) ON DELETE CASCADE,
+    tnc_version_id UUID NOT NULL REFERENCES tnc_version(id),
+    accepted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (booking_id, tnc_version_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_tnc_acceptance_version
+    ON booking_tnc_acceptance(tnc_version_id);
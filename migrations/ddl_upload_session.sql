@@ -0,0 +1,54 @@
+/*
+Membuat tabel upload_session untuk upload langsung ke bucket via presigned PUT URL.
+Menyimpan path dan content type yang diterbitkan server agar finalize hanya menerima objek yang sesuai.
+*/
+CREATE TABLE upload_session (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    target VARCHAR(50) NOT NULL CHECK (target IN ('item_photo', 'identity_ktp')),
+    target_id UUID,
+    bucket VARCHAR(100) NOT NULL,
+    path VARCHAR NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'finalized', 'failed')),
+    reason TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finalized_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Menambahkan index pada kolom user_id di tabel upload_session.
+Mempercepat query sesi milik user yang sedang login.
+*/
+CREATE INDEX idx_upload_session_user_id
+    ON upload_session(user_id);
+
+/*
+Menambahkan index pada kolom status dan expires_at di tabel upload_session.
+Mempercepat pencarian sesi pending yang sudah kadaluarsa.
+*/
+CREATE INDEX idx_upload_session_status_expires_at
+    ON upload_session(status, expires_at);
+
+/*
+Membuat fungsi untuk memperbarui kolom updated_at secara otomatis.
+Digunakan oleh trigger untuk menjaga timestamp pembaruan di tabel upload_session.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan pada tabel upload_session.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_upload_session_updated_at
+BEFORE UPDATE ON upload_session
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();